@@ -385,3 +385,44 @@ func stopState(state *state.State) error {
 		"encrypted_password",
 	)
 }
+
+// Disable2FA disables two-factor authentication for
+// a user, for example if they've lost their device.
+var Disable2FA action.GTSAction = func(ctx context.Context) error {
+	state, err := initState(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := stopState(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	username := config.GetAdminAccountUsername()
+	if err := validate.Username(username); err != nil {
+		return err
+	}
+
+	account, err := state.DB.GetAccountByUsernameDomain(ctx, username, "")
+	if err != nil {
+		return err
+	}
+
+	user, err := state.DB.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	user.TwoFactorSecret = ""
+	user.TwoFactorBackups = nil
+	user.TwoFactorEnabledAt = time.Time{}
+	return state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_secret",
+		"two_factor_backups",
+		"two_factor_enabled_at",
+	)
+}
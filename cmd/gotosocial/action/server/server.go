@@ -65,6 +65,7 @@
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/web"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // Start creates and starts a gotosocial server
@@ -242,6 +243,9 @@
 		}
 	}
 
+	// Create a Web Push notification sender.
+	webPushSender := webpush.NewRealSender(client, state)
+
 	// Initialize both home / list timelines.
 	state.Timelines.Home = timeline.NewManager(
 		tlprocessor.HomeTimelineGrab(state),
@@ -298,6 +302,7 @@ func(context.Context, time.Time) {
 		mediaManager,
 		state,
 		emailSender,
+		webPushSender,
 		visFilter,
 		intFilter,
 	)
@@ -309,6 +314,18 @@ func(context.Context, time.Time) {
 	state.Workers.Client.Process = process.Workers().ProcessFromClientAPI
 	state.Workers.Federator.Process = process.Workers().ProcessFromFediAPI
 
+	// Mark any archives left building when
+	// we were last stopped as failed, before
+	// workers start building any new ones.
+	if err := process.Account().ArchivesFailInterrupted(ctx); err != nil {
+		return fmt.Errorf("error failing interrupted archives: %w", err)
+	}
+
+	// Same goes for imports left processing.
+	if err := process.Account().ImportsFailInterrupted(ctx); err != nil {
+		return fmt.Errorf("error failing interrupted imports: %w", err)
+	}
+
 	// Now start workers!
 	state.Workers.Start()
 
@@ -317,6 +334,26 @@ func(context.Context, time.Time) {
 		return fmt.Errorf("error scheduling poll expiries: %w", err)
 	}
 
+	// Schedule publication of all pending scheduled statuses.
+	if err := process.Status().ScheduledStatusesScheduleAll(ctx); err != nil {
+		return fmt.Errorf("error scheduling statuses: %w", err)
+	}
+
+	// Schedule processing of domain permission subscriptions.
+	if err := process.Admin().ScheduleDomainPermissionSubscriptions(); err != nil {
+		return fmt.Errorf("error scheduling domain permission subscriptions: %w", err)
+	}
+
+	// Schedule streaming of announcements at their start / end.
+	if err := process.Admin().ScheduleAnnouncements(ctx); err != nil {
+		return fmt.Errorf("error scheduling announcements: %w", err)
+	}
+
+	// Schedule background calculation of trends.
+	if err := process.Trends().ScheduleCalculate(); err != nil {
+		return fmt.Errorf("error scheduling trends calculation: %w", err)
+	}
+
 	// Initialize metrics.
 	if err := metrics.Initialize(state.DB); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
@@ -412,26 +449,31 @@ func(context.Context, time.Time) {
 		return fmt.Errorf("error generating session name for session middleware: %w", err)
 	}
 
-	var (
-		authModule        = api.NewAuth(dbService, process, idp, routerSession, sessionName) // auth/oauth paths
-		clientModule      = api.NewClient(state, process)                                    // api client endpoints
-		metricsModule     = api.NewMetrics()                                                 // Metrics endpoints
-		healthModule      = api.NewHealth(dbService.Ready)                                   // Health check endpoints
-		fileserverModule  = api.NewFileserver(process)                                       // fileserver endpoints
-		wellKnownModule   = api.NewWellKnown(process)                                        // .well-known endpoints
-		nodeInfoModule    = api.NewNodeInfo(process)                                         // nodeinfo endpoint
-		activityPubModule = api.NewActivityPub(dbService, process)                           // ActivityPub endpoints
-		webModule         = web.New(dbService, process)                                      // web pages + user profiles + settings panels etc
-	)
-
 	// create required middleware
-	// rate limiting
+	// rate limiting, with each grouping
+	// counted in its own bucket of the store
+	rlStore := middleware.NewRateLimitStore(config.GetAdvancedRateLimitStore(), dbService)
 	rlLimit := config.GetAdvancedRateLimitRequests()
 	rlExceptions := config.GetAdvancedRateLimitExceptions()
-	clLimit := middleware.RateLimit(rlLimit, rlExceptions)        // client api
-	s2sLimit := middleware.RateLimit(rlLimit, rlExceptions)       // server-to-server (AP)
-	fsMainLimit := middleware.RateLimit(rlLimit, rlExceptions)    // fileserver / web templates
-	fsEmojiLimit := middleware.RateLimit(rlLimit*2, rlExceptions) // fileserver (emojis only, use high limit)
+	clLimit := middleware.RateLimit(rlStore, "client", rlLimit, rlExceptions)                             // client api
+	clIPLimit := middleware.RateLimitIP(rlStore, "client-ip", rlLimit*4, rlExceptions)                    // client api (per IP before token checks, use high limit)
+	s2sLimit := middleware.RateLimit(rlStore, "s2s", rlLimit, rlExceptions)                               // server-to-server (AP)
+	fsMainLimit := middleware.RateLimit(rlStore, "fileserver", rlLimit, rlExceptions)                     // fileserver / web templates
+	fsEmojiLimit := middleware.RateLimit(rlStore, "emoji", rlLimit*2, rlExceptions)                       // fileserver (emojis only, use high limit)
+	signInLimit := middleware.RateLimitSignIn(rlStore, config.GetAdvancedRateLimitSignIn(), rlExceptions) // sign-in attempts
+	inboxLimit := middleware.RateLimitInbox(rlStore, config.GetAdvancedRateLimitInbox())                  // inbox deliveries per remote domain
+
+	var (
+		authModule        = api.NewAuth(dbService, process, idp, routerSession, sessionName, signInLimit) // auth/oauth paths
+		clientModule      = api.NewClient(state, process, clIPLimit)                                      // api client endpoints
+		metricsModule     = api.NewMetrics()                                                              // Metrics endpoints
+		healthModule      = api.NewHealth(dbService.Ready)                                                // Health check endpoints
+		fileserverModule  = api.NewFileserver(process)                                                    // fileserver endpoints
+		wellKnownModule   = api.NewWellKnown(process)                                                     // .well-known endpoints
+		nodeInfoModule    = api.NewNodeInfo(process)                                                      // nodeinfo endpoint
+		activityPubModule = api.NewActivityPub(dbService, process, inboxLimit)                            // ActivityPub endpoints
+		webModule         = web.New(dbService, process)                                                   // web pages + user profiles + settings panels etc
+	)
 
 	// throttling
 	cpuMultiplier := config.GetAdvancedThrottlingMultiplier()
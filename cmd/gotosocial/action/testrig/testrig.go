@@ -286,16 +286,22 @@
 		return fmt.Errorf("error generating session name for session middleware: %w", err)
 	}
 
+	// rate limiting for sign-ins and inbox deliveries,
+	// other rate limiting isn't used by the testrig
+	rlStore := middleware.NewRateLimitStore(config.GetAdvancedRateLimitStore(), state.DB)
+	signInLimit := middleware.RateLimitSignIn(rlStore, config.GetAdvancedRateLimitSignIn(), config.GetAdvancedRateLimitExceptions())
+	inboxLimit := middleware.RateLimitInbox(rlStore, config.GetAdvancedRateLimitInbox())
+
 	var (
-		authModule        = api.NewAuth(state.DB, processor, idp, routerSession, sessionName) // auth/oauth paths
-		clientModule      = api.NewClient(state, processor)                                   // api client endpoints
-		metricsModule     = api.NewMetrics()                                                  // Metrics endpoints
-		healthModule      = api.NewHealth(state.DB.Ready)                                     // Health check endpoints
-		fileserverModule  = api.NewFileserver(processor)                                      // fileserver endpoints
-		wellKnownModule   = api.NewWellKnown(processor)                                       // .well-known endpoints
-		nodeInfoModule    = api.NewNodeInfo(processor)                                        // nodeinfo endpoint
-		activityPubModule = api.NewActivityPub(state.DB, processor)                           // ActivityPub endpoints
-		webModule         = web.New(state.DB, processor)                                      // web pages + user profiles + settings panels etc
+		authModule        = api.NewAuth(state.DB, processor, idp, routerSession, sessionName, signInLimit) // auth/oauth paths
+		clientModule      = api.NewClient(state, processor, func(*gin.Context) {})                         // api client endpoints
+		metricsModule     = api.NewMetrics()                                                               // Metrics endpoints
+		healthModule      = api.NewHealth(state.DB.Ready)                                                  // Health check endpoints
+		fileserverModule  = api.NewFileserver(processor)                                                   // fileserver endpoints
+		wellKnownModule   = api.NewWellKnown(processor)                                                    // .well-known endpoints
+		nodeInfoModule    = api.NewNodeInfo(processor)                                                     // nodeinfo endpoint
+		activityPubModule = api.NewActivityPub(state.DB, processor, inboxLimit)                            // ActivityPub endpoints
+		webModule         = web.New(state.DB, processor)                                                   // web pages + user profiles + settings panels etc
 	)
 
 	// these should be routed in order
@@ -81,6 +81,11 @@
 	// Not in the AS spec, just used internally to indicate
 	// that we don't *yet* know what type of Object something is.
 	ObjectUnknown = "Unknown"
+
+	// Not in the AS spec, just used internally to indicate
+	// the quote of one status by another (eg., when handling
+	// the approval or rejection of a pending quote).
+	ObjectQuote = "Quote"
 )
 
 // isActivity returns whether AS type name is of an Activity (NOT IntransitiveActivity).
@@ -25,6 +25,7 @@
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
@@ -1081,6 +1082,7 @@ func ExtractInteractionPolicy(
 		CanLike:     extractCanLike(policy.GetGoToSocialCanLike(), owner),
 		CanReply:    extractCanReply(policy.GetGoToSocialCanReply(), owner),
 		CanAnnounce: extractCanAnnounce(policy.GetGoToSocialCanAnnounce(), owner),
+		CanQuote:    extractCanQuote(policy.GetUnknownProperties()["canQuote"], owner),
 	}
 }
 
@@ -1156,11 +1158,60 @@ func extractCanAnnounce(
 	}
 }
 
+// extractCanQuote extracts quote policy rules from the raw
+// value of the canQuote property, which go-fed doesn't know
+// about, and so leaves as an unknown property of the policy.
+func extractCanQuote(
+	v any,
+	owner *gtsmodel.Account,
+) gtsmodel.PolicyRules {
+	withRules, ok := v.(map[string]any)
+	if !ok {
+		return gtsmodel.PolicyRules{}
+	}
+
+	return gtsmodel.PolicyRules{
+		Always:       policyValuesFromIRIs(rawIRIs(withRules["always"]), owner),
+		WithApproval: policyValuesFromIRIs(rawIRIs(withRules["approvalRequired"]), owner),
+	}
+}
+
+// rawIRIs parses IRIs from a raw JSON value
+// that may be either a string, or an array.
+func rawIRIs(v any) []*url.URL {
+	var strs []string
+	switch v := v.(type) {
+	case string:
+		strs = []string{v}
+	case []any:
+		for _, v := range v {
+			if str, ok := v.(string); ok {
+				strs = append(strs, str)
+			}
+		}
+	}
+
+	iris := make([]*url.URL, 0, len(strs))
+	for _, str := range strs {
+		if iri, err := url.Parse(str); err == nil {
+			iris = append(iris, iri)
+		}
+	}
+
+	return iris
+}
+
 func extractPolicyValues[T WithIRI](
 	prop Property[T],
 	owner *gtsmodel.Account,
 ) gtsmodel.PolicyValues {
-	iris := getIRIs(prop)
+	return policyValuesFromIRIs(getIRIs(prop), owner)
+}
+
+func policyValuesFromIRIs(
+	iris []*url.URL,
+	owner *gtsmodel.Account,
+) gtsmodel.PolicyValues {
 	PolicyValues := make(gtsmodel.PolicyValues, 0, len(iris))
 
 	for _, iri := range iris {
@@ -1183,6 +1234,124 @@ func extractPolicyValues[T WithIRI](
 	return PolicyValues
 }
 
+// quoteProps are the non-standard properties used by
+// various implementations to point to the quoted
+// object of a quote post, in order of preference.
+var quoteProps = []string{
+	"quote",          // FEP-044f, Mastodon.
+	"quoteUrl",       // Pleroma, Akkoma.
+	"quoteUri",       // Fedibird.
+	"_misskey_quote", // Misskey and forks.
+}
+
+// quoteLinkMediaTypes are the mediaTypes with which
+// an FEP-e232 object link tag may point to an object.
+var quoteLinkMediaTypes = []string{
+	`application/ld+json; profile="https://www.w3.org/ns/activitystreams"`,
+	"application/activity+json",
+}
+
+// QuoteLinkRel is the rel value used on an FEP-e232
+// object link tag to mark the link as a quote.
+const QuoteLinkRel = "https://misskey-hub.net/ns#_misskey_quote"
+
+// ExtractQuoteURI extracts the URI of the status quoted
+// by the given Statusable, if any, checking the various
+// non-standard quote properties first, and then falling
+// back to FEP-e232 object link tags.
+//
+// Will be nil if the Statusable does not quote anything.
+func ExtractQuoteURI(statusable Statusable) *url.URL {
+	if withUnknown, ok := statusable.(WithUnknownProperties); ok {
+		unknown := withUnknown.GetUnknownProperties()
+		for _, prop := range quoteProps {
+			if uri := extractQuotePropURI(unknown[prop]); uri != nil {
+				return uri
+			}
+		}
+	}
+
+	tagsProp := statusable.GetActivityStreamsTag()
+	if tagsProp == nil {
+		return nil
+	}
+
+	for iter := tagsProp.Begin(); iter != tagsProp.End(); iter = iter.Next() {
+		if !iter.IsActivityStreamsLink() {
+			continue
+		}
+
+		link := iter.GetActivityStreamsLink()
+		if link == nil || !isQuoteLink(link) {
+			continue
+		}
+
+		hrefProp := link.GetActivityStreamsHref()
+		if hrefProp == nil || !hrefProp.IsIRI() {
+			continue
+		}
+
+		if href := hrefProp.GetIRI(); isHTTP(href) {
+			return href
+		}
+	}
+
+	return nil
+}
+
+// extractQuotePropURI extracts a URI from the raw value of
+// a quote property, which may be either a plain string,
+// or an object with an id.
+func extractQuotePropURI(v any) *url.URL {
+	var uriStr string
+	switch v := v.(type) {
+	case string:
+		uriStr = v
+	case map[string]any:
+		uriStr, _ = v["id"].(string)
+	}
+
+	if uriStr == "" {
+		return nil
+	}
+
+	uri, err := url.Parse(uriStr)
+	if err != nil || !isHTTP(uri) {
+		return nil
+	}
+
+	return uri
+}
+
+// isQuoteLink returns true if the given Link is
+// an FEP-e232 object link, that either has the
+// quote rel set, or points to an AS object.
+func isQuoteLink(link vocab.ActivityStreamsLink) bool {
+	if relProp := link.GetActivityStreamsRel(); relProp != nil {
+		for iter := relProp.Begin(); iter != relProp.End(); iter = iter.Next() {
+			switch {
+			case iter.IsRFCRfc5988() && iter.Get() == QuoteLinkRel:
+				return true
+			case iter.IsIRI() && iter.GetIRI().String() == QuoteLinkRel:
+				return true
+			}
+		}
+	}
+
+	mediaTypeProp := link.GetActivityStreamsMediaType()
+	if mediaTypeProp == nil || !mediaTypeProp.IsRFCRfc2045() {
+		return false
+	}
+
+	return slices.Contains(quoteLinkMediaTypes, mediaTypeProp.Get())
+}
+
+// isHTTP returns true if the given URI
+// is non-nil with an http(s) scheme.
+func isHTTP(uri *url.URL) bool {
+	return uri != nil && (uri.Scheme == "http" || uri.Scheme == "https")
+}
+
 // ExtractSensitive extracts whether or not an item should
 // be marked as sensitive according to its ActivityStreams
 // sensitive property.
@@ -68,6 +68,15 @@ func (suite *ExtractPolicyTestSuite) TestExtractPolicy() {
       "approvalRequired": [
         "https://www.w3.org/ns/activitystreams#Public"
       ]
+    },
+    "canQuote": {
+      "always": [
+        "http://localhost:8080/users/the_mighty_zork",
+        "http://localhost:8080/users/the_mighty_zork/followers"
+      ],
+      "approvalRequired": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ]
     }
   },
   "tag": [
@@ -128,6 +137,15 @@ func (suite *ExtractPolicyTestSuite) TestExtractPolicy() {
 				gtsmodel.PolicyValuePublic,
 			},
 		},
+		CanQuote: gtsmodel.PolicyRules{
+			Always: gtsmodel.PolicyValues{
+				gtsmodel.PolicyValueAuthor,
+				gtsmodel.PolicyValueFollowers,
+			},
+			WithApproval: gtsmodel.PolicyValues{
+				gtsmodel.PolicyValuePublic,
+			},
+		},
 	}
 	suite.EqualValues(expectedPolicy, policy)
 }
@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+type ExtractQuoteTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractQuoteTestSuite) extractQuoteURI(rawNote string) string {
+	statusable, err := ap.ResolveStatusable(
+		context.Background(),
+		io.NopCloser(
+			bytes.NewBufferString(rawNote),
+		),
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	quoteURI := ap.ExtractQuoteURI(statusable)
+	if quoteURI == nil {
+		return ""
+	}
+
+	return quoteURI.String()
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuote() {
+	quoteURI := suite.extractQuoteURI(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/statuses/1",
+  "type": "Note",
+  "content": "look at this",
+  "quote": "https://example.org/users/someone_else/statuses/2"
+}`)
+	suite.Equal("https://example.org/users/someone_else/statuses/2", quoteURI)
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteMisskey() {
+	quoteURI := suite.extractQuoteURI(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/statuses/1",
+  "type": "Note",
+  "content": "look at this",
+  "_misskey_quote": "https://example.org/users/someone_else/statuses/2"
+}`)
+	suite.Equal("https://example.org/users/someone_else/statuses/2", quoteURI)
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteLinkTag() {
+	quoteURI := suite.extractQuoteURI(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/statuses/1",
+  "type": "Note",
+  "content": "look at this",
+  "tag": [
+    {
+      "type": "Link",
+      "href": "https://example.org/users/someone_else/statuses/2",
+      "mediaType": "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"",
+      "name": "RE: https://example.org/users/someone_else/statuses/2"
+    }
+  ]
+}`)
+	suite.Equal("https://example.org/users/someone_else/statuses/2", quoteURI)
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteNone() {
+	quoteURI := suite.extractQuoteURI(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/statuses/1",
+  "type": "Note",
+  "content": "nothing to see here",
+  "tag": [
+    {
+      "type": "Link",
+      "href": "https://example.org/some/page",
+      "mediaType": "text/html"
+    }
+  ]
+}`)
+	suite.Empty(quoteURI)
+}
+
+func TestExtractQuoteTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractQuoteTestSuite{})
+}
@@ -196,6 +196,7 @@ type Statusable interface {
 	WithName
 	WithInReplyTo
 	WithPublished
+	WithUpdated
 	WithURL
 	WithAttributedTo
 	WithTo
@@ -701,3 +702,9 @@ type WithApprovedBy interface {
 	GetGoToSocialApprovedBy() vocab.GoToSocialApprovedByProperty
 	SetGoToSocialApprovedBy(vocab.GoToSocialApprovedByProperty)
 }
+
+// WithUnknownProperties represents an object with properties
+// unknown to go-fed, which are kept as raw JSON values.
+type WithUnknownProperties interface {
+	GetUnknownProperties() map[string]interface{}
+}
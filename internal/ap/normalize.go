@@ -638,9 +638,11 @@ func NormalizeOutgoingInteractionPolicyProp(item WithInteractionPolicy, rawJSON
 		"canLike",
 		"canReply",
 		"canAnnounce",
+		"canQuote",
 	} {
 		// Either "canAnnounce",
-		// "canLike", or "canApprove"
+		// "canLike", "canReply",
+		// or "canQuote".
 		rulesVal, ok := policyMap[rulesKey]
 		if !ok {
 			// Not set.
@@ -408,6 +408,25 @@ func SetPublished(with WithPublished, published time.Time) {
 	publishProp.Set(published)
 }
 
+// GetUpdated returns the time contained in the Updated property of 'with'.
+func GetUpdated(with WithUpdated) time.Time {
+	updateProp := with.GetActivityStreamsUpdated()
+	if updateProp == nil || !updateProp.IsXMLSchemaDateTime() {
+		return time.Time{}
+	}
+	return updateProp.Get()
+}
+
+// SetUpdated sets the given time on the Updated property of 'with'.
+func SetUpdated(with WithUpdated, updated time.Time) {
+	updateProp := with.GetActivityStreamsUpdated()
+	if updateProp == nil {
+		updateProp = streams.NewActivityStreamsUpdatedProperty()
+		with.SetActivityStreamsUpdated(updateProp)
+	}
+	updateProp.Set(updated)
+}
+
 // GetEndTime returns the time contained in the EndTime property of 'with'.
 func GetEndTime(with WithEndTime) time.Time {
 	endTimeProp := with.GetActivityStreamsEndTime()
@@ -541,6 +560,70 @@ func SetApprovedBy(with WithApprovedBy, approvedBy *url.URL) {
 	abProp.Set(approvedBy)
 }
 
+// SetQuoteURI sets the given quoted status URI on 'with',
+// using the quote properties understood by the widest
+// range of implementations, and an FEP-e232 object link.
+func SetQuoteURI(with Statusable, quoteURI *url.URL) {
+	if withUnknown, ok := with.(WithUnknownProperties); ok {
+		unknown := withUnknown.GetUnknownProperties()
+		unknown["quote"] = quoteURI.String()
+		unknown["quoteUrl"] = quoteURI.String()
+		unknown["_misskey_quote"] = quoteURI.String()
+	}
+
+	link := streams.NewActivityStreamsLink()
+
+	hrefProp := streams.NewActivityStreamsHrefProperty()
+	hrefProp.SetIRI(quoteURI)
+	link.SetActivityStreamsHref(hrefProp)
+
+	mediaTypeProp := streams.NewActivityStreamsMediaTypeProperty()
+	mediaTypeProp.Set(quoteLinkMediaTypes[0])
+	link.SetActivityStreamsMediaType(mediaTypeProp)
+
+	relProp := streams.NewActivityStreamsRelProperty()
+	relProp.AppendRFCRfc5988(QuoteLinkRel)
+	link.SetActivityStreamsRel(relProp)
+
+	nameProp := streams.NewActivityStreamsNameProperty()
+	nameProp.AppendXMLSchemaString("RE: " + quoteURI.String())
+	link.SetActivityStreamsName(nameProp)
+
+	tagProp := with.GetActivityStreamsTag()
+	if tagProp == nil {
+		tagProp = streams.NewActivityStreamsTagProperty()
+		with.SetActivityStreamsTag(tagProp)
+	}
+	tagProp.AppendActivityStreamsLink(link)
+}
+
+// SetCanQuote sets the given always and approvalRequired
+// properties as the canQuote rules of the given policy.
+// go-fed doesn't know about canQuote, so the rules are
+// set as an unknown property, serialized with the rest.
+func SetCanQuote(
+	policy vocab.GoToSocialInteractionPolicy,
+	always vocab.GoToSocialAlwaysProperty,
+	approvalRequired vocab.GoToSocialApprovalRequiredProperty,
+) error {
+	alwaysRaw, err := always.Serialize()
+	if err != nil {
+		return gtserror.Newf("error serializing always: %w", err)
+	}
+
+	approvalRequiredRaw, err := approvalRequired.Serialize()
+	if err != nil {
+		return gtserror.Newf("error serializing approvalRequired: %w", err)
+	}
+
+	policy.GetUnknownProperties()["canQuote"] = map[string]interface{}{
+		"always":           alwaysRaw,
+		"approvalRequired": approvalRequiredRaw,
+	}
+
+	return nil
+}
+
 // extractIRIs extracts just the AP IRIs from an iterable
 // property that may contain types (with IRIs) or just IRIs.
 //
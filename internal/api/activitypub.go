@@ -33,6 +33,7 @@ type ActivityPub struct {
 	users                    *users.Module
 	publicKey                *publickey.Module
 	signatureCheckMiddleware gin.HandlerFunc
+	inboxLimit               gin.HandlerFunc
 }
 
 func (a *ActivityPub) Route(r *router.Router, m ...gin.HandlerFunc) {
@@ -47,7 +48,7 @@ func (a *ActivityPub) Route(r *router.Router, m ...gin.HandlerFunc) {
 	emojiGroup.Use(m...)
 	usersGroup.Use(m...)
 	emojiGroup.Use(a.signatureCheckMiddleware, ccMiddleware)
-	usersGroup.Use(a.signatureCheckMiddleware, ccMiddleware)
+	usersGroup.Use(a.signatureCheckMiddleware, a.inboxLimit, ccMiddleware)
 
 	a.emoji.Route(emojiGroup.Handle)
 	a.users.Route(usersGroup.Handle)
@@ -69,11 +70,15 @@ func (a *ActivityPub) RoutePublicKey(r *router.Router, m ...gin.HandlerFunc) {
 	a.publicKey.Route(publicKeyGroup.Handle)
 }
 
-func NewActivityPub(db db.DB, p *processing.Processor) *ActivityPub {
+// NewActivityPub returns a new ActivityPub router module. The given
+// inboxLimit middleware is attached after signature checks on the
+// 'users' group, to rate limit inbox deliveries per remote domain.
+func NewActivityPub(db db.DB, p *processing.Processor, inboxLimit gin.HandlerFunc) *ActivityPub {
 	return &ActivityPub{
 		emoji:                    emoji.New(p),
 		users:                    users.New(p),
 		publicKey:                publickey.New(p),
 		signatureCheckMiddleware: middleware.SignatureCheck(db.IsURIBlocked),
+		inboxLimit:               inboxLimit,
 	}
 }
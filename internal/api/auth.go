@@ -31,6 +31,7 @@
 type Auth struct {
 	routerSession *gtsmodel.RouterSession
 	sessionName   string
+	signInLimit   gin.HandlerFunc
 
 	auth *auth.Module
 }
@@ -51,17 +52,20 @@ func (a *Auth) Route(r *router.Router, m ...gin.HandlerFunc) {
 	)
 	authGroup.Use(m...)
 	oauthGroup.Use(m...)
-	authGroup.Use(ccMiddleware, sessionMiddleware)
+	authGroup.Use(a.signInLimit, ccMiddleware, sessionMiddleware)
 	oauthGroup.Use(ccMiddleware, sessionMiddleware)
 
 	a.auth.RouteAuth(authGroup.Handle)
 	a.auth.RouteOauth(oauthGroup.Handle)
 }
 
-func NewAuth(db db.DB, p *processing.Processor, idp oidc.IDP, routerSession *gtsmodel.RouterSession, sessionName string) *Auth {
+// NewAuth returns a new Auth router module. The given signInLimit
+// middleware is attached to the 'auth' group, to rate limit sign-ins.
+func NewAuth(db db.DB, p *processing.Processor, idp oidc.IDP, routerSession *gtsmodel.RouterSession, sessionName string, signInLimit gin.HandlerFunc) *Auth {
 	return &Auth{
 		routerSession: routerSession,
 		sessionName:   sessionName,
+		signInLimit:   signInLimit,
 		auth:          auth.New(db, p, idp),
 	}
 }
@@ -34,6 +34,8 @@
 
 	// AuthSignInPath is the API path for users to sign in through
 	AuthSignInPath = "/sign_in"
+	// AuthTwoFactorPath is the API path for users with 2FA enabled to enter their code after signing in
+	AuthTwoFactorPath = "/2fa"
 	// AuthCheckYourEmailPath users land here after registering a new account, instructs them to confirm their email
 	AuthCheckYourEmailPath = "/check_your_email"
 	// AuthWaitForApprovalPath users land here after confirming their email
@@ -64,6 +66,8 @@
 	callbackStateParam   = "state"
 	callbackCodeParam    = "code"
 	sessionUserID        = "userid"
+	sessionUserID2FA     = "userid_2fa"
+	sessionFails2FA      = "fails_2fa"
 	sessionClientID      = "client_id"
 	sessionRedirectURI   = "redirect_uri"
 	sessionForceLogin    = "force_login"
@@ -96,6 +100,8 @@ func New(db db.DB, processor *processing.Processor, idp oidc.IDP) *Module {
 func (m *Module) RouteAuth(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, AuthSignInPath, m.SignInGETHandler)
 	attachHandler(http.MethodPost, AuthSignInPath, m.SignInPOSTHandler)
+	attachHandler(http.MethodGet, AuthTwoFactorPath, m.TwoFactorGETHandler)
+	attachHandler(http.MethodPost, AuthTwoFactorPath, m.TwoFactorPOSTHandler)
 	attachHandler(http.MethodGet, AuthCallbackPath, m.CallbackGETHandler)
 }
 
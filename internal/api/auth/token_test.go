@@ -96,6 +96,10 @@ func (suite *TokenTestSuite) TestRetrieveClientCredentialsOK() {
 	err = suite.db.GetWhere(context.Background(), []db.Where{{Key: "access", Value: t.AccessToken}}, dbToken)
 	suite.NoError(err)
 	suite.NotNil(dbToken)
+
+	// no scope was requested, so the token
+	// should have the scopes of the application
+	suite.Equal(suite.testApplications["application_1"].Scopes, dbToken.Scope)
 }
 
 func (suite *TokenTestSuite) TestRetrieveAuthorizationCodeOK() {
@@ -0,0 +1,74 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/auth"
+)
+
+const (
+	sessionUserID2FA = "userid_2fa"
+	sessionFails2FA  = "fails_2fa"
+)
+
+type AuthTwoFactorTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *AuthTwoFactorTestSuite) postCode(fails int) sessions.Session {
+	user := suite.testUsers["local_account_1"]
+	user.TwoFactorSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+	user.TwoFactorEnabledAt = time.Now()
+	if err := suite.db.UpdateUser(context.Background(), user,
+		"two_factor_secret",
+		"two_factor_enabled_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx, recorder := suite.newContext(
+		http.MethodPost,
+		"auth"+auth.AuthTwoFactorPath,
+		[]byte("code=000000"),
+		"application/x-www-form-urlencoded",
+	)
+
+	s := sessions.Default(ctx)
+	s.Set(sessionUserID2FA, user.ID)
+	if fails != 0 {
+		s.Set(sessionFails2FA, fails)
+	}
+	if err := s.Save(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.authModule.TwoFactorPOSTHandler(ctx)
+	suite.Equal(http.StatusUnauthorized, recorder.Code)
+
+	return s
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorWrongCode() {
+	// A wrong code should be counted, but
+	// leave the sign-in in place for a retry.
+	s := suite.postCode(0)
+	suite.Equal(suite.testUsers["local_account_1"].ID, s.Get(sessionUserID2FA))
+	suite.Equal(1, s.Get(sessionFails2FA))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorTooManyWrongCodes() {
+	// After too many wrong codes, the
+	// user has to sign in again.
+	s := suite.postCode(4)
+	suite.Nil(s.Get(sessionUserID2FA))
+	suite.Nil(s.Get(sessionFails2FA))
+}
+
+func TestAuthTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthTwoFactorTestSuite))
+}
@@ -23,11 +23,13 @@
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/announcements"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/apps"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/bookmarks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/conversations"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/customemojis"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/domainblocks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/exports"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/featuredtags"
@@ -39,6 +41,7 @@
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/interactionpolicies"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/interactionrequests"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/invites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/lists"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/markers"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/media"
@@ -46,12 +49,17 @@
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notifications"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/polls"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/preferences"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/reports"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/scheduledstatuses"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/suggestions"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/tags"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timelines"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tokens"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/trends"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
@@ -63,14 +71,17 @@
 type Client struct {
 	processor *processing.Processor
 	db        db.DB
+	ipLimit   gin.HandlerFunc
 
 	accounts            *accounts.Module            // api/v1/accounts, api/v1/profile
 	admin               *admin.Module               // api/v1/admin
+	announcements       *announcements.Module       // api/v1/announcements
 	apps                *apps.Module                // api/v1/apps
 	blocks              *blocks.Module              // api/v1/blocks
 	bookmarks           *bookmarks.Module           // api/v1/bookmarks
 	conversations       *conversations.Module       // api/v1/conversations
 	customEmojis        *customemojis.Module        // api/v1/custom_emojis
+	domainBlocks        *domainblocks.Module        // api/v1/domain_blocks
 	exports             *exports.Module             // api/v1/exports
 	favourites          *favourites.Module          // api/v1/favourites
 	featuredTags        *featuredtags.Module        // api/v1/featured_tags
@@ -82,19 +93,25 @@ type Client struct {
 	instance            *instance.Module            // api/v1/instance
 	interactionPolicies *interactionpolicies.Module // api/v1/interaction_policies
 	interactionRequests *interactionrequests.Module // api/v1/interaction_requests
+	invites             *invites.Module             // api/v1/invites
 	lists               *lists.Module               // api/v1/lists
 	markers             *markers.Module             // api/v1/markers
 	media               *media.Module               // api/v1/media, api/v2/media
 	mutes               *mutes.Module               // api/v1/mutes
 	notifications       *notifications.Module       // api/v1/notifications
 	polls               *polls.Module               // api/v1/polls
+	push                *push.Module                // api/v1/push
 	preferences         *preferences.Module         // api/v1/preferences
 	reports             *reports.Module             // api/v1/reports
+	scheduledStatuses   *scheduledstatuses.Module   // api/v1/scheduled_statuses
 	search              *search.Module              // api/v1/search, api/v2/search
 	statuses            *statuses.Module            // api/v1/statuses
 	streaming           *streaming.Module           // api/v1/streaming
+	suggestions         *suggestions.Module         // api/v1/suggestions, api/v2/suggestions
 	tags                *tags.Module                // api/v1/tags
 	timelines           *timelines.Module           // api/v1/timelines
+	tokens              *tokens.Module              // api/v1/tokens
+	trends              *trends.Module              // api/v1/trends
 	user                *user.Module                // api/v1/user
 }
 
@@ -102,10 +119,15 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	// create a new group on the top level client 'api' prefix
 	apiGroup := r.AttachGroup("api")
 
-	// attach non-global middlewares appropriate to the client api
+	// attach non-global middlewares appropriate to the client api;
+	// limit by IP before checking the token, so that floods of bogus
+	// tokens can't hammer the database, then check the token before
+	// the given middlewares, so that rate limiting can count requests
+	// by the authorized account
+	apiGroup.Use(c.ipLimit)
+	apiGroup.Use(middleware.TokenCheck(c.db, c.processor.OAuthValidateBearerToken))
 	apiGroup.Use(m...)
 	apiGroup.Use(
-		middleware.TokenCheck(c.db, c.processor.OAuthValidateBearerToken),
 		middleware.CacheControl(middleware.CacheControlConfig{
 			// Never cache client api responses.
 			Directives: []string{"no-store"},
@@ -117,11 +139,13 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	h := apiGroup.Handle
 	c.accounts.Route(h)
 	c.admin.Route(h)
+	c.announcements.Route(h)
 	c.apps.Route(h)
 	c.blocks.Route(h)
 	c.bookmarks.Route(h)
 	c.conversations.Route(h)
 	c.customEmojis.Route(h)
+	c.domainBlocks.Route(h)
 	c.exports.Route(h)
 	c.favourites.Route(h)
 	c.featuredTags.Route(h)
@@ -133,34 +157,47 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.instance.Route(h)
 	c.interactionPolicies.Route(h)
 	c.interactionRequests.Route(h)
+	c.invites.Route(h)
 	c.lists.Route(h)
 	c.markers.Route(h)
 	c.media.Route(h)
 	c.mutes.Route(h)
 	c.notifications.Route(h)
 	c.polls.Route(h)
+	c.push.Route(h)
 	c.preferences.Route(h)
 	c.reports.Route(h)
+	c.scheduledStatuses.Route(h)
 	c.search.Route(h)
 	c.statuses.Route(h)
 	c.streaming.Route(h)
+	c.suggestions.Route(h)
 	c.tags.Route(h)
 	c.timelines.Route(h)
+	c.tokens.Route(h)
+	c.trends.Route(h)
 	c.user.Route(h)
 }
 
-func NewClient(state *state.State, p *processing.Processor) *Client {
+// NewClient returns a new client API router module. The given
+// ipLimit middleware is attached before token checks on the 'api'
+// group, to rate limit requests per IP address before the token
+// of each request is looked up.
+func NewClient(state *state.State, p *processing.Processor, ipLimit gin.HandlerFunc) *Client {
 	return &Client{
 		processor: p,
 		db:        state.DB,
+		ipLimit:   ipLimit,
 
 		accounts:            accounts.New(p),
 		admin:               admin.New(state, p),
+		announcements:       announcements.New(p),
 		apps:                apps.New(p),
 		blocks:              blocks.New(p),
 		bookmarks:           bookmarks.New(p),
 		conversations:       conversations.New(p),
 		customEmojis:        customemojis.New(p),
+		domainBlocks:        domainblocks.New(p),
 		exports:             exports.New(p),
 		favourites:          favourites.New(p),
 		featuredTags:        featuredtags.New(p),
@@ -172,19 +209,25 @@ func NewClient(state *state.State, p *processing.Processor) *Client {
 		instance:            instance.New(p),
 		interactionPolicies: interactionpolicies.New(p),
 		interactionRequests: interactionrequests.New(p),
+		invites:             invites.New(p),
 		lists:               lists.New(p),
 		markers:             markers.New(p),
 		media:               media.New(p),
 		mutes:               mutes.New(p),
 		notifications:       notifications.New(p),
 		polls:               polls.New(p),
+		push:                push.New(p),
 		preferences:         preferences.New(p),
 		reports:             reports.New(p),
+		scheduledStatuses:   scheduledstatuses.New(p),
 		search:              search.New(p),
 		statuses:            statuses.New(p),
 		streaming:           streaming.New(p, time.Second*30, 4096),
+		suggestions:         suggestions.New(p),
 		tags:                tags.New(p),
 		timelines:           timelines.New(p),
+		tokens:              tokens.New(p),
+		trends:              trends.New(p),
 		user:                user.New(p),
 	}
 }
@@ -21,6 +21,8 @@
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -80,57 +82,57 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, BasePath, m.AccountCreatePOSTHandler)
 
 	// get account
-	attachHandler(http.MethodGet, BasePathWithID, m.AccountGETHandler)
+	attachHandler(http.MethodGet, BasePathWithID, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.AccountGETHandler)
 
 	// delete account
-	attachHandler(http.MethodPost, DeletePath, m.AccountDeletePOSTHandler)
+	attachHandler(http.MethodPost, DeletePath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.AccountDeletePOSTHandler)
 
 	// verify account
-	attachHandler(http.MethodGet, VerifyPath, m.AccountVerifyGETHandler)
+	attachHandler(http.MethodGet, VerifyPath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.AccountVerifyGETHandler)
 
 	// modify account
-	attachHandler(http.MethodPatch, UpdatePath, m.AccountUpdateCredentialsPATCHHandler)
+	attachHandler(http.MethodPatch, UpdatePath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.AccountUpdateCredentialsPATCHHandler)
 
 	// modify account profile media
-	attachHandler(http.MethodDelete, AvatarPath, m.AccountAvatarDELETEHandler)
-	attachHandler(http.MethodDelete, HeaderPath, m.AccountHeaderDELETEHandler)
+	attachHandler(http.MethodDelete, AvatarPath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.AccountAvatarDELETEHandler)
+	attachHandler(http.MethodDelete, HeaderPath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.AccountHeaderDELETEHandler)
 
 	// get account's statuses
-	attachHandler(http.MethodGet, StatusesPath, m.AccountStatusesGETHandler)
+	attachHandler(http.MethodGet, StatusesPath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.AccountStatusesGETHandler)
 
 	// get following or followers
-	attachHandler(http.MethodGet, FollowersPath, m.AccountFollowersGETHandler)
-	attachHandler(http.MethodGet, FollowingPath, m.AccountFollowingGETHandler)
+	attachHandler(http.MethodGet, FollowersPath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.AccountFollowersGETHandler)
+	attachHandler(http.MethodGet, FollowingPath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.AccountFollowingGETHandler)
 
 	// get relationship with account
-	attachHandler(http.MethodGet, RelationshipsPath, m.AccountRelationshipsGETHandler)
+	attachHandler(http.MethodGet, RelationshipsPath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.AccountRelationshipsGETHandler)
 
 	// follow or unfollow account
-	attachHandler(http.MethodPost, FollowPath, m.AccountFollowPOSTHandler)
-	attachHandler(http.MethodPost, UnfollowPath, m.AccountUnfollowPOSTHandler)
+	attachHandler(http.MethodPost, FollowPath, middleware.ScopeCheck(oauth.ScopeWriteFollows), m.AccountFollowPOSTHandler)
+	attachHandler(http.MethodPost, UnfollowPath, middleware.ScopeCheck(oauth.ScopeWriteFollows), m.AccountUnfollowPOSTHandler)
 
 	// block or unblock account
-	attachHandler(http.MethodPost, BlockPath, m.AccountBlockPOSTHandler)
-	attachHandler(http.MethodPost, UnblockPath, m.AccountUnblockPOSTHandler)
+	attachHandler(http.MethodPost, BlockPath, middleware.ScopeCheck(oauth.ScopeWriteBlocks), m.AccountBlockPOSTHandler)
+	attachHandler(http.MethodPost, UnblockPath, middleware.ScopeCheck(oauth.ScopeWriteBlocks), m.AccountUnblockPOSTHandler)
 
 	// account lists
-	attachHandler(http.MethodGet, ListsPath, m.AccountListsGETHandler)
+	attachHandler(http.MethodGet, ListsPath, middleware.ScopeCheck(oauth.ScopeReadLists), m.AccountListsGETHandler)
 
 	// account note
-	attachHandler(http.MethodPost, NotePath, m.AccountNotePOSTHandler)
+	attachHandler(http.MethodPost, NotePath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.AccountNotePOSTHandler)
 
 	// mute or unmute account
-	attachHandler(http.MethodPost, MutePath, m.AccountMutePOSTHandler)
-	attachHandler(http.MethodPost, UnmutePath, m.AccountUnmutePOSTHandler)
+	attachHandler(http.MethodPost, MutePath, middleware.ScopeCheck(oauth.ScopeWriteMutes), m.AccountMutePOSTHandler)
+	attachHandler(http.MethodPost, UnmutePath, middleware.ScopeCheck(oauth.ScopeWriteMutes), m.AccountUnmutePOSTHandler)
 
 	// search for accounts
-	attachHandler(http.MethodGet, SearchPath, m.AccountSearchGETHandler)
-	attachHandler(http.MethodGet, LookupPath, m.AccountLookupGETHandler)
+	attachHandler(http.MethodGet, SearchPath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.AccountSearchGETHandler)
+	attachHandler(http.MethodGet, LookupPath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.AccountLookupGETHandler)
 
 	// migration handlers
-	attachHandler(http.MethodPost, AliasPath, m.AccountAliasPOSTHandler)
-	attachHandler(http.MethodPost, MovePath, m.AccountMovePOSTHandler)
+	attachHandler(http.MethodPost, AliasPath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.AccountAliasPOSTHandler)
+	attachHandler(http.MethodPost, MovePath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.AccountMovePOSTHandler)
 
 	// account themes
-	attachHandler(http.MethodGet, ThemesPath, m.AccountThemesGETHandler)
+	attachHandler(http.MethodGet, ThemesPath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.AccountThemesGETHandler)
 }
@@ -23,42 +23,67 @@
 	"codeberg.org/gruf/go-debug"
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 )
 
 const (
-	BasePath                = "/v1/admin"
-	EmojiPath               = BasePath + "/custom_emojis"
-	EmojiPathWithID         = EmojiPath + "/:" + apiutil.IDKey
-	EmojiCategoriesPath     = EmojiPath + "/categories"
-	DomainBlocksPath        = BasePath + "/domain_blocks"
-	DomainBlocksPathWithID  = DomainBlocksPath + "/:" + apiutil.IDKey
-	DomainAllowsPath        = BasePath + "/domain_allows"
-	DomainAllowsPathWithID  = DomainAllowsPath + "/:" + apiutil.IDKey
-	DomainKeysExpirePath    = BasePath + "/domain_keys_expire"
-	HeaderAllowsPath        = BasePath + "/header_allows"
-	HeaderAllowsPathWithID  = HeaderAllowsPath + "/:" + apiutil.IDKey
-	HeaderBlocksPath        = BasePath + "/header_blocks"
-	HeaderBlocksPathWithID  = HeaderBlocksPath + "/:" + apiutil.IDKey
-	AccountsV1Path          = BasePath + "/accounts"
-	AccountsV2Path          = "/v2/admin/accounts"
-	AccountsPathWithID      = AccountsV1Path + "/:" + apiutil.IDKey
-	AccountsActionPath      = AccountsPathWithID + "/action"
-	AccountsApprovePath     = AccountsPathWithID + "/approve"
-	AccountsRejectPath      = AccountsPathWithID + "/reject"
-	MediaCleanupPath        = BasePath + "/media_cleanup"
-	MediaRefetchPath        = BasePath + "/media_refetch"
-	ReportsPath             = BasePath + "/reports"
-	ReportsPathWithID       = ReportsPath + "/:" + apiutil.IDKey
-	ReportsResolvePath      = ReportsPathWithID + "/resolve"
-	EmailPath               = BasePath + "/email"
-	EmailTestPath           = EmailPath + "/test"
-	InstanceRulesPath       = BasePath + "/instance/rules"
-	InstanceRulesPathWithID = InstanceRulesPath + "/:" + apiutil.IDKey
-	DebugPath               = BasePath + "/debug"
-	DebugAPUrlPath          = DebugPath + "/apurl"
-	DebugClearCachesPath    = DebugPath + "/caches/clear"
+	BasePath                                = "/v1/admin"
+	EmojiPath                               = BasePath + "/custom_emojis"
+	EmojiPathWithID                         = EmojiPath + "/:" + apiutil.IDKey
+	EmojiCategoriesPath                     = EmojiPath + "/categories"
+	DomainBlocksPath                        = BasePath + "/domain_blocks"
+	DomainBlocksPathWithID                  = DomainBlocksPath + "/:" + apiutil.IDKey
+	DomainAllowsPath                        = BasePath + "/domain_allows"
+	DomainAllowsPathWithID                  = DomainAllowsPath + "/:" + apiutil.IDKey
+	DomainKeysExpirePath                    = BasePath + "/domain_keys_expire"
+	DomainPermissionDraftsPath              = BasePath + "/domain_permission_drafts"
+	DomainPermissionDraftsPathWithID        = DomainPermissionDraftsPath + "/:" + apiutil.IDKey
+	DomainPermissionDraftAcceptPath         = DomainPermissionDraftsPathWithID + "/accept"
+	DomainPermissionDraftRejectPath         = DomainPermissionDraftsPathWithID + "/reject"
+	DomainPermissionExcludesPath            = BasePath + "/domain_permission_excludes"
+	DomainPermissionExcludesPathWithID      = DomainPermissionExcludesPath + "/:" + apiutil.IDKey
+	DomainPermissionSubscriptionsPath       = BasePath + "/domain_permission_subscriptions"
+	DomainPermissionSubscriptionsPathWithID = DomainPermissionSubscriptionsPath + "/:" + apiutil.IDKey
+	DomainPermissionSubscriptionRemovePath  = DomainPermissionSubscriptionsPathWithID + "/remove"
+	DomainPermissionSubscriptionTestPath    = DomainPermissionSubscriptionsPathWithID + "/test"
+	HeaderAllowsPath                        = BasePath + "/header_allows"
+	HeaderAllowsPathWithID                  = HeaderAllowsPath + "/:" + apiutil.IDKey
+	HeaderBlocksPath                        = BasePath + "/header_blocks"
+	HeaderBlocksPathWithID                  = HeaderBlocksPath + "/:" + apiutil.IDKey
+	AccountsV1Path                          = BasePath + "/accounts"
+	AccountsV2Path                          = "/v2/admin/accounts"
+	AccountsPathWithID                      = AccountsV1Path + "/:" + apiutil.IDKey
+	AccountsActionPath                      = AccountsPathWithID + "/action"
+	AccountsApprovePath                     = AccountsPathWithID + "/approve"
+	AccountsRejectPath                      = AccountsPathWithID + "/reject"
+	MediaCleanupPath                        = BasePath + "/media_cleanup"
+	MediaRefetchPath                        = BasePath + "/media_refetch"
+	ReportsPath                             = BasePath + "/reports"
+	ReportsPathWithID                       = ReportsPath + "/:" + apiutil.IDKey
+	ReportsResolvePath                      = ReportsPathWithID + "/resolve"
+	EmailPath                               = BasePath + "/email"
+	EmailTestPath                           = EmailPath + "/test"
+	InstanceRulesPath                       = BasePath + "/instance/rules"
+	InstanceRulesPathWithID                 = InstanceRulesPath + "/:" + apiutil.IDKey
+	RelaysPath                              = BasePath + "/relays"
+	RelaysPathWithID                        = RelaysPath + "/:" + apiutil.IDKey
+	AnnouncementsPath                       = BasePath + "/announcements"
+	AnnouncementsPathWithID                 = AnnouncementsPath + "/:" + apiutil.IDKey
+	TrendsPath                              = BasePath + "/trends"
+	TrendsTagsPath                          = TrendsPath + "/tags"
+	TrendsStatusesPath                      = TrendsPath + "/statuses"
+	TrendsLinksPath                         = TrendsPath + "/links"
+	TrendReviewsPathWithID                  = TrendsPath + "/reviews/:" + apiutil.IDKey
+	TrendReviewApprovePath                  = TrendReviewsPathWithID + "/approve"
+	TrendReviewRejectPath                   = TrendReviewsPathWithID + "/reject"
+	StaffPicksPath                          = BasePath + "/staff_picks"
+	StaffPicksPathWithID                    = StaffPicksPath + "/:" + apiutil.IDKey
+	DebugPath                               = BasePath + "/debug"
+	DebugAPUrlPath                          = DebugPath + "/apurl"
+	DebugClearCachesPath                    = DebugPath + "/caches/clear"
 
 	FilterQueryKey        = "filter"
 	MaxShortcodeDomainKey = "max_shortcode_domain"
@@ -80,68 +105,114 @@ func New(state *state.State, processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	// emoji stuff
-	attachHandler(http.MethodPost, EmojiPath, m.EmojiCreatePOSTHandler)
-	attachHandler(http.MethodGet, EmojiPath, m.EmojisGETHandler)
-	attachHandler(http.MethodDelete, EmojiPathWithID, m.EmojiDELETEHandler)
-	attachHandler(http.MethodGet, EmojiPathWithID, m.EmojiGETHandler)
-	attachHandler(http.MethodPatch, EmojiPathWithID, m.EmojiPATCHHandler)
-	attachHandler(http.MethodGet, EmojiCategoriesPath, m.EmojiCategoriesGETHandler)
+	attachHandler(http.MethodPost, EmojiPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.EmojiCreatePOSTHandler)
+	attachHandler(http.MethodGet, EmojiPath, middleware.ScopeCheck(oauth.ScopeAdminRead), m.EmojisGETHandler)
+	attachHandler(http.MethodDelete, EmojiPathWithID, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.EmojiDELETEHandler)
+	attachHandler(http.MethodGet, EmojiPathWithID, middleware.ScopeCheck(oauth.ScopeAdminRead), m.EmojiGETHandler)
+	attachHandler(http.MethodPatch, EmojiPathWithID, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.EmojiPATCHHandler)
+	attachHandler(http.MethodGet, EmojiCategoriesPath, middleware.ScopeCheck(oauth.ScopeAdminRead), m.EmojiCategoriesGETHandler)
 
 	// domain block stuff
-	attachHandler(http.MethodPost, DomainBlocksPath, m.DomainBlocksPOSTHandler)
-	attachHandler(http.MethodGet, DomainBlocksPath, m.DomainBlocksGETHandler)
-	attachHandler(http.MethodGet, DomainBlocksPathWithID, m.DomainBlockGETHandler)
-	attachHandler(http.MethodDelete, DomainBlocksPathWithID, m.DomainBlockDELETEHandler)
+	attachHandler(http.MethodPost, DomainBlocksPath, middleware.ScopeCheck(oauth.ScopeAdminWriteDomainBlocks), m.DomainBlocksPOSTHandler)
+	attachHandler(http.MethodGet, DomainBlocksPath, middleware.ScopeCheck(oauth.ScopeAdminReadDomainBlocks), m.DomainBlocksGETHandler)
+	attachHandler(http.MethodGet, DomainBlocksPathWithID, middleware.ScopeCheck(oauth.ScopeAdminReadDomainBlocks), m.DomainBlockGETHandler)
+	attachHandler(http.MethodDelete, DomainBlocksPathWithID, middleware.ScopeCheck(oauth.ScopeAdminWriteDomainBlocks), m.DomainBlockDELETEHandler)
 
 	// domain allow stuff
-	attachHandler(http.MethodPost, DomainAllowsPath, m.DomainAllowsPOSTHandler)
-	attachHandler(http.MethodGet, DomainAllowsPath, m.DomainAllowsGETHandler)
-	attachHandler(http.MethodGet, DomainAllowsPathWithID, m.DomainAllowGETHandler)
-	attachHandler(http.MethodDelete, DomainAllowsPathWithID, m.DomainAllowDELETEHandler)
+	attachHandler(http.MethodPost, DomainAllowsPath, middleware.ScopeCheck(oauth.ScopeAdminWriteDomainAllows), m.DomainAllowsPOSTHandler)
+	attachHandler(http.MethodGet, DomainAllowsPath, middleware.ScopeCheck(oauth.ScopeAdminReadDomainAllows), m.DomainAllowsGETHandler)
+	attachHandler(http.MethodGet, DomainAllowsPathWithID, middleware.ScopeCheck(oauth.ScopeAdminReadDomainAllows), m.DomainAllowGETHandler)
+	attachHandler(http.MethodDelete, DomainAllowsPathWithID, middleware.ScopeCheck(oauth.ScopeAdminWriteDomainAllows), m.DomainAllowDELETEHandler)
+
+	// domain permission drafts stuff
+	attachHandler(http.MethodPost, DomainPermissionDraftsPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.DomainPermissionDraftsPOSTHandler)
+	attachHandler(http.MethodGet, DomainPermissionDraftsPath, middleware.ScopeCheck(oauth.ScopeAdminRead), m.DomainPermissionDraftsGETHandler)
+	attachHandler(http.MethodGet, DomainPermissionDraftsPathWithID, middleware.ScopeCheck(oauth.ScopeAdminRead), m.DomainPermissionDraftGETHandler)
+	attachHandler(http.MethodPost, DomainPermissionDraftAcceptPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.DomainPermissionDraftAcceptPOSTHandler)
+	attachHandler(http.MethodPost, DomainPermissionDraftRejectPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.DomainPermissionDraftRejectPOSTHandler)
+
+	// domain permission excludes stuff
+	attachHandler(http.MethodPost, DomainPermissionExcludesPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.DomainPermissionExcludesPOSTHandler)
+	attachHandler(http.MethodGet, DomainPermissionExcludesPath, middleware.ScopeCheck(oauth.ScopeAdminRead), m.DomainPermissionExcludesGETHandler)
+	attachHandler(http.MethodGet, DomainPermissionExcludesPathWithID, middleware.ScopeCheck(oauth.ScopeAdminRead), m.DomainPermissionExcludeGETHandler)
+	attachHandler(http.MethodDelete, DomainPermissionExcludesPathWithID, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.DomainPermissionExcludeDELETEHandler)
+
+	// domain permission subscriptions stuff
+	attachHandler(http.MethodPost, DomainPermissionSubscriptionsPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.DomainPermissionSubscriptionPOSTHandler)
+	attachHandler(http.MethodGet, DomainPermissionSubscriptionsPath, middleware.ScopeCheck(oauth.ScopeAdminRead), m.DomainPermissionSubscriptionsGETHandler)
+	attachHandler(http.MethodGet, DomainPermissionSubscriptionsPathWithID, middleware.ScopeCheck(oauth.ScopeAdminRead), m.DomainPermissionSubscriptionGETHandler)
+	attachHandler(http.MethodPatch, DomainPermissionSubscriptionsPathWithID, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.DomainPermissionSubscriptionPATCHHandler)
+	attachHandler(http.MethodPost, DomainPermissionSubscriptionRemovePath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.DomainPermissionSubscriptionRemovePOSTHandler)
+	attachHandler(http.MethodPost, DomainPermissionSubscriptionTestPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.DomainPermissionSubscriptionTestPOSTHandler)
 
 	// header filtering administration routes
-	attachHandler(http.MethodGet, HeaderAllowsPathWithID, m.HeaderFilterAllowGET)
-	attachHandler(http.MethodGet, HeaderBlocksPathWithID, m.HeaderFilterBlockGET)
-	attachHandler(http.MethodGet, HeaderAllowsPath, m.HeaderFilterAllowsGET)
-	attachHandler(http.MethodGet, HeaderBlocksPath, m.HeaderFilterBlocksGET)
-	attachHandler(http.MethodPost, HeaderAllowsPath, m.HeaderFilterAllowPOST)
-	attachHandler(http.MethodPost, HeaderBlocksPath, m.HeaderFilterBlockPOST)
-	attachHandler(http.MethodDelete, HeaderAllowsPathWithID, m.HeaderFilterAllowDELETE)
-	attachHandler(http.MethodDelete, HeaderBlocksPathWithID, m.HeaderFilterBlockDELETE)
+	attachHandler(http.MethodGet, HeaderAllowsPathWithID, middleware.ScopeCheck(oauth.ScopeAdminRead), m.HeaderFilterAllowGET)
+	attachHandler(http.MethodGet, HeaderBlocksPathWithID, middleware.ScopeCheck(oauth.ScopeAdminRead), m.HeaderFilterBlockGET)
+	attachHandler(http.MethodGet, HeaderAllowsPath, middleware.ScopeCheck(oauth.ScopeAdminRead), m.HeaderFilterAllowsGET)
+	attachHandler(http.MethodGet, HeaderBlocksPath, middleware.ScopeCheck(oauth.ScopeAdminRead), m.HeaderFilterBlocksGET)
+	attachHandler(http.MethodPost, HeaderAllowsPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.HeaderFilterAllowPOST)
+	attachHandler(http.MethodPost, HeaderBlocksPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.HeaderFilterBlockPOST)
+	attachHandler(http.MethodDelete, HeaderAllowsPathWithID, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.HeaderFilterAllowDELETE)
+	attachHandler(http.MethodDelete, HeaderBlocksPathWithID, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.HeaderFilterBlockDELETE)
 
 	// domain maintenance stuff
-	attachHandler(http.MethodPost, DomainKeysExpirePath, m.DomainKeysExpirePOSTHandler)
+	attachHandler(http.MethodPost, DomainKeysExpirePath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.DomainKeysExpirePOSTHandler)
 
 	// accounts stuff
-	attachHandler(http.MethodGet, AccountsV1Path, m.AccountsGETV1Handler)
-	attachHandler(http.MethodGet, AccountsV2Path, m.AccountsGETV2Handler)
-	attachHandler(http.MethodGet, AccountsPathWithID, m.AccountGETHandler)
-	attachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
-	attachHandler(http.MethodPost, AccountsApprovePath, m.AccountApprovePOSTHandler)
-	attachHandler(http.MethodPost, AccountsRejectPath, m.AccountRejectPOSTHandler)
+	attachHandler(http.MethodGet, AccountsV1Path, middleware.ScopeCheck(oauth.ScopeAdminReadAccounts), m.AccountsGETV1Handler)
+	attachHandler(http.MethodGet, AccountsV2Path, middleware.ScopeCheck(oauth.ScopeAdminReadAccounts), m.AccountsGETV2Handler)
+	attachHandler(http.MethodGet, AccountsPathWithID, middleware.ScopeCheck(oauth.ScopeAdminReadAccounts), m.AccountGETHandler)
+	attachHandler(http.MethodPost, AccountsActionPath, middleware.ScopeCheck(oauth.ScopeAdminWriteAccounts), m.AccountActionPOSTHandler)
+	attachHandler(http.MethodPost, AccountsApprovePath, middleware.ScopeCheck(oauth.ScopeAdminWriteAccounts), m.AccountApprovePOSTHandler)
+	attachHandler(http.MethodPost, AccountsRejectPath, middleware.ScopeCheck(oauth.ScopeAdminWriteAccounts), m.AccountRejectPOSTHandler)
 
 	// media stuff
-	attachHandler(http.MethodPost, MediaCleanupPath, m.MediaCleanupPOSTHandler)
-	attachHandler(http.MethodPost, MediaRefetchPath, m.MediaRefetchPOSTHandler)
+	attachHandler(http.MethodPost, MediaCleanupPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.MediaCleanupPOSTHandler)
+	attachHandler(http.MethodPost, MediaRefetchPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.MediaRefetchPOSTHandler)
 
 	// reports stuff
-	attachHandler(http.MethodGet, ReportsPath, m.ReportsGETHandler)
-	attachHandler(http.MethodGet, ReportsPathWithID, m.ReportGETHandler)
-	attachHandler(http.MethodPost, ReportsResolvePath, m.ReportResolvePOSTHandler)
+	attachHandler(http.MethodGet, ReportsPath, middleware.ScopeCheck(oauth.ScopeAdminReadReports), m.ReportsGETHandler)
+	attachHandler(http.MethodGet, ReportsPathWithID, middleware.ScopeCheck(oauth.ScopeAdminReadReports), m.ReportGETHandler)
+	attachHandler(http.MethodPost, ReportsResolvePath, middleware.ScopeCheck(oauth.ScopeAdminWriteReports), m.ReportResolvePOSTHandler)
 
 	// email stuff
-	attachHandler(http.MethodPost, EmailTestPath, m.EmailTestPOSTHandler)
+	attachHandler(http.MethodPost, EmailTestPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.EmailTestPOSTHandler)
 
 	// instance rules stuff
-	attachHandler(http.MethodGet, InstanceRulesPath, m.RulesGETHandler)
-	attachHandler(http.MethodGet, InstanceRulesPathWithID, m.RuleGETHandler)
-	attachHandler(http.MethodPost, InstanceRulesPath, m.RulePOSTHandler)
-	attachHandler(http.MethodPatch, InstanceRulesPathWithID, m.RulePATCHHandler)
-	attachHandler(http.MethodDelete, InstanceRulesPathWithID, m.RuleDELETEHandler)
+	attachHandler(http.MethodGet, InstanceRulesPath, middleware.ScopeCheck(oauth.ScopeAdminRead), m.RulesGETHandler)
+	attachHandler(http.MethodGet, InstanceRulesPathWithID, middleware.ScopeCheck(oauth.ScopeAdminRead), m.RuleGETHandler)
+	attachHandler(http.MethodPost, InstanceRulesPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.RulePOSTHandler)
+	attachHandler(http.MethodPatch, InstanceRulesPathWithID, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.RulePATCHHandler)
+	attachHandler(http.MethodDelete, InstanceRulesPathWithID, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.RuleDELETEHandler)
+
+	// relays stuff
+	attachHandler(http.MethodGet, RelaysPath, middleware.ScopeCheck(oauth.ScopeAdminRead), m.RelaysGETHandler)
+	attachHandler(http.MethodPost, RelaysPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.RelaysPOSTHandler)
+	attachHandler(http.MethodGet, RelaysPathWithID, middleware.ScopeCheck(oauth.ScopeAdminRead), m.RelayGETHandler)
+	attachHandler(http.MethodDelete, RelaysPathWithID, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.RelayDELETEHandler)
+
+	// announcements stuff
+	attachHandler(http.MethodGet, AnnouncementsPath, middleware.ScopeCheck(oauth.ScopeAdminRead), m.AnnouncementsGETHandler)
+	attachHandler(http.MethodPost, AnnouncementsPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.AnnouncementsPOSTHandler)
+	attachHandler(http.MethodGet, AnnouncementsPathWithID, middleware.ScopeCheck(oauth.ScopeAdminRead), m.AnnouncementGETHandler)
+	attachHandler(http.MethodPatch, AnnouncementsPathWithID, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.AnnouncementPATCHHandler)
+	attachHandler(http.MethodDelete, AnnouncementsPathWithID, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.AnnouncementDELETEHandler)
+
+	// trends stuff
+	attachHandler(http.MethodGet, TrendsTagsPath, middleware.ScopeCheck(oauth.ScopeAdminRead), m.TrendsTagsGETHandler)
+	attachHandler(http.MethodGet, TrendsStatusesPath, middleware.ScopeCheck(oauth.ScopeAdminRead), m.TrendsStatusesGETHandler)
+	attachHandler(http.MethodGet, TrendsLinksPath, middleware.ScopeCheck(oauth.ScopeAdminRead), m.TrendsLinksGETHandler)
+	attachHandler(http.MethodPost, TrendReviewApprovePath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.TrendReviewApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendReviewRejectPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.TrendReviewRejectPOSTHandler)
+
+	// staff picks stuff
+	attachHandler(http.MethodGet, StaffPicksPath, middleware.ScopeCheck(oauth.ScopeAdminRead), m.StaffPicksGETHandler)
+	attachHandler(http.MethodPost, StaffPicksPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.StaffPicksPOSTHandler)
+	attachHandler(http.MethodDelete, StaffPicksPathWithID, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.StaffPickDELETEHandler)
 
 	// debug stuff
 	if debug.DEBUG {
-		attachHandler(http.MethodGet, DebugAPUrlPath, m.DebugAPUrlHandler)
-		attachHandler(http.MethodPost, DebugClearCachesPath, m.DebugClearCachesHandler)
+		attachHandler(http.MethodGet, DebugAPUrlPath, middleware.ScopeCheck(oauth.ScopeAdminRead), m.DebugAPUrlHandler)
+		attachHandler(http.MethodPost, DebugClearCachesPath, middleware.ScopeCheck(oauth.ScopeAdminWrite), m.DebugClearCachesHandler)
 	}
 }
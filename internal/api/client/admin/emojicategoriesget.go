@@ -38,6 +38,10 @@
 //	produces:
 //	- application/json
 //
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
 //	responses:
 //		'200':
 //			description: Array of existing emoji categories.
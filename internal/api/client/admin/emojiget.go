@@ -46,6 +46,10 @@
 //		in: path
 //		required: true
 //
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
 //	responses:
 //		'200':
 //			description: A single emoji.
@@ -99,6 +99,10 @@
 //			Emoji with the given `[shortcode]@[domain]` will not be included in the result set.
 //		in: query
 //
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
 //	responses:
 //		'200':
 //			headers:
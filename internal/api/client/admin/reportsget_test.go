@@ -485,6 +485,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       {
         "id": "01FVW7JHQFSFK166WWKR8CBA6M",
         "created_at": "2021-09-20T10:40:37.000Z",
+        "edited_at": null,
         "in_reply_to_id": null,
         "in_reply_to_account_id": null,
         "sensitive": false,
@@ -583,6 +584,13 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
               "me"
             ],
             "with_approval": []
+          },
+          "can_quote": {
+            "always": [
+              "public",
+              "me"
+            ],
+            "with_approval": []
           }
         }
       }
@@ -738,6 +746,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       {
         "id": "01FVW7JHQFSFK166WWKR8CBA6M",
         "created_at": "2021-09-20T10:40:37.000Z",
+        "edited_at": null,
         "in_reply_to_id": null,
         "in_reply_to_account_id": null,
         "sensitive": false,
@@ -836,6 +845,13 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
               "me"
             ],
             "with_approval": []
+          },
+          "can_quote": {
+            "always": [
+              "public",
+              "me"
+            ],
+            "with_approval": []
           }
         }
       }
@@ -991,6 +1007,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       {
         "id": "01FVW7JHQFSFK166WWKR8CBA6M",
         "created_at": "2021-09-20T10:40:37.000Z",
+        "edited_at": null,
         "in_reply_to_id": null,
         "in_reply_to_account_id": null,
         "sensitive": false,
@@ -1089,6 +1106,13 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
               "me"
             ],
             "with_approval": []
+          },
+          "can_quote": {
+            "always": [
+              "public",
+              "me"
+            ],
+            "with_approval": []
           }
         }
       }
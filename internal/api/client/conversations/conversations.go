@@ -22,6 +22,8 @@
 
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -45,7 +47,7 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodGet, BasePath, m.ConversationsGETHandler)
-	attachHandler(http.MethodDelete, BasePathWithID, m.ConversationDELETEHandler)
-	attachHandler(http.MethodPost, ReadPathWithID, m.ConversationReadPOSTHandler)
+	attachHandler(http.MethodGet, BasePath, middleware.ScopeCheck(oauth.ScopeReadStatuses), m.ConversationsGETHandler)
+	attachHandler(http.MethodDelete, BasePathWithID, middleware.ScopeCheck(oauth.ScopeWriteConversations), m.ConversationDELETEHandler)
+	attachHandler(http.MethodPost, ReadPathWithID, middleware.ScopeCheck(oauth.ScopeWriteConversations), m.ConversationReadPOSTHandler)
 }
@@ -39,7 +39,7 @@
 //
 //	security:
 //	- OAuth2 Bearer:
-//		- read:custom_emojis
+//		- read
 //
 //	responses:
 //		'200':
@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ArchiveDownloadGETHandler swagger:operation GET /api/v1/exports/archive/{id}/download archiveDownload
+//
+// Download the zip file of one archive of your account, once it's ready.
+//
+//	---
+//	tags:
+//	- import-export
+//
+//	produces:
+//	- application/zip
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the archive.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			description: Zip file of the archive.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable entity (archive not ready)
+//		'500':
+//			description: internal server error
+func (m *Module) ArchiveDownloadGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.ZipHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	archiveID := c.Param(IDKey)
+	if archiveID == "" {
+		err := errors.New("no archive id specified")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	content, errWithCode := m.processor.Account().ArchiveFileGet(
+		c.Request.Context(),
+		authed.Account,
+		archiveID,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if content.URL != nil {
+		// This is a signed URL to the
+		// archive in storage, so redirect.
+		c.Redirect(http.StatusFound, content.URL.String())
+		return
+	}
+
+	defer func() {
+		// Close content when we're done, catch errors.
+		if err := content.Content.Close(); err != nil {
+			log.Errorf(c.Request.Context(), "error closing readcloser: %v", err)
+		}
+	}()
+
+	c.DataFromReader(
+		http.StatusOK,
+		content.ContentLength,
+		content.ContentType,
+		content.Content,
+		map[string]string{
+			"Content-Disposition": `attachment; filename="archive-` + archiveID + `.zip"`,
+		},
+	)
+}
@@ -21,17 +21,24 @@
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
 const (
-	BasePath      = "/v1/exports"
-	StatsPath     = BasePath + "/stats"
-	FollowingPath = BasePath + "/following.csv"
-	FollowersPath = BasePath + "/followers.csv"
-	ListsPath     = BasePath + "/lists.csv"
-	BlocksPath    = BasePath + "/blocks.csv"
-	MutesPath     = BasePath + "/mutes.csv"
+	BasePath            = "/v1/exports"
+	StatsPath           = BasePath + "/stats"
+	FollowingPath       = BasePath + "/following.csv"
+	FollowersPath       = BasePath + "/followers.csv"
+	ListsPath           = BasePath + "/lists.csv"
+	BlocksPath          = BasePath + "/blocks.csv"
+	MutesPath           = BasePath + "/mutes.csv"
+	DomainBlocksPath    = BasePath + "/domain_blocks.csv"
+	IDKey               = "id"
+	ArchivePath         = BasePath + "/archive"
+	ArchivePathWithID   = ArchivePath + "/:" + IDKey
+	ArchiveDownloadPath = ArchivePathWithID + "/download"
 )
 
 type Module struct {
@@ -45,10 +52,15 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodGet, StatsPath, m.ExportStatsGETHandler)
-	attachHandler(http.MethodGet, FollowingPath, m.ExportFollowingGETHandler)
-	attachHandler(http.MethodGet, FollowersPath, m.ExportFollowersGETHandler)
-	attachHandler(http.MethodGet, ListsPath, m.ExportListsGETHandler)
-	attachHandler(http.MethodGet, BlocksPath, m.ExportBlocksGETHandler)
-	attachHandler(http.MethodGet, MutesPath, m.ExportMutesGETHandler)
+	attachHandler(http.MethodGet, StatsPath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.ExportStatsGETHandler)
+	attachHandler(http.MethodGet, FollowingPath, middleware.ScopeCheck(oauth.ScopeReadFollows), m.ExportFollowingGETHandler)
+	attachHandler(http.MethodGet, FollowersPath, middleware.ScopeCheck(oauth.ScopeReadFollows), m.ExportFollowersGETHandler)
+	attachHandler(http.MethodGet, ListsPath, middleware.ScopeCheck(oauth.ScopeReadLists), m.ExportListsGETHandler)
+	attachHandler(http.MethodGet, BlocksPath, middleware.ScopeCheck(oauth.ScopeReadBlocks), m.ExportBlocksGETHandler)
+	attachHandler(http.MethodGet, MutesPath, middleware.ScopeCheck(oauth.ScopeReadMutes), m.ExportMutesGETHandler)
+	attachHandler(http.MethodGet, DomainBlocksPath, middleware.ScopeCheck(oauth.ScopeReadBlocks), m.ExportDomainBlocksGETHandler)
+	attachHandler(http.MethodPost, ArchivePath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.ArchivePOSTHandler)
+	attachHandler(http.MethodGet, ArchivePath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.ArchivesGETHandler)
+	attachHandler(http.MethodGet, ArchivePathWithID, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.ArchiveGETHandler)
+	attachHandler(http.MethodGet, ArchiveDownloadPath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.ArchiveDownloadGETHandler)
 }
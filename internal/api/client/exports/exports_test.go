@@ -216,6 +216,17 @@ type testCase struct {
 			expect: `foss_satan@fossbros-anonymous.io
 `,
 		},
+		// Export Domain Blocks.
+		{
+			handler:     suite.exportsModule.ExportDomainBlocksGETHandler,
+			path:        exports.DomainBlocksPath,
+			contentType: apiutil.TextCSV,
+			application: suite.testApplications["application_1"],
+			token:       suite.testTokens["local_account_1"],
+			user:        suite.testUsers["local_account_1"],
+			account:     suite.testAccounts["local_account_1"],
+			expect:      ``,
+		},
 		// Export Stats.
 		{
 			handler:     suite.exportsModule.ExportStatsGETHandler,
@@ -232,7 +243,8 @@ type testCase struct {
   "statuses_count": 8,
   "lists_count": 1,
   "blocks_count": 0,
-  "mutes_count": 0
+  "mutes_count": 0,
+  "domain_blocks_count": 0
 }`,
 		},
 	}
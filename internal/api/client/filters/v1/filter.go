@@ -20,6 +20,8 @@
 import (
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"net/http"
 )
@@ -43,9 +45,9 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodGet, BasePath, m.FiltersGETHandler)
-	attachHandler(http.MethodPost, BasePath, m.FilterPOSTHandler)
-	attachHandler(http.MethodGet, BasePathWithID, m.FilterGETHandler)
-	attachHandler(http.MethodPut, BasePathWithID, m.FilterPUTHandler)
-	attachHandler(http.MethodDelete, BasePathWithID, m.FilterDELETEHandler)
+	attachHandler(http.MethodGet, BasePath, middleware.ScopeCheck(oauth.ScopeReadFilters), m.FiltersGETHandler)
+	attachHandler(http.MethodPost, BasePath, middleware.ScopeCheck(oauth.ScopeWriteFilters), m.FilterPOSTHandler)
+	attachHandler(http.MethodGet, BasePathWithID, middleware.ScopeCheck(oauth.ScopeReadFilters), m.FilterGETHandler)
+	attachHandler(http.MethodPut, BasePathWithID, middleware.ScopeCheck(oauth.ScopeWriteFilters), m.FilterPUTHandler)
+	attachHandler(http.MethodDelete, BasePathWithID, middleware.ScopeCheck(oauth.ScopeWriteFilters), m.FilterDELETEHandler)
 }
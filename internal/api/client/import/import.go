@@ -28,17 +28,25 @@
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
 const (
-	BasePath = "/v1/import"
+	IDKey          = "id"
+	BasePath       = "/v1/import"
+	BasePathWithID = BasePath + "/:" + IDKey
 )
 
 var types = []string{
 	"following",
 	"blocks",
+	"domain_blocks",
+	"lists",
+	"mutes",
+	"bookmarks",
+	"statuses",
 }
 
 var modes = []string{
@@ -57,7 +65,9 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodPost, BasePath, m.ImportPOSTHandler)
+	attachHandler(http.MethodPost, BasePath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.ImportPOSTHandler)
+	attachHandler(http.MethodGet, BasePath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.ImportsGETHandler)
+	attachHandler(http.MethodGet, BasePathWithID, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.ImportGETHandler)
 }
 
 // ImportPOSTHandler swagger:operation POST /api/v1/import importData
@@ -66,9 +76,16 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //
 // This can be used to migrate data from a Mastodon-compatible CSV file to a GoToSocial account.
 //
+// Statuses are instead imported from a Mastodon-compatible account archive zip, containing
+// outbox.json and media files. Its public and unlisted statuses are recreated as new statuses
+// with their original timestamps and attachments, but are not federated or sent to timelines.
+//
 // Uploaded data will be processed asynchronously, and not all entries may be processed depending
 // on domain blocks, user-level blocks, network availability of referenced accounts and statuses, etc.
 //
+// The returned import can be used to check on the progress of processing, and on any errors
+// encountered, using the `/api/v1/import/{id}` endpoint.
+//
 //	---
 //	tags:
 //	- import-export
@@ -83,7 +100,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //	-
 //		name: data
 //		in: formData
-//		description: The CSV data file to upload.
+//		description: The CSV data file to upload, or archive zip file for `statuses`.
 //		type: file
 //		required: true
 //	-
@@ -94,6 +111,11 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //
 //			- `following` - accounts to follow.
 //			- `blocks` - accounts to block.
+//			- `domain_blocks` - domains to block.
+//			- `lists` - lists of followed accounts.
+//			- `mutes` - accounts to mute.
+//			- `bookmarks` - statuses to bookmark.
+//			- `statuses` - statuses to recreate from an archive.
 //		type: string
 //		required: true
 //	-
@@ -103,7 +125,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //			Mode to use when creating entries from the data file:
 //
 //			- `merge` to merge entries in file with existing entries.
-//			- `overwrite` to replace existing entries with entries in file.
+//			- `overwrite` to replace existing entries with entries in file (not supported for `statuses`).
 //		type: string
 //		default: merge
 //
@@ -113,13 +135,17 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //
 //	responses:
 //		'202':
-//			description: Upload accepted.
+//			description: Upload accepted, returns the newly created import.
+//			schema:
+//				"$ref": "#/definitions/import"
 //		'400':
 //			description: bad request
 //		'401':
 //			description: unauthorized
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: unprocessable entity
 //		'500':
 //			description: internal server error
 func (m *Module) ImportPOSTHandler(c *gin.Context) {
@@ -179,7 +205,7 @@ func (m *Module) ImportPOSTHandler(c *gin.Context) {
 	overwrite := form.Mode == "overwrite"
 
 	// Trigger the import.
-	errWithCode := m.processor.Account().ImportData(
+	imp, errWithCode := m.processor.Account().ImportData(
 		c.Request.Context(),
 		authed.Account,
 		form.Data,
@@ -191,5 +217,5 @@ func (m *Module) ImportPOSTHandler(c *gin.Context) {
 		return
 	}
 
-	apiutil.JSON(c, http.StatusAccepted, gin.H{"status": "accepted"})
+	apiutil.JSON(c, http.StatusAccepted, imp)
 }
@@ -18,15 +18,24 @@
 package importdata_test
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	importdata "github.com/superseriousbusiness/gotosocial/internal/api/client/import"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -46,6 +55,9 @@ type ImportTestSuite struct {
 	testApplications map[string]*gtsmodel.Application
 	testUsers        map[string]*gtsmodel.User
 	testAccounts     map[string]*gtsmodel.Account
+	testStatuses     map[string]*gtsmodel.Status
+	testLists        map[string]*gtsmodel.List
+	testBookmarks    map[string]*gtsmodel.StatusBookmark
 
 	// module being tested
 	importModule *importdata.Module
@@ -57,6 +69,9 @@ func (suite *ImportTestSuite) SetupSuite() {
 	suite.testApplications = testrig.NewTestApplications()
 	suite.testUsers = testrig.NewTestUsers()
 	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testLists = testrig.NewTestLists()
+	suite.testBookmarks = testrig.NewTestBookmarks()
 }
 
 func (suite *ImportTestSuite) SetupTest() {
@@ -103,7 +118,7 @@ func (suite *ImportTestSuite) TriggerHandler(
 	importData string,
 	importType string,
 	importMode string,
-) {
+) *apimodel.Import {
 	// Set up request.
 	recorder := httptest.NewRecorder()
 	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
@@ -142,6 +157,63 @@ func (suite *ImportTestSuite) TriggerHandler(
 		}
 		suite.FailNow("", "expected 202, got %d: %s", code, string(b))
 	}
+
+	imp := new(apimodel.Import)
+	if err := json.NewDecoder(recorder.Body).Decode(imp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return imp
+}
+
+func (suite *ImportTestSuite) GetImport(importID string) *apimodel.Import {
+	// Set up request.
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+
+	// Authorize the request ctx as though it
+	// had passed through API auth handlers.
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	target := "http://localhost:8080/api/v1/import/" + importID
+	ctx.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	ctx.Request.Header.Set("Accept", "application/json")
+	ctx.AddParam(importdata.IDKey, importID)
+
+	// Trigger handler.
+	suite.importModule.ImportGETHandler(ctx)
+
+	if code := recorder.Code; code != http.StatusOK {
+		b, err := io.ReadAll(recorder.Body)
+		if err != nil {
+			panic(err)
+		}
+		suite.FailNow("", "expected 200, got %d: %s", code, string(b))
+	}
+
+	imp := new(apimodel.Import)
+	if err := json.NewDecoder(recorder.Body).Decode(imp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return imp
+}
+
+// WaitForImport waits for the import with
+// the given ID to finish, and returns it.
+func (suite *ImportTestSuite) WaitForImport(importID string) *apimodel.Import {
+	var imp *apimodel.Import
+	if !testrig.WaitFor(func() bool {
+		imp = suite.GetImport(importID)
+		return imp.State == "finished"
+	}) {
+		suite.FailNow("timed out waiting for import to finish")
+	}
+
+	return imp
 }
 
 func (suite *ImportTestSuite) TearDownTest() {
@@ -205,6 +277,351 @@ func (suite *ImportTestSuite) TestImportFollows() {
 	}
 }
 
+func (suite *ImportTestSuite) TestImportLists() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		testList    = suite.testLists["local_account_1_list_1"]
+	)
+
+	// Keep admin in zork's existing list (dropping
+	// turtle), and put turtle in a new list. Zork
+	// doesn't follow foss_satan, so that should fail.
+	data := `Cool Ass Posters From This Instance,admin@localhost:8080
+Mutuals,1happyturtle@localhost:8080
+Mutuals,foss_satan@fossbros-anonymous.io
+`
+
+	// Trigger the import handler.
+	imp := suite.TriggerHandler(data, "lists", "overwrite")
+	suite.Equal("lists", imp.Type)
+	suite.Equal("overwrite", imp.Mode)
+	suite.Equal(3, imp.TotalItems)
+
+	// Wait for all rows to be processed.
+	imp = suite.WaitForImport(imp.ID)
+	suite.Equal(3, imp.ProcessedItems)
+	suite.Equal(1, imp.FailedItems)
+	suite.Equal([]string{
+		"foss_satan@fossbros-anonymous.io: account not followed, so cannot be added to list Mutuals",
+	}, imp.Errors)
+
+	// Turtle should be gone from the existing list.
+	follows, err := suite.state.DB.GetFollowsInList(ctx, testList.ID, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(follows, 1)
+	suite.Equal(suite.testAccounts["admin_account"].ID, follows[0].TargetAccountID)
+
+	// And present in the new one.
+	lists, err := suite.state.DB.GetListsByAccountID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(lists, 2)
+
+	for _, list := range lists {
+		if list.Title != "Mutuals" {
+			continue
+		}
+
+		follows, err := suite.state.DB.GetFollowsInList(ctx, list.ID, nil)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Len(follows, 1)
+		suite.Equal(suite.testAccounts["local_account_2"].ID, follows[0].TargetAccountID)
+	}
+}
+
+func (suite *ImportTestSuite) TestImportMutes() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		targetAcct  = suite.testAccounts["admin_account"]
+	)
+
+	data := `Account address,Hide notifications
+admin@localhost:8080,false
+`
+
+	// Trigger the import handler.
+	imp := suite.TriggerHandler(data, "mutes", "merge")
+
+	// Wait for all rows to be processed.
+	imp = suite.WaitForImport(imp.ID)
+	suite.Equal(1, imp.ProcessedItems)
+	suite.Zero(imp.FailedItems)
+	suite.Empty(imp.Errors)
+
+	// Zork should now have admin muted,
+	// but still get notifications.
+	mute, err := suite.state.DB.GetMute(ctx, testAccount.ID, targetAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*mute.Notifications)
+}
+
+func (suite *ImportTestSuite) TestImportDomainBlocksChunked() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		data        strings.Builder
+	)
+
+	// More rows than fit in one
+	// chunk, so they're processed
+	// by several worker tasks.
+	const total = 120
+	for i := range total {
+		fmt.Fprintf(&data, "blocked-%d.example.org\n", i)
+	}
+
+	// Trigger the import handler.
+	imp := suite.TriggerHandler(data.String(), "domain_blocks", "merge")
+	suite.Equal(total, imp.TotalItems)
+
+	// Wait for all rows to be processed.
+	imp = suite.WaitForImport(imp.ID)
+	suite.Equal(total, imp.ProcessedItems)
+	suite.Zero(imp.FailedItems)
+	suite.Empty(imp.Errors)
+
+	for _, domain := range []string{
+		"blocked-0.example.org",
+		"blocked-119.example.org",
+	} {
+		blocked, err := suite.state.DB.IsAccountDomainBlocked(ctx, testAccount.ID, domain)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.True(blocked)
+	}
+}
+
+func (suite *ImportTestSuite) TestImportBookmarks() {
+	var (
+		ctx          = context.Background()
+		testAccount  = suite.testAccounts["local_account_1"]
+		testBookmark = suite.testBookmarks["local_account_1_admin_account_status_1"]
+		testStatus   = suite.testStatuses["local_account_2_status_1"]
+	)
+
+	data := testStatus.URI + "\n"
+
+	// Trigger the import handler.
+	imp := suite.TriggerHandler(data, "bookmarks", "overwrite")
+
+	// Wait for all rows to be processed.
+	imp = suite.WaitForImport(imp.ID)
+	suite.Equal(1, imp.ProcessedItems)
+	suite.Zero(imp.FailedItems)
+
+	// Zork should now have turtle's
+	// status bookmarked instead of
+	// the existing admin status.
+	bookmark, err := suite.state.DB.GetStatusBookmark(ctx, testAccount.ID, testStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(testStatus.ID, bookmark.StatusID)
+
+	_, err = suite.state.DB.GetStatusBookmarkByID(ctx, testBookmark.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *ImportTestSuite) TestImportStatuses() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	outbox := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "outbox.json",
+  "type": "OrderedCollection",
+  "totalItems": 4,
+  "orderedItems": [
+    {
+      "id": "https://example.org/users/someone/statuses/2/activity",
+      "type": "Create",
+      "actor": "https://example.org/users/someone",
+      "published": "2023-06-02T10:00:00Z",
+      "object": {
+        "id": "https://example.org/users/someone/statuses/2",
+        "type": "Note",
+        "published": "2023-06-02T10:00:00Z",
+        "inReplyTo": "https://example.org/users/someone/statuses/1",
+        "to": ["https://example.org/users/someone/followers"],
+        "cc": ["https://www.w3.org/ns/activitystreams#Public"],
+        "content": "<p>replying to myself</p><script>alert(1)</script>"
+      }
+    },
+    {
+      "id": "https://example.org/users/someone/statuses/1/activity",
+      "type": "Create",
+      "actor": "https://example.org/users/someone",
+      "published": "2023-06-01T10:00:00Z",
+      "object": {
+        "id": "https://example.org/users/someone/statuses/1",
+        "type": "Note",
+        "published": "2023-06-01T10:00:00Z",
+        "to": ["https://www.w3.org/ns/activitystreams#Public"],
+        "cc": ["https://example.org/users/someone/followers"],
+        "summary": "a turnip",
+        "sensitive": true,
+        "contentMap": {"en": "<p>look at this turnip</p>"},
+        "attachment": [
+          {
+            "type": "Document",
+            "mediaType": "image/jpeg",
+            "url": "/media_attachments/files/000/000/001/original/turnip.jpg",
+            "name": "a very large turnip"
+          }
+        ]
+      }
+    },
+    {
+      "id": "https://example.org/users/someone/statuses/3/activity",
+      "type": "Create",
+      "actor": "https://example.org/users/someone",
+      "published": "2023-06-03T10:00:00Z",
+      "object": {
+        "id": "https://example.org/users/someone/statuses/3",
+        "type": "Note",
+        "published": "2023-06-03T10:00:00Z",
+        "to": ["https://example.org/users/someone/followers"],
+        "content": "<p>followers only</p>"
+      }
+    },
+    {
+      "id": "https://example.org/users/someone/statuses/4/activity",
+      "type": "Announce",
+      "actor": "https://example.org/users/someone",
+      "published": "2023-06-04T10:00:00Z",
+      "to": ["https://www.w3.org/ns/activitystreams#Public"],
+      "object": "https://example.org/users/someoneelse/statuses/1"
+    }
+  ]
+}`
+
+	turnip, err := os.ReadFile("../../../../testrig/media/giant-turnip-world-record.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Zip up the outbox and attachment.
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, data := range map[string][]byte{
+		"outbox.json": []byte(outbox),
+		"media_attachments/files/000/000/001/original/turnip.jpg": turnip,
+	} {
+		f, err := zw.Create(name)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		if _, err := f.Write(data); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+	if err := zw.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Note existing statuses, so as
+	// to find the imported ones after.
+	existing, err := suite.state.DB.GetAccountStatuses(ctx, testAccount.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	existingIDs := make(map[string]bool, len(existing))
+	for _, status := range existing {
+		existingIDs[status.ID] = true
+	}
+
+	// Trigger the import handler.
+	imp := suite.TriggerHandler(buf.String(), "statuses", "merge")
+
+	// Only the public and unlisted
+	// statuses should be imported.
+	imp = suite.WaitForImport(imp.ID)
+	suite.Equal(2, imp.TotalItems)
+	suite.Equal(2, imp.ProcessedItems)
+	suite.Zero(imp.FailedItems, imp.Errors)
+
+	all, err := suite.state.DB.GetAccountStatuses(ctx, testAccount.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Statuses are returned newest first.
+	var imported []*gtsmodel.Status
+	for _, status := range all {
+		if !existingIDs[status.ID] {
+			imported = append(imported, status)
+		}
+	}
+	if !suite.Len(imported, 2) {
+		suite.FailNow("")
+	}
+	reply, parent := imported[0], imported[1]
+
+	// Original timestamps, content,
+	// and attachment should be kept.
+	suite.True(parent.IsLocal())
+	suite.Equal(gtsmodel.VisibilityPublic, parent.Visibility)
+	suite.Equal("2023-06-01T10:00:00Z", parent.CreatedAt.UTC().Format(time.RFC3339))
+	suite.Equal("<p>look at this turnip</p>", parent.Content)
+	suite.Equal("en", parent.Language)
+	suite.Equal("a turnip", parent.ContentWarning)
+	suite.True(*parent.Sensitive)
+	suite.Equal(testAccount.URI+"/statuses/"+parent.ID, parent.URI)
+	suite.Len(parent.AttachmentIDs, 1)
+
+	attachment, err := suite.state.DB.GetAttachmentByID(ctx, parent.AttachmentIDs[0])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(parent.ID, attachment.StatusID)
+	suite.Equal("a very large turnip", attachment.Description)
+
+	// Reply should be threaded onto the
+	// new parent, with content sanitized.
+	suite.Equal(gtsmodel.VisibilityUnlocked, reply.Visibility)
+	suite.Equal("2023-06-02T10:00:00Z", reply.CreatedAt.UTC().Format(time.RFC3339))
+	suite.Equal("<p>replying to myself</p>", reply.Content)
+	suite.Equal(parent.ID, reply.InReplyToID)
+	suite.Equal(parent.URI, reply.InReplyToURI)
+	suite.Equal(parent.ThreadID, reply.ThreadID)
+
+	// Imported media is in use, so
+	// it should survive the cleaner.
+	if _, err := cleaner.New(&suite.state).Media().PruneUnused(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+	attachment, err = suite.state.DB.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(parent.ID, attachment.StatusID)
+
+	// Importing the same archive again
+	// shouldn't create duplicate statuses.
+	imp = suite.TriggerHandler(buf.String(), "statuses", "merge")
+	imp = suite.WaitForImport(imp.ID)
+	suite.Equal(2, imp.ProcessedItems)
+	suite.Zero(imp.FailedItems, imp.Errors)
+
+	again, err := suite.state.DB.GetAccountStatuses(ctx, testAccount.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(again, len(all))
+}
+
 func TestImportTestSuite(t *testing.T) {
 	suite.Run(t, new(ImportTestSuite))
 }
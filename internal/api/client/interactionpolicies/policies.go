@@ -21,6 +21,8 @@
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -40,6 +42,6 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodGet, DefaultsPath, m.PoliciesDefaultsGETHandler)
-	attachHandler(http.MethodPatch, DefaultsPath, m.PoliciesDefaultsPATCHHandler)
+	attachHandler(http.MethodGet, DefaultsPath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.PoliciesDefaultsGETHandler)
+	attachHandler(http.MethodPatch, DefaultsPath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.PoliciesDefaultsPATCHHandler)
 }
@@ -22,6 +22,8 @@
 
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -43,8 +45,8 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodGet, BasePath, m.InteractionRequestsGETHandler)
-	attachHandler(http.MethodGet, BasePathWithID, m.InteractionRequestGETHandler)
-	attachHandler(http.MethodPost, AuthorizePath, m.InteractionRequestAuthorizePOSTHandler)
-	attachHandler(http.MethodPost, RejectPath, m.InteractionRequestRejectPOSTHandler)
+	attachHandler(http.MethodGet, BasePath, middleware.ScopeCheck(oauth.ScopeReadNotifications), m.InteractionRequestsGETHandler)
+	attachHandler(http.MethodGet, BasePathWithID, middleware.ScopeCheck(oauth.ScopeReadNotifications), m.InteractionRequestGETHandler)
+	attachHandler(http.MethodPost, AuthorizePath, middleware.ScopeCheck(oauth.ScopeWriteStatuses), m.InteractionRequestAuthorizePOSTHandler)
+	attachHandler(http.MethodPost, RejectPath, middleware.ScopeCheck(oauth.ScopeWriteStatuses), m.InteractionRequestRejectPOSTHandler)
 }
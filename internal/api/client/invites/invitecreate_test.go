@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package invites_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/invites"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+// createInvite creates an invite
+// with the given form values.
+func (suite *InvitesTestSuite) createInvite(
+	accountKey string,
+	form string,
+	expectedHTTPStatus int,
+) string {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(
+		recorder,
+		accountKey,
+		http.MethodPost,
+		[]byte(form),
+		"api"+invites.BasePath,
+		"application/x-www-form-urlencoded",
+	)
+
+	suite.invitesModule.InvitePOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(expectedHTTPStatus, result.StatusCode)
+	return string(b)
+}
+
+func (suite *InvitesTestSuite) TestCreateInvite() {
+	resp := suite.createInvite("admin_account", "max_uses=10&expires_in=86400&auto_approve=true", http.StatusOK)
+
+	invite := new(apimodel.Invite)
+	if err := json.Unmarshal([]byte(resp), invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(10, *invite.MaxUses)
+	suite.NotNil(invite.ExpiresAt)
+	suite.True(invite.AutoApprove)
+	suite.Zero(invite.Uses)
+	suite.True(strings.HasSuffix(invite.URL, "/signup?invite="+invite.Code))
+
+	dbInvite, err := suite.db.GetInviteByID(context.Background(), invite.ID)
+	suite.NoError(err)
+	suite.Equal(suite.testAccounts["admin_account"].ID, dbInvite.AccountID)
+}
+
+// Only mods and admins can create
+// invites with the default config.
+func (suite *InvitesTestSuite) TestCreateInviteNotPermitted() {
+	resp := suite.createInvite("local_account_1", "", http.StatusForbidden)
+	suite.Equal(`{"error":"Forbidden: you are not permitted to create invites on this instance"}`, resp)
+}
@@ -0,0 +1,364 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package notifications_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/notifications"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+// addGroupableNotifications adds notifications for local_account_1, newest last:
+// three faves of one status, a follow, and a follow request in between them.
+func (suite *NotificationsTestSuite) addGroupableNotifications() []*gtsmodel.Notification {
+	var (
+		// Put all the notifications in the
+		// same 12 hour span, a second apart.
+		start     = time.Now().Truncate(12 * time.Hour).Add(time.Hour)
+		target    = suite.testAccounts["local_account_1"]
+		statusID  = suite.testStatuses["local_account_1_status_1"].ID
+		local2    = suite.testAccounts["local_account_2"]
+		remote1   = suite.testAccounts["remote_account_1"]
+		remote2   = suite.testAccounts["remote_account_2"]
+		newNotifs = []*gtsmodel.Notification{
+			{NotificationType: gtsmodel.NotificationFave, OriginAccountID: local2.ID, StatusID: statusID},
+			{NotificationType: gtsmodel.NotificationFollow, OriginAccountID: remote1.ID},
+			{NotificationType: gtsmodel.NotificationFave, OriginAccountID: remote1.ID, StatusID: statusID},
+			{NotificationType: gtsmodel.NotificationFollowRequest, OriginAccountID: remote2.ID},
+			{NotificationType: gtsmodel.NotificationFave, OriginAccountID: remote2.ID, StatusID: statusID},
+		}
+	)
+
+	for i, n := range newNotifs {
+		n.CreatedAt = start.Add(time.Duration(i) * time.Second)
+		n.ID, _ = id.NewULIDFromTime(n.CreatedAt)
+		n.TargetAccountID = target.ID
+		if err := suite.db.PutNotification(context.Background(), n); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	return newNotifs
+}
+
+// groupKeySlot returns the time slot used
+// in group keys for the given time.
+func groupKeySlot(t time.Time) string {
+	return strconv.FormatInt(t.Unix()/int64(12*time.Hour/time.Second), 10)
+}
+
+// requestNotifGroups calls the given handler as local_account_1
+// with the given query and group key param, and returns the
+// response recorder after checking the status code.
+func (suite *NotificationsTestSuite) requestNotifGroups(
+	handler gin.HandlerFunc,
+	method string,
+	path string,
+	query string,
+	groupKey string,
+	expectedHTTPStatus int,
+) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	ctx.Request = httptest.NewRequest(method, config.GetProtocol()+"://"+config.GetHost()+"/api"+path+"?"+query, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	if groupKey != "" {
+		ctx.AddParam(notifications.GroupKeyKey, groupKey)
+	}
+
+	handler(ctx)
+
+	suite.Equal(expectedHTTPStatus, recorder.Code, recorder.Body.String())
+	return recorder
+}
+
+func (suite *NotificationsTestSuite) getNotifGroups(query string) (*apimodel.GroupedNotificationsResults, string) {
+	recorder := suite.requestNotifGroups(
+		suite.notificationsModule.NotificationGroupsGETHandler,
+		http.MethodGet, notifications.BasePathV2, query, "",
+		http.StatusOK,
+	)
+
+	resp := new(apimodel.GroupedNotificationsResults)
+	if err := json.Unmarshal(recorder.Body.Bytes(), resp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return resp, recorder.Header().Get("Link")
+}
+
+func (suite *NotificationsTestSuite) TestGetNotificationGroups() {
+	newNotifs := suite.addGroupableNotifications()
+	var (
+		slot     = groupKeySlot(newNotifs[0].CreatedAt)
+		statusID = suite.testStatuses["local_account_1_status_1"].ID
+		oldFave  = suite.testNotifications["local_account_1_like"]
+	)
+
+	resp, _ := suite.getNotifGroups("")
+	suite.Len(resp.NotificationGroups, 4)
+
+	// Newest group should be the three faves.
+	faves := resp.NotificationGroups[0]
+	suite.Equal("favourite-"+statusID+"-"+slot, faves.GroupKey)
+	suite.Equal("favourite", faves.Type)
+	suite.Equal(3, faves.NotificationsCount)
+	suite.Equal(newNotifs[4].ID, faves.MostRecentNotificationID)
+	suite.Equal(newNotifs[4].ID, faves.PageMaxID)
+	suite.Equal(newNotifs[0].ID, faves.PageMinID)
+	suite.NotEmpty(faves.LatestPageNotificationAt)
+	suite.Equal(statusID, faves.StatusID)
+	suite.Equal([]string{
+		newNotifs[4].OriginAccountID,
+		newNotifs[2].OriginAccountID,
+		newNotifs[0].OriginAccountID,
+	}, faves.SampleAccountIDs)
+
+	// Then the follow request on its own.
+	suite.Equal("ungrouped-"+newNotifs[3].ID, resp.NotificationGroups[1].GroupKey)
+	suite.Equal(1, resp.NotificationGroups[1].NotificationsCount)
+
+	// Then the follow.
+	suite.Equal("follow-"+slot, resp.NotificationGroups[2].GroupKey)
+	suite.Empty(resp.NotificationGroups[2].StatusID)
+
+	// Then the older fave of the same
+	// status, which is in another group.
+	suite.Equal("favourite-"+statusID+"-"+groupKeySlot(oldFave.CreatedAt), resp.NotificationGroups[3].GroupKey)
+	suite.Equal(oldFave.ID, resp.NotificationGroups[3].MostRecentNotificationID)
+
+	// Accounts and statuses should be deduplicated.
+	suite.Len(resp.Accounts, 4)
+	suite.Len(resp.Statuses, 1)
+	suite.Equal(statusID, resp.Statuses[0].ID)
+
+	// Without grouping, every notification has its own group.
+	resp, _ = suite.getNotifGroups("grouped_types[]=mention")
+	suite.Len(resp.NotificationGroups, 4)
+	resp, _ = suite.getNotifGroups("grouped_types[]=follow")
+	suite.Len(resp.NotificationGroups, 6)
+}
+
+func (suite *NotificationsTestSuite) TestGetNotificationGroupsPaged() {
+	newNotifs := suite.addGroupableNotifications()
+	statusID := suite.testStatuses["local_account_1_status_1"].ID
+
+	// First page should cover the newest
+	// faves and the follow request.
+	resp, linkHeader := suite.getNotifGroups("limit=2")
+	suite.Len(resp.NotificationGroups, 2)
+	suite.Equal(2, resp.NotificationGroups[0].NotificationsCount)
+	suite.Equal(newNotifs[2].ID, resp.NotificationGroups[0].PageMinID)
+	suite.Equal(
+		`<http://localhost:8080/api/v2/notifications?limit=2&max_id=`+newNotifs[2].ID+`>; rel="next", `+
+			`<http://localhost:8080/api/v2/notifications?limit=2&min_id=`+newNotifs[4].ID+`>; rel="prev"`,
+		linkHeader,
+	)
+
+	// Next page should carry on with the follow,
+	// and the rest of the same fave group.
+	resp, _ = suite.getNotifGroups("limit=2&max_id=" + newNotifs[2].ID)
+	suite.Len(resp.NotificationGroups, 2)
+	suite.Equal("follow-"+groupKeySlot(newNotifs[1].CreatedAt), resp.NotificationGroups[0].GroupKey)
+	suite.Equal("favourite-"+statusID+"-"+groupKeySlot(newNotifs[0].CreatedAt), resp.NotificationGroups[1].GroupKey)
+	suite.Equal(1, resp.NotificationGroups[1].NotificationsCount)
+	suite.Equal(newNotifs[0].ID, resp.NotificationGroups[1].PageMaxID)
+
+	// Paging up from the oldest should cover the
+	// oldest two new faves and the follow between.
+	resp, _ = suite.getNotifGroups("limit=2&min_id=" + suite.testNotifications["local_account_1_like"].ID)
+	suite.Len(resp.NotificationGroups, 2)
+	suite.Equal("favourite-"+statusID+"-"+groupKeySlot(newNotifs[0].CreatedAt), resp.NotificationGroups[0].GroupKey)
+	suite.Equal(2, resp.NotificationGroups[0].NotificationsCount)
+	suite.Equal(newNotifs[2].ID, resp.NotificationGroups[0].PageMaxID)
+	suite.Equal(newNotifs[0].ID, resp.NotificationGroups[0].PageMinID)
+	suite.Equal("follow-"+groupKeySlot(newNotifs[1].CreatedAt), resp.NotificationGroups[1].GroupKey)
+}
+
+func (suite *NotificationsTestSuite) TestGetNotificationGroup() {
+	newNotifs := suite.addGroupableNotifications()
+	groupKey := "favourite-" + suite.testStatuses["local_account_1_status_1"].ID + "-" + groupKeySlot(newNotifs[0].CreatedAt)
+
+	recorder := suite.requestNotifGroups(
+		suite.notificationsModule.NotificationGroupGETHandler,
+		http.MethodGet, notifications.BasePathV2+"/"+groupKey, "", groupKey,
+		http.StatusOK,
+	)
+
+	resp := new(apimodel.GroupedNotificationsResults)
+	if err := json.Unmarshal(recorder.Body.Bytes(), resp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(resp.NotificationGroups, 1)
+	group := resp.NotificationGroups[0]
+	suite.Equal(groupKey, group.GroupKey)
+	suite.Equal(3, group.NotificationsCount)
+	suite.Equal(newNotifs[4].ID, group.MostRecentNotificationID)
+	suite.Empty(group.PageMinID)
+	suite.Empty(group.PageMaxID)
+	suite.Len(resp.Accounts, 3)
+	suite.Len(resp.Statuses, 1)
+
+	// Accounts of the group, newest first.
+	recorder = suite.requestNotifGroups(
+		suite.notificationsModule.NotificationGroupAccountsGETHandler,
+		http.MethodGet, notifications.BasePathV2+"/"+groupKey+"/accounts", "", groupKey,
+		http.StatusOK,
+	)
+
+	accounts := make([]*apimodel.Account, 0)
+	if err := json.Unmarshal(recorder.Body.Bytes(), &accounts); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(accounts, 3)
+	suite.Equal(newNotifs[4].OriginAccountID, accounts[0].ID)
+
+	// Ungrouped notifications can be got by key too.
+	ungroupedKey := "ungrouped-" + newNotifs[3].ID
+	suite.requestNotifGroups(
+		suite.notificationsModule.NotificationGroupGETHandler,
+		http.MethodGet, notifications.BasePathV2+"/"+ungroupedKey, "", ungroupedKey,
+		http.StatusOK,
+	)
+
+	// But not other accounts' notifications.
+	otherKey := "ungrouped-" + suite.testNotifications["local_account_2_like"].ID
+	suite.requestNotifGroups(
+		suite.notificationsModule.NotificationGroupGETHandler,
+		http.MethodGet, notifications.BasePathV2+"/"+otherKey, "", otherKey,
+		http.StatusNotFound,
+	)
+
+	// And junk keys aren't found.
+	suite.requestNotifGroups(
+		suite.notificationsModule.NotificationGroupGETHandler,
+		http.MethodGet, notifications.BasePathV2+"/favourite-nope", "", "favourite-nope",
+		http.StatusNotFound,
+	)
+}
+
+func (suite *NotificationsTestSuite) TestDismissNotificationGroup() {
+	newNotifs := suite.addGroupableNotifications()
+	groupKey := "favourite-" + suite.testStatuses["local_account_1_status_1"].ID + "-" + groupKeySlot(newNotifs[0].CreatedAt)
+
+	suite.requestNotifGroups(
+		suite.notificationsModule.NotificationGroupDismissPOSTHandler,
+		http.MethodPost, notifications.BasePathV2+"/"+groupKey+"/dismiss", "", groupKey,
+		http.StatusOK,
+	)
+
+	// The faves in the group should
+	// be gone, the other notifs not.
+	for _, n := range newNotifs {
+		_, err := suite.db.GetNotificationByID(context.Background(), n.ID)
+		if n.NotificationType == gtsmodel.NotificationFave {
+			suite.Error(err)
+		} else {
+			suite.NoError(err)
+		}
+	}
+
+	// The group should be gone too.
+	suite.requestNotifGroups(
+		suite.notificationsModule.NotificationGroupGETHandler,
+		http.MethodGet, notifications.BasePathV2+"/"+groupKey, "", groupKey,
+		http.StatusNotFound,
+	)
+}
+
+func (suite *NotificationsTestSuite) TestNotificationsUnreadCount() {
+	suite.addGroupableNotifications()
+
+	for _, test := range []struct {
+		handler gin.HandlerFunc
+		path    string
+		query   string
+		count   int
+	}{
+		// Only new notifications are after the marker.
+		{suite.notificationsModule.NotificationsUnreadCountGETHandler, notifications.BasePathWithUnreadCount, "", 5},
+		{suite.notificationsModule.NotificationsUnreadCountGETHandler, notifications.BasePathWithUnreadCount, "limit=2", 2},
+		{suite.notificationsModule.NotificationsUnreadCountGETHandler, notifications.BasePathWithUnreadCount, "types[]=favourite", 3},
+		// Faves, follow request, follow.
+		{suite.notificationsModule.NotificationGroupsUnreadCountGETHandler, notifications.BasePathV2WithUnreadCount, "", 3},
+		{suite.notificationsModule.NotificationGroupsUnreadCountGETHandler, notifications.BasePathV2WithUnreadCount, "grouped_types[]=follow", 5},
+	} {
+		recorder := suite.requestNotifGroups(
+			test.handler,
+			http.MethodGet, test.path, test.query, "",
+			http.StatusOK,
+		)
+		suite.Equal(`{"count":`+strconv.Itoa(test.count)+`}`, recorder.Body.String(), test.query)
+	}
+}
+
+func (suite *NotificationsTestSuite) TestNotificationsUnreadCountBlocked() {
+	suite.addGroupableNotifications()
+
+	// Block remote_account_2, so their follow
+	// request and fave are no longer visible.
+	if err := suite.db.PutBlock(
+		context.Background(),
+		&gtsmodel.Block{
+			ID:              id.NewULID(),
+			URI:             "https://example.org/nooooooo",
+			AccountID:       suite.testAccounts["local_account_1"].ID,
+			TargetAccountID: suite.testAccounts["remote_account_2"].ID,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, test := range []struct {
+		handler gin.HandlerFunc
+		path    string
+		query   string
+		count   int
+	}{
+		{suite.notificationsModule.NotificationsUnreadCountGETHandler, notifications.BasePathWithUnreadCount, "", 3},
+		{suite.notificationsModule.NotificationsUnreadCountGETHandler, notifications.BasePathWithUnreadCount, "limit=2", 2},
+		// Faves, follow.
+		{suite.notificationsModule.NotificationGroupsUnreadCountGETHandler, notifications.BasePathV2WithUnreadCount, "", 2},
+	} {
+		recorder := suite.requestNotifGroups(
+			test.handler,
+			http.MethodGet, test.path, test.query, "",
+			http.StatusOK,
+		)
+		suite.Equal(`{"count":`+strconv.Itoa(test.count)+`}`, recorder.Body.String(), test.query)
+	}
+}
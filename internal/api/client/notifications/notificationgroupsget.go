@@ -0,0 +1,203 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// NotificationGroupsGETHandler swagger:operation GET /api/v2/notifications notificationGroups
+//
+// Get grouped notifications for currently authorized user.
+//
+// Notifications of the same groupable type (favourites and boosts of one status, or follows)
+// that happened within 12 hours of each other are returned together as one group, with a
+// count and sample of the accounts involved. Other notifications get a group of their own.
+//
+// The groups will be returned in descending chronological order (newest first) of their
+// most recent notification. The limit is the maximum number of groups to return.
+//
+// The next and previous queries can be parsed from the returned Link header.
+// Example:
+//
+// ```
+// <https://example.org/api/v2/notifications?limit=40&max_id=01FC0SKA48HNSVR6YKZCQGS2V8>; rel="next", <https://example.org/api/v2/notifications?limit=40&min_id=01FC0SKW5JK2Q4EVAV2B462YY0>; rel="prev"
+// ````
+//
+//	---
+//	tags:
+//	- notifications
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: max_id
+//		type: string
+//		description: >-
+//			Return only notification groups with notifications *OLDER* than the given max notification ID.
+//			The notification with the specified ID will not be included in the response.
+//		in: query
+//		required: false
+//	-
+//		name: since_id
+//		type: string
+//		description: >-
+//			Return only notification groups with notifications *newer* than the given since notification ID.
+//			The notification with the specified ID will not be included in the response.
+//		in: query
+//	-
+//		name: min_id
+//		type: string
+//		description: >-
+//			Return only notification groups with notifications *immediately newer* than the given since notification ID.
+//			The notification with the specified ID will not be included in the response.
+//		in: query
+//		required: false
+//	-
+//		name: limit
+//		type: integer
+//		description: Number of notification groups to return.
+//		default: 40
+//		minimum: 1
+//		maximum: 80
+//		in: query
+//		required: false
+//	-
+//		name: types[]
+//		type: array
+//		items:
+//			type: string
+//			enum:
+//				- follow
+//				- follow_request
+//				- mention
+//				- reblog
+//				- favourite
+//				- poll
+//				- status
+//				- admin.sign_up
+//				- quote
+//				- pending.quote
+//		description: Types of notifications to include. If not provided, all notification types will be included.
+//		in: query
+//		required: false
+//	-
+//		name: exclude_types[]
+//		type: array
+//		items:
+//			type: string
+//			enum:
+//				- follow
+//				- follow_request
+//				- mention
+//				- reblog
+//				- favourite
+//				- poll
+//				- status
+//				- admin.sign_up
+//				- quote
+//				- pending.quote
+//		description: Types of notifications to exclude.
+//		in: query
+//		required: false
+//	-
+//		name: grouped_types[]
+//		type: array
+//		items:
+//			type: string
+//			enum:
+//				- favourite
+//				- follow
+//				- reblog
+//		description: Types of notifications to group. If not provided, all groupable types will be grouped.
+//		in: query
+//		required: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:notifications
+//
+//	responses:
+//		'200':
+//			headers:
+//				Link:
+//					type: string
+//					description: Links to the next and previous queries.
+//			name: notifications
+//			description: Grouped notifications.
+//			schema:
+//				"$ref": "#/definitions/groupedNotificationsResults"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) NotificationGroupsGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	page, errWithCode := paging.ParseIDPage(c,
+		1,  // min limit
+		80, // max limit
+		40, // default limit
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, linkHeader, errWithCode := m.processor.Timeline().NotificationGroupsGet(
+		c.Request.Context(),
+		authed,
+		page,
+		c.QueryArray(TypesKey),
+		c.QueryArray(ExcludeTypesKey),
+		c.QueryArray(GroupedTypesKey),
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if linkHeader != "" {
+		c.Header("Link", linkHeader)
+	}
+
+	apiutil.JSON(c, http.StatusOK, resp)
+}
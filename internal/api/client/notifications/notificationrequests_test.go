@@ -0,0 +1,264 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package notifications_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/notifications"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+// addFilteredNotification adds a filtered fave of local_account_1's status
+// by the given account, and a notification request containing it.
+func (suite *NotificationsTestSuite) addFilteredNotification(from *gtsmodel.Account) (*gtsmodel.Notification, *gtsmodel.NotificationRequest) {
+	var (
+		ctx    = context.Background()
+		target = suite.testAccounts["local_account_1"]
+		status = suite.testStatuses["local_account_1_status_1"]
+	)
+
+	notif := &gtsmodel.Notification{
+		ID:               id.NewULID(),
+		NotificationType: gtsmodel.NotificationFave,
+		TargetAccountID:  target.ID,
+		OriginAccountID:  from.ID,
+		StatusID:         status.ID,
+		Filtered:         util.Ptr(true),
+	}
+	if err := suite.db.PutNotification(ctx, notif); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	req := &gtsmodel.NotificationRequest{
+		ID:                 id.NewULID(),
+		AccountID:          target.ID,
+		FromAccountID:      from.ID,
+		LastStatusID:       status.ID,
+		NotificationsCount: 1,
+	}
+	if err := suite.db.PutNotificationRequest(ctx, req); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return notif, req
+}
+
+// requestNotifPolicy calls the given handler as local_account_1
+// with the given form body and request ID param, and returns the
+// response body after checking the status code.
+func (suite *NotificationsTestSuite) requestNotifPolicy(
+	handler gin.HandlerFunc,
+	method string,
+	path string,
+	form url.Values,
+	reqID string,
+	expectedHTTPStatus int,
+) []byte {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	ctx.Request = httptest.NewRequest(method, config.GetProtocol()+"://"+config.GetHost()+"/api"+path, strings.NewReader(form.Encode()))
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Header.Set("content-type", "application/x-www-form-urlencoded")
+	if reqID != "" {
+		ctx.AddParam(notifications.IDKey, reqID)
+	}
+
+	handler(ctx)
+
+	suite.Equal(expectedHTTPStatus, recorder.Code, recorder.Body.String())
+	return recorder.Body.Bytes()
+}
+
+func (suite *NotificationsTestSuite) getNotifPolicy() *apimodel.NotificationPolicy {
+	b := suite.requestNotifPolicy(
+		suite.notificationsModule.NotificationPolicyGETHandler,
+		http.MethodGet, notifications.BasePathV2WithPolicy, nil, "",
+		http.StatusOK,
+	)
+
+	policy := new(apimodel.NotificationPolicy)
+	if err := json.Unmarshal(b, policy); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return policy
+}
+
+func (suite *NotificationsTestSuite) TestNotificationPolicyUpdate() {
+	// Default policy.
+	policy := suite.getNotifPolicy()
+	suite.Equal(&apimodel.NotificationPolicy{
+		ForNotFollowing:    "accept",
+		ForNotFollowers:    "accept",
+		ForNewAccounts:     "accept",
+		ForPrivateMentions: "filter",
+		ForLimitedAccounts: "filter",
+	}, policy)
+
+	// Invalid values are rejected.
+	suite.requestNotifPolicy(
+		suite.notificationsModule.NotificationPolicyPATCHHandler,
+		http.MethodPatch, notifications.BasePathV2WithPolicy,
+		url.Values{"for_new_accounts": {"ignore"}}, "",
+		http.StatusBadRequest,
+	)
+
+	// Update some of the policy.
+	b := suite.requestNotifPolicy(
+		suite.notificationsModule.NotificationPolicyPATCHHandler,
+		http.MethodPatch, notifications.BasePathV2WithPolicy,
+		url.Values{
+			"for_not_following":    {"filter"},
+			"for_private_mentions": {"drop"},
+		}, "",
+		http.StatusOK,
+	)
+
+	policy = new(apimodel.NotificationPolicy)
+	if err := json.Unmarshal(b, policy); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("filter", policy.ForNotFollowing)
+	suite.Equal("accept", policy.ForNotFollowers)
+	suite.Equal("drop", policy.ForPrivateMentions)
+
+	// Update is stored.
+	suite.Equal(policy, suite.getNotifPolicy())
+}
+
+func (suite *NotificationsTestSuite) TestNotificationRequestsAccept() {
+	var (
+		ctx            = context.Background()
+		target         = suite.testAccounts["local_account_1"]
+		from           = suite.testAccounts["remote_account_1"]
+		notif, request = suite.addFilteredNotification(from)
+	)
+
+	// Filtered notif is counted in the policy summary.
+	summary := suite.getNotifPolicy().Summary
+	suite.Equal(1, summary.PendingRequestsCount)
+	suite.Equal(1, summary.PendingNotificationsCount)
+
+	// Filtered notif isn't in the account's notifs.
+	notifs, err := suite.db.GetAccountNotifications(gtscontext.SetBarebones(ctx), target.ID, "", "", "", 0, nil, nil)
+	suite.NoError(err)
+	for _, n := range notifs {
+		suite.NotEqual(notif.ID, n.ID)
+	}
+
+	// Request is listed.
+	b := suite.requestNotifPolicy(
+		suite.notificationsModule.NotificationRequestsGETHandler,
+		http.MethodGet, notifications.BasePathWithRequests, nil, "",
+		http.StatusOK,
+	)
+
+	var reqs []*apimodel.NotificationRequest
+	if err := json.Unmarshal(b, &reqs); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(reqs, 1)
+	suite.Equal(request.ID, reqs[0].ID)
+	suite.Equal(from.ID, reqs[0].Account.ID)
+	suite.Equal("1", reqs[0].NotificationsCount)
+	suite.Equal(notif.StatusID, reqs[0].LastStatus.ID)
+
+	// Accept the request.
+	suite.requestNotifPolicy(
+		suite.notificationsModule.NotificationRequestAcceptPOSTHandler,
+		http.MethodPost, notifications.BasePathWithRequestIDAccept, nil, request.ID,
+		http.StatusOK,
+	)
+
+	// Accepted request can't be got anymore.
+	suite.requestNotifPolicy(
+		suite.notificationsModule.NotificationRequestGETHandler,
+		http.MethodGet, notifications.BasePathWithRequestID, nil, request.ID,
+		http.StatusNotFound,
+	)
+
+	// Notif is now in the account's notifs.
+	notifs, err = suite.db.GetAccountNotifications(gtscontext.SetBarebones(ctx), target.ID, "", "", "", 0, nil, nil)
+	suite.NoError(err)
+	suite.Equal(notif.ID, notifs[0].ID)
+	suite.False(*notifs[0].Filtered)
+
+	summary = suite.getNotifPolicy().Summary
+	suite.Zero(summary.PendingRequestsCount)
+	suite.Zero(summary.PendingNotificationsCount)
+}
+
+func (suite *NotificationsTestSuite) TestNotificationRequestsDismiss() {
+	var (
+		ctx       = context.Background()
+		notif1, _ = suite.addFilteredNotification(suite.testAccounts["remote_account_1"])
+		notif2, _ = suite.addFilteredNotification(suite.testAccounts["remote_account_2"])
+	)
+
+	reqs, err := suite.db.GetAccountNotificationRequests(ctx, suite.testAccounts["local_account_1"].ID, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(reqs, 2)
+
+	// Bulk dismiss needs IDs.
+	suite.requestNotifPolicy(
+		suite.notificationsModule.NotificationRequestsDismissPOSTHandler,
+		http.MethodPost, notifications.BasePathWithRequestsDismiss, nil, "",
+		http.StatusBadRequest,
+	)
+
+	// Dismiss both requests.
+	suite.requestNotifPolicy(
+		suite.notificationsModule.NotificationRequestsDismissPOSTHandler,
+		http.MethodPost, notifications.BasePathWithRequestsDismiss,
+		url.Values{"id[]": {reqs[0].ID, reqs[1].ID}}, "",
+		http.StatusOK,
+	)
+
+	// Requests and their notifs are gone.
+	for _, req := range reqs {
+		_, err := suite.db.GetNotificationRequestByID(ctx, req.ID)
+		suite.ErrorIs(err, db.ErrNoEntries)
+	}
+
+	for _, notif := range []*gtsmodel.Notification{notif1, notif2} {
+		_, err := suite.db.GetNotificationByID(ctx, notif.ID)
+		suite.ErrorIs(err, db.ErrNoEntries)
+	}
+}
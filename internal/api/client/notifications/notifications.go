@@ -21,6 +21,8 @@
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -31,13 +33,34 @@
 	BasePath = "/v1/notifications"
 	// BasePathWithID is just the base path with the ID key in it.
 	// Use this anywhere you need to know the ID of the notification being queried.
-	BasePathWithID    = BasePath + "/:" + IDKey
-	BasePathWithClear = BasePath + "/clear"
+	BasePathWithID          = BasePath + "/:" + IDKey
+	BasePathWithClear       = BasePath + "/clear"
+	BasePathWithUnreadCount = BasePath + "/unread_count"
+
+	// GroupKeyKey is for notification group keys.
+	GroupKeyKey = "group_key"
+	// BasePathV2 is the base path for serving the grouped notifications API, minus the 'api' prefix.
+	BasePathV2                = "/v2/notifications"
+	BasePathV2WithGroupKey    = BasePathV2 + "/:" + GroupKeyKey
+	BasePathV2WithDismiss     = BasePathV2WithGroupKey + "/dismiss"
+	BasePathV2WithAccounts    = BasePathV2WithGroupKey + "/accounts"
+	BasePathV2WithUnreadCount = BasePathV2 + "/unread_count"
+	BasePathV2WithPolicy      = BasePathV2 + "/policy"
+
+	// BasePathWithRequests is the base path for serving notification requests.
+	BasePathWithRequests         = BasePath + "/requests"
+	BasePathWithRequestsAccept   = BasePathWithRequests + "/accept"
+	BasePathWithRequestsDismiss  = BasePathWithRequests + "/dismiss"
+	BasePathWithRequestID        = BasePathWithRequests + "/:" + IDKey
+	BasePathWithRequestIDAccept  = BasePathWithRequestID + "/accept"
+	BasePathWithRequestIDDismiss = BasePathWithRequestID + "/dismiss"
 
 	// TypesKey names an array param specifying notification types to include.
 	TypesKey = "types[]"
 	// ExcludeTypesKey names an array param specifying notification types to exclude.
 	ExcludeTypesKey = "exclude_types[]"
+	// GroupedTypesKey names an array param specifying notification types to group.
+	GroupedTypesKey = "grouped_types[]"
 	MaxIDKey        = "max_id"
 	LimitKey        = "limit"
 	SinceIDKey      = "since_id"
@@ -55,7 +78,25 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodGet, BasePath, m.NotificationsGETHandler)
-	attachHandler(http.MethodGet, BasePathWithID, m.NotificationGETHandler)
-	attachHandler(http.MethodPost, BasePathWithClear, m.NotificationsClearPOSTHandler)
+	attachHandler(http.MethodGet, BasePath, middleware.ScopeCheck(oauth.ScopeReadNotifications), m.NotificationsGETHandler)
+	attachHandler(http.MethodGet, BasePathWithID, middleware.ScopeCheck(oauth.ScopeReadNotifications), m.NotificationGETHandler)
+	attachHandler(http.MethodGet, BasePathWithUnreadCount, middleware.ScopeCheck(oauth.ScopeReadNotifications), m.NotificationsUnreadCountGETHandler)
+	attachHandler(http.MethodPost, BasePathWithClear, middleware.ScopeCheck(oauth.ScopeWriteNotifications), m.NotificationsClearPOSTHandler)
+
+	// Grouped notifications.
+	attachHandler(http.MethodGet, BasePathV2, middleware.ScopeCheck(oauth.ScopeReadNotifications), m.NotificationGroupsGETHandler)
+	attachHandler(http.MethodGet, BasePathV2WithGroupKey, middleware.ScopeCheck(oauth.ScopeReadNotifications), m.NotificationGroupGETHandler)
+	attachHandler(http.MethodGet, BasePathV2WithAccounts, middleware.ScopeCheck(oauth.ScopeReadNotifications), m.NotificationGroupAccountsGETHandler)
+	attachHandler(http.MethodPost, BasePathV2WithDismiss, middleware.ScopeCheck(oauth.ScopeWriteNotifications), m.NotificationGroupDismissPOSTHandler)
+	attachHandler(http.MethodGet, BasePathV2WithUnreadCount, middleware.ScopeCheck(oauth.ScopeReadNotifications), m.NotificationGroupsUnreadCountGETHandler)
+
+	// Notification policy and requests.
+	attachHandler(http.MethodGet, BasePathV2WithPolicy, middleware.ScopeCheck(oauth.ScopeReadNotifications), m.NotificationPolicyGETHandler)
+	attachHandler(http.MethodPatch, BasePathV2WithPolicy, middleware.ScopeCheck(oauth.ScopeWriteNotifications), m.NotificationPolicyPATCHHandler)
+	attachHandler(http.MethodGet, BasePathWithRequests, middleware.ScopeCheck(oauth.ScopeReadNotifications), m.NotificationRequestsGETHandler)
+	attachHandler(http.MethodGet, BasePathWithRequestID, middleware.ScopeCheck(oauth.ScopeReadNotifications), m.NotificationRequestGETHandler)
+	attachHandler(http.MethodPost, BasePathWithRequestIDAccept, middleware.ScopeCheck(oauth.ScopeWriteNotifications), m.NotificationRequestAcceptPOSTHandler)
+	attachHandler(http.MethodPost, BasePathWithRequestIDDismiss, middleware.ScopeCheck(oauth.ScopeWriteNotifications), m.NotificationRequestDismissPOSTHandler)
+	attachHandler(http.MethodPost, BasePathWithRequestsAccept, middleware.ScopeCheck(oauth.ScopeWriteNotifications), m.NotificationRequestsAcceptPOSTHandler)
+	attachHandler(http.MethodPost, BasePathWithRequestsDismiss, middleware.ScopeCheck(oauth.ScopeWriteNotifications), m.NotificationRequestsDismissPOSTHandler)
 }
@@ -93,6 +93,8 @@
 //				- poll
 //				- status
 //				- admin.sign_up
+//				- quote
+//				- pending.quote
 //		description: Types of notifications to include. If not provided, all notification types will be included.
 //		in: query
 //		required: false
@@ -110,6 +112,8 @@
 //				- poll
 //				- status
 //				- admin.sign_up
+//				- quote
+//				- pending.quote
 //		description: Types of notifications to exclude.
 //		in: query
 //		required: false
@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
+)
+
+// deleteSubscription deletes the push subscription for the named token.
+func (suite *PushTestSuite) deleteSubscription(tokenKey string, expectedHTTPStatus int) string {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, tokenKey, http.MethodDelete, nil, push.SubscriptionPath, "")
+
+	suite.pushModule.PushSubscriptionDELETEHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(expectedHTTPStatus, result.StatusCode)
+	return string(b)
+}
+
+// Delete a subscription, after which it should be gone.
+func (suite *PushTestSuite) TestDeleteSubscription() {
+	resp := suite.deleteSubscription("local_account_1", http.StatusOK)
+	suite.Equal(`{}`, resp)
+
+	suite.getSubscription("local_account_1", http.StatusNotFound)
+}
+
+// Deleting a subscription that doesn't exist is fine.
+func (suite *PushTestSuite) TestDeleteMissingSubscription() {
+	resp := suite.deleteSubscription("local_account_1_client_application_token", http.StatusOK)
+	suite.Equal(`{}`, resp)
+}
@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
+)
+
+// getSubscription gets the push subscription for the named token.
+func (suite *PushTestSuite) getSubscription(tokenKey string, expectedHTTPStatus int) string {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, tokenKey, http.MethodGet, nil, push.SubscriptionPath, "")
+
+	suite.pushModule.PushSubscriptionGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(expectedHTTPStatus, result.StatusCode)
+	return string(b)
+}
+
+// Get the subscription for a token that has one.
+func (suite *PushTestSuite) TestGetSubscription() {
+	resp := suite.getSubscription("local_account_1", http.StatusOK)
+	suite.Equal(`{"id":"01JCZ84PHSHE18T4DPDFH16RD7","endpoint":"https://push.example.org/send/01JCZ84PHSHE18T4DPDFH16RD7","server_key":"BOp8s8FC_In8M9fAPN9WpAUswyzJXb4AGjDXLSVx03fve4MexXiw9CQ64Jzs6vb4LzdIeXfjb-6ziWCDH3MvSV0","alerts":{"follow":false,"follow_request":false,"favourite":true,"mention":true,"reblog":false,"poll":false,"status":false,"admin.sign_up":false,"pending.favourite":false,"pending.reply":false,"pending.reblog":false,"quote":false,"pending.quote":false},"policy":"all"}`, resp)
+}
+
+// Get the subscription for a token that doesn't have one.
+func (suite *PushTestSuite) TestGetMissingSubscription() {
+	resp := suite.getSubscription("local_account_1_client_application_token", http.StatusNotFound)
+	suite.Equal(`{"error":"Not Found: no web push subscription exists for this access token"}`, resp)
+}
@@ -0,0 +1,202 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// PushSubscriptionPOSTHandler swagger:operation POST /api/v1/push/subscription pushSubscriptionPost
+//
+// Create a new Web Push subscription for the current access token, or replace the existing one.
+//
+//	---
+//	tags:
+//	- push
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: subscription[endpoint]
+//		in: formData
+//		type: string
+//		required: true
+//		description: The URL to which Web Push notifications will be sent. Must be an https URL.
+//	-
+//		name: subscription[keys][p256dh]
+//		in: formData
+//		type: string
+//		required: true
+//		description: User agent public key. Base64url encoded string of a public key from a ECDH keypair using the prime256v1 curve.
+//	-
+//		name: subscription[keys][auth]
+//		in: formData
+//		type: string
+//		required: true
+//		description: Auth secret. Base64url encoded string of 16 bytes of random data.
+//	-
+//		name: data[alerts][follow]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when someone has followed you?
+//	-
+//		name: data[alerts][follow_request]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when someone has requested to follow you?
+//	-
+//		name: data[alerts][favourite]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when a status you created has been favourited by someone else?
+//	-
+//		name: data[alerts][mention]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when someone else has mentioned you in a status?
+//	-
+//		name: data[alerts][reblog]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when a status you created has been boosted by someone else?
+//	-
+//		name: data[alerts][poll]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when a poll you voted in or created has ended?
+//	-
+//		name: data[alerts][status]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when a subscribed account posts a status?
+//	-
+//		name: data[alerts][admin.sign_up]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when someone has signed up to your instance (admins/moderators only)?
+//	-
+//		name: data[alerts][pending.favourite]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when someone has faved a status of yours, which requires approval?
+//	-
+//		name: data[alerts][pending.reply]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when someone has replied to a status of yours, which requires approval?
+//	-
+//		name: data[alerts][pending.reblog]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when someone has boosted a status of yours, which requires approval?
+//	-
+//		name: data[alerts][quote]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when a status you created has been quoted by someone else?
+//	-
+//		name: data[alerts][pending.quote]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when someone has quoted a status of yours, which requires approval?
+//	-
+//		name: data[policy]
+//		in: formData
+//		type: string
+//		default: all
+//		enum:
+//			- all
+//			- followed
+//			- follower
+//			- none
+//		description: Which accounts to receive push notifications from.
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- push
+//
+//	responses:
+//		'200':
+//			description: Web Push subscription for current access token.
+//			schema:
+//				"$ref": "#/definitions/webPushSubscription"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) PushSubscriptionPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.WebPushSubscriptionCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+	form.Normalize()
+
+	apiSubscription, errWithCode := m.processor.Push().CreateOrReplace(
+		c.Request.Context(),
+		authed.Account.ID,
+		authed.Token.GetAccess(),
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, apiSubscription)
+}
@@ -0,0 +1,148 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
+)
+
+const (
+	testP256dh = "BLRPuwMR_RAaaJzorvqDPm7np72Kh_A_vF14owxaZGvu8PsuhtxI7MCwnpz_TyM325oq-oxfWPXez0J_TTq-r_c"
+	testAuth   = "Wbhwt14_yAmVAbJ6O-rGOg"
+)
+
+// postSubscription creates or replaces the
+// push subscription for the named token.
+func (suite *PushTestSuite) postSubscription(
+	tokenKey string,
+	body []byte,
+	contentType string,
+	expectedHTTPStatus int,
+) string {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, tokenKey, http.MethodPost, body, push.SubscriptionPath, contentType)
+
+	suite.pushModule.PushSubscriptionPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(expectedHTTPStatus, result.StatusCode)
+	return string(b)
+}
+
+// Create a subscription for a token that doesn't have one, using form data.
+func (suite *PushTestSuite) TestPostSubscriptionForm() {
+	form := url.Values{
+		"subscription[endpoint]":     {"https://push.example.org/send/new"},
+		"subscription[keys][p256dh]": {testP256dh},
+		"subscription[keys][auth]":   {testAuth},
+		"data[alerts][follow]":       {"true"},
+		"data[alerts][reblog]":       {"true"},
+		"data[policy]":               {"follower"},
+	}
+
+	resp := suite.postSubscription(
+		"local_account_1_client_application_token",
+		[]byte(form.Encode()),
+		"application/x-www-form-urlencoded",
+		http.StatusOK,
+	)
+
+	suite.Contains(resp, `"endpoint":"https://push.example.org/send/new"`)
+	suite.Contains(resp, `"alerts":{"follow":true,"follow_request":false,"favourite":false,"mention":false,"reblog":true,`)
+	suite.Contains(resp, `"policy":"follower"`)
+
+	// Should now be retrievable with the same token.
+	suite.Equal(resp, suite.getSubscription("local_account_1_client_application_token", http.StatusOK))
+}
+
+// Replace an existing subscription, using JSON.
+func (suite *PushTestSuite) TestPostSubscriptionJSONReplace() {
+	body := `{
+  "subscription": {
+    "endpoint": "https://push.example.org/send/replacement",
+    "keys": {
+      "p256dh": "` + testP256dh + `",
+      "auth": "` + testAuth + `"
+    }
+  },
+  "data": {
+    "alerts": {
+      "mention": true
+    }
+  }
+}`
+
+	resp := suite.postSubscription(
+		"local_account_1",
+		[]byte(body),
+		"application/json",
+		http.StatusOK,
+	)
+
+	suite.NotContains(resp, suite.testWebPushSubscriptions["local_account_1_token_1"].ID)
+	suite.Contains(resp, `"endpoint":"https://push.example.org/send/replacement"`)
+	suite.Contains(resp, `"favourite":false,"mention":true`)
+	suite.Contains(resp, `"policy":"all"`)
+}
+
+// Endpoints must be https.
+func (suite *PushTestSuite) TestPostSubscriptionInsecureEndpoint() {
+	form := url.Values{
+		"subscription[endpoint]":     {"http://push.example.org/send/new"},
+		"subscription[keys][p256dh]": {testP256dh},
+		"subscription[keys][auth]":   {testAuth},
+	}
+
+	resp := suite.postSubscription(
+		"local_account_1_client_application_token",
+		[]byte(form.Encode()),
+		"application/x-www-form-urlencoded",
+		http.StatusBadRequest,
+	)
+	suite.True(strings.HasPrefix(resp, `{"error":"Bad Request: `))
+}
+
+// Keys must be usable for encryption.
+func (suite *PushTestSuite) TestPostSubscriptionInvalidKeys() {
+	form := url.Values{
+		"subscription[endpoint]":     {"https://push.example.org/send/new"},
+		"subscription[keys][p256dh]": {"not a key"},
+		"subscription[keys][auth]":   {testAuth},
+	}
+
+	resp := suite.postSubscription(
+		"local_account_1_client_application_token",
+		[]byte(form.Encode()),
+		"application/x-www-form-urlencoded",
+		http.StatusBadRequest,
+	)
+	suite.True(strings.HasPrefix(resp, `{"error":"Bad Request: `))
+}
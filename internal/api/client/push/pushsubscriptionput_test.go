@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
+)
+
+// putSubscription updates the push subscription for the named token.
+func (suite *PushTestSuite) putSubscription(
+	tokenKey string,
+	body []byte,
+	contentType string,
+	expectedHTTPStatus int,
+) string {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, tokenKey, http.MethodPut, body, push.SubscriptionPath, contentType)
+
+	suite.pushModule.PushSubscriptionPUTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(expectedHTTPStatus, result.StatusCode)
+	return string(b)
+}
+
+// Update alerts and policy, leaving the endpoint alone.
+// Alerts are replaced as a whole, not merged.
+func (suite *PushTestSuite) TestPutSubscription() {
+	form := url.Values{
+		"data[alerts][poll]":    {"true"},
+		"data[alerts][mention]": {"false"},
+		"data[policy]":          {"followed"},
+	}
+
+	resp := suite.putSubscription(
+		"local_account_1",
+		[]byte(form.Encode()),
+		"application/x-www-form-urlencoded",
+		http.StatusOK,
+	)
+	suite.Equal(`{"id":"01JCZ84PHSHE18T4DPDFH16RD7","endpoint":"https://push.example.org/send/01JCZ84PHSHE18T4DPDFH16RD7","server_key":"BOp8s8FC_In8M9fAPN9WpAUswyzJXb4AGjDXLSVx03fve4MexXiw9CQ64Jzs6vb4LzdIeXfjb-6ziWCDH3MvSV0","alerts":{"follow":false,"follow_request":false,"favourite":false,"mention":false,"reblog":false,"poll":true,"status":false,"admin.sign_up":false,"pending.favourite":false,"pending.reply":false,"pending.reblog":false,"quote":false,"pending.quote":false},"policy":"followed"}`, resp)
+}
+
+// Policy must be one of the known values.
+func (suite *PushTestSuite) TestPutSubscriptionInvalidPolicy() {
+	resp := suite.putSubscription(
+		"local_account_1",
+		[]byte(`{"data":{"policy":"everyone"}}`),
+		"application/json",
+		http.StatusBadRequest,
+	)
+	suite.Contains(resp, "everyone")
+}
+
+// Can't update a subscription that doesn't exist.
+func (suite *PushTestSuite) TestPutMissingSubscription() {
+	resp := suite.putSubscription(
+		"local_account_1_client_application_token",
+		[]byte(`{"data":{"policy":"none"}}`),
+		"application/json",
+		http.StatusNotFound,
+	)
+	suite.Equal(`{"error":"Not Found: no web push subscription exists for this access token"}`, resp)
+}
@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scheduledstatuses_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/scheduledstatuses"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+// deleteScheduledStatus deletes the given scheduled status.
+func (suite *ScheduledStatusesTestSuite) deleteScheduledStatus(
+	accountKey string,
+	id string,
+	expectedHTTPStatus int,
+) string {
+	recorder := httptest.NewRecorder()
+	path := "api" + strings.Replace(scheduledstatuses.BasePathWithID, ":"+apiutil.IDKey, id, 1)
+	ctx := suite.newContext(recorder, accountKey, http.MethodDelete, nil, path, "")
+	ctx.Params = gin.Params{{Key: apiutil.IDKey, Value: id}}
+
+	suite.scheduledStatusesModule.ScheduledStatusDELETEHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(expectedHTTPStatus, result.StatusCode)
+	return string(b)
+}
+
+func (suite *ScheduledStatusesTestSuite) TestDeleteScheduledStatus() {
+	scheduledStatus := suite.testScheduledStatuses["local_account_1_scheduled_status_1"]
+	resp := suite.deleteScheduledStatus("local_account_1", scheduledStatus.ID, http.StatusOK)
+	suite.Equal(`{}`, resp)
+
+	// Scheduled status should be gone.
+	_, err := suite.db.GetScheduledStatusByID(context.Background(), scheduledStatus.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+// Scheduled statuses of other accounts should not be deletable.
+func (suite *ScheduledStatusesTestSuite) TestDeleteScheduledStatusOtherAccount() {
+	scheduledStatus := suite.testScheduledStatuses["local_account_1_scheduled_status_1"]
+	resp := suite.deleteScheduledStatus("local_account_2", scheduledStatus.ID, http.StatusNotFound)
+	suite.Equal(`{"error":"Not Found"}`, resp)
+
+	// Scheduled status should still be there.
+	_, err := suite.db.GetScheduledStatusByID(context.Background(), scheduledStatus.ID)
+	suite.NoError(err)
+}
@@ -0,0 +1,89 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scheduledstatuses_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/scheduledstatuses"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+)
+
+// getScheduledStatuses gets the scheduled statuses
+// of the given account, or one of them if id is set.
+func (suite *ScheduledStatusesTestSuite) getScheduledStatuses(
+	accountKey string,
+	id string,
+	expectedHTTPStatus int,
+) string {
+	recorder := httptest.NewRecorder()
+
+	path := "api" + scheduledstatuses.BasePath
+	if id != "" {
+		path = "api" + strings.Replace(scheduledstatuses.BasePathWithID, ":"+apiutil.IDKey, id, 1)
+	}
+
+	ctx := suite.newContext(recorder, accountKey, http.MethodGet, nil, path, "")
+
+	if id != "" {
+		ctx.Params = gin.Params{{Key: apiutil.IDKey, Value: id}}
+		suite.scheduledStatusesModule.ScheduledStatusGETHandler(ctx)
+	} else {
+		suite.scheduledStatusesModule.ScheduledStatusesGETHandler(ctx)
+	}
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(expectedHTTPStatus, result.StatusCode)
+	return string(b)
+}
+
+const localAccount1ScheduledStatus1JSON = `{"id":"01JDNYS1XPZ7Y3Z0KYHD5VAN2T","scheduled_at":"2080-10-04T15:32:02.000Z","params":{"text":"this status will be posted in the far future!","poll":null,"media_ids":[],"sensitive":false,"spoiler_text":"","visibility":"public","local_only":false,"in_reply_to_id":"","language":"en","content_type":"text/plain","interaction_policy":null,"application_id":"01F8MGY43H3N2C8EWPR2FPYEXG","scheduled_at":null},"media_attachments":[]}`
+
+func (suite *ScheduledStatusesTestSuite) TestGetScheduledStatuses() {
+	resp := suite.getScheduledStatuses("local_account_1", "", http.StatusOK)
+	suite.Equal(`[`+localAccount1ScheduledStatus1JSON+`]`, resp)
+}
+
+func (suite *ScheduledStatusesTestSuite) TestGetScheduledStatusesNone() {
+	resp := suite.getScheduledStatuses("local_account_2", "", http.StatusOK)
+	suite.Equal(`[]`, resp)
+}
+
+func (suite *ScheduledStatusesTestSuite) TestGetScheduledStatus() {
+	scheduledStatus := suite.testScheduledStatuses["local_account_1_scheduled_status_1"]
+	resp := suite.getScheduledStatuses("local_account_1", scheduledStatus.ID, http.StatusOK)
+	suite.Equal(localAccount1ScheduledStatus1JSON, resp)
+}
+
+// Scheduled statuses of other accounts should not be visible.
+func (suite *ScheduledStatusesTestSuite) TestGetScheduledStatusOtherAccount() {
+	scheduledStatus := suite.testScheduledStatuses["local_account_1_scheduled_status_1"]
+	resp := suite.getScheduledStatuses("local_account_2", scheduledStatus.ID, http.StatusNotFound)
+	suite.Equal(`{"error":"Not Found"}`, resp)
+}
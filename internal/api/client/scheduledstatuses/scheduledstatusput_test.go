@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scheduledstatuses_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/scheduledstatuses"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+)
+
+// updateScheduledStatus changes the publication time of the given scheduled status.
+func (suite *ScheduledStatusesTestSuite) updateScheduledStatus(
+	accountKey string,
+	id string,
+	requestJson string,
+	expectedHTTPStatus int,
+) string {
+	recorder := httptest.NewRecorder()
+	path := "api" + strings.Replace(scheduledstatuses.BasePathWithID, ":"+apiutil.IDKey, id, 1)
+	ctx := suite.newContext(recorder, accountKey, http.MethodPut, []byte(requestJson), path, "application/json")
+	ctx.Params = gin.Params{{Key: apiutil.IDKey, Value: id}}
+
+	suite.scheduledStatusesModule.ScheduledStatusPUTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(expectedHTTPStatus, result.StatusCode)
+	return string(b)
+}
+
+func (suite *ScheduledStatusesTestSuite) TestUpdateScheduledStatus() {
+	scheduledStatus := suite.testScheduledStatuses["local_account_1_scheduled_status_1"]
+	resp := suite.updateScheduledStatus(
+		"local_account_1",
+		scheduledStatus.ID,
+		`{"scheduled_at":"2081-01-01T12:00:00Z"}`,
+		http.StatusOK,
+	)
+	suite.Contains(resp, `"scheduled_at":"2081-01-01T12:00:00.000Z"`)
+
+	// Change should be persisted.
+	resp = suite.getScheduledStatuses("local_account_1", scheduledStatus.ID, http.StatusOK)
+	suite.Contains(resp, `"scheduled_at":"2081-01-01T12:00:00.000Z"`)
+}
+
+func (suite *ScheduledStatusesTestSuite) TestUpdateScheduledStatusInPast() {
+	scheduledStatus := suite.testScheduledStatuses["local_account_1_scheduled_status_1"]
+	resp := suite.updateScheduledStatus(
+		"local_account_1",
+		scheduledStatus.ID,
+		`{"scheduled_at":"2020-01-01T12:00:00Z"}`,
+		http.StatusUnprocessableEntity,
+	)
+	suite.Equal(`{"error":"Unprocessable Entity: scheduled_at must be at least 5 minutes in the future"}`, resp)
+}
+
+func (suite *ScheduledStatusesTestSuite) TestUpdateScheduledStatusInvalidTime() {
+	scheduledStatus := suite.testScheduledStatuses["local_account_1_scheduled_status_1"]
+	resp := suite.updateScheduledStatus(
+		"local_account_1",
+		scheduledStatus.ID,
+		`{"scheduled_at":"next tuesday"}`,
+		http.StatusUnprocessableEntity,
+	)
+	suite.Equal(`{"error":"Unprocessable Entity: scheduled_at must be a valid ISO 8601 datetime"}`, resp)
+}
@@ -21,6 +21,8 @@
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -83,35 +85,36 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	// create / get / delete status
-	attachHandler(http.MethodPost, BasePath, m.StatusCreatePOSTHandler)
-	attachHandler(http.MethodGet, BasePathWithID, m.StatusGETHandler)
-	attachHandler(http.MethodDelete, BasePathWithID, m.StatusDELETEHandler)
+	// create / get / edit / delete status
+	attachHandler(http.MethodPost, BasePath, middleware.ScopeCheck(oauth.ScopeWriteStatuses), m.StatusCreatePOSTHandler)
+	attachHandler(http.MethodGet, BasePathWithID, middleware.ScopeCheck(oauth.ScopeReadStatuses), m.StatusGETHandler)
+	attachHandler(http.MethodPut, BasePathWithID, middleware.ScopeCheck(oauth.ScopeWriteStatuses), m.StatusEditPUTHandler)
+	attachHandler(http.MethodDelete, BasePathWithID, middleware.ScopeCheck(oauth.ScopeWriteStatuses), m.StatusDELETEHandler)
 
 	// fave stuff
-	attachHandler(http.MethodPost, FavouritePath, m.StatusFavePOSTHandler)
-	attachHandler(http.MethodPost, UnfavouritePath, m.StatusUnfavePOSTHandler)
-	attachHandler(http.MethodGet, FavouritedPath, m.StatusFavedByGETHandler)
+	attachHandler(http.MethodPost, FavouritePath, middleware.ScopeCheck(oauth.ScopeWriteFavourites), m.StatusFavePOSTHandler)
+	attachHandler(http.MethodPost, UnfavouritePath, middleware.ScopeCheck(oauth.ScopeWriteFavourites), m.StatusUnfavePOSTHandler)
+	attachHandler(http.MethodGet, FavouritedPath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.StatusFavedByGETHandler)
 
 	// pin stuff
-	attachHandler(http.MethodPost, PinPath, m.StatusPinPOSTHandler)
-	attachHandler(http.MethodPost, UnpinPath, m.StatusUnpinPOSTHandler)
+	attachHandler(http.MethodPost, PinPath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.StatusPinPOSTHandler)
+	attachHandler(http.MethodPost, UnpinPath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.StatusUnpinPOSTHandler)
 
 	// mute stuff
-	attachHandler(http.MethodPost, MutePath, m.StatusMutePOSTHandler)
-	attachHandler(http.MethodPost, UnmutePath, m.StatusUnmutePOSTHandler)
+	attachHandler(http.MethodPost, MutePath, middleware.ScopeCheck(oauth.ScopeWriteMutes), m.StatusMutePOSTHandler)
+	attachHandler(http.MethodPost, UnmutePath, middleware.ScopeCheck(oauth.ScopeWriteMutes), m.StatusUnmutePOSTHandler)
 
 	// reblog stuff
-	attachHandler(http.MethodPost, ReblogPath, m.StatusBoostPOSTHandler)
-	attachHandler(http.MethodPost, UnreblogPath, m.StatusUnboostPOSTHandler)
-	attachHandler(http.MethodGet, RebloggedPath, m.StatusBoostedByGETHandler)
-	attachHandler(http.MethodPost, BookmarkPath, m.StatusBookmarkPOSTHandler)
-	attachHandler(http.MethodPost, UnbookmarkPath, m.StatusUnbookmarkPOSTHandler)
+	attachHandler(http.MethodPost, ReblogPath, middleware.ScopeCheck(oauth.ScopeWriteStatuses), m.StatusBoostPOSTHandler)
+	attachHandler(http.MethodPost, UnreblogPath, middleware.ScopeCheck(oauth.ScopeWriteStatuses), m.StatusUnboostPOSTHandler)
+	attachHandler(http.MethodGet, RebloggedPath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.StatusBoostedByGETHandler)
+	attachHandler(http.MethodPost, BookmarkPath, middleware.ScopeCheck(oauth.ScopeWriteBookmarks), m.StatusBookmarkPOSTHandler)
+	attachHandler(http.MethodPost, UnbookmarkPath, middleware.ScopeCheck(oauth.ScopeWriteBookmarks), m.StatusUnbookmarkPOSTHandler)
 
 	// context / status thread
-	attachHandler(http.MethodGet, ContextPath, m.StatusContextGETHandler)
+	attachHandler(http.MethodGet, ContextPath, middleware.ScopeCheck(oauth.ScopeReadStatuses), m.StatusContextGETHandler)
 
 	// history/edit stuff
-	attachHandler(http.MethodGet, HistoryPath, m.StatusHistoryGETHandler)
-	attachHandler(http.MethodGet, SourcePath, m.StatusSourceGETHandler)
+	attachHandler(http.MethodGet, HistoryPath, middleware.ScopeCheck(oauth.ScopeReadStatuses), m.StatusHistoryGETHandler)
+	attachHandler(http.MethodGet, SourcePath, middleware.ScopeCheck(oauth.ScopeReadStatuses), m.StatusSourceGETHandler)
 }
@@ -121,6 +121,10 @@ func (suite *StatusStandardTestSuite) determinateStatus(rawMap map[string]any) {
 		rawMap["created_at"] = "right the hell just now babyee"
 	}
 
+	if editedAt, ok := rawMap["edited_at"]; ok && editedAt != nil {
+		rawMap["edited_at"] = "right the hell just now babyee"
+	}
+
 	// Make ID of any mentions determinate.
 	if menchiesRaw, ok := rawMap["mentions"]; ok {
 		menchies, ok := menchiesRaw.([]any)
@@ -100,6 +100,7 @@ func (suite *StatusBoostTestSuite) TestPostBoost() {
   "card": null,
   "content": "",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": true,
   "favourites_count": 0,
@@ -114,6 +115,13 @@ func (suite *StatusBoostTestSuite) TestPostBoost() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -145,6 +153,7 @@ func (suite *StatusBoostTestSuite) TestPostBoost() {
     "card": null,
     "content": "hello world! #welcome ! first post on the instance :rainbow: !",
     "created_at": "right the hell just now babyee",
+    "edited_at": null,
     "emojis": [
       {
         "category": "reactions",
@@ -167,6 +176,13 @@ func (suite *StatusBoostTestSuite) TestPostBoost() {
         ],
         "with_approval": []
       },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
+      },
       "can_reblog": {
         "always": [
           "public",
@@ -280,6 +296,7 @@ func (suite *StatusBoostTestSuite) TestPostBoostOwnFollowersOnly() {
   "card": null,
   "content": "",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": false,
   "favourites_count": 0,
@@ -296,6 +313,13 @@ func (suite *StatusBoostTestSuite) TestPostBoostOwnFollowersOnly() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -329,6 +353,7 @@ func (suite *StatusBoostTestSuite) TestPostBoostOwnFollowersOnly() {
     "card": null,
     "content": "hi!",
     "created_at": "right the hell just now babyee",
+    "edited_at": null,
     "emojis": [],
     "favourited": false,
     "favourites_count": 0,
@@ -345,6 +370,13 @@ func (suite *StatusBoostTestSuite) TestPostBoostOwnFollowersOnly() {
         ],
         "with_approval": []
       },
+      "can_quote": {
+        "always": [
+          "author",
+          "me"
+        ],
+        "with_approval": []
+      },
       "can_reblog": {
         "always": [
           "author",
@@ -494,6 +526,7 @@ func (suite *StatusBoostTestSuite) TestPostBoostImplicitAccept() {
   "card": null,
   "content": "",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": false,
   "favourites_count": 0,
@@ -508,6 +541,13 @@ func (suite *StatusBoostTestSuite) TestPostBoostImplicitAccept() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -539,6 +579,7 @@ func (suite *StatusBoostTestSuite) TestPostBoostImplicitAccept() {
     "card": null,
     "content": "<p>Hi <span class=\"h-card\"><a href=\"http://localhost:8080/@1happyturtle\" class=\"u-url mention\" rel=\"nofollow noreferrer noopener\" target=\"_blank\">@<span>1happyturtle</span></a></span>, can I reply?</p>",
     "created_at": "right the hell just now babyee",
+    "edited_at": null,
     "emojis": [],
     "favourited": false,
     "favourites_count": 0,
@@ -553,6 +594,13 @@ func (suite *StatusBoostTestSuite) TestPostBoostImplicitAccept() {
         ],
         "with_approval": []
       },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
+      },
       "can_reblog": {
         "always": [
           "public",
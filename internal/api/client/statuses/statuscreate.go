@@ -180,8 +180,6 @@
 //			ISO 8601 Datetime at which to schedule a status.
 //			Providing this parameter will cause ScheduledStatus to be returned instead of Status.
 //			Must be at least 5 minutes in the future.
-//
-//			This feature isn't implemented yet; attemping to set it will return 501 Not Implemented.
 //		type: string
 //		in: formData
 //	-
@@ -239,7 +237,11 @@
 //
 //	responses:
 //		'200':
-//			description: "The newly created status."
+//			description: >-
+//				The newly created status.
+//				If scheduled_at was set, this will instead be the newly
+//				created scheduledStatus, and the status itself will be
+//				published at the scheduled time.
 //			schema:
 //				"$ref": "#/definitions/status"
 //		'400':
@@ -252,10 +254,10 @@
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: scheduled_at was too soon, or scheduled status limits were reached
 //		'500':
 //			description: internal server error
-//		'501':
-//			description: scheduled_at was set, but this feature is not yet implemented
 func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
@@ -293,6 +295,24 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if form.ScheduledAt != "" {
+		// Status is to be published later,
+		// return the scheduled status instead.
+		apiScheduledStatus, errWithCode := m.processor.Status().ScheduledStatusCreate(
+			c.Request.Context(),
+			authed.Account,
+			authed.Application,
+			form,
+		)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		c.JSON(http.StatusOK, apiScheduledStatus)
+		return
+	}
+
 	apiStatus, errWithCode := m.processor.Status().Create(
 		c.Request.Context(),
 		authed.Account,
@@ -413,16 +433,11 @@ func validateStatusCreateForm(form *apimodel.StatusCreateRequest) gtserror.WithC
 	}
 
 	if form.Poll != nil {
-		if errWithCode := validateStatusPoll(form); errWithCode != nil {
+		if errWithCode := validateStatusPoll(form.Poll); errWithCode != nil {
 			return errWithCode
 		}
 	}
 
-	if form.ScheduledAt != "" {
-		const text = "scheduled_at is not yet implemented"
-		return gtserror.NewErrorNotImplemented(errors.New(text), text)
-	}
-
 	// Validate + normalize
 	// language tag if provided.
 	if form.Language != "" {
@@ -442,10 +457,10 @@ func validateStatusCreateForm(form *apimodel.StatusCreateRequest) gtserror.WithC
 	return nil
 }
 
-func validateStatusPoll(form *apimodel.StatusCreateRequest) gtserror.WithCode {
+func validateStatusPoll(poll *apimodel.PollRequest) gtserror.WithCode {
 	var (
 		maxPollOptions     = config.GetStatusesPollMaxOptions()
-		pollOptions        = len(form.Poll.Options)
+		pollOptions        = len(poll.Options)
 		maxPollOptionChars = config.GetStatusesPollOptionMaxChars()
 	)
 
@@ -462,7 +477,7 @@ func validateStatusPoll(form *apimodel.StatusCreateRequest) gtserror.WithCode {
 		return gtserror.NewErrorBadRequest(errors.New(text), text)
 	}
 
-	for _, option := range form.Poll.Options {
+	for _, option := range poll.Options {
 		optionChars := len([]rune(option))
 		if optionChars > maxPollOptionChars {
 			text := fmt.Sprintf(
@@ -476,10 +491,10 @@ func validateStatusPoll(form *apimodel.StatusCreateRequest) gtserror.WithCode {
 	// Normalize poll expiry if necessary.
 	// If we parsed this as JSON, expires_in
 	// may be either a float64 or a string.
-	if ei := form.Poll.ExpiresInI; ei != nil {
+	if ei := poll.ExpiresInI; ei != nil {
 		switch e := ei.(type) {
 		case float64:
-			form.Poll.ExpiresIn = int(e)
+			poll.ExpiresIn = int(e)
 
 		case string:
 			expiresIn, err := strconv.Atoi(e)
@@ -488,7 +503,7 @@ func validateStatusPoll(form *apimodel.StatusCreateRequest) gtserror.WithCode {
 				return gtserror.NewErrorBadRequest(errors.New(text), text)
 			}
 
-			form.Poll.ExpiresIn = expiresIn
+			poll.ExpiresIn = expiresIn
 
 		default:
 			text := fmt.Sprintf("could not parse expires_in type %T as integer", ei)
@@ -102,6 +102,7 @@ func (suite *StatusCreateTestSuite) TestPostNewStatus() {
   "card": null,
   "content": "<p>this is a brand new status! <a href=\"http://localhost:8080/tags/helloworld\" class=\"mention hashtag\" rel=\"tag nofollow noreferrer noopener\" target=\"_blank\">#<span>helloworld</span></a></p>",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": false,
   "favourites_count": 0,
@@ -118,6 +119,13 @@ func (suite *StatusCreateTestSuite) TestPostNewStatus() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -187,6 +195,7 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusIntPolicy() {
   "card": null,
   "content": "<p>this is a brand new status! <a href=\"http://localhost:8080/tags/helloworld\" class=\"mention hashtag\" rel=\"tag nofollow noreferrer noopener\" target=\"_blank\">#<span>helloworld</span></a></p>",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": false,
   "favourites_count": 0,
@@ -201,6 +210,12 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusIntPolicy() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -282,6 +297,7 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusIntPolicyJSON() {
   "card": null,
   "content": "<p>this is a brand new status! <a href=\"http://localhost:8080/tags/helloworld\" class=\"mention hashtag\" rel=\"tag nofollow noreferrer noopener\" target=\"_blank\">#<span>helloworld</span></a></p>",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": false,
   "favourites_count": 0,
@@ -296,6 +312,12 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusIntPolicyJSON() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -374,13 +396,46 @@ func (suite *StatusCreateTestSuite) TestPostNewScheduledStatus() {
 		"scheduled_at": {"2080-10-04T15:32:02.018Z"},
 	}, "")
 
-	// We should have 501 from
+	// We should have OK from
 	// our call to the function.
-	suite.Equal(http.StatusNotImplemented, recorder.Code)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	// We should get the scheduled status back.
+	suite.Equal(`{
+  "id": "ZZZZZZZZZZZZZZZZZZZZZZZZZZ",
+  "media_attachments": [],
+  "params": {
+    "application_id": "01F8MGY43H3N2C8EWPR2FPYEXG",
+    "content_type": "text/plain",
+    "in_reply_to_id": "",
+    "interaction_policy": null,
+    "language": "en",
+    "local_only": false,
+    "media_ids": [],
+    "poll": null,
+    "scheduled_at": null,
+    "sensitive": true,
+    "spoiler_text": "hello hello",
+    "text": "this is a brand new status! #helloworld",
+    "visibility": "private"
+  },
+  "scheduled_at": "2080-10-04T15:32:02.018Z"
+}`, out)
+}
+
+func (suite *StatusCreateTestSuite) TestPostNewScheduledStatusTooSoon() {
+	out, recorder := suite.postStatus(map[string][]string{
+		"status":       {"this is a brand new status! #helloworld"},
+		"scheduled_at": {"2020-10-04T15:32:02.018Z"},
+	}, "")
+
+	// We should have 422 from
+	// our call to the function.
+	suite.Equal(http.StatusUnprocessableEntity, recorder.Code)
 
 	// We should have a helpful error message.
 	suite.Equal(`{
-  "error": "Not Implemented: scheduled_at is not yet implemented"
+  "error": "Unprocessable Entity: scheduled_at must be at least 5 minutes in the future"
 }`, out)
 }
 
@@ -407,6 +462,7 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusMarkdown() {
   "card": null,
   "content": "<h1>Title</h1><h2>Smaller title</h2><p>This is a post written in <a href=\"https://www.markdownguide.org/\" rel=\"nofollow noreferrer noopener\" target=\"_blank\">markdown</a></p>",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": false,
   "favourites_count": 0,
@@ -421,6 +477,13 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusMarkdown() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -490,6 +553,7 @@ func (suite *StatusCreateTestSuite) TestMentionUnknownAccount() {
   "card": null,
   "content": "<p>hello <span class=\"h-card\"><a href=\"https://unknown-instance.com/@brand_new_person\" class=\"u-url mention\" rel=\"nofollow noreferrer noopener\" target=\"_blank\">@<span>brand_new_person</span></a></span></p>",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": false,
   "favourites_count": 0,
@@ -504,6 +568,13 @@ func (suite *StatusCreateTestSuite) TestMentionUnknownAccount() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -567,6 +638,7 @@ func (suite *StatusCreateTestSuite) TestPostStatusWithLinksAndTags() {
   "card": null,
   "content": "<p><a href=\"http://localhost:8080/tags/test\" class=\"mention hashtag\" rel=\"tag nofollow noreferrer noopener\" target=\"_blank\">#<span>test</span></a> alright, should be able to post <a href=\"http://localhost:8080/tags/links\" class=\"mention hashtag\" rel=\"tag nofollow noreferrer noopener\" target=\"_blank\">#<span>links</span></a> with fragments in them now, let's see........<br><br><a href=\"https://docs.gotosocial.org/en/latest/user_guide/posts/#links\" rel=\"nofollow noreferrer noopener\" target=\"_blank\">https://docs.gotosocial.org/en/latest/user_guide/posts/#links</a><br><br><a href=\"http://localhost:8080/tags/gotosocial\" class=\"mention hashtag\" rel=\"tag nofollow noreferrer noopener\" target=\"_blank\">#<span>gotosocial</span></a><br><br>(tobi remember to pull the docker image challenge)</p>",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": false,
   "favourites_count": 0,
@@ -581,6 +653,13 @@ func (suite *StatusCreateTestSuite) TestPostStatusWithLinksAndTags() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -650,6 +729,7 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithEmoji() {
   "card": null,
   "content": "<p>here is a rainbow emoji a few times! :rainbow: :rainbow: :rainbow:<br>here's an emoji that isn't in the db: :test_emoji:</p>",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [
     {
       "category": "reactions",
@@ -672,6 +752,13 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithEmoji() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -747,6 +834,7 @@ func (suite *StatusCreateTestSuite) TestReplyToLocalStatus() {
   "card": null,
   "content": "<p>hello <span class=\"h-card\"><a href=\"http://localhost:8080/@1happyturtle\" class=\"u-url mention\" rel=\"nofollow noreferrer noopener\" target=\"_blank\">@<span>1happyturtle</span></a></span> this reply should work!</p>",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": false,
   "favourites_count": 0,
@@ -761,6 +849,13 @@ func (suite *StatusCreateTestSuite) TestReplyToLocalStatus() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -829,6 +924,7 @@ func (suite *StatusCreateTestSuite) TestAttachNewMediaSuccess() {
   "card": null,
   "content": "<p>here's an image attachment</p>",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": false,
   "favourites_count": 0,
@@ -843,6 +939,13 @@ func (suite *StatusCreateTestSuite) TestAttachNewMediaSuccess() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -933,6 +1036,7 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithNoncanonicalLanguageTag
   "card": null,
   "content": "<p>English? what's English? i speak American</p>",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": false,
   "favourites_count": 0,
@@ -947,6 +1051,13 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithNoncanonicalLanguageTag
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -1007,6 +1118,7 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithPollForm() {
   "card": null,
   "content": "<p>this is a status with a poll!</p>",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": false,
   "favourites_count": 0,
@@ -1021,6 +1133,13 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithPollForm() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -1103,6 +1222,7 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithPollJSON() {
   "card": null,
   "content": "<p>this is a status with a poll!</p>",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": false,
   "favourites_count": 0,
@@ -1117,6 +1237,13 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithPollJSON() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
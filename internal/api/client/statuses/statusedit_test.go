@@ -0,0 +1,343 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusEditTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) editStatus(
+	statusID string,
+	formData map[string][]string,
+	jsonData string,
+) (string, *httptest.ResponseRecorder) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+
+	target := "http://localhost:8080" + strings.ReplaceAll(statuses.BasePathWithID, ":id", statusID)
+
+	if formData != nil {
+		buf, w, err := testrig.CreateMultipartFormData(nil, formData)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		ctx.Request = httptest.NewRequest(
+			http.MethodPut,
+			target,
+			bytes.NewReader(buf.Bytes()),
+		)
+		ctx.Request.Header.Set("content-type", w.FormDataContentType())
+	} else {
+		ctx.Request = httptest.NewRequest(
+			http.MethodPut,
+			target,
+			bytes.NewReader([]byte(jsonData)),
+		)
+		ctx.Request.Header.Set("content-type", "application/json")
+	}
+
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.AddParam(statuses.IDKey, statusID)
+
+	// Trigger handler.
+	suite.statusModule.StatusEditPUTHandler(ctx)
+	return suite.parseStatusResponse(recorder)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatus() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	out, recorder := suite.editStatus(targetStatus.ID, map[string][]string{
+		"status":       {"hello everyone! i've been edited #edits"},
+		"spoiler_text": {"edited"},
+		"language":     {"en"},
+	}, "")
+
+	// We should have OK from
+	// our call to the function.
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal(`{
+  "account": "yeah this is my account, what about it punk",
+  "application": {
+    "name": "really cool gts application",
+    "website": "https://reallycool.app"
+  },
+  "bookmarked": false,
+  "card": null,
+  "content": "<p>hello everyone! i've been edited <a href=\"http://localhost:8080/tags/edits\" class=\"mention hashtag\" rel=\"tag nofollow noreferrer noopener\" target=\"_blank\">#<span>edits</span></a></p>",
+  "created_at": "right the hell just now babyee",
+  "edited_at": "right the hell just now babyee",
+  "emojis": [],
+  "favourited": false,
+  "favourites_count": 1,
+  "id": "ZZZZZZZZZZZZZZZZZZZZZZZZZZ",
+  "in_reply_to_account_id": null,
+  "in_reply_to_id": null,
+  "interaction_policy": {
+    "can_favourite": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_reblog": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_reply": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    }
+  },
+  "language": "en",
+  "media_attachments": [],
+  "mentions": [],
+  "muted": false,
+  "pinned": false,
+  "poll": null,
+  "reblog": null,
+  "reblogged": false,
+  "reblogs_count": 1,
+  "replies_count": 2,
+  "sensitive": false,
+  "spoiler_text": "edited",
+  "tags": [
+    {
+      "name": "edits",
+      "url": "http://localhost:8080/tags/edits"
+    }
+  ],
+  "text": "hello everyone! i've been edited #edits",
+  "uri": "http://localhost:8080/some/determinate/url",
+  "url": "http://localhost:8080/some/determinate/url",
+  "visibility": "public"
+}`, out)
+
+	// The previous revision should be stored.
+	dbStatus, err := suite.db.GetStatusByID(context.Background(), targetStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(dbStatus.EditIDs, 1)
+	suite.Equal(targetStatus.Content, dbStatus.Edits[0].Content)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusJSON() {
+	targetStatus := suite.testStatuses["local_account_1_status_4"]
+	keptMediaID := targetStatus.AttachmentIDs[0]
+
+	out, recorder := suite.editStatus(targetStatus.ID, nil, `{
+  "status": "here's a little gif of trent",
+  "media_ids": ["`+keptMediaID+`"],
+  "media_attributes": [
+    {
+      "id": "`+keptMediaID+`",
+      "description": "trent reznor, looking sad",
+      "focus": "-0.5,0.5"
+    }
+  ]
+}`)
+
+	// We should have OK from
+	// our call to the function.
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal(`{
+  "account": "yeah this is my account, what about it punk",
+  "application": {
+    "name": "really cool gts application",
+    "website": "https://reallycool.app"
+  },
+  "bookmarked": false,
+  "card": null,
+  "content": "<p>here's a little gif of trent</p>",
+  "created_at": "right the hell just now babyee",
+  "edited_at": "right the hell just now babyee",
+  "emojis": [],
+  "favourited": false,
+  "favourites_count": 0,
+  "id": "ZZZZZZZZZZZZZZZZZZZZZZZZZZ",
+  "in_reply_to_account_id": null,
+  "in_reply_to_id": null,
+  "interaction_policy": {
+    "can_favourite": {
+      "always": [
+        "author",
+        "followers",
+        "mentioned",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_reblog": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": []
+    },
+    "can_reply": {
+      "always": [
+        "author",
+        "followers",
+        "mentioned",
+        "me"
+      ],
+      "with_approval": []
+    }
+  },
+  "language": "en",
+  "media_attachments": [
+    {
+      "blurhash": "LCDRH758KOxsEMNxENEM9]}?aKxZ",
+      "description": "trent reznor, looking sad",
+      "id": "01F8MH7TDVANYKWVE8VVKFPJTJ",
+      "meta": {
+        "focus": {
+          "x": -0.5,
+          "y": 0.5
+        },
+        "original": {
+          "aspect": 1.4285715,
+          "height": 280,
+          "size": "400x280",
+          "width": 400
+        },
+        "small": {
+          "aspect": 1.4285715,
+          "height": 280,
+          "size": "400x280",
+          "width": 400
+        }
+      },
+      "preview_remote_url": null,
+      "preview_url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/attachment/small/01F8MH7TDVANYKWVE8VVKFPJTJ.webp",
+      "remote_url": null,
+      "text_url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/attachment/original/01F8MH7TDVANYKWVE8VVKFPJTJ.gif",
+      "type": "image",
+      "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/attachment/original/01F8MH7TDVANYKWVE8VVKFPJTJ.gif"
+    }
+  ],
+  "mentions": [],
+  "muted": false,
+  "pinned": false,
+  "poll": null,
+  "reblog": null,
+  "reblogged": false,
+  "reblogs_count": 0,
+  "replies_count": 0,
+  "sensitive": false,
+  "spoiler_text": "",
+  "tags": [],
+  "text": "here's a little gif of trent",
+  "uri": "http://localhost:8080/some/determinate/url",
+  "url": "http://localhost:8080/some/determinate/url",
+  "visibility": "private"
+}`, out)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusMediaAttributesForm() {
+	targetStatus := suite.testStatuses["local_account_1_status_4"]
+	keptMediaID := targetStatus.AttachmentIDs[0]
+
+	_, recorder := suite.editStatus(targetStatus.ID, map[string][]string{
+		"status":                           {"here's a little gif of trent"},
+		"media_ids[]":                      {keptMediaID},
+		"media_attributes[0][id]":          {keptMediaID},
+		"media_attributes[0][description]": {"trent reznor, looking sad"},
+	}, "")
+
+	// We should have OK from
+	// our call to the function.
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	attachment, err := suite.db.GetAttachmentByID(context.Background(), keptMediaID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("trent reznor, looking sad", attachment.Description)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusNotOwned() {
+	targetStatus := suite.testStatuses["local_account_2_status_1"]
+
+	out, recorder := suite.editStatus(targetStatus.ID, map[string][]string{
+		"status": {"this isn't mine but i'm editing it anyway"},
+	}, "")
+
+	suite.Equal(http.StatusNotFound, recorder.Code)
+	suite.Equal(`{
+  "error": "Not Found: status not found"
+}`, out)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusNoContent() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	out, recorder := suite.editStatus(targetStatus.ID, map[string][]string{
+		"spoiler_text": {""},
+	}, "")
+
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Equal(`{
+  "error": "Bad Request: no status content, content warning, media, or poll provided"
+}`, out)
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEditTestSuite))
+}
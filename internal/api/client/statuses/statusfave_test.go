@@ -105,6 +105,7 @@ func (suite *StatusFaveTestSuite) TestPostFave() {
   "card": null,
   "content": "🐕🐕🐕🐕🐕",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": true,
   "favourites_count": 1,
@@ -119,6 +120,13 @@ func (suite *StatusFaveTestSuite) TestPostFave() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -228,6 +236,7 @@ func (suite *StatusFaveTestSuite) TestPostFaveImplicitAccept() {
   "card": null,
   "content": "<p>Hi <span class=\"h-card\"><a href=\"http://localhost:8080/@1happyturtle\" class=\"u-url mention\" rel=\"nofollow noreferrer noopener\" target=\"_blank\">@<span>1happyturtle</span></a></span>, can I reply?</p>",
   "created_at": "right the hell just now babyee",
+  "edited_at": null,
   "emojis": [],
   "favourited": true,
   "favourites_count": 1,
@@ -242,6 +251,13 @@ func (suite *StatusFaveTestSuite) TestPostFaveImplicitAccept() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
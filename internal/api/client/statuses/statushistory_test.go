@@ -19,6 +19,7 @@
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -30,6 +31,7 @@
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -129,6 +131,84 @@ func (suite *StatusHistoryTestSuite) TestGetHistory() {
 ]`, dst.String())
 }
 
+func (suite *StatusHistoryTestSuite) TestGetHistoryEdited() {
+	var (
+		testApplication = suite.testApplications["application_1"]
+		testAccount     = suite.testAccounts["local_account_1"]
+		testUser        = suite.testUsers["local_account_1"]
+		testToken       = oauth.DBTokenToToken(suite.testTokens["local_account_1"])
+		targetStatusID  = suite.testStatuses["local_account_1_status_1"].ID
+		target          = fmt.Sprintf("http://localhost:8080%s", strings.ReplaceAll(statuses.HistoryPath, ":id", targetStatusID))
+	)
+
+	// Edit the status twice.
+	for _, text := range []string{"hello everyone! (edited)", "hello everyone! (edited again)"} {
+		if _, errWithCode := suite.processor.Status().Edit(
+			context.Background(),
+			testAccount,
+			targetStatusID,
+			&apimodel.StatusEditRequest{
+				Status:      text,
+				SpoilerText: "introduction post",
+				Sensitive:   true,
+			},
+		); errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+	}
+
+	// Setup request.
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, target, nil)
+	request.Header.Set("accept", "application/json")
+	ctx, _ := testrig.CreateGinTestContext(recorder, request)
+
+	// Set auth + path params.
+	ctx.Set(oauth.SessionAuthorizedApplication, testApplication)
+	ctx.Set(oauth.SessionAuthorizedToken, testToken)
+	ctx.Set(oauth.SessionAuthorizedUser, testUser)
+	ctx.Set(oauth.SessionAuthorizedAccount, testAccount)
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: targetStatusID,
+		},
+	}
+
+	// Call the handler.
+	suite.statusModule.StatusHistoryGETHandler(ctx)
+
+	// Check code.
+	if code := recorder.Code; code != http.StatusOK {
+		suite.FailNow("", "unexpected http code: %d", code)
+	}
+
+	// Read body.
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	edits := []*apimodel.StatusEdit{}
+	if err := json.NewDecoder(result.Body).Decode(&edits); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Revisions should be oldest first,
+	// with the current version last.
+	if !suite.Len(edits, 3) {
+		suite.FailNow("")
+	}
+	suite.Equal("hello everyone!", edits[0].Content)
+	suite.Equal("2021-10-20T10:40:37.000Z", edits[0].CreatedAt)
+	suite.Equal("<p>hello everyone! (edited)</p>", edits[1].Content)
+	suite.Equal("<p>hello everyone! (edited again)</p>", edits[2].Content)
+	suite.Less(edits[0].CreatedAt, edits[1].CreatedAt)
+	suite.LessOrEqual(edits[1].CreatedAt, edits[2].CreatedAt)
+	for _, edit := range edits {
+		suite.Equal("introduction post", edit.SpoilerText)
+		suite.Equal(testAccount.ID, edit.Account.ID)
+	}
+}
+
 func TestStatusHistoryTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusHistoryTestSuite))
 }
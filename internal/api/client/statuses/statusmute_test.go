@@ -91,6 +91,7 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
 	suite.Equal(`{
   "id": "01F8MHAMCHF6Y650WCRSCP4WMY",
   "created_at": "2021-10-20T10:40:37.000Z",
+  "edited_at": null,
   "in_reply_to_id": null,
   "in_reply_to_account_id": null,
   "sensitive": true,
@@ -168,6 +169,13 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, muted)
@@ -178,6 +186,7 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
 	suite.Equal(`{
   "id": "01F8MHAMCHF6Y650WCRSCP4WMY",
   "created_at": "2021-10-20T10:40:37.000Z",
+  "edited_at": null,
   "in_reply_to_id": null,
   "in_reply_to_account_id": null,
   "sensitive": true,
@@ -255,6 +264,13 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, unmuted)
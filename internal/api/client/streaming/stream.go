@@ -19,8 +19,11 @@
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"slices"
+	"strings"
 	"time"
 
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
@@ -30,6 +33,7 @@
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	streampkg "github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
@@ -102,7 +106,8 @@
 //
 //	security:
 //	- OAuth2 Bearer:
-//		- read:streaming
+//		- read:statuses
+//		- read:notifications
 //
 //	responses:
 //		'101':
@@ -152,10 +157,13 @@
 //			description: unauthorized
 //		'400':
 //			description: bad request
+//		'403':
+//			description: forbidden
 func (m *Module) StreamGETHandler(c *gin.Context) {
 	var (
 		token         string
 		tokenInHeader bool
+		scope         string
 		account       *gtsmodel.Account
 		errWithCode   gtserror.WithCode
 	)
@@ -177,7 +185,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	if token != "" {
 
 		// Token was provided, use it to authorize stream.
-		account, errWithCode = m.processor.Stream().Authorize(c.Request.Context(), token)
+		account, scope, errWithCode = m.processor.Stream().Authorize(c.Request.Context(), token)
 		if errWithCode != nil {
 			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 			return
@@ -194,8 +202,9 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 			return
 		}
 
-		// Set the auth'ed account.
+		// Set the auth'ed account and scope.
 		account = authed.Account
+		scope = authed.Token.GetScope()
 	}
 
 	if account.IsMoving() {
@@ -215,7 +224,31 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	if list := c.Query(StreamListKey); list != "" {
 		streamType += ":" + list
 	} else if tag := c.Query(StreamTagKey); tag != "" {
-		streamType += ":" + tag
+		tagName, ok := text.NormalizeHashtag(tag)
+		if !ok {
+			err := errors.New("invalid tag name")
+			errWithCode := gtserror.NewErrorBadRequest(err, err.Error())
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		// Tag names are stored lowercased, and
+		// hashtag stream types are keyed on them.
+		streamType += ":" + strings.ToLower(tagName)
+	} else if streamType == streampkg.TimelineHashtag ||
+		streamType == streampkg.TimelineHashtagLocal {
+		err := errors.New("tag must be provided for hashtag streams")
+		errWithCode := gtserror.NewErrorBadRequest(err, err.Error())
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if required := streamScope(streamType); streamType != "" && !required.Permits(scope) {
+		const help = "this action is outside the authorized scopes; required scope: "
+		err := fmt.Errorf("token scope %q does not permit required scope %s", scope, required)
+		errWithCode := gtserror.NewErrorForbidden(err, help+string(required))
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
 	}
 
 	// Open a stream with the processor; this lets processor
@@ -263,7 +296,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	// This prevents the upgrade handler from holding open any
 	// throttle / rate-limit request tokens which could become
 	// problematic on instances with multiple users.
-	go m.handleWSConn(&l, wsConn, stream)
+	go m.handleWSConn(&l, wsConn, stream, scope)
 }
 
 // handleWSConn handles a two-way websocket streaming connection.
@@ -271,7 +304,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 // into the connection. If any errors are encountered while reading
 // or writing (including expected errors like clients leaving), the
 // connection will be closed.
-func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *streampkg.Stream) {
+func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *streampkg.Stream, scope string) {
 	l.Info("opened websocket connection")
 
 	// Create new async context with cancel.
@@ -281,7 +314,7 @@ func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *stre
 		defer cncl()
 
 		// Read messages from websocket to server.
-		m.readFromWSConn(ctx, wsConn, stream, l)
+		m.readFromWSConn(ctx, wsConn, stream, scope, l)
 	}()
 
 	go func() {
@@ -310,6 +343,7 @@ func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *stre
 // readFromWSConn reads control messages coming in from the given
 // websockets connection, and modifies the subscription StreamTypes
 // of the given stream accordingly after acquiring a lock on it.
+// Subscriptions to stream types not permitted by scope are ignored.
 //
 // This is a blocking function; will return only on read error or
 // if the given context is canceled.
@@ -317,6 +351,7 @@ func (m *Module) readFromWSConn(
 	ctx context.Context,
 	wsConn *websocket.Conn,
 	stream *streampkg.Stream,
+	scope string,
 	l *log.Entry,
 ) {
 
@@ -325,6 +360,7 @@ func (m *Module) readFromWSConn(
 			Type   string `json:"type"`
 			Stream string `json:"stream"`
 			List   string `json:"list,omitempty"`
+			Tag    string `json:"tag,omitempty"`
 		}
 
 		// Read JSON objects from the client and act on them.
@@ -360,10 +396,28 @@ func (m *Module) readFromWSConn(
 			// the stream name as this is how we
 			// we track stream types internally.
 			msg.Stream += ":" + msg.List
+		} else if msg.Tag != "" {
+			// Same goes for tags, which
+			// should be normalized and
+			// lowercased first.
+			tagName, ok := text.NormalizeHashtag(msg.Tag)
+			if !ok {
+				l.Warnf("invalid 'tag' field: %v", msg)
+				continue
+			}
+			msg.Stream += ":" + strings.ToLower(tagName)
+		} else if msg.Stream == streampkg.TimelineHashtag ||
+			msg.Stream == streampkg.TimelineHashtagLocal {
+			l.Warnf("missing 'tag' field: %v", msg)
+			continue
 		}
 
 		switch msg.Type {
 		case "subscribe":
+			if !streamScope(msg.Stream).Permits(scope) {
+				l.Warnf("'stream' field not permitted by token scope: %v", msg)
+				continue
+			}
 			stream.Subscribe(msg.Stream)
 		case "unsubscribe":
 			stream.Unsubscribe(msg.Stream)
@@ -445,3 +499,12 @@ func (m *Module) writeToWSConn(
 		}
 	}
 }
+
+// streamScope returns the oauth scope that a
+// token must be granted to stream streamType.
+func streamScope(streamType string) oauth.Scope {
+	if streamType == streampkg.TimelineNotifications {
+		return oauth.ScopeReadNotifications
+	}
+	return oauth.ScopeReadStatuses
+}
@@ -59,5 +59,8 @@ func New(processor *processing.Processor, dTicker time.Duration, wsBuf int) *Mod
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	// Scopes are checked by the handler, as
+	// they depend on the requested stream(s),
+	// and tokens may be given as query params.
 	attachHandler(http.MethodGet, BasePath, m.StreamGETHandler)
 }
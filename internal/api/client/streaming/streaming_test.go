@@ -26,10 +26,13 @@
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -245,6 +248,50 @@ func (suite *StreamingTestSuite) TestSecurityHeader() {
 	}
 }
 
+func (suite *StreamingTestSuite) TestHashtagMixedCase() {
+	var (
+		token   = suite.testTokens["local_account_1"]
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	engine := gin.New()
+	engine.GET(streaming.BasePath, suite.streamingModule.StreamGETHandler)
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	// Open a hashtag stream using a mixed-case tag in the query.
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + streaming.BasePath +
+		"?stream=hashtag&tag=WelCome&access_token=" + url.QueryEscape(token.Access)
+	wsConn, rsp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer rsp.Body.Close()
+	defer wsConn.Close()
+
+	// Stream should be keyed on the lowercased tag name,
+	// as that's how tag names are stored in the database.
+	streams := suite.processor.Stream()
+	suite.Equal([]string{account.ID}, streams.SubscribedAccountIDs("hashtag:welcome"))
+	suite.Empty(streams.SubscribedAccountIDs("hashtag:WelCome"))
+
+	// Subscribe to another mixed-case tag over the websocket.
+	if err := wsConn.WriteJSON(map[string]string{
+		"type":   "subscribe",
+		"stream": "hashtag",
+		"tag":    "GoToSocial",
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !testrig.WaitFor(func() bool {
+		return len(streams.SubscribedAccountIDs("hashtag:gotosocial")) == 1
+	}) {
+		suite.FailNow("timed out waiting for hashtag subscription")
+	}
+	suite.Empty(streams.SubscribedAccountIDs("hashtag:GoToSocial"))
+}
+
 func TestStreamingTestSuite(t *testing.T) {
 	suite.Run(t, new(StreamingTestSuite))
 }
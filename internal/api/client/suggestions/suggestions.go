@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package suggestions
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+const (
+	// BasePathV1WithID is the path for dismissing one suggestion,
+	// as used by Mastodon clients, minus the 'api' prefix.
+	BasePathV1WithID = "/v1/suggestions/:" + apiutil.IDKey
+	// BasePathV2 is the base path for serving follow suggestions, minus the 'api' prefix.
+	BasePathV2 = "/v2/suggestions"
+	// BasePathV2WithID is the path for dismissing one suggestion, minus the 'api' prefix.
+	BasePathV2WithID = BasePathV2 + "/:" + apiutil.IDKey
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, BasePathV2, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.SuggestionsGETHandler)
+	attachHandler(http.MethodDelete, BasePathV1WithID, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.SuggestionDELETEHandler)
+	attachHandler(http.MethodDelete, BasePathV2WithID, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.SuggestionDELETEHandler)
+}
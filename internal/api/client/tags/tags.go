@@ -22,6 +22,8 @@
 
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -43,7 +45,7 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodGet, TagPath, m.TagGETHandler)
-	attachHandler(http.MethodPost, FollowPath, m.FollowTagPOSTHandler)
-	attachHandler(http.MethodPost, UnfollowPath, m.UnfollowTagPOSTHandler)
+	attachHandler(http.MethodGet, TagPath, middleware.ScopeCheck(oauth.ScopeReadFollows), m.TagGETHandler)
+	attachHandler(http.MethodPost, FollowPath, middleware.ScopeCheck(oauth.ScopeWriteFollows), m.FollowTagPOSTHandler)
+	attachHandler(http.MethodPost, UnfollowPath, middleware.ScopeCheck(oauth.ScopeWriteFollows), m.UnfollowTagPOSTHandler)
 }
@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tokens_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tokens"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+// invalidateToken invalidates the given token.
+func (suite *TokensTestSuite) invalidateToken(
+	accountKey string,
+	id string,
+	expectedHTTPStatus int,
+) string {
+	recorder := httptest.NewRecorder()
+	path := "api" + strings.Replace(tokens.InvalidatePath, ":"+apiutil.IDKey, id, 1)
+	ctx := suite.newContext(recorder, accountKey, http.MethodPost, nil, path, "")
+	ctx.Params = gin.Params{{Key: apiutil.IDKey, Value: id}}
+
+	suite.tokensModule.TokenInvalidatePOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(expectedHTTPStatus, result.StatusCode)
+	return string(b)
+}
+
+func (suite *TokensTestSuite) TestInvalidateToken() {
+	token := suite.testTokens["local_account_1"]
+	resp := suite.invalidateToken("local_account_1", token.ID, http.StatusOK)
+	suite.Equal(`{"id":"01F8MGTQW4DKTDF8SW5CT9HYGA","created_at":"2022-06-10T15:22:08.000Z","scope":"read write follow push","application":{"name":"really cool gts application","website":"https://reallycool.app"}}`, resp)
+
+	// Token should be gone.
+	_, err := suite.db.GetTokenByID(context.Background(), token.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+// Tokens of other users should not be invalidatable.
+func (suite *TokensTestSuite) TestInvalidateTokenOtherUser() {
+	token := suite.testTokens["local_account_1"]
+	resp := suite.invalidateToken("local_account_2", token.ID, http.StatusNotFound)
+	suite.Equal(`{"error":"Not Found: token not found"}`, resp)
+
+	// Token should still be there.
+	_, err := suite.db.GetTokenByID(context.Background(), token.ID)
+	suite.NoError(err)
+}
@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tokens
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+const (
+	// BasePath is the base path for serving the tokens API, minus the 'api' prefix
+	BasePath = "/v1/tokens"
+	// BasePathWithID is the base path with the ID key in it, for operations on a single token.
+	BasePathWithID = BasePath + "/:" + apiutil.IDKey
+	// InvalidatePath is for invalidating a single token.
+	InvalidatePath = BasePathWithID + "/invalidate"
+	// InvalidateAllPath is for invalidating all of the caller's tokens, except the current one.
+	InvalidateAllPath = BasePath + "/invalidate_all"
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, BasePath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.TokensGETHandler)
+	attachHandler(http.MethodGet, BasePathWithID, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.TokenGETHandler)
+	attachHandler(http.MethodPost, InvalidatePath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.TokenInvalidatePOSTHandler)
+	attachHandler(http.MethodPost, InvalidateAllPath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.TokensInvalidateAllPOSTHandler)
+}
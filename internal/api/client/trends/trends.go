@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+const (
+	// BasePath is the base path for serving the trends API, minus the 'api' prefix.
+	// Mastodon serves trending tags here too, for backwards compatibility.
+	BasePath = "/v1/trends"
+	// TagsPath is for serving trending tags.
+	TagsPath = BasePath + "/tags"
+	// StatusesPath is for serving trending statuses.
+	StatusesPath = BasePath + "/statuses"
+	// LinksPath is for serving trending links.
+	LinksPath = BasePath + "/links"
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, BasePath, middleware.ScopeCheck(oauth.ScopeRead), m.TrendsTagsGETHandler)
+	attachHandler(http.MethodGet, TagsPath, middleware.ScopeCheck(oauth.ScopeRead), m.TrendsTagsGETHandler)
+	attachHandler(http.MethodGet, StatusesPath, middleware.ScopeCheck(oauth.ScopeReadStatuses), m.TrendsStatusesGETHandler)
+	attachHandler(http.MethodGet, LinksPath, middleware.ScopeCheck(oauth.ScopeRead), m.TrendsLinksGETHandler)
+}
@@ -0,0 +1,328 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+const (
+	OIDCTwoFactorHelp     = "two-factor authentication cannot be managed by GoToSocial as this instance is running with OIDC enabled; you must configure 2FA using your OIDC provider"
+	SettingsTwoFactorHelp = "two-factor authentication can only be managed through the settings panel"
+)
+
+// TwoFactorSecretPOSTHandler swagger:operation POST /api/v1/user/2fa/secret userTwoFactorSecret
+//
+// Generate a new TOTP secret for the authenticated user.
+//
+// The returned secret (and provisioning URI, which can be rendered as a QR code)
+// should be added to an authenticator app. Two-factor authentication is not
+// enabled until a code generated using the secret is POSTed to /api/v1/user/2fa/enable.
+//
+// Calling this endpoint again before enabling 2FA replaces the previous secret.
+//
+// Only tokens issued to the settings panel may use this endpoint.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+//	---
+//	tags:
+//	- user
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: password
+//		in: formData
+//		description: User's current password, for verification.
+//		type: string
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'200':
+//			description: The new secret.
+//			schema:
+//				"$ref": "#/definitions/twoFactorSecret"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized, or password was incorrect
+//		'403':
+//			description: token was not issued to the settings panel
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: 2FA is already enabled for this user
+//		'422':
+//			description: unprocessable request because instance is running with OIDC backend
+//		'500':
+//			description: internal error
+func (m *Module) TwoFactorSecretPOSTHandler(c *gin.Context) {
+	authed, errWithCode := m.twoFactorAuthed(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.TwoFactorSecretRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Password == "" {
+		err := errors.New("2fa secret request missing field password")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	secret, errWithCode := m.processor.User().TwoFactorSecretCreate(c.Request.Context(), authed.User, form.Password)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, secret)
+}
+
+// TwoFactorEnablePOSTHandler swagger:operation POST /api/v1/user/2fa/enable userTwoFactorEnable
+//
+// Enable two-factor authentication for the authenticated user, by confirming a code generated using the secret from /api/v1/user/2fa/secret.
+//
+// On success, a list of one-time recovery codes is returned. These won't be shown again,
+// and can each be used once in place of a TOTP code when signing in.
+//
+// Only tokens issued to the settings panel may use this endpoint.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+//	---
+//	tags:
+//	- user
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: password
+//		in: formData
+//		description: User's current password, for verification.
+//		type: string
+//		required: true
+//	-
+//		name: code
+//		in: formData
+//		description: Current TOTP code generated using the 2FA secret.
+//		type: string
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'200':
+//			description: 2FA enabled.
+//			schema:
+//				"$ref": "#/definitions/twoFactorRecoveryCodes"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized, or password was incorrect
+//		'403':
+//			description: code was incorrect, or token was not issued to the settings panel
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: 2FA is already enabled for this user
+//		'422':
+//			description: no secret created yet, or instance is running with OIDC backend
+//		'500':
+//			description: internal error
+func (m *Module) TwoFactorEnablePOSTHandler(c *gin.Context) {
+	authed, errWithCode := m.twoFactorAuthed(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.TwoFactorEnableRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Password == "" {
+		err := errors.New("2fa enable request missing field password")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Code == "" {
+		err := errors.New("2fa enable request missing field code")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	codes, errWithCode := m.processor.User().TwoFactorEnable(c.Request.Context(), authed.User, form.Password, form.Code)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, codes)
+}
+
+// TwoFactorDisablePOSTHandler swagger:operation POST /api/v1/user/2fa/disable userTwoFactorDisable
+//
+// Disable two-factor authentication for the authenticated user.
+//
+// Only tokens issued to the settings panel may use this endpoint.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+//	---
+//	tags:
+//	- user
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: password
+//		in: formData
+//		description: User's current password, for verification.
+//		type: string
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'200':
+//			description: 2FA disabled.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized, or password was incorrect
+//		'403':
+//			description: token was not issued to the settings panel
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: 2FA is not enabled for this user
+//		'422':
+//			description: unprocessable request because instance is running with OIDC backend
+//		'500':
+//			description: internal error
+func (m *Module) TwoFactorDisablePOSTHandler(c *gin.Context) {
+	authed, errWithCode := m.twoFactorAuthed(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.TwoFactorDisableRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Password == "" {
+		err := errors.New("2fa disable request missing field password")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := m.processor.User().TwoFactorDisable(c.Request.Context(), authed.User, form.Password); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.Data(c, http.StatusOK, apiutil.AppJSON, apiutil.StatusOKJSON)
+}
+
+// twoFactorAuthed performs the checks common
+// to all 2FA management handlers, returning
+// the authed user's details if all is well.
+//
+// 2FA may only be managed using tokens issued
+// to the settings panel, so that third-party
+// apps can't lock the user out of their account.
+func (m *Module) twoFactorAuthed(c *gin.Context) (*oauth.Auth, gtserror.WithCode) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		return nil, gtserror.NewErrorUnauthorized(err, err.Error())
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		return nil, gtserror.NewErrorNotAcceptable(err, err.Error())
+	}
+
+	if config.GetOIDCEnabled() {
+		err := errors.New("instance running with OIDC")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, OIDCTwoFactorHelp)
+	}
+
+	// The settings panel registers its app with
+	// itself as redirect URI, so only the settings
+	// panel can be given tokens for such an app.
+	settingsURI := config.GetProtocol() + "://" + config.GetHost() + "/settings"
+	if authed.Application.RedirectURI != settingsURI {
+		err := fmt.Errorf("app %s is not the settings panel", authed.Application.ID)
+		return nil, gtserror.NewErrorForbidden(err, SettingsTwoFactorHelp)
+	}
+
+	return authed, nil
+}
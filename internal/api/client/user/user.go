@@ -21,6 +21,8 @@
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -31,6 +33,12 @@
 	PasswordChangePath = BasePath + "/password_change"
 	// EmailChangePath is the path for POSTing an email address change request.
 	EmailChangePath = BasePath + "/email_change"
+	// TwoFactorSecretPath is the path for POSTing a request for a new 2FA secret.
+	TwoFactorSecretPath = BasePath + "/2fa/secret"
+	// TwoFactorEnablePath is the path for POSTing a 2FA code to enable 2FA.
+	TwoFactorEnablePath = BasePath + "/2fa/enable"
+	// TwoFactorDisablePath is the path for POSTing a request to disable 2FA.
+	TwoFactorDisablePath = BasePath + "/2fa/disable"
 )
 
 type Module struct {
@@ -44,7 +52,10 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodGet, BasePath, m.UserGETHandler)
-	attachHandler(http.MethodPost, PasswordChangePath, m.PasswordChangePOSTHandler)
-	attachHandler(http.MethodPost, EmailChangePath, m.EmailChangePOSTHandler)
+	attachHandler(http.MethodGet, BasePath, middleware.ScopeCheck(oauth.ScopeReadAccounts), m.UserGETHandler)
+	attachHandler(http.MethodPost, PasswordChangePath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.PasswordChangePOSTHandler)
+	attachHandler(http.MethodPost, EmailChangePath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.EmailChangePOSTHandler)
+	attachHandler(http.MethodPost, TwoFactorSecretPath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.TwoFactorSecretPOSTHandler)
+	attachHandler(http.MethodPost, TwoFactorEnablePath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.TwoFactorEnablePOSTHandler)
+	attachHandler(http.MethodPost, TwoFactorDisablePath, middleware.ScopeCheck(oauth.ScopeWriteAccounts), m.TwoFactorDisablePOSTHandler)
 }
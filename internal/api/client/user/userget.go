@@ -39,7 +39,7 @@
 //
 //	security:
 //	- OAuth2 Bearer:
-//		- read:user
+//		- read:accounts
 //
 //	responses:
 //		'200':
@@ -19,7 +19,7 @@
 
 // Announcement models an admin announcement for the instance.
 //
-// TODO: swagger:model announcement once announcement API is supported
+// swagger:model announcement
 type Announcement struct {
 	// The ID of the announcement.
 	// example: 01FC30T7X4TNCZK0TH90QYF3M4
@@ -28,6 +28,10 @@ type Announcement struct {
 	// Should be HTML formatted.
 	// example: <p>This is an announcement. No malarky.</p>
 	Content string `json:"content"`
+	// The original markdown text of the announcement.
+	// Only shown to admins.
+	// example: This is an announcement. No malarky.
+	Text string `json:"text,omitempty"`
 	// When the announcement should begin to be displayed (ISO 8601 Datetime).
 	// If the announcement has no start time, this will be omitted or empty.
 	// example: 2021-07-30T09:20:25+00:00
@@ -60,3 +64,37 @@ type Announcement struct {
 	// Reactions to this announcement.
 	Reactions []AnnouncementReaction `json:"reactions"`
 }
+
+// AnnouncementCreateRequest models a request to create an announcement.
+//
+// swagger:ignore
+type AnnouncementCreateRequest struct {
+	// Text of the announcement, parsed as markdown.
+	Text string `form:"text" json:"text"`
+	// When the event described by the announcement starts (ISO 8601 Datetime).
+	StartsAt string `form:"starts_at" json:"starts_at"`
+	// When the event described by the announcement ends (ISO 8601 Datetime).
+	EndsAt string `form:"ends_at" json:"ends_at"`
+	// StartsAt and EndsAt should be treated as dates rather than times.
+	AllDay bool `form:"all_day" json:"all_day"`
+	// Publish the announcement immediately.
+	Published bool `form:"published" json:"published"`
+}
+
+// AnnouncementUpdateRequest models a request to update an announcement.
+//
+// swagger:ignore
+type AnnouncementUpdateRequest struct {
+	// Text of the announcement, parsed as markdown.
+	Text *string `form:"text" json:"text"`
+	// When the event described by the announcement starts (ISO 8601 Datetime).
+	// Empty string to unset.
+	StartsAt *string `form:"starts_at" json:"starts_at"`
+	// When the event described by the announcement ends (ISO 8601 Datetime).
+	// Empty string to unset.
+	EndsAt *string `form:"ends_at" json:"ends_at"`
+	// StartsAt and EndsAt should be treated as dates rather than times.
+	AllDay *bool `form:"all_day" json:"all_day"`
+	// Publish or unpublish the announcement.
+	Published *bool `form:"published" json:"published"`
+}
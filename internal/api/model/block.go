@@ -23,3 +23,12 @@ type BlocksResponse struct {
 	Accounts   []*Account
 	LinkHeader string
 }
+
+// DomainBlockRequest is the form submitted as a POST or DELETE
+// to /api/v1/domain_blocks to block or unblock a domain.
+//
+// swagger:ignore
+type DomainBlockRequest struct {
+	// Domain to block or unblock.
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+}
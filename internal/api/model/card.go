@@ -30,6 +30,9 @@ type Card struct {
 	// Description of preview.
 	// example: Is water wet? We're not sure. In this article, we ask an expert...
 	Description string `json:"description"`
+	// Language of linked resource (ISO 639 Part 1 two-letter language code), if known.
+	// example: en
+	Language string `json:"language"`
 	// The type of the preview card.
 	// enum:
 	// - link
@@ -55,6 +55,9 @@ type DomainPermission struct {
 	// If applicable, the ID of the subscription that caused this domain permission entry to be created.
 	// example: 01FBW25TF5J67JW3HFHZCSD23K
 	SubscriptionID string `json:"subscription_id,omitempty"`
+	// The type of this domain permission entry, if it's a draft. One of "block" or "allow".
+	// example: block
+	PermissionType string `json:"permission_type,omitempty"`
 	// ID of the account that created this domain permission entry.
 	// example: 01FBW2758ZB6PBR200YPDDJK4C
 	CreatedBy string `json:"created_by,omitempty"`
@@ -85,6 +88,31 @@ type DomainPermissionRequest struct {
 	// Will be visible to requesters at /api/v1/instance/peers if this endpoint is exposed.
 	// example: foss dorks 😫
 	PublicComment string `form:"public_comment" json:"public_comment" xml:"public_comment"`
+	// Type of permission to create, if this is a draft. One of "block" or "allow".
+	// Ignored when creating a domain block or domain allow directly.
+	// example: block
+	PermissionType string `form:"permission_type" json:"permission_type" xml:"permission_type"`
+}
+
+// DomainPermissionDraftAcceptRequest is the form submitted as a POST
+// to /api/v1/admin/domain_permission_drafts/{id}/accept to accept a draft.
+//
+// swagger:ignore
+type DomainPermissionDraftAcceptRequest struct {
+	// If a domain permission of the same type already exists
+	// for the draft's domain, replace it with the draft.
+	Overwrite bool `form:"overwrite" json:"overwrite" xml:"overwrite"`
+}
+
+// DomainPermissionDraftRejectRequest is the form submitted as a POST
+// to /api/v1/admin/domain_permission_drafts/{id}/reject to reject a draft.
+//
+// swagger:ignore
+type DomainPermissionDraftRejectRequest struct {
+	// Also create a domain permission exclude entry
+	// for the draft's domain, so that it won't be
+	// drafted again by imports or subscriptions.
+	ExcludeTarget bool `form:"exclude_target" json:"exclude_target" xml:"exclude_target"`
 }
 
 // DomainKeysExpireRequest is the form submitted as a POST to /api/v1/admin/domain_keys_expire to expire a domain's public keys.
@@ -59,6 +59,11 @@ type AccountExportStats struct {
 	//
 	// example: 11
 	MutesCount int `json:"mutes_count"`
+
+	// Number of domains blocked by this account.
+	//
+	// example: 4
+	DomainBlocksCount int `json:"domain_blocks_count"`
 }
 
 // AttachmentRequest models media attachment creation parameters.
@@ -72,6 +77,7 @@ type ImportRequest struct {
 	//	- `following` - accounts to follow.
 	//	- `lists` - lists of accounts.
 	//	- `blocks` - accounts to block.
+	//	- `domain_blocks` - domains to block.
 	//	- `mutes` - accounts to mute.
 	//	- `bookmarks` - statuses to bookmark.
 	Type string `form:"type" binding:"required"`
@@ -80,3 +86,90 @@ type ImportRequest struct {
 	//	- `overwrite` to replace existing entries with entries in file.
 	Mode string `form:"mode"`
 }
+
+// Import models the progress of processing
+// one CSV data file uploaded to /api/v1/import.
+//
+// swagger:model import
+type Import struct {
+	// The ID of the import.
+	// example: 01JHQZ6N3G2W6DK8FZ5ZX9E8AM
+	ID string `json:"id"`
+
+	// Type of entries contained in the data file.
+	// example: following
+	Type string `json:"type"`
+
+	// Mode used when creating entries from the data file.
+	// example: merge
+	Mode string `json:"mode"`
+
+	// State of processing the import, either
+	// `processing`, `finished` or `failed`.
+	// example: processing
+	State string `json:"state"`
+
+	// Time the data was uploaded (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+
+	// Time the import was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+
+	// Number of entries parsed from the data file.
+	// example: 50
+	TotalItems int `json:"total_items"`
+
+	// Number of entries processed so far,
+	// whether successfully or not.
+	// example: 20
+	ProcessedItems int `json:"processed_items"`
+
+	// Number of entries that could
+	// not be imported.
+	// example: 2
+	FailedItems int `json:"failed_items"`
+
+	// Errors encountered processing entries.
+	// Only the first errors are stored.
+	Errors []string `json:"errors"`
+}
+
+// AccountArchive represents one archive of
+// a local account's profile, statuses, likes,
+// bookmarks and media, as a zip file.
+//
+// swagger:model accountArchive
+type AccountArchive struct {
+	// The ID of the archive.
+	// example: 01JHQZ6N3G2W6DK8FZ5ZX9E8AM
+	ID string `json:"id"`
+
+	// State of building the archive,
+	// either `processing`, `ready` or `failed`.
+	// example: ready
+	State string `json:"state"`
+
+	// Time the archive was requested (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+
+	// Time the archive was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+
+	// Time the archive expires and will be
+	// removed (ISO 8601 Datetime), once ready.
+	// example: 2021-08-06T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+
+	// Size of the zip file in bytes, once ready.
+	// example: 1048576
+	Size int64 `json:"size"`
+
+	// URL at which the zip file
+	// can be downloaded, once ready.
+	// example: https://example.org/api/v1/exports/archive/01JHQZ6N3G2W6DK8FZ5ZX9E8AM/download
+	URL *string `json:"url"`
+}
@@ -152,6 +152,15 @@ type InstanceV2ConfigurationTranslation struct {
 	Enabled bool `json:"enabled"`
 }
 
+// Instance configuration pertaining to Web Push.
+//
+// swagger:model instanceV2ConfigurationVAPID
+type InstanceV2ConfigurationVAPID struct {
+	// The instance's VAPID public key, used to verify
+	// Web Push notifications sent by this instance.
+	PublicKey string `json:"public_key"`
+}
+
 // Configured values and limits for this instance.
 //
 // swagger:model instanceV2Configuration
@@ -170,6 +179,8 @@ type InstanceV2Configuration struct {
 	Translation InstanceV2ConfigurationTranslation `json:"translation"`
 	// Instance configuration pertaining to emojis.
 	Emojis InstanceConfigurationEmojis `json:"emojis"`
+	// Instance configuration pertaining to Web Push.
+	VAPID InstanceV2ConfigurationVAPID `json:"vapid"`
 	// True if instance is running with OIDC as auth/identity backend, else omitted.
 	OIDCEnabled bool `json:"oidc_enabled,omitempty"`
 }
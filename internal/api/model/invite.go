@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Invite represents an invite link that can
+// be used to sign up to this instance, even
+// when registration is otherwise closed.
+//
+// swagger:model invite
+type Invite struct {
+	// ID of the invite.
+	ID string `json:"id"`
+	// Code that must be provided to sign up with this invite.
+	// example: f00dbeefcafe
+	Code string `json:"code"`
+	// Web URL of the signup page for this invite.
+	// example: https://example.org/signup?invite=f00dbeefcafe
+	URL string `json:"url"`
+	// ISO 8601 Datetime at which the invite was created.
+	CreatedAt string `json:"created_at"`
+	// ISO 8601 Datetime at which the invite expires.
+	// Null if the invite doesn't expire.
+	ExpiresAt *string `json:"expires_at"`
+	// Maximum number of signups allowed with this invite.
+	// Null if the invite can be used any number of times.
+	MaxUses *int `json:"max_uses"`
+	// Number of times the invite has been used to sign up.
+	Uses int `json:"uses"`
+	// Accounts signing up with this invite will be approved automatically.
+	AutoApprove bool `json:"auto_approve"`
+	// The invite has expired, or has no uses left.
+	Expired bool `json:"expired"`
+}
+
+// InviteCreateRequest models a request to create an invite.
+//
+// swagger:ignore
+type InviteCreateRequest struct {
+	// Maximum number of signups allowed with this
+	// invite. 0 or not set means unlimited.
+	MaxUses int `form:"max_uses" json:"max_uses"`
+	// Number of seconds from now after which the
+	// invite will expire. 0 or not set means never.
+	ExpiresIn int `form:"expires_in" json:"expires_in"`
+	// Accounts signing up with this invite should be
+	// approved automatically. Moderators and admins only.
+	AutoApprove bool `form:"auto_approve" json:"auto_approve"`
+}
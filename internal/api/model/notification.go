@@ -34,6 +34,8 @@ type Notification struct {
 	// 	poll = A poll you have voted in or created has ended. `status` will be set. `account` will be set.
 	// 	status = Someone you enabled notifications for has posted a status. `status` will be set. `account` will be set.
 	// 	admin.sign_up = Someone has signed up for a new account on the instance. `account` will be set.
+	// 	quote = Someone quoted one of your statuses. `status` will be set to the quoting status. `account` will be set.
+	// 	pending.quote = Someone quoted one of your statuses, pending your approval. `status` will be set to the quoting status. `account` will be set.
 	Type string `json:"type"`
 	// The timestamp of the notification (ISO 8601 Datetime)
 	CreatedAt string `json:"created_at"`
@@ -46,6 +48,66 @@ type Notification struct {
 	Status *Status `json:"status,omitempty"`
 }
 
+// NotificationGroup represents a group of notifications of the same
+// type, eg., favourites of one status, or follows, that happened close
+// together in time. Notifications that can't be grouped are returned
+// in a group of their own.
+//
+// swagger:model notificationGroup
+type NotificationGroup struct {
+	// Key identifying this group. Ungrouped notifications
+	// have a key of the form "ungrouped-[notification id]".
+	GroupKey string `json:"group_key"`
+	// Total number of notifications in this group.
+	NotificationsCount int `json:"notifications_count"`
+	// The type of event that resulted in the notifications in this group.
+	// See notification.type for possible values.
+	Type string `json:"type"`
+	// ID of the most recent notification in this group.
+	MostRecentNotificationID string `json:"most_recent_notification_id"`
+	// ID of the oldest notification from this group within the current page.
+	// Only set when getting a page of notification groups.
+	PageMinID string `json:"page_min_id,omitempty"`
+	// ID of the newest notification from this group within the current page.
+	// Only set when getting a page of notification groups.
+	PageMaxID string `json:"page_max_id,omitempty"`
+	// Timestamp of the newest notification from this group within the current
+	// page (ISO 8601 Datetime). Only set when getting a page of notification groups.
+	LatestPageNotificationAt string `json:"latest_page_notification_at,omitempty"`
+	// IDs of some of the accounts that performed the actions that generated
+	// the notifications in this group, most recent first. Accounts are
+	// included in the accounts of the returned grouped notifications.
+	SampleAccountIDs []string `json:"sample_account_ids"`
+	// ID of the status that was the object of the notifications in this group,
+	// if any. The status is included in the statuses of the returned grouped
+	// notifications.
+	StatusID string `json:"status_id,omitempty"`
+}
+
+// GroupedNotificationsResults contains notification groups, and the
+// accounts and statuses referenced by those groups, deduplicated.
+//
+// swagger:model groupedNotificationsResults
+type GroupedNotificationsResults struct {
+	// Accounts referenced by the notification groups.
+	Accounts []*Account `json:"accounts"`
+	// Statuses referenced by the notification groups.
+	Statuses []*Status `json:"statuses"`
+	// The notification groups.
+	NotificationGroups []*NotificationGroup `json:"notification_groups"`
+}
+
+// NotificationsUnreadCount contains the
+// count of unread notifications (or groups).
+//
+// swagger:model notificationsUnreadCount
+type NotificationsUnreadCount struct {
+	// Number of unread notifications, or notification groups
+	// if requested from the grouped notifications API, up to
+	// the requested limit.
+	Count int `json:"count"`
+}
+
 /*
 	The below functions are added onto the apimodel notification so that it satisfies
 	the Timelineable interface in internal/timeline.
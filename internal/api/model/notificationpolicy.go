@@ -0,0 +1,101 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// NotificationPolicy represents the settings of an account
+// for which notifications should be accepted, filtered into
+// notification requests, or dropped, based on who they're from.
+//
+// Each policy is one of `accept`, `filter` or `drop`.
+//
+// swagger:model notificationPolicy
+type NotificationPolicy struct {
+	// What to do with notifications from accounts you don't follow.
+	// example: accept
+	ForNotFollowing string `json:"for_not_following"`
+	// What to do with notifications from accounts that don't follow you.
+	// example: accept
+	ForNotFollowers string `json:"for_not_followers"`
+	// What to do with notifications from accounts created in the past 30 days.
+	// example: accept
+	ForNewAccounts string `json:"for_new_accounts"`
+	// What to do with private mentions from accounts you don't follow,
+	// unless they're a reply to one of your statuses.
+	// example: filter
+	ForPrivateMentions string `json:"for_private_mentions"`
+	// What to do with notifications from accounts limited by moderators.
+	// example: filter
+	ForLimitedAccounts string `json:"for_limited_accounts"`
+	// Summary of filtered notifications.
+	Summary NotificationPolicySummary `json:"summary"`
+}
+
+// NotificationPolicySummary summarizes the
+// filtered notifications of an account.
+//
+// swagger:model notificationPolicySummary
+type NotificationPolicySummary struct {
+	// Number of pending notification requests.
+	PendingRequestsCount int `json:"pending_requests_count"`
+	// Number of filtered notifications in pending notification requests.
+	PendingNotificationsCount int `json:"pending_notifications_count"`
+}
+
+// NotificationPolicyUpdateRequest models a request to
+// update a notification policy. Unset fields are unchanged.
+//
+// swagger:ignore
+type NotificationPolicyUpdateRequest struct {
+	ForNotFollowing    *string `form:"for_not_following" json:"for_not_following"`
+	ForNotFollowers    *string `form:"for_not_followers" json:"for_not_followers"`
+	ForNewAccounts     *string `form:"for_new_accounts" json:"for_new_accounts"`
+	ForPrivateMentions *string `form:"for_private_mentions" json:"for_private_mentions"`
+	ForLimitedAccounts *string `form:"for_limited_accounts" json:"for_limited_accounts"`
+}
+
+// NotificationRequest represents the filtered
+// notifications from one account, which can be
+// accepted or dismissed together.
+//
+// swagger:model notificationRequest
+type NotificationRequest struct {
+	// ID of the notification request.
+	ID string `json:"id"`
+	// ISO 8601 Datetime at which the notification request was created.
+	CreatedAt string `json:"created_at"`
+	// ISO 8601 Datetime at which the notification request was last updated.
+	UpdatedAt string `json:"updated_at"`
+	// The account that sent the filtered notifications.
+	Account *Account `json:"account"`
+	// Number of filtered notifications in this request,
+	// as a string for compatibility with other software.
+	// example: 5
+	NotificationsCount string `json:"notifications_count"`
+	// Status of the most recent filtered notification
+	// that had one, if it's still there and visible.
+	LastStatus *Status `json:"last_status,omitempty"`
+}
+
+// NotificationRequestsBulkRequest models a request
+// to accept or dismiss several notification requests.
+//
+// swagger:ignore
+type NotificationRequestsBulkRequest struct {
+	// IDs of the notification requests.
+	IDs []string `form:"id[]" json:"id"`
+}
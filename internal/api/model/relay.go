@@ -0,0 +1,73 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminRelay represents one relay followed by the instance actor.
+//
+// swagger:model adminRelay
+type AdminRelay struct {
+	// The ID of the relay.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// Inbox URL of the relay.
+	// example: https://relay.example.org/inbox
+	InboxURL string `json:"inbox_url"`
+
+	// ActivityPub actor URL of the relay.
+	// Only set once the relay has accepted the instance's follow.
+	// example: https://relay.example.org/actor
+	// readonly: true
+	ActorURL string `json:"actor_url,omitempty"`
+
+	// State of the instance's follow of the relay.
+	// One of "pending", "accepted", or "rejected".
+	// example: accepted
+	// readonly: true
+	State string `json:"state"`
+
+	// Most recent error encountered when following
+	// or delivering to the relay, if any.
+	// example: relay responded with 502 Bad Gateway
+	// readonly: true
+	Error string `json:"error,omitempty"`
+
+	// The ID of the admin account that added this relay.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+
+	// Time at which the relay was added (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+
+	// Time at which the relay was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	UpdatedAt string `json:"updated_at"`
+}
+
+// AdminRelayCreateRequest is the form submitted as a POST to add a new relay.
+//
+// swagger:ignore
+type AdminRelayCreateRequest struct {
+	// Inbox URL of the relay.
+	InboxURL string `form:"inbox_url" json:"inbox_url"`
+}
@@ -18,21 +18,74 @@
 package model
 
 // ScheduledStatus represents a status that will be published at a future scheduled date.
+//
+// swagger:model scheduledStatus
 type ScheduledStatus struct {
-	ID               string        `json:"id"`
-	ScheduledAt      string        `json:"scheduled_at"`
-	Params           *StatusParams `json:"params"`
-	MediaAttachments []Attachment  `json:"media_attachments"`
+	// ID of the scheduled status.
+	ID string `json:"id"`
+	// ISO 8601 Datetime at which the status will be published.
+	ScheduledAt string `json:"scheduled_at"`
+	// Parameters that will be used to create the status.
+	Params *StatusParams `json:"params"`
+	// Media that will be attached to the status.
+	MediaAttachments []*Attachment `json:"media_attachments"`
 }
 
 // StatusParams represents parameters for a scheduled status.
+//
+// swagger:model scheduledStatusParams
 type StatusParams struct {
-	Text          string   `json:"text"`
-	InReplyToID   string   `json:"in_reply_to_id,omitempty"`
-	MediaIDs      []string `json:"media_ids,omitempty"`
-	Sensitive     bool     `json:"sensitive,omitempty"`
-	SpoilerText   string   `json:"spoiler_text,omitempty"`
-	Visibility    string   `json:"visibility"`
-	ScheduledAt   string   `json:"scheduled_at,omitempty"`
-	ApplicationID string   `json:"application_id"`
+	// Text of the status.
+	Text string `json:"text"`
+	// Poll to be attached to the status, if any.
+	Poll *StatusParamsPoll `json:"poll"`
+	// IDs of media to be attached to the status.
+	MediaIDs []string `json:"media_ids"`
+	// Status and attached media should be marked as sensitive.
+	Sensitive bool `json:"sensitive"`
+	// Text to be shown as a warning or subject before the actual content.
+	SpoilerText string `json:"spoiler_text"`
+	// Visibility of the status.
+	Visibility Visibility `json:"visibility"`
+	// Status will not be federated.
+	LocalOnly bool `json:"local_only"`
+	// ID of the status being replied to, if any.
+	InReplyToID string `json:"in_reply_to_id"`
+	// ID of the status being quoted, if any.
+	QuoteID string `json:"quote_id,omitempty"`
+	// ISO 639 language code of the status.
+	Language string `json:"language"`
+	// Content type to parse the status text with.
+	ContentType StatusContentType `json:"content_type"`
+	// Interaction policy to use for the status, if given.
+	InteractionPolicy *InteractionPolicy `json:"interaction_policy"`
+	// ID of the application used to schedule the status.
+	ApplicationID string `json:"application_id"`
+	// Always null, since the status is published at the top-level scheduled_at.
+	ScheduledAt *string `json:"scheduled_at"`
+}
+
+// StatusParamsPoll represents the parameters
+// of a poll to be attached to a scheduled status.
+//
+// swagger:model scheduledStatusParamsPoll
+type StatusParamsPoll struct {
+	// Possible answers to the poll.
+	Options []string `json:"options"`
+	// Duration the poll should be open, in seconds.
+	ExpiresIn int `json:"expires_in"`
+	// Allow multiple choices on this poll.
+	Multiple bool `json:"multiple"`
+	// Hide vote counts until the poll ends.
+	HideTotals bool `json:"hide_totals"`
+}
+
+// ScheduledStatusUpdateRequest models a request
+// to change the publication time of a scheduled status.
+//
+// swagger:ignore
+type ScheduledStatusUpdateRequest struct {
+	// ISO 8601 Datetime at which the status should be published.
+	// Must be at least 5 minutes in the future.
+	ScheduledAt string `form:"scheduled_at" json:"scheduled_at"`
 }
@@ -29,6 +29,11 @@ type Status struct {
 	// The date when this status was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at"`
+	// Timestamp of when the status was last edited (ISO 8601 Datetime).
+	// Will be null if the status has never been edited.
+	// example: 2021-07-30T09:20:25+00:00
+	// nullable: true
+	EditedAt *string `json:"edited_at"`
 	// ID of the status being replied to.
 	// example: 01FBVD42CQ3ZEEVMW180SBX03B
 	// nullable: true
@@ -106,6 +111,9 @@ type Status struct {
 	Filtered []FilterResult `json:"filtered,omitempty"`
 	// The interaction policy for this status, as set by the status author.
 	InteractionPolicy InteractionPolicy `json:"interaction_policy"`
+	// The status that this status quotes, if any.
+	// nullable: true
+	Quote *StatusQuote `json:"quote,omitempty"`
 }
 
 // WebStatus is like *model.Status, but contains
@@ -130,6 +138,14 @@ type WebStatus struct {
 	// calculated as a percentage of total votes.
 	PollOptions []WebPollOption
 
+	// Previous revisions of this status,
+	// oldest first, if it has been edited.
+	Edits []*StatusEdit
+
+	// The status this status quotes, if
+	// the quote is accepted and visible.
+	QuotedStatus *WebStatus
+
 	// Status is from a local account.
 	Local bool
 
@@ -189,6 +205,30 @@ type StatusReblogged struct {
 	*Status
 }
 
+// StatusQuote represents the quote of another status.
+//
+// swagger:model statusQuote
+type StatusQuote struct {
+	// State of the quote.
+	// Only accepted quotes include the quoted status.
+	// enum:
+	//	- pending
+	//	- accepted
+	//	- rejected
+	//	- deleted
+	//	- unauthorized
+	// example: accepted
+	State string `json:"state"`
+	// The quoted status, if accepted and
+	// visible to the requesting account.
+	// nullable: true
+	QuotedStatus *Status `json:"quoted_status"`
+	// ID of the quoted status, set instead of
+	// quoted_status for quotes nested within
+	// a quoted status, to avoid deep nesting.
+	QuotedStatusID string `json:"quoted_status_id,omitempty"`
+}
+
 // StatusCreateRequest models status creation parameters.
 //
 // swagger:ignore
@@ -204,6 +244,8 @@ type StatusCreateRequest struct {
 	Poll *PollRequest `form:"poll" json:"poll"`
 	// ID of the status being replied to, if status is a reply.
 	InReplyToID string `form:"in_reply_to_id" json:"in_reply_to_id"`
+	// ID of the status being quoted, if status is a quote.
+	QuoteID string `form:"quote_id" json:"quote_id"`
 	// Status and attached media should be marked as sensitive.
 	Sensitive bool `form:"sensitive" json:"sensitive"`
 	// Text to be shown as a warning or subject before the actual content.
@@ -227,6 +269,58 @@ type StatusCreateRequest struct {
 	InteractionPolicy *InteractionPolicy `form:"-" json:"interaction_policy"`
 }
 
+// StatusEditRequest models status edit parameters.
+//
+// swagger:ignore
+type StatusEditRequest struct {
+	// Text content of the status.
+	// If media_ids is provided, this becomes optional.
+	// Attaching a poll is optional while status is provided.
+	Status string `form:"status" json:"status"`
+	// Text to be shown as a warning or subject before the actual content.
+	// Statuses are generally collapsed behind this field.
+	SpoilerText string `form:"spoiler_text" json:"spoiler_text"`
+	// Status and attached media should be marked as sensitive.
+	Sensitive bool `form:"sensitive" json:"sensitive"`
+	// ISO 639 language code for this status.
+	Language string `form:"language" json:"language"`
+	// Content type to use when parsing this status.
+	ContentType StatusContentType `form:"content_type" json:"content_type"`
+	// Array of Attachment ids to be attached as media.
+	// If provided, status becomes optional, and poll cannot be used.
+	MediaIDs []string `form:"media_ids[]" json:"media_ids"`
+	// Array of Attachment attributes to be updated on attached media.
+	MediaAttributes []AttachmentAttributesRequest `form:"-" json:"media_attributes"`
+	// Poll to include with this status.
+	Poll *PollRequest `form:"poll" json:"poll"`
+}
+
+// AttachmentAttributesRequest models an edit
+// request for attachment attributes (description, focus).
+//
+// swagger:ignore
+type AttachmentAttributesRequest struct {
+	// ID of the attachment to update.
+	ID string `form:"id" json:"id"`
+	// Image or media description to use as alt-text on the attachment.
+	// This is very useful for users of screenreaders!
+	// May or may not be required, depending on your instance settings.
+	Description string `form:"description" json:"description"`
+	// Focus of the media file.
+	// If present, it should be in the form of two comma-separated floats between -1 and 1.
+	// For example: `-0.5,0.25`.
+	Focus string `form:"focus" json:"focus"`
+}
+
+// Separate form for parsing media attributes
+// on status edit requests.
+//
+// swagger:ignore
+type StatusEditMediaAttributesForm struct {
+	// Array of Attachment attributes to be updated on attached media.
+	MediaAttributes []AttachmentAttributesRequest `form:"media_attributes" json:"-"`
+}
+
 // Separate form for parsing interaction
 // policy on status create requests.
 //
@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Suggestion represents one account suggested to a user as worth following.
+//
+// swagger:model suggestion
+type Suggestion struct {
+	// The reason this account is being suggested.
+	// Deprecated in favour of sources, but kept for compatibility.
+	// One of "staff", "past_interactions", or "global".
+	// example: staff
+	Source string `json:"source"`
+
+	// All reasons why this account is being suggested.
+	// Each one of "featured" (picked by an admin),
+	// "friends_of_friends" (followed by accounts that
+	// you follow), or "most_interactions" (recently
+	// active on this instance).
+	// example: ["featured","friends_of_friends"]
+	Sources []string `json:"sources"`
+
+	// The suggested account.
+	Account *Account `json:"account"`
+}
+
+// AdminStaffPick represents one account that admins have
+// chosen to suggest to users as worth following.
+//
+// swagger:model adminStaffPick
+type AdminStaffPick struct {
+	// The ID of the staff pick.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// Time at which the account was picked (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+
+	// The picked account.
+	// readonly: true
+	Account *Account `json:"account"`
+}
+
+// AdminStaffPickCreateRequest is the form submitted as a POST to pick an account.
+//
+// swagger:ignore
+type AdminStaffPickCreateRequest struct {
+	// ID of the account to pick.
+	AccountID string `form:"account_id" json:"account_id"`
+}
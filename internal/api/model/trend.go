@@ -0,0 +1,67 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// TrendsLink represents a link that
+// is currently trending on this instance.
+//
+// swagger:model trendsLink
+type TrendsLink struct {
+	Card
+	// Daily history of this link's
+	// usage, most recent day first.
+	History []History `json:"history"`
+}
+
+// AdminTrend represents a tag, status, or link
+// that is trending on this instance, along with
+// the state of its review by an admin.
+//
+// swagger:model adminTrend
+type AdminTrend struct {
+	// The ID of the trend review.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	ID string `json:"id"`
+	// Type of the trending item.
+	// enum:
+	// - tag
+	// - status
+	// - link
+	// example: tag
+	Type string `json:"type"`
+	// State of the admin review of this item.
+	// Only approved items are shown in trends.
+	// enum:
+	// - pending
+	// - approved
+	// - rejected
+	// example: pending
+	State string `json:"state"`
+	// Number of distinct accounts that used this item recently.
+	// example: 5
+	Accounts int `json:"accounts"`
+	// Number of times this item was used recently.
+	// example: 12
+	Uses int `json:"uses"`
+	// The trending tag, if type is tag.
+	Tag *Tag `json:"tag,omitempty"`
+	// The trending status, if type is status.
+	Status *Status `json:"status,omitempty"`
+	// The trending link, if type is link.
+	Link *TrendsLink `json:"link,omitempty"`
+}
@@ -60,6 +60,9 @@ type User struct {
 	// Time when the last "please reset your password" email was sent, if at all. (ISO 8601 Datetime)
 	// example: 2021-07-30T09:20:25+00:00
 	ResetPasswordSentAt string `json:"reset_password_sent_at,omitempty"`
+	// Time when the user enabled two-factor authentication, if at all. (ISO 8601 Datetime)
+	// example: 2021-07-30T09:20:25+00:00
+	TwoFactorEnabledAt string `json:"two_factor_enabled_at,omitempty"`
 }
 
 // PasswordChangeRequest models user password change parameters.
@@ -95,3 +98,56 @@ type EmailChangeRequest struct {
 	// required: true
 	NewEmail string `form:"new_email" json:"new_email" xml:"new_email" validation:"required"`
 }
+
+// TwoFactorSecret models a newly-generated TOTP
+// secret for two-factor authentication enrollment.
+//
+// swagger:model twoFactorSecret
+type TwoFactorSecret struct {
+	// Base32-encoded TOTP secret, for manual entry into an authenticator app.
+	// example: JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+	Secret string `json:"secret"`
+	// otpauth:// provisioning URI, suitable for
+	// rendering as a QR code for authenticator apps.
+	// example: otpauth://totp/example.org:some_user?secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP&issuer=example.org
+	URI string `json:"uri"`
+}
+
+// TwoFactorRecoveryCodes models the one-time recovery codes
+// generated when the user enables two-factor authentication.
+//
+// swagger:model twoFactorRecoveryCodes
+type TwoFactorRecoveryCodes struct {
+	// One-time recovery codes. These are shown only once,
+	// and can each be used once in place of a TOTP code.
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorSecretRequest models a request to
+// generate a new two-factor authentication secret.
+//
+// swagger:ignore
+type TwoFactorSecretRequest struct {
+	// User's current password, for verification.
+	Password string `form:"password" json:"password" xml:"password"`
+}
+
+// TwoFactorEnableRequest models a request
+// to confirm and enable two-factor authentication.
+//
+// swagger:ignore
+type TwoFactorEnableRequest struct {
+	// User's current password, for verification.
+	Password string `form:"password" json:"password" xml:"password"`
+	// Current TOTP code from the user's authenticator app.
+	Code string `form:"code" json:"code" xml:"code"`
+}
+
+// TwoFactorDisableRequest models a request
+// to disable two-factor authentication.
+//
+// swagger:ignore
+type TwoFactorDisableRequest struct {
+	// User's current password, for verification.
+	Password string `form:"password" json:"password" xml:"password"`
+}
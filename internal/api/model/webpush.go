@@ -0,0 +1,238 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// WebPushSubscription represents a subscription to a Web Push server.
+//
+// swagger:model webPushSubscription
+type WebPushSubscription struct {
+	// The id of the push subscription in the database.
+	ID string `json:"id"`
+	// Where push alerts will be sent to.
+	Endpoint string `json:"endpoint"`
+	// The instance's VAPID public key, used to verify pushed notifications.
+	ServerKey string `json:"server_key"`
+	// Which alerts should be delivered to the endpoint.
+	Alerts WebPushSubscriptionAlerts `json:"alerts"`
+	// Which accounts should trigger alerts.
+	//	all = Receive alerts from any account
+	//	followed = Receive alerts from accounts you follow
+	//	follower = Receive alerts from accounts that follow you
+	//	none = Receive no alerts
+	Policy string `json:"policy"`
+}
+
+// WebPushSubscriptionAlerts represents the specific
+// alerts that this push subscription will give.
+//
+// swagger:model webPushSubscriptionAlerts
+type WebPushSubscriptionAlerts struct {
+	// Receive a push notification when someone has followed you?
+	Follow bool `json:"follow"`
+	// Receive a push notification when someone has requested to follow you?
+	FollowRequest bool `json:"follow_request"`
+	// Receive a push notification when a status you created has been favourited by someone else?
+	Favourite bool `json:"favourite"`
+	// Receive a push notification when someone else has mentioned you in a status?
+	Mention bool `json:"mention"`
+	// Receive a push notification when a status you created has been boosted by someone else?
+	Reblog bool `json:"reblog"`
+	// Receive a push notification when a poll you voted in or created has ended?
+	Poll bool `json:"poll"`
+	// Receive a push notification when a subscribed account posts a status?
+	Status bool `json:"status"`
+	// Receive a push notification when someone has signed up to your instance (admins/moderators only)?
+	AdminSignup bool `json:"admin.sign_up"`
+	// Receive a push notification when someone has faved a status of yours, which requires approval?
+	PendingFavourite bool `json:"pending.favourite"`
+	// Receive a push notification when someone has replied to a status of yours, which requires approval?
+	PendingReply bool `json:"pending.reply"`
+	// Receive a push notification when someone has boosted a status of yours, which requires approval?
+	PendingReblog bool `json:"pending.reblog"`
+	// Receive a push notification when a status you created has been quoted by someone else?
+	Quote bool `json:"quote"`
+	// Receive a push notification when someone has quoted a status of yours, which requires approval?
+	PendingQuote bool `json:"pending.quote"`
+}
+
+// WebPushSubscriptionCreateRequest models a request to create a push subscription.
+// This has two sets of fields to support a goofy nested map structure in both form data and JSON bodies.
+//
+// swagger:ignore
+type WebPushSubscriptionCreateRequest struct {
+	Subscription               *WebPushSubscriptionRequestSubscription `form:"-" json:"subscription"`
+	FormSubscriptionEndpoint   string                                  `form:"subscription[endpoint]" json:"-"`
+	FormSubscriptionKeysP256dh string                                  `form:"subscription[keys][p256dh]" json:"-"`
+	FormSubscriptionKeysAuth   string                                  `form:"subscription[keys][auth]" json:"-"`
+
+	WebPushSubscriptionUpdateRequest
+}
+
+// WebPushSubscriptionRequestSubscription contains the
+// push service endpoint and user agent encryption keys.
+//
+// swagger:ignore
+type WebPushSubscriptionRequestSubscription struct {
+	// The endpoint URL that is called when a notification event occurs.
+	Endpoint string `json:"endpoint"`
+	// Encryption keys of the user agent.
+	Keys WebPushSubscriptionRequestKeys `json:"keys"`
+}
+
+// WebPushSubscriptionRequestKeys contains
+// the user agent's encryption keys.
+//
+// swagger:ignore
+type WebPushSubscriptionRequestKeys struct {
+	// User agent public key. Base64 encoded string of a public key from a ECDH keypair using the prime256v1 curve.
+	P256dh string `json:"p256dh"`
+	// Auth secret. Base64 encoded string of 16 bytes of random data.
+	Auth string `json:"auth"`
+}
+
+// WebPushSubscriptionUpdateRequest models a request to update a push subscription.
+// This has two sets of fields to support a goofy nested map structure in both form data and JSON bodies.
+//
+// swagger:ignore
+type WebPushSubscriptionUpdateRequest struct {
+	Data                           *WebPushSubscriptionRequestData `form:"-" json:"data"`
+	FormDataAlertsFollow           *bool                           `form:"data[alerts][follow]" json:"-"`
+	FormDataAlertsFollowRequest    *bool                           `form:"data[alerts][follow_request]" json:"-"`
+	FormDataAlertsFavourite        *bool                           `form:"data[alerts][favourite]" json:"-"`
+	FormDataAlertsMention          *bool                           `form:"data[alerts][mention]" json:"-"`
+	FormDataAlertsReblog           *bool                           `form:"data[alerts][reblog]" json:"-"`
+	FormDataAlertsPoll             *bool                           `form:"data[alerts][poll]" json:"-"`
+	FormDataAlertsStatus           *bool                           `form:"data[alerts][status]" json:"-"`
+	FormDataAlertsAdminSignup      *bool                           `form:"data[alerts][admin.sign_up]" json:"-"`
+	FormDataAlertsPendingFavourite *bool                           `form:"data[alerts][pending.favourite]" json:"-"`
+	FormDataAlertsPendingReply     *bool                           `form:"data[alerts][pending.reply]" json:"-"`
+	FormDataAlertsPendingReblog    *bool                           `form:"data[alerts][pending.reblog]" json:"-"`
+	FormDataAlertsQuote            *bool                           `form:"data[alerts][quote]" json:"-"`
+	FormDataAlertsPendingQuote     *bool                           `form:"data[alerts][pending.quote]" json:"-"`
+	FormDataPolicy                 *string                         `form:"data[policy]" json:"-"`
+}
+
+// WebPushSubscriptionRequestData contains the
+// alerts and policy settings of a subscription.
+//
+// swagger:ignore
+type WebPushSubscriptionRequestData struct {
+	// Alerts to receive. Unset alerts are disabled.
+	Alerts *WebPushSubscriptionAlerts `json:"alerts"`
+	// Policy for which accounts should trigger alerts.
+	Policy *string `json:"policy"`
+}
+
+// Normalize copies any subscription form fields
+// into Subscription, so that only Subscription
+// needs to be checked, then normalizes Data.
+func (r *WebPushSubscriptionCreateRequest) Normalize() {
+	if r.Subscription == nil {
+		r.Subscription = &WebPushSubscriptionRequestSubscription{
+			Endpoint: r.FormSubscriptionEndpoint,
+			Keys: WebPushSubscriptionRequestKeys{
+				P256dh: r.FormSubscriptionKeysP256dh,
+				Auth:   r.FormSubscriptionKeysAuth,
+			},
+		}
+	}
+	r.WebPushSubscriptionUpdateRequest.Normalize()
+}
+
+// Normalize copies any data form fields into
+// Data, so that only Data needs to be checked.
+// Data is left nil if no data was provided.
+func (r *WebPushSubscriptionUpdateRequest) Normalize() {
+	if r.Data != nil {
+		// Provided as JSON.
+		return
+	}
+
+	formAlerts := []*bool{
+		r.FormDataAlertsFollow,
+		r.FormDataAlertsFollowRequest,
+		r.FormDataAlertsFavourite,
+		r.FormDataAlertsMention,
+		r.FormDataAlertsReblog,
+		r.FormDataAlertsPoll,
+		r.FormDataAlertsStatus,
+		r.FormDataAlertsAdminSignup,
+		r.FormDataAlertsPendingFavourite,
+		r.FormDataAlertsPendingReply,
+		r.FormDataAlertsPendingReblog,
+		r.FormDataAlertsQuote,
+		r.FormDataAlertsPendingQuote,
+	}
+
+	var alerts *WebPushSubscriptionAlerts
+	for _, alert := range formAlerts {
+		if alert != nil {
+			alerts = new(WebPushSubscriptionAlerts)
+			break
+		}
+	}
+
+	if alerts == nil && r.FormDataPolicy == nil {
+		// No data provided.
+		return
+	}
+
+	if alerts != nil {
+		get := func(b *bool) bool { return b != nil && *b }
+		alerts.Follow = get(r.FormDataAlertsFollow)
+		alerts.FollowRequest = get(r.FormDataAlertsFollowRequest)
+		alerts.Favourite = get(r.FormDataAlertsFavourite)
+		alerts.Mention = get(r.FormDataAlertsMention)
+		alerts.Reblog = get(r.FormDataAlertsReblog)
+		alerts.Poll = get(r.FormDataAlertsPoll)
+		alerts.Status = get(r.FormDataAlertsStatus)
+		alerts.AdminSignup = get(r.FormDataAlertsAdminSignup)
+		alerts.PendingFavourite = get(r.FormDataAlertsPendingFavourite)
+		alerts.PendingReply = get(r.FormDataAlertsPendingReply)
+		alerts.PendingReblog = get(r.FormDataAlertsPendingReblog)
+		alerts.Quote = get(r.FormDataAlertsQuote)
+		alerts.PendingQuote = get(r.FormDataAlertsPendingQuote)
+	}
+
+	r.Data = &WebPushSubscriptionRequestData{
+		Alerts: alerts,
+		Policy: r.FormDataPolicy,
+	}
+}
+
+// WebPushNotification represents the decrypted
+// payload of a Web Push notification sent to a
+// push subscription endpoint.
+//
+// swagger:model webPushNotification
+type WebPushNotification struct {
+	// The access token of the subscription this notification was sent to.
+	AccessToken string `json:"access_token"`
+	// Preferred locale of the user the notification was sent to.
+	PreferredLocale string `json:"preferred_locale"`
+	// The ID of the notification, which can be used to fetch it from the API.
+	NotificationID string `json:"notification_id"`
+	// The type of the notification.
+	NotificationType string `json:"notification_type"`
+	// URL of an icon to display with the notification, usually the origin account's avatar.
+	Icon string `json:"icon"`
+	// Title of the notification.
+	Title string `json:"title"`
+	// Body of the notification, in plain text.
+	Body string `json:"body"`
+}
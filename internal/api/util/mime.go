@@ -31,6 +31,7 @@
 	AppActivityLDJSON = appActivityLDJSON + `; profile="https://www.w3.org/ns/activitystreams"`
 	AppJRDJSON        = `application/jrd+json` // https://www.rfc-editor.org/rfc/rfc7033#section-10.2
 	AppForm           = `application/x-www-form-urlencoded`
+	AppZip            = `application/zip`
 	MultipartForm     = `multipart/form-data`
 	TextXML           = `text/xml`
 	TextHTML          = `text/html`
@@ -94,6 +94,12 @@
 	TextCSV,
 }
 
+// ZipHeaders just contains the application/zip
+// MIME type, used for account archive export.
+var ZipHeaders = []string{
+	AppZip,
+}
+
 // NegotiateAccept takes the *gin.Context from an incoming request, and a
 // slice of Offers, and performs content negotiation for the given request
 // with the given content-type offers. It will return a string representation
@@ -45,6 +45,11 @@
 	TargetAccountIDKey = "target_account_id"
 	ResolvedKey        = "resolved"
 
+	/* Announcement keys */
+
+	AnnouncementWithDismissedKey = "with_dismissed"
+	AnnouncementReactionNameKey  = "name"
+
 	/* AP endpoint keys */
 
 	OnlyOtherAccountsKey = "only_other_accounts"
@@ -63,14 +68,21 @@
 
 	TagNameKey = "tag_name"
 
+	/* Trends keys */
+
+	TrendsOffsetKey = "offset"
+
 	/* Web endpoint keys */
 
 	WebStatusIDKey = "status"
 
 	/* Domain permission keys */
 
-	DomainPermissionExportKey = "export"
-	DomainPermissionImportKey = "import"
+	DomainPermissionExportKey         = "export"
+	DomainPermissionImportKey         = "import"
+	DomainPermissionPermTypeKey       = "permission_type"
+	DomainPermissionSubscriptionIDKey = "subscription_id"
+	DomainPermissionDomainKey         = "domain"
 
 	/* Admin query keys */
 
@@ -98,6 +110,7 @@
 	InteractionFavouritesKey = "favourites"
 	InteractionRepliesKey    = "replies"
 	InteractionReblogsKey    = "reblogs"
+	InteractionQuotesKey     = "quotes"
 )
 
 /*
@@ -161,6 +174,10 @@ func ParseSearchResolve(value string, defaultValue bool) (bool, gtserror.WithCod
 	return parseBool(value, defaultValue, SearchResolveKey)
 }
 
+func ParseTrendsOffset(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, TrendsOffsetKey)
+}
+
 func ParseDomainPermissionExport(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, DomainPermissionExportKey)
 }
@@ -169,6 +186,10 @@ func ParseDomainPermissionImport(value string, defaultValue bool) (bool, gtserro
 	return parseBool(value, defaultValue, DomainPermissionImportKey)
 }
 
+func ParseAnnouncementWithDismissed(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, AnnouncementWithDismissedKey)
+}
+
 func ParseOnlyOtherAccounts(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, OnlyOtherAccountsKey)
 }
@@ -213,6 +234,10 @@ func ParseInteractionReblogs(value string, defaultValue bool) (bool, gtserror.Wi
 	return parseBool(value, defaultValue, InteractionReblogsKey)
 }
 
+func ParseInteractionQuotes(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, InteractionQuotesKey)
+}
+
 /*
 	Parse functions for *REQUIRED* parameters.
 */
@@ -277,6 +302,16 @@ func ParseTagName(value string) (string, gtserror.WithCode) {
 	return value, nil
 }
 
+func ParseAnnouncementReactionName(value string) (string, gtserror.WithCode) {
+	key := AnnouncementReactionNameKey
+
+	if value == "" {
+		return "", requiredError(key)
+	}
+
+	return value, nil
+}
+
 func ParseUsername(value string) (string, gtserror.WithCode) {
 	key := UsernameKey
 
@@ -50,6 +50,11 @@ type WebPage struct {
 	// Can be nil.
 	Javascript []string
 
+	// Active admin announcements to show
+	// in a banner at the top of the page.
+	// Can be nil.
+	Announcements []*apimodel.Announcement
+
 	// Extra parameters to pass to
 	// the template for rendering,
 	// eg., "account": *Account etc.
@@ -68,10 +73,11 @@ func TemplateWebPage(
 	page WebPage,
 ) {
 	obj := map[string]any{
-		"instance":    page.Instance,
-		"ogMeta":      page.OGMeta,
-		"stylesheets": page.Stylesheets,
-		"javascript":  page.Javascript,
+		"instance":      page.Instance,
+		"ogMeta":        page.OGMeta,
+		"stylesheets":   page.Stylesheets,
+		"javascript":    page.Javascript,
+		"announcements": page.Announcements,
 	}
 
 	for k, v := range page.Extra {
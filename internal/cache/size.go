@@ -173,6 +173,7 @@ func totalOfRatios() float64 {
 	// we only do this on init so fuck it :D
 	return 0 +
 		config.GetCacheAccountMemRatio() +
+		config.GetCacheAccountDomainBlocksMemRatio() +
 		config.GetCacheAccountNoteMemRatio() +
 		config.GetCacheAccountSettingsMemRatio() +
 		config.GetCacheAccountStatsMemRatio() +
@@ -180,6 +181,7 @@ func totalOfRatios() float64 {
 		config.GetCacheBlockMemRatio() +
 		config.GetCacheBlockIDsMemRatio() +
 		config.GetCacheBoostOfIDsMemRatio() +
+		config.GetCacheCardMemRatio() +
 		config.GetCacheClientMemRatio() +
 		config.GetCacheEmojiMemRatio() +
 		config.GetCacheEmojiCategoryMemRatio() +
@@ -206,10 +208,12 @@ func totalOfRatios() float64 {
 		config.GetCachePollVoteMemRatio() +
 		config.GetCachePollVoteIDsMemRatio() +
 		config.GetCacheReportMemRatio() +
+		config.GetCacheScheduledStatusMemRatio() +
 		config.GetCacheSinBinStatusMemRatio() +
 		config.GetCacheStatusMemRatio() +
 		config.GetCacheStatusBookmarkMemRatio() +
 		config.GetCacheStatusBookmarkIDsMemRatio() +
+		config.GetCacheStatusEditMemRatio() +
 		config.GetCacheStatusFaveMemRatio() +
 		config.GetCacheStatusFaveIDsMemRatio() +
 		config.GetCacheTagMemRatio() +
@@ -219,6 +223,8 @@ func totalOfRatios() float64 {
 		config.GetCacheUserMemRatio() +
 		config.GetCacheUserMuteMemRatio() +
 		config.GetCacheUserMuteIDsMemRatio() +
+		config.GetCacheWebPushSubscriptionMemRatio() +
+		config.GetCacheWebPushSubscriptionIDsMemRatio() +
 		config.GetCacheWebfingerMemRatio() +
 		config.GetCacheVisibilityMemRatio()
 }
@@ -328,6 +334,27 @@ func sizeofClient() uintptr {
 	}))
 }
 
+func sizeofCard() uintptr {
+	return uintptr(size.Of(&gtsmodel.Card{
+		ID:           exampleID,
+		CreatedAt:    exampleTime,
+		UpdatedAt:    exampleTime,
+		FetchedAt:    exampleTime,
+		URL:          exampleURI,
+		Type:         gtsmodel.CardTypeLink,
+		Title:        exampleTextSmall,
+		Description:  exampleText,
+		AuthorName:   exampleUsername,
+		AuthorURL:    exampleURI,
+		ProviderName: exampleUsername,
+		ProviderURL:  exampleURI,
+		Width:        640,
+		Height:       480,
+		Language:     "en",
+		ImageID:      exampleID,
+	}))
+}
+
 func sizeofConversation() uintptr {
 	return uintptr(size.Of(&gtsmodel.Conversation{
 		ID:               exampleID,
@@ -590,6 +617,29 @@ func sizeofReport() uintptr {
 	}))
 }
 
+func sizeofScheduledStatus() uintptr {
+	return uintptr(size.Of(&gtsmodel.ScheduledStatus{
+		ID:          exampleID,
+		CreatedAt:   exampleTime,
+		UpdatedAt:   exampleTime,
+		AccountID:   exampleID,
+		ScheduledAt: exampleTime,
+		Text:        exampleText,
+		SpoilerText: exampleText,
+		Sensitive:   func() *bool { ok := false; return &ok }(),
+		Visibility:  gtsmodel.VisibilityPublic,
+		LocalOnly:   func() *bool { ok := false; return &ok }(),
+		Language:    "en",
+		InReplyToID: exampleID,
+		MediaIDs:    []string{exampleID, exampleID, exampleID},
+		Poll: &gtsmodel.ScheduledStatusPoll{
+			Options:   []string{exampleText, exampleText},
+			ExpiresIn: 86400,
+		},
+		ApplicationID: exampleID,
+	}))
+}
+
 func sizeofSinBinStatus() uintptr {
 	return uintptr(size.Of(&gtsmodel.SinBinStatus{
 		ID:                  exampleID,
@@ -659,6 +709,23 @@ func sizeofStatusBookmark() uintptr {
 	}))
 }
 
+func sizeofStatusEdit() uintptr {
+	return uintptr(size.Of(&gtsmodel.StatusEdit{
+		ID:                     exampleID,
+		Content:                exampleText,
+		ContentWarning:         exampleUsername, // similar length
+		Text:                   exampleText,
+		Language:               "en",
+		Sensitive:              func() *bool { ok := false; return &ok }(),
+		AttachmentIDs:          []string{exampleID, exampleID, exampleID},
+		AttachmentDescriptions: []string{exampleText, exampleText, exampleText},
+		PollOptions:            []string{exampleTextSmall, exampleTextSmall, exampleTextSmall, exampleTextSmall},
+		PollVotes:              []int{69, 420, 1337, 1969},
+		StatusID:               exampleID,
+		CreatedAt:              exampleTime,
+	}))
+}
+
 func sizeofStatusFave() uintptr {
 	return uintptr(size.Of(&gtsmodel.StatusFave{
 		ID:              exampleID,
@@ -771,3 +838,18 @@ func sizeofUserMute() uintptr {
 		Notifications:   util.Ptr(false),
 	}))
 }
+
+func sizeofWebPushSubscription() uintptr {
+	return uintptr(size.Of(&gtsmodel.WebPushSubscription{
+		ID:                exampleID,
+		CreatedAt:         exampleTime,
+		UpdatedAt:         exampleTime,
+		AccountID:         exampleID,
+		TokenID:           exampleID,
+		Endpoint:          exampleURI,
+		Auth:              exampleTextSmall,
+		P256dh:            exampleTextSmall,
+		NotificationFlags: 0,
+		Policy:            gtsmodel.WebPushNotificationPolicyAll,
+	}))
+}
@@ -0,0 +1,78 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Archive encompasses a set of
+// account archive cleanup utils.
+type Archive struct{ *Cleaner }
+
+// All will execute all cleaner.Archive utilities synchronously, including output logging.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (a *Archive) All(ctx context.Context) {
+	a.LogPruneExpired(ctx)
+}
+
+// LogPruneExpired performs Archive.PruneExpired(...), logging the start and outcome.
+func (a *Archive) LogPruneExpired(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := a.PruneExpired(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "pruned: %d", n)
+	}
+}
+
+// PruneExpired will delete all expired account archives from the database and storage driver.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (a *Archive) PruneExpired(ctx context.Context) (int, error) {
+	archives, err := a.state.DB.GetExpiredAccountArchives(ctx, time.Now())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, gtserror.Newf("error getting expired archives: %w", err)
+	}
+
+	var total int
+
+	for _, archive := range archives {
+		// Remove the archive's zip file from storage.
+		if _, err := a.removeFiles(ctx, archive.Path); err != nil {
+			return total, err
+		}
+
+		if !gtscontext.DryRun(ctx) {
+			// Now delete the archive from the database.
+			if err := a.state.DB.DeleteAccountArchiveByID(ctx, archive.ID); err != nil {
+				return total, gtserror.Newf("error deleting archive: %w", err)
+			}
+		}
+
+		total++
+	}
+
+	return total, nil
+}
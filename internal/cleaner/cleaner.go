@@ -34,19 +34,26 @@
 )
 
 type Cleaner struct {
-	state *state.State
-	emoji Emoji
-	media Media
+	state   *state.State
+	archive Archive
+	emoji   Emoji
+	media   Media
 }
 
 func New(state *state.State) *Cleaner {
 	c := new(Cleaner)
 	c.state = state
+	c.archive.Cleaner = c
 	c.emoji.Cleaner = c
 	c.media.Cleaner = c
 	return c
 }
 
+// Archive returns the account archive set of cleaner utilities.
+func (c *Cleaner) Archive() *Archive {
+	return &c.archive
+}
+
 // Emoji returns the emoji set of cleaner utilities.
 func (c *Cleaner) Emoji() *Emoji {
 	return &c.emoji
@@ -163,6 +170,7 @@ func (c *Cleaner) ScheduleJobs() error {
 		log.Info(ctx, "starting media clean")
 		c.Media().All(ctx, config.GetMediaRemoteCacheDays())
 		c.Emoji().All(ctx, config.GetMediaRemoteCacheDays())
+		c.Archive().All(ctx)
 		log.Infof(ctx, "finished media clean after %s", time.Since(start))
 	}
 
@@ -181,5 +189,29 @@ func (c *Cleaner) ScheduleJobs() error {
 		panic("failed to schedule @mediacleanup")
 	}
 
+	if config.GetAdvancedRateLimitStore() == config.RateLimitStoreDatabase {
+		// Rate limits kept in the database are reset
+		// in place when they're next hit, so only
+		// unused ones need to be cleared out now and
+		// then to stop the table growing unbounded.
+		fn := func(ctx context.Context, start time.Time) {
+			deleted, err := c.state.DB.DeleteExpiredRateLimits(ctx, start)
+			if err != nil {
+				log.Errorf(ctx, "error deleting expired rate limits: %v", err)
+				return
+			}
+			log.Debugf(ctx, "deleted %d expired rate limits", deleted)
+		}
+
+		if !c.state.Workers.Scheduler.AddRecurring(
+			"@ratelimitcleanup",
+			now.Add(time.Hour),
+			time.Hour,
+			fn,
+		) {
+			panic("failed to schedule @ratelimitcleanup")
+		}
+	}
+
 	return nil
 }
@@ -355,6 +355,38 @@ func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment
 		}
 	}
 
+	if media.ScheduledStatusID != "" {
+		// Check whether media is reserved by a pending scheduled status.
+		scheduledStatus, err := m.state.DB.GetScheduledStatusByID(
+			gtscontext.SetBarebones(ctx),
+			media.ScheduledStatusID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return false, gtserror.Newf("error fetching scheduled status for media: %w", err)
+		}
+
+		if scheduledStatus != nil {
+			l.Debug("skipping as attached to scheduled status")
+			return false, nil
+		}
+	}
+
+	if media.CardID != "" {
+		// Check whether media is still the image of a preview card.
+		card, err := m.state.DB.GetCardByID(
+			gtscontext.SetBarebones(ctx),
+			media.CardID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return false, gtserror.Newf("error fetching card for media: %w", err)
+		}
+
+		if card != nil && card.ImageID == media.ID {
+			l.Debug("skipping as image of card")
+			return false, nil
+		}
+	}
+
 	// Check whether we have the required status for media.
 	status, missing, err := m.getRelatedStatus(ctx, media)
 	if err != nil {
@@ -372,6 +404,24 @@ func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment
 				return false, nil
 			}
 		}
+
+		// Check whether attached to a previous revision of status.
+		edits, err := m.state.DB.GetStatusEditsByIDs(
+			gtscontext.SetBarebones(ctx),
+			status.EditIDs,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error fetching edits for status %s: %w", status.ID, err)
+		}
+
+		for _, edit := range edits {
+			for _, id := range edit.AttachmentIDs {
+				if id == media.ID {
+					l.Debug("skippping as attached to status edit")
+					return false, nil
+				}
+			}
+		}
 	}
 
 	// Media totally unused, delete it.
@@ -447,6 +497,8 @@ func (m *Media) uncacheRemote(ctx context.Context, after time.Time, media *gtsmo
 	//      it if we haven't seen the account recently.
 	//   2. Media is attached to a status; we should uncache
 	//      it if we haven't seen the status recently.
+	//   3. Media is the image of a preview card; we should
+	//      uncache it if we haven't fetched the card recently.
 	if *media.Avatar || *media.Header {
 		// Check whether we have the account that owns the media.
 		account, missing, err := m.getOwningAccount(ctx, media)
@@ -462,6 +514,20 @@ func (m *Media) uncacheRemote(ctx context.Context, after time.Time, media *gtsmo
 			l.Debug("skipping due to recently fetched account")
 			return false, nil
 		}
+	} else if media.CardID != "" {
+		// Check whether we have the card that media is the image of.
+		card, err := m.state.DB.GetCardByID(
+			gtscontext.SetBarebones(ctx),
+			media.CardID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return false, gtserror.Newf("error fetching card for media: %w", err)
+		}
+
+		if card != nil && card.FetchedAt.After(after) {
+			l.Debug("skipping due to recently fetched card")
+			return false, nil
+		}
 	} else {
 		// Check whether we have the status that media is attached to.
 		status, missing, err := m.getRelatedStatus(ctx, media)
@@ -24,6 +24,20 @@
 	InstanceFederationModeAllowlist = "allowlist"
 	InstanceFederationModeDefault   = InstanceFederationModeBlocklist
 
+	// Accounts invite role determines the minimum
+	// role an account needs to create invites.
+	AccountsInviteRoleNone      = "none"
+	AccountsInviteRoleModerator = "moderator"
+	AccountsInviteRoleUser      = "user"
+	AccountsInviteRoleDefault   = AccountsInviteRoleModerator
+
+	// Rate limit store determines where rate
+	// limit counts are kept, and so whether
+	// they're shared between processes.
+	RateLimitStoreMemory   = "memory"
+	RateLimitStoreDatabase = "database"
+	RateLimitStoreDefault  = RateLimitStoreMemory
+
 	// Request header filter mode determines how
 	// this instance will perform request filtering.
 	RequestHeaderFilterModeAllow    = "allow"
@@ -58,18 +58,23 @@
 	WebTemplateBaseDir: "./web/template/",
 	WebAssetBaseDir:    "./web/assets/",
 
-	InstanceFederationMode:         InstanceFederationModeDefault,
-	InstanceFederationSpamFilter:   false,
-	InstanceExposePeers:            false,
-	InstanceExposeSuspended:        false,
-	InstanceExposeSuspendedWeb:     false,
-	InstanceDeliverToSharedInboxes: true,
-	InstanceLanguages:              make(language.Languages, 0),
+	InstanceFederationMode:                      InstanceFederationModeDefault,
+	InstanceFederationSpamFilter:                false,
+	InstanceExposePeers:                         false,
+	InstanceExposeSuspended:                     false,
+	InstanceExposeSuspendedWeb:                  false,
+	InstanceDeliverToSharedInboxes:              true,
+	InstanceLanguages:                           make(language.Languages, 0),
+	InstanceSubscriptionsProcessFrom:            "23:00",        // 11pm,
+	InstanceSubscriptionsProcessEvery:           24 * time.Hour, // 1/day.
+	InstanceDomainPermissionDraftsRequireReview: true,
 
 	AccountsRegistrationOpen: false,
 	AccountsReasonRequired:   true,
+	AccountsInviteRole:       AccountsInviteRoleDefault,
 	AccountsAllowCustomCSS:   false,
 	AccountsCustomCSSLength:  10000,
+	AccountsArchiveExpiry:    7 * 24 * time.Hour, // 1/week.
 
 	MediaDescriptionMinChars: 0,
 	MediaDescriptionMaxChars: 1500,
@@ -132,7 +137,10 @@
 	AdvancedCookiesSamesite:      "lax",
 	AdvancedRateLimitRequests:    300, // 1 per second per 5 minutes
 	AdvancedRateLimitExceptions:  []string{},
-	AdvancedThrottlingMultiplier: 8, // 8 open requests per CPU
+	AdvancedRateLimitStore:       RateLimitStoreDefault,
+	AdvancedRateLimitSignIn:      25,   // 5 per minute
+	AdvancedRateLimitInbox:       3000, // 10 per second
+	AdvancedThrottlingMultiplier: 8,    // 8 open requests per CPU
 	AdvancedThrottlingRetryAfter: time.Second * 30,
 	AdvancedSenderMultiplier:     2, // 2 senders per CPU
 	AdvancedCSPExtraURIs:         []string{},
@@ -159,6 +167,7 @@
 		// file have been addressed, these should
 		// be able to make some more sense :D
 		AccountMemRatio:                   5,
+		AccountDomainBlocksMemRatio:       0.5,
 		AccountNoteMemRatio:               1,
 		AccountSettingsMemRatio:           0.1,
 		AccountStatsMemRatio:              2,
@@ -166,6 +175,7 @@
 		BlockMemRatio:                     2,
 		BlockIDsMemRatio:                  3,
 		BoostOfIDsMemRatio:                3,
+		CardMemRatio:                      0.5,
 		ClientMemRatio:                    0.1,
 		ConversationMemRatio:              1,
 		ConversationLastStatusIDsMemRatio: 2,
@@ -194,10 +204,12 @@
 		PollVoteMemRatio:                  2,
 		PollVoteIDsMemRatio:               2,
 		ReportMemRatio:                    1,
+		ScheduledStatusMemRatio:           0.5,
 		SinBinStatusMemRatio:              0.5,
 		StatusMemRatio:                    5,
 		StatusBookmarkMemRatio:            0.5,
 		StatusBookmarkIDsMemRatio:         2,
+		StatusEditMemRatio:                2,
 		StatusFaveMemRatio:                2,
 		StatusFaveIDsMemRatio:             3,
 		TagMemRatio:                       2,
@@ -207,6 +219,8 @@
 		UserMemRatio:                      0.25,
 		UserMuteMemRatio:                  2,
 		UserMuteIDsMemRatio:               3,
+		WebPushSubscriptionMemRatio:       1,
+		WebPushSubscriptionIDsMemRatio:    1,
 		WebfingerMemRatio:                 0.1,
 		VisibilityMemRatio:                2,
 	},
@@ -725,6 +725,31 @@ func GetDbPostgresConnectionString() string { return global.GetDbPostgresConnect
 // SetDbPostgresConnectionString safely sets the value for global configuration 'DbPostgresConnectionString' field
 func SetDbPostgresConnectionString(v string) { global.SetDbPostgresConnectionString(v) }
 
+// GetDbFullTextSearch safely fetches the Configuration value for state's 'DbFullTextSearch' field
+func (st *ConfigState) GetDbFullTextSearch() (v bool) {
+	st.mutex.RLock()
+	v = st.config.DbFullTextSearch
+	st.mutex.RUnlock()
+	return
+}
+
+// SetDbFullTextSearch safely sets the Configuration value for state's 'DbFullTextSearch' field
+func (st *ConfigState) SetDbFullTextSearch(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.DbFullTextSearch = v
+	st.reloadToViper()
+}
+
+// DbFullTextSearchFlag returns the flag name for the 'DbFullTextSearch' field
+func DbFullTextSearchFlag() string { return "db-full-text-search" }
+
+// GetDbFullTextSearch safely fetches the value for global configuration 'DbFullTextSearch' field
+func GetDbFullTextSearch() bool { return global.GetDbFullTextSearch() }
+
+// SetDbFullTextSearch safely sets the value for global configuration 'DbFullTextSearch' field
+func SetDbFullTextSearch(v bool) { global.SetDbFullTextSearch(v) }
+
 // GetWebTemplateBaseDir safely fetches the Configuration value for state's 'WebTemplateBaseDir' field
 func (st *ConfigState) GetWebTemplateBaseDir() (v string) {
 	st.mutex.RLock()
@@ -1000,6 +1025,93 @@ func GetInstanceLanguages() language.Languages { return global.GetInstanceLangua
 // SetInstanceLanguages safely sets the value for global configuration 'InstanceLanguages' field
 func SetInstanceLanguages(v language.Languages) { global.SetInstanceLanguages(v) }
 
+// GetInstanceSubscriptionsProcessFrom safely fetches the Configuration value for state's 'InstanceSubscriptionsProcessFrom' field
+func (st *ConfigState) GetInstanceSubscriptionsProcessFrom() (v string) {
+	st.mutex.RLock()
+	v = st.config.InstanceSubscriptionsProcessFrom
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceSubscriptionsProcessFrom safely sets the Configuration value for state's 'InstanceSubscriptionsProcessFrom' field
+func (st *ConfigState) SetInstanceSubscriptionsProcessFrom(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceSubscriptionsProcessFrom = v
+	st.reloadToViper()
+}
+
+// InstanceSubscriptionsProcessFromFlag returns the flag name for the 'InstanceSubscriptionsProcessFrom' field
+func InstanceSubscriptionsProcessFromFlag() string { return "instance-subscriptions-process-from" }
+
+// GetInstanceSubscriptionsProcessFrom safely fetches the value for global configuration 'InstanceSubscriptionsProcessFrom' field
+func GetInstanceSubscriptionsProcessFrom() string {
+	return global.GetInstanceSubscriptionsProcessFrom()
+}
+
+// SetInstanceSubscriptionsProcessFrom safely sets the value for global configuration 'InstanceSubscriptionsProcessFrom' field
+func SetInstanceSubscriptionsProcessFrom(v string) { global.SetInstanceSubscriptionsProcessFrom(v) }
+
+// GetInstanceSubscriptionsProcessEvery safely fetches the Configuration value for state's 'InstanceSubscriptionsProcessEvery' field
+func (st *ConfigState) GetInstanceSubscriptionsProcessEvery() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.InstanceSubscriptionsProcessEvery
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceSubscriptionsProcessEvery safely sets the Configuration value for state's 'InstanceSubscriptionsProcessEvery' field
+func (st *ConfigState) SetInstanceSubscriptionsProcessEvery(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceSubscriptionsProcessEvery = v
+	st.reloadToViper()
+}
+
+// InstanceSubscriptionsProcessEveryFlag returns the flag name for the 'InstanceSubscriptionsProcessEvery' field
+func InstanceSubscriptionsProcessEveryFlag() string { return "instance-subscriptions-process-every" }
+
+// GetInstanceSubscriptionsProcessEvery safely fetches the value for global configuration 'InstanceSubscriptionsProcessEvery' field
+func GetInstanceSubscriptionsProcessEvery() time.Duration {
+	return global.GetInstanceSubscriptionsProcessEvery()
+}
+
+// SetInstanceSubscriptionsProcessEvery safely sets the value for global configuration 'InstanceSubscriptionsProcessEvery' field
+func SetInstanceSubscriptionsProcessEvery(v time.Duration) {
+	global.SetInstanceSubscriptionsProcessEvery(v)
+}
+
+// GetInstanceDomainPermissionDraftsRequireReview safely fetches the Configuration value for state's 'InstanceDomainPermissionDraftsRequireReview' field
+func (st *ConfigState) GetInstanceDomainPermissionDraftsRequireReview() (v bool) {
+	st.mutex.RLock()
+	v = st.config.InstanceDomainPermissionDraftsRequireReview
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceDomainPermissionDraftsRequireReview safely sets the Configuration value for state's 'InstanceDomainPermissionDraftsRequireReview' field
+func (st *ConfigState) SetInstanceDomainPermissionDraftsRequireReview(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceDomainPermissionDraftsRequireReview = v
+	st.reloadToViper()
+}
+
+// InstanceDomainPermissionDraftsRequireReviewFlag returns the flag name for the 'InstanceDomainPermissionDraftsRequireReview' field
+func InstanceDomainPermissionDraftsRequireReviewFlag() string {
+	return "instance-domain-permission-drafts-require-review"
+}
+
+// GetInstanceDomainPermissionDraftsRequireReview safely fetches the value for global configuration 'InstanceDomainPermissionDraftsRequireReview' field
+func GetInstanceDomainPermissionDraftsRequireReview() bool {
+	return global.GetInstanceDomainPermissionDraftsRequireReview()
+}
+
+// SetInstanceDomainPermissionDraftsRequireReview safely sets the value for global configuration 'InstanceDomainPermissionDraftsRequireReview' field
+func SetInstanceDomainPermissionDraftsRequireReview(v bool) {
+	global.SetInstanceDomainPermissionDraftsRequireReview(v)
+}
+
 // GetAccountsRegistrationOpen safely fetches the Configuration value for state's 'AccountsRegistrationOpen' field
 func (st *ConfigState) GetAccountsRegistrationOpen() (v bool) {
 	st.mutex.RLock()
@@ -1050,6 +1162,31 @@ func GetAccountsReasonRequired() bool { return global.GetAccountsReasonRequired(
 // SetAccountsReasonRequired safely sets the value for global configuration 'AccountsReasonRequired' field
 func SetAccountsReasonRequired(v bool) { global.SetAccountsReasonRequired(v) }
 
+// GetAccountsInviteRole safely fetches the Configuration value for state's 'AccountsInviteRole' field
+func (st *ConfigState) GetAccountsInviteRole() (v string) {
+	st.mutex.RLock()
+	v = st.config.AccountsInviteRole
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsInviteRole safely sets the Configuration value for state's 'AccountsInviteRole' field
+func (st *ConfigState) SetAccountsInviteRole(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsInviteRole = v
+	st.reloadToViper()
+}
+
+// AccountsInviteRoleFlag returns the flag name for the 'AccountsInviteRole' field
+func AccountsInviteRoleFlag() string { return "accounts-invite-role" }
+
+// GetAccountsInviteRole safely fetches the value for global configuration 'AccountsInviteRole' field
+func GetAccountsInviteRole() string { return global.GetAccountsInviteRole() }
+
+// SetAccountsInviteRole safely sets the value for global configuration 'AccountsInviteRole' field
+func SetAccountsInviteRole(v string) { global.SetAccountsInviteRole(v) }
+
 // GetAccountsAllowCustomCSS safely fetches the Configuration value for state's 'AccountsAllowCustomCSS' field
 func (st *ConfigState) GetAccountsAllowCustomCSS() (v bool) {
 	st.mutex.RLock()
@@ -1100,6 +1237,31 @@ func GetAccountsCustomCSSLength() int { return global.GetAccountsCustomCSSLength
 // SetAccountsCustomCSSLength safely sets the value for global configuration 'AccountsCustomCSSLength' field
 func SetAccountsCustomCSSLength(v int) { global.SetAccountsCustomCSSLength(v) }
 
+// GetAccountsArchiveExpiry safely fetches the Configuration value for state's 'AccountsArchiveExpiry' field
+func (st *ConfigState) GetAccountsArchiveExpiry() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AccountsArchiveExpiry
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsArchiveExpiry safely sets the Configuration value for state's 'AccountsArchiveExpiry' field
+func (st *ConfigState) SetAccountsArchiveExpiry(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsArchiveExpiry = v
+	st.reloadToViper()
+}
+
+// AccountsArchiveExpiryFlag returns the flag name for the 'AccountsArchiveExpiry' field
+func AccountsArchiveExpiryFlag() string { return "accounts-archive-expiry" }
+
+// GetAccountsArchiveExpiry safely fetches the value for global configuration 'AccountsArchiveExpiry' field
+func GetAccountsArchiveExpiry() time.Duration { return global.GetAccountsArchiveExpiry() }
+
+// SetAccountsArchiveExpiry safely sets the value for global configuration 'AccountsArchiveExpiry' field
+func SetAccountsArchiveExpiry(v time.Duration) { global.SetAccountsArchiveExpiry(v) }
+
 // GetMediaDescriptionMinChars safely fetches the Configuration value for state's 'MediaDescriptionMinChars' field
 func (st *ConfigState) GetMediaDescriptionMinChars() (v int) {
 	st.mutex.RLock()
@@ -2575,6 +2737,81 @@ func GetAdvancedRateLimitExceptions() []string { return global.GetAdvancedRateLi
 // SetAdvancedRateLimitExceptions safely sets the value for global configuration 'AdvancedRateLimitExceptions' field
 func SetAdvancedRateLimitExceptions(v []string) { global.SetAdvancedRateLimitExceptions(v) }
 
+// GetAdvancedRateLimitStore safely fetches the Configuration value for state's 'AdvancedRateLimitStore' field
+func (st *ConfigState) GetAdvancedRateLimitStore() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedRateLimitStore
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedRateLimitStore safely sets the Configuration value for state's 'AdvancedRateLimitStore' field
+func (st *ConfigState) SetAdvancedRateLimitStore(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitStore = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitStoreFlag returns the flag name for the 'AdvancedRateLimitStore' field
+func AdvancedRateLimitStoreFlag() string { return "advanced-rate-limit-store" }
+
+// GetAdvancedRateLimitStore safely fetches the value for global configuration 'AdvancedRateLimitStore' field
+func GetAdvancedRateLimitStore() string { return global.GetAdvancedRateLimitStore() }
+
+// SetAdvancedRateLimitStore safely sets the value for global configuration 'AdvancedRateLimitStore' field
+func SetAdvancedRateLimitStore(v string) { global.SetAdvancedRateLimitStore(v) }
+
+// GetAdvancedRateLimitSignIn safely fetches the Configuration value for state's 'AdvancedRateLimitSignIn' field
+func (st *ConfigState) GetAdvancedRateLimitSignIn() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedRateLimitSignIn
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedRateLimitSignIn safely sets the Configuration value for state's 'AdvancedRateLimitSignIn' field
+func (st *ConfigState) SetAdvancedRateLimitSignIn(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitSignIn = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitSignInFlag returns the flag name for the 'AdvancedRateLimitSignIn' field
+func AdvancedRateLimitSignInFlag() string { return "advanced-rate-limit-sign-in" }
+
+// GetAdvancedRateLimitSignIn safely fetches the value for global configuration 'AdvancedRateLimitSignIn' field
+func GetAdvancedRateLimitSignIn() int { return global.GetAdvancedRateLimitSignIn() }
+
+// SetAdvancedRateLimitSignIn safely sets the value for global configuration 'AdvancedRateLimitSignIn' field
+func SetAdvancedRateLimitSignIn(v int) { global.SetAdvancedRateLimitSignIn(v) }
+
+// GetAdvancedRateLimitInbox safely fetches the Configuration value for state's 'AdvancedRateLimitInbox' field
+func (st *ConfigState) GetAdvancedRateLimitInbox() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedRateLimitInbox
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedRateLimitInbox safely sets the Configuration value for state's 'AdvancedRateLimitInbox' field
+func (st *ConfigState) SetAdvancedRateLimitInbox(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitInbox = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitInboxFlag returns the flag name for the 'AdvancedRateLimitInbox' field
+func AdvancedRateLimitInboxFlag() string { return "advanced-rate-limit-inbox" }
+
+// GetAdvancedRateLimitInbox safely fetches the value for global configuration 'AdvancedRateLimitInbox' field
+func GetAdvancedRateLimitInbox() int { return global.GetAdvancedRateLimitInbox() }
+
+// SetAdvancedRateLimitInbox safely sets the value for global configuration 'AdvancedRateLimitInbox' field
+func SetAdvancedRateLimitInbox(v int) { global.SetAdvancedRateLimitInbox(v) }
+
 // GetAdvancedThrottlingMultiplier safely fetches the Configuration value for state's 'AdvancedThrottlingMultiplier' field
 func (st *ConfigState) GetAdvancedThrottlingMultiplier() (v int) {
 	st.mutex.RLock()
@@ -2850,6 +3087,33 @@ func GetCacheAccountMemRatio() float64 { return global.GetCacheAccountMemRatio()
 // SetCacheAccountMemRatio safely sets the value for global configuration 'Cache.AccountMemRatio' field
 func SetCacheAccountMemRatio(v float64) { global.SetCacheAccountMemRatio(v) }
 
+// GetCacheAccountDomainBlocksMemRatio safely fetches the Configuration value for state's 'Cache.AccountDomainBlocksMemRatio' field
+func (st *ConfigState) GetCacheAccountDomainBlocksMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.AccountDomainBlocksMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheAccountDomainBlocksMemRatio safely sets the Configuration value for state's 'Cache.AccountDomainBlocksMemRatio' field
+func (st *ConfigState) SetCacheAccountDomainBlocksMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.AccountDomainBlocksMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheAccountDomainBlocksMemRatioFlag returns the flag name for the 'Cache.AccountDomainBlocksMemRatio' field
+func CacheAccountDomainBlocksMemRatioFlag() string { return "cache-account-domain-blocks-mem-ratio" }
+
+// GetCacheAccountDomainBlocksMemRatio safely fetches the value for global configuration 'Cache.AccountDomainBlocksMemRatio' field
+func GetCacheAccountDomainBlocksMemRatio() float64 {
+	return global.GetCacheAccountDomainBlocksMemRatio()
+}
+
+// SetCacheAccountDomainBlocksMemRatio safely sets the value for global configuration 'Cache.AccountDomainBlocksMemRatio' field
+func SetCacheAccountDomainBlocksMemRatio(v float64) { global.SetCacheAccountDomainBlocksMemRatio(v) }
+
 // GetCacheAccountNoteMemRatio safely fetches the Configuration value for state's 'Cache.AccountNoteMemRatio' field
 func (st *ConfigState) GetCacheAccountNoteMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3025,6 +3289,31 @@ func GetCacheBoostOfIDsMemRatio() float64 { return global.GetCacheBoostOfIDsMemR
 // SetCacheBoostOfIDsMemRatio safely sets the value for global configuration 'Cache.BoostOfIDsMemRatio' field
 func SetCacheBoostOfIDsMemRatio(v float64) { global.SetCacheBoostOfIDsMemRatio(v) }
 
+// GetCacheCardMemRatio safely fetches the Configuration value for state's 'Cache.CardMemRatio' field
+func (st *ConfigState) GetCacheCardMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.CardMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheCardMemRatio safely sets the Configuration value for state's 'Cache.CardMemRatio' field
+func (st *ConfigState) SetCacheCardMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.CardMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheCardMemRatioFlag returns the flag name for the 'Cache.CardMemRatio' field
+func CacheCardMemRatioFlag() string { return "cache-card-mem-ratio" }
+
+// GetCacheCardMemRatio safely fetches the value for global configuration 'Cache.CardMemRatio' field
+func GetCacheCardMemRatio() float64 { return global.GetCacheCardMemRatio() }
+
+// SetCacheCardMemRatio safely sets the value for global configuration 'Cache.CardMemRatio' field
+func SetCacheCardMemRatio(v float64) { global.SetCacheCardMemRatio(v) }
+
 // GetCacheClientMemRatio safely fetches the Configuration value for state's 'Cache.ClientMemRatio' field
 func (st *ConfigState) GetCacheClientMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3731,6 +4020,31 @@ func GetCacheReportMemRatio() float64 { return global.GetCacheReportMemRatio() }
 // SetCacheReportMemRatio safely sets the value for global configuration 'Cache.ReportMemRatio' field
 func SetCacheReportMemRatio(v float64) { global.SetCacheReportMemRatio(v) }
 
+// GetCacheScheduledStatusMemRatio safely fetches the Configuration value for state's 'Cache.ScheduledStatusMemRatio' field
+func (st *ConfigState) GetCacheScheduledStatusMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.ScheduledStatusMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheScheduledStatusMemRatio safely sets the Configuration value for state's 'Cache.ScheduledStatusMemRatio' field
+func (st *ConfigState) SetCacheScheduledStatusMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.ScheduledStatusMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheScheduledStatusMemRatioFlag returns the flag name for the 'Cache.ScheduledStatusMemRatio' field
+func CacheScheduledStatusMemRatioFlag() string { return "cache-scheduled-status-mem-ratio" }
+
+// GetCacheScheduledStatusMemRatio safely fetches the value for global configuration 'Cache.ScheduledStatusMemRatio' field
+func GetCacheScheduledStatusMemRatio() float64 { return global.GetCacheScheduledStatusMemRatio() }
+
+// SetCacheScheduledStatusMemRatio safely sets the value for global configuration 'Cache.ScheduledStatusMemRatio' field
+func SetCacheScheduledStatusMemRatio(v float64) { global.SetCacheScheduledStatusMemRatio(v) }
+
 // GetCacheSinBinStatusMemRatio safely fetches the Configuration value for state's 'Cache.SinBinStatusMemRatio' field
 func (st *ConfigState) GetCacheSinBinStatusMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3831,6 +4145,31 @@ func GetCacheStatusBookmarkIDsMemRatio() float64 { return global.GetCacheStatusB
 // SetCacheStatusBookmarkIDsMemRatio safely sets the value for global configuration 'Cache.StatusBookmarkIDsMemRatio' field
 func SetCacheStatusBookmarkIDsMemRatio(v float64) { global.SetCacheStatusBookmarkIDsMemRatio(v) }
 
+// GetCacheStatusEditMemRatio safely fetches the Configuration value for state's 'Cache.StatusEditMemRatio' field
+func (st *ConfigState) GetCacheStatusEditMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.StatusEditMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheStatusEditMemRatio safely sets the Configuration value for state's 'Cache.StatusEditMemRatio' field
+func (st *ConfigState) SetCacheStatusEditMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.StatusEditMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheStatusEditMemRatioFlag returns the flag name for the 'Cache.StatusEditMemRatio' field
+func CacheStatusEditMemRatioFlag() string { return "cache-status-edit-mem-ratio" }
+
+// GetCacheStatusEditMemRatio safely fetches the value for global configuration 'Cache.StatusEditMemRatio' field
+func GetCacheStatusEditMemRatio() float64 { return global.GetCacheStatusEditMemRatio() }
+
+// SetCacheStatusEditMemRatio safely sets the value for global configuration 'Cache.StatusEditMemRatio' field
+func SetCacheStatusEditMemRatio(v float64) { global.SetCacheStatusEditMemRatio(v) }
+
 // GetCacheStatusFaveMemRatio safely fetches the Configuration value for state's 'Cache.StatusFaveMemRatio' field
 func (st *ConfigState) GetCacheStatusFaveMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -4056,6 +4395,64 @@ func GetCacheUserMuteIDsMemRatio() float64 { return global.GetCacheUserMuteIDsMe
 // SetCacheUserMuteIDsMemRatio safely sets the value for global configuration 'Cache.UserMuteIDsMemRatio' field
 func SetCacheUserMuteIDsMemRatio(v float64) { global.SetCacheUserMuteIDsMemRatio(v) }
 
+// GetCacheWebPushSubscriptionMemRatio safely fetches the Configuration value for state's 'Cache.WebPushSubscriptionMemRatio' field
+func (st *ConfigState) GetCacheWebPushSubscriptionMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.WebPushSubscriptionMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheWebPushSubscriptionMemRatio safely sets the Configuration value for state's 'Cache.WebPushSubscriptionMemRatio' field
+func (st *ConfigState) SetCacheWebPushSubscriptionMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.WebPushSubscriptionMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheWebPushSubscriptionMemRatioFlag returns the flag name for the 'Cache.WebPushSubscriptionMemRatio' field
+func CacheWebPushSubscriptionMemRatioFlag() string { return "cache-web-push-subscription-mem-ratio" }
+
+// GetCacheWebPushSubscriptionMemRatio safely fetches the value for global configuration 'Cache.WebPushSubscriptionMemRatio' field
+func GetCacheWebPushSubscriptionMemRatio() float64 {
+	return global.GetCacheWebPushSubscriptionMemRatio()
+}
+
+// SetCacheWebPushSubscriptionMemRatio safely sets the value for global configuration 'Cache.WebPushSubscriptionMemRatio' field
+func SetCacheWebPushSubscriptionMemRatio(v float64) { global.SetCacheWebPushSubscriptionMemRatio(v) }
+
+// GetCacheWebPushSubscriptionIDsMemRatio safely fetches the Configuration value for state's 'Cache.WebPushSubscriptionIDsMemRatio' field
+func (st *ConfigState) GetCacheWebPushSubscriptionIDsMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.WebPushSubscriptionIDsMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheWebPushSubscriptionIDsMemRatio safely sets the Configuration value for state's 'Cache.WebPushSubscriptionIDsMemRatio' field
+func (st *ConfigState) SetCacheWebPushSubscriptionIDsMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.WebPushSubscriptionIDsMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheWebPushSubscriptionIDsMemRatioFlag returns the flag name for the 'Cache.WebPushSubscriptionIDsMemRatio' field
+func CacheWebPushSubscriptionIDsMemRatioFlag() string {
+	return "cache-web-push-subscription-ids-mem-ratio"
+}
+
+// GetCacheWebPushSubscriptionIDsMemRatio safely fetches the value for global configuration 'Cache.WebPushSubscriptionIDsMemRatio' field
+func GetCacheWebPushSubscriptionIDsMemRatio() float64 {
+	return global.GetCacheWebPushSubscriptionIDsMemRatio()
+}
+
+// SetCacheWebPushSubscriptionIDsMemRatio safely sets the value for global configuration 'Cache.WebPushSubscriptionIDsMemRatio' field
+func SetCacheWebPushSubscriptionIDsMemRatio(v float64) {
+	global.SetCacheWebPushSubscriptionIDsMemRatio(v)
+}
+
 // GetCacheWebfingerMemRatio safely fetches the Configuration value for state's 'Cache.WebfingerMemRatio' field
 func (st *ConfigState) GetCacheWebfingerMemRatio() (v float64) {
 	st.mutex.RLock()
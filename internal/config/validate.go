@@ -100,6 +100,38 @@ func Validate() error {
 		)
 	}
 
+	// `accounts-invite-role` should be
+	// "none", "moderator" or "user".
+	switch inviteRole := GetAccountsInviteRole(); inviteRole {
+	case AccountsInviteRoleNone, AccountsInviteRoleModerator, AccountsInviteRoleUser:
+		// No problem.
+
+	case "":
+		errf("%s must be set", AccountsInviteRoleFlag())
+
+	default:
+		errf(
+			"%s must be set to one of none, moderator or user, provided value was %s",
+			AccountsInviteRoleFlag(), inviteRole,
+		)
+	}
+
+	// `advanced-rate-limit-store` should
+	// be "memory" or "database".
+	switch rlStore := GetAdvancedRateLimitStore(); rlStore {
+	case RateLimitStoreMemory, RateLimitStoreDatabase:
+		// No problem.
+
+	case "":
+		errf("%s must be set", AdvancedRateLimitStoreFlag())
+
+	default:
+		errf(
+			"%s must be set to either memory or database, provided value was %s",
+			AdvancedRateLimitStoreFlag(), rlStore,
+		)
+	}
+
 	// Parse `instance-languages`, and
 	// set enriched version into config.
 	parsedLangs, err := language.InitLangs(GetInstanceLanguages().TagStrs())
@@ -123,6 +123,10 @@ type Account interface {
 	// In the case of no statuses, this function will return db.ErrNoEntries.
 	GetAccountWebStatuses(ctx context.Context, account *gtsmodel.Account, limit int, maxID string) ([]*gtsmodel.Status, error)
 
+	// GetRecentlyActiveLocalAccountIDs returns the IDs of up to limit local
+	// accounts that have posted statuses, most recently active first.
+	GetRecentlyActiveLocalAccountIDs(ctx context.Context, limit int) ([]string, error)
+
 	// GetInstanceAccount returns the instance account for the given domain.
 	// If domain is empty, this instance account will be returned.
 	GetInstanceAccount(ctx context.Context, domain string) (*gtsmodel.Account, error)
@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type AccountArchive interface {
+	// GetAccountArchiveByID gets one account archive with the given id.
+	GetAccountArchiveByID(ctx context.Context, id string) (*gtsmodel.AccountArchive, error)
+
+	// GetAccountArchivesByAccountID returns all account
+	// archives requested by the given account, newest first.
+	GetAccountArchivesByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.AccountArchive, error)
+
+	// GetAccountArchivesByState returns all account
+	// archives in the given state of being built.
+	GetAccountArchivesByState(ctx context.Context, state gtsmodel.AccountArchiveState) ([]*gtsmodel.AccountArchive, error)
+
+	// GetExpiredAccountArchives returns all account
+	// archives that expired at or before the given time.
+	GetExpiredAccountArchives(ctx context.Context, now time.Time) ([]*gtsmodel.AccountArchive, error)
+
+	// PutAccountArchive puts the given account archive in the database.
+	PutAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive) error
+
+	// UpdateAccountArchive updates the given account archive in the database.
+	UpdateAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive, columns ...string) error
+
+	// DeleteAccountArchiveByID deletes one account archive with the given id.
+	DeleteAccountArchiveByID(ctx context.Context, id string) error
+}
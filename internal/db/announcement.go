@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type Announcement interface {
+	// GetAnnouncementByID gets one announcement with the given id.
+	GetAnnouncementByID(ctx context.Context, id string) (*gtsmodel.Announcement, error)
+
+	// GetAnnouncements returns all announcements,
+	// including unpublished ones, newest first.
+	GetAnnouncements(ctx context.Context) ([]*gtsmodel.Announcement, error)
+
+	// GetActiveAnnouncements returns all announcements which are published,
+	// have started, and have not ended as of the given time, newest first.
+	GetActiveAnnouncements(ctx context.Context, now time.Time) ([]*gtsmodel.Announcement, error)
+
+	// PutAnnouncement puts the given announcement in the database.
+	PutAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error
+
+	// UpdateAnnouncement updates the given announcement in the database.
+	UpdateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement, columns ...string) error
+
+	// DeleteAnnouncementByID deletes one announcement with the
+	// given ID, along with all of its dismissals and reactions.
+	DeleteAnnouncementByID(ctx context.Context, id string) error
+
+	// IsAnnouncementDismissed returns true if the given
+	// account has dismissed the given announcement.
+	IsAnnouncementDismissed(ctx context.Context, announcementID string, accountID string) (bool, error)
+
+	// PutAnnouncementDismissal puts the given announcement dismissal in the database.
+	PutAnnouncementDismissal(ctx context.Context, dismissal *gtsmodel.AnnouncementDismissal) error
+
+	// GetAnnouncementReactions returns all reactions
+	// to the given announcement, oldest first.
+	GetAnnouncementReactions(ctx context.Context, announcementID string) ([]*gtsmodel.AnnouncementReaction, error)
+
+	// PutAnnouncementReaction puts the given announcement reaction in the database.
+	PutAnnouncementReaction(ctx context.Context, reaction *gtsmodel.AnnouncementReaction) error
+
+	// DeleteAnnouncementReaction deletes the reaction with the given
+	// name by the given account to the given announcement, if it exists.
+	DeleteAnnouncementReaction(ctx context.Context, announcementID string, accountID string, name string) error
+
+	// DeleteAnnouncementDataByAccountID deletes all announcement
+	// dismissals and reactions created by the given account.
+	DeleteAnnouncementDataByAccountID(ctx context.Context, accountID string) error
+}
@@ -21,6 +21,7 @@
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 type Application interface {
@@ -48,6 +49,9 @@ type Application interface {
 	// GetAllTokens ...
 	GetAllTokens(ctx context.Context) ([]*gtsmodel.Token, error)
 
+	// GetTokenByID ...
+	GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, error)
+
 	// GetTokenByCode ...
 	GetTokenByCode(ctx context.Context, code string) (*gtsmodel.Token, error)
 
@@ -57,9 +61,17 @@ type Application interface {
 	// GetTokenByRefresh ...
 	GetTokenByRefresh(ctx context.Context, refresh string) (*gtsmodel.Token, error)
 
+	// GetAccessTokensByUserID returns a page of access tokens owned by the given user ID,
+	// newest first. Tokens which are only an authorization code (no access token) are skipped.
+	GetAccessTokensByUserID(ctx context.Context, userID string, page *paging.Page) ([]*gtsmodel.Token, error)
+
 	// PutToken ...
 	PutToken(ctx context.Context, token *gtsmodel.Token) error
 
+	// UpdateToken updates the given token. If no columns
+	// are specified, all columns will be updated.
+	UpdateToken(ctx context.Context, token *gtsmodel.Token, columns ...string) error
+
 	// DeleteTokenByID ...
 	DeleteTokenByID(ctx context.Context, id string) error
 
@@ -463,7 +463,28 @@ func (a *accountDB) GetAccounts(
 		useAccountIDIn = true
 	}
 
-	// TODO: invitedBy
+	if invitedBy != "" {
+		// Get only accounts of users who signed
+		// up with an invite created by invitedBy.
+		invites, err := a.state.DB.GetInvitesByAccountID(ctx, invitedBy, nil)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, fmt.Errorf("error getting invites: %w", err)
+		}
+		if err := lazyLoadUsers(); err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			if user.InviteID == "" {
+				continue
+			}
+			if slices.ContainsFunc(invites, func(invite *gtsmodel.Invite) bool {
+				return invite.ID == user.InviteID
+			}) {
+				accountIDIn = append(accountIDIn, user.AccountID)
+			}
+		}
+		useAccountIDIn = true
+	}
 
 	if username != "" {
 		q = q.Where("? = ?", bun.Ident("account.username"), username)
@@ -1094,6 +1115,27 @@ func (a *accountDB) GetAccountWebStatuses(
 	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
 }
 
+func (a *accountDB) GetRecentlyActiveLocalAccountIDs(ctx context.Context, limit int) ([]string, error) {
+	accountIDs := make([]string, 0, limit)
+
+	// Select the authors of local, non-boost statuses,
+	// ordered by the ID (ie., age) of their newest status.
+	if err := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("? AS ?", bun.Ident("status.account_id"), bun.Ident("account_id")).
+		Where("? = ?", bun.Ident("status.local"), true).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		GroupExpr("?", bun.Ident("status.account_id")).
+		OrderExpr("MAX(?) DESC", bun.Ident("status.id")).
+		Limit(limit).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	return accountIDs, nil
+}
+
 func (a *accountDB) GetAccountSettings(
 	ctx context.Context,
 	accountID string,
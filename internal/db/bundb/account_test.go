@@ -715,6 +715,54 @@ func (suite *AccountTestSuite) TestGetPendingAccounts() {
 	suite.Len(accounts, 1)
 }
 
+func (suite *AccountTestSuite) TestGetAccountsInvitedBy() {
+	var (
+		ctx         = context.Background()
+		origin      = ""
+		status      = ""
+		mods        = false
+		invitedBy   = suite.testAccounts["admin_account"].ID
+		username    = ""
+		displayName = ""
+		domain      = ""
+		email       = ""
+		ip          netip.Addr
+		page        *paging.Page = nil
+	)
+
+	// Mark local_account_1 as having
+	// signed up with the admin's invite.
+	user, err := suite.db.GetUserByAccountID(ctx, suite.testAccounts["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user.InviteID = suite.testInvites["admin_account_invite_1"].ID
+	if err := suite.db.UpdateUser(ctx, user, "invite_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accounts, err := suite.db.GetAccounts(
+		ctx,
+		origin,
+		status,
+		mods,
+		invitedBy,
+		username,
+		displayName,
+		domain,
+		email,
+		ip,
+		page,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(accounts, 1)
+	suite.Equal(user.AccountID, accounts[0].ID)
+}
+
 func (suite *AccountTestSuite) TestAccountStatsAll() {
 	ctx := context.Background()
 	for _, account := range suite.testAccounts {
@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type accountArchiveDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (a *accountArchiveDB) GetAccountArchiveByID(ctx context.Context, id string) (*gtsmodel.AccountArchive, error) {
+	archive := new(gtsmodel.AccountArchive)
+
+	if err := a.db.
+		NewSelect().
+		Model(archive).
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+func (a *accountArchiveDB) GetAccountArchivesByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.AccountArchive, error) {
+	var archives []*gtsmodel.AccountArchive
+
+	if err := a.db.
+		NewSelect().
+		Model(&archives).
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		OrderExpr("? DESC", bun.Ident("id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(archives) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return archives, nil
+}
+
+func (a *accountArchiveDB) GetAccountArchivesByState(ctx context.Context, state gtsmodel.AccountArchiveState) ([]*gtsmodel.AccountArchive, error) {
+	var archives []*gtsmodel.AccountArchive
+
+	if err := a.db.
+		NewSelect().
+		Model(&archives).
+		Where("? = ?", bun.Ident("state"), state).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(archives) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return archives, nil
+}
+
+func (a *accountArchiveDB) GetExpiredAccountArchives(ctx context.Context, now time.Time) ([]*gtsmodel.AccountArchive, error) {
+	var archives []*gtsmodel.AccountArchive
+
+	if err := a.db.
+		NewSelect().
+		Model(&archives).
+		Where("? <= ?", bun.Ident("expires_at"), now).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(archives) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return archives, nil
+}
+
+func (a *accountArchiveDB) PutAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive) error {
+	_, err := a.db.
+		NewInsert().
+		Model(archive).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountArchiveDB) UpdateAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive, columns ...string) error {
+	archive.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := a.db.
+		NewUpdate().
+		Model(archive).
+		Column(columns...).
+		Where("? = ?", bun.Ident("id"), archive.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountArchiveDB) DeleteAccountArchiveByID(ctx context.Context, id string) error {
+	_, err := a.db.
+		NewDelete().
+		Table("account_archives").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
@@ -19,8 +19,12 @@
 
 import (
 	"context"
+	"errors"
+	"slices"
 
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
@@ -143,6 +147,79 @@ func (a *applicationDB) GetAllTokens(ctx context.Context) ([]*gtsmodel.Token, er
 		return nil, err
 	}
 
+	// Load all tokens by their IDs.
+	return a.getTokensByIDs(ctx, tokenIDs)
+}
+
+func (a *applicationDB) GetAccessTokensByUserID(ctx context.Context, userID string, page *paging.Page) ([]*gtsmodel.Token, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		tokenIDs = make([]string, 0, limit)
+	)
+
+	// Create the basic select query.
+	q := a.db.
+		NewSelect().
+		Column("id").
+		TableExpr(
+			"? AS ?",
+			bun.Ident("tokens"),
+			bun.Ident("token"),
+		).
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Where("? != ''", bun.Ident("access"))
+
+	// Add paging param max ID.
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("id"), maxID)
+	}
+
+	// Add paging param min ID.
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("id"), minID)
+	}
+
+	// Add paging param order.
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("id"))
+	}
+
+	// Add paging param limit.
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	// Execute the query and scan into IDs.
+	if err := q.Scan(ctx, &tokenIDs); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no items early
+	if len(tokenIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want tokens
+	// to be sorted by ID desc, so reverse ids slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(tokenIDs)
+	}
+
+	// Load all tokens by their IDs.
+	return a.getTokensByIDs(ctx, tokenIDs)
+}
+
+func (a *applicationDB) getTokensByIDs(ctx context.Context, tokenIDs []string) ([]*gtsmodel.Token, error) {
 	// Load all input token IDs via cache loader callback.
 	tokens, err := a.state.Caches.DB.Token.LoadIDs("ID",
 		tokenIDs,
@@ -174,6 +251,16 @@ func(uncached []string) ([]*gtsmodel.Token, error) {
 	return tokens, nil
 }
 
+func (a *applicationDB) GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, error) {
+	return a.getTokenBy(
+		"ID",
+		func(t *gtsmodel.Token) error {
+			return a.db.NewSelect().Model(t).Where("? = ?", bun.Ident("id"), id).Scan(ctx)
+		},
+		id,
+	)
+}
+
 func (a *applicationDB) GetTokenByCode(ctx context.Context, code string) (*gtsmodel.Token, error) {
 	return a.getTokenBy(
 		"Code",
@@ -223,54 +310,59 @@ func (a *applicationDB) PutToken(ctx context.Context, token *gtsmodel.Token) err
 	})
 }
 
-func (a *applicationDB) DeleteTokenByID(ctx context.Context, id string) error {
-	_, err := a.db.NewDelete().
-		Table("tokens").
-		Where("? = ?", bun.Ident("id"), id).
-		Exec(ctx)
-	if err != nil {
+func (a *applicationDB) UpdateToken(ctx context.Context, token *gtsmodel.Token, columns ...string) error {
+	return a.state.Caches.DB.Token.Store(token, func() error {
+		_, err := a.db.NewUpdate().
+			Model(token).
+			Column(columns...).
+			Where("? = ?", bun.Ident("id"), token.ID).
+			Exec(ctx)
 		return err
-	}
+	})
+}
 
-	a.state.Caches.DB.Token.Invalidate("ID", id)
-	return nil
+func (a *applicationDB) DeleteTokenByID(ctx context.Context, id string) error {
+	return a.deleteTokensBy(ctx, "id", id)
 }
 
 func (a *applicationDB) DeleteTokenByCode(ctx context.Context, code string) error {
-	_, err := a.db.NewDelete().
-		Table("tokens").
-		Where("? = ?", bun.Ident("code"), code).
-		Exec(ctx)
-	if err != nil {
-		return err
-	}
-
-	a.state.Caches.DB.Token.Invalidate("Code", code)
-	return nil
+	return a.deleteTokensBy(ctx, "code", code)
 }
 
 func (a *applicationDB) DeleteTokenByAccess(ctx context.Context, access string) error {
-	_, err := a.db.NewDelete().
-		Table("tokens").
-		Where("? = ?", bun.Ident("access"), access).
-		Exec(ctx)
-	if err != nil {
-		return err
-	}
-
-	a.state.Caches.DB.Token.Invalidate("Access", access)
-	return nil
+	return a.deleteTokensBy(ctx, "access", access)
 }
 
 func (a *applicationDB) DeleteTokenByRefresh(ctx context.Context, refresh string) error {
-	_, err := a.db.NewDelete().
+	return a.deleteTokensBy(ctx, "refresh", refresh)
+}
+
+// deleteTokensBy deletes tokens where given column matches
+// value, along with any web push subscriptions created with
+// the deleted tokens, as these can no longer be managed.
+func (a *applicationDB) deleteTokensBy(ctx context.Context, column string, value string) error {
+	var deletedIDs []string
+
+	// Delete matching tokens,
+	// returning deleted IDs.
+	if err := a.db.NewDelete().
 		Table("tokens").
-		Where("? = ?", bun.Ident("refresh"), refresh).
-		Exec(ctx)
-	if err != nil {
+		Where("? = ?", bun.Ident(column), value).
+		Returning("?", bun.Ident("id")).
+		Scan(ctx, &deletedIDs); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
 		return err
 	}
 
-	a.state.Caches.DB.Token.Invalidate("Refresh", refresh)
+	for _, id := range deletedIDs {
+		// Invalidate deleted token from the cache.
+		a.state.Caches.DB.Token.Invalidate("ID", id)
+
+		// Delete any push subscription using this token.
+		if err := a.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, id); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
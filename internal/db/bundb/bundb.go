@@ -54,16 +54,21 @@
 // DBService satisfies the DB interface
 type DBService struct {
 	db.Account
+	db.AccountArchive
 	db.Admin
 	db.AdvancedMigration
+	db.Announcement
 	db.Application
 	db.Basic
+	db.Card
 	db.Conversation
 	db.Domain
 	db.Emoji
 	db.HeaderFilter
+	db.Import
 	db.Instance
 	db.Interaction
+	db.Invite
 	db.Filter
 	db.List
 	db.Marker
@@ -72,20 +77,27 @@ type DBService struct {
 	db.Move
 	db.Notification
 	db.Poll
+	db.RateLimit
 	db.Relationship
+	db.Relay
 	db.Report
 	db.Rule
+	db.ScheduledStatus
 	db.Search
 	db.Session
 	db.SinBinStatus
 	db.Status
 	db.StatusBookmark
+	db.StatusEdit
 	db.StatusFave
+	db.Suggestion
 	db.Tag
 	db.Thread
 	db.Timeline
+	db.Trend
 	db.User
 	db.Tombstone
+	db.WebPush
 	db.WorkerTask
 	db *bun.DB
 }
@@ -183,6 +195,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		AccountArchive: &accountArchiveDB{
+			db:    db,
+			state: state,
+		},
 		Admin: &adminDB{
 			db:    db,
 			state: state,
@@ -191,6 +207,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Announcement: &announcementDB{
+			db:    db,
+			state: state,
+		},
 		Application: &applicationDB{
 			db:    db,
 			state: state,
@@ -198,6 +218,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 		Basic: &basicDB{
 			db: db,
 		},
+		Card: &cardDB{
+			db:    db,
+			state: state,
+		},
 		Conversation: &conversationDB{
 			db:    db,
 			state: state,
@@ -214,6 +238,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Import: &importDB{
+			db:    db,
+			state: state,
+		},
 		Instance: &instanceDB{
 			db:    db,
 			state: state,
@@ -222,6 +250,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Invite: &inviteDB{
+			db:    db,
+			state: state,
+		},
 		Filter: &filterDB{
 			db:    db,
 			state: state,
@@ -254,10 +286,17 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		RateLimit: &rateLimitDB{
+			db: db,
+		},
 		Relationship: &relationshipDB{
 			db:    db,
 			state: state,
 		},
+		Relay: &relayDB{
+			db:    db,
+			state: state,
+		},
 		Report: &reportDB{
 			db:    db,
 			state: state,
@@ -266,6 +305,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		ScheduledStatus: &scheduledStatusDB{
+			db:    db,
+			state: state,
+		},
 		Search: &searchDB{
 			db:    db,
 			state: state,
@@ -285,10 +328,18 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		StatusEdit: &statusEditDB{
+			db:    db,
+			state: state,
+		},
 		StatusFave: &statusFaveDB{
 			db:    db,
 			state: state,
 		},
+		Suggestion: &suggestionDB{
+			db:    db,
+			state: state,
+		},
 		Tag: &tagDB{
 			db:    db,
 			state: state,
@@ -301,6 +352,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Trend: &trendDB{
+			db:    db,
+			state: state,
+		},
 		User: &userDB{
 			db:    db,
 			state: state,
@@ -309,6 +364,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		WebPush: &webPushDB{
+			db:    db,
+			state: state,
+		},
 		WorkerTask: &workerTaskDB{
 			db: db,
 		},
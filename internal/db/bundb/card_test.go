@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type CardTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *CardTestSuite) TestPutGetUpdateCard() {
+	ctx := context.Background()
+
+	card := &gtsmodel.Card{
+		ID:          id.NewULID(),
+		FetchedAt:   time.Now(),
+		URL:         "https://example.org/some/article",
+		Type:        gtsmodel.CardTypeVideo,
+		Title:       "Some Article",
+		Description: "It's about something.",
+		HTML:        `<iframe src="https://example.org/embed/video"></iframe>`,
+	}
+
+	if err := suite.db.PutCard(ctx, card); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Cards are unique by URL.
+	err := suite.db.PutCard(ctx, &gtsmodel.Card{
+		ID:  id.NewULID(),
+		URL: card.URL,
+	})
+	suite.True(errors.Is(err, db.ErrAlreadyExists))
+
+	dbCard, err := suite.db.GetCardByURL(ctx, card.URL)
+	suite.NoError(err)
+	suite.Equal(card.ID, dbCard.ID)
+	suite.Equal(gtsmodel.CardTypeVideo, dbCard.Type)
+	suite.Equal(card.HTML, dbCard.HTML)
+
+	card.Title = "Some Updated Article"
+	if err := suite.db.UpdateCard(ctx, card, "title"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbCard, err = suite.db.GetCardByID(ctx, card.ID)
+	suite.NoError(err)
+	suite.Equal("Some Updated Article", dbCard.Title)
+
+	// Attach card to a status,
+	// it should be populated.
+	status := suite.testStatuses["local_account_1_status_1"]
+	status.CardID = card.ID
+	if err := suite.db.UpdateStatus(ctx, status, "card_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	suite.NoError(err)
+	suite.NotNil(dbStatus.Card)
+	suite.Equal(card.URL, dbStatus.Card.URL)
+}
+
+func TestCardTestSuite(t *testing.T) {
+	suite.Run(t, new(CardTestSuite))
+}
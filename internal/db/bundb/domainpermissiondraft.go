@@ -0,0 +1,186 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+func (d *domainDB) GetDomainPermissionDraftByID(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionDraft, error) {
+	var draft gtsmodel.DomainPermissionDraft
+
+	q := d.db.
+		NewSelect().
+		Model(&draft).
+		Where("? = ?", bun.Ident("domain_permission_draft.id"), id)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &draft, nil
+}
+
+func (d *domainDB) GetDomainPermissionDraftByDomain(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	domain string,
+) (*gtsmodel.DomainPermissionDraft, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var draft gtsmodel.DomainPermissionDraft
+
+	q := d.db.
+		NewSelect().
+		Model(&draft).
+		Where("? = ?", bun.Ident("domain_permission_draft.permission_type"), permType).
+		Where("? = ?", bun.Ident("domain_permission_draft.domain"), domain)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &draft, nil
+}
+
+func (d *domainDB) GetDomainPermissionDrafts(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	subscriptionID string,
+	domain string,
+	page *paging.Page,
+) ([]*gtsmodel.DomainPermissionDraft, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		drafts = make([]*gtsmodel.DomainPermissionDraft, 0, limit)
+	)
+
+	q := d.db.
+		NewSelect().
+		Model(&drafts)
+
+	// Filter on permission type, if given.
+	if permType != gtsmodel.DomainPermissionUnknown {
+		q = q.Where("? = ?", bun.Ident("domain_permission_draft.permission_type"), permType)
+	}
+
+	// Filter on subscription ID, if given.
+	if subscriptionID != "" {
+		q = q.Where("? = ?", bun.Ident("domain_permission_draft.subscription_id"), subscriptionID)
+	}
+
+	// Filter on domain, if given.
+	if domain != "" {
+		var err error
+		domain, err = util.Punify(domain)
+		if err != nil {
+			return nil, err
+		}
+
+		q = q.Where("? = ?", bun.Ident("domain_permission_draft.domain"), domain)
+	}
+
+	// Add paging param max ID.
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("domain_permission_draft.id"), maxID)
+	}
+
+	// Add paging param min ID.
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("domain_permission_draft.id"), minID)
+	}
+
+	// Add paging param order.
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("domain_permission_draft.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("domain_permission_draft.id"))
+	}
+
+	// Add paging param limit.
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no items early
+	if len(drafts) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want drafts
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(drafts)
+	}
+
+	return drafts, nil
+}
+
+func (d *domainDB) PutDomainPermissionDraft(
+	ctx context.Context,
+	draft *gtsmodel.DomainPermissionDraft,
+) error {
+	// Normalize the domain as punycode
+	var err error
+	draft.Domain, err = util.Punify(draft.Domain)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.
+		NewInsert().
+		Model(draft).
+		Exec(ctx)
+	return err
+}
+
+func (d *domainDB) DeleteDomainPermissionDraft(
+	ctx context.Context,
+	id string,
+) error {
+	_, err := d.db.
+		NewDelete().
+		Model((*gtsmodel.DomainPermissionDraft)(nil)).
+		Where("? = ?", bun.Ident("domain_permission_draft.id"), id).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,191 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+func (d *domainDB) GetDomainPermissionExcludeByID(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionExclude, error) {
+	var exclude gtsmodel.DomainPermissionExclude
+
+	q := d.db.
+		NewSelect().
+		Model(&exclude).
+		Where("? = ?", bun.Ident("domain_permission_exclude.id"), id)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &exclude, nil
+}
+
+func (d *domainDB) GetDomainPermissionExcludes(
+	ctx context.Context,
+	domain string,
+	page *paging.Page,
+) ([]*gtsmodel.DomainPermissionExclude, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		excludes = make([]*gtsmodel.DomainPermissionExclude, 0, limit)
+	)
+
+	q := d.db.
+		NewSelect().
+		Model(&excludes)
+
+	// Filter on domain, if given.
+	if domain != "" {
+		var err error
+		domain, err = util.Punify(domain)
+		if err != nil {
+			return nil, err
+		}
+
+		q = q.Where("? = ?", bun.Ident("domain_permission_exclude.domain"), domain)
+	}
+
+	// Add paging param max ID.
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("domain_permission_exclude.id"), maxID)
+	}
+
+	// Add paging param min ID.
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("domain_permission_exclude.id"), minID)
+	}
+
+	// Add paging param order.
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("domain_permission_exclude.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("domain_permission_exclude.id"))
+	}
+
+	// Add paging param limit.
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no items early
+	if len(excludes) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want excludes
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(excludes)
+	}
+
+	return excludes, nil
+}
+
+func (d *domainDB) PutDomainPermissionExclude(
+	ctx context.Context,
+	exclude *gtsmodel.DomainPermissionExclude,
+) error {
+	// Normalize the domain as punycode
+	var err error
+	exclude.Domain, err = util.Punify(exclude.Domain)
+	if err != nil {
+		return err
+	}
+
+	if _, err := d.db.
+		NewInsert().
+		Model(exclude).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the domain exclude cache (for later reload)
+	d.state.Caches.DB.DomainPermissionExclude.Clear()
+
+	return nil
+}
+
+func (d *domainDB) DeleteDomainPermissionExclude(
+	ctx context.Context,
+	id string,
+) error {
+	if _, err := d.db.
+		NewDelete().
+		Model((*gtsmodel.DomainPermissionExclude)(nil)).
+		Where("? = ?", bun.Ident("domain_permission_exclude.id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the domain exclude cache (for later reload)
+	d.state.Caches.DB.DomainPermissionExclude.Clear()
+
+	return nil
+}
+
+func (d *domainDB) IsDomainPermissionExcluded(ctx context.Context, domain string) (bool, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return false, err
+	}
+
+	// Domain referencing *us* is always excluded.
+	if domain == "" || domain == config.GetAccountDomain() ||
+		domain == config.GetHost() {
+		return true, nil
+	}
+
+	// Check the cache for a domain exclude (hydrating the cache with callback if necessary)
+	return d.state.Caches.DB.DomainPermissionExclude.Matches(domain, func() ([]string, error) {
+		var domains []string
+
+		// Scan list of all excluded domains from DB
+		q := d.db.NewSelect().
+			Table("domain_permission_excludes").
+			Column("domain")
+		if err := q.Scan(ctx, &domains); err != nil {
+			return nil, err
+		}
+
+		return domains, nil
+	})
+}
@@ -0,0 +1,202 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/uptrace/bun"
+)
+
+func (d *domainDB) GetDomainPermissionSubscriptionByID(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionSubscription, error) {
+	var sub gtsmodel.DomainPermissionSubscription
+
+	q := d.db.
+		NewSelect().
+		Model(&sub).
+		Where("? = ?", bun.Ident("domain_permission_subscription.id"), id)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &sub, nil
+}
+
+func (d *domainDB) GetDomainPermissionSubscriptions(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	page *paging.Page,
+) ([]*gtsmodel.DomainPermissionSubscription, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		subs = make([]*gtsmodel.DomainPermissionSubscription, 0, limit)
+	)
+
+	q := d.db.
+		NewSelect().
+		Model(&subs)
+
+	// Filter on permission type, if given.
+	if permType != gtsmodel.DomainPermissionUnknown {
+		q = q.Where("? = ?", bun.Ident("domain_permission_subscription.permission_type"), permType)
+	}
+
+	// Add paging param max ID.
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("domain_permission_subscription.id"), maxID)
+	}
+
+	// Add paging param min ID.
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("domain_permission_subscription.id"), minID)
+	}
+
+	// Add paging param order.
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("domain_permission_subscription.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("domain_permission_subscription.id"))
+	}
+
+	// Add paging param limit.
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no items early
+	if len(subs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want subs
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(subs)
+	}
+
+	return subs, nil
+}
+
+func (d *domainDB) GetDomainPermissionSubscriptionsByPriority(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+) ([]*gtsmodel.DomainPermissionSubscription, error) {
+	subs := []*gtsmodel.DomainPermissionSubscription{}
+
+	// Order by priority, then by ID so
+	// that subscriptions with the same
+	// priority are always processed in
+	// a predictable (oldest first) order.
+	if err := d.db.
+		NewSelect().
+		Model(&subs).
+		Where("? = ?", bun.Ident("domain_permission_subscription.permission_type"), permType).
+		OrderExpr("? DESC", bun.Ident("domain_permission_subscription.priority")).
+		OrderExpr("? ASC", bun.Ident("domain_permission_subscription.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return subs, nil
+}
+
+func (d *domainDB) PutDomainPermissionSubscription(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+) error {
+	_, err := d.db.
+		NewInsert().
+		Model(sub).
+		Exec(ctx)
+	return err
+}
+
+func (d *domainDB) UpdateDomainPermissionSubscription(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+	columns ...string,
+) error {
+	// Ensure updated_at is set.
+	sub.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := d.db.
+		NewUpdate().
+		Model(sub).
+		Column(columns...).
+		Where("? = ?", bun.Ident("domain_permission_subscription.id"), sub.ID).
+		Exec(ctx)
+	return err
+}
+
+func (d *domainDB) DeleteDomainPermissionSubscription(
+	ctx context.Context,
+	id string,
+) error {
+	_, err := d.db.
+		NewDelete().
+		Model((*gtsmodel.DomainPermissionSubscription)(nil)).
+		Where("? = ?", bun.Ident("domain_permission_subscription.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (d *domainDB) CountDomainPermissionSubscriptionPerms(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+) (int, error) {
+	var table string
+
+	switch sub.PermissionType {
+	case gtsmodel.DomainPermissionBlock:
+		table = "domain_blocks"
+	case gtsmodel.DomainPermissionAllow:
+		table = "domain_allows"
+	default:
+		return 0, gtserror.Newf("unrecognized permission type %d", sub.PermissionType)
+	}
+
+	return d.db.
+		NewSelect().
+		Table(table).
+		Where("? = ?", bun.Ident("subscription_id"), sub.ID).
+		Count(ctx)
+}
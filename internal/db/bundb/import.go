@@ -0,0 +1,164 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type importDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (i *importDB) GetImportByID(ctx context.Context, id string) (*gtsmodel.Import, error) {
+	imp := new(gtsmodel.Import)
+
+	if err := i.db.
+		NewSelect().
+		Model(imp).
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return imp, nil
+}
+
+func (i *importDB) GetImportsByAccountID(
+	ctx context.Context,
+	accountID string,
+	page *paging.Page,
+) ([]*gtsmodel.Import, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		imports = make([]*gtsmodel.Import, 0, limit)
+	)
+
+	q := i.db.
+		NewSelect().
+		Model(&imports).
+		Where("? = ?", bun.Ident("account_id"), accountID)
+
+	// Add paging param max ID.
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("id"), maxID)
+	}
+
+	// Add paging param min ID.
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("id"), minID)
+	}
+
+	// Add paging param order.
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("id"))
+	}
+
+	// Add paging param limit.
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no items early
+	if len(imports) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want imports
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(imports)
+	}
+
+	return imports, nil
+}
+
+func (i *importDB) GetImportsByState(ctx context.Context, state gtsmodel.ImportState) ([]*gtsmodel.Import, error) {
+	var imports []*gtsmodel.Import
+
+	if err := i.db.
+		NewSelect().
+		Model(&imports).
+		Where("? = ?", bun.Ident("state"), state).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(imports) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return imports, nil
+}
+
+func (i *importDB) PutImport(ctx context.Context, imp *gtsmodel.Import) error {
+	_, err := i.db.
+		NewInsert().
+		Model(imp).
+		Exec(ctx)
+	return err
+}
+
+func (i *importDB) UpdateImport(ctx context.Context, imp *gtsmodel.Import, columns ...string) error {
+	imp.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.db.
+		NewUpdate().
+		Model(imp).
+		Column(columns...).
+		Where("? = ?", bun.Ident("id"), imp.ID).
+		Exec(ctx)
+	return err
+}
+
+func (i *importDB) DeleteImportsByAccountID(ctx context.Context, accountID string) error {
+	_, err := i.db.
+		NewDelete().
+		Table("imports").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type ImportTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ImportTestSuite) TestImportPutGetUpdateDelete() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	imp := &gtsmodel.Import{
+		ID:         "01JHYX1QDJ6XW2W5F3XC5C5Z4D",
+		AccountID:  testAccount.ID,
+		Type:       gtsmodel.ImportTypeMutes,
+		Overwrite:  util.Ptr(false),
+		State:      gtsmodel.ImportStateProcessing,
+		TotalItems: 3,
+	}
+
+	if err := suite.db.PutImport(ctx, imp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Record some progress.
+	imp.ProcessedItems = 2
+	imp.FailedItems = 1
+	imp.Errors = []string{"someone@example.org: could not retrieve account"}
+	if err := suite.db.UpdateImport(ctx, imp,
+		"processed_items",
+		"failed_items",
+		"errors",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbImp, err := suite.db.GetImportByID(ctx, imp.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.ImportTypeMutes, dbImp.Type)
+	suite.Equal(gtsmodel.ImportStateProcessing, dbImp.State)
+	suite.False(*dbImp.Overwrite)
+	suite.Equal(3, dbImp.TotalItems)
+	suite.Equal(2, dbImp.ProcessedItems)
+	suite.Equal(1, dbImp.FailedItems)
+	suite.Equal(imp.Errors, dbImp.Errors)
+
+	imports, err := suite.db.GetImportsByAccountID(ctx, testAccount.ID, &paging.Page{})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(imports, 1)
+	suite.Equal(imp.ID, imports[0].ID)
+
+	if err := suite.db.DeleteImportsByAccountID(ctx, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetImportByID(ctx, imp.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
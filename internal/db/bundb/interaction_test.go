@@ -145,6 +145,7 @@ func (suite *InteractionTestSuite) TestGetPending() {
 		likes      = true
 		replies    = true
 		boosts     = true
+		quotes     = true
 		page       = &paging.Page{
 			Max:   paging.MaxID(id.Highest),
 			Limit: 20,
@@ -163,6 +164,7 @@ func (suite *InteractionTestSuite) TestGetPending() {
 		likes,
 		replies,
 		boosts,
+		quotes,
 		page,
 	)
 	suite.NoError(err)
@@ -180,6 +182,9 @@ func (suite *InteractionTestSuite) TestGetPending() {
 
 		case gtsmodel.InteractionAnnounce:
 			suite.NotNil(pendingInt.Announce)
+
+		case gtsmodel.InteractionQuote:
+			suite.NotNil(pendingInt.Quote)
 		}
 	}
 }
@@ -193,6 +198,7 @@ func (suite *InteractionTestSuite) TestGetPendingRepliesOnly() {
 		likes      = false
 		replies    = true
 		boosts     = false
+		quotes     = false
 		page       = &paging.Page{
 			Max:   paging.MaxID(id.Highest),
 			Limit: 20,
@@ -211,6 +217,7 @@ func (suite *InteractionTestSuite) TestGetPendingRepliesOnly() {
 		likes,
 		replies,
 		boosts,
+		quotes,
 		page,
 	)
 	suite.NoError(err)
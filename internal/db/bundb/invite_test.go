@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type InviteTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *InviteTestSuite) TestPutGetUpdateInvite() {
+	ctx := context.Background()
+
+	invite := &gtsmodel.Invite{
+		ID:          id.NewULID(),
+		Code:        "c0ffeec0ffee",
+		AccountID:   suite.testAccounts["local_account_1"].ID,
+		MaxUses:     1,
+		AutoApprove: util.Ptr(false),
+	}
+
+	if err := suite.db.PutInvite(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Invites are unique by code.
+	err := suite.db.PutInvite(ctx, &gtsmodel.Invite{
+		ID:        id.NewULID(),
+		Code:      invite.Code,
+		AccountID: invite.AccountID,
+	})
+	suite.True(errors.Is(err, db.ErrAlreadyExists))
+
+	dbInvite, err := suite.db.GetInviteByCode(ctx, invite.Code)
+	suite.NoError(err)
+	suite.Equal(invite.ID, dbInvite.ID)
+	suite.Zero(dbInvite.Uses)
+	suite.True(dbInvite.Usable())
+
+	invite.Uses++
+	if err := suite.db.UpdateInvite(ctx, invite, "uses"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbInvite, err = suite.db.GetInviteByID(ctx, invite.ID)
+	suite.NoError(err)
+	suite.Equal(1, dbInvite.Uses)
+	suite.True(dbInvite.UsedUp())
+	suite.False(dbInvite.Usable())
+}
+
+func (suite *InviteTestSuite) TestGetInvitesByAccountID() {
+	ctx := context.Background()
+
+	invites, err := suite.db.GetInvitesByAccountID(ctx, suite.testAccounts["admin_account"].ID, &paging.Page{Limit: 1})
+	suite.NoError(err)
+	suite.Len(invites, 1)
+	suite.Equal(suite.testInvites["admin_account_invite_2_expired"].ID, invites[0].ID)
+
+	_, err = suite.db.GetInvitesByAccountID(ctx, suite.testAccounts["local_account_1"].ID, nil)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestInviteTestSuite(t *testing.T) {
+	suite.Run(t, new(InviteTestSuite))
+}
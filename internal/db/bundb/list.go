@@ -367,6 +367,17 @@ func (l *listDB) PopulateListEntry(ctx context.Context, listEntry *gtsmodel.List
 		}
 	}
 
+	if listEntry.List == nil {
+		// ListEntry list is not set, fetch from the database.
+		listEntry.List, err = l.state.DB.GetListByID(
+			gtscontext.SetBarebones(ctx),
+			listEntry.ListID,
+		)
+		if err != nil {
+			return fmt.Errorf("error populating listEntry list: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Add the two-factor auth columns to users.
+			userType := reflect.TypeOf((*gtsmodel.User)(nil))
+			for _, column := range []string{
+				"two_factor_secret",
+				"two_factor_backups",
+				"two_factor_enabled_at",
+			} {
+				// If column already exists we don't need to do anything.
+				if exists, err := doesColumnExist(ctx, tx, "users", column); err != nil {
+					return err
+				} else if exists {
+					continue
+				}
+
+				// Generate column definition as bun would.
+				colDef, err := getBunColumnDef(tx, userType, column)
+				if err != nil {
+					return err
+				}
+
+				if _, err := tx.ExecContext(ctx,
+					"ALTER TABLE ? ADD COLUMN "+colDef,
+					bun.Ident("users"),
+				); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
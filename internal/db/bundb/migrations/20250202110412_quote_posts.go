@@ -0,0 +1,103 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Add the quote columns to statuses.
+			statusType := reflect.TypeOf((*gtsmodel.Status)(nil))
+			for _, column := range []string{
+				"quote_id",
+				"quote_uri",
+				"quote_state",
+				"quote_approved_by_uri",
+			} {
+				// If column already exists we don't need to do anything.
+				if exists, err := doesColumnExist(ctx, tx, "statuses", column); err != nil {
+					return err
+				} else if exists {
+					continue
+				}
+
+				// Generate column definition as bun would.
+				colDef, err := getBunColumnDef(tx, statusType, column)
+				if err != nil {
+					return err
+				}
+
+				if _, err := tx.ExecContext(ctx,
+					"ALTER TABLE ? ADD COLUMN "+colDef,
+					bun.Ident("statuses"),
+				); err != nil {
+					return err
+				}
+			}
+
+			// Add the quote column to scheduled statuses.
+			if exists, err := doesColumnExist(ctx, tx, "scheduled_statuses", "quote_id"); err != nil {
+				return err
+			} else if !exists {
+				scheduledStatusType := reflect.TypeOf((*gtsmodel.ScheduledStatus)(nil))
+				colDef, err := getBunColumnDef(tx, scheduledStatusType, "quote_id")
+				if err != nil {
+					return err
+				}
+
+				if _, err := tx.ExecContext(ctx,
+					"ALTER TABLE ? ADD COLUMN "+colDef,
+					bun.Ident("scheduled_statuses"),
+				); err != nil {
+					return err
+				}
+			}
+
+			// Index statuses by the status they quote,
+			// so quotes of a status can be found quickly.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("statuses").
+				Index("statuses_quote_id_idx").
+				Column("quote_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,276 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+// Creates the full-text search index of statuses, and
+// backfills it with the text of all existing statuses.
+//
+// The helpers below are frozen copies of those used to
+// maintain the index in bundb, so that this migration
+// keeps indexing the same way if those ever change.
+func init() {
+	const batchSize = 1000
+
+	up := func(ctx context.Context, db *bun.DB) error {
+		if err := db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Drop any index left behind by a previously
+			// interrupted run of this migration, so we can
+			// just backfill it from scratch.
+			for _, table := range []string{
+				"status_search",
+				"status_search_unstemmed",
+			} {
+				if _, err := tx.ExecContext(ctx,
+					"DROP TABLE IF EXISTS ?",
+					bun.Ident(table),
+				); err != nil {
+					return err
+				}
+			}
+
+			switch d := tx.Dialect().Name(); d {
+
+			case dialect.SQLite:
+				// SQLite's porter stemmer only knows English,
+				// so English statuses are indexed with stemming
+				// and all others in a separate unstemmed table.
+				if _, err := tx.ExecContext(ctx,
+					"CREATE VIRTUAL TABLE ? USING fts5(?, ?, tokenize = 'porter unicode61 remove_diacritics 2')",
+					bun.Ident("status_search"),
+					bun.Ident("status_id"),
+					bun.Ident("text"),
+				); err != nil {
+					return err
+				}
+
+				_, err := tx.ExecContext(ctx,
+					"CREATE VIRTUAL TABLE ? USING fts5(?, ?, tokenize = 'unicode61 remove_diacritics 2')",
+					bun.Ident("status_search_unstemmed"),
+					bun.Ident("status_id"),
+					bun.Ident("text"),
+				)
+				return err
+
+			case dialect.PG:
+				if _, err := tx.ExecContext(ctx,
+					"CREATE TABLE ? (? CHAR(26) NOT NULL PRIMARY KEY, ? TSVECTOR NOT NULL)",
+					bun.Ident("status_search"),
+					bun.Ident("status_id"),
+					bun.Ident("vector"),
+				); err != nil {
+					return err
+				}
+
+				_, err := tx.ExecContext(ctx,
+					"CREATE INDEX ? ON ? USING GIN (?)",
+					bun.Ident("status_search_vector_idx"),
+					bun.Ident("status_search"),
+					bun.Ident("vector"),
+				)
+				return err
+
+			default:
+				log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+				return nil
+			}
+		}); err != nil {
+			return err
+		}
+
+		log.Info(ctx,
+			"indexing statuses for full-text search, please wait; "+
+				"this may take a long time if your database has lots of statuses, don't interrupt it!",
+		)
+
+		var (
+			maxID string
+			total int
+		)
+
+		for {
+			// Select next batch of non-boost
+			// statuses, working up through IDs.
+			var statuses []*statusSearchStatus
+			if err := db.NewSelect().
+				Model(&statuses).
+				Column("id", "content", "content_warning", "language").
+				Where("? IS NULL", bun.Ident("boost_of_id")).
+				Where("? > ?", bun.Ident("id"), maxID).
+				OrderExpr("? ASC", bun.Ident("id")).
+				Limit(batchSize).
+				Scan(ctx); err != nil {
+				return err
+			}
+
+			if len(statuses) == 0 {
+				// Done.
+				break
+			}
+
+			if err := db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+				for _, status := range statuses {
+					if err := indexStatusSearch(ctx, tx, status); err != nil {
+						return err
+					}
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			total += len(statuses)
+			maxID = statuses[len(statuses)-1].ID
+			log.Infof(ctx, "indexed %d statuses so far, continuing...", total)
+		}
+
+		log.Infof(ctx, "finished indexing %d statuses for full-text search", total)
+		return nil
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
+
+// statusSearchStatus contains only
+// the status columns we index.
+type statusSearchStatus struct {
+	bun.BaseModel `bun:"table:statuses"`
+
+	ID             string `bun:"type:CHAR(26),pk,nullzero"`
+	Content        string `bun:""`
+	ContentWarning string `bun:",nullzero"`
+	Language       string `bun:",nullzero"`
+}
+
+// indexStatusSearch inserts the given
+// status into the full-text search index.
+func indexStatusSearch(ctx context.Context, tx bun.Tx, status *statusSearchStatus) error {
+	// Pad tags with a space before removing them,
+	// so that text in adjacent elements isn't joined up.
+	content := strings.ReplaceAll(status.Content, "<", " <")
+	content = text.SanitizeToPlaintext(content)
+	searchText := strings.TrimSpace(status.ContentWarning + " " + content)
+
+	switch d := tx.Dialect().Name(); d {
+
+	case dialect.SQLite:
+		if searchText == "" {
+			// Nothing to index.
+			return nil
+		}
+
+		table := "status_search_unstemmed"
+		if lang, _, _ := strings.Cut(strings.ToLower(status.Language), "-"); lang == "en" {
+			table = "status_search"
+		}
+
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO ? (?, ?) VALUES (?, ?)",
+			bun.Ident(table),
+			bun.Ident("status_id"),
+			bun.Ident("text"),
+			status.ID,
+			searchText,
+		)
+		return err
+
+	case dialect.PG:
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO ? (?, ?) VALUES (?, to_tsvector(?::regconfig, ?) || to_tsvector('simple', ?))",
+			bun.Ident("status_search"),
+			bun.Ident("status_id"),
+			bun.Ident("vector"),
+			status.ID,
+			statusSearchPGConfig(status.Language), searchText, searchText,
+		)
+		return err
+
+	default:
+		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return nil
+	}
+}
+
+// statusSearchPGConfig returns the Postgres text
+// search configuration for given language tag.
+func statusSearchPGConfig(lang string) string {
+	lang, _, _ = strings.Cut(strings.ToLower(lang), "-")
+	switch lang {
+	case "ar":
+		return "arabic"
+	case "da":
+		return "danish"
+	case "de":
+		return "german"
+	case "en":
+		return "english"
+	case "es":
+		return "spanish"
+	case "fi":
+		return "finnish"
+	case "fr":
+		return "french"
+	case "ga":
+		return "irish"
+	case "hu":
+		return "hungarian"
+	case "id":
+		return "indonesian"
+	case "it":
+		return "italian"
+	case "lt":
+		return "lithuanian"
+	case "nb", "nn", "no":
+		return "norwegian"
+	case "ne":
+		return "nepali"
+	case "nl":
+		return "dutch"
+	case "pt":
+		return "portuguese"
+	case "ro":
+		return "romanian"
+	case "ru":
+		return "russian"
+	case "sv":
+		return "swedish"
+	case "ta":
+		return "tamil"
+	case "tr":
+		return "turkish"
+	default:
+		return "simple"
+	}
+}
@@ -19,7 +19,9 @@
 
 import (
 	"context"
+	"reflect"
 
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect"
 )
@@ -38,3 +40,29 @@ func doesColumnExist(ctx context.Context, tx bun.Tx, table, col string) (bool, e
 	}
 	return (n > 0), err
 }
+
+// getBunColumnDef generates a column definition string for the SQL
+// table represented by the given Go type, for the column with given
+// SQL name. This ensures that a column added to an existing table by
+// a migration ends up exactly as bun would have created it, taking
+// account of dialect specific types (e.g. arrays) for SQLite / Postgres.
+func getBunColumnDef(db bun.IDB, rtype reflect.Type, column string) (string, error) {
+	table := db.Dialect().Tables().Get(rtype)
+
+	field, ok := table.FieldMap[column]
+	if !ok {
+		return "", gtserror.Newf("no column %s on table %s", column, table.Name)
+	}
+
+	def := string(field.SQLName) + " " + field.CreateTableSQLType
+
+	if field.NotNull {
+		def += " NOT NULL"
+	}
+
+	if field.SQLDefault != "" {
+		def += " DEFAULT " + field.SQLDefault
+	}
+
+	return def, nil
+}
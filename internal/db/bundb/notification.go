@@ -21,6 +21,7 @@
 	"context"
 	"errors"
 	"slices"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
@@ -247,6 +248,10 @@ func (n *notificationDB) GetAccountNotifications(
 	// Return only notifs for this account.
 	q = q.Where("? = ?", bun.Ident("notification.target_account_id"), accountID)
 
+	// Filtered notifs are only
+	// shown in notif requests.
+	q = q.Where("? = ?", bun.Ident("notification.filtered"), false)
+
 	if limit > 0 {
 		q = q.Limit(limit)
 	}
@@ -280,6 +285,59 @@ func (n *notificationDB) GetAccountNotifications(
 	return n.GetNotificationsByIDs(ctx, notifIDs)
 }
 
+func (n *notificationDB) GetAccountNotificationsGroup(
+	ctx context.Context,
+	accountID string,
+	notifType gtsmodel.NotificationType,
+	statusID string,
+	from time.Time,
+	to time.Time,
+) ([]*gtsmodel.Notification, error) {
+	var notifIDs []string
+
+	q := n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notifications"), bun.Ident("notification")).
+		Column("notification.id").
+		Where("? = ?", bun.Ident("notification.target_account_id"), accountID).
+		Where("? = ?", bun.Ident("notification.notification_type"), notifType).
+		Where("? >= ?", bun.Ident("notification.created_at"), from).
+		Where("? < ?", bun.Ident("notification.created_at"), to).
+		Where("? = ?", bun.Ident("notification.filtered"), false)
+
+	switch {
+	case statusID != "" && notifType == gtsmodel.NotificationReblog:
+		// Reblog notifs are about the boost
+		// wrapper status, so return only notifs
+		// about boosts of this status.
+		q = q.Where("? IN (?)",
+			bun.Ident("notification.status_id"),
+			n.db.
+				NewSelect().
+				Table("statuses").
+				Column("id").
+				Where("? = ?", bun.Ident("boost_of_id"), statusID),
+		)
+
+	case statusID != "":
+		// Return only notifs about this status.
+		q = q.Where("? = ?", bun.Ident("notification.status_id"), statusID)
+	}
+
+	if err := q.
+		Order("notification.id DESC").
+		Scan(ctx, &notifIDs); err != nil {
+		return nil, err
+	}
+
+	if len(notifIDs) == 0 {
+		return nil, nil
+	}
+
+	// Fetch notification models by their IDs.
+	return n.GetNotificationsByIDs(ctx, notifIDs)
+}
+
 func (n *notificationDB) PutNotification(ctx context.Context, notif *gtsmodel.Notification) error {
 	return n.state.Caches.DB.Notification.Store(notif, func() error {
 		_, err := n.db.NewInsert().Model(notif).Exec(ctx)
@@ -354,3 +412,43 @@ func (n *notificationDB) DeleteNotificationsForStatus(ctx context.Context, statu
 	n.state.Caches.DB.Notification.InvalidateIDs("ID", notifIDs)
 	return nil
 }
+
+func (n *notificationDB) UnfilterNotifications(ctx context.Context, targetAccountID string, originAccountID string) error {
+	var notifIDs []string
+
+	if _, err := n.db.
+		NewUpdate().
+		Table("notifications").
+		Set("? = ?", bun.Ident("filtered"), false).
+		Set("? = ?", bun.Ident("updated_at"), time.Now()).
+		Where("? = ?", bun.Ident("target_account_id"), targetAccountID).
+		Where("? = ?", bun.Ident("origin_account_id"), originAccountID).
+		Where("? = ?", bun.Ident("filtered"), true).
+		Returning("?", bun.Ident("id")).
+		Exec(ctx, &notifIDs); err != nil {
+		return err
+	}
+
+	// Invalidate all updated notifications by IDs.
+	n.state.Caches.DB.Notification.InvalidateIDs("ID", notifIDs)
+	return nil
+}
+
+func (n *notificationDB) DeleteFilteredNotifications(ctx context.Context, targetAccountID string, originAccountID string) error {
+	var notifIDs []string
+
+	if _, err := n.db.
+		NewDelete().
+		Table("notifications").
+		Where("? = ?", bun.Ident("target_account_id"), targetAccountID).
+		Where("? = ?", bun.Ident("origin_account_id"), originAccountID).
+		Where("? = ?", bun.Ident("filtered"), true).
+		Returning("?", bun.Ident("id")).
+		Exec(ctx, &notifIDs); err != nil {
+		return err
+	}
+
+	// Invalidate all deleted notifications by IDs.
+	n.state.Caches.DB.Notification.InvalidateIDs("ID", notifIDs)
+	return nil
+}
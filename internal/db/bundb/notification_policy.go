@@ -0,0 +1,318 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/uptrace/bun"
+)
+
+func (n *notificationDB) GetNotificationPolicy(ctx context.Context, accountID string) (*gtsmodel.NotificationPolicy, error) {
+	policy := new(gtsmodel.NotificationPolicy)
+
+	if err := n.db.
+		NewSelect().
+		Model(policy).
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (n *notificationDB) PutNotificationPolicy(ctx context.Context, policy *gtsmodel.NotificationPolicy) error {
+	_, err := n.db.
+		NewInsert().
+		Model(policy).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationDB) UpdateNotificationPolicy(ctx context.Context, policy *gtsmodel.NotificationPolicy, columns ...string) error {
+	policy.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := n.db.
+		NewUpdate().
+		Model(policy).
+		Column(columns...).
+		Where("? = ?", bun.Ident("id"), policy.ID).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationDB) DeleteNotificationPolicy(ctx context.Context, accountID string) error {
+	_, err := n.db.
+		NewDelete().
+		Table("notification_policies").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationDB) GetNotificationRequestByID(ctx context.Context, id string) (*gtsmodel.NotificationRequest, error) {
+	return n.getNotificationRequest(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("? = ?", bun.Ident("id"), id)
+	})
+}
+
+func (n *notificationDB) GetNotificationRequest(ctx context.Context, accountID string, fromAccountID string) (*gtsmodel.NotificationRequest, error) {
+	return n.getNotificationRequest(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			Where("? = ?", bun.Ident("from_account_id"), fromAccountID)
+	})
+}
+
+func (n *notificationDB) getNotificationRequest(
+	ctx context.Context,
+	where func(*bun.SelectQuery) *bun.SelectQuery,
+) (*gtsmodel.NotificationRequest, error) {
+	req := new(gtsmodel.NotificationRequest)
+
+	if err := where(n.db.
+		NewSelect().
+		Model(req)).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return req, nil
+	}
+
+	if err := n.PopulateNotificationRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (n *notificationDB) GetAccountNotificationRequests(
+	ctx context.Context,
+	accountID string,
+	page *paging.Page,
+) ([]*gtsmodel.NotificationRequest, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		reqs = make([]*gtsmodel.NotificationRequest, 0, limit)
+	)
+
+	q := n.db.
+		NewSelect().
+		Model(&reqs).
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Where("? IS NULL", bun.Ident("accepted_at"))
+
+	// Add paging param max ID.
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("id"), maxID)
+	}
+
+	// Add paging param min ID.
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("id"), minID)
+	}
+
+	// Add paging param order.
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("id"))
+	}
+
+	// Add paging param limit.
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no items early
+	if len(reqs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want requests
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(reqs)
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return reqs, nil
+	}
+
+	// Populate all loaded requests, removing those we
+	// fail to populate (eg., from since-deleted accounts).
+	reqs = slices.DeleteFunc(reqs, func(req *gtsmodel.NotificationRequest) bool {
+		if err := n.PopulateNotificationRequest(ctx, req); err != nil {
+			log.Errorf(ctx, "error populating notif request %s: %v", req.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return reqs, nil
+}
+
+func (n *notificationDB) CountAccountNotificationRequests(ctx context.Context, accountID string) (int, int, error) {
+	var counts struct {
+		Requests      int
+		Notifications int
+	}
+
+	if err := n.db.
+		NewSelect().
+		Table("notification_requests").
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("requests")).
+		ColumnExpr("COALESCE(SUM(?), 0) AS ?", bun.Ident("notifications_count"), bun.Ident("notifications")).
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Where("? IS NULL", bun.Ident("accepted_at")).
+		Scan(ctx, &counts); err != nil {
+		return 0, 0, err
+	}
+
+	return counts.Requests, counts.Notifications, nil
+}
+
+func (n *notificationDB) PopulateNotificationRequest(ctx context.Context, req *gtsmodel.NotificationRequest) error {
+	var (
+		errs gtserror.MultiError
+		err  error
+	)
+
+	if req.Account == nil {
+		req.Account, err = n.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			req.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating notif request account: %w", err)
+		}
+	}
+
+	if req.FromAccount == nil {
+		req.FromAccount, err = n.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			req.FromAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating notif request from account: %w", err)
+		}
+	}
+
+	if req.LastStatusID != "" && req.LastStatus == nil {
+		req.LastStatus, err = n.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			req.LastStatusID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			// Status may have since
+			// been deleted, that's ok.
+			errs.Appendf("error populating notif request last status: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (n *notificationDB) PutNotificationRequest(ctx context.Context, req *gtsmodel.NotificationRequest) error {
+	_, err := n.db.
+		NewInsert().
+		Model(req).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationDB) UpdateNotificationRequest(ctx context.Context, req *gtsmodel.NotificationRequest, columns ...string) error {
+	req.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := n.db.
+		NewUpdate().
+		Model(req).
+		Column(columns...).
+		Where("? = ?", bun.Ident("id"), req.ID).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationDB) DeleteNotificationRequestByID(ctx context.Context, id string) error {
+	if _, err := n.db.
+		NewDelete().
+		Table("notification_requests").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
+
+func (n *notificationDB) DeleteNotificationRequests(ctx context.Context, accountID string, fromAccountID string) error {
+	if accountID == "" && fromAccountID == "" {
+		return gtserror.New("one of accountID or fromAccountID must be set")
+	}
+
+	q := n.db.
+		NewDelete().
+		Table("notification_requests")
+
+	if accountID != "" {
+		q = q.Where("? = ?", bun.Ident("account_id"), accountID)
+	}
+
+	if fromAccountID != "" {
+		q = q.Where("? = ?", bun.Ident("from_account_id"), fromAccountID)
+	}
+
+	_, err := q.Exec(ctx)
+	return err
+}
@@ -29,6 +29,7 @@
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 func (suite *NotificationTestSuite) spamNotifs() {
@@ -263,6 +264,46 @@ func (suite *NotificationTestSuite) TestDeleteNotificationsPertainingToStatusID(
 	}
 }
 
+func (suite *NotificationTestSuite) TestGetAccountNotificationsGroup() {
+	var (
+		ctx           = context.Background()
+		testAccount   = suite.testAccounts["local_account_1"]
+		testStatus    = suite.testStatuses["local_account_1_status_1"]
+		testBoost     = suite.testStatuses["admin_account_status_4"]
+		testFave      = testrig.NewTestNotifications()["local_account_1_like"]
+		from          = testFave.CreatedAt.Truncate(12 * time.Hour)
+		to            = from.Add(12 * time.Hour)
+		boostNotifNow = &gtsmodel.Notification{
+			ID:               id.NewULID(),
+			NotificationType: gtsmodel.NotificationReblog,
+			TargetAccountID:  testAccount.ID,
+			OriginAccountID:  testBoost.AccountID,
+			StatusID:         testBoost.ID,
+		}
+	)
+
+	if err := suite.db.PutNotification(ctx, boostNotifNow); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Fave of the status in the time range.
+	notifs, err := suite.db.GetAccountNotificationsGroup(ctx, testAccount.ID, gtsmodel.NotificationFave, testStatus.ID, from, to)
+	suite.NoError(err)
+	suite.Len(notifs, 1)
+	suite.Equal(testFave.ID, notifs[0].ID)
+
+	// No faves of the status in another time range.
+	notifs, err = suite.db.GetAccountNotificationsGroup(ctx, testAccount.ID, gtsmodel.NotificationFave, testStatus.ID, to, to.Add(12*time.Hour))
+	suite.NoError(err)
+	suite.Empty(notifs)
+
+	// Reblogs are matched by the boosted status.
+	notifs, err = suite.db.GetAccountNotificationsGroup(ctx, testAccount.ID, gtsmodel.NotificationReblog, testStatus.ID, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	suite.NoError(err)
+	suite.Len(notifs, 1)
+	suite.Equal(boostNotifNow.ID, notifs[0].ID)
+}
+
 func TestNotificationTestSuite(t *testing.T) {
 	suite.Run(t, new(NotificationTestSuite))
 }
@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type rateLimitDB struct {
+	db *bun.DB
+}
+
+func (r *rateLimitDB) GetRateLimit(ctx context.Context, key string) (*gtsmodel.RateLimit, error) {
+	rateLimit := new(gtsmodel.RateLimit)
+
+	if err := r.db.
+		NewSelect().
+		Model(rateLimit).
+		Where("? = ?", bun.Ident("key"), key).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return rateLimit, nil
+}
+
+func (r *rateLimitDB) IncrementRateLimit(
+	ctx context.Context,
+	key string,
+	count int64,
+	period time.Duration,
+) (*gtsmodel.RateLimit, error) {
+	var (
+		now       = time.Now()
+		nowMs     = now.UnixMilli()
+		resetAt   = now.Add(period).UnixMilli()
+		rateLimit = &gtsmodel.RateLimit{Key: key}
+	)
+
+	// Insert a new rate limit, or add to the hits of
+	// the existing one; unless its period has ended,
+	// in which case start it again from this count.
+	//
+	// Both SQLite and Postgres evaluate the SET exprs
+	// against the row as it was before the update, so
+	// reset_at in each CASE refers to the old value.
+	if err := r.db.NewRaw(
+		"INSERT INTO ? (?, ?, ?) VALUES (?, ?, ?) "+
+			"ON CONFLICT (?) DO UPDATE SET "+
+			"? = CASE WHEN ?.? <= ? THEN EXCLUDED.? ELSE ?.? + EXCLUDED.? END, "+
+			"? = CASE WHEN ?.? <= ? THEN EXCLUDED.? ELSE ?.? END "+
+			"RETURNING ?, ?",
+		bun.Ident("rate_limits"),
+		bun.Ident("key"), bun.Ident("hits"), bun.Ident("reset_at"),
+		key, count, resetAt,
+		bun.Ident("key"),
+		bun.Ident("hits"), bun.Ident("rate_limits"), bun.Ident("reset_at"), nowMs,
+		bun.Ident("hits"), bun.Ident("rate_limits"), bun.Ident("hits"), bun.Ident("hits"),
+		bun.Ident("reset_at"), bun.Ident("rate_limits"), bun.Ident("reset_at"), nowMs,
+		bun.Ident("reset_at"), bun.Ident("rate_limits"), bun.Ident("reset_at"),
+		bun.Ident("hits"), bun.Ident("reset_at"),
+	).Scan(ctx, &rateLimit.Hits, &rateLimit.ResetAt); err != nil {
+		return nil, err
+	}
+
+	return rateLimit, nil
+}
+
+func (r *rateLimitDB) DeleteRateLimit(ctx context.Context, key string) error {
+	_, err := r.db.
+		NewDelete().
+		Table("rate_limits").
+		Where("? = ?", bun.Ident("key"), key).
+		Exec(ctx)
+	return err
+}
+
+func (r *rateLimitDB) DeleteExpiredRateLimits(ctx context.Context, before time.Time) (int, error) {
+	res, err := r.db.
+		NewDelete().
+		Table("rate_limits").
+		Where("? <= ?", bun.Ident("reset_at"), before.UnixMilli()).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rows), nil
+}
@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type RateLimitTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *RateLimitTestSuite) TestIncrementRateLimit() {
+	var (
+		ctx    = context.Background()
+		key    = "client:ip:192.0.2.1"
+		period = 5 * time.Minute
+	)
+
+	rateLimit, err := suite.db.IncrementRateLimit(ctx, key, 1, period)
+	suite.NoError(err)
+	suite.EqualValues(1, rateLimit.Hits)
+	suite.WithinDuration(time.Now().Add(period), time.UnixMilli(rateLimit.ResetAt), 5*time.Second)
+	resetAt := rateLimit.ResetAt
+
+	// Further hits are added to the
+	// same period without resetting.
+	rateLimit, err = suite.db.IncrementRateLimit(ctx, key, 2, period)
+	suite.NoError(err)
+	suite.EqualValues(3, rateLimit.Hits)
+	suite.Equal(resetAt, rateLimit.ResetAt)
+
+	// Other keys are counted separately.
+	rateLimit, err = suite.db.IncrementRateLimit(ctx, "client:ip:192.0.2.2", 1, period)
+	suite.NoError(err)
+	suite.EqualValues(1, rateLimit.Hits)
+
+	dbRateLimit, err := suite.db.GetRateLimit(ctx, key)
+	suite.NoError(err)
+	suite.EqualValues(3, dbRateLimit.Hits)
+}
+
+func (suite *RateLimitTestSuite) TestIncrementRateLimitExpired() {
+	var (
+		ctx = context.Background()
+		key = "client:ip:192.0.2.1"
+	)
+
+	// Start a period that's already over.
+	_, err := suite.db.IncrementRateLimit(ctx, key, 10, -time.Second)
+	suite.NoError(err)
+
+	// Next hit should start a new period.
+	rateLimit, err := suite.db.IncrementRateLimit(ctx, key, 1, 5*time.Minute)
+	suite.NoError(err)
+	suite.EqualValues(1, rateLimit.Hits)
+	suite.True(time.UnixMilli(rateLimit.ResetAt).After(time.Now()))
+}
+
+func (suite *RateLimitTestSuite) TestDeleteRateLimits() {
+	ctx := context.Background()
+
+	_, err := suite.db.IncrementRateLimit(ctx, "expired", 1, -time.Second)
+	suite.NoError(err)
+
+	_, err = suite.db.IncrementRateLimit(ctx, "current", 1, 5*time.Minute)
+	suite.NoError(err)
+
+	// Only the expired one should be deleted.
+	deleted, err := suite.db.DeleteExpiredRateLimits(ctx, time.Now())
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+
+	_, err = suite.db.GetRateLimit(ctx, "expired")
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	err = suite.db.DeleteRateLimit(ctx, "current")
+	suite.NoError(err)
+
+	_, err = suite.db.GetRateLimit(ctx, "current")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestRateLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
@@ -96,6 +96,19 @@ func (r *relationshipDB) GetRelationship(ctx context.Context, requestingAccount
 		return nil, gtserror.Newf("error checking blockedBy: %w", err)
 	}
 
+	// check if the requesting account is blocking the target account's domain
+	target, err := r.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		targetAccount,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error fetching target account: %w", err)
+	}
+	rel.DomainBlocking, err = r.IsAccountDomainBlocked(ctx, requestingAccount, target.Domain)
+	if err != nil {
+		return nil, gtserror.Newf("error checking domainBlocking: %w", err)
+	}
+
 	// retrieve a note by the requesting account on the target account, if there is one
 	note, err := r.GetNote(
 		gtscontext.SetBarebones(ctx),
@@ -288,6 +301,39 @@ func (r *relationshipDB) GetAccountBlockIDs(ctx context.Context, accountID strin
 	})
 }
 
+func (r *relationshipDB) GetFollowsOfFollowsAccountIDs(ctx context.Context, accountID string, limit int) ([]string, error) {
+	targetAccountIDs := make([]string, 0, limit)
+
+	// Subquery selecting IDs of
+	// accounts followed by accountID.
+	followedIDs := r.db.
+		NewSelect().
+		Table("follows").
+		Column("target_account_id").
+		Where("? = ?", bun.Ident("account_id"), accountID)
+
+	// Select accounts followed by accounts that accountID
+	// follows, excluding accountID itself and accounts it
+	// already follows, ordered by how many of accountID's
+	// follows follow them, then by newest follow.
+	if err := r.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		ColumnExpr("? AS ?", bun.Ident("follow.target_account_id"), bun.Ident("target_account_id")).
+		Where("? IN (?)", bun.Ident("follow.account_id"), followedIDs).
+		Where("? != ?", bun.Ident("follow.target_account_id"), accountID).
+		Where("? NOT IN (?)", bun.Ident("follow.target_account_id"), followedIDs).
+		GroupExpr("?", bun.Ident("follow.target_account_id")).
+		OrderExpr("COUNT(*) DESC").
+		OrderExpr("MAX(?) DESC", bun.Ident("follow.id")).
+		Limit(limit).
+		Scan(ctx, &targetAccountIDs); err != nil {
+		return nil, err
+	}
+
+	return targetAccountIDs, nil
+}
+
 // newSelectFollowRequests returns a new select query for all rows in the follow_requests table with target_account_id = accountID.
 func newSelectFollowRequests(db *bun.DB, accountID string) *bun.SelectQuery {
 	return db.NewSelect().
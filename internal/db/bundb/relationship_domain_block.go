@@ -0,0 +1,235 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+func (r *relationshipDB) IsAccountDomainBlocked(ctx context.Context, accountID string, domain string) (bool, error) {
+	if domain == "" {
+		// Local accounts
+		// can't be blocked.
+		return false, nil
+	}
+
+	// Normalize the domain as punycode.
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return false, err
+	}
+
+	// Load all domains blocked by account.
+	blocked, err := r.GetAccountBlockedDomains(ctx, accountID)
+	if err != nil {
+		return false, err
+	}
+
+	// Check for a block on either this
+	// exact domain, or a parent domain.
+	for _, b := range blocked {
+		if domain == b ||
+			strings.HasSuffix(domain, "."+b) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *relationshipDB) GetAccountDomainBlock(ctx context.Context, accountID string, domain string) (*gtsmodel.AccountDomainBlock, error) {
+	// Normalize the domain as punycode.
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var block gtsmodel.AccountDomainBlock
+	if err := r.db.
+		NewSelect().
+		Model(&block).
+		Where("? = ?", bun.Ident("account_domain_block.account_id"), accountID).
+		Where("? = ?", bun.Ident("account_domain_block.domain"), domain).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+func (r *relationshipDB) GetAccountDomainBlocks(
+	ctx context.Context,
+	accountID string,
+	page *paging.Page,
+) ([]*gtsmodel.AccountDomainBlock, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		blocks = make([]*gtsmodel.AccountDomainBlock, 0, limit)
+	)
+
+	q := r.db.
+		NewSelect().
+		Model(&blocks).
+		Where("? = ?", bun.Ident("account_domain_block.account_id"), accountID)
+
+	// Add paging param max ID.
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("account_domain_block.id"), maxID)
+	}
+
+	// Add paging param min ID.
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("account_domain_block.id"), minID)
+	}
+
+	// Add paging param order.
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("account_domain_block.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("account_domain_block.id"))
+	}
+
+	// Add paging param limit.
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no items early
+	if len(blocks) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want blocks
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(blocks)
+	}
+
+	return blocks, nil
+}
+
+func (r *relationshipDB) GetAccountBlockedDomains(ctx context.Context, accountID string) ([]string, error) {
+	return r.state.Caches.DB.AccountDomainBlocks.Load(accountID, func() ([]string, error) {
+		var domains []string
+
+		// Blocked domains not in cache, perform DB query!
+		if err := r.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("account_domain_blocks"), bun.Ident("account_domain_block")).
+			Column("account_domain_block.domain").
+			Where("? = ?", bun.Ident("account_domain_block.account_id"), accountID).
+			OrderExpr("? DESC", bun.Ident("account_domain_block.id")).
+			Scan(ctx, &domains); err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+
+		return domains, nil
+	})
+}
+
+func (r *relationshipDB) CountAccountDomainBlocks(ctx context.Context, accountID string) (int, error) {
+	domains, err := r.GetAccountBlockedDomains(ctx, accountID)
+	return len(domains), err
+}
+
+func (r *relationshipDB) PutAccountDomainBlock(ctx context.Context, block *gtsmodel.AccountDomainBlock) error {
+	// Normalize the domain as punycode.
+	var err error
+	block.Domain, err = util.Punify(block.Domain)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.db.
+		NewInsert().
+		Model(block).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	r.invalidateAccountDomainBlocks(block.AccountID)
+	return nil
+}
+
+func (r *relationshipDB) DeleteAccountDomainBlock(ctx context.Context, accountID string, domain string) error {
+	// Normalize the domain as punycode.
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("account_domain_blocks"), bun.Ident("account_domain_block")).
+		Where("? = ?", bun.Ident("account_domain_block.account_id"), accountID).
+		Where("? = ?", bun.Ident("account_domain_block.domain"), domain).
+		Exec(ctx); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	r.invalidateAccountDomainBlocks(accountID)
+	return nil
+}
+
+func (r *relationshipDB) DeleteAccountDomainBlocks(ctx context.Context, accountID string) error {
+	if _, err := r.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("account_domain_blocks"), bun.Ident("account_domain_block")).
+		Where("? = ?", bun.Ident("account_domain_block.account_id"), accountID).
+		Exec(ctx); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	r.invalidateAccountDomainBlocks(accountID)
+	return nil
+}
+
+// invalidateAccountDomainBlocks invalidates
+// cached blocked domains of the given account.
+func (r *relationshipDB) invalidateAccountDomainBlocks(accountID string) {
+	r.state.Caches.DB.AccountDomainBlocks.Invalidate(accountID)
+
+	// A domain block can change visibility of
+	// any account or status on the domain, in
+	// either direction, so clear all cached
+	// visibility results.
+	//
+	// todo: invalidate JUST the affected items.
+	r.state.Caches.Visibility.Clear()
+}
@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ScheduledStatusTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ScheduledStatusTestSuite) TestGetScheduledStatusByID() {
+	testScheduledStatus := testrig.NewTestScheduledStatuses()["local_account_1_scheduled_status_1"]
+
+	scheduledStatus, err := suite.db.GetScheduledStatusByID(context.Background(), testScheduledStatus.ID)
+	suite.NoError(err)
+	suite.Equal(testScheduledStatus.Text, scheduledStatus.Text)
+	suite.True(testScheduledStatus.ScheduledAt.Equal(scheduledStatus.ScheduledAt))
+
+	// Account and application should be populated.
+	suite.Equal(suite.testAccounts["local_account_1"].ID, scheduledStatus.Account.ID)
+	suite.Equal(suite.testApplications["application_1"].ID, scheduledStatus.Application.ID)
+}
+
+func (suite *ScheduledStatusTestSuite) TestPutCountDeleteScheduledStatuses() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	existing := testrig.NewTestScheduledStatuses()["local_account_1_scheduled_status_1"]
+
+	// Put another scheduled status on the same
+	// day as the existing one, and one the day after.
+	for _, scheduledAt := range []time.Time{
+		existing.ScheduledAt.Add(time.Hour),
+		existing.ScheduledAt.Add(24 * time.Hour),
+	} {
+		if err := suite.db.PutScheduledStatus(ctx, &gtsmodel.ScheduledStatus{
+			ID:          id.NewULID(),
+			AccountID:   account.ID,
+			ScheduledAt: scheduledAt,
+			Text:        "hello",
+			Sensitive:   util.Ptr(false),
+			Visibility:  gtsmodel.VisibilityPublic,
+			LocalOnly:   util.Ptr(false),
+			Poll: &gtsmodel.ScheduledStatusPoll{
+				Options:   []string{"yes", "no"},
+				ExpiresIn: 3600,
+			},
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	total, err := suite.db.CountScheduledStatusesForAcct(ctx, account.ID, time.Time{})
+	suite.NoError(err)
+	suite.Equal(3, total)
+
+	daily, err := suite.db.CountScheduledStatusesForAcct(ctx, account.ID, existing.ScheduledAt)
+	suite.NoError(err)
+	suite.Equal(2, daily)
+
+	scheduledStatuses, err := suite.db.GetScheduledStatusesForAcct(ctx, account.ID, nil)
+	suite.NoError(err)
+	suite.Len(scheduledStatuses, 3)
+	suite.Equal([]string{"yes", "no"}, scheduledStatuses[0].Poll.Options)
+
+	// Delete them all again.
+	err = suite.db.DeleteScheduledStatusesByAccountID(ctx, account.ID)
+	suite.NoError(err)
+
+	_, err = suite.db.GetScheduledStatusByID(ctx, existing.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+
+	total, err = suite.db.CountScheduledStatusesForAcct(ctx, account.ID, time.Time{})
+	suite.NoError(err)
+	suite.Zero(total)
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusTestSuite))
+}
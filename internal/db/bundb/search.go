@@ -19,8 +19,11 @@
 
 import (
 	"context"
+	"errors"
 	"strings"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -317,12 +320,18 @@ func (s *searchDB) SearchForStatuses(
 		frontToBack = false
 	}
 
-	// Select status text as subquery.
-	statusTextSubq := s.statusText()
+	if config.GetDbFullTextSearch() {
+		// Search for matches of query words
+		// within the full-text search index.
+		q = s.whereStatusMatches(ctx, q, requestingAccountID, query)
+	} else {
+		// Select status text as subquery.
+		statusTextSubq := s.statusText()
 
-	// Search using LIKE for matches of query
-	// string within statusText subquery.
-	q = whereLike(q, statusTextSubq, query)
+		// Search using LIKE for matches of query
+		// string within statusText subquery.
+		q = whereLike(q, statusTextSubq, query)
+	}
 
 	if limit > 0 {
 		// Limit amount of statuses returned.
@@ -370,6 +379,63 @@ func (s *searchDB) SearchForStatuses(
 	return statuses, nil
 }
 
+// whereStatusMatches limits the given statuses query to
+// those whose entry in the full-text search index matches
+// all of the words in the given search query.
+func (s *searchDB) whereStatusMatches(
+	ctx context.Context,
+	q *bun.SelectQuery,
+	requestingAccountID string,
+	query string,
+) *bun.SelectQuery {
+	switch d := s.db.Dialect().Name(); d {
+
+	case dialect.SQLite:
+		// Statuses are split between a stemmed table
+		// for English, and an unstemmed table for any
+		// other language, so look for matches in both.
+		return q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			for _, table := range sqliteStatusSearchTables {
+				subQ := s.db.
+					NewSelect().
+					Table(table).
+					Column("status_id").
+					Where("? MATCH ?", bun.Ident(table), fts5Query(query))
+				q = q.WhereOr("? IN (?)", bun.Ident("status.id"), subQ)
+			}
+			return q
+		})
+
+	case dialect.PG:
+		// Statuses are indexed with words both as written and
+		// as stemmed by status language. The query language is
+		// unknown, so take a best guess from the default post
+		// language of the searcher, and match either form.
+		var lang string
+		settings, err := s.state.DB.GetAccountSettings(ctx, requestingAccountID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "error getting account settings %s: %v", requestingAccountID, err)
+		} else if settings != nil {
+			lang = settings.Language
+		}
+
+		subQ := s.db.
+			NewSelect().
+			Table("status_search").
+			Column("status_id").
+			Where("? @@ (plainto_tsquery('simple', ?) || plainto_tsquery(?::regconfig, ?))",
+				bun.Ident("vector"),
+				query,
+				pgTextSearchConfig(lang), query)
+
+		return q.Where("? IN (?)", bun.Ident("status.id"), subQ)
+
+	default:
+		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return nil
+	}
+}
+
 // statusText returns a subquery that selects a concatenation
 // of status content and content warning as "status_text".
 func (s *searchDB) statusText() *bun.SelectQuery {
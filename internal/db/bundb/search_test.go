@@ -22,7 +22,9 @@
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type SearchTestSuite struct {
@@ -123,6 +125,98 @@ func (suite *SearchTestSuite) TestSearchStatusesFromAccount() {
 	}
 }
 
+func (suite *SearchTestSuite) TestSearchStatusesFullText() {
+	config.SetDbFullTextSearch(true)
+
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		status      = &gtsmodel.Status{}
+	)
+	*status = *suite.testStatuses["local_account_1_status_1"]
+
+	// Statuses are only indexed as they're
+	// put, so unindexed fixtures won't match.
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "hello", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// Delete the fixture and reinsert
+	// it with some new, indexed content.
+	if err := suite.db.DeleteStatusByID(ctx, status.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	status.Content = "<p>The quick brown foxes</p><p>jumped over the lazy dog</p>"
+	status.Language = "en"
+	if err := suite.db.PutStatus(ctx, status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Words should match regardless of order and
+	// adjacent paragraphs, and stemmed by language.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "FOX jumped BROWN", "", "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(status.ID, statuses[0].ID)
+	}
+
+	// Search syntax in query should be treated as text.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, `"fox OR* NEAR(`, "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// Whole words must match.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "qui", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// Update the status content, old
+	// content should no longer match.
+	status.Content = "<p>something else entirely</p>"
+	if err := suite.db.UpdateStatus(ctx, status, "content"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "fox", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "entirely", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	// Change the status language, it
+	// should still be found afterwards.
+	status.Content = "<p>Les chats sont là</p>"
+	status.Language = "fr"
+	if err := suite.db.UpdateStatus(ctx, status, "content", "language"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "chats", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	if config.GetDbType() == "sqlite" {
+		// SQLite can only stem English, so words in
+		// French statuses must match as written,
+		// rather than mangled by English stemming.
+		statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "chat", "", "", "", 10, 0)
+		suite.NoError(err)
+		suite.Empty(statuses)
+	}
+
+	// Deleted status should no longer match.
+	if err := suite.db.DeleteStatusByID(ctx, status.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "chats", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
 func (suite *SearchTestSuite) TestSearchTags() {
 	// Search with full tag string.
 	tags, err := suite.db.SearchForTags(context.Background(), "welcome", "", "", 10, 0)
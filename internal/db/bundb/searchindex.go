@@ -0,0 +1,227 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+// The full-text search index of statuses is stored in the
+// "status_search" table, created by the status_search migration.
+//
+// On SQLite this is an FTS5 virtual table of (status_id, text),
+// using the porter tokenizer. SQLite's porter stemmer only knows
+// English, so statuses in other (or unknown) languages are kept
+// in a second, unstemmed table "status_search_unstemmed" of the
+// same shape instead, and searches query both tables.
+//
+// On Postgres it's a regular table of (status_id, vector),
+// with a GIN index on the tsvector column.
+//
+// The index is maintained alongside statuses regardless of whether
+// db-full-text-search is enabled, so the setting can be toggled
+// without needing to rebuild anything.
+
+// pgTextSearchConfigs maps ISO 639-1 language codes to
+// the text search configurations built in to Postgres,
+// which are used to stem status text by its language.
+var pgTextSearchConfigs = map[string]string{
+	"ar": "arabic",
+	"da": "danish",
+	"de": "german",
+	"en": "english",
+	"es": "spanish",
+	"fi": "finnish",
+	"fr": "french",
+	"ga": "irish",
+	"hu": "hungarian",
+	"id": "indonesian",
+	"it": "italian",
+	"lt": "lithuanian",
+	"nb": "norwegian",
+	"ne": "nepali",
+	"nl": "dutch",
+	"nn": "norwegian",
+	"no": "norwegian",
+	"pt": "portuguese",
+	"ro": "romanian",
+	"ru": "russian",
+	"sv": "swedish",
+	"ta": "tamil",
+	"tr": "turkish",
+}
+
+// pgTextSearchConfig returns the Postgres text search
+// configuration to use for the given language tag, falling
+// back to "simple" (ie., no stemming) for unknown languages.
+func pgTextSearchConfig(lang string) string {
+	lang, _, _ = strings.Cut(strings.ToLower(lang), "-")
+	if config, ok := pgTextSearchConfigs[lang]; ok {
+		return config
+	}
+	return "simple"
+}
+
+// sqliteStatusSearchTables are the FTS5 tables
+// that make up the status index on SQLite.
+var sqliteStatusSearchTables = []string{
+	"status_search",
+	"status_search_unstemmed",
+}
+
+// sqliteStatusSearchTable returns the FTS5 table to index
+// a status with the given language tag in on SQLite. Only
+// English statuses go in the stemmed table, as English is
+// the only language the porter tokenizer can stem.
+func sqliteStatusSearchTable(lang string) string {
+	lang, _, _ = strings.Cut(strings.ToLower(lang), "-")
+	if lang == "en" {
+		return "status_search"
+	}
+	return "status_search_unstemmed"
+}
+
+// statusSearchText returns the text of the given status to
+// index for full-text search, ie., its content warning and
+// content, with any html removed.
+func statusSearchText(status *gtsmodel.Status) string {
+	// Pad tags with a space before removing them,
+	// so that text in adjacent elements, eg.,
+	// "<p>hello</p><p>world</p>", isn't joined up.
+	content := strings.ReplaceAll(status.Content, "<", " <")
+	content = text.SanitizeToPlaintext(content)
+	return strings.TrimSpace(status.ContentWarning + " " + content)
+}
+
+// fts5Query converts an arbitrary search query into an FTS5
+// query expression, which matches statuses containing all of
+// the query's words (in any order) in the indexed text column.
+//
+// Each word is quoted, so that query syntax characters
+// supplied by the user are treated as plain text.
+func fts5Query(query string) string {
+	words := strings.Fields(query)
+	for i, word := range words {
+		words[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"`
+	}
+	return "text : (" + strings.Join(words, " ") + ")"
+}
+
+// putStatusSearch indexes (or reindexes) the
+// given status in the full-text search index.
+func putStatusSearch(ctx context.Context, tx bun.Tx, status *gtsmodel.Status) error {
+	if status.BoostOfID != "" {
+		// Boosts have no text of their own.
+		return nil
+	}
+
+	searchText := statusSearchText(status)
+
+	switch d := tx.Dialect().Name(); d {
+
+	case dialect.SQLite:
+		// FTS5 tables don't support upserts, so drop
+		// any existing entry first, from either table,
+		// in case the status language has changed.
+		if err := deleteStatusSearch(ctx, tx, status.ID); err != nil {
+			return err
+		}
+
+		if searchText == "" {
+			// Nothing to index.
+			return nil
+		}
+
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO ? (?, ?) VALUES (?, ?)",
+			bun.Ident(sqliteStatusSearchTable(status.Language)),
+			bun.Ident("status_id"),
+			bun.Ident("text"),
+			status.ID,
+			searchText,
+		)
+		return err
+
+	case dialect.PG:
+		// Index both the stemmed form of each word,
+		// according to status language, and the word
+		// as written, so that statuses can be found
+		// by searchers writing in other languages.
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO ? (?, ?) VALUES (?, to_tsvector(?::regconfig, ?) || to_tsvector('simple', ?)) "+
+				"ON CONFLICT (?) DO UPDATE SET ? = EXCLUDED.?",
+			bun.Ident("status_search"),
+			bun.Ident("status_id"),
+			bun.Ident("vector"),
+			status.ID,
+			pgTextSearchConfig(status.Language), searchText, searchText,
+			bun.Ident("status_id"),
+			bun.Ident("vector"), bun.Ident("vector"),
+		)
+		return err
+
+	default:
+		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return nil
+	}
+}
+
+// deleteStatusSearch removes the status with
+// given ID from the full-text search index.
+func deleteStatusSearch(ctx context.Context, tx bun.Tx, statusID string) error {
+	switch d := tx.Dialect().Name(); d {
+
+	case dialect.SQLite:
+		// Look up the entry by MATCHing on the status_id
+		// column, which is tokenized like any other FTS5
+		// column, so that this doesn't scan the whole table.
+		for _, table := range sqliteStatusSearchTables {
+			if _, err := tx.ExecContext(ctx,
+				"DELETE FROM ? WHERE ? MATCH ? AND ? = ?",
+				bun.Ident(table),
+				bun.Ident(table),
+				`status_id : "`+statusID+`"`,
+				bun.Ident("status_id"),
+				statusID,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case dialect.PG:
+		_, err := tx.ExecContext(ctx,
+			"DELETE FROM ? WHERE ? = ?",
+			bun.Ident("status_search"),
+			bun.Ident("status_id"),
+			statusID,
+		)
+		return err
+
+	default:
+		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return nil
+	}
+}
@@ -181,7 +181,7 @@ func (s *statusDB) getStatus(ctx context.Context, lookup string, dbQuery func(*g
 func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status) error {
 	var (
 		err  error
-		errs = gtserror.NewMultiError(9)
+		errs = gtserror.NewMultiError(11)
 	)
 
 	if status.Account == nil {
@@ -243,6 +243,28 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.QuoteID != "" && status.Quote == nil {
+		// Quoted status is not set, fetch from database.
+		status.Quote, err = s.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			status.QuoteID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating quoted status: %w", err)
+		}
+	}
+
+	if status.CardID != "" && status.Card == nil {
+		// Status preview card is not set, fetch from database.
+		status.Card, err = s.state.DB.GetCardByID(
+			ctx, // populate card image
+			status.CardID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating status card: %w", err)
+		}
+	}
+
 	if status.PollID != "" && status.Poll == nil {
 		// Status poll is not set, fetch from database.
 		status.Poll, err = s.state.DB.GetPollByID(
@@ -298,6 +320,17 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if !status.EditsPopulated() {
+		// Status edits are out-of-date with IDs, repopulate.
+		status.Edits, err = s.state.DB.GetStatusEditsByIDs(
+			ctx, // leave fully populated for now
+			status.EditIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating status edits: %w", err)
+		}
+	}
+
 	if status.CreatedWithApplicationID != "" && status.CreatedWithApplication == nil {
 		// Populate the status' expected CreatedWithApplication (not always set).
 		status.CreatedWithApplication, err = s.state.DB.GetApplicationByID(
@@ -383,6 +416,11 @@ func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) error
 				}
 			}
 
+			// Index status text for full-text search.
+			if err := putStatusSearch(ctx, tx, status); err != nil {
+				return err
+			}
+
 			// Finally, insert the status
 			_, err := tx.NewInsert().Model(status).Exec(ctx)
 			return err
@@ -402,6 +440,34 @@ func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, co
 		// as the cache does not attempt a mutex lock until AFTER hook.
 		//
 		return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			if len(columns) == 0 || slices.Contains(columns, "emojis") {
+				// delete links between this status and any emojis it no longer uses
+				q := tx.
+					NewDelete().
+					TableExpr("? AS ?", bun.Ident("status_to_emojis"), bun.Ident("status_to_emoji")).
+					Where("? = ?", bun.Ident("status_to_emoji.status_id"), status.ID)
+				if len(status.EmojiIDs) > 0 {
+					q = q.Where("? NOT IN (?)", bun.Ident("status_to_emoji.emoji_id"), bun.In(status.EmojiIDs))
+				}
+				if _, err := q.Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			if len(columns) == 0 || slices.Contains(columns, "tags") {
+				// delete links between this status and any tags it no longer uses
+				q := tx.
+					NewDelete().
+					TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+					Where("? = ?", bun.Ident("status_to_tag.status_id"), status.ID)
+				if len(status.TagIDs) > 0 {
+					q = q.Where("? NOT IN (?)", bun.Ident("status_to_tag.tag_id"), bun.In(status.TagIDs))
+				}
+				if _, err := q.Exec(ctx); err != nil {
+					return err
+				}
+			}
+
 			// create links between this status and any emojis it uses
 			for _, i := range status.EmojiIDs {
 				if _, err := tx.
@@ -466,6 +532,16 @@ func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, co
 				}
 			}
 
+			if len(columns) == 0 ||
+				slices.Contains(columns, "content") ||
+				slices.Contains(columns, "content_warning") ||
+				slices.Contains(columns, "language") {
+				// Reindex updated status text for full-text search.
+				if err := putStatusSearch(ctx, tx, status); err != nil {
+					return err
+				}
+			}
+
 			// Finally, update the status
 			_, err := tx.
 				NewUpdate().
@@ -514,6 +590,11 @@ func (s *statusDB) DeleteStatusByID(ctx context.Context, id string) error {
 			return err
 		}
 
+		// Delete status from the full-text search index.
+		if err := deleteStatusSearch(ctx, tx, id); err != nil {
+			return err
+		}
+
 		// delete the status itself
 		if _, err := tx.
 			NewDelete().
@@ -0,0 +1,156 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type suggestionDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *suggestionDB) GetStaffPickByID(ctx context.Context, id string) (*gtsmodel.StaffPick, error) {
+	return s.getStaffPick(ctx, "id", id)
+}
+
+func (s *suggestionDB) GetStaffPickByAccountID(ctx context.Context, accountID string) (*gtsmodel.StaffPick, error) {
+	return s.getStaffPick(ctx, "account_id", accountID)
+}
+
+func (s *suggestionDB) getStaffPick(ctx context.Context, column string, value string) (*gtsmodel.StaffPick, error) {
+	staffPick := new(gtsmodel.StaffPick)
+
+	if err := s.db.
+		NewSelect().
+		Model(staffPick).
+		Where("? = ?", bun.Ident(column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.populateStaffPick(ctx, staffPick); err != nil {
+		return nil, err
+	}
+
+	return staffPick, nil
+}
+
+func (s *suggestionDB) GetStaffPicks(ctx context.Context) ([]*gtsmodel.StaffPick, error) {
+	staffPicks := []*gtsmodel.StaffPick{}
+
+	if err := s.db.
+		NewSelect().
+		Model(&staffPicks).
+		Order("id DESC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, staffPick := range staffPicks {
+		if err := s.populateStaffPick(ctx, staffPick); err != nil {
+			return nil, err
+		}
+	}
+
+	return staffPicks, nil
+}
+
+func (s *suggestionDB) populateStaffPick(ctx context.Context, staffPick *gtsmodel.StaffPick) error {
+	if staffPick.Account != nil {
+		// Already populated.
+		return nil
+	}
+
+	var err error
+	staffPick.Account, err = s.state.DB.GetAccountByID(ctx, staffPick.AccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error populating staff pick account: %w", err)
+	}
+
+	return nil
+}
+
+func (s *suggestionDB) PutStaffPick(ctx context.Context, staffPick *gtsmodel.StaffPick) error {
+	_, err := s.db.
+		NewInsert().
+		Model(staffPick).
+		Exec(ctx)
+	return err
+}
+
+func (s *suggestionDB) DeleteStaffPickByID(ctx context.Context, id string) error {
+	_, err := s.db.
+		NewDelete().
+		Table("staff_picks").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (s *suggestionDB) GetSuggestionDismissalTargetIDs(ctx context.Context, accountID string) ([]string, error) {
+	var targetAccountIDs []string
+
+	if err := s.db.
+		NewSelect().
+		Table("suggestion_dismissals").
+		Column("target_account_id").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Scan(ctx, &targetAccountIDs); err != nil {
+		return nil, err
+	}
+
+	return targetAccountIDs, nil
+}
+
+func (s *suggestionDB) PutSuggestionDismissal(ctx context.Context, dismissal *gtsmodel.SuggestionDismissal) error {
+	_, err := s.db.
+		NewInsert().
+		Model(dismissal).
+		On("CONFLICT (?, ?) DO NOTHING", bun.Ident("account_id"), bun.Ident("target_account_id")).
+		Exec(ctx)
+	return err
+}
+
+func (s *suggestionDB) DeleteSuggestionDataByAccountID(ctx context.Context, accountID string) error {
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.
+			NewDelete().
+			Table("staff_picks").
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.
+			NewDelete().
+			Table("suggestion_dismissals").
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			WhereOr("? = ?", bun.Ident("target_account_id"), accountID).
+			Exec(ctx)
+		return err
+	})
+}
@@ -0,0 +1,176 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type trendDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (t *trendDB) GetTrendReviewByID(ctx context.Context, id string) (*gtsmodel.TrendReview, error) {
+	review := new(gtsmodel.TrendReview)
+
+	if err := t.db.
+		NewSelect().
+		Model(review).
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (t *trendDB) GetTrendReview(ctx context.Context, trendType gtsmodel.TrendType, target string) (*gtsmodel.TrendReview, error) {
+	review := new(gtsmodel.TrendReview)
+
+	if err := t.db.
+		NewSelect().
+		Model(review).
+		Where("? = ?", bun.Ident("trend_type"), trendType).
+		Where("? = ?", bun.Ident("target"), target).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (t *trendDB) PutTrendReview(ctx context.Context, review *gtsmodel.TrendReview) error {
+	_, err := t.db.
+		NewInsert().
+		Model(review).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) UpdateTrendReview(ctx context.Context, review *gtsmodel.TrendReview, columns ...string) error {
+	review.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := t.db.
+		NewUpdate().
+		Model(review).
+		Column(columns...).
+		Where("? = ?", bun.Ident("id"), review.ID).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) GetTagTrendActivity(ctx context.Context, sinceID string) ([]*gtsmodel.TrendActivity, error) {
+	activities := []*gtsmodel.TrendActivity{}
+
+	q := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		ColumnExpr("? AS ?", bun.Ident("status_to_tag.tag_id"), bun.Ident("target")).
+		Join("JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+		).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("? > ?", bun.Ident("status.id"), sinceID)
+
+	if err := t.withActivityAccount(q, "status").
+		Scan(ctx, &activities); err != nil {
+		return nil, err
+	}
+
+	return activities, nil
+}
+
+func (t *trendDB) GetStatusTrendActivity(ctx context.Context, sinceID string) ([]*gtsmodel.TrendActivity, error) {
+	faves := []*gtsmodel.TrendActivity{}
+
+	q := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		ColumnExpr("? AS ?", bun.Ident("status_fave.status_id"), bun.Ident("target")).
+		Where("? > ?", bun.Ident("status_fave.id"), sinceID)
+
+	if err := t.withActivityAccount(q, "status_fave").
+		Scan(ctx, &faves); err != nil {
+		return nil, err
+	}
+
+	boosts := []*gtsmodel.TrendActivity{}
+
+	q = t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("boost")).
+		ColumnExpr("? AS ?", bun.Ident("boost.boost_of_id"), bun.Ident("target")).
+		Where("? IS NOT NULL", bun.Ident("boost.boost_of_id")).
+		Where("? > ?", bun.Ident("boost.id"), sinceID)
+
+	if err := t.withActivityAccount(q, "boost").
+		Scan(ctx, &boosts); err != nil {
+		return nil, err
+	}
+
+	return append(faves, boosts...), nil
+}
+
+func (t *trendDB) GetLinkTrendActivity(ctx context.Context, sinceID string) ([]*gtsmodel.TrendActivity, error) {
+	activities := []*gtsmodel.TrendActivity{}
+
+	q := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("? AS ?", bun.Ident("status.id"), bun.Ident("target")).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		Where("? LIKE ?", bun.Ident("status.content"), "%href=%").
+		Where("? > ?", bun.Ident("status.id"), sinceID)
+
+	if err := t.withActivityAccount(q, "status").
+		Scan(ctx, &activities); err != nil {
+		return nil, err
+	}
+
+	return activities, nil
+}
+
+// withActivityAccount selects the remaining columns of a
+// trend activity from the given table, and joins it with
+// the responsible account, leaving out activity by silenced
+// or suspended accounts as they shouldn't influence trends.
+func (t *trendDB) withActivityAccount(q *bun.SelectQuery, table string) *bun.SelectQuery {
+	return q.
+		ColumnExpr("? AS ?", bun.Ident(table+".account_id"), bun.Ident("account_id")).
+		ColumnExpr("? AS ?", bun.Ident("account.domain"), bun.Ident("account_domain")).
+		ColumnExpr("? AS ?", bun.Ident(table+".created_at"), bun.Ident("created_at")).
+		Join("JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("account.id"), bun.Ident(table+".account_id"),
+		).
+		Where("? IS NULL", bun.Ident("account.silenced_at")).
+		Where("? IS NULL", bun.Ident("account.suspended_at"))
+}
@@ -0,0 +1,276 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type webPushDB struct {
+	db    *bun.DB
+	state *state.State
+
+	// vapidKeyPair caches the
+	// instance VAPID key pair,
+	// which never changes once
+	// it has been generated.
+	vapidKeyPair atomic.Pointer[gtsmodel.VAPIDKeyPair]
+}
+
+func (w *webPushDB) GetVAPIDKeyPair(ctx context.Context) (*gtsmodel.VAPIDKeyPair, error) {
+	if keyPair := w.vapidKeyPair.Load(); keyPair != nil {
+		// Already cached.
+		return keyPair, nil
+	}
+
+	// Not cached! Look for stored key pair.
+	keyPair, err := w.getVAPIDKeyPair(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	if keyPair == nil {
+		// None stored yet, generate a new one.
+		newKeyPair, err := generateVAPIDKeyPair()
+		if err != nil {
+			return nil, err
+		}
+
+		// Insert the new key pair, ignoring any conflict
+		// in case another caller got there before us.
+		if _, err := w.db.NewInsert().
+			Model(newKeyPair).
+			On("CONFLICT (?) DO NOTHING", bun.Ident("id")).
+			Exec(ctx); err != nil {
+			return nil, err
+		}
+
+		// Reload the stored key pair,
+		// which might not be ours.
+		keyPair, err = w.getVAPIDKeyPair(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w.vapidKeyPair.Store(keyPair)
+	return keyPair, nil
+}
+
+func (w *webPushDB) getVAPIDKeyPair(ctx context.Context) (*gtsmodel.VAPIDKeyPair, error) {
+	var keyPair gtsmodel.VAPIDKeyPair
+	if err := w.db.NewSelect().
+		Model(&keyPair).
+		Where("? = ?", bun.Ident("id"), 1).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return &keyPair, nil
+}
+
+// generateVAPIDKeyPair generates a new P-256 key
+// pair encoded as expected by push services.
+func generateVAPIDKeyPair() (*gtsmodel.VAPIDKeyPair, error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gtsmodel.VAPIDKeyPair{
+		ID:         1,
+		PublicKey:  base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		PrivateKey: base64.RawURLEncoding.EncodeToString(key.Bytes()),
+	}, nil
+}
+
+func (w *webPushDB) GetWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) (*gtsmodel.WebPushSubscription, error) {
+	return w.state.Caches.DB.WebPushSubscription.LoadOne("TokenID",
+		func() (*gtsmodel.WebPushSubscription, error) {
+			var subscription gtsmodel.WebPushSubscription
+
+			// Not cached! Perform database query.
+			if err := w.db.NewSelect().
+				Model(&subscription).
+				Where("? = ?", bun.Ident("token_id"), tokenID).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return &subscription, nil
+		},
+		tokenID,
+	)
+}
+
+func (w *webPushDB) GetWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.WebPushSubscription, error) {
+	// Fetch IDs of all subscriptions owned by this account.
+	subscriptionIDs, err := w.state.Caches.DB.WebPushSubscriptionIDs.Load(accountID, func() ([]string, error) {
+		var subscriptionIDs []string
+
+		// Subscription IDs not in cache, perform DB query!
+		if err := w.db.NewSelect().
+			Table("web_push_subscriptions").
+			Column("id").
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			Order("id DESC").
+			Scan(ctx, &subscriptionIDs); err != nil {
+			return nil, err
+		}
+
+		return subscriptionIDs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subscriptionIDs) == 0 {
+		return nil, nil
+	}
+
+	// Load all subscription IDs via cache loader callback.
+	subscriptions, err := w.state.Caches.DB.WebPushSubscription.LoadIDs("ID",
+		subscriptionIDs,
+		func(uncached []string) ([]*gtsmodel.WebPushSubscription, error) {
+			// Preallocate expected length of uncached subscriptions.
+			subscriptions := make([]*gtsmodel.WebPushSubscription, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) subscription IDs.
+			if err := w.db.NewSelect().
+				Model(&subscriptions).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return subscriptions, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the subscriptions by their
+	// IDs to ensure in correct order.
+	getID := func(s *gtsmodel.WebPushSubscription) string { return s.ID }
+	util.OrderBy(subscriptions, subscriptionIDs, getID)
+
+	return subscriptions, nil
+}
+
+func (w *webPushDB) PutWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription) error {
+	return w.state.Caches.DB.WebPushSubscription.Store(subscription, func() error {
+		_, err := w.db.NewInsert().Model(subscription).Exec(ctx)
+		return err
+	})
+}
+
+func (w *webPushDB) UpdateWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription, columns ...string) error {
+	subscription.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return w.state.Caches.DB.WebPushSubscription.Store(subscription, func() error {
+		_, err := w.db.NewUpdate().
+			Model(subscription).
+			Where("? = ?", bun.Ident("web_push_subscription.id"), subscription.ID).
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (w *webPushDB) DeleteWebPushSubscriptionByID(ctx context.Context, id string) error {
+	// Gather necessary fields from
+	// deleted for cache invaliation.
+	var deleted gtsmodel.WebPushSubscription
+
+	// Delete subscription with given ID,
+	// returning the deleted model.
+	if _, err := w.db.NewDelete().
+		Model(&deleted).
+		Where("? = ?", bun.Ident("id"), id).
+		Returning("?", bun.Ident("account_id")).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Invalidate cached subscription with ID,
+	// manually call invalidate hook in case not cached.
+	w.state.Caches.DB.WebPushSubscription.Invalidate("ID", id)
+	w.state.Caches.OnInvalidateWebPushSubscription(&deleted)
+
+	return nil
+}
+
+func (w *webPushDB) DeleteWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) error {
+	// Gather necessary fields from
+	// deleted for cache invaliation.
+	var deleted gtsmodel.WebPushSubscription
+
+	// Delete subscription with given token
+	// ID, returning the deleted model.
+	if _, err := w.db.NewDelete().
+		Model(&deleted).
+		Where("? = ?", bun.Ident("token_id"), tokenID).
+		Returning("?", bun.Ident("account_id")).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Invalidate cached subscription with token
+	// ID, manually call invalidate hook in case
+	// not cached.
+	w.state.Caches.DB.WebPushSubscription.Invalidate("TokenID", tokenID)
+	w.state.Caches.OnInvalidateWebPushSubscription(&deleted)
+
+	return nil
+}
+
+func (w *webPushDB) DeleteWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) error {
+	// Delete all subscriptions owned by account.
+	if _, err := w.db.NewDelete().
+		Table("web_push_subscriptions").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Invalidate all cached subscriptions of account.
+	w.state.Caches.DB.WebPushSubscription.Invalidate("AccountID", accountID)
+	w.state.Caches.DB.WebPushSubscriptionIDs.Invalidate(accountID)
+
+	return nil
+}
@@ -0,0 +1,74 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type WebPushTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *WebPushTestSuite) TestGetVAPIDKeyPair() {
+	keyPair, err := suite.db.GetVAPIDKeyPair(context.Background())
+	suite.NoError(err)
+	suite.Equal(testrig.NewTestVAPIDKeyPair(), keyPair)
+}
+
+func (suite *WebPushTestSuite) TestGetWebPushSubscriptionsByAccountID() {
+	subscriptions, err := suite.db.GetWebPushSubscriptionsByAccountID(
+		context.Background(),
+		suite.testAccounts["local_account_1"].ID,
+	)
+	suite.NoError(err)
+	suite.Len(subscriptions, 1)
+	suite.Equal(testrig.NewTestWebPushSubscriptions()["local_account_1_token_1"].ID, subscriptions[0].ID)
+}
+
+func (suite *WebPushTestSuite) TestDeleteTokenDeletesWebPushSubscription() {
+	ctx := context.Background()
+	token := suite.testTokens["local_account_1"]
+
+	// Load the subscription into the cache first.
+	_, err := suite.db.GetWebPushSubscriptionByTokenID(ctx, token.ID)
+	suite.NoError(err)
+
+	// Revoke the token.
+	err = suite.db.DeleteTokenByID(ctx, token.ID)
+	suite.NoError(err)
+
+	// Subscription should be gone from
+	// the cache and the database.
+	_, err = suite.db.GetWebPushSubscriptionByTokenID(ctx, token.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+
+	subscriptions, err := suite.db.GetWebPushSubscriptionsByAccountID(ctx, suite.testAccounts["local_account_1"].ID)
+	suite.NoError(err)
+	suite.Empty(subscriptions)
+}
+
+func TestWebPushTestSuite(t *testing.T) {
+	suite.Run(t, new(WebPushTestSuite))
+}
@@ -25,16 +25,21 @@
 // DB provides methods for interacting with an underlying database or other storage mechanism.
 type DB interface {
 	Account
+	AccountArchive
 	Admin
 	AdvancedMigration
+	Announcement
 	Application
 	Basic
+	Card
 	Conversation
 	Domain
 	Emoji
 	HeaderFilter
+	Import
 	Instance
 	Interaction
+	Invite
 	Filter
 	List
 	Marker
@@ -43,19 +48,26 @@ type DB interface {
 	Move
 	Notification
 	Poll
+	RateLimit
 	Relationship
+	Relay
 	Report
 	Rule
+	ScheduledStatus
 	Search
 	Session
 	SinBinStatus
 	Status
 	StatusBookmark
+	StatusEdit
 	StatusFave
+	Suggestion
 	Tag
 	Thread
 	Timeline
+	Trend
 	User
 	Tombstone
+	WebPush
 	WorkerTask
 }
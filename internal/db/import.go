@@ -0,0 +1,48 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type Import interface {
+	// GetImportByID gets one import with the given id.
+	GetImportByID(ctx context.Context, id string) (*gtsmodel.Import, error)
+
+	// GetImportsByAccountID returns imports uploaded
+	// by the given account, with optional paging.
+	GetImportsByAccountID(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Import, error)
+
+	// GetImportsByState returns all imports
+	// in the given state of processing.
+	GetImportsByState(ctx context.Context, state gtsmodel.ImportState) ([]*gtsmodel.Import, error)
+
+	// PutImport puts the given import in the database.
+	PutImport(ctx context.Context, imp *gtsmodel.Import) error
+
+	// UpdateImport updates the given import in the database.
+	UpdateImport(ctx context.Context, imp *gtsmodel.Import, columns ...string) error
+
+	// DeleteImportsByAccountID deletes all
+	// imports uploaded by the given account.
+	DeleteImportsByAccountID(ctx context.Context, accountID string) error
+}
@@ -19,8 +19,10 @@
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // Notification contains functions for creating and getting notifications.
@@ -28,9 +30,18 @@ type Notification interface {
 	// GetAccountNotifications returns a slice of notifications that pertain to the given accountID.
 	//
 	// Returned notifications will be ordered ID descending (ie., highest/newest to lowest/oldest).
-	// If types is empty, *all* notification types will be included.
+	// If types is empty, *all* notification types will be included. Filtered notifications are
+	// never included.
 	GetAccountNotifications(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, types []string, excludeTypes []string) ([]*gtsmodel.Notification, error)
 
+	// GetAccountNotificationsGroup returns a slice of notifications of the given type that pertain
+	// to the given accountID, and were created in the time range [from, to). If statusID is set,
+	// only notifications about that status (or for reblogs, about boosts of that status) will be
+	// included. This is used to get all notifications making up one notification group.
+	//
+	// Returned notifications will be ordered ID descending (ie., highest/newest to lowest/oldest).
+	GetAccountNotificationsGroup(ctx context.Context, accountID string, notifType gtsmodel.NotificationType, statusID string, from time.Time, to time.Time) ([]*gtsmodel.Notification, error)
+
 	// GetNotificationByID returns one notification according to its id.
 	GetNotificationByID(ctx context.Context, id string) (*gtsmodel.Notification, error)
 
@@ -70,4 +81,60 @@ type Notification interface {
 	// the given statusID. This function is useful when a status has been deleted,
 	// and so notifications relating to that status must also be deleted.
 	DeleteNotificationsForStatus(ctx context.Context, statusID string) error
+
+	// UnfilterNotifications marks all filtered notifications targeting
+	// targetAccountID and originating from originAccountID as unfiltered.
+	UnfilterNotifications(ctx context.Context, targetAccountID string, originAccountID string) error
+
+	// DeleteFilteredNotifications deletes all filtered notifications
+	// targeting targetAccountID and originating from originAccountID.
+	DeleteFilteredNotifications(ctx context.Context, targetAccountID string, originAccountID string) error
+
+	// GetNotificationPolicy returns the notification policy of the given account,
+	// or db.ErrNoEntries if the account hasn't set one.
+	GetNotificationPolicy(ctx context.Context, accountID string) (*gtsmodel.NotificationPolicy, error)
+
+	// PutNotificationPolicy inserts the given notification policy into the database.
+	PutNotificationPolicy(ctx context.Context, policy *gtsmodel.NotificationPolicy) error
+
+	// UpdateNotificationPolicy updates the given notification policy in the database.
+	UpdateNotificationPolicy(ctx context.Context, policy *gtsmodel.NotificationPolicy, columns ...string) error
+
+	// DeleteNotificationPolicy deletes the notification policy of the given account, if any.
+	DeleteNotificationPolicy(ctx context.Context, accountID string) error
+
+	// GetNotificationRequestByID returns one notification request according to its id.
+	GetNotificationRequestByID(ctx context.Context, id string) (*gtsmodel.NotificationRequest, error)
+
+	// GetNotificationRequest returns the notification request
+	// for notifications targeting accountID from fromAccountID.
+	GetNotificationRequest(ctx context.Context, accountID string, fromAccountID string) (*gtsmodel.NotificationRequest, error)
+
+	// GetAccountNotificationRequests returns the pending (ie., not accepted)
+	// notification requests for the given account, with optional paging.
+	//
+	// Returned requests will be ordered ID descending (ie., highest/newest to lowest/oldest).
+	GetAccountNotificationRequests(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.NotificationRequest, error)
+
+	// CountAccountNotificationRequests returns the number of pending notification
+	// requests for the given account, and the number of notifications in them.
+	CountAccountNotificationRequests(ctx context.Context, accountID string) (requests int, notifications int, err error)
+
+	// PopulateNotificationRequest ensures that the notification request's struct fields are populated.
+	PopulateNotificationRequest(ctx context.Context, req *gtsmodel.NotificationRequest) error
+
+	// PutNotificationRequest inserts the given notification request into the database.
+	PutNotificationRequest(ctx context.Context, req *gtsmodel.NotificationRequest) error
+
+	// UpdateNotificationRequest updates the given notification request in the database.
+	UpdateNotificationRequest(ctx context.Context, req *gtsmodel.NotificationRequest, columns ...string) error
+
+	// DeleteNotificationRequestByID deletes one notification request according to its id.
+	DeleteNotificationRequestByID(ctx context.Context, id string) error
+
+	// DeleteNotificationRequests mass deletes notification requests targeting
+	// accountID and/or from fromAccountID, in the same way as DeleteNotifications.
+	//
+	// At least one parameter must not be an empty string.
+	DeleteNotificationRequests(ctx context.Context, accountID string, fromAccountID string) error
 }
@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type RateLimit interface {
+	// GetRateLimit gets the rate limit with the given key.
+	GetRateLimit(ctx context.Context, key string) (*gtsmodel.RateLimit, error)
+
+	// IncrementRateLimit adds count hits to the rate limit with the given
+	// key, creating it if it doesn't exist yet. If the current period of the
+	// rate limit has ended, it will be reset first, with a new period of the
+	// given length. This is done atomically, so that processes sharing the
+	// database all see the same count. The updated rate limit is returned.
+	IncrementRateLimit(ctx context.Context, key string, count int64, period time.Duration) (*gtsmodel.RateLimit, error)
+
+	// DeleteRateLimit deletes the rate limit with the given key.
+	DeleteRateLimit(ctx context.Context, key string) error
+
+	// DeleteExpiredRateLimits deletes rate limits with periods
+	// that ended before the given time, returning the number deleted.
+	DeleteExpiredRateLimits(ctx context.Context, before time.Time) (int, error)
+}
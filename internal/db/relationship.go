@@ -56,6 +56,30 @@ type Relationship interface {
 	// DeleteAccountBlocks will delete all database blocks to / from the given account ID.
 	DeleteAccountBlocks(ctx context.Context, accountID string) error
 
+	// IsAccountDomainBlocked checks whether account has a domain block in place against the given domain, or one of its parent domains.
+	IsAccountDomainBlocked(ctx context.Context, accountID string, domain string) (bool, error)
+
+	// GetAccountDomainBlock returns the domain block owned by account against exactly the given domain, if it exists.
+	GetAccountDomainBlock(ctx context.Context, accountID string, domain string) (*gtsmodel.AccountDomainBlock, error)
+
+	// GetAccountDomainBlocks returns all domain blocks owned by the given account, with given optional paging parameters.
+	GetAccountDomainBlocks(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.AccountDomainBlock, error)
+
+	// GetAccountBlockedDomains is like GetAccountDomainBlocks, but returns just the blocked domains.
+	GetAccountBlockedDomains(ctx context.Context, accountID string) ([]string, error)
+
+	// CountAccountDomainBlocks counts the number of domain blocks owned by the given account.
+	CountAccountDomainBlocks(ctx context.Context, accountID string) (int, error)
+
+	// PutAccountDomainBlock attempts to place the given account domain block in the database.
+	PutAccountDomainBlock(ctx context.Context, block *gtsmodel.AccountDomainBlock) error
+
+	// DeleteAccountDomainBlock removes the domain block owned by account against the given domain.
+	DeleteAccountDomainBlock(ctx context.Context, accountID string, domain string) error
+
+	// DeleteAccountDomainBlocks will delete all domain blocks owned by the given account ID.
+	DeleteAccountDomainBlocks(ctx context.Context, accountID string) error
+
 	// GetRelationship retrieves the relationship of the targetAccount to the requestingAccount.
 	GetRelationship(ctx context.Context, requestingAccount string, targetAccount string) (*gtsmodel.Relationship, error)
 
@@ -164,6 +188,11 @@ type Relationship interface {
 	// GetAccountLocalFollowerIDs is like GetAccountLocalFollowers, but returns just IDs.
 	GetAccountLocalFollowerIDs(ctx context.Context, accountID string) ([]string, error)
 
+	// GetFollowsOfFollowsAccountIDs returns the IDs of up to limit accounts followed by accounts
+	// that the given account follows, excluding the given account and accounts it already follows.
+	// IDs are ordered by how many of the given account's follows follow each account, descending.
+	GetFollowsOfFollowsAccountIDs(ctx context.Context, accountID string, limit int) ([]string, error)
+
 	// GetAccountFollowRequests returns all follow requests targeting the given account.
 	GetAccountFollowRequests(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.FollowRequest, error)
 
@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type ScheduledStatus interface {
+	// GetAllScheduledStatuses returns all pending scheduled statuses.
+	GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, error)
+
+	// GetScheduledStatusByID gets one scheduled status with the given id.
+	GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error)
+
+	// GetScheduledStatusesByIDs gets scheduled statuses with the given ids.
+	GetScheduledStatusesByIDs(ctx context.Context, ids []string) ([]*gtsmodel.ScheduledStatus, error)
+
+	// GetScheduledStatusesForAcct returns pending
+	// scheduled statuses owned by the given account.
+	GetScheduledStatusesForAcct(
+		ctx context.Context,
+		acctID string,
+		page *paging.Page,
+	) ([]*gtsmodel.ScheduledStatus, error)
+
+	// CountScheduledStatusesForAcct returns the number of pending scheduled
+	// statuses owned by the given account. If scheduledAt is not zero, only
+	// statuses scheduled on the same UTC day as scheduledAt are counted.
+	CountScheduledStatusesForAcct(ctx context.Context, acctID string, scheduledAt time.Time) (int, error)
+
+	// PopulateScheduledStatus ensures that the scheduled status' struct fields are populated.
+	PopulateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error
+
+	// PutScheduledStatus puts the given scheduled status in the database.
+	PutScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error
+
+	// UpdateScheduledStatus updates the given scheduled status in the database.
+	UpdateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus, columns ...string) error
+
+	// DeleteScheduledStatusByID deletes one scheduled status with the given ID.
+	DeleteScheduledStatusByID(ctx context.Context, id string) error
+
+	// DeleteScheduledStatusesByAccountID deletes all
+	// scheduled statuses owned by the given account.
+	DeleteScheduledStatusesByAccountID(ctx context.Context, accountID string) error
+}
@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type Suggestion interface {
+	// GetStaffPickByID gets one staff pick with the given id.
+	GetStaffPickByID(ctx context.Context, id string) (*gtsmodel.StaffPick, error)
+
+	// GetStaffPickByAccountID gets the staff pick
+	// of the account with the given ID, if it exists.
+	GetStaffPickByAccountID(ctx context.Context, accountID string) (*gtsmodel.StaffPick, error)
+
+	// GetStaffPicks returns all staff picks, newest first.
+	GetStaffPicks(ctx context.Context) ([]*gtsmodel.StaffPick, error)
+
+	// PutStaffPick puts the given staff pick in the database.
+	PutStaffPick(ctx context.Context, staffPick *gtsmodel.StaffPick) error
+
+	// DeleteStaffPickByID deletes one staff pick with the given ID.
+	DeleteStaffPickByID(ctx context.Context, id string) error
+
+	// GetSuggestionDismissalTargetIDs returns the IDs of all
+	// accounts dismissed from the given account's suggestions.
+	GetSuggestionDismissalTargetIDs(ctx context.Context, accountID string) ([]string, error)
+
+	// PutSuggestionDismissal puts the given suggestion dismissal in the
+	// database. Dismissing an already-dismissed account is a no-op.
+	PutSuggestionDismissal(ctx context.Context, dismissal *gtsmodel.SuggestionDismissal) error
+
+	// DeleteSuggestionDataByAccountID deletes the staff pick of the given
+	// account, and all suggestion dismissals created by or targeting it.
+	DeleteSuggestionDataByAccountID(ctx context.Context, accountID string) error
+}
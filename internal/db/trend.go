@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type Trend interface {
+	// GetTrendReviewByID gets one trend review with the given id.
+	GetTrendReviewByID(ctx context.Context, id string) (*gtsmodel.TrendReview, error)
+
+	// GetTrendReview gets the review of the given trend type and target.
+	GetTrendReview(ctx context.Context, trendType gtsmodel.TrendType, target string) (*gtsmodel.TrendReview, error)
+
+	// PutTrendReview puts the given trend review in the database.
+	PutTrendReview(ctx context.Context, review *gtsmodel.TrendReview) error
+
+	// UpdateTrendReview updates the given trend review in the database.
+	UpdateTrendReview(ctx context.Context, review *gtsmodel.TrendReview, columns ...string) error
+
+	// GetTagTrendActivity returns one activity per use of a tag in a
+	// public status with ID greater than sinceID, with the tag ID as
+	// target. Uses by silenced or suspended accounts are excluded.
+	GetTagTrendActivity(ctx context.Context, sinceID string) ([]*gtsmodel.TrendActivity, error)
+
+	// GetStatusTrendActivity returns one activity per fave or boost
+	// with ID greater than sinceID, with the faved or boosted status
+	// ID as target. Faves and boosts by silenced or suspended
+	// accounts are excluded.
+	GetStatusTrendActivity(ctx context.Context, sinceID string) ([]*gtsmodel.TrendActivity, error)
+
+	// GetLinkTrendActivity returns one activity per public status
+	// containing links with ID greater than sinceID, with the status
+	// ID as target. Statuses by silenced or suspended accounts are
+	// excluded. Links themselves must be extracted by the caller.
+	GetLinkTrendActivity(ctx context.Context, sinceID string) ([]*gtsmodel.TrendActivity, error)
+}
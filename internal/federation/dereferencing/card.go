@@ -0,0 +1,676 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeberg.org/gruf/go-bytesize"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/language"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// cardFreshness is how long after fetching
+	// a preview card we wait before refetching
+	// it from the linked page, when it's next
+	// linked to in a new or updated status.
+	cardFreshness = 7 * 24 * time.Hour
+
+	// maxCardPageSize is the maximum size of
+	// web page we'll read to generate a card.
+	maxCardPageSize = 1 * bytesize.MiB
+
+	// maximum lengths (in runes) of card text.
+	maxCardTitleLen       = 255
+	maxCardDescriptionLen = 1000
+)
+
+// FetchStatusCardAsync enqueues a worker function to fetch
+// (or refresh) the preview card of the given status, see
+// FetchStatusCard(). Statuses that can't have a card are
+// skipped before anything is enqueued.
+func (d *Dereferencer) FetchStatusCardAsync(ctx context.Context, status *gtsmodel.Status) {
+	if status.CardID == "" &&
+		!strings.Contains(status.Content, "href") {
+		// Nothing to
+		// preview here.
+		return
+	}
+
+	d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		if err := d.FetchStatusCard(ctx, status); err != nil {
+			log.Errorf(ctx, "error fetching card for status %s: %v", status.URI, err)
+		}
+	})
+}
+
+// FetchStatusCard generates a preview card from the OpenGraph and / or
+// oEmbed metadata of the first link in the content of the given status
+// (that isn't a mention or hashtag), fetching it if we don't have a fresh
+// copy stored yet, and attaches it to the status. If the status no longer
+// contains any link, any previously attached card is removed from it.
+//
+// Failures to fetch the linked page itself are only logged, as there's
+// nothing that can be done about them; a status just won't get a card.
+func (d *Dereferencer) FetchStatusCard(ctx context.Context, status *gtsmodel.Status) error {
+	// Get the latest version of the status, as it may have changed
+	// since we were queued, and ensure mentions etc are populated.
+	latest, err := d.state.DB.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		return gtserror.Newf("error getting status %s: %w", status.ID, err)
+	}
+
+	var card *gtsmodel.Card
+
+	// Boosts show the card of the boosted status,
+	// and we don't go fetching links in private
+	// messages, as that would leak to the linked
+	// page that the link has been shared.
+	if latest.BoostOfID == "" &&
+		latest.Visibility != gtsmodel.VisibilityDirect {
+		if link := statusCardLink(latest); link != nil {
+			card, err = d.getCard(ctx, link)
+			if err != nil {
+				log.Debugf(ctx, "couldn't generate card for %s: %v", link, err)
+			}
+		}
+	}
+
+	var cardID string
+	if card != nil {
+		cardID = card.ID
+	}
+
+	if cardID == latest.CardID {
+		// Nothing
+		// changed.
+		return nil
+	}
+
+	// Lock the status while we update it.
+	unlock := d.state.FedLocks.Lock(latest.URI)
+	defer unlock()
+
+	// Get the status again now that we hold the
+	// lock, and check its content is still what we
+	// generated the card from, else leave it to the
+	// fetch that must have been queued by that edit.
+	current, err := d.state.DB.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		return gtserror.Newf("error getting status %s: %w", status.ID, err)
+	}
+
+	if current.Content != latest.Content {
+		return nil
+	}
+
+	current.CardID = cardID
+	current.Card = card
+	if err := d.state.DB.UpdateStatus(ctx, current, "card_id"); err != nil {
+		return gtserror.Newf("error updating status %s: %w", current.ID, err)
+	}
+
+	// Make sure the status is rerendered
+	// with its new card in any timelines.
+	if err := d.state.Timelines.Home.UnprepareItemFromAllTimelines(ctx, current.ID); err != nil {
+		log.Errorf(ctx, "error unpreparing status from home timelines: %v", err)
+	}
+	if err := d.state.Timelines.List.UnprepareItemFromAllTimelines(ctx, current.ID); err != nil {
+		log.Errorf(ctx, "error unpreparing status from list timelines: %v", err)
+	}
+
+	return nil
+}
+
+// getCard returns the preview card for the given link, fetching
+// it if we don't have it stored, or refreshing it if it's stale.
+// If a stale card can't be refreshed it's returned alongside the
+// error, so that statuses can keep showing it in the meantime.
+func (d *Dereferencer) getCard(ctx context.Context, link *url.URL) (*gtsmodel.Card, error) {
+	linkStr := link.String()
+
+	// Acquire per-link lock, so that statuses
+	// linking to the same page don't all fetch it.
+	unlock := d.state.FedLocks.Lock("card:" + linkStr)
+	defer unlock()
+
+	card, err := d.state.DB.GetCardByURL(ctx, linkStr)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error getting card %s: %w", linkStr, err)
+	}
+
+	if card != nil && time.Since(card.FetchedAt) < cardFreshness {
+		// Still fresh.
+		return card, nil
+	}
+
+	latest, imageURL, fetchErr := d.fetchCard(ctx, link)
+	if fetchErr != nil {
+		if card != nil {
+			// Mark the stale card as fetched anyway,
+			// so we don't keep hitting a broken page.
+			card.FetchedAt = time.Now()
+			if err := d.state.DB.UpdateCard(ctx, card, "fetched_at"); err != nil {
+				log.Errorf(ctx, "error updating card %s: %v", linkStr, err)
+			}
+		}
+		return card, fetchErr
+	}
+
+	if card == nil {
+		latest.ID = id.NewULID()
+	} else {
+		// Reuse existing card ID, and
+		// keep its image if unchanged.
+		latest.ID = card.ID
+		latest.CreatedAt = card.CreatedAt
+		if card.Image != nil &&
+			card.Image.RemoteURL == imageURL {
+			latest.ImageID = card.ImageID
+			latest.Image = card.Image
+		}
+	}
+
+	if imageURL != "" {
+		// Fetch (or recache) the preview image. Cards
+		// are shared between statuses, so the image is
+		// owned by, and fetched as, the instance account.
+		latest.Image, err = d.fetchCardImage(ctx, latest, imageURL)
+		if err != nil {
+			log.Warnf(ctx, "error fetching image for card %s: %v", linkStr, err)
+		}
+
+		latest.ImageID = ""
+		if latest.Image != nil {
+			latest.ImageID = latest.Image.ID
+		}
+	}
+
+	if card == nil {
+		err = d.state.DB.PutCard(ctx, latest)
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Inserted in the meantime by another
+			// instance of us, just use that one.
+			return d.state.DB.GetCardByURL(ctx, linkStr)
+		}
+	} else {
+		err = d.state.DB.UpdateCard(ctx, latest)
+	}
+
+	if err != nil {
+		return nil, gtserror.Newf("error storing card %s: %w", linkStr, err)
+	}
+
+	return latest, nil
+}
+
+// fetchCardImage returns the preview image at imageURL for the
+// given card, either fetching it new, or recaching the existing
+// image if it has been uncached by the media cleaner since.
+func (d *Dereferencer) fetchCardImage(
+	ctx context.Context,
+	card *gtsmodel.Card,
+	imageURL string,
+) (*gtsmodel.MediaAttachment, error) {
+	if card.Image != nil {
+		return d.RefreshMedia(ctx,
+			"", // instance account
+			card.Image,
+			media.AdditionalMediaInfo{},
+			false,
+		)
+	}
+
+	instanceAcc, err := d.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("error getting instance account: %w", err)
+	}
+
+	return d.GetMedia(ctx,
+		"", // instance account
+		instanceAcc.ID,
+		imageURL,
+		media.AdditionalMediaInfo{
+			CardID:      &card.ID,
+			Description: &card.Title,
+		},
+	)
+}
+
+// fetchCard fetches the web page at the given link, and generates
+// a new (unstored) card from its metadata, also returning the URL
+// of the image to use as the card's thumbnail, if any.
+func (d *Dereferencer) fetchCard(ctx context.Context, link *url.URL) (*gtsmodel.Card, string, error) {
+	if blocked, err := d.state.DB.IsDomainBlocked(ctx, link.Host); err != nil {
+		return nil, "", gtserror.Newf("error checking blocked domain: %w", err)
+	} else if blocked {
+		return nil, "", gtserror.Newf("%s is blocked", link.Host)
+	}
+
+	// Fetch pages as the instance account. The transport's
+	// http client takes care of respecting IP allow / block
+	// lists, including on any redirects the page returns.
+	tsport, err := d.transportController.NewTransportForUsername(ctx, "")
+	if err != nil {
+		return nil, "", gtserror.Newf("error getting instance transport: %w", err)
+	}
+
+	rsp, err := tsport.DereferenceWebPage(ctx, link, int64(maxCardPageSize))
+	if err != nil {
+		return nil, "", gtserror.Newf("error fetching page: %w", err)
+	}
+
+	// Parse metadata from page head, relative
+	// to final page URL after any redirects.
+	meta := parsePageMeta(rsp.Body, rsp.Request.URL)
+	_ = rsp.Body.Close()
+
+	card := &gtsmodel.Card{
+		FetchedAt:    time.Now(),
+		URL:          link.String(),
+		Type:         gtsmodel.CardTypeLink,
+		Title:        meta.title,
+		Description:  meta.description,
+		AuthorName:   meta.authorName,
+		ProviderName: meta.siteName,
+		Width:        meta.imageWidth,
+		Height:       meta.imageHeight,
+		Language:     meta.language,
+	}
+	imageURL := meta.image
+
+	if meta.oembed != nil {
+		// Prefer details from oEmbed where
+		// the page provides them, as these
+		// are intended for embedding.
+		oembed, err := tsport.DereferenceOEmbed(ctx, meta.oembed)
+		if err != nil {
+			log.Debugf(ctx, "error fetching oembed for %s: %v", link, err)
+		} else {
+			imageURL = applyOEmbed(card, oembed, meta.oembed, imageURL)
+		}
+	}
+
+	if card.Title == "" {
+		// Without a title
+		// there's no card.
+		return nil, "", gtserror.New("no title in page metadata")
+	}
+
+	card.Title = truncate(card.Title, maxCardTitleLen)
+	card.Description = truncate(card.Description, maxCardDescriptionLen)
+
+	return card, imageURL, nil
+}
+
+// applyOEmbed sets any fields provided by the given oEmbed
+// response on the card, returning the (possibly updated)
+// URL of the image to use as the card's thumbnail.
+func applyOEmbed(
+	card *gtsmodel.Card,
+	oembed *transport.OEmbed,
+	base *url.URL,
+	imageURL string,
+) string {
+	card.Type = gtsmodel.NewCardType(oembed.Type)
+
+	if oembed.Title != "" {
+		card.Title = oembed.Title
+	}
+	if oembed.AuthorName != "" {
+		card.AuthorName = oembed.AuthorName
+		card.AuthorURL = resolveHTTPURL(base, oembed.AuthorURL)
+	}
+	if oembed.ProviderName != "" {
+		card.ProviderName = oembed.ProviderName
+		card.ProviderURL = resolveHTTPURL(base, oembed.ProviderURL)
+	}
+
+	width, _ := strconv.Atoi(oembed.Width.String())
+	height, _ := strconv.Atoi(oembed.Height.String())
+	if width > 0 && height > 0 {
+		card.Width = width
+		card.Height = height
+	}
+
+	switch card.Type {
+	case gtsmodel.CardTypePhoto:
+		card.EmbedURL = resolveHTTPURL(base, oembed.URL)
+		if card.EmbedURL == "" {
+			// Nothing to show.
+			card.Type = gtsmodel.CardTypeLink
+		} else if oembed.ThumbnailURL == "" {
+			// Use the photo itself.
+			imageURL = card.EmbedURL
+		}
+
+	case gtsmodel.CardTypeVideo, gtsmodel.CardTypeRich:
+		card.HTML = sanitizeEmbedHTML(oembed.HTML)
+		if card.HTML == "" {
+			// Nothing we're
+			// willing to embed.
+			card.Type = gtsmodel.CardTypeLink
+		}
+	}
+
+	if thumb := resolveHTTPURL(base, oembed.ThumbnailURL); thumb != "" {
+		imageURL = thumb
+	}
+
+	return imageURL
+}
+
+// statusCardLink returns the first link in the content of the
+// given status to generate a preview card for, skipping over
+// mentions, hashtags, and links to the status it quotes.
+func statusCardLink(status *gtsmodel.Status) *url.URL {
+	// Gather hrefs that we know point to accounts,
+	// tags or statuses rather than to web pages.
+	skip := make([]string, 0, 2*len(status.Mentions)+1)
+	for _, mention := range status.Mentions {
+		skip = append(skip, mention.TargetAccountURI, mention.TargetAccountURL)
+		if mention.TargetAccount != nil {
+			skip = append(skip, mention.TargetAccount.URI, mention.TargetAccount.URL)
+		}
+	}
+	if status.QuoteURI != "" {
+		skip = append(skip, status.QuoteURI)
+		if status.Quote != nil {
+			skip = append(skip, status.Quote.URL)
+		}
+	}
+
+	tokenizer := html.NewTokenizer(strings.NewReader(status.Content))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			// End of content.
+			return nil
+
+		case html.StartTagToken:
+			token := tokenizer.Token()
+			if token.DataAtom != atom.A {
+				continue
+			}
+
+			var href, class, rel string
+			for _, attr := range token.Attr {
+				switch attr.Key {
+				case "href":
+					href = attr.Val
+				case "class":
+					class = attr.Val
+				case "rel":
+					rel = attr.Val
+				}
+			}
+
+			classes := strings.Fields(class)
+			if slices.Contains(classes, "mention") ||
+				slices.Contains(classes, "hashtag") ||
+				slices.Contains(strings.Fields(rel), "tag") ||
+				slices.Contains(skip, href) {
+				continue
+			}
+
+			link, err := url.Parse(href)
+			if err != nil ||
+				(link.Scheme != "http" && link.Scheme != "https") ||
+				link.Host == "" {
+				continue
+			}
+
+			// Fragments don't change
+			// the page being linked to.
+			link.Fragment = ""
+			return link
+		}
+	}
+}
+
+// pageMeta contains the metadata
+// parsed from a web page's head.
+type pageMeta struct {
+	title       string
+	description string
+	image       string
+	imageWidth  int
+	imageHeight int
+	siteName    string
+	authorName  string
+	language    string
+	oembed      *url.URL
+}
+
+// parsePageMeta parses OpenGraph, Twitter and plain html metadata
+// from the head of the web page in r, resolving any links against
+// base. Where a page provides the same detail in multiple forms,
+// OpenGraph is preferred, then Twitter, then plain html.
+func parsePageMeta(r io.Reader, base *url.URL) *pageMeta {
+	var (
+		metas     = make(map[string]string)
+		title     string
+		inTitle   bool
+		oembedURL string
+		lang      string
+	)
+
+	tokenizer := html.NewTokenizer(r)
+parse:
+	for {
+		tt := tokenizer.Next()
+		switch tt {
+		case html.ErrorToken:
+			// End of page (or
+			// size limit hit).
+			break parse
+
+		case html.TextToken:
+			if inTitle {
+				title += string(tokenizer.Text())
+			}
+
+		case html.EndTagToken:
+			token := tokenizer.Token()
+			switch token.DataAtom {
+			case atom.Title:
+				inTitle = false
+			case atom.Head:
+				// All we need is
+				// in the head.
+				break parse
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			attrs := make(map[string]string, len(token.Attr))
+			for _, attr := range token.Attr {
+				attrs[attr.Key] = attr.Val
+			}
+
+			switch token.DataAtom {
+			case atom.Html:
+				lang = attrs["lang"]
+
+			case atom.Title:
+				inTitle = (tt == html.StartTagToken)
+
+			case atom.Meta:
+				key := attrs["property"]
+				if key == "" {
+					key = attrs["name"]
+				}
+				key = strings.ToLower(key)
+				if _, ok := metas[key]; !ok && key != "" {
+					metas[key] = strings.TrimSpace(attrs["content"])
+				}
+
+			case atom.Link:
+				if oembedURL == "" &&
+					strings.EqualFold(attrs["type"], "application/json+oembed") &&
+					slices.Contains(strings.Fields(strings.ToLower(attrs["rel"])), "alternate") {
+					oembedURL = attrs["href"]
+				}
+
+			case atom.Body:
+				// Head is over
+				// (or missing).
+				break parse
+			}
+		}
+	}
+
+	// first returns the first non-empty meta of keys.
+	first := func(keys ...string) string {
+		for _, key := range keys {
+			if v := metas[key]; v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	meta := &pageMeta{
+		title:       first("og:title", "twitter:title"),
+		description: first("og:description", "twitter:description", "description"),
+		image:       resolveHTTPURL(base, first("og:image:secure_url", "og:image:url", "og:image", "twitter:image", "twitter:image:src")),
+		siteName:    first("og:site_name", "application-name"),
+		authorName:  first("author", "article:author"),
+	}
+
+	if meta.title == "" {
+		meta.title = strings.Join(strings.Fields(title), " ")
+	}
+
+	if meta.image != "" {
+		meta.imageWidth, _ = strconv.Atoi(metas["og:image:width"])
+		meta.imageHeight, _ = strconv.Atoi(metas["og:image:height"])
+	}
+
+	if strings.HasPrefix(meta.authorName, "http") {
+		// Some sites put links to
+		// author profiles in here.
+		meta.authorName = ""
+	}
+
+	if lang == "" {
+		// og:locale looks like "en_US".
+		lang = strings.ReplaceAll(metas["og:locale"], "_", "-")
+	}
+	if l, err := language.Parse(lang); err == nil {
+		meta.language = l.TagStr
+	}
+
+	if oembedURL := resolveHTTPURL(base, oembedURL); oembedURL != "" {
+		meta.oembed, _ = url.Parse(oembedURL)
+	}
+
+	return meta
+}
+
+// sanitizeEmbedHTML reduces the given oEmbed html to a single https
+// iframe with only its source and dimensions set, which is all that
+// Mastodon clients expect to embed. If there's no such iframe in the
+// html, an empty string is returned.
+func sanitizeEmbedHTML(in string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(in))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			// No iframe.
+			return ""
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if token.DataAtom != atom.Iframe {
+				continue
+			}
+
+			var src, width, height string
+			for _, attr := range token.Attr {
+				switch attr.Key {
+				case "src":
+					src = attr.Val
+				case "width":
+					width = attr.Val
+				case "height":
+					height = attr.Val
+				}
+			}
+
+			srcURL, err := url.Parse(src)
+			if err != nil ||
+				srcURL.Scheme != "https" ||
+				srcURL.Host == "" {
+				return ""
+			}
+
+			out := `<iframe src="` + html.EscapeString(srcURL.String()) + `"`
+			if _, err := strconv.Atoi(width); err == nil {
+				out += ` width="` + width + `"`
+			}
+			if _, err := strconv.Atoi(height); err == nil {
+				out += ` height="` + height + `"`
+			}
+			return out + ` frameborder="0" allowfullscreen="true"></iframe>`
+		}
+	}
+}
+
+// resolveHTTPURL resolves the given raw URL against base,
+// returning it only if it's a valid http(s) URL.
+func resolveHTTPURL(base *url.URL, raw string) string {
+	if raw == "" {
+		return ""
+	}
+
+	u, err := base.Parse(strings.TrimSpace(raw))
+	if err != nil ||
+		(u.Scheme != "http" && u.Scheme != "https") ||
+		u.Host == "" {
+		return ""
+	}
+
+	return u.String()
+}
+
+// truncate trims given string
+// to specified length (in runes).
+func truncate(s string, l int) string {
+	r := []rune(s)
+	if len(r) <= l {
+		return s
+	}
+	return string(r[:l-1]) + "…"
+}
@@ -0,0 +1,186 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type CardTestSuite struct {
+	DereferencerStandardTestSuite
+}
+
+// statusWithContent updates the content of a test
+// status to the given html, returning the status.
+func (suite *CardTestSuite) statusWithContent(content string) *gtsmodel.Status {
+	status, err := suite.db.GetStatusByID(
+		context.Background(),
+		testrig.NewTestStatuses()["local_account_1_status_1"].ID,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	status.Content = content
+	if err := suite.db.UpdateStatus(context.Background(), status, "content"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return status
+}
+
+func (suite *CardTestSuite) TestFetchStatusCardOpenGraph() {
+	ctx := context.Background()
+
+	// The first link in this status is a mention, and the
+	// second a hashtag, so the card should be of the third.
+	status := suite.statusWithContent(`<p>` +
+		`<span class="h-card"><a href="https://links.example.org/@someone" class="u-url mention">@<span>someone</span></a></span> ` +
+		`<a href="https://links.example.org/tags/water" class="mention hashtag" rel="tag">#<span>water</span></a> ` +
+		`read this: <a href="https://links.example.org/article" rel="nofollow noreferrer noopener" target="_blank">https://links.example.org/article</a>` +
+		`</p>`)
+
+	err := suite.dereferencer.FetchStatusCard(ctx, status)
+	suite.NoError(err)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	suite.NoError(err)
+	suite.NotEmpty(dbStatus.CardID)
+	suite.NotNil(dbStatus.Card)
+
+	card := dbStatus.Card
+	suite.Equal("https://links.example.org/article", card.URL)
+	suite.Equal(gtsmodel.CardTypeLink, card.Type)
+	suite.Equal("Is Water Wet?", card.Title)
+	suite.Equal("We're not sure. In this article, we ask an expert...", card.Description)
+	suite.Equal("Links Example", card.ProviderName)
+	suite.Equal("Some Writer", card.AuthorName)
+	suite.Equal("en-GB", card.Language)
+	suite.Empty(card.HTML)
+	suite.WithinDuration(time.Now(), card.FetchedAt, 10*time.Second)
+
+	// Fetching again while the card is still
+	// fresh should give the same stored card.
+	err = suite.dereferencer.FetchStatusCard(ctx, dbStatus)
+	suite.NoError(err)
+
+	dbCard, err := suite.db.GetCardByURL(ctx, card.URL)
+	suite.NoError(err)
+	suite.Equal(card.ID, dbCard.ID)
+	suite.Equal(card.FetchedAt, dbCard.FetchedAt)
+}
+
+func (suite *CardTestSuite) TestFetchStatusCardOEmbed() {
+	ctx := context.Background()
+
+	status := suite.statusWithContent(`<p>watch this: <a href="https://links.example.org/video#t=10">https://links.example.org/video</a></p>`)
+
+	err := suite.dereferencer.FetchStatusCard(ctx, status)
+	suite.NoError(err)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	suite.NoError(err)
+	suite.NotNil(dbStatus.Card)
+
+	card := dbStatus.Card
+	suite.Equal("https://links.example.org/video", card.URL)
+	suite.Equal(gtsmodel.CardTypeVideo, card.Type)
+	suite.Equal("Water: The Video", card.Title)
+	suite.Equal("Video Person", card.AuthorName)
+	suite.Equal("https://links.example.org/@videoperson", card.AuthorURL)
+	suite.Equal("Links Example Video", card.ProviderName)
+	suite.Equal("https://links.example.org/", card.ProviderURL)
+	suite.Equal(640, card.Width)
+	suite.Equal(360, card.Height)
+
+	// Only the iframe itself should be kept.
+	suite.Equal(`<iframe src="https://links.example.org/embed/video" width="640" height="360" frameborder="0" allowfullscreen="true"></iframe>`, card.HTML)
+}
+
+func (suite *CardTestSuite) TestFetchStatusCardRemoved() {
+	ctx := context.Background()
+
+	status := suite.statusWithContent(`<p><a href="https://links.example.org/article">https://links.example.org/article</a></p>`)
+	err := suite.dereferencer.FetchStatusCard(ctx, status)
+	suite.NoError(err)
+
+	// Edit the link out of the status,
+	// the card should now be removed.
+	status = suite.statusWithContent(`<p>never mind</p>`)
+	suite.NotEmpty(status.CardID)
+
+	err = suite.dereferencer.FetchStatusCard(ctx, status)
+	suite.NoError(err)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	suite.NoError(err)
+	suite.Empty(dbStatus.CardID)
+	suite.Nil(dbStatus.Card)
+}
+
+func (suite *CardTestSuite) TestFetchStatusCardNoCard() {
+	ctx := context.Background()
+
+	for _, content := range []string{
+		// Page has no title.
+		`<p><a href="https://links.example.org/untitled">https://links.example.org/untitled</a></p>`,
+
+		// Page doesn't exist.
+		`<p><a href="https://links.example.org/missing">https://links.example.org/missing</a></p>`,
+
+		// Not a web link.
+		`<p><a href="mailto:someone@example.org">someone@example.org</a></p>`,
+	} {
+		status := suite.statusWithContent(content)
+
+		err := suite.dereferencer.FetchStatusCard(ctx, status)
+		suite.NoError(err)
+
+		dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+		suite.NoError(err)
+		suite.Empty(dbStatus.CardID, content)
+	}
+}
+
+func (suite *CardTestSuite) TestFetchStatusCardDirect() {
+	ctx := context.Background()
+
+	status := suite.statusWithContent(`<p><a href="https://links.example.org/article">https://links.example.org/article</a></p>`)
+	status.Visibility = gtsmodel.VisibilityDirect
+	if err := suite.db.UpdateStatus(ctx, status, "visibility"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	err := suite.dereferencer.FetchStatusCard(ctx, status)
+	suite.NoError(err)
+
+	// Links in direct messages aren't fetched.
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	suite.NoError(err)
+	suite.Empty(dbStatus.CardID)
+}
+
+func TestCardTestSuite(t *testing.T) {
+	suite.Run(t, new(CardTestSuite))
+}
@@ -34,6 +34,7 @@
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -104,6 +105,12 @@ func (d *Dereferencer) GetStatusByURI(ctx context.Context, requestUser string, u
 			statusable,
 			isNew,
 		)
+
+		// Deref quoted status.
+		d.dereferenceQuote(ctx,
+			requestUser,
+			status,
+		)
 	}
 
 	return status, statusable, nil
@@ -213,6 +220,12 @@ func (d *Dereferencer) RefreshStatus(
 			statusable,
 			isNew,
 		)
+
+		// Deref quoted status.
+		d.dereferenceQuote(ctx,
+			requestUser,
+			latest,
+		)
 	}
 
 	return latest, statusable, err
@@ -260,6 +273,77 @@ func (d *Dereferencer) RefreshStatusAsync(
 			if err := d.DereferenceStatusDescendants(ctx, requestUser, uri, statusable); err != nil {
 				log.Error(ctx, err)
 			}
+			d.dereferenceQuote(ctx, requestUser, latest)
+		}
+	})
+}
+
+// dereferenceQuote enqueues dereferencing of the status quoted
+// by the given status, if we don't have it stored yet. Once
+// fetched, the quote is linked to the quoting status, and the
+// state of the quote is checked against the quoted status.
+func (d *Dereferencer) dereferenceQuote(
+	ctx context.Context,
+	requestUser string,
+	status *gtsmodel.Status,
+) {
+	if status == nil ||
+		status.QuoteURI == "" ||
+		status.QuoteID != "" {
+		// Nothing to do.
+		return
+	}
+
+	d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		quoteURI, err := url.Parse(status.QuoteURI)
+		if err != nil {
+			log.Errorf(ctx, "invalid quote uri %q: %v", status.QuoteURI, err)
+			return
+		}
+
+		// Fetch the quoted status, but not its thread,
+		// nor anything that it might quote in turn.
+		quote, _, _, err := d.getStatusByURI(ctx,
+			requestUser,
+			quoteURI,
+		)
+		if err != nil {
+			log.Errorf(ctx, "error dereferencing quote %s: %v", quoteURI, err)
+			return
+		}
+
+		// Lock the quoting status while we update it.
+		unlock := d.state.FedLocks.Lock(status.URI)
+		defer unlock()
+
+		// Get the latest version of the quoting status,
+		// as it may have changed since we were queued.
+		latest, err := d.state.DB.GetStatusByURI(ctx, status.URI)
+		if err != nil {
+			log.Errorf(ctx, "error getting status %s: %v", status.URI, err)
+			return
+		}
+
+		if latest.QuoteURI != status.QuoteURI ||
+			latest.QuoteID != "" {
+			// Changed in the
+			// meantime, leave it.
+			return
+		}
+
+		latest.QuoteID = quote.ID
+		latest.Quote = quote
+		if err := d.checkPermittedQuote(ctx, latest); err != nil {
+			log.Errorf(ctx, "error checking quote permissivity: %v", err)
+			return
+		}
+
+		if err := d.state.DB.UpdateStatus(ctx, latest,
+			"quote_id",
+			"quote_state",
+			"quote_approved_by_uri",
+		); err != nil {
+			log.Errorf(ctx, "error updating status %s: %v", latest.URI, err)
 		}
 	})
 }
@@ -522,6 +606,8 @@ func (d *Dereferencer) enrichStatus(
 	latestStatus.UpdatedAt = status.UpdatedAt
 	latestStatus.Local = status.Local
 	latestStatus.PinnedAt = status.PinnedAt
+	latestStatus.CardID = status.CardID
+	latestStatus.Card = status.Card
 
 	// Carry-over approvals. Remote instances might not yet
 	// serve statuses with the `approved_by` field, but we
@@ -537,6 +623,13 @@ func (d *Dereferencer) enrichStatus(
 		latestStatus.ApprovedByURI = status.ApprovedByURI
 	}
 
+	// Likewise carry-over the state of the quote,
+	// if any, provided it still quotes the same.
+	if latestStatus.QuoteURI != "" && latestStatus.QuoteURI == status.QuoteURI {
+		latestStatus.QuoteState = status.QuoteState
+		latestStatus.QuoteApprovedByURI = status.QuoteApprovedByURI
+	}
+
 	// Check if this is a permitted status we should accept.
 	// Function also sets "PendingApproval" bool as necessary.
 	permit, err := d.isPermittedStatus(ctx, requestUser, status, latestStatus)
@@ -550,6 +643,36 @@ func (d *Dereferencer) enrichStatus(
 		return nil, nil, gtserror.SetNotPermitted(err)
 	}
 
+	// Carry-over previous edits of the status.
+	latestStatus.EditIDs = status.EditIDs
+	latestStatus.Edits = status.Edits
+
+	var edit *gtsmodel.StatusEdit
+
+	if !isNew && statusEdited(status, latestStatus) {
+		// Status has changed since we last saw it, take a
+		// snapshot of the previous revision. This needs to be
+		// done before attachments are fetched, as existing
+		// attachment models may be updated in-place.
+		edit = typeutils.StatusToStatusEdit(status)
+
+		if latestStatus.EditedAt.IsZero() {
+			// Remote didn't provide an
+			// updated time, just use now.
+			latestStatus.EditedAt = time.Now()
+		}
+
+		if status.Poll != nil && latestStatus.Poll != nil &&
+			!slices.Equal(status.Poll.Options, latestStatus.Poll.Options) {
+			// Poll is going to be reset,
+			// store votes at this revision.
+			edit.PollVotes = status.Poll.Votes
+		}
+	} else if latestStatus.EditedAt.IsZero() {
+		// Carry-over any previous edit time.
+		latestStatus.EditedAt = status.EditedAt
+	}
+
 	// Ensure the status' mentions are populated, and pass in existing to check for changes.
 	if err := d.fetchStatusMentions(ctx, requestUser, status, latestStatus); err != nil {
 		return nil, nil, gtserror.Newf("error populating mentions for status %s: %w", uri, err)
@@ -588,12 +711,26 @@ func (d *Dereferencer) enrichStatus(
 			return nil, nil, gtserror.Newf("error putting in database: %w", err)
 		}
 	} else {
+		if edit != nil {
+			// Store the previous revision of this
+			// status, and add it to the list of edits.
+			if err := d.state.DB.PutStatusEdit(ctx, edit); err != nil {
+				return nil, nil, gtserror.Newf("error putting edit in database: %w", err)
+			}
+			latestStatus.EditIDs = append(slices.Clone(status.EditIDs), edit.ID)
+			latestStatus.Edits = append(slices.Clone(status.Edits), edit)
+		}
+
 		// This is an existing status, update the model in the database.
 		if err := d.state.DB.UpdateStatus(ctx, latestStatus); err != nil {
 			return nil, nil, gtserror.Newf("error updating database: %w", err)
 		}
 	}
 
+	// Generate (or refresh) the preview card
+	// for any link in the status content.
+	d.FetchStatusCardAsync(ctx, latestStatus)
+
 	return latestStatus, statusable, nil
 }
 
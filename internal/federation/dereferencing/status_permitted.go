@@ -98,6 +98,18 @@ func (d *Dereferencer) isPermittedStatus(
 		permitted = true
 	}
 
+	if permitted && status.QuoteURI != "" {
+		// Status is permitted, and quotes another,
+		// check whether the quote itself is permitted.
+		// This never drops the status, it just sets
+		// the state of the quote as appropriate.
+		if err := d.checkPermittedQuote(ctx,
+			status,
+		); err != nil {
+			return false, gtserror.Newf("error checking quote permissivity: %w", err)
+		}
+	}
+
 	if !permitted && existing != nil {
 		log.Infof(ctx, "deleting unpermitted: %s", existing.URI)
 
@@ -624,6 +636,110 @@ func (d *Dereferencer) isPermittedBoost(
 	return true, nil
 }
 
+// checkPermittedQuote sets the QuoteState of the given
+// quoting status according to the interaction policy of
+// the quoted status, if we have it. Unlike with replies
+// and boosts, an unpermitted quote doesn't cause the
+// quoting status to be dropped, instead the quote is
+// marked as rejected so that it won't be shown.
+func (d *Dereferencer) checkPermittedQuote(
+	ctx context.Context,
+	status *gtsmodel.Status,
+) error {
+	quote := status.Quote
+	if quote == nil {
+		// If we didn't have the quoted status
+		// in our database (yet), we can't check
+		// right now if this quote is permitted.
+		status.QuoteState = gtsmodel.QuoteStateUnknown
+		return nil
+	}
+
+	if status.QuoteState == gtsmodel.QuoteStateAccepted ||
+		status.QuoteState == gtsmodel.QuoteStateRejected {
+		// Quote was already decided,
+		// carried over from existing.
+		return nil
+	}
+
+	// Check if we have a stored interaction
+	// request that decided this quote already.
+	req, err := d.state.DB.GetInteractionRequestByInteractionURI(
+		gtscontext.SetBarebones(ctx),
+		status.URI,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting interaction request: %w", err)
+	}
+
+	if req != nil && req.InteractionType == gtsmodel.InteractionQuote {
+		switch {
+		case req.IsAccepted():
+			status.QuoteState = gtsmodel.QuoteStateAccepted
+			status.QuoteApprovedByURI = req.URI
+			return nil
+		case req.IsRejected():
+			status.QuoteState = gtsmodel.QuoteStateRejected
+			return nil
+		}
+	}
+
+	if quote.BoostOfID != "" {
+		// We do not permit quotes
+		// of boost wrapper statuses.
+		status.QuoteState = gtsmodel.QuoteStateRejected
+		return nil
+	}
+
+	if quote.IsLocal() {
+		visible, err := d.visFilter.StatusVisible(ctx,
+			status.Account,
+			quote,
+		)
+		if err != nil {
+			return gtserror.Newf("error checking quote visibility: %w", err)
+		}
+
+		// Our status is not visible to
+		// the account trying to quote it.
+		if !visible {
+			status.QuoteState = gtsmodel.QuoteStateRejected
+			return nil
+		}
+	}
+
+	quoteable, err := d.intFilter.StatusQuoteable(ctx,
+		status.Account,
+		quote,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking status quoteability: %w", err)
+	}
+
+	switch {
+	case quoteable.Forbidden():
+		// Quote is not permitted by policy.
+		status.QuoteState = gtsmodel.QuoteStateRejected
+
+	case quoteable.Permitted():
+		// Quote is permitted; for a remote
+		// quoted status matched on collection
+		// we can't verify membership, but a
+		// quote isn't shown without the quoted
+		// status anyway so we trust the remote.
+		status.QuoteState = gtsmodel.QuoteStateAccepted
+
+	default:
+		// Quote requires approval from
+		// the quoted account. If quoted
+		// status is ours, the processor
+		// will create a pending request.
+		status.QuoteState = gtsmodel.QuoteStatePending
+	}
+
+	return nil
+}
+
 // isValidAccept dereferences the activitystreams Accept at the
 // specified IRI, and checks the Accept for validity against the
 // provided expectedObject and expectedActor.
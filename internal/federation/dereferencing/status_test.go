@@ -20,9 +20,12 @@
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -229,6 +232,79 @@ func (suite *StatusTestSuite) TestDereferenceStatusWithNonMatchingURI() {
 	suite.Nil(fetchedStatus)
 }
 
+func (suite *StatusTestSuite) TestDereferenceStatusEdit() {
+	var (
+		ctx             = context.Background()
+		fetchingAccount = suite.testAccounts["local_account_1"]
+		statusURI       = "https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839"
+	)
+
+	// Dereference the original version of the status.
+	status, _, err := suite.dereferencer.GetStatusByURI(ctx, fetchingAccount.Username, testrig.URLMustParse(statusURI))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(status.EditIDs)
+	suite.True(status.EditedAt.IsZero())
+
+	// Create an edited version of the status.
+	editedAt := testrig.TimeMustParse("2022-07-14T12:13:12+02:00")
+	edited := testrig.NewAPNote(
+		testrig.URLMustParse(statusURI),
+		testrig.URLMustParse("https://unknown-instance.com/users/@brand_new_person/01FE4NTHKWW7THT67EF10EB839"),
+		testrig.TimeMustParse("2022-07-13T12:13:12+02:00"),
+		"Hello world! (edited)",
+		"greetings",
+		testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person"),
+		[]*url.URL{testrig.URLMustParse(pub.PublicActivityPubIRI)},
+		[]*url.URL{},
+		false,
+		nil,
+		[]vocab.TootHashtag{},
+		nil,
+	)
+	ap.SetUpdated(edited, editedAt)
+
+	// Refresh the status with the edited version.
+	status, _, err = suite.dereferencer.RefreshStatus(ctx,
+		fetchingAccount.Username,
+		status,
+		edited,
+		nil,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("Hello world! (edited)", status.Content)
+	suite.Equal("greetings", status.ContentWarning)
+	suite.True(status.EditedAt.Equal(editedAt))
+
+	// The previous revision should have been stored.
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(dbStatus.EditIDs, 1)
+	suite.Len(dbStatus.Edits, 1)
+	suite.Equal("Hello world!", dbStatus.Edits[0].Content)
+	suite.Empty(dbStatus.Edits[0].ContentWarning)
+	suite.True(dbStatus.Edits[0].CreatedAt.Equal(dbStatus.CreatedAt))
+
+	// Refreshing again without changes
+	// shouldn't store another revision.
+	status, _, err = suite.dereferencer.RefreshStatus(ctx,
+		fetchingAccount.Username,
+		dbStatus,
+		edited,
+		nil,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(status.EditIDs, 1)
+	suite.True(status.EditedAt.Equal(editedAt))
+}
+
 func TestStatusTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusTestSuite))
 }
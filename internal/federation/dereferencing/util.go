@@ -21,6 +21,7 @@
 	"slices"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // getEmojiByShortcodeDomain searches input slice
@@ -50,6 +51,39 @@ func emojiChanged(existing, latest *gtsmodel.Emoji) bool {
 		existing.ImageStaticRemoteURL != latest.ImageStaticRemoteURL
 }
 
+// statusEdited returns whether a status has changed in a way that
+// indicates it has been edited, and a revision should be stored.
+// i.e. if the content, content warning, sensitivity, media or poll
+// options have changed. Note this expects existing to be populated
+// and latest to contain *placeholder* attachments from the remote.
+func statusEdited(existing, latest *gtsmodel.Status) bool {
+	if existing.Content != latest.Content ||
+		existing.ContentWarning != latest.ContentWarning ||
+		util.PtrOrZero(existing.Sensitive) != util.PtrOrZero(latest.Sensitive) {
+		return true
+	}
+
+	if len(existing.Attachments) != len(latest.Attachments) {
+		return true
+	}
+
+	for i, attachment := range latest.Attachments {
+		if existing.Attachments[i].RemoteURL != attachment.RemoteURL ||
+			existing.Attachments[i].Description != attachment.Description {
+			return true
+		}
+	}
+
+	switch {
+	case existing.Poll == nil && latest.Poll == nil:
+		return false
+	case existing.Poll == nil || latest.Poll == nil:
+		return true
+	default:
+		return !slices.Equal(existing.Poll.Options, latest.Poll.Options)
+	}
+}
+
 // pollChanged returns whether a poll has changed in way that
 // indicates that this should be an entirely new poll. i.e. if
 // the available options have changed, or the expiry has increased.
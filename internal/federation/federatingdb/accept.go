@@ -93,6 +93,19 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 
 			// ACCEPT FOLLOW
 			case ap.ActivityFollow:
+				// Check first whether this is
+				// the Accept of a relay Follow.
+				if ok, err := f.relayFollowResponse(
+					ctx,
+					ap.GetJSONLDId(asType),
+					requestingAcct,
+					true,
+				); err != nil {
+					return err
+				} else if ok {
+					continue
+				}
+
 				if err := f.acceptFollowType(
 					ctx,
 					asType,
@@ -110,7 +123,22 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 		} else if object.IsIRI() {
 			// Check and handle any
 			// IRI type objects.
-			switch objIRI := object.GetIRI(); {
+			objIRI := object.GetIRI()
+
+			// Check first whether this is
+			// the Accept of a relay Follow.
+			if ok, err := f.relayFollowResponse(
+				ctx,
+				objIRI,
+				requestingAcct,
+				true,
+			); err != nil {
+				return err
+			} else if ok {
+				continue
+			}
+
+			switch {
 
 			// ACCEPT FOLLOW
 			case uris.IsFollowPath(objIRI):
@@ -375,6 +403,20 @@ func (f *federatingDB) acceptStoredStatus(
 	unlock := f.state.FedLocks.Lock(status.URI)
 	defer unlock()
 
+	if status.QuotePendingApproval() &&
+		status.Quote != nil &&
+		status.Quote.AccountID == requestingAcct.ID {
+		// This is the quoted status author
+		// approving the quote of their status.
+		return f.acceptStoredQuote(
+			ctx,
+			activityID,
+			status,
+			receivingAcct,
+			requestingAcct,
+		)
+	}
+
 	pendingApproval := util.PtrOrValue(status.PendingApproval, false)
 	if !pendingApproval {
 		// Status doesn't need approval or it's
@@ -426,6 +468,39 @@ func (f *federatingDB) acceptStoredStatus(
 	return nil
 }
 
+func (f *federatingDB) acceptStoredQuote(
+	ctx context.Context,
+	activityID *url.URL,
+	status *gtsmodel.Status,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+) error {
+	// Mark the quote as approved by this Accept URI.
+	status.QuoteState = gtsmodel.QuoteStateAccepted
+	status.QuoteApprovedByURI = activityID.String()
+	if err := f.state.DB.UpdateStatus(
+		ctx,
+		status,
+		"quote_state",
+		"quote_approved_by_uri",
+	); err != nil {
+		err := gtserror.Newf("db error accepting quote: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Send the now-approved quote through to the
+	// fedi worker again to process side effects.
+	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ObjectQuote,
+		APActivityType: ap.ActivityAccept,
+		GTSModel:       status,
+		Receiving:      receivingAcct,
+		Requesting:     requestingAcct,
+	})
+
+	return nil
+}
+
 func (f *federatingDB) acceptLikeIRI(
 	ctx context.Context,
 	activityID string,
@@ -60,6 +60,24 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		)
 	}
 
+	// Announces delivered to the instance actor
+	// come from relays that it follows. Only take
+	// these from relays that accepted our Follow.
+	if receivingAcct.IsInstance() {
+		accepted, err := f.isAcceptedRelay(ctx, requestingAcct)
+		if err != nil {
+			return err
+		}
+
+		if !accepted {
+			log.Debugf(ctx,
+				"dropping announce from %s: not an accepted relay",
+				requestingAcct.URI,
+			)
+			return nil
+		}
+	}
+
 	boost, isNew, err := f.converter.ASAnnounceToStatus(ctx, announce)
 	if err != nil {
 		return gtserror.Newf("error converting announce to boost: %w", err)
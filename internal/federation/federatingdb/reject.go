@@ -58,6 +58,19 @@ func (f *federatingDB) Reject(ctx context.Context, reject vocab.ActivityStreamsR
 
 			// REJECT FOLLOW
 			case ap.ActivityFollow:
+				// Check first whether this is
+				// the Reject of a relay Follow.
+				if ok, err := f.relayFollowResponse(
+					ctx,
+					ap.GetJSONLDId(asType),
+					requestingAcct,
+					false,
+				); err != nil {
+					return err
+				} else if ok {
+					continue
+				}
+
 				if err := f.rejectFollowType(
 					ctx,
 					asType,
@@ -75,7 +88,22 @@ func (f *federatingDB) Reject(ctx context.Context, reject vocab.ActivityStreamsR
 		} else if object.IsIRI() {
 			// Check and handle any
 			// IRI type objects.
-			switch objIRI := object.GetIRI(); {
+			objIRI := object.GetIRI()
+
+			// Check first whether this is
+			// the Reject of a relay Follow.
+			if ok, err := f.relayFollowResponse(
+				ctx,
+				objIRI,
+				requestingAcct,
+				false,
+			); err != nil {
+				return err
+			} else if ok {
+				continue
+			}
+
+			switch {
 
 			// REJECT FOLLOW
 			case uris.IsFollowPath(objIRI):
@@ -271,6 +299,20 @@ func (f *federatingDB) rejectStatusIRI(
 		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
 	}
 
+	if status.IsQuote() &&
+		status.Quote != nil &&
+		status.Quote.AccountID == requestingAcct.ID &&
+		status.InReplyToAccountID != requestingAcct.ID {
+		// This is the quoted status author
+		// rejecting the quote of their status.
+		return f.rejectStoredQuote(
+			ctx,
+			status,
+			receivingAcct,
+			requestingAcct,
+		)
+	}
+
 	// Check if we're dealing with a reply
 	// or an announce, and make sure the
 	// requester is permitted to Reject.
@@ -379,6 +421,46 @@ func (f *federatingDB) rejectStatusIRI(
 	return nil
 }
 
+func (f *federatingDB) rejectStoredQuote(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+) error {
+	if status.QuoteState == gtsmodel.QuoteStateRejected {
+		// Already rejected,
+		// nothing to do.
+		return nil
+	}
+
+	// Mark the quote as rejected. The
+	// quoting status itself is kept, it
+	// just won't show the quoted status.
+	status.QuoteState = gtsmodel.QuoteStateRejected
+	status.QuoteApprovedByURI = ""
+	if err := f.state.DB.UpdateStatus(
+		ctx,
+		status,
+		"quote_state",
+		"quote_approved_by_uri",
+	); err != nil {
+		err := gtserror.Newf("db error rejecting quote: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Send the rejected quote through to the
+	// fedi worker to process side effects.
+	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ObjectQuote,
+		APActivityType: ap.ActivityReject,
+		GTSModel:       status,
+		Receiving:      receivingAcct,
+		Requesting:     requestingAcct,
+	})
+
+	return nil
+}
+
 func (f *federatingDB) rejectLikeIRI(
 	ctx context.Context,
 	activityID string,
@@ -0,0 +1,104 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// relayFollowResponse checks whether the given Follow IRI
+// is the URI of a Follow sent by the instance actor to a
+// relay, and if so, updates the relay state to accepted or
+// rejected. Returns true if the Follow was a relay Follow,
+// in which case there's nothing further to do with it.
+func (f *federatingDB) relayFollowResponse(
+	ctx context.Context,
+	followIRI *url.URL,
+	requestingAcct *gtsmodel.Account,
+	accepted bool,
+) (bool, error) {
+	if followIRI == nil ||
+		followIRI.Host != config.GetHost() {
+		// Can't be ours.
+		return false, nil
+	}
+
+	relay, err := f.state.DB.GetRelayByFollowURI(ctx, followIRI.String())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting relay: %w", err)
+		return false, gtserror.NewErrorInternalError(err)
+	}
+
+	if relay == nil {
+		// Not a relay Follow.
+		return false, nil
+	}
+
+	// Make sure the relay is the
+	// one responding to the Follow.
+	if requestingAcct.Domain != relay.Host() {
+		const text = "relay host and requesting account domain were not the same"
+		return true, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	relay.ActorURI = requestingAcct.URI
+	if accepted {
+		relay.State = gtsmodel.RelayStateAccepted
+		relay.Error = ""
+	} else {
+		relay.State = gtsmodel.RelayStateRejected
+		relay.Error = "relay rejected follow"
+	}
+
+	if err := f.state.DB.UpdateRelay(ctx,
+		relay,
+		"actor_uri",
+		"state",
+		"error",
+	); err != nil {
+		err := gtserror.Newf("db error updating relay: %w", err)
+		return true, gtserror.NewErrorInternalError(err)
+	}
+
+	log.Infof(ctx, "relay %s %s follow", relay.InboxURI, relay.State)
+	return true, nil
+}
+
+// isAcceptedRelay returns true if
+// the given account is the actor
+// of a relay that's accepted our
+// instance actor's Follow.
+func (f *federatingDB) isAcceptedRelay(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (bool, error) {
+	relay, err := f.state.DB.GetRelayByActorURI(ctx, account.URI)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error getting relay: %w", err)
+	}
+
+	return relay != nil && relay.Accepted(), nil
+}
@@ -0,0 +1,168 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RelayTestSuite struct {
+	FederatingDBTestSuite
+}
+
+// relayAccount returns a barebones
+// account for the test relay's actor.
+func (suite *RelayTestSuite) relayAccount() *gtsmodel.Account {
+	return &gtsmodel.Account{
+		ID:       "01JFEGAV3S3Y4F0J5W4PQ5D3QE",
+		Username: "relay",
+		Domain:   "relay.example.org",
+		URI:      "https://relay.example.org/actor",
+	}
+}
+
+func (suite *RelayTestSuite) TestAcceptRelayFollow() {
+	var (
+		relay          = testrig.NewTestRelays()["relay.example.org"]
+		receivingAcct  = suite.testAccounts["instance_account"]
+		requestingAcct = suite.relayAccount()
+		ctx            = createTestContext(receivingAcct, requestingAcct)
+	)
+
+	// Accept the relay Follow by IRI.
+	accept := streams.NewActivityStreamsAccept()
+	ap.SetJSONLDId(accept, testrig.URLMustParse("https://relay.example.org/activities/accept"))
+	ap.AppendActorIRIs(accept, testrig.URLMustParse(requestingAcct.URI))
+	ap.AppendObjectIRIs(accept, testrig.URLMustParse(relay.FollowURI))
+
+	if err := suite.federatingDB.Accept(ctx, accept); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Relay should now be accepted.
+	dbRelay, err := suite.state.DB.GetRelayByID(ctx, relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.RelayStateAccepted, dbRelay.State)
+	suite.Equal(requestingAcct.URI, dbRelay.ActorURI)
+}
+
+func (suite *RelayTestSuite) TestRejectRelayFollow() {
+	var (
+		relay          = testrig.NewTestRelays()["relay.example.org"]
+		receivingAcct  = suite.testAccounts["instance_account"]
+		requestingAcct = suite.relayAccount()
+		ctx            = createTestContext(receivingAcct, requestingAcct)
+	)
+
+	reject := streams.NewActivityStreamsReject()
+	ap.SetJSONLDId(reject, testrig.URLMustParse("https://relay.example.org/activities/reject"))
+	ap.AppendActorIRIs(reject, testrig.URLMustParse(requestingAcct.URI))
+	ap.AppendObjectIRIs(reject, testrig.URLMustParse(relay.FollowURI))
+
+	if err := suite.federatingDB.Reject(ctx, reject); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Relay should now be rejected.
+	dbRelay, err := suite.state.DB.GetRelayByID(ctx, relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.RelayStateRejected, dbRelay.State)
+	suite.NotEmpty(dbRelay.Error)
+}
+
+func (suite *RelayTestSuite) TestAcceptRelayFollowWrongHost() {
+	var (
+		relay          = testrig.NewTestRelays()["relay.example.org"]
+		receivingAcct  = suite.testAccounts["instance_account"]
+		requestingAcct = suite.testAccounts["remote_account_1"]
+		ctx            = createTestContext(receivingAcct, requestingAcct)
+	)
+
+	// Someone other than the
+	// relay tries to Accept.
+	accept := streams.NewActivityStreamsAccept()
+	ap.SetJSONLDId(accept, testrig.URLMustParse("http://example.org/activities/accept"))
+	ap.AppendActorIRIs(accept, testrig.URLMustParse(requestingAcct.URI))
+	ap.AppendObjectIRIs(accept, testrig.URLMustParse(relay.FollowURI))
+
+	err := suite.federatingDB.Accept(ctx, accept)
+	suite.Error(err)
+
+	// Relay should still be pending.
+	dbRelay, err := suite.state.DB.GetRelayByID(ctx, relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.RelayStatePending, dbRelay.State)
+}
+
+func (suite *RelayTestSuite) TestAnnounceFromRelay() {
+	var (
+		relay          = testrig.NewTestRelays()["relay.example.org"]
+		receivingAcct  = suite.testAccounts["instance_account"]
+		requestingAcct = suite.testAccounts["remote_account_1"]
+		ctx            = createTestContext(receivingAcct, requestingAcct)
+		announce       = suite.testActivities["announce_forwarded_1_zork"].Activity.(vocab.ActivityStreamsAnnounce)
+	)
+
+	// Announce to the instance actor from
+	// an account that isn't a relay is dropped.
+	if err := suite.federatingDB.Announce(ctx, announce); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, ok := suite.getFederatorMsg(time.Second)
+	suite.False(ok)
+
+	// Pretend the announcing account is
+	// the actor of an accepted relay.
+	relay.ActorURI = requestingAcct.URI
+	relay.State = gtsmodel.RelayStateAccepted
+	if err := suite.state.DB.UpdateRelay(context.Background(), relay); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The same Announce should now be taken.
+	if err := suite.federatingDB.Announce(ctx, announce); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	msg, ok := suite.getFederatorMsg(5 * time.Second)
+	if !ok {
+		suite.FailNow("no federator message")
+	}
+	suite.Equal(ap.ActivityAnnounce, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, &RelayTestSuite{})
+}
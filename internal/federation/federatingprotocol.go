@@ -241,6 +241,24 @@ func (f *Federator) AuthenticatePostInbox(ctx context.Context, w http.ResponseWr
 		return ctx, false, nil
 	}
 
+	// Now we know for sure who sent this delivery,
+	// count it against their domain's rate limit.
+	if limit := gtscontext.InboxLimitFunc(ctx); limit != nil {
+		ok, err := limit(pubKeyAuth.Owner.Domain)
+		if err != nil {
+			err = gtserror.Newf("error checking inbox rate limit: %w", err)
+			return ctx, false, err
+		}
+
+		if !ok {
+			// Obey the go-fed interface by
+			// writing the header and bailing.
+			w.WriteHeader(http.StatusTooManyRequests)
+			err := gtserror.Newf("inbox rate limit reached for %s", pubKeyAuth.Owner.Domain)
+			return ctx, false, gtserror.NewErrorTooManyRequests(err)
+		}
+	}
+
 	// We have everything we need now, set the requesting
 	// and receiving accounts on the context for later use.
 	ctx = gtscontext.SetRequestingAccount(ctx, pubKeyAuth.Owner)
@@ -308,6 +326,18 @@ func (f *Federator) Blocked(ctx context.Context, actorIRIs []*url.URL) (bool, er
 		return blocked, nil
 	}
 
+	// Receiver should not block requester's domain either.
+	blocked, err = f.db.IsAccountDomainBlocked(ctx, receivingAccount.ID, requestingAccount.Domain)
+	if err != nil {
+		err = gtserror.Newf("db error checking domain block between receiver and requester: %w", err)
+		return false, err
+	}
+
+	if blocked {
+		l.Trace("receiving account blocks requesting account domain")
+		return blocked, nil
+	}
+
 	// We've established that no blocks exist between directly
 	// involved actors, but what about IRIs of other actors and
 	// objects which are tangentially involved in the activity
@@ -266,6 +266,37 @@ func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInbox() {
 	suite.Equal(http.StatusOK, code)
 }
 
+func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxRateLimited() {
+	var (
+		activity         = suite.testActivities["dm_for_zork"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+		limitedDomains   []string
+	)
+
+	// Refuse all deliveries, noting
+	// which domain they were counted for.
+	ctx := gtscontext.SetInboxLimitFunc(
+		context.Background(),
+		func(domain string) (bool, error) {
+			limitedDomains = append(limitedDomains, domain)
+			return false, nil
+		},
+	)
+
+	ctx, authed, _, code := suite.authenticatePostInbox(
+		ctx,
+		receivingAccount,
+		activity,
+	)
+
+	// Delivery should have been counted against
+	// the domain of the authenticated requester.
+	suite.Equal([]string{"fossbros-anonymous.io"}, limitedDomains)
+	suite.Nil(gtscontext.RequestingAccount(ctx))
+	suite.False(authed)
+	suite.Equal(http.StatusTooManyRequests, code)
+}
+
 func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxKeyExpired() {
 	var (
 		ctx              = context.Background()
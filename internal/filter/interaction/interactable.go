@@ -336,6 +336,78 @@ func (f *Filter) StatusBoostable(
 	}
 }
 
+// StatusQuoteable checks if the given status
+// is quoteable by the requester account.
+//
+// Callers to this function should have already
+// checked the visibility of status to requester,
+// including taking account of blocks, as this
+// function does not do visibility checks, only
+// interaction policy checks.
+func (f *Filter) StatusQuoteable(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	status *gtsmodel.Status,
+) (*gtsmodel.PolicyCheckResult, error) {
+	if status.Visibility == gtsmodel.VisibilityDirect {
+		log.Trace(ctx, "direct statuses are not quoteable")
+		return &gtsmodel.PolicyCheckResult{
+			Permission: gtsmodel.PolicyPermissionForbidden,
+		}, nil
+	}
+
+	if requester.ID == status.AccountID {
+		// Status author themself can
+		// always quote non-directs,
+		// no need for further checks.
+		return &gtsmodel.PolicyCheckResult{
+			Permission:         gtsmodel.PolicyPermissionPermitted,
+			PermittedMatchedOn: util.Ptr(gtsmodel.PolicyValueAuthor),
+		}, nil
+	}
+
+	switch {
+	// If status has policy set, check against that,
+	// falling back to the default quote rules if
+	// the policy predates quotes being supported.
+	case status.InteractionPolicy != nil:
+		return f.checkPolicy(
+			ctx,
+			requester,
+			status,
+			status.InteractionPolicy.CanQuoteFor(status.Visibility),
+		)
+
+	// If status has no policy set but it's local,
+	// check against the default policy for this
+	// visibility, as we're interaction-policy aware.
+	case *status.Local:
+		policy := gtsmodel.DefaultInteractionPolicyFor(status.Visibility)
+		return f.checkPolicy(
+			ctx,
+			requester,
+			status,
+			policy.CanQuote,
+		)
+
+	// Status is from an instance that does not use
+	// or does not care about interaction policies.
+	// We can quote it if it's unlisted or public.
+	case status.Visibility == gtsmodel.VisibilityPublic ||
+		status.Visibility == gtsmodel.VisibilityUnlocked:
+		return &gtsmodel.PolicyCheckResult{
+			Permission: gtsmodel.PolicyPermissionPermitted,
+		}, nil
+
+	// Not permitted by any of the
+	// above checks, so it's forbidden.
+	default:
+		return &gtsmodel.PolicyCheckResult{
+			Permission: gtsmodel.PolicyPermissionForbidden,
+		}, nil
+	}
+}
+
 func (f *Filter) checkPolicy(
 	ctx context.Context,
 	requester *gtsmodel.Account,
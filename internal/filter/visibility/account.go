@@ -27,7 +27,7 @@
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
-// AccountVisible will check if given account is visible to requester, accounting for requester with no auth (i.e is nil), suspensions, disabled local users and account blocks.
+// AccountVisible will check if given account is visible to requester, accounting for requester with no auth (i.e is nil), suspensions, disabled local users, account blocks and account domain blocks.
 func (f *Filter) AccountVisible(ctx context.Context, requester *gtsmodel.Account, account *gtsmodel.Account) (bool, error) {
 	const vtype = cache.VisibilityTypeAccount
 
@@ -105,9 +105,42 @@ func (f *Filter) isAccountVisibleTo(ctx context.Context, requester *gtsmodel.Acc
 		return false, nil
 	}
 
+	// Check whether either blocks the other's domain.
+	blocked, err = f.isEitherDomainBlocked(ctx,
+		requester,
+		account,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking account domain blocks: %w", err)
+	}
+
+	if blocked {
+		log.Trace(ctx, "domain block exists between accounts")
+		return false, nil
+	}
+
 	return true, nil
 }
 
+// isEitherDomainBlocked will check whether either account has
+// a user-level domain block in place against the other's domain.
+func (f *Filter) isEitherDomainBlocked(ctx context.Context, account1 *gtsmodel.Account, account2 *gtsmodel.Account) (bool, error) {
+	if account1.Domain == account2.Domain {
+		// Accounts on the same domain
+		// are unaffected by domain blocks.
+		return false, nil
+	}
+
+	// Look for a domain block in direction of account1->account2.
+	blocked, err := f.state.DB.IsAccountDomainBlocked(ctx, account1.ID, account2.Domain)
+	if err != nil || blocked {
+		return blocked, err
+	}
+
+	// Look for a domain block in direction of account2->account1.
+	return f.state.DB.IsAccountDomainBlocked(ctx, account2.ID, account1.Domain)
+}
+
 // isAccountVisible will check if given account should be visible at all, e.g. it may not be if suspended or disabled.
 func (f *Filter) isAccountVisible(ctx context.Context, account *gtsmodel.Account) (bool, error) {
 	if account.IsLocal() {
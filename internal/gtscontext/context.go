@@ -44,6 +44,7 @@
 	httpSigPubKeyIDKey
 	dryRunKey
 	httpClientSignFnKey
+	inboxLimitFnKey
 )
 
 // DryRun returns whether the "dryrun" context key has been set. This can be
@@ -142,6 +143,21 @@ func SetHTTPClientSignFunc(ctx context.Context, fn func(*http.Request) error) co
 	return context.WithValue(ctx, httpClientSignFnKey, fn)
 }
 
+// InboxLimitFunc returns the inbox rate limit function for the current
+// ActivityPub request chain. Once a delivery has been authenticated, this
+// can be called with the domain of the requesting account to count the
+// delivery, returning false if that domain has exceeded its rate limit.
+func InboxLimitFunc(ctx context.Context) func(domain string) (bool, error) {
+	fn, _ := ctx.Value(inboxLimitFnKey).(func(string) (bool, error))
+	return fn
+}
+
+// SetInboxLimitFunc stores the given inbox rate limit function and returns the wrapped
+// context. See InboxLimitFunc() for further information on the rate limit function value.
+func SetInboxLimitFunc(ctx context.Context, fn func(domain string) (bool, error)) context.Context {
+	return context.WithValue(ctx, inboxLimitFnKey, fn)
+}
+
 // HTTPSignatureVerifier returns an http signature verifier for the current ActivityPub
 // request chain. This verifier can be called to authenticate the current request.
 func HTTPSignatureVerifier(ctx context.Context) httpsig.VerifierWithOptions {
@@ -0,0 +1,73 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AccountArchive represents one archive of
+// a local account's data, ie., its profile,
+// statuses, likes, bookmarks and media, built
+// on request and stored as a zip file.
+type AccountArchive struct {
+	ID        string              `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID string              `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the local account that requested the archive
+	Account   *Account            `bun:"-"`                                                           // account corresponding to AccountID
+	State     AccountArchiveState `bun:",nullzero,notnull,default:1"`                                 // state of building the archive
+	Path      string              `bun:",nullzero"`                                                   // path of the zip file in storage, once ready
+	Size      int64               `bun:",notnull,default:0"`                                          // size of the zip file in bytes, once ready
+	ExpiresAt time.Time           `bun:"type:timestamptz,nullzero"`                                   // when the archive expires, once ready or failed
+}
+
+// Ready returns true if the archive
+// has been built and can be downloaded.
+func (a *AccountArchive) Ready() bool {
+	return a.State == AccountArchiveStateReady
+}
+
+// Expired returns true if the archive has
+// expired, and should be removed. Archives
+// only expire once they're ready or failed.
+func (a *AccountArchive) Expired(now time.Time) bool {
+	return !a.ExpiresAt.IsZero() && !a.ExpiresAt.After(now)
+}
+
+// AccountArchiveState describes the
+// state of building an account archive.
+type AccountArchiveState uint8
+
+const (
+	AccountArchiveStateUnknown    AccountArchiveState = iota
+	AccountArchiveStateProcessing                     // Archive is being built.
+	AccountArchiveStateReady                          // Archive can be downloaded.
+	AccountArchiveStateFailed                         // Archive could not be built.
+)
+
+func (s AccountArchiveState) String() string {
+	switch s {
+	case AccountArchiveStateProcessing:
+		return "processing"
+	case AccountArchiveStateReady:
+		return "ready"
+	case AccountArchiveStateFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
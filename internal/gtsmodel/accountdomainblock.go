@@ -0,0 +1,30 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AccountDomainBlock represents one account blocking
+// an entire domain, hiding all accounts on that domain
+// (and its subdomains) from the blocking account.
+type AccountDomainBlock struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                           // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                        // when was item created
+	AccountID string    `bun:"type:CHAR(26),unique:account_domain_blocks_account_id_domain_uniq,notnull,nullzero"` // ID of the account that owns this block.
+	Domain    string    `bun:",nullzero,notnull,unique:account_domain_blocks_account_id_domain_uniq"`              // Domain that is blocked, in punycode.
+}
@@ -0,0 +1,73 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Announcement represents an admin announcement,
+// which is shown to all users of the instance.
+type Announcement struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Text               string    `bun:""`                                                            // Original markdown text of the announcement, as submitted by the admin.
+	Content            string    `bun:""`                                                            // HTML content of the announcement, parsed from Text.
+	EmojiIDs           []string  `bun:"emojis,array"`                                                // Database IDs of any emojis used in this announcement.
+	Emojis             []*Emoji  `bun:"-"`                                                           // Emojis corresponding to EmojiIDs.
+	StartsAt           time.Time `bun:"type:timestamptz,nullzero"`                                   // When the event described by the announcement starts, if any.
+	EndsAt             time.Time `bun:"type:timestamptz,nullzero"`                                   // When the event described by the announcement ends, if any. Announcements stop being shown after this time.
+	AllDay             *bool     `bun:",nullzero,notnull,default:false"`                             // StartsAt and EndsAt should be treated as dates rather than times.
+	PublishedAt        time.Time `bun:"type:timestamptz,nullzero"`                                   // When the announcement was published. Unset for unpublished (draft) announcements.
+	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the admin who created this announcement.
+}
+
+// Published returns true if the
+// announcement has been published.
+func (a *Announcement) Published() bool {
+	return !a.PublishedAt.IsZero()
+}
+
+// Active returns true if the announcement
+// is published, has started, and has not yet
+// ended, ie., if it should be shown to users at now.
+func (a *Announcement) Active(now time.Time) bool {
+	return a.Published() &&
+		!a.StartsAt.After(now) &&
+		(a.EndsAt.IsZero() || a.EndsAt.After(now))
+}
+
+// AnnouncementDismissal represents one account
+// dismissing (ie., marking as read) an announcement.
+type AnnouncementDismissal struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                      // id of this item in the database
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                   // when was item created
+	AnnouncementID string    `bun:"type:CHAR(26),unique:announcement_dismissals_announcement_id_account_id_uniq,notnull,nullzero"` // ID of the dismissed announcement.
+	AccountID      string    `bun:"type:CHAR(26),unique:announcement_dismissals_announcement_id_account_id_uniq,notnull,nullzero"` // ID of the local account that dismissed the announcement.
+}
+
+// AnnouncementReaction represents one account
+// reacting to an announcement with an emoji.
+type AnnouncementReaction struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                          // id of this item in the database
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                       // when was item created
+	AnnouncementID string    `bun:"type:CHAR(26),unique:announcement_reactions_announcement_id_account_id_name_uniq,notnull,nullzero"` // ID of the announcement reacted to.
+	AccountID      string    `bun:"type:CHAR(26),unique:announcement_reactions_announcement_id_account_id_name_uniq,notnull,nullzero"` // ID of the local account that reacted.
+	Name           string    `bun:",unique:announcement_reactions_announcement_id_account_id_name_uniq,notnull,nullzero"`              // Unicode emoji, or shortcode of a local custom emoji.
+	EmojiID        string    `bun:"type:CHAR(26),nullzero"`                                                                            // ID of the custom emoji corresponding to Name, if any.
+	Emoji          *Emoji    `bun:"-"`                                                                                                 // Emoji corresponding to EmojiID.
+}
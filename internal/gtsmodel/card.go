@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Card represents a preview card of a link found in the content
+// of one or more statuses, generated from OpenGraph and / or oEmbed
+// metadata of the linked web page. Cards are shared between all
+// statuses linking to the same URL.
+type Card struct {
+	ID           string           `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt    time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt    time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	FetchedAt    time.Time        `bun:"type:timestamptz,nullzero"`                                   // when was the linked page last fetched
+	URL          string           `bun:",nullzero,notnull,unique"`                                    // link that this card is a preview of
+	Type         CardType         `bun:",notnull"`                                                    // type of this card
+	Title        string           `bun:",nullzero"`                                                   // title of linked page
+	Description  string           `bun:",nullzero"`                                                   // description of linked page
+	AuthorName   string           `bun:",nullzero"`                                                   // author of linked page
+	AuthorURL    string           `bun:",nullzero"`                                                   // link to author of linked page
+	ProviderName string           `bun:",nullzero"`                                                   // provider (site name) of linked page
+	ProviderURL  string           `bun:",nullzero"`                                                   // link to provider of linked page
+	HTML         string           `bun:"html,nullzero"`                                               // sanitized embed html (iframe) for video / rich cards
+	Width        int              `bun:",nullzero"`                                                   // width of embed or image, in pixels
+	Height       int              `bun:",nullzero"`                                                   // height of embed or image, in pixels
+	EmbedURL     string           `bun:",nullzero"`                                                   // link to full size photo, for photo cards
+	Language     string           `bun:",nullzero"`                                                   // language of linked page
+	ImageID      string           `bun:"type:CHAR(26),nullzero"`                                      // id of the media attachment containing preview thumbnail
+	Image        *MediaAttachment `bun:"-"`                                                           // media attachment corresponding to imageID
+}
+
+// CardType represents the type of a preview card,
+// as reported by oEmbed, or else assumed to be a link.
+type CardType uint8
+
+const (
+	CardTypeLink  CardType = iota // A plain link to a web page.
+	CardTypePhoto                 // A photo, with embed url to the full image.
+	CardTypeVideo                 // A video, with an embed html iframe.
+	CardTypeRich                  // Some other rich content, with an embed html iframe.
+)
+
+func (t CardType) String() string {
+	switch t {
+	case CardTypePhoto:
+		return "photo"
+	case CardTypeVideo:
+		return "video"
+	case CardTypeRich:
+		return "rich"
+	default:
+		return "link"
+	}
+}
+
+func NewCardType(in string) CardType {
+	switch in {
+	case "photo":
+		return CardTypePhoto
+	case "video":
+		return CardTypeVideo
+	case "rich":
+		return CardTypeRich
+	default:
+		return CardTypeLink
+	}
+}
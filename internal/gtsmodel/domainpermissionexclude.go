@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// DomainPermissionExclude represents one domain that should be
+// excluded when processing domain permission imports and
+// subscriptions, ie., a domain that should never be blocked
+// or allowed through a list of domain permissions.
+//
+// Excluding a domain also excludes all its subdomains.
+type DomainPermissionExclude struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Domain             string    `bun:",nullzero,notnull,unique"`                                    // domain to exclude. Eg. 'whatever.com'
+	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this exclude
+	CreatedByAccount   *Account  `bun:"-"`                                                           // Account corresponding to createdByAccountID
+	PrivateComment     string    `bun:",nullzero"`                                                   // Private comment on this exclude, viewable to admins
+}
+
+func (d *DomainPermissionExclude) GetID() string {
+	return d.ID
+}
+
+func (d *DomainPermissionExclude) GetCreatedAt() time.Time {
+	return d.CreatedAt
+}
+
+func (d *DomainPermissionExclude) GetUpdatedAt() time.Time {
+	return d.UpdatedAt
+}
+
+func (d *DomainPermissionExclude) GetDomain() string {
+	return d.Domain
+}
+
+func (d *DomainPermissionExclude) GetCreatedByAccountID() string {
+	return d.CreatedByAccountID
+}
+
+func (d *DomainPermissionExclude) GetCreatedByAccount() *Account {
+	return d.CreatedByAccount
+}
+
+func (d *DomainPermissionExclude) GetPrivateComment() string {
+	return d.PrivateComment
+}
+
+// GetPublicComment always returns an empty
+// string, as excludes are never shown publicly.
+func (d *DomainPermissionExclude) GetPublicComment() string {
+	return ""
+}
+
+// GetObfuscate always returns false,
+// as excludes are never shown publicly.
+func (d *DomainPermissionExclude) GetObfuscate() *bool {
+	return new(bool)
+}
+
+// GetSubscriptionID always returns an empty string,
+// as excludes are never created by subscriptions.
+func (d *DomainPermissionExclude) GetSubscriptionID() string {
+	return ""
+}
+
+// GetType always returns DomainPermissionUnknown,
+// as excludes apply to both blocks and allows.
+func (d *DomainPermissionExclude) GetType() DomainPermissionType {
+	return DomainPermissionUnknown
+}
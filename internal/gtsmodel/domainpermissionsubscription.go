@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// DomainPermissionSubscription represents a remote list of domain
+// permissions (blocks or allows) that this instance subscribes to.
+// The list is fetched periodically, and domain permission entries
+// are created, updated, or retracted to match its contents.
+type DomainPermissionSubscription struct {
+	ID                    string                   `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt             time.Time                `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt             time.Time                `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Priority              uint8                    `bun:""`                                                            // priority of this subscription compared to others of the same permission type, higher wins
+	Title                 string                   `bun:",nullzero,unique"`                                            // moderator-set title for this subscription
+	PermissionType        DomainPermissionType     `bun:",notnull"`                                                    // permission type of entries created by this subscription
+	AsDraft               *bool                    `bun:",nullzero,notnull,default:true"`                              // create entries as drafts rather than enacting them directly
+	AdoptOrphans          *bool                    `bun:",nullzero,notnull,default:false"`                             // take ownership of existing entries of the same type that have no subscription
+	RemoveRetracted       *bool                    `bun:",nullzero,notnull,default:true"`                              // remove owned entries when they're retracted from the list, rather than orphaning them
+	CreatedByAccountID    string                   `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this subscription
+	CreatedByAccount      *Account                 `bun:"-"`                                                           // Account corresponding to createdByAccountID
+	URI                   string                   `bun:",nullzero,notnull,unique"`                                    // URI of the domain permission list
+	ContentType           DomainPermSubContentType `bun:",notnull"`                                                    // content type to expect from the URI
+	FetchUsername         string                   `bun:",nullzero"`                                                   // username to send when doing a GET of URI using basic auth
+	FetchPassword         string                   `bun:",nullzero"`                                                   // password to send when doing a GET of URI using basic auth
+	FetchedAt             time.Time                `bun:"type:timestamptz,nullzero"`                                   // time when fetch of URI was last attempted
+	SuccessfullyFetchedAt time.Time                `bun:"type:timestamptz,nullzero"`                                   // time when the domain permission list was last successfully fetched
+	LastModified          time.Time                `bun:"type:timestamptz,nullzero"`                                   // Last-Modified time of the list, as reported by the remote server
+	ETag                  string                   `bun:"etag,nullzero"`                                               // ETag of the list, as reported by the remote server
+	Error                 string                   `bun:",nullzero"`                                                   // if the last fetch or parse of the list failed, what was the error?
+}
+
+// DomainPermSubContentType represents the
+// content type of a domain permission list.
+type DomainPermSubContentType uint8
+
+const (
+	DomainPermSubContentTypeUnknown DomainPermSubContentType = iota
+	DomainPermSubContentTypeCSV                              // CSV domain permissions, as exported by Mastodon.
+	DomainPermSubContentTypeJSON                             // JSON array of domain permissions, as exported by GoToSocial.
+	DomainPermSubContentTypePlain                            // Plaintext list of domains, one per line.
+)
+
+func (p DomainPermSubContentType) String() string {
+	switch p {
+	case DomainPermSubContentTypeCSV:
+		return "text/csv"
+	case DomainPermSubContentTypeJSON:
+		return "application/json"
+	case DomainPermSubContentTypePlain:
+		return "text/plain"
+	default:
+		return "unknown"
+	}
+}
+
+func NewDomainPermSubContentType(in string) DomainPermSubContentType {
+	switch in {
+	case "text/csv":
+		return DomainPermSubContentTypeCSV
+	case "application/json":
+		return DomainPermSubContentTypeJSON
+	case "text/plain":
+		return DomainPermSubContentTypePlain
+	default:
+		return DomainPermSubContentTypeUnknown
+	}
+}
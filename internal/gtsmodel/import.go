@@ -0,0 +1,125 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Import represents one upload of CSV data by a local
+// account, eg., a following list exported from Mastodon,
+// along with the progress of processing its rows.
+type Import struct {
+	ID             string      `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt      time.Time   `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt      time.Time   `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID      string      `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the local account that uploaded the data
+	Account        *Account    `bun:"-"`                                                           // account corresponding to AccountID
+	Type           ImportType  `bun:",nullzero,notnull"`                                           // type of entries contained in the data
+	Overwrite      *bool       `bun:",nullzero,notnull,default:false"`                             // replace existing entries rather than merging with them
+	State          ImportState `bun:",nullzero,notnull,default:1"`                                 // state of processing the import
+	TotalItems     int         `bun:",notnull,default:0"`                                          // number of rows parsed from the data
+	ProcessedItems int         `bun:",notnull,default:0"`                                          // number of rows processed so far, whether successful or not
+	FailedItems    int         `bun:",notnull,default:0"`                                          // number of rows that could not be imported
+	Errors         []string    `bun:"errors,array"`                                                // errors encountered processing rows, capped in length
+}
+
+// Finished returns true if all
+// rows of the import are processed.
+func (i *Import) Finished() bool {
+	return i.State == ImportStateFinished
+}
+
+// ImportType describes the type
+// of entries contained in an import.
+type ImportType uint8
+
+const (
+	ImportTypeUnknown      ImportType = iota
+	ImportTypeFollowing               // Accounts to follow.
+	ImportTypeBlocks                  // Accounts to block.
+	ImportTypeDomainBlocks            // Domains to block.
+	ImportTypeLists                   // Lists of followed accounts.
+	ImportTypeMutes                   // Accounts to mute.
+	ImportTypeBookmarks               // Statuses to bookmark.
+	ImportTypeStatuses                // Statuses to recreate from an archive.
+)
+
+func (t ImportType) String() string {
+	switch t {
+	case ImportTypeFollowing:
+		return "following"
+	case ImportTypeBlocks:
+		return "blocks"
+	case ImportTypeDomainBlocks:
+		return "domain_blocks"
+	case ImportTypeLists:
+		return "lists"
+	case ImportTypeMutes:
+		return "mutes"
+	case ImportTypeBookmarks:
+		return "bookmarks"
+	case ImportTypeStatuses:
+		return "statuses"
+	default:
+		return "unknown"
+	}
+}
+
+func NewImportType(in string) ImportType {
+	switch in {
+	case "following":
+		return ImportTypeFollowing
+	case "blocks":
+		return ImportTypeBlocks
+	case "domain_blocks":
+		return ImportTypeDomainBlocks
+	case "lists":
+		return ImportTypeLists
+	case "mutes":
+		return ImportTypeMutes
+	case "bookmarks":
+		return ImportTypeBookmarks
+	case "statuses":
+		return ImportTypeStatuses
+	default:
+		return ImportTypeUnknown
+	}
+}
+
+// ImportState describes the
+// state of processing an import.
+type ImportState uint8
+
+const (
+	ImportStateUnknown    ImportState = iota
+	ImportStateProcessing             // Rows are being processed.
+	ImportStateFinished               // All rows have been processed.
+	ImportStateFailed                 // Processing was interrupted.
+)
+
+func (s ImportState) String() string {
+	switch s {
+	case ImportStateProcessing:
+		return "processing"
+	case ImportStateFinished:
+		return "finished"
+	case ImportStateFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
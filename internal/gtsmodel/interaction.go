@@ -19,7 +19,7 @@
 
 import "time"
 
-// Like / Reply / Announce
+// Like / Reply / Announce / Quote
 type InteractionType int
 
 const (
@@ -32,6 +32,7 @@
 	InteractionLike InteractionType = iota
 	InteractionReply
 	InteractionAnnounce
+	InteractionQuote
 )
 
 // Stringifies this InteractionType in a
@@ -47,12 +48,15 @@ func (i InteractionType) String() string {
 	case InteractionAnnounce:
 		const text = "reblog"
 		return text
+	case InteractionQuote:
+		const text = "quote"
+		return text
 	default:
 		panic("undefined InteractionType")
 	}
 }
 
-// InteractionRequest represents one interaction (like, reply, fave)
+// InteractionRequest represents one interaction (like, reply, fave, quote)
 // that is either accepted, rejected, or currently still awaiting
 // acceptance or rejection by the target account.
 type InteractionRequest struct {
@@ -64,11 +68,12 @@ type InteractionRequest struct {
 	TargetAccount        *Account        `bun:"-"`                                                           // Not stored in DB. Account being interacted with.
 	InteractingAccountID string          `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the account requesting the interaction.
 	InteractingAccount   *Account        `bun:"-"`                                                           // Not stored in DB. Account corresponding to targetAccountID
-	InteractionURI       string          `bun:",nullzero,notnull,unique"`                                    // URI of the interacting like, reply, or announce. Unique (only one interaction request allowed per interaction URI).
-	InteractionType      InteractionType `bun:",notnull"`                                                    // One of Like, Reply, or Announce.
+	InteractionURI       string          `bun:",nullzero,notnull,unique"`                                    // URI of the interacting like, reply, announce, or quoting status. Unique (only one interaction request allowed per interaction URI).
+	InteractionType      InteractionType `bun:",notnull"`                                                    // One of Like, Reply, Announce, or Quote.
 	Like                 *StatusFave     `bun:"-"`                                                           // Not stored in DB. Only set if InteractionType = InteractionLike.
 	Reply                *Status         `bun:"-"`                                                           // Not stored in DB. Only set if InteractionType = InteractionReply.
 	Announce             *Status         `bun:"-"`                                                           // Not stored in DB. Only set if InteractionType = InteractionAnnounce.
+	Quote                *Status         `bun:"-"`                                                           // Not stored in DB. Only set if InteractionType = InteractionQuote.
 	AcceptedAt           time.Time       `bun:"type:timestamptz,nullzero"`                                   // If interaction request was accepted, time at which this occurred.
 	RejectedAt           time.Time       `bun:"type:timestamptz,nullzero"`                                   // If interaction request was rejected, time at which this occurred.
 
@@ -195,6 +195,26 @@ type InteractionPolicy struct {
 	// interaction will be accepted
 	// for an item with this policy.
 	CanAnnounce PolicyRules
+	// Conditions in which a Quote
+	// interaction will be accepted
+	// for an item with this policy.
+	//
+	// Policies created before quotes
+	// were supported won't have this
+	// set; use CanQuoteFor() to fall
+	// back to the default rules.
+	CanQuote PolicyRules
+}
+
+// CanQuoteFor returns the CanQuote rules of the
+// policy, or the default rules for an item with
+// the given visibility if CanQuote was never set.
+func (p *InteractionPolicy) CanQuoteFor(v Visibility) PolicyRules {
+	if p.CanQuote.Always == nil &&
+		p.CanQuote.WithApproval == nil {
+		return DefaultInteractionPolicyFor(v).CanQuote
+	}
+	return p.CanQuote
 }
 
 // PolicyRules represents the rules according
@@ -250,6 +270,13 @@ func DefaultInteractionPolicyFor(v Visibility) *InteractionPolicy {
 		},
 		WithApproval: make(PolicyValues, 0),
 	},
+	CanQuote: PolicyRules{
+		// Anyone can quote.
+		Always: PolicyValues{
+			PolicyValuePublic,
+		},
+		WithApproval: make(PolicyValues, 0),
+	},
 }
 
 // Returns the default interaction policy
@@ -293,6 +320,13 @@ func DefaultInteractionPolicyUnlocked() *InteractionPolicy {
 		},
 		WithApproval: make(PolicyValues, 0),
 	},
+	CanQuote: PolicyRules{
+		// Only self can quote.
+		Always: PolicyValues{
+			PolicyValueAuthor,
+		},
+		WithApproval: make(PolicyValues, 0),
+	},
 }
 
 // Returns the default interaction policy for
@@ -327,6 +361,13 @@ func DefaultInteractionPolicyFollowersOnly() *InteractionPolicy {
 		},
 		WithApproval: make(PolicyValues, 0),
 	},
+	CanQuote: PolicyRules{
+		// Only self can quote.
+		Always: PolicyValues{
+			PolicyValueAuthor,
+		},
+		WithApproval: make(PolicyValues, 0),
+	},
 }
 
 // Returns the default interaction policy
@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Invite represents an invite link created by a local
+// account, with which people can sign up to this instance
+// even when registration is otherwise closed.
+type Invite struct {
+	ID          string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Code        string    `bun:",nullzero,notnull,unique"`                                    // code to give on sign-up to use this invite
+	AccountID   string    `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the local account that created the invite
+	Account     *Account  `bun:"-"`                                                           // account corresponding to AccountID
+	MaxUses     int       `bun:",nullzero"`                                                   // number of sign-ups allowed with this invite, 0 for unlimited
+	Uses        int       `bun:",notnull,default:0"`                                          // number of sign-ups made with this invite so far
+	ExpiresAt   time.Time `bun:"type:timestamptz,nullzero"`                                   // time after which the invite can't be used, zero for never
+	AutoApprove *bool     `bun:",nullzero,notnull,default:false"`                             // sign-ups made with this invite don't need approval
+}
+
+// Expired returns true if the invite
+// has passed its expiry time, if any.
+func (i *Invite) Expired() bool {
+	return !i.ExpiresAt.IsZero() && time.Now().After(i.ExpiresAt)
+}
+
+// UsedUp returns true if the invite has been
+// used for its maximum number of sign-ups, if any.
+func (i *Invite) UsedUp() bool {
+	return i.MaxUses > 0 && i.Uses >= i.MaxUses
+}
+
+// Usable returns true if the invite can
+// still be used to sign up a new account.
+func (i *Invite) Usable() bool {
+	return !i.Expired() && !i.UsedUp()
+}
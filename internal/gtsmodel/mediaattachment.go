@@ -35,6 +35,7 @@ type MediaAttachment struct {
 	AccountID         string           `bun:"type:CHAR(26),nullzero,notnull"`                              // To which account does this attachment belong
 	Description       string           `bun:""`                                                            // Description of the attachment (for screenreaders)
 	ScheduledStatusID string           `bun:"type:CHAR(26),nullzero"`                                      // To which scheduled status does this attachment belong
+	CardID            string           `bun:"type:CHAR(26),nullzero"`                                      // Of which preview card is this attachment the image
 	Blurhash          string           `bun:",nullzero"`                                                   // What is the generated blurhash of this attachment
 	Processing        ProcessingStatus `bun:",notnull,default:2"`                                          // What is the processing status of this attachment
 	File              File             `bun:",embed:file_,notnull,nullzero"`                               // metadata for the whole file
@@ -32,6 +32,7 @@ type Notification struct {
 	StatusID         string           `bun:"type:CHAR(26),nullzero"`                                      // If the notification pertains to a status, what is the database ID of that status?
 	Status           *Status          `bun:"-"`                                                           // Status corresponding to StatusID. Can be nil, always check first + select using ID if necessary.
 	Read             *bool            `bun:",nullzero,notnull,default:false"`                             // Notification has been seen/read
+	Filtered         *bool            `bun:",nullzero,notnull,default:false"`                             // Notification was filtered by the target's notification policy, and is only shown in notification requests
 }
 
 // NotificationType describes the reason/type of this notification.
@@ -50,4 +51,6 @@ type Notification struct {
 	NotificationPendingFave   NotificationType = "pending.favourite" // Someone has faved a status of yours, which requires approval by you.
 	NotificationPendingReply  NotificationType = "pending.reply"     // Someone has replied to a status of yours, which requires approval by you.
 	NotificationPendingReblog NotificationType = "pending.reblog"    // Someone has boosted a status of yours, which requires approval by you.
+	NotificationQuote         NotificationType = "quote"             // Someone has quoted a status of yours.
+	NotificationPendingQuote  NotificationType = "pending.quote"     // Someone has quoted a status of yours, which requires approval by you.
 )
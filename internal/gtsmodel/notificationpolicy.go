@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// NotificationPolicy models the settings of a local account
+// for which notifications should be accepted, filtered into
+// notification requests, or dropped, based on who they're from.
+type NotificationPolicy struct {
+	ID                 string                  `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time               `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time               `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID          string                  `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // id of the local account this policy belongs to
+	ForNotFollowing    NotificationPolicyValue `bun:",nullzero,notnull,default:'accept'"`                          // what to do with notifications from accounts the account doesn't follow
+	ForNotFollowers    NotificationPolicyValue `bun:",nullzero,notnull,default:'accept'"`                          // what to do with notifications from accounts that don't follow the account
+	ForNewAccounts     NotificationPolicyValue `bun:",nullzero,notnull,default:'accept'"`                          // what to do with notifications from accounts created in the last NewAccountAge
+	ForPrivateMentions NotificationPolicyValue `bun:",nullzero,notnull,default:'filter'"`                          // what to do with unsolicited private mentions from accounts the account doesn't follow
+	ForLimitedAccounts NotificationPolicyValue `bun:",nullzero,notnull,default:'filter'"`                          // what to do with notifications from accounts silenced by moderators
+}
+
+// NotificationPolicyValue describes what
+// to do with notifications matching a policy.
+type NotificationPolicyValue string
+
+// Notification policy values
+const (
+	NotificationPolicyAccept NotificationPolicyValue = "accept" // Notify as normal.
+	NotificationPolicyFilter NotificationPolicyValue = "filter" // Store notification as filtered, and create or update a notification request.
+	NotificationPolicyDrop   NotificationPolicyValue = "drop"   // Don't store the notification at all.
+)
+
+// NotificationPolicyNewAccountAge is the age under
+// which an account counts as a new account for the
+// purposes of the ForNewAccounts notification policy.
+const NotificationPolicyNewAccountAge = 30 * 24 * time.Hour
+
+// ParseNotificationPolicyValue returns the notification
+// policy value corresponding to the given string, if valid.
+func ParseNotificationPolicyValue(str string) (NotificationPolicyValue, bool) {
+	switch v := NotificationPolicyValue(str); v {
+	case NotificationPolicyAccept,
+		NotificationPolicyFilter,
+		NotificationPolicyDrop:
+		return v, true
+	default:
+		return "", false
+	}
+}
+
+// DefaultNotificationPolicy returns the notification
+// policy used for accounts that haven't set one, ie.,
+// filter unsolicited private mentions and notifications
+// from limited accounts, and accept everything else.
+func DefaultNotificationPolicy(accountID string) *NotificationPolicy {
+	return &NotificationPolicy{
+		AccountID:          accountID,
+		ForNotFollowing:    NotificationPolicyAccept,
+		ForNotFollowers:    NotificationPolicyAccept,
+		ForNewAccounts:     NotificationPolicyAccept,
+		ForPrivateMentions: NotificationPolicyFilter,
+		ForLimitedAccounts: NotificationPolicyFilter,
+	}
+}
+
+// NotificationRequest groups the filtered notifications
+// that an account has received from one other account,
+// so that they can be accepted or dismissed together.
+type NotificationRequest struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                 // when was item created
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                 // when was item last updated
+	AccountID          string    `bun:"type:CHAR(26),unique:notification_requests_account_id_from_account_id_uniq,nullzero,notnull"` // id of the local account the filtered notifications target
+	Account            *Account  `bun:"-"`                                                                                           // Not stored in DB. Account corresponding to AccountID.
+	FromAccountID      string    `bun:"type:CHAR(26),unique:notification_requests_account_id_from_account_id_uniq,nullzero,notnull"` // id of the account the filtered notifications originate from
+	FromAccount        *Account  `bun:"-"`                                                                                           // Not stored in DB. Account corresponding to FromAccountID.
+	LastStatusID       string    `bun:"type:CHAR(26),nullzero"`                                                                      // id of the status of the most recent filtered notification that had one
+	LastStatus         *Status   `bun:"-"`                                                                                           // Not stored in DB. Status corresponding to LastStatusID.
+	NotificationsCount int       `bun:",notnull,default:0"`                                                                          // number of filtered notifications in this request
+	AcceptedAt         time.Time `bun:"type:timestamptz,nullzero"`                                                                   // If request was accepted, time at which this occurred. Later notifications from FromAccount won't be filtered.
+}
+
+// IsAccepted returns true if this notification
+// request has been accepted by the account.
+func (r *NotificationRequest) IsAccepted() bool {
+	return !r.AcceptedAt.IsZero()
+}
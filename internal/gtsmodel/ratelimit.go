@@ -0,0 +1,31 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+// RateLimit represents the number of hits made against
+// one rate limit key (eg., an IP address or account, in
+// some bucket) within the current rate limit period.
+//
+// ResetAt is stored as unix milliseconds rather than a
+// timestamp, so that it can be compared when upserting
+// in the same way on both SQLite and Postgres.
+type RateLimit struct {
+	Key     string `bun:",pk,nullzero,notnull,unique"` // bucket-prefixed key being rate limited
+	Hits    int64  `bun:",notnull,default:0"`          // number of hits against this key in the current period
+	ResetAt int64  `bun:",notnull"`                    // unix milliseconds at which the current period ends
+}
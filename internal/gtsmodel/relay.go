@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"net/url"
+	"time"
+)
+
+// Relay represents one LitePub or Mastodon-style
+// relay that the instance actor follows in order
+// to exchange public statuses with other instances.
+type Relay struct {
+	ID                 string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	InboxURI           string     `bun:",nullzero,notnull,unique"`                                    // Inbox of the relay, to which statuses and the Follow will be delivered.
+	ActorURI           string     `bun:",nullzero"`                                                   // ActivityPub URI of the relay's actor, set once the relay has accepted our Follow.
+	FollowURI          string     `bun:",nullzero,notnull,unique"`                                    // ActivityPub URI of the Follow sent from the instance actor to the relay.
+	State              RelayState `bun:",nullzero,notnull,default:1"`                                 // State of the relay follow.
+	CreatedByAccountID string     `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the admin who added this relay.
+	CreatedByAccount   *Account   `bun:"-"`                                                           // Account corresponding to CreatedByAccountID.
+	Error              string     `bun:",nullzero"`                                                   // Most recent error encountered when following or delivering to this relay, if any.
+}
+
+// Accepted returns true if the
+// relay has accepted our Follow.
+func (r *Relay) Accepted() bool {
+	return r.State == RelayStateAccepted
+}
+
+// Host returns the host
+// of the relay's inbox.
+func (r *Relay) Host() string {
+	u, err := url.Parse(r.InboxURI)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// RelayState describes the state
+// of the instance's Follow of a relay.
+type RelayState uint8
+
+const (
+	RelayStateUnknown  RelayState = iota
+	RelayStatePending             // Follow sent, awaiting Accept.
+	RelayStateAccepted            // Follow accepted by relay.
+	RelayStateRejected            // Follow rejected by relay.
+)
+
+func (s RelayState) String() string {
+	switch s {
+	case RelayStatePending:
+		return "pending"
+	case RelayStateAccepted:
+		return "accepted"
+	case RelayStateRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ScheduledStatus represents a status that a local account has
+// scheduled for publication at a future date. It holds the status
+// creation parameters, which are processed into a real Status only
+// once the scheduled time arrives.
+type ScheduledStatus struct {
+	ID                string               `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt         time.Time            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt         time.Time            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID         string               `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the local account that scheduled this status
+	Account           *Account             `bun:"-"`                                                           // account corresponding to AccountID
+	ScheduledAt       time.Time            `bun:"type:timestamptz,nullzero,notnull"`                           // time at which the status should be published
+	Text              string               `bun:""`                                                            // text of the status as submitted
+	SpoilerText       string               `bun:""`                                                            // content warning of the status as submitted
+	Sensitive         *bool                `bun:",nullzero,notnull,default:false"`                             // status and attached media should be marked as sensitive
+	Visibility        Visibility           `bun:",nullzero,notnull"`                                           // visibility the status will be published with
+	LocalOnly         *bool                `bun:",nullzero,notnull,default:false"`                             // status will not be federated
+	Language          string               `bun:",nullzero"`                                                   // language tag of the status
+	ContentType       string               `bun:",nullzero"`                                                   // content type to parse the status text with
+	InReplyToID       string               `bun:"type:CHAR(26),nullzero"`                                      // id of the status this status will reply to, if any
+	QuoteID           string               `bun:"type:CHAR(26),nullzero"`                                      // id of the status this status will quote, if any
+	MediaIDs          []string             `bun:"attachments,array"`                                           // database IDs of media attachments reserved for this status
+	MediaAttachments  []*MediaAttachment   `bun:"-"`                                                           // attachments corresponding to MediaIDs
+	Poll              *ScheduledStatusPoll `bun:""`                                                            // poll to attach to the status, if any
+	InteractionPolicy *InteractionPolicy   `bun:""`                                                            // interaction policy given for the status, if any; if null the account default is used on publication
+	ApplicationID     string               `bun:"type:CHAR(26),nullzero"`                                      // id of the application used to schedule this status
+	Application       *Application         `bun:"-"`                                                           // application corresponding to ApplicationID
+}
+
+// ScheduledStatusPoll holds the parameters of a
+// poll to be attached to a scheduled status.
+type ScheduledStatusPoll struct {
+	Options    []string `json:"options"`     // The available options for this poll.
+	ExpiresIn  int      `json:"expires_in"`  // Seconds after publication at which the poll expires.
+	Multiple   bool     `json:"multiple"`    // Is this a multiple choice poll?
+	HideTotals bool     `json:"hide_totals"` // Hide vote counts until poll ends?
+}
+
+// AttachmentsPopulated returns whether media attachments
+// are populated according to current MediaIDs.
+func (s *ScheduledStatus) AttachmentsPopulated() bool {
+	if len(s.MediaIDs) != len(s.MediaAttachments) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range s.MediaIDs {
+		if id != s.MediaAttachments[i].ID {
+			return false
+		}
+	}
+	return true
+}
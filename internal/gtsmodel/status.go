@@ -29,6 +29,7 @@ type Status struct {
 	UpdatedAt                time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
 	FetchedAt                time.Time          `bun:"type:timestamptz,nullzero"`                                   // when was item (remote) last fetched.
 	PinnedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // Status was pinned by owning account at this time.
+	EditedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // Status was last edited by owning account at this time; zero if never edited.
 	URI                      string             `bun:",unique,nullzero,notnull"`                                    // activitypub URI of this status
 	URL                      string             `bun:",nullzero"`                                                   // web url for viewing this status
 	Content                  string             `bun:""`                                                            // content of this status; likely html-formatted but not guaranteed
@@ -40,6 +41,8 @@ type Status struct {
 	Mentions                 []*Mention         `bun:"attached_mentions,rel:has-many"`                              // Mentions corresponding to mentionIDs
 	EmojiIDs                 []string           `bun:"emojis,array"`                                                // Database IDs of any emojis used in this status
 	Emojis                   []*Emoji           `bun:"attached_emojis,m2m:status_to_emojis"`                        // Emojis corresponding to emojiIDs. https://bun.uptrace.dev/guide/relations.html#many-to-many-relation
+	EditIDs                  []string           `bun:"edits,array"`                                                 // Database IDs of previous versions of this status, in chronological order (oldest first).
+	Edits                    []*StatusEdit      `bun:"-"`                                                           // Edits corresponding to editIDs.
 	Local                    *bool              `bun:",nullzero,notnull,default:false"`                             // is this status from a local account?
 	AccountID                string             `bun:"type:CHAR(26),nullzero,notnull"`                              // which account posted this status?
 	Account                  *Account           `bun:"rel:belongs-to"`                                              // account corresponding to accountID
@@ -70,6 +73,13 @@ type Status struct {
 	PendingApproval          *bool              `bun:",nullzero,notnull,default:false"`                             // If true then status is a reply or boost wrapper that must be Approved by the reply-ee or boost-ee before being fully distributed.
 	PreApproved              bool               `bun:"-"`                                                           // If true, then status is a reply to or boost wrapper of a status on our instance, has permission to do the interaction, and an Accept should be sent out for it immediately. Field not stored in the DB.
 	ApprovedByURI            string             `bun:",nullzero"`                                                   // URI of an Accept Activity that approves the Announce or Create Activity that this status was/will be attached to.
+	QuoteID                  string             `bun:"type:CHAR(26),nullzero"`                                      // id of the status this status quotes
+	QuoteURI                 string             `bun:",nullzero"`                                                   // activitypub uri of the status this status quotes
+	Quote                    *Status            `bun:"-"`                                                           // status corresponding to quoteID
+	QuoteState               QuoteState         `bun:",nullzero"`                                                   // state of approval of the quote by the quoted account; only set if QuoteURI is set
+	QuoteApprovedByURI       string             `bun:",nullzero"`                                                   // URI of an Accept Activity that approves the quote of the quoted status.
+	CardID                   string             `bun:"type:CHAR(26),nullzero"`                                      // id of the preview card for the first link in this status
+	Card                     *Card              `bun:"-"`                                                           // preview card corresponding to cardID
 }
 
 // GetID implements timeline.Timelineable{}.
@@ -164,6 +174,20 @@ func (s *Status) EmojisUpToDate(other *Status) bool {
 	return true
 }
 
+// EditsPopulated returns whether edits are populated according to current EditIDs.
+func (s *Status) EditsPopulated() bool {
+	if len(s.EditIDs) != len(s.Edits) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range s.EditIDs {
+		if s.Edits[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
+
 // GetAttachmentByRemoteURL searches status for MediaAttachment{} with remote URL.
 func (s *Status) GetAttachmentByRemoteURL(url string) (*MediaAttachment, bool) {
 	for _, media := range s.Attachments {
@@ -247,6 +271,17 @@ func (s *Status) IsLocalOnly() bool {
 	return s.Federated == nil || !*s.Federated
 }
 
+// IsQuote returns true if this status quotes another.
+func (s *Status) IsQuote() bool {
+	return s.QuoteURI != ""
+}
+
+// QuotePendingApproval returns true if this status
+// quotes another, pending approval by its author.
+func (s *Status) QuotePendingApproval() bool {
+	return s.QuoteState == QuoteStatePending
+}
+
 // StatusToTag is an intermediate struct to facilitate the many2many relationship between a status and one or more tags.
 type StatusToTag struct {
 	StatusID string  `bun:"type:CHAR(26),unique:statustag,nullzero,notnull"`
@@ -294,3 +329,28 @@ type Content struct {
 	Content    string
 	ContentMap map[string]string
 }
+
+// QuoteState describes the state of approval
+// of a status quoting another, by the quoted
+// status's author, according to its policy.
+type QuoteState uint8
+
+const (
+	QuoteStateUnknown  QuoteState = iota
+	QuoteStatePending             // Quote awaits approval.
+	QuoteStateAccepted            // Quote is permitted or approved.
+	QuoteStateRejected            // Quote was rejected.
+)
+
+func (s QuoteState) String() string {
+	switch s {
+	case QuoteStatePending:
+		return "pending"
+	case QuoteStateAccepted:
+		return "accepted"
+	case QuoteStateRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,58 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// StatusEdit represents a *historical* revision of a status,
+// ie., the state the status was in before an edit was applied
+// to it. The Status model itself always contains the latest,
+// up-to-date version of the content.
+//
+// For remote statuses these are a best-effort record made by
+// this instance of the changes it saw, and may not exactly match
+// the revision history kept by the status' origin server.
+type StatusEdit struct {
+	ID                     string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt              time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was this revision created (ie., when was the status previously created / edited)
+	StatusID               string             `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the status this is a revision of
+	Content                string             `bun:""`                                                            // content of the status at this revision; likely html-formatted but not guaranteed
+	ContentWarning         string             `bun:",nullzero"`                                                   // cw string of the status at this revision
+	Text                   string             `bun:""`                                                            // original text of the status at this revision, without formatting (local only)
+	Language               string             `bun:",nullzero"`                                                   // language of the status at this revision
+	Sensitive              *bool              `bun:",nullzero,notnull,default:false"`                             // was the status marked sensitive at this revision?
+	AttachmentIDs          []string           `bun:"attachments,array"`                                           // Database IDs of media attachments on the status at this revision
+	AttachmentDescriptions []string           `bun:",array"`                                                      // descriptions of the media attachments at this revision, in the same order as attachmentIDs
+	Attachments            []*MediaAttachment `bun:"-"`                                                           // Attachments corresponding to attachmentIDs
+	PollOptions            []string           `bun:",array"`                                                      // poll options of the status at this revision, if it had a poll
+	PollVotes              []int              `bun:",array"`                                                      // poll vote counts of the status at this revision, if votes were reset by the edit
+}
+
+// AttachmentsPopulated returns whether media attachments are populated according to current AttachmentIDs.
+func (e *StatusEdit) AttachmentsPopulated() bool {
+	if len(e.AttachmentIDs) != len(e.Attachments) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range e.AttachmentIDs {
+		if e.Attachments[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
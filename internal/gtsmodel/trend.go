@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// TrendReview represents an admin's review of one tag,
+// status, or link that has been trending on this instance.
+// Trends are only shown to users once they've been approved.
+type TrendReview struct {
+	ID                  string           `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                      // id of this item in the database
+	CreatedAt           time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`   // when was item created
+	UpdatedAt           time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`   // when was item last updated
+	TrendType           TrendType        `bun:",nullzero,notnull,unique:trend_reviews_trend_type_target_uniq"` // Type of the trending item.
+	Target              string           `bun:",nullzero,notnull,unique:trend_reviews_trend_type_target_uniq"` // ID of the trending tag or status, or URL of the trending link.
+	State               TrendReviewState `bun:",nullzero,notnull,default:1"`                                   // State of the review.
+	ReviewedAt          time.Time        `bun:"type:timestamptz,nullzero"`                                     // When was this item approved or rejected by an admin.
+	ReviewedByAccountID string           `bun:"type:CHAR(26),nullzero"`                                        // Account ID of the admin who approved or rejected this item.
+}
+
+// Approved returns true if the
+// trend review has been approved.
+func (t *TrendReview) Approved() bool {
+	return t.State == TrendReviewStateApproved
+}
+
+// TrendType describes the type
+// of a trending item on this instance.
+type TrendType uint8
+
+const (
+	TrendTypeUnknown TrendType = iota
+	TrendTypeTag               // Trending hashtag.
+	TrendTypeStatus            // Trending status.
+	TrendTypeLink              // Trending link.
+)
+
+func (t TrendType) String() string {
+	switch t {
+	case TrendTypeTag:
+		return "tag"
+	case TrendTypeStatus:
+		return "status"
+	case TrendTypeLink:
+		return "link"
+	default:
+		return "unknown"
+	}
+}
+
+// TrendReviewState describes the state
+// of an admin's review of a trending item.
+type TrendReviewState uint8
+
+const (
+	TrendReviewStateUnknown  TrendReviewState = iota
+	TrendReviewStatePending                   // Awaiting review by an admin.
+	TrendReviewStateApproved                  // Approved, may be shown in trends.
+	TrendReviewStateRejected                  // Rejected, never shown in trends.
+)
+
+func (s TrendReviewState) String() string {
+	switch s {
+	case TrendReviewStatePending:
+		return "pending"
+	case TrendReviewStateApproved:
+		return "approved"
+	case TrendReviewStateRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// TrendActivity represents one use of a tag, boost or fave of a
+// status, or post of a link by an account, as used to calculate
+// trends. It's assembled from other tables and not stored itself.
+type TrendActivity struct {
+	Target        string    // ID of the tag or status, or ID of the status containing a link.
+	AccountID     string    // ID of the account responsible for the activity.
+	AccountDomain string    // Domain of the account responsible for the activity, empty if local.
+	CreatedAt     time.Time // When the activity occurred.
+}
@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// WebPushSubscription represents a Web Push subscription registered
+// by a client application on behalf of a local account. Subscriptions
+// belong to the OAuth token they were created with, and each token
+// can hold at most one subscription.
+type WebPushSubscription struct {
+	ID                string                               `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt         time.Time                            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt         time.Time                            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID         string                               `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the local account that owns this subscription
+	TokenID           string                               `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // id of the token this subscription was created with
+	Endpoint          string                               `bun:",nullzero,notnull"`                                           // push service endpoint URL to deliver notifications to
+	Auth              string                               `bun:",nullzero,notnull"`                                           // base64url encoded client auth secret
+	P256dh            string                               `bun:",nullzero,notnull"`                                           // base64url encoded client P-256 ECDH public key
+	NotificationFlags WebPushSubscriptionNotificationFlags `bun:",notnull"`                                                    // which notification types should be pushed
+	Policy            WebPushNotificationPolicy            `bun:",nullzero,notnull,default:'all'"`                             // which accounts should trigger pushed notifications
+}
+
+// WebPushSubscriptionNotificationFlags is a bitfield
+// of the notification types a subscription wants pushed.
+type WebPushSubscriptionNotificationFlags int64
+
+// webPushNotificationFlag returns the bit
+// of WebPushSubscriptionNotificationFlags that
+// corresponds to the given notification type,
+// or 0 if the type is not supported for push.
+func webPushNotificationFlag(notificationType NotificationType) WebPushSubscriptionNotificationFlags {
+	switch notificationType {
+	case NotificationFollow:
+		return 1 << 0
+	case NotificationFollowRequest:
+		return 1 << 1
+	case NotificationMention:
+		return 1 << 2
+	case NotificationReblog:
+		return 1 << 3
+	case NotificationFave:
+		return 1 << 4
+	case NotificationPoll:
+		return 1 << 5
+	case NotificationStatus:
+		return 1 << 6
+	case NotificationSignup:
+		return 1 << 7
+	case NotificationPendingFave:
+		return 1 << 8
+	case NotificationPendingReply:
+		return 1 << 9
+	case NotificationPendingReblog:
+		return 1 << 10
+	case NotificationQuote:
+		return 1 << 11
+	case NotificationPendingQuote:
+		return 1 << 12
+	default:
+		return 0
+	}
+}
+
+// Get returns whether notifications of the given type are enabled.
+func (f WebPushSubscriptionNotificationFlags) Get(notificationType NotificationType) bool {
+	flag := webPushNotificationFlag(notificationType)
+	return flag != 0 && f&flag != 0
+}
+
+// Set enables or disables notifications of the given type.
+func (f *WebPushSubscriptionNotificationFlags) Set(notificationType NotificationType, value bool) {
+	flag := webPushNotificationFlag(notificationType)
+	if value {
+		*f |= flag
+	} else {
+		*f &^= flag
+	}
+}
+
+// WebPushNotificationPolicy describes which
+// accounts' activity should result in a push.
+type WebPushNotificationPolicy string
+
+const (
+	WebPushNotificationPolicyAll      WebPushNotificationPolicy = "all"      // Push notifications from any account.
+	WebPushNotificationPolicyFollowed WebPushNotificationPolicy = "followed" // Push notifications from accounts the subscriber follows.
+	WebPushNotificationPolicyFollower WebPushNotificationPolicy = "follower" // Push notifications from accounts that follow the subscriber.
+	WebPushNotificationPolicyNone     WebPushNotificationPolicy = "none"     // Push no notifications.
+)
+
+// VAPIDKeyPair is the instance's VAPID (RFC 8292) key pair,
+// used to identify this instance to push services. There is
+// only ever one key pair stored, which always has ID 1.
+type VAPIDKeyPair struct {
+	ID         int    `bun:",pk,notnull"`       // always 1
+	PublicKey  string `bun:",nullzero,notnull"` // base64url encoded uncompressed P-256 public key
+	PrivateKey string `bun:",nullzero,notnull"` // base64url encoded raw P-256 private key
+}
@@ -18,7 +18,9 @@
 package id
 
 import (
+	"bytes"
 	"crypto/rand"
+	"crypto/sha256"
 	"math/big"
 	"time"
 
@@ -54,6 +56,18 @@ func NewULIDFromTime(t time.Time) (string, error) {
 	return newUlid.String(), nil
 }
 
+// NewULIDFromTimeAndKey returns a ULID string using the given time, with
+// its random part derived from the given key instead, so that the same
+// time and key always give the same ULID.
+func NewULIDFromTimeAndKey(t time.Time, key string) (string, error) {
+	sum := sha256.Sum256([]byte(key))
+	newUlid, err := ulid.New(ulid.Timestamp(t), bytes.NewReader(sum[:]))
+	if err != nil {
+		return "", err
+	}
+	return newUlid.String(), nil
+}
+
 // NewRandomULID returns a new ULID string using a random time in an ~80 year range around the current datetime, or an error if something goes wrong.
 func NewRandomULID() (string, error) {
 	b1, err := rand.Int(rand.Reader, big.NewInt(randomRange))
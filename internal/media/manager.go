@@ -139,6 +139,9 @@ func (m *Manager) CreateMedia(
 	if info.ScheduledStatusID != nil {
 		attachment.ScheduledStatusID = *info.ScheduledStatusID
 	}
+	if info.CardID != nil {
+		attachment.CardID = *info.CardID
+	}
 	if info.Blurhash != nil {
 		attachment.Blurhash = *info.Blurhash
 	}
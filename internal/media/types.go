@@ -68,6 +68,10 @@ type AdditionalMediaInfo struct {
 	// this media is attached; defaults to "".
 	ScheduledStatusID *string
 
+	// ID of the preview card of which
+	// this media is the image; defaults to "".
+	CardID *string
+
 	// Mark this media as in-use
 	// as an avatar; defaults to false.
 	Avatar *bool
@@ -25,44 +25,144 @@
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/ulule/limiter/v3"
-	"github.com/ulule/limiter/v3/drivers/store/memory"
 
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 )
 
 const rateLimitPeriod = 5 * time.Minute
 
+// rateLimitPolicy is the value of the RateLimit-Policy header
+// for the given limit, ie., "[limit];w=[period in seconds]".
+func rateLimitPolicy(limit int) string {
+	return strconv.Itoa(limit) + ";w=" + strconv.Itoa(int(rateLimitPeriod/time.Second))
+}
+
 // RateLimit returns a gin middleware that will automatically rate
-// limit caller (by IP address), and enrich the response header with
-// the following headers:
+// limit caller, counting requests in the given bucket of the given
+// store, and enrich the response header with the following headers:
 //
 //   - `X-Ratelimit-Limit`     - max requests allowed per time period (fixed).
-//   - `X-Ratelimit-Remaining` - requests remaining for this IP before reset.
+//   - `X-Ratelimit-Remaining` - requests remaining for this caller before reset.
 //   - `X-Ratelimit-Reset`     - ISO8601 timestamp when the rate limit will reset.
+//   - `RateLimit-Limit`       - as X-Ratelimit-Limit.
+//   - `RateLimit-Remaining`   - as X-Ratelimit-Remaining.
+//   - `RateLimit-Reset`       - seconds until the rate limit will reset.
+//   - `RateLimit-Policy`      - limit and time period in seconds, eg., "300;w=300".
+//
+// Callers with an authorized account set on the gin context by
+// TokenCheck are limited by account ID, other callers by IP address.
 //
 // If `X-Ratelimit-Limit` is exceeded, the request is aborted and an
-// HTTP 429 TooManyRequests status is returned.
+// HTTP 429 TooManyRequests status is returned, with Retry-After set.
+//
+// If the config AdvancedRateLimitRequests value is <= 0, then a noop
+// handler will be returned, which performs no rate limiting.
+func RateLimit(store limiter.Store, bucket string, limit int, exceptions []string) gin.HandlerFunc {
+	keyByIP := rateLimitIPKeyer(exceptions)
+
+	return rateLimit(store, limit, func(c *gin.Context) (string, bool) {
+		if account, ok := c.Get(oauth.SessionAuthorizedAccount); ok {
+			// Limit authorized callers by account,
+			// so that callers sharing an IP don't
+			// use up each other's requests.
+			return bucket + ":account:" + account.(*gtsmodel.Account).ID, true
+		}
+
+		key, ok := keyByIP(c)
+		return bucket + ":ip:" + key, ok
+	})
+}
+
+// RateLimitIP returns a gin middleware like RateLimit, which always
+// limits callers by IP address, counting requests in the given bucket.
+// It's intended to be attached before TokenCheck, so that requests are
+// limited cheaply before any token lookups are done.
 //
 // If the config AdvancedRateLimitRequests value is <= 0, then a noop
 // handler will be returned, which performs no rate limiting.
-func RateLimit(limit int, exceptions []string) gin.HandlerFunc {
+func RateLimitIP(store limiter.Store, bucket string, limit int, exceptions []string) gin.HandlerFunc {
+	keyByIP := rateLimitIPKeyer(exceptions)
+
+	return rateLimit(store, limit, func(c *gin.Context) (string, bool) {
+		key, ok := keyByIP(c)
+		return bucket + ":ip:" + key, ok
+	})
+}
+
+// RateLimitSignIn returns a gin middleware like RateLimit, which
+// only counts POST requests (ie., sign-in attempts), always limiting
+// callers by IP address. It should be attached to sign-in routes
+// in addition to the general RateLimit middleware.
+//
+// If the config AdvancedRateLimitSignIn value is <= 0, then a noop
+// handler will be returned, which performs no rate limiting.
+func RateLimitSignIn(store limiter.Store, limit int, exceptions []string) gin.HandlerFunc {
+	keyByIP := rateLimitIPKeyer(exceptions)
+
+	return rateLimit(store, limit, func(c *gin.Context) (string, bool) {
+		if c.Request.Method != http.MethodPost {
+			// Only count sign-in attempts.
+			return "", false
+		}
+
+		key, ok := keyByIP(c)
+		return "signin:ip:" + key, ok
+	})
+}
+
+// RateLimitInbox returns a gin middleware which rate limits POST requests
+// (ie., inbox deliveries) by the domain of the account that sent them.
+//
+// The sending domain is only known for sure once the delivery's http
+// signature has been verified, so rather than counting the request
+// straight away, this sets a function on the request context which the
+// federator calls after authenticating the delivery. That way, requests
+// merely claiming to be signed by another domain's key can't use up
+// the allowance of that domain's real deliveries.
+//
+// If the config AdvancedRateLimitInbox value is <= 0, then a noop
+// handler will be returned, which performs no rate limiting.
+func RateLimitInbox(store limiter.Store, limit int) gin.HandlerFunc {
 	if limit <= 0 {
 		// Rate limiting is disabled.
 		// Return noop middleware.
 		return func(ctx *gin.Context) {}
 	}
 
-	limiter := limiter.New(
-		memory.NewStore(),
-		limiter.Rate{
-			Period: rateLimitPeriod,
-			Limit:  int64(limit),
-		},
-	)
+	count := rateLimitCounter(store, limit)
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodPost {
+			// Only count deliveries.
+			return
+		}
+
+		ctx := gtscontext.SetInboxLimitFunc(
+			c.Request.Context(),
+			func(domain string) (bool, error) {
+				reached, err := count(c, "inbox:domain:"+domain)
+				return !reached, err
+			},
+		)
+
+		// Replace request with a shallow
+		// copy with the new context.
+		c.Request = c.Request.WithContext(ctx)
+	}
+}
 
+// rateLimitIPKeyer returns a function that derives a rate limit
+// key from the caller's (masked) IP address, returning false
+// if the IP address is exempt from rate limits.
+//
+// Panics if any of the given exceptions isn't a valid CIDR.
+func rateLimitIPKeyer(exceptions []string) func(*gin.Context) (string, bool) {
 	// Convert exceptions IP ranges into prefixes.
 	exceptPrefs := make([]netip.Prefix, len(exceptions))
 	for i, str := range exceptions {
@@ -80,7 +180,7 @@ func RateLimit(limit int, exceptions []string) gin.HandlerFunc {
 	// legit users access to the service.
 	ipv6Mask := net.CIDRMask(64, 128)
 
-	return func(c *gin.Context) {
+	return func(c *gin.Context) (string, bool) {
 		// Use Gin's heuristic for determining
 		// clientIP, which accounts for reverse
 		// proxies and trusted proxies setting.
@@ -90,8 +190,7 @@ func RateLimit(limit int, exceptions []string) gin.HandlerFunc {
 		// limits and skip further checks if so.
 		for _, prefix := range exceptPrefs {
 			if prefix.Contains(clientIP) {
-				c.Next()
-				return
+				return "", false
 			}
 		}
 
@@ -106,13 +205,41 @@ func RateLimit(limit int, exceptions []string) gin.HandlerFunc {
 			clientIP, _ = netip.AddrFromSlice(asIP)
 		}
 
-		// Fetch rate limit info for this (masked) clientIP.
-		context, err := limiter.Get(c, clientIP.String())
+		return clientIP.String(), true
+	}
+}
+
+// rateLimit returns a gin middleware that counts requests against
+// the given limit in the given store, using keys derived with the
+// given function. Requests for which key returns false are let
+// through without being counted.
+func rateLimit(
+	store limiter.Store,
+	limit int,
+	key func(*gin.Context) (string, bool),
+) gin.HandlerFunc {
+	if limit <= 0 {
+		// Rate limiting is disabled.
+		// Return noop middleware.
+		return func(ctx *gin.Context) {}
+	}
+
+	count := rateLimitCounter(store, limit)
+
+	return func(c *gin.Context) {
+		k, ok := key(c)
+		if !ok {
+			// Not counted,
+			// skip checks.
+			c.Next()
+			return
+		}
+
+		reached, err := count(c, k)
 		if err != nil {
-			// Since we use an in-memory cache now,
-			// it's actually impossible for this to
-			// error, but handle it nicely anyway in
-			// case we switch implementation in future.
+			// The database store can error if
+			// the database is unavailable, in
+			// which case we can't do much else.
 			errWithCode := gtserror.NewErrorInternalError(err)
 
 			// Set error on gin context so it'll
@@ -127,20 +254,7 @@ func RateLimit(limit int, exceptions []string) gin.HandlerFunc {
 			return
 		}
 
-		// Provide reset in same format used by
-		// Mastodon. There's no real standard as
-		// to what format X-RateLimit-Reset SHOULD
-		// use, but since most clients interacting
-		// with us will expect the Mastodon version,
-		// it makes sense to take this.
-		resetT := time.Unix(context.Reset, 0)
-		reset := util.FormatISO8601(resetT)
-
-		c.Header("X-RateLimit-Limit", strconv.FormatInt(context.Limit, 10))
-		c.Header("X-RateLimit-Remaining", strconv.FormatInt(context.Remaining, 10))
-		c.Header("X-RateLimit-Reset", reset)
-
-		if context.Reached {
+		if reached {
 			// Return JSON error message for
 			// consistency with other endpoints.
 			apiutil.Data(c,
@@ -157,3 +271,63 @@ func RateLimit(limit int, exceptions []string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// rateLimitCounter returns a function that counts a request under
+// the given key against the given limit in the given store, setting
+// rate limit headers on the response, and returning true if the
+// limit has been reached.
+func rateLimitCounter(
+	store limiter.Store,
+	limit int,
+) func(c *gin.Context, key string) (bool, error) {
+	limiter := limiter.New(
+		store,
+		limiter.Rate{
+			Period: rateLimitPeriod,
+			Limit:  int64(limit),
+		},
+	)
+
+	policy := rateLimitPolicy(limit)
+
+	return func(c *gin.Context, key string) (bool, error) {
+		// Fetch rate limit info for this key.
+		context, err := limiter.Get(c, key)
+		if err != nil {
+			return false, err
+		}
+
+		// Provide reset in same format used by
+		// Mastodon. There's no real standard as
+		// to what format X-RateLimit-Reset SHOULD
+		// use, but since most clients interacting
+		// with us will expect the Mastodon version,
+		// it makes sense to take this.
+		resetT := time.Unix(context.Reset, 0)
+		reset := util.FormatISO8601(resetT)
+
+		// The standard RateLimit-Reset header
+		// instead uses seconds until the reset.
+		resetIn := max(0, int64(time.Until(resetT).Round(time.Second)/time.Second))
+		resetInStr := strconv.FormatInt(resetIn, 10)
+
+		limitStr := strconv.FormatInt(context.Limit, 10)
+		remainingStr := strconv.FormatInt(context.Remaining, 10)
+
+		c.Header("X-RateLimit-Limit", limitStr)
+		c.Header("X-RateLimit-Remaining", remainingStr)
+		c.Header("X-RateLimit-Reset", reset)
+		c.Header("RateLimit-Limit", limitStr)
+		c.Header("RateLimit-Remaining", remainingStr)
+		c.Header("RateLimit-Reset", resetInStr)
+		c.Header("RateLimit-Policy", policy)
+
+		if context.Reached {
+			// Let the caller know
+			// when to try again.
+			c.Header("Retry-After", resetInStr)
+		}
+
+		return context.Reached, nil
+	}
+}
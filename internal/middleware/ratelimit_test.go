@@ -26,8 +26,15 @@
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+	"github.com/ulule/limiter/v3/drivers/store/memory"
 )
 
 type RateLimitTestSuite struct {
@@ -43,6 +50,10 @@ func (suite *RateLimitTestSuite) TestRateLimit() {
 		rlLimit         = "X-RateLimit-Limit"
 		rlRemaining     = "X-RateLimit-Remaining"
 		rlReset         = "X-RateLimit-Reset"
+		stdLimit        = "RateLimit-Limit"
+		stdRemaining    = "RateLimit-Remaining"
+		stdReset        = "RateLimit-Reset"
+		stdPolicy       = "RateLimit-Policy"
 	)
 
 	type rlTest struct {
@@ -94,6 +105,8 @@ type rlTest struct {
 			// Try to trigger panic.
 			suite.Panics(func() {
 				_ = middleware.RateLimit(
+					memory.NewStore(),
+					"test",
 					test.limit,
 					test.exceptions,
 				)
@@ -102,6 +115,8 @@ type rlTest struct {
 		}
 
 		rlMiddleware := middleware.RateLimit(
+			memory.NewStore(),
+			"test",
 			test.limit,
 			test.exceptions,
 		)
@@ -141,12 +156,26 @@ type rlTest struct {
 				suite.Empty(limitStr)
 				suite.Empty(remainingStr)
 				suite.Empty(resetStr)
+				suite.Empty(recorder.Header().Get(stdLimit))
 				continue
 			}
 
 			suite.Equal(strconv.Itoa(test.limit), limitStr)
 			suite.Equal(strconv.Itoa(test.limit-requestsCount), remainingStr)
 
+			// Standard headers should match.
+			suite.Equal(limitStr, recorder.Header().Get(stdLimit))
+			suite.Equal(remainingStr, recorder.Header().Get(stdRemaining))
+			suite.Equal(strconv.Itoa(test.limit)+";w=300", recorder.Header().Get(stdPolicy))
+
+			// Ensure standard reset is seconds
+			// until approximate reset time.
+			resetIn, err := strconv.Atoi(recorder.Header().Get(stdReset))
+			if err != nil {
+				suite.FailNow("", "couldn't parse %s as seconds: %q", recorder.Header().Get(stdReset), err.Error())
+			}
+			suite.InDelta(300, resetIn, 10)
+
 			// Ensure reset is ISO8601, and resets at
 			// approximate reset time (+/- 10 seconds).
 			reset, err := util.ParseISO8601(resetStr)
@@ -187,6 +216,209 @@ type rlTest struct {
 	}
 }
 
+// request calls the given rate limit middleware with a request
+// of the given method from the given IP, after calling setup
+// on the gin context if set, returning the recorded response.
+func (suite *RateLimitTestSuite) request(
+	rlMiddleware gin.HandlerFunc,
+	method string,
+	clientIP string,
+	setup func(*gin.Context),
+) *httptest.ResponseRecorder {
+	// Suppress warnings about debug mode.
+	gin.SetMode(gin.ReleaseMode)
+
+	var (
+		recorder = httptest.NewRecorder()
+		ctx, e   = gin.CreateTestContext(recorder)
+	)
+
+	// Instruct engine to derive
+	// clientIP from test header.
+	e.TrustedPlatform = "X-Test-IP"
+	ctx.Request = httptest.NewRequest(method, "/example", nil)
+	ctx.Request.Header.Add("X-Test-IP", clientIP)
+
+	if setup != nil {
+		setup(ctx)
+	}
+
+	rlMiddleware(ctx)
+	return recorder
+}
+
+func (suite *RateLimitTestSuite) TestRateLimitAccount() {
+	rlMiddleware := middleware.RateLimit(memory.NewStore(), "test", 1, nil)
+
+	withAccount := func(id string) func(*gin.Context) {
+		return func(c *gin.Context) {
+			c.Set(oauth.SessionAuthorizedAccount, &gtsmodel.Account{ID: id})
+		}
+	}
+
+	// Use up the limit for one account.
+	rec := suite.request(rlMiddleware, http.MethodGet, "192.0.2.0", withAccount("01HQ6RY6J6Q9A5KH8R7XHMA2B5"))
+	suite.Equal(http.StatusOK, rec.Code)
+	rec = suite.request(rlMiddleware, http.MethodGet, "192.0.2.0", withAccount("01HQ6RY6J6Q9A5KH8R7XHMA2B5"))
+	suite.Equal(http.StatusTooManyRequests, rec.Code)
+	suite.NotEmpty(rec.Header().Get("Retry-After"))
+
+	// Another account on the same
+	// IP should be allowed through.
+	rec = suite.request(rlMiddleware, http.MethodGet, "192.0.2.0", withAccount("01HQ6S0ZB1VN7ZQ4D6V5SXMM1H"))
+	suite.Equal(http.StatusOK, rec.Code)
+
+	// As should an unauthorized
+	// request from the same IP.
+	rec = suite.request(rlMiddleware, http.MethodGet, "192.0.2.0", nil)
+	suite.Equal(http.StatusOK, rec.Code)
+}
+
+func (suite *RateLimitTestSuite) TestRateLimitIP() {
+	rlMiddleware := middleware.RateLimitIP(memory.NewStore(), "test", 1, nil)
+
+	withAccount := func(id string) func(*gin.Context) {
+		return func(c *gin.Context) {
+			c.Set(oauth.SessionAuthorizedAccount, &gtsmodel.Account{ID: id})
+		}
+	}
+
+	rec := suite.request(rlMiddleware, http.MethodGet, "192.0.2.0", withAccount("01HQ6RY6J6Q9A5KH8R7XHMA2B5"))
+	suite.Equal(http.StatusOK, rec.Code)
+
+	// Another account on the same IP is
+	// limited together with the first one.
+	rec = suite.request(rlMiddleware, http.MethodGet, "192.0.2.0", withAccount("01HQ6S0ZB1VN7ZQ4D6V5SXMM1H"))
+	suite.Equal(http.StatusTooManyRequests, rec.Code)
+
+	// Other IPs are still allowed through.
+	rec = suite.request(rlMiddleware, http.MethodGet, "192.0.2.1", nil)
+	suite.Equal(http.StatusOK, rec.Code)
+}
+
+func (suite *RateLimitTestSuite) TestRateLimitSignIn() {
+	rlMiddleware := middleware.RateLimitSignIn(memory.NewStore(), 1, nil)
+
+	// GET requests aren't sign-in
+	// attempts, so aren't counted.
+	for i := 0; i < 3; i++ {
+		rec := suite.request(rlMiddleware, http.MethodGet, "192.0.2.0", nil)
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Empty(rec.Header().Get("RateLimit-Limit"))
+	}
+
+	rec := suite.request(rlMiddleware, http.MethodPost, "192.0.2.0", nil)
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal("1", rec.Header().Get("RateLimit-Limit"))
+	suite.Equal("0", rec.Header().Get("RateLimit-Remaining"))
+
+	rec = suite.request(rlMiddleware, http.MethodPost, "192.0.2.0", nil)
+	suite.Equal(http.StatusTooManyRequests, rec.Code)
+
+	// Other IPs can still sign in.
+	rec = suite.request(rlMiddleware, http.MethodPost, "192.0.2.1", nil)
+	suite.Equal(http.StatusOK, rec.Code)
+}
+
+func (suite *RateLimitTestSuite) TestRateLimitInbox() {
+	rlMiddleware := middleware.RateLimitInbox(memory.NewStore(), 1)
+
+	// Stand in for the federator, which counts
+	// a delivery against the domain of the
+	// requesting account once it's authenticated.
+	deliver := func(domain string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			rlMiddleware(c)
+
+			limit := gtscontext.InboxLimitFunc(c.Request.Context())
+			if limit == nil {
+				// Not counted.
+				return
+			}
+
+			if domain == "" {
+				// Not authenticated, so
+				// the limit isn't checked.
+				return
+			}
+
+			ok, err := limit(domain)
+			if err != nil {
+				suite.FailNow(err.Error())
+			}
+
+			if !ok {
+				c.AbortWithStatus(http.StatusTooManyRequests)
+			}
+		}
+	}
+
+	// Requests that fail authentication aren't
+	// counted, whatever key they claim to be
+	// signed with, so they can't use up the
+	// allowance of another domain.
+	for i := 0; i < 3; i++ {
+		rec := suite.request(deliver(""), http.MethodPost, "192.0.2.0", nil)
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Empty(rec.Header().Get("RateLimit-Limit"))
+	}
+
+	rec := suite.request(deliver("example.org"), http.MethodPost, "192.0.2.0", nil)
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal("0", rec.Header().Get("RateLimit-Remaining"))
+
+	// Deliveries from the same domain are limited
+	// together, even from another IP address.
+	rec = suite.request(deliver("example.org"), http.MethodPost, "192.0.2.1", nil)
+	suite.Equal(http.StatusTooManyRequests, rec.Code)
+
+	// Other domains can still deliver.
+	rec = suite.request(deliver("fossbros-anonymous.io"), http.MethodPost, "192.0.2.0", nil)
+	suite.Equal(http.StatusOK, rec.Code)
+
+	// GET requests aren't deliveries.
+	rec = suite.request(rlMiddleware, http.MethodGet, "192.0.2.0", nil)
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Empty(rec.Header().Get("RateLimit-Limit"))
+}
+
+func (suite *RateLimitTestSuite) TestRateLimitDatabaseStore() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	var state state.State
+	state.Caches.Init()
+
+	db := testrig.NewTestDB(&state)
+	testrig.CreateTestTables(db)
+	defer testrig.StandardDBTeardown(db)
+
+	store := middleware.NewRateLimitStore(config.RateLimitStoreDatabase, db)
+
+	// Limiters sharing a database
+	// store should share counts.
+	rlMiddleware1 := middleware.RateLimit(store, "test", 2, nil)
+	rlMiddleware2 := middleware.RateLimit(store, "test", 2, nil)
+
+	rec := suite.request(rlMiddleware1, http.MethodGet, "192.0.2.0", nil)
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal("1", rec.Header().Get("RateLimit-Remaining"))
+
+	rec = suite.request(rlMiddleware2, http.MethodGet, "192.0.2.0", nil)
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal("0", rec.Header().Get("RateLimit-Remaining"))
+
+	rec = suite.request(rlMiddleware1, http.MethodGet, "192.0.2.0", nil)
+	suite.Equal(http.StatusTooManyRequests, rec.Code)
+
+	// Limiters for other buckets
+	// shouldn't share counts.
+	rlMiddleware3 := middleware.RateLimit(store, "other", 2, nil)
+	rec = suite.request(rlMiddleware3, http.MethodGet, "192.0.2.0", nil)
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal("1", rec.Header().Get("RateLimit-Remaining"))
+}
+
 func TestRateLimitTestSuite(t *testing.T) {
 	suite.Run(t, new(RateLimitTestSuite))
 }
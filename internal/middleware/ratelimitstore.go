@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/ulule/limiter/v3"
+	"github.com/ulule/limiter/v3/drivers/store/common"
+	"github.com/ulule/limiter/v3/drivers/store/memory"
+)
+
+// NewRateLimitStore returns a store for rate limit counts
+// of the given type (see config.RateLimitStore* consts).
+//
+// The in-memory store is returned for unrecognized types,
+// and the given database is only used by the database store.
+func NewRateLimitStore(storeType string, db db.RateLimit) limiter.Store {
+	switch storeType {
+	case config.RateLimitStoreDatabase:
+		return &dbRateLimitStore{db: db}
+	default:
+		return memory.NewStore()
+	}
+}
+
+// dbRateLimitStore implements limiter.Store
+// by keeping rate limit counts in the database,
+// so that they're shared by every process
+// using that database, and survive restarts.
+type dbRateLimitStore struct {
+	db db.RateLimit
+}
+
+func (s *dbRateLimitStore) Get(ctx context.Context, key string, rate limiter.Rate) (limiter.Context, error) {
+	return s.Increment(ctx, key, 1, rate)
+}
+
+func (s *dbRateLimitStore) Peek(ctx context.Context, key string, rate limiter.Rate) (limiter.Context, error) {
+	now := time.Now()
+
+	rateLimit, err := s.db.GetRateLimit(ctx, key)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return limiter.Context{}, err
+	}
+
+	if rateLimit == nil || rateLimit.ResetAt <= now.UnixMilli() {
+		// No hits yet in the current period.
+		return common.GetContextFromState(now, rate, now.Add(rate.Period), 0), nil
+	}
+
+	resetAt := time.UnixMilli(rateLimit.ResetAt)
+	return common.GetContextFromState(now, rate, resetAt, rateLimit.Hits), nil
+}
+
+func (s *dbRateLimitStore) Reset(ctx context.Context, key string, rate limiter.Rate) (limiter.Context, error) {
+	now := time.Now()
+
+	if err := s.db.DeleteRateLimit(ctx, key); err != nil {
+		return limiter.Context{}, err
+	}
+
+	return common.GetContextFromState(now, rate, now.Add(rate.Period), 0), nil
+}
+
+func (s *dbRateLimitStore) Increment(ctx context.Context, key string, count int64, rate limiter.Rate) (limiter.Context, error) {
+	now := time.Now()
+
+	rateLimit, err := s.db.IncrementRateLimit(ctx, key, count, rate.Period)
+	if err != nil {
+		return limiter.Context{}, err
+	}
+
+	resetAt := time.UnixMilli(rateLimit.ResetAt)
+	return common.GetContextFromState(now, rate, resetAt, rateLimit.Hits), nil
+}
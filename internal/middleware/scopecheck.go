@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/oauth2/v4"
+)
+
+// ScopeCheck returns a new gin middleware for checking that the oauth
+// token set on a request by TokenCheck has been granted the given scope.
+//
+// If no token is set, then the middleware does nothing, as the server may
+// allow public requests on the route; the handler itself is expected to
+// return 401 if a token is required.
+//
+// If a token is set but does not permit the given scope, the middleware
+// will respond with 403, and abort the handler chain.
+func ScopeCheck(scope oauth.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		i, ok := c.Get(oauth.SessionAuthorizedToken)
+		if !ok {
+			// No token set,
+			// let handler decide.
+			return
+		}
+
+		ti, ok := i.(oauth2.TokenInfo)
+		if !ok {
+			// Should never happen.
+			return
+		}
+
+		if scope.Permits(ti.GetScope()) {
+			// All good.
+			return
+		}
+
+		log.Debugf(c.Request.Context(),
+			"token scope %q does not permit required scope %s",
+			ti.GetScope(), scope,
+		)
+
+		apiutil.JSON(c, http.StatusForbidden, map[string]string{
+			"error": "Forbidden: this action is outside the authorized scopes; required scope: " + string(scope),
+		})
+		c.Abort()
+	}
+}
@@ -0,0 +1,89 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/oauth2/v4"
+	"github.com/superseriousbusiness/oauth2/v4/models"
+)
+
+func TestScopeCheck(t *testing.T) {
+	for _, test := range []struct {
+		required oauth.Scope
+		token    oauth2.TokenInfo
+		expect   int
+	}{
+		{
+			// No token, let handler decide.
+			required: oauth.ScopeWriteStatuses,
+			token:    nil,
+			expect:   http.StatusOK,
+		},
+		{
+			// Token with top-level scope.
+			required: oauth.ScopeWriteStatuses,
+			token:    &models.Token{Scope: "read write"},
+			expect:   http.StatusOK,
+		},
+		{
+			// Read-only token trying to write.
+			required: oauth.ScopeWriteStatuses,
+			token:    &models.Token{Scope: "read"},
+			expect:   http.StatusForbidden,
+		},
+		{
+			// Granular token with wrong scope.
+			required: oauth.ScopeReadNotifications,
+			token:    &models.Token{Scope: "read:statuses"},
+			expect:   http.StatusForbidden,
+		},
+		{
+			// Non-admin token trying admin action.
+			required: oauth.ScopeAdminWriteAccounts,
+			token:    &models.Token{Scope: "read write follow push"},
+			expect:   http.StatusForbidden,
+		},
+	} {
+		engine := gin.New()
+		engine.Use(func(c *gin.Context) {
+			if test.token != nil {
+				c.Set(oauth.SessionAuthorizedToken, test.token)
+			}
+		})
+		engine.Handle(http.MethodGet, "/",
+			middleware.ScopeCheck(test.required),
+			func(c *gin.Context) { c.Status(http.StatusOK) },
+		)
+
+		rw := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		engine.ServeHTTP(rw, r)
+
+		if rw.Code != test.expect {
+			t.Errorf("expected code %d for required scope %s, got %d",
+				test.expect, test.required, rw.Code)
+		}
+	}
+}
@@ -18,7 +18,9 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -27,6 +29,11 @@
 	"github.com/superseriousbusiness/oauth2/v4"
 )
 
+// tokenLastUsedInterval is the minimum interval between
+// updates to a token's last used time. It saves writing to
+// the database on every single request made with a token.
+const tokenLastUsedInterval = 5 * time.Minute
+
 // TokenCheck returns a new gin middleware for validating oauth tokens in requests.
 //
 // The middleware checks the request Authorization header for a valid oauth Bearer token.
@@ -42,6 +49,8 @@
 // Next, it will look up the *gtsmodel.Account for the User. If the Account has been suspended, then the
 // middleware will return early. Otherwise, it will set the Account on the gin context too.
 //
+// The time when the token was last used is recorded too, so that users can spot tokens they no longer need.
+//
 // Finally, it will check the client ID of the token to see if a *gtsmodel.Application can be retrieved
 // for that client ID. This will also be set on the gin context.
 //
@@ -65,6 +74,9 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 		}
 		c.Set(oauth.SessionAuthorizedToken, ti)
 
+		// record (approximately) when this token was last used
+		updateTokenLastUsed(ctx, dbConn, ti.GetAccess())
+
 		// check for user-level token
 		if userID := ti.GetUserID(); userID != "" {
 			log.Tracef(ctx, "authenticated user %s with bearer token, scope is %s", userID, ti.GetScope())
@@ -138,3 +150,28 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 		}
 	}
 }
+
+// updateTokenLastUsed updates the last used time of the
+// token with the given access value, if it's out of date.
+func updateTokenLastUsed(ctx context.Context, dbConn db.DB, access string) {
+	if access == "" {
+		return
+	}
+
+	token, err := dbConn.GetTokenByAccess(ctx, access)
+	if err != nil {
+		log.Errorf(ctx, "database error getting token: %v", err)
+		return
+	}
+
+	now := time.Now()
+	if now.Sub(token.LastUsed) < tokenLastUsedInterval {
+		// Recent enough.
+		return
+	}
+
+	token.LastUsed = now
+	if err := dbConn.UpdateToken(ctx, token, "last_used"); err != nil {
+		log.Errorf(ctx, "database error updating token last used: %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Scope represents an OAuth scope which may
+// be requested by an application, and granted
+// to a token. Scopes are either top-level (eg.,
+// "read") or granular (eg., "read:statuses").
+type Scope string
+
+const (
+	ScopeRead    Scope = "read"
+	ScopeWrite   Scope = "write"
+	ScopeFollow  Scope = "follow"
+	ScopePush    Scope = "push"
+	ScopeProfile Scope = "profile"
+
+	// ScopeUser is a deprecated GoToSocial-specific
+	// scope from before scopes were enforced. It is
+	// still accepted so that tokens issued with it
+	// keep working, and grants read, write, follow
+	// and push, as it did then.
+	ScopeUser Scope = "user"
+
+	ScopeReadAccounts      Scope = ScopeRead + ":accounts"
+	ScopeReadBlocks        Scope = ScopeRead + ":blocks"
+	ScopeReadBookmarks     Scope = ScopeRead + ":bookmarks"
+	ScopeReadConversations Scope = ScopeRead + ":conversations"
+	ScopeReadFavourites    Scope = ScopeRead + ":favourites"
+	ScopeReadFilters       Scope = ScopeRead + ":filters"
+	ScopeReadFollows       Scope = ScopeRead + ":follows"
+	ScopeReadLists         Scope = ScopeRead + ":lists"
+	ScopeReadMedia         Scope = ScopeRead + ":media"
+	ScopeReadMutes         Scope = ScopeRead + ":mutes"
+	ScopeReadNotifications Scope = ScopeRead + ":notifications"
+	ScopeReadReports       Scope = ScopeRead + ":reports"
+	ScopeReadSearch        Scope = ScopeRead + ":search"
+	ScopeReadStatuses      Scope = ScopeRead + ":statuses"
+
+	ScopeWriteAccounts      Scope = ScopeWrite + ":accounts"
+	ScopeWriteBlocks        Scope = ScopeWrite + ":blocks"
+	ScopeWriteBookmarks     Scope = ScopeWrite + ":bookmarks"
+	ScopeWriteConversations Scope = ScopeWrite + ":conversations"
+	ScopeWriteFavourites    Scope = ScopeWrite + ":favourites"
+	ScopeWriteFilters       Scope = ScopeWrite + ":filters"
+	ScopeWriteFollows       Scope = ScopeWrite + ":follows"
+	ScopeWriteLists         Scope = ScopeWrite + ":lists"
+	ScopeWriteMedia         Scope = ScopeWrite + ":media"
+	ScopeWriteMutes         Scope = ScopeWrite + ":mutes"
+	ScopeWriteNotifications Scope = ScopeWrite + ":notifications"
+	ScopeWriteReports       Scope = ScopeWrite + ":reports"
+	ScopeWriteStatuses      Scope = ScopeWrite + ":statuses"
+
+	// ScopeAdmin is a GoToSocial-specific
+	// scope which grants all admin scopes.
+	ScopeAdmin      Scope = "admin"
+	ScopeAdminRead  Scope = ScopeAdmin + ":read"
+	ScopeAdminWrite Scope = ScopeAdmin + ":write"
+
+	ScopeAdminReadAccounts      Scope = ScopeAdminRead + ":accounts"
+	ScopeAdminReadReports       Scope = ScopeAdminRead + ":reports"
+	ScopeAdminReadDomainAllows  Scope = ScopeAdminRead + ":domain_allows"
+	ScopeAdminReadDomainBlocks  Scope = ScopeAdminRead + ":domain_blocks"
+	ScopeAdminWriteAccounts     Scope = ScopeAdminWrite + ":accounts"
+	ScopeAdminWriteReports      Scope = ScopeAdminWrite + ":reports"
+	ScopeAdminWriteDomainAllows Scope = ScopeAdminWrite + ":domain_allows"
+	ScopeAdminWriteDomainBlocks Scope = ScopeAdminWrite + ":domain_blocks"
+)
+
+// knownScopes contains all scopes
+// which may be requested by a client.
+var knownScopes = map[Scope]struct{}{
+	ScopeRead:                   {},
+	ScopeWrite:                  {},
+	ScopeFollow:                 {},
+	ScopePush:                   {},
+	ScopeProfile:                {},
+	ScopeUser:                   {},
+	ScopeReadAccounts:           {},
+	ScopeReadBlocks:             {},
+	ScopeReadBookmarks:          {},
+	ScopeReadConversations:      {},
+	ScopeReadFavourites:         {},
+	ScopeReadFilters:            {},
+	ScopeReadFollows:            {},
+	ScopeReadLists:              {},
+	ScopeReadMedia:              {},
+	ScopeReadMutes:              {},
+	ScopeReadNotifications:      {},
+	ScopeReadReports:            {},
+	ScopeReadSearch:             {},
+	ScopeReadStatuses:           {},
+	ScopeWriteAccounts:          {},
+	ScopeWriteBlocks:            {},
+	ScopeWriteBookmarks:         {},
+	ScopeWriteConversations:     {},
+	ScopeWriteFavourites:        {},
+	ScopeWriteFilters:           {},
+	ScopeWriteFollows:           {},
+	ScopeWriteLists:             {},
+	ScopeWriteMedia:             {},
+	ScopeWriteMutes:             {},
+	ScopeWriteNotifications:     {},
+	ScopeWriteReports:           {},
+	ScopeWriteStatuses:          {},
+	ScopeAdmin:                  {},
+	ScopeAdminRead:              {},
+	ScopeAdminWrite:             {},
+	ScopeAdminReadAccounts:      {},
+	ScopeAdminReadReports:       {},
+	ScopeAdminReadDomainAllows:  {},
+	ScopeAdminReadDomainBlocks:  {},
+	ScopeAdminWriteAccounts:     {},
+	ScopeAdminWriteReports:      {},
+	ScopeAdminWriteDomainAllows: {},
+	ScopeAdminWriteDomainBlocks: {},
+}
+
+// followScopes are the granular scopes
+// granted by the deprecated "follow" scope.
+var followScopes = map[Scope]struct{}{
+	ScopeReadBlocks:   {},
+	ScopeWriteBlocks:  {},
+	ScopeReadFollows:  {},
+	ScopeWriteFollows: {},
+	ScopeReadMutes:    {},
+	ScopeWriteMutes:   {},
+}
+
+// userScopes are the scopes granted
+// by the deprecated "user" scope.
+var userScopes = []Scope{
+	ScopeRead,
+	ScopeWrite,
+	ScopeFollow,
+	ScopePush,
+}
+
+// grants returns whether scope s grants the
+// other scope, either by being equal to it,
+// or by being a parent scope of it.
+func (s Scope) grants(other Scope) bool {
+	switch {
+	case s == other:
+		return true
+
+	// eg., "read" grants "read:statuses",
+	// and "admin:read" grants "admin:read:reports".
+	case strings.HasPrefix(string(other), string(s)+":"):
+		return true
+
+	case s == ScopeFollow:
+		_, ok := followScopes[other]
+		return ok
+
+	case s == ScopeUser:
+		return slices.ContainsFunc(
+			userScopes,
+			func(scope Scope) bool { return scope.grants(other) },
+		)
+
+	default:
+		return false
+	}
+}
+
+// Permits returns whether the given space-separated
+// scope string (as stored on a token or application)
+// contains a scope that grants this scope.
+func (s Scope) Permits(scopes string) bool {
+	for _, granted := range strings.Fields(scopes) {
+		if Scope(granted).grants(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateScopes checks that the given space-separated scope
+// string contains only scopes known to this instance, returning
+// a helpful error if not.
+func ValidateScopes(scopes string) error {
+	for _, scope := range strings.Fields(scopes) {
+		if _, ok := knownScopes[Scope(scope)]; !ok {
+			return fmt.Errorf("scope %s not recognized", scope)
+		}
+	}
+	return nil
+}
+
+// ScopesPermitted checks that every scope in the requested
+// space-separated scope string is permitted by the allowed
+// space-separated scope string, eg., those of an application.
+func ScopesPermitted(requested string, allowed string) bool {
+	for _, scope := range strings.Fields(requested) {
+		if !Scope(scope).Permits(allowed) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth_test
+
+import (
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func TestScopePermits(t *testing.T) {
+	for _, test := range []struct {
+		scope   oauth.Scope
+		granted string
+		expect  bool
+	}{
+		{oauth.ScopeReadStatuses, "read", true},
+		{oauth.ScopeReadStatuses, "read:statuses", true},
+		{oauth.ScopeReadStatuses, "read:accounts write", false},
+		{oauth.ScopeWriteStatuses, "read", false},
+		{oauth.ScopeWriteStatuses, "read write follow push", true},
+		{oauth.ScopeWriteFollows, "follow", true},
+		{oauth.ScopeWriteStatuses, "follow", false},
+		{oauth.ScopeAdminReadAccounts, "admin:read", true},
+		{oauth.ScopeAdminWriteAccounts, "admin:read", false},
+		{oauth.ScopeAdminWriteReports, "admin", true},
+		{oauth.ScopeAdminWrite, "read write", false},
+		{oauth.ScopeRead, "read:statuses", false},
+		{oauth.ScopeReadStatuses, "", false},
+		{oauth.ScopeWriteStatuses, "user admin", true},
+		{oauth.ScopeWriteFollows, "user", true},
+		{oauth.ScopeAdminReadReports, "user admin", true},
+		{oauth.ScopeAdminReadReports, "user", false},
+	} {
+		if actual := test.scope.Permits(test.granted); actual != test.expect {
+			t.Errorf("expected %s permitted by %q to be %v, got %v",
+				test.scope, test.granted, test.expect, actual)
+		}
+	}
+}
+
+func TestValidateScopes(t *testing.T) {
+	for _, test := range []struct {
+		scopes string
+		valid  bool
+	}{
+		{"read", true},
+		{"read write follow push", true},
+		{"read:statuses write:media admin:read:reports", true},
+		{"", true},
+		{"read:nothing", false},
+		{"read  write", true},
+		{"READ", false},
+		{"user admin", true},
+	} {
+		err := oauth.ValidateScopes(test.scopes)
+		if (err == nil) != test.valid {
+			t.Errorf("expected scopes %q valid to be %v, got error %v",
+				test.scopes, test.valid, err)
+		}
+	}
+}
+
+func TestScopesPermitted(t *testing.T) {
+	for _, test := range []struct {
+		requested string
+		allowed   string
+		expect    bool
+	}{
+		{"read", "read write", true},
+		{"read:statuses write:statuses", "read write", true},
+		{"read write", "read", false},
+		{"write", "write:statuses", false},
+		{"", "read", true},
+		{"read write admin:read", "user admin", true},
+	} {
+		if actual := oauth.ScopesPermitted(test.requested, test.allowed); actual != test.expect {
+			t.Errorf("expected %q permitted by %q to be %v, got %v",
+				test.requested, test.allowed, test.expect, actual)
+		}
+	}
+}
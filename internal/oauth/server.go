@@ -122,6 +122,39 @@ func New(ctx context.Context, database db.DB) Server {
 		}
 		return userID, nil
 	})
+	srv.SetClientScopeHandler(func(tgr *oauth2.TokenGenerateRequest) (bool, error) {
+		if err := ValidateScopes(tgr.Scope); err != nil {
+			return false, nil
+		}
+
+		var ctx context.Context
+		if tgr.Request != nil {
+			ctx = tgr.Request.Context()
+		} else {
+			ctx = context.Background()
+		}
+
+		app, err := database.GetApplicationByClientID(ctx, tgr.ClientID)
+		if err != nil {
+			return false, fmt.Errorf("error getting application for client %s: %w", tgr.ClientID, err)
+		}
+
+		if tgr.Scope == "" {
+			// No scope requested, so default to those given at
+			// app creation, else the token would be unusable.
+			// The token is generated from this same request,
+			// so setting the scope here sets it on the token.
+			tgr.Scope = app.Scopes
+			if tgr.Scope == "" {
+				tgr.Scope = string(ScopeRead)
+			}
+			return true, nil
+		}
+
+		// Requested scopes must be a subset
+		// of those given at app creation.
+		return ScopesPermitted(tgr.Scope, app.Scopes), nil
+	})
 	srv.SetClientInfoHandler(server.ClientFormHandler)
 	return &s{
 		server: srv,
@@ -0,0 +1,573 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// archiveStaleAfter is how long after creation an archive
+// that's still processing is assumed to have been abandoned,
+// so that it no longer prevents new archives being built.
+const archiveStaleAfter = 24 * time.Hour
+
+// ArchiveCreate queues the building of a new archive
+// of the requester's profile, statuses, likes, bookmarks
+// and media, returning the archive so that the caller
+// can check when it's ready to download.
+func (p *Processor) ArchiveCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) (*apimodel.AccountArchive, gtserror.WithCode) {
+	archives, err := p.state.DB.GetAccountArchivesByAccountID(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting archives: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Building an archive is expensive, so
+	// only allow one to be built at a time.
+	for _, archive := range archives {
+		if archive.State == gtsmodel.AccountArchiveStateProcessing &&
+			time.Since(archive.CreatedAt) < archiveStaleAfter {
+			const text = "an archive is already being built for this account"
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+	}
+
+	archive := &gtsmodel.AccountArchive{
+		ID:        id.NewULID(),
+		AccountID: requester.ID,
+		Account:   requester,
+		State:     gtsmodel.AccountArchiveStateProcessing,
+	}
+
+	if err := p.state.DB.PutAccountArchive(ctx, archive); err != nil {
+		err = gtserror.Newf("db error putting archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Take a copy of the archive for the
+	// response, before processing begins.
+	apiArchive := p.converter.AccountArchiveToAPIAccountArchive(ctx, archive)
+
+	// Build the archive asynchronously.
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		p.buildArchive(ctx, requester, archive)
+	})
+
+	return apiArchive, nil
+}
+
+// ArchivesGet returns all unexpired archives
+// requested by the requester, newest first.
+func (p *Processor) ArchivesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.AccountArchive, gtserror.WithCode) {
+	archives, err := p.state.DB.GetAccountArchivesByAccountID(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting archives: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	now := time.Now()
+	apiArchives := make([]*apimodel.AccountArchive, 0, len(archives))
+	for _, archive := range archives {
+		if archive.Expired(now) {
+			// Awaiting cleanup.
+			continue
+		}
+
+		apiArchive := p.converter.AccountArchiveToAPIAccountArchive(ctx, archive)
+		apiArchives = append(apiArchives, apiArchive)
+	}
+
+	return apiArchives, nil
+}
+
+// ArchiveGet returns the archive with the given ID,
+// if it was requested by requester and hasn't expired.
+func (p *Processor) ArchiveGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*apimodel.AccountArchive, gtserror.WithCode) {
+	archive, errWithCode := p.getArchive(ctx, requester, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.AccountArchiveToAPIAccountArchive(ctx, archive), nil
+}
+
+// ArchiveFileGet returns the zip file of the archive
+// with the given ID, if it was requested by requester,
+// is ready to download, and hasn't expired.
+func (p *Processor) ArchiveFileGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*apimodel.Content, gtserror.WithCode) {
+	archive, errWithCode := p.getArchive(ctx, requester, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if !archive.Ready() {
+		const text = "archive is not ready to download"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	content := &apimodel.Content{
+		ContentType:    "application/zip",
+		ContentLength:  archive.Size,
+		ContentUpdated: archive.UpdatedAt,
+	}
+
+	// If running on S3 storage with proxying disabled then
+	// just fetch pre-signed URL instead of the content.
+	if url := p.state.Storage.URL(ctx, archive.Path); url != nil {
+		content.URL = url
+		return content, nil
+	}
+
+	rc, err := p.state.Storage.GetStream(ctx, archive.Path)
+	if err != nil {
+		err := gtserror.Newf("error getting archive %s from storage: %w", archive.Path, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	content.Content = rc
+
+	return content, nil
+}
+
+// getArchive gets the archive with the given ID, returning
+// 404 if it wasn't requested by requester or has expired.
+func (p *Processor) getArchive(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*gtsmodel.AccountArchive, gtserror.WithCode) {
+	archive, err := p.state.DB.GetAccountArchiveByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if archive == nil ||
+		archive.AccountID != requester.ID ||
+		archive.Expired(time.Now()) {
+		err := gtserror.Newf("archive %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return archive, nil
+}
+
+// deleteArchives deletes all archives
+// requested by account, and their files.
+func (p *Processor) deleteArchives(ctx context.Context, account *gtsmodel.Account) error {
+	archives, err := p.state.DB.GetAccountArchivesByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting archives: %w", err)
+	}
+
+	for _, archive := range archives {
+		if archive.Path != "" {
+			err := p.state.Storage.Delete(ctx, archive.Path)
+			if err != nil && !storage.IsNotFound(err) {
+				return gtserror.Newf("error deleting archive %s from storage: %w", archive.Path, err)
+			}
+		}
+
+		if err := p.state.DB.DeleteAccountArchiveByID(ctx, archive.ID); err != nil {
+			return gtserror.Newf("db error deleting archive: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// buildArchive builds the zip file for the given
+// archive, stores it, and marks the archive as
+// ready to download (or failed, on error).
+func (p *Processor) buildArchive(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	archive *gtsmodel.AccountArchive,
+) {
+	path, size, err := p.writeArchive(ctx, requester, archive)
+	if err != nil {
+		log.Errorf(ctx, "error building archive %s: %v", archive.ID, err)
+		p.failArchive(ctx, archive)
+		return
+	}
+
+	archive.State = gtsmodel.AccountArchiveStateReady
+	archive.Path = path
+	archive.Size = size
+	archive.ExpiresAt = time.Now().Add(config.GetAccountsArchiveExpiry())
+	if err := p.state.DB.UpdateAccountArchive(ctx, archive,
+		"state",
+		"path",
+		"size",
+		"expires_at",
+	); err != nil {
+		log.Errorf(ctx, "db error updating archive: %v", err)
+	}
+}
+
+// failArchive marks the given archive as failed. Failed
+// archives expire just like ready ones, so that they're
+// eventually cleaned up rather than kept forever.
+func (p *Processor) failArchive(
+	ctx context.Context,
+	archive *gtsmodel.AccountArchive,
+) {
+	archive.State = gtsmodel.AccountArchiveStateFailed
+	archive.ExpiresAt = time.Now().Add(config.GetAccountsArchiveExpiry())
+	if err := p.state.DB.UpdateAccountArchive(ctx, archive,
+		"state",
+		"expires_at",
+	); err != nil {
+		log.Errorf(ctx, "db error updating archive: %v", err)
+	}
+}
+
+// ArchivesFailInterrupted marks archives that were still
+// processing when the instance was last stopped as failed,
+// as their building was interrupted and will never finish.
+// It should be called once on startup, before workers start.
+func (p *Processor) ArchivesFailInterrupted(ctx context.Context) error {
+	archives, err := p.state.DB.GetAccountArchivesByState(ctx,
+		gtsmodel.AccountArchiveStateProcessing,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting processing archives: %w", err)
+	}
+
+	for _, archive := range archives {
+		log.Infof(ctx, "marking interrupted archive %s as failed", archive.ID)
+		p.failArchive(ctx, archive)
+	}
+
+	return nil
+}
+
+// archiveWriter wraps a zip writer with
+// the files to include in an archive.
+type archiveWriter struct {
+	zw *zip.Writer
+
+	// Media files to include,
+	// by storage path => name.
+	media map[string]string
+}
+
+// writeJSON serializes the given ActivityStreams
+// type as JSON, and writes it to the named file.
+func (w *archiveWriter) writeJSON(name string, t vocab.Type) error {
+	m, err := ap.Serialize(t)
+	if err != nil {
+		return gtserror.Newf("error serializing %s: %w", name, err)
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return gtserror.Newf("error marshaling %s: %w", name, err)
+	}
+
+	f, err := w.zw.Create(name)
+	if err != nil {
+		return gtserror.Newf("error creating %s: %w", name, err)
+	}
+
+	if _, err := f.Write(b); err != nil {
+		return gtserror.Newf("error writing %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// archiveAttachment returns a copy of the given
+// attachment with its URL pointing to its file in
+// the archive, and adds its file to be included.
+func (w *archiveWriter) archiveAttachment(attachment *gtsmodel.MediaAttachment) *gtsmodel.MediaAttachment {
+	if attachment == nil || !util.PtrOrValue(attachment.Cached, false) || attachment.File.Path == "" {
+		// Nothing stored
+		// to include.
+		return attachment
+	}
+
+	// Name the file by attachment ID, in the
+	// same directory that Mastodon archives use.
+	name := "media_attachments/" + attachment.ID + path.Ext(attachment.File.Path)
+	w.media[attachment.File.Path] = name
+
+	attachment2 := new(gtsmodel.MediaAttachment)
+	*attachment2 = *attachment
+	attachment2.URL = "/" + name
+	return attachment2
+}
+
+// writeMedia writes all media files
+// added to the archive from storage.
+func (w *archiveWriter) writeMedia(ctx context.Context, st *storage.Driver) error {
+	for key, name := range w.media {
+		rc, err := st.GetStream(ctx, key)
+		if err != nil {
+			if storage.IsNotFound(err) {
+				log.Warnf(ctx, "media %s not found in storage", key)
+				continue
+			}
+			return gtserror.Newf("error getting media %s from storage: %w", key, err)
+		}
+
+		// Media files are already compressed,
+		// so store them without deflating.
+		f, err := w.zw.CreateHeader(&zip.FileHeader{
+			Name:   name,
+			Method: zip.Store,
+		})
+		if err != nil {
+			rc.Close()
+			return gtserror.Newf("error creating %s: %w", name, err)
+		}
+
+		_, err = io.Copy(f, rc)
+		rc.Close()
+		if err != nil {
+			return gtserror.Newf("error writing %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// writeArchive writes the zip file for the given
+// archive to a temporary file, then moves it into
+// storage, returning its storage path and size.
+func (p *Processor) writeArchive(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	archive *gtsmodel.AccountArchive,
+) (string, int64, error) {
+	tmp, err := os.CreateTemp(os.TempDir(), "gotosocial-*")
+	if err != nil {
+		return "", 0, gtserror.Newf("error creating temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := &archiveWriter{
+		zw:    zip.NewWriter(tmp),
+		media: make(map[string]string),
+	}
+
+	if err := p.writeArchiveFiles(ctx, requester, w); err != nil {
+		tmp.Close()
+		return "", 0, err
+	}
+
+	if err := w.zw.Close(); err != nil {
+		tmp.Close()
+		return "", 0, gtserror.Newf("error closing zip: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return "", 0, gtserror.Newf("error closing temp file: %w", err)
+	}
+
+	// Store the archive alongside the account's media,
+	// using a path the fileserver will refuse to serve.
+	key := requester.ID + "/archive/original/" + archive.ID + ".zip"
+	size, err := p.state.Storage.PutFile(ctx, key, tmp.Name(), "application/zip")
+	if err != nil {
+		return "", 0, gtserror.Newf("error storing archive: %w", err)
+	}
+
+	return key, size, nil
+}
+
+// writeArchiveFiles writes actor.json, outbox.json,
+// likes.json, bookmarks.json and media of requester.
+func (p *Processor) writeArchiveFiles(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	w *archiveWriter,
+) error {
+	// Ensure we have the avatar and header.
+	account, err := p.state.DB.GetAccountByID(ctx, requester.ID)
+	if err != nil {
+		return gtserror.Newf("db error getting account: %w", err)
+	}
+
+	// Point avatar and header at their archived
+	// files, on a copy so as not to touch caches.
+	account2 := new(gtsmodel.Account)
+	*account2 = *account
+	account2.AvatarMediaAttachment = w.archiveAttachment(account.AvatarMediaAttachment)
+	account2.HeaderMediaAttachment = w.archiveAttachment(account.HeaderMediaAttachment)
+
+	actor, err := p.converter.AccountToAS(ctx, account2)
+	if err != nil {
+		return gtserror.Newf("error converting account: %w", err)
+	}
+
+	if err := w.writeJSON("actor.json", actor); err != nil {
+		return err
+	}
+
+	// Get all of the account's own statuses,
+	// excluding boosts, which aren't Creates.
+	statuses, err := p.state.DB.GetAccountStatuses(ctx,
+		account.ID,
+		0,     // no limit
+		false, // include replies
+		true,  // exclude boosts
+		"",    // no max ID
+		"",    // no min ID
+		false, // not only media
+		false, // not only public
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting statuses: %w", err)
+	}
+
+	// Oldest first, so that replies come
+	// after the statuses they reply to.
+	slices.Reverse(statuses)
+
+	for i, status := range statuses {
+		if len(status.Attachments) == 0 {
+			continue
+		}
+
+		// Point attachments at their archived files,
+		// on a copy so as not to touch caches.
+		status2 := new(gtsmodel.Status)
+		*status2 = *status
+		status2.Attachments = make([]*gtsmodel.MediaAttachment, 0, len(status.Attachments))
+		for _, attachment := range status.Attachments {
+			status2.Attachments = append(status2.Attachments, w.archiveAttachment(attachment))
+		}
+		statuses[i] = status2
+	}
+
+	outbox, err := p.converter.StatusesToASArchiveOutbox(ctx, "outbox.json", statuses)
+	if err != nil {
+		return gtserror.Newf("error converting statuses: %w", err)
+	}
+
+	if err := w.writeJSON("outbox.json", outbox); err != nil {
+		return err
+	}
+
+	// Get statuses liked by the account.
+	faves, err := p.state.DB.GetAccountFaves(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting faves: %w", err)
+	}
+
+	faveStatusIDs := make([]string, 0, len(faves))
+	for _, fave := range faves {
+		faveStatusIDs = append(faveStatusIDs, fave.StatusID)
+	}
+
+	likes, err := p.archiveStatusURIs(ctx, "likes.json", faveStatusIDs)
+	if err != nil {
+		return err
+	}
+
+	if err := w.writeJSON("likes.json", likes); err != nil {
+		return err
+	}
+
+	// Get statuses bookmarked by the account.
+	bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, account.ID, 0, "", "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting bookmarks: %w", err)
+	}
+
+	bookmarkStatusIDs := make([]string, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		bookmarkStatusIDs = append(bookmarkStatusIDs, bookmark.StatusID)
+	}
+
+	bookmarked, err := p.archiveStatusURIs(ctx, "bookmarks.json", bookmarkStatusIDs)
+	if err != nil {
+		return err
+	}
+
+	if err := w.writeJSON("bookmarks.json", bookmarked); err != nil {
+		return err
+	}
+
+	// Finally, all the media
+	// files referenced above.
+	return w.writeMedia(ctx, p.state.Storage)
+}
+
+// archiveStatusURIs returns an OrderedCollection
+// with the given ID, of URIs of the given statuses.
+func (p *Processor) archiveStatusURIs(
+	ctx context.Context,
+	id string,
+	statusIDs []string,
+) (vocab.ActivityStreamsOrderedCollection, error) {
+	statuses, err := p.state.DB.GetStatusesByIDs(
+		gtscontext.SetBarebones(ctx),
+		statusIDs,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting statuses: %w", err)
+	}
+
+	uris := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		uris = append(uris, status.URI)
+	}
+
+	collection, err := p.converter.URIsToASArchiveCollection(id, uris)
+	if err != nil {
+		return nil, gtserror.Newf("error converting %s: %w", id, err)
+	}
+
+	return collection, nil
+}
@@ -0,0 +1,241 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type ArchiveTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *ArchiveTestSuite) TestArchiveCreate() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	apiArchive, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("processing", apiArchive.State)
+	suite.Nil(apiArchive.URL)
+
+	// Only one archive can be
+	// built at a time per account.
+	_, errWithCode = suite.accountProcessor.ArchiveCreate(ctx, requester)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Build the archive by running
+	// the queued processing function.
+	build, ok := suite.state.Workers.Processing.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected archive build to be queued")
+	}
+	build(ctx)
+
+	apiArchive, errWithCode = suite.accountProcessor.ArchiveGet(ctx, requester, apiArchive.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("ready", apiArchive.State)
+	suite.NotNil(apiArchive.ExpiresAt)
+	suite.Equal("http://localhost:8080/api/v1/exports/archive/"+apiArchive.ID+"/download", *apiArchive.URL)
+
+	// Download and open the zip file.
+	content, errWithCode := suite.accountProcessor.ArchiveFileGet(ctx, requester, apiArchive.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	b, err := io.ReadAll(content.Content)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	content.Content.Close()
+	suite.EqualValues(apiArchive.Size, len(b))
+
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	readJSON := func(name string) map[string]any {
+		f, ok := files[name]
+		if !ok {
+			suite.FailNow("", "expected %s in archive", name)
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		defer rc.Close()
+
+		m := make(map[string]any)
+		if err := json.NewDecoder(rc).Decode(&m); err != nil {
+			suite.FailNow(err.Error())
+		}
+		return m
+	}
+
+	actor := readJSON("actor.json")
+	suite.Equal(requester.URI, actor["id"])
+
+	// Avatar should point at its file in the archive.
+	avatar := suite.testAttachments["local_account_1_avatar"]
+	avatarName := "media_attachments/" + avatar.ID + path.Ext(avatar.File.Path)
+	suite.Equal("/"+avatarName, actor["icon"].(map[string]any)["url"])
+	suite.Contains(files, avatarName)
+
+	// Outbox should contain a Create
+	// for each of the account's statuses.
+	statuses, err := suite.state.DB.GetAccountStatuses(ctx, requester.ID, 0, false, true, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	outbox := readJSON("outbox.json")
+	suite.Equal("OrderedCollection", outbox["type"])
+	suite.EqualValues(len(statuses), outbox["totalItems"])
+
+	items := outbox["orderedItems"].([]any)
+	suite.Len(items, len(statuses))
+	for _, item := range items {
+		create := item.(map[string]any)
+		suite.Equal("Create", create["type"])
+		suite.IsType(map[string]any{}, create["object"])
+	}
+
+	// Attachments of statuses should be included.
+	attachment := suite.testAttachments["local_account_1_status_4_attachment_1"]
+	suite.Contains(files, "media_attachments/"+attachment.ID+path.Ext(attachment.File.Path))
+
+	// Zork has bookmarked one of admin's statuses.
+	bookmarks := readJSON("bookmarks.json")
+	suite.Equal([]any{suite.testStatuses["admin_account_status_1"].URI}, bookmarks["orderedItems"])
+
+	likes := readJSON("likes.json")
+	suite.Equal("OrderedCollection", likes["type"])
+
+	// Once expired, the archive is gone.
+	archive, err := suite.state.DB.GetAccountArchiveByID(ctx, apiArchive.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	archive.ExpiresAt = time.Now().Add(-time.Minute)
+	if err := suite.state.DB.UpdateAccountArchive(ctx, archive, "expires_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, errWithCode = suite.accountProcessor.ArchiveFileGet(ctx, requester, apiArchive.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	archives, err := suite.state.DB.GetExpiredAccountArchives(ctx, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(archives, 1)
+	suite.Equal(gtsmodel.AccountArchiveStateReady, archives[0].State)
+}
+
+func (suite *ArchiveTestSuite) TestArchiveCreateStale() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	// An archive that's been processing
+	// for days has been abandoned, and
+	// shouldn't block building a new one.
+	stale := &gtsmodel.AccountArchive{
+		ID:        id.NewULID(),
+		CreatedAt: time.Now().Add(-48 * time.Hour),
+		AccountID: requester.ID,
+		State:     gtsmodel.AccountArchiveStateProcessing,
+	}
+	if err := suite.state.DB.PutAccountArchive(ctx, stale); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiArchive, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("processing", apiArchive.State)
+}
+
+func (suite *ArchiveTestSuite) TestArchivesFailInterrupted() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	apiArchive, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Pretend the instance was stopped before the
+	// build ran, then mark it failed on startup.
+	if _, ok := suite.state.Workers.Processing.Queue.Pop(); !ok {
+		suite.FailNow("expected archive build to be queued")
+	}
+
+	if err := suite.accountProcessor.ArchivesFailInterrupted(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	archive, err := suite.state.DB.GetAccountArchiveByID(ctx, apiArchive.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.AccountArchiveStateFailed, archive.State)
+
+	// Failed archive should expire
+	// so that it gets cleaned up.
+	suite.False(archive.ExpiresAt.IsZero())
+	suite.True(archive.Expired(time.Now().Add(365 * 24 * time.Hour)))
+
+	// And shouldn't prevent
+	// building a new archive.
+	if _, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, requester); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+}
+
+func TestArchiveTestSuite(t *testing.T) {
+	suite.Run(t, new(ArchiveTestSuite))
+}
@@ -96,7 +96,8 @@ func (p *Processor) Delete(
 }
 
 // deleteUserAndTokensForAccount deletes the gtsmodel.User and
-// any OAuth tokens and applications for the given account.
+// any OAuth tokens, applications and web push subscriptions
+// for the given account.
 //
 // Callers to this function should already have checked that
 // this is a local account, or else it won't have a user associated
@@ -129,6 +130,11 @@ func (p *Processor) deleteUserAndTokensForAccount(ctx context.Context, account *
 		}
 	}
 
+	// Delete any web push subscriptions created with the tokens.
+	if err := p.state.DB.DeleteWebPushSubscriptionsByAccountID(ctx, account.ID); err != nil {
+		return gtserror.Newf("db error deleting web push subscriptions: %w", err)
+	}
+
 	columns, err := stubbifyUser(user)
 	if err != nil {
 		return gtserror.Newf("error stubbifying user: %w", err)
@@ -297,6 +303,9 @@ func (p *Processor) deleteAccountBlocks(ctx context.Context, account *gtsmodel.A
 	if err := p.state.DB.DeleteAccountBlocks(ctx, account.ID); err != nil {
 		return gtserror.Newf("db error deleting account blocks for %s: %w", account.ID, err)
 	}
+	if err := p.state.DB.DeleteAccountDomainBlocks(ctx, account.ID); err != nil {
+		return gtserror.Newf("db error deleting account domain blocks for %s: %w", account.ID, err)
+	}
 	return nil
 }
 
@@ -430,6 +439,21 @@ func (p *Processor) deleteAccountNotifications(ctx context.Context, account *gts
 		return gtserror.Newf("error deleting notifications by account: %w", err)
 	}
 
+	// Delete all notification requests targeting given account.
+	if err := p.state.DB.DeleteNotificationRequests(ctx, account.ID, ""); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting notification requests targeting account: %w", err)
+	}
+
+	// Delete all notification requests from given account.
+	if err := p.state.DB.DeleteNotificationRequests(ctx, "", account.ID); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting notification requests from account: %w", err)
+	}
+
+	// Delete notification policy of given account.
+	if err := p.state.DB.DeleteNotificationPolicy(ctx, account.ID); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting notification policy: %w", err)
+	}
+
 	return nil
 }
 
@@ -480,6 +504,50 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting followed tags by account: %w", err)
 	}
 
+	// Delete all announcement dismissals and reactions owned by given account.
+	if err := p.state.DB.DeleteAnnouncementDataByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting announcement data by account: %w", err)
+	}
+
+	// Delete any staff pick of given account, and all
+	// suggestion dismissals owned by or targeting it.
+	if err := p.state.DB.DeleteSuggestionDataByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting suggestion data by account: %w", err)
+	}
+
+	// Cancel publication of any scheduled statuses owned by given account.
+	scheduledStatuses, err := p.state.DB.GetScheduledStatusesForAcct(
+		gtscontext.SetBarebones(ctx),
+		account.ID,
+		nil,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting scheduled statuses by account: %w", err)
+	}
+
+	for _, scheduledStatus := range scheduledStatuses {
+		p.state.Workers.Scheduler.Cancel(scheduledStatus.ID)
+	}
+
+	// Delete all scheduled statuses owned by given account.
+	if err := p.state.DB.DeleteScheduledStatusesByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting scheduled statuses by account: %w", err)
+	}
+
+	// Delete all imports uploaded by given account.
+	if err := p.state.DB.DeleteImportsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting imports by account: %w", err)
+	}
+
+	// Delete all archives requested by given account.
+	if err := p.deleteArchives(ctx, account); err != nil {
+		return gtserror.Newf("error deleting archives by account: %w", err)
+	}
+
 	// Delete account stats model.
 	if err := p.state.DB.DeleteAccountStats(ctx, account.ID); err != nil {
 		return gtserror.Newf("error deleting stats for account: %w", err)
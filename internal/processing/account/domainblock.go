@@ -0,0 +1,287 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DomainBlocksGet returns a page of
+// domains blocked by the requester.
+func (p *Processor) DomainBlocksGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetAccountDomainBlocks(ctx,
+		requester.ID,
+		page,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting domain blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(blocks)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := blocks[count-1].ID
+	hi := blocks[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, block := range blocks {
+		domain, err := util.DePunify(block.Domain)
+		if err != nil {
+			log.Errorf(ctx, "error depunifying domain %s: %v", block.Domain, err)
+			continue
+		}
+
+		items = append(items, domain)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/domain_blocks",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// DomainBlockCreate blocks the given domain for the requester,
+// hiding accounts on the domain from them and vice versa, and
+// removing any follows between the requester and the domain.
+func (p *Processor) DomainBlockCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	domain string,
+) gtserror.WithCode {
+	domain, errWithCode := validateBlockDomain(domain)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Check if domain already blocked.
+	_, err := p.state.DB.GetAccountDomainBlock(ctx, requester.ID, domain)
+	if err == nil {
+		// Block already exists, nothing to do.
+		return nil
+	} else if !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error checking existing domain block: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Create and store a new domain block.
+	block := &gtsmodel.AccountDomainBlock{
+		ID:        id.NewULID(),
+		AccountID: requester.ID,
+		Domain:    domain,
+	}
+
+	if err := p.state.DB.PutAccountDomainBlock(ctx, block); err != nil {
+		err = gtserror.Newf("db error creating domain block: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Remove follows to / from the domain,
+	// and process their side effects.
+	msgs, err := p.severDomainFollows(ctx, requester, domain)
+	if err != nil {
+		err = gtserror.Newf("error removing follows: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Batch queue accreted client api messages.
+	p.state.Workers.Client.Queue.Push(msgs...)
+
+	return nil
+}
+
+// DomainBlockRemove removes the requester's
+// block of the given domain, if it exists.
+func (p *Processor) DomainBlockRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	domain string,
+) gtserror.WithCode {
+	domain, errWithCode := validateBlockDomain(domain)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Removing a block that doesn't
+	// exist is a no-op, so just go.
+	if err := p.state.DB.DeleteAccountDomainBlock(ctx, requester.ID, domain); err != nil {
+		err = gtserror.Newf("db error removing domain block: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// validateBlockDomain checks that the given domain is
+// valid for a user domain block, returning it punified.
+func validateBlockDomain(domain string) (string, gtserror.WithCode) {
+	domain = strings.TrimSpace(domain)
+	if domain == "" {
+		const text = "domain must be provided"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	domain, err := util.Punify(domain)
+	if err != nil ||
+		strings.ContainsAny(domain, "/:@ \t") ||
+		!strings.Contains(domain, ".") {
+		text := fmt.Sprintf("invalid domain %s", domain)
+		return "", gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Can't block our own domain.
+	if domain == config.GetHost() ||
+		domain == config.GetAccountDomain() {
+		const text = "cannot block own domain"
+		return "", gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return domain, nil
+}
+
+// severDomainFollows removes all follows and follow requests
+// between requester and accounts on the given domain (or its
+// subdomains), returning client API messages for side effects.
+func (p *Processor) severDomainFollows(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	domain string,
+) ([]*messages.FromClientAPI, error) {
+	var msgs []*messages.FromClientAPI
+
+	// Gather accounts on domain that requester
+	// follows or has requested to follow.
+	follows, err := p.state.DB.GetAccountFollows(ctx, requester.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting follows: %w", err)
+	}
+
+	followReqs, err := p.state.DB.GetAccountFollowRequesting(ctx, requester.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting follow requesting: %w", err)
+	}
+
+	targets := make([]*gtsmodel.Account, 0, len(follows)+len(followReqs))
+	for _, follow := range follows {
+		if isOnDomain(follow.TargetAccount, domain) {
+			targets = append(targets, follow.TargetAccount)
+		}
+	}
+	for _, followReq := range followReqs {
+		if isOnDomain(followReq.TargetAccount, domain) {
+			targets = append(targets, followReq.TargetAccount)
+		}
+	}
+
+	// Unfollow each, this
+	// will federate Undos.
+	for _, target := range targets {
+		unfollowMsgs, err := p.unfollow(ctx, requester, target)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, unfollowMsgs...)
+	}
+
+	// Remove followers on domain,
+	// federating a Reject to each.
+	followers, err := p.state.DB.GetAccountFollowers(ctx, requester.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting followers: %w", err)
+	}
+
+	for _, follow := range followers {
+		if !isOnDomain(follow.Account, domain) {
+			continue
+		}
+
+		if err := p.state.DB.DeleteFollowByID(ctx, follow.ID); err != nil &&
+			!errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error deleting follow %s: %w", follow.ID, err)
+		}
+
+		msgs = append(msgs, &messages.FromClientAPI{
+			APObjectType:   ap.ActivityFollow,
+			APActivityType: ap.ActivityReject,
+			GTSModel:       follow,
+			Origin:         follow.Account,
+			Target:         requester,
+		})
+	}
+
+	// Reject pending follow
+	// requests from domain.
+	followReqs, err = p.state.DB.GetAccountFollowRequests(ctx, requester.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting follow requests: %w", err)
+	}
+
+	for _, followReq := range followReqs {
+		if !isOnDomain(followReq.Account, domain) {
+			continue
+		}
+
+		if err := p.state.DB.DeleteFollowRequestByID(ctx, followReq.ID); err != nil &&
+			!errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error deleting follow request %s: %w", followReq.ID, err)
+		}
+
+		msgs = append(msgs, &messages.FromClientAPI{
+			APObjectType:   ap.ActivityFollow,
+			APActivityType: ap.ActivityReject,
+			GTSModel:       followReq,
+			Origin:         followReq.Account,
+			Target:         requester,
+		})
+	}
+
+	return msgs, nil
+}
+
+// isOnDomain returns whether account is
+// on the given domain, or a subdomain of it.
+func isOnDomain(account *gtsmodel.Account, domain string) bool {
+	return account.Domain == domain ||
+		strings.HasSuffix(account.Domain, "."+domain)
+}
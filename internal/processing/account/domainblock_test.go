@@ -0,0 +1,191 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type DomainBlockTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *DomainBlockTestSuite) TestDomainBlockCreateRemove() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+		remote    = suite.testAccounts["remote_account_3"]
+		filter    = visibility.NewFilter(&suite.state)
+	)
+
+	// Set up a follow in each direction
+	// between requester and remote account.
+	for _, follow := range []*gtsmodel.Follow{
+		{
+			ID:              id.NewULID(),
+			URI:             "http://localhost:8080/users/the_mighty_zork/follow/" + id.NewULID(),
+			AccountID:       requester.ID,
+			TargetAccountID: remote.ID,
+			ShowReblogs:     util.Ptr(true),
+			Notify:          util.Ptr(false),
+		},
+		{
+			ID:              id.NewULID(),
+			URI:             "http://thequeenisstillalive.technology/users/" + id.NewULID(),
+			AccountID:       remote.ID,
+			TargetAccountID: requester.ID,
+			ShowReblogs:     util.Ptr(true),
+			Notify:          util.Ptr(false),
+		},
+	} {
+		if err := suite.db.PutFollow(ctx, follow); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Block the remote account's domain,
+	// twice to check the second is a no-op.
+	for range 2 {
+		if errWithCode := suite.accountProcessor.DomainBlockCreate(ctx, requester, "thequeenisstillalive.technology"); errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+	}
+
+	// Follows in both directions should be gone,
+	// with an Undo and a Reject queued to federate.
+	following, err := suite.db.IsFollowing(ctx, requester.ID, remote.ID)
+	suite.NoError(err)
+	suite.False(following)
+
+	followedBy, err := suite.db.IsFollowing(ctx, remote.ID, requester.ID)
+	suite.NoError(err)
+	suite.False(followedBy)
+
+	msg, _ := suite.getClientMsg(time.Second)
+	suite.Equal(ap.ActivityUndo, msg.APActivityType)
+	suite.Equal(ap.ActivityFollow, msg.APObjectType)
+
+	msg, _ = suite.getClientMsg(time.Second)
+	suite.Equal(ap.ActivityReject, msg.APActivityType)
+	suite.Equal(ap.ActivityFollow, msg.APObjectType)
+
+	// Domain should be listed.
+	resp, errWithCode := suite.accountProcessor.DomainBlocksGet(ctx, requester, &paging.Page{Limit: 10})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal([]interface{}{"thequeenisstillalive.technology"}, resp.Items)
+
+	// Relationship should show the domain block.
+	relationship, errWithCode := suite.accountProcessor.RelationshipGet(ctx, requester, remote.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(relationship.DomainBlocking)
+
+	// The remote account should now be hidden from
+	// the requester, and the requester from it.
+	visible, err := filter.AccountVisible(ctx, requester, remote)
+	suite.NoError(err)
+	suite.False(visible)
+
+	visible, err = filter.AccountVisible(ctx, remote, requester)
+	suite.NoError(err)
+	suite.False(visible)
+
+	// Remove the block again.
+	if errWithCode := suite.accountProcessor.DomainBlockRemove(ctx, requester, "thequeenisstillalive.technology"); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	resp, errWithCode = suite.accountProcessor.DomainBlocksGet(ctx, requester, &paging.Page{Limit: 10})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(resp.Items)
+
+	visible, err = filter.AccountVisible(ctx, requester, remote)
+	suite.NoError(err)
+	suite.True(visible)
+}
+
+func (suite *DomainBlockTestSuite) TestDomainBlockCreateSubdomain() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+		remote    = suite.testAccounts["remote_account_3"]
+	)
+
+	// Bare TLDs can't be blocked.
+	if errWithCode := suite.accountProcessor.DomainBlockCreate(ctx, requester, "technology"); errWithCode == nil {
+		suite.FailNow("expected error blocking tld")
+	}
+
+	// Blocking a parent domain should
+	// also block accounts on subdomains.
+	if err := suite.db.PutAccountDomainBlock(ctx, &gtsmodel.AccountDomainBlock{
+		ID:        id.NewULID(),
+		AccountID: requester.ID,
+		Domain:    remote.Domain,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	blocked, err := suite.db.IsAccountDomainBlocked(ctx, requester.ID, "sub."+remote.Domain)
+	suite.NoError(err)
+	suite.True(blocked)
+
+	// A domain that only shares
+	// a suffix should not be blocked.
+	blocked, err = suite.db.IsAccountDomainBlocked(ctx, requester.ID, "not"+remote.Domain)
+	suite.NoError(err)
+	suite.False(blocked)
+}
+
+func (suite *DomainBlockTestSuite) TestDomainBlockCreateInvalid() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	for domain, code := range map[string]int{
+		"":                    http.StatusBadRequest,
+		"localhost:8080":      http.StatusUnprocessableEntity,
+		"https://example.org": http.StatusUnprocessableEntity,
+	} {
+		errWithCode := suite.accountProcessor.DomainBlockCreate(ctx, requester, domain)
+		if suite.NotNil(errWithCode, domain) {
+			suite.Equal(code, errWithCode.Code(), domain)
+		}
+	}
+}
+
+func TestDomainBlockTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainBlockTestSuite))
+}
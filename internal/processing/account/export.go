@@ -136,6 +136,28 @@ func (p *Processor) ExportBlocks(
 	return records, nil
 }
 
+// ExportDomainBlocks returns a CSV file of
+// account domain blocks created by the requester.
+func (p *Processor) ExportDomainBlocks(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([][]string, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetAccountDomainBlocks(ctx, requester.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting domain blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Convert domain blocks to CSV-compatible records.
+	records, err := p.converter.DomainBlocksToCSV(ctx, blocks)
+	if err != nil {
+		err = gtserror.Newf("error converting domain blocks to records: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return records, nil
+}
+
 // ExportMutes returns a CSV file of
 // account mutes created by the requester.
 func (p *Processor) ExportMutes(
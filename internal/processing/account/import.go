@@ -18,362 +18,1059 @@
 package account
 
 import (
+	"archive/zip"
 	"context"
 	"encoding/csv"
 	"errors"
 	"fmt"
 	"mime/multipart"
+	"net/url"
+	"sync"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
+// maxImportErrors is the maximum number of row
+// errors stored on an import, so that a very
+// large, broken file doesn't bloat the database.
+const maxImportErrors = 100
+
+// importChunkSize is the maximum number of rows of an
+// import processed by one worker task, so that a large
+// import doesn't flood the worker queue, and progress
+// is stored once per chunk rather than once per row.
+const importChunkSize = 50
+
+// importRow processes one row parsed from
+// an import's data file, returning an error
+// suitable for showing to the importer.
+type importRow func(ctx context.Context, job *importJob) error
+
+// importJob tracks processing of the rows of one
+// import, which are pushed in chunks onto the
+// processing worker pool and so run concurrently
+// (except for statuses, which must run in order).
+type importJob struct {
+	p         *Processor
+	requester *gtsmodel.Account
+	imp       *gtsmodel.Import
+
+	// Called once all rows have been
+	// processed, if the import overwrites
+	// existing entries, to remove unwanted.
+	cleanup func(ctx context.Context)
+
+	// Lists by title, created
+	// or found by "lists" rows.
+	lists   map[string]*gtsmodel.List
+	listsMu sync.Mutex
+
+	// Archive opened for a "statuses"
+	// import, and the statuses created
+	// so far, by their URI in the archive.
+	// Rows of a "statuses" import run in
+	// order, so these need no locking.
+	archive  *zip.ReadCloser
+	statuses map[string]*gtsmodel.Status
+
+	// Protects imp.
+	mu sync.Mutex
+}
+
+// ImportData parses the given CSV data file (or archive zip,
+// for statuses) into rows of the given type, and queues the
+// rows for processing on the worker pool, returning the
+// created import so that the caller can check on its progress.
 func (p *Processor) ImportData(
 	ctx context.Context,
 	requester *gtsmodel.Account,
 	data *multipart.FileHeader,
 	importType string,
 	overwrite bool,
-) gtserror.WithCode {
-	switch importType {
+) (*apimodel.Import, gtserror.WithCode) {
+	imp := &gtsmodel.Import{
+		ID:        id.NewULID(),
+		AccountID: requester.ID,
+		Account:   requester,
+		Type:      gtsmodel.NewImportType(importType),
+		Overwrite: &overwrite,
+		State:     gtsmodel.ImportStateProcessing,
+	}
 
-	case "following":
-		return p.importFollowing(
-			ctx,
-			requester,
-			data,
-			overwrite,
-		)
+	if imp.Type == gtsmodel.ImportTypeUnknown {
+		const text = "import type not yet supported"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
 
-	case "blocks":
-		return p.importBlocks(
-			ctx,
-			requester,
-			data,
-			overwrite,
-		)
+	job := &importJob{
+		p:         p,
+		requester: requester,
+		imp:       imp,
+	}
 
-	default:
-		const text = "import type not yet supported"
-		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	if imp.Type == gtsmodel.ImportTypeStatuses {
+		// Statuses come in an archive
+		// zip rather than a CSV file.
+		return p.importStatuses(ctx, job, data)
+	}
+
+	records, errWithCode := readImportRecords(data)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
+
+	// Convert the records into rows
+	// to process for this import type.
+	rows, err := job.parse(ctx, records)
+	if err != nil {
+		err := fmt.Errorf("error converting records to %s: %w", imp.Type, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	imp.TotalItems = len(rows)
+
+	if err := p.state.DB.PutImport(ctx, imp); err != nil {
+		err := gtserror.Newf("db error putting import: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Take a copy of the import for the
+	// response, before processing begins.
+	apiImport := p.converter.ImportToAPIImport(ctx, imp)
+
+	if len(rows) == 0 {
+		// Nothing to process, but an overwrite
+		// will still need to remove entries.
+		p.state.Workers.Processing.Queue.Push(job.finish)
+		return apiImport, nil
+	}
+
+	// Do remaining processing of this import
+	// asynchronously, one worker task per chunk.
+	for _, chunk := range importChunks(rows) {
+		p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+			errs := make([]error, len(chunk))
+			for i, row := range chunk {
+				errs[i] = row(ctx, job)
+			}
+			job.done(ctx, errs...)
+		})
+	}
+
+	return apiImport, nil
 }
 
-func (p *Processor) importFollowing(
+// ImportsGet returns a page of imports
+// uploaded by the requester, newest first.
+func (p *Processor) ImportsGet(
 	ctx context.Context,
 	requester *gtsmodel.Account,
-	followingData *multipart.FileHeader,
-	overwrite bool,
-) gtserror.WithCode {
-	file, err := followingData.Open()
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	imports, err := p.state.DB.GetImportsByAccountID(ctx,
+		requester.ID,
+		page,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting imports: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(imports)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := imports[count-1].ID
+	hi := imports[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, imp := range imports {
+		items = append(items, p.converter.ImportToAPIImport(ctx, imp))
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/import",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// ImportGet returns the import with the
+// given ID, if it was uploaded by requester.
+func (p *Processor) ImportGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*apimodel.Import, gtserror.WithCode) {
+	imp, err := p.state.DB.GetImportByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting import: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if imp == nil || imp.AccountID != requester.ID {
+		err := gtserror.Newf("import %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return p.converter.ImportToAPIImport(ctx, imp), nil
+}
+
+// readImportRecords reads all CSV
+// records out of the given data file.
+func readImportRecords(data *multipart.FileHeader) ([][]string, gtserror.WithCode) {
+	file, err := data.Open()
 	if err != nil {
-		err := fmt.Errorf("error opening following data file: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		err := fmt.Errorf("error opening data file: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
 	defer file.Close()
 
+	// Row lengths are checked by
+	// each converter, so allow
+	// them to vary in the reader.
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
 	// Parse records out of the file.
-	records, err := csv.NewReader(file).ReadAll()
+	records, err := reader.ReadAll()
 	if err != nil {
-		err := fmt.Errorf("error reading following data file: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		err := fmt.Errorf("error reading data file: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
 
-	// Convert the records into a slice of barebones follows.
-	//
-	// Only TargetAccount.Username, TargetAccount.Domain,
-	// and ShowReblogs will be set on each Follow.
-	follows, err := p.converter.CSVToFollowing(ctx, records)
-	if err != nil {
-		err := fmt.Errorf("error converting records to follows: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+	return records, nil
+}
+
+// parse converts the given records into rows
+// for the job's import type, also setting the
+// job's cleanup function if overwriting.
+func (j *importJob) parse(ctx context.Context, records [][]string) ([]importRow, error) {
+	overwrite := *j.imp.Overwrite
+
+	switch j.imp.Type {
+
+	case gtsmodel.ImportTypeFollowing:
+		// Only TargetAccount.Username, TargetAccount.Domain,
+		// ShowReblogs and Notify will be set on each Follow.
+		follows, err := j.p.converter.CSVToFollowing(ctx, records)
+		if err != nil {
+			return nil, err
+		}
+
+		if overwrite {
+			j.cleanup = j.p.importFollowingCleanupF(j.requester, follows)
+		}
+
+		rows := make([]importRow, 0, len(follows))
+		for _, follow := range follows {
+			rows = append(rows, importFollowingRow(follow))
+		}
+		return rows, nil
+
+	case gtsmodel.ImportTypeBlocks:
+		// Only TargetAccount.Username and TargetAccount.Domain
+		// will be set on each Block.
+		blocks, err := j.p.converter.CSVToBlocks(ctx, records)
+		if err != nil {
+			return nil, err
+		}
+
+		if overwrite {
+			j.cleanup = j.p.importBlocksCleanupF(j.requester, blocks)
+		}
+
+		rows := make([]importRow, 0, len(blocks))
+		for _, block := range blocks {
+			rows = append(rows, importBlocksRow(block))
+		}
+		return rows, nil
+
+	case gtsmodel.ImportTypeDomainBlocks:
+		// Only Domain will be set on each AccountDomainBlock.
+		blocks, err := j.p.converter.CSVToDomainBlocks(ctx, records)
+		if err != nil {
+			return nil, err
+		}
+
+		if overwrite {
+			j.cleanup = j.p.importDomainBlocksCleanupF(j.requester, blocks)
+		}
+
+		rows := make([]importRow, 0, len(blocks))
+		for _, block := range blocks {
+			rows = append(rows, importDomainBlocksRow(block))
+		}
+		return rows, nil
+
+	case gtsmodel.ImportTypeLists:
+		// Only List.Title, Follow.TargetAccount.Username and
+		// Follow.TargetAccount.Domain will be set on each ListEntry.
+		entries, err := j.p.converter.CSVToListEntries(ctx, records)
+		if err != nil {
+			return nil, err
+		}
+
+		if overwrite {
+			j.cleanup = j.p.importListsCleanupF(j.requester, entries)
+		}
+
+		j.lists = make(map[string]*gtsmodel.List)
+		rows := make([]importRow, 0, len(entries))
+		for _, entry := range entries {
+			rows = append(rows, importListsRow(entry))
+		}
+		return rows, nil
+
+	case gtsmodel.ImportTypeMutes:
+		// Only TargetAccount.Username, TargetAccount.Domain
+		// and Notifications will be set on each UserMute.
+		mutes, err := j.p.converter.CSVToMutes(ctx, records)
+		if err != nil {
+			return nil, err
+		}
+
+		if overwrite {
+			j.cleanup = j.p.importMutesCleanupF(j.requester, mutes)
+		}
+
+		rows := make([]importRow, 0, len(mutes))
+		for _, mute := range mutes {
+			rows = append(rows, importMutesRow(mute))
+		}
+		return rows, nil
+
+	case gtsmodel.ImportTypeBookmarks:
+		// Only Status.URI will be set on each StatusBookmark.
+		bookmarks, err := j.p.converter.CSVToBookmarks(ctx, records)
+		if err != nil {
+			return nil, err
+		}
+
+		if overwrite {
+			j.cleanup = j.p.importBookmarksCleanupF(j.requester, bookmarks)
+		}
+
+		rows := make([]importRow, 0, len(bookmarks))
+		for _, bookmark := range bookmarks {
+			rows = append(rows, importBookmarksRow(bookmark))
+		}
+		return rows, nil
+
+	default:
+		return nil, fmt.Errorf("unrecognized import type %s", j.imp.Type)
 	}
+}
 
-	// Do remaining processing of this import asynchronously.
-	f := importFollowingAsyncF(p, requester, follows, overwrite)
-	p.state.Workers.Processing.Queue.Push(f)
+// done records the outcomes of processing a chunk of
+// rows, finishing the import if they were the last.
+func (j *importJob) done(ctx context.Context, errs ...error) {
+	j.mu.Lock()
 
-	return nil
+	j.imp.ProcessedItems += len(errs)
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		j.imp.FailedItems++
+		if len(j.imp.Errors) < maxImportErrors {
+			j.imp.Errors = append(j.imp.Errors, err.Error())
+		}
+	}
+
+	if j.imp.ProcessedItems < j.imp.TotalItems {
+		// Still rows to go, just store progress.
+		if err := j.p.state.DB.UpdateImport(ctx, j.imp,
+			"processed_items",
+			"failed_items",
+			"errors",
+		); err != nil {
+			log.Errorf(ctx, "db error updating import: %v", err)
+		}
+
+		j.mu.Unlock()
+		return
+	}
+
+	// That was the last row, so no
+	// other workers can touch imp now.
+	j.mu.Unlock()
+	j.finish(ctx)
 }
 
-func importFollowingAsyncF(
-	p *Processor,
-	requester *gtsmodel.Account,
-	follows []*gtsmodel.Follow,
-	overwrite bool,
-) func(context.Context) {
-	return func(ctx context.Context) {
-		// Map used to store wanted
-		// follow targets (if overwriting).
-		var wantedFollows map[string]struct{}
+// finish runs the cleanup function for the
+// import (if any), and marks it as finished.
+func (j *importJob) finish(ctx context.Context) {
+	if j.cleanup != nil {
+		j.cleanup(ctx)
+	}
 
-		if overwrite {
-			// If we're overwriting, we need to get current
-			// follow(-req)s owned by requester *before*
-			// making any changes, so that we can remove
-			// unwanted follows after we've created new ones.
-			prevFollows, err := p.state.DB.GetAccountFollows(ctx, requester.ID, nil)
-			if err != nil {
-				log.Errorf(ctx, "db error getting following: %v", err)
-				return
-			}
+	j.imp.State = gtsmodel.ImportStateFinished
+	if err := j.p.state.DB.UpdateImport(ctx, j.imp,
+		"state",
+		"processed_items",
+		"failed_items",
+		"errors",
+	); err != nil {
+		log.Errorf(ctx, "db error updating import: %v", err)
+	}
+}
 
-			prevFollowReqs, err := p.state.DB.GetAccountFollowRequesting(ctx, requester.ID, nil)
-			if err != nil {
-				log.Errorf(ctx, "db error getting follow requesting: %v", err)
-				return
-			}
+// ImportsFailInterrupted marks imports that were still
+// processing when the instance was last stopped as failed,
+// as their remaining rows were lost and will never finish.
+// It should be called once on startup, before workers start.
+func (p *Processor) ImportsFailInterrupted(ctx context.Context) error {
+	imports, err := p.state.DB.GetImportsByState(ctx,
+		gtsmodel.ImportStateProcessing,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting processing imports: %w", err)
+	}
 
-			// Initialize new follows map.
-			wantedFollows = make(map[string]struct{}, len(follows))
+	for _, imp := range imports {
+		log.Infof(ctx, "marking interrupted import %s as failed", imp.ID)
 
-			// Once we've created (or tried to create)
-			// the required follows, go through previous
-			// follow(-request)s and remove unwanted ones.
-			defer func() {
+		imp.State = gtsmodel.ImportStateFailed
+		if err := p.state.DB.UpdateImport(ctx, imp, "state"); err != nil {
+			log.Errorf(ctx, "db error updating import: %v", err)
+		}
+	}
 
-				// AccountIDs to unfollow.
-				toRemove := []string{}
+	return nil
+}
 
-				// Check previous follows.
-				for _, prev := range prevFollows {
-					username := prev.TargetAccount.Username
-					domain := prev.TargetAccount.Domain
+// importChunks splits the given items into
+// chunks of at most importChunkSize items.
+func importChunks[T any](items []T) [][]T {
+	chunks := make([][]T, 0, (len(items)+importChunkSize-1)/importChunkSize)
+	for len(items) > importChunkSize {
+		chunks = append(chunks, items[:importChunkSize])
+		items = items[importChunkSize:]
+	}
 
-					_, wanted := wantedFollows[username+"@"+domain]
-					if !wanted {
-						toRemove = append(toRemove, prev.TargetAccountID)
-					}
-				}
+	if len(items) > 0 {
+		chunks = append(chunks, items)
+	}
 
-				// Now any pending follow requests.
-				for _, prev := range prevFollowReqs {
-					username := prev.TargetAccount.Username
-					domain := prev.TargetAccount.Domain
+	return chunks
+}
 
-					_, wanted := wantedFollows[username+"@"+domain]
-					if !wanted {
-						toRemove = append(toRemove, prev.TargetAccountID)
-					}
-				}
+// getAccount gets the account with the given username
+// and domain, dereferencing it if necessary, as the
+// given request user (empty to use instance account).
+func (j *importJob) getAccount(
+	ctx context.Context,
+	requestUser string,
+	username string,
+	domain string,
+) (*gtsmodel.Account, error) {
+	account, _, err := j.p.federator.Dereferencer.GetAccountByUsernameDomain(
+		ctx,
+		requestUser,
+		username,
+		domain,
+	)
+	if err != nil {
+		log.Errorf(ctx, "could not retrieve account: %v", err)
+		return nil, fmt.Errorf("%s: could not retrieve account", namestring(username, domain))
+	}
 
-				// Remove each discovered
-				// unwanted follow.
-				for _, accountID := range toRemove {
-					if _, errWithCode := p.FollowRemove(
-						ctx,
-						requester,
-						accountID,
-					); errWithCode != nil {
-						log.Errorf(ctx, "could not unfollow account: %v", errWithCode.Unwrap())
-						continue
-					}
-				}
-			}()
+	return account, nil
+}
+
+// getList gets the list of requester with the given
+// title, creating it if it doesn't exist yet.
+func (j *importJob) getList(ctx context.Context, title string) (*gtsmodel.List, error) {
+	j.listsMu.Lock()
+	defer j.listsMu.Unlock()
+
+	if list, ok := j.lists[title]; ok {
+		// Already found
+		// or created.
+		return list, nil
+	}
+
+	lists, err := j.p.state.DB.GetListsByAccountID(ctx, j.requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting lists: %w", err)
+	}
+
+	for _, list := range lists {
+		if list.Title == title {
+			j.lists[title] = list
+			return list, nil
 		}
+	}
 
-		// Go through the follows parsed from CSV
-		// file, and create / update each one.
-		for _, follow := range follows {
-			var (
-				// Username of the target.
-				username = follow.TargetAccount.Username
+	// No list with this title
+	// yet, so create a new one.
+	list := &gtsmodel.List{
+		ID:            id.NewULID(),
+		Title:         title,
+		AccountID:     j.requester.ID,
+		RepliesPolicy: gtsmodel.RepliesPolicyFollowed,
+		Exclusive:     util.Ptr(false),
+	}
+
+	if err := j.p.state.DB.PutList(ctx, list); err != nil {
+		return nil, gtserror.Newf("db error putting list: %w", err)
+	}
+
+	j.lists[title] = list
+	return list, nil
+}
 
-				// Domain of the target.
-				// Empty for our domain.
-				domain = follow.TargetAccount.Domain
+// namestring returns a username and
+// domain formatted as an account address.
+func namestring(username string, domain string) string {
+	if domain == "" {
+		return username
+	}
+	return username + "@" + domain
+}
 
-				// Show reblogs on
-				// the new follow.
-				showReblogs = follow.ShowReblogs
+func importFollowingRow(follow *gtsmodel.Follow) importRow {
+	return func(ctx context.Context, j *importJob) error {
+		// Get the target account, dereferencing it if necessary.
+		targetAcct, err := j.getAccount(
+			ctx,
+			j.requester.Username,
+			follow.TargetAccount.Username,
+			follow.TargetAccount.Domain,
+		)
+		if err != nil {
+			return err
+		}
 
-				// Notify when new
-				// follow posts.
-				notify = follow.Notify
+		// Use the processor's FollowCreate function
+		// to create or update the follow. This takes
+		// account of existing follows, and also sends
+		// the follow to the FromClientAPI processor.
+		if _, errWithCode := j.p.FollowCreate(
+			ctx,
+			j.requester,
+			&apimodel.AccountFollowRequest{
+				ID:      targetAcct.ID,
+				Reblogs: follow.ShowReblogs,
+				Notify:  follow.Notify,
+			},
+		); errWithCode != nil {
+			log.Errorf(ctx, "could not follow account: %v", errWithCode.Unwrap())
+			return fmt.Errorf("%s: could not follow account: %s",
+				namestring(targetAcct.Username, targetAcct.Domain),
+				errWithCode.Safe(),
 			)
+		}
 
-			if overwrite {
-				// We'll be overwriting, so store
-				// this new follow in our handy map.
-				wantedFollows[username+"@"+domain] = struct{}{}
+		return nil
+	}
+}
+
+func (p *Processor) importFollowingCleanupF(
+	requester *gtsmodel.Account,
+	follows []*gtsmodel.Follow,
+) func(context.Context) {
+	// Set of wanted follow targets.
+	wanted := make(map[string]struct{}, len(follows))
+	for _, follow := range follows {
+		key := follow.TargetAccount.Username + "@" + follow.TargetAccount.Domain
+		wanted[key] = struct{}{}
+	}
+
+	return func(ctx context.Context) {
+		follows, err := p.state.DB.GetAccountFollows(ctx, requester.ID, nil)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting following: %v", err)
+			return
+		}
+
+		followReqs, err := p.state.DB.GetAccountFollowRequesting(ctx, requester.ID, nil)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting follow requesting: %v", err)
+			return
+		}
+
+		// AccountIDs to unfollow.
+		toRemove := []string{}
+
+		// Check current follows.
+		for _, follow := range follows {
+			key := follow.TargetAccount.Username + "@" + follow.TargetAccount.Domain
+			if _, ok := wanted[key]; !ok {
+				toRemove = append(toRemove, follow.TargetAccountID)
 			}
+		}
 
-			// Get the target account, dereferencing it if necessary.
-			targetAcct, _, err := p.federator.Dereferencer.GetAccountByUsernameDomain(
+		// Now any pending follow requests.
+		for _, followReq := range followReqs {
+			key := followReq.TargetAccount.Username + "@" + followReq.TargetAccount.Domain
+			if _, ok := wanted[key]; !ok {
+				toRemove = append(toRemove, followReq.TargetAccountID)
+			}
+		}
+
+		// Remove each discovered
+		// unwanted follow.
+		for _, accountID := range toRemove {
+			if _, errWithCode := p.FollowRemove(
 				ctx,
-				requester.Username,
-				username,
-				domain,
+				requester,
+				accountID,
+			); errWithCode != nil {
+				log.Errorf(ctx, "could not unfollow account: %v", errWithCode.Unwrap())
+				continue
+			}
+		}
+	}
+}
+
+func importBlocksRow(block *gtsmodel.Block) importRow {
+	return func(ctx context.Context, j *importJob) error {
+		// Get the target account, dereferencing it if necessary.
+		targetAcct, err := j.getAccount(
+			ctx,
+			// Provide empty request user to use the
+			// instance account to deref the account.
+			//
+			// It's pointless to make lots of calls
+			// to a remote from an account that's about
+			// to block that account.
+			"",
+			block.TargetAccount.Username,
+			block.TargetAccount.Domain,
+		)
+		if err != nil {
+			return err
+		}
+
+		// Use the processor's BlockCreate function
+		// to create or update the block. This takes
+		// account of existing blocks, and also sends
+		// the block to the FromClientAPI processor.
+		if _, errWithCode := j.p.BlockCreate(
+			ctx,
+			j.requester,
+			targetAcct.ID,
+		); errWithCode != nil {
+			log.Errorf(ctx, "could not block account: %v", errWithCode.Unwrap())
+			return fmt.Errorf("%s: could not block account: %s",
+				namestring(targetAcct.Username, targetAcct.Domain),
+				errWithCode.Safe(),
 			)
-			if err != nil {
-				log.Errorf(ctx, "could not retrieve account: %v", err)
+		}
+
+		return nil
+	}
+}
+
+func (p *Processor) importBlocksCleanupF(
+	requester *gtsmodel.Account,
+	blocks []*gtsmodel.Block,
+) func(context.Context) {
+	// Set of wanted block targets.
+	wanted := make(map[string]struct{}, len(blocks))
+	for _, block := range blocks {
+		key := block.TargetAccount.Username + "@" + block.TargetAccount.Domain
+		wanted[key] = struct{}{}
+	}
+
+	return func(ctx context.Context) {
+		blocks, err := p.state.DB.GetAccountBlocks(ctx, requester.ID, nil)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting blocks: %v", err)
+			return
+		}
+
+		for _, block := range blocks {
+			key := block.TargetAccount.Username + "@" + block.TargetAccount.Domain
+			if _, ok := wanted[key]; ok {
+				// Leave this
+				// one alone.
 				continue
 			}
 
-			// Use the processor's FollowCreate function
-			// to create or update the follow. This takes
-			// account of existing follows, and also sends
-			// the follow to the FromClientAPI processor.
-			if _, errWithCode := p.FollowCreate(
+			if _, errWithCode := p.BlockRemove(
 				ctx,
 				requester,
-				&apimodel.AccountFollowRequest{
-					ID:      targetAcct.ID,
-					Reblogs: showReblogs,
-					Notify:  notify,
-				},
+				block.TargetAccountID,
 			); errWithCode != nil {
-				log.Errorf(ctx, "could not follow account: %v", errWithCode.Unwrap())
+				log.Errorf(ctx, "could not unblock account: %v", errWithCode.Unwrap())
 				continue
 			}
 		}
 	}
 }
 
-func (p *Processor) importBlocks(
-	ctx context.Context,
-	requester *gtsmodel.Account,
-	blocksData *multipart.FileHeader,
-	overwrite bool,
-) gtserror.WithCode {
-	file, err := blocksData.Open()
-	if err != nil {
-		err := fmt.Errorf("error opening blocks data file: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+func importDomainBlocksRow(block *gtsmodel.AccountDomainBlock) importRow {
+	return func(ctx context.Context, j *importJob) error {
+		// Use the processor's DomainBlockCreate
+		// function to create the block. This takes
+		// account of existing blocks, and also
+		// removes follows to / from the domain.
+		if errWithCode := j.p.DomainBlockCreate(
+			ctx,
+			j.requester,
+			block.Domain,
+		); errWithCode != nil {
+			log.Errorf(ctx, "could not block domain: %v", errWithCode.Unwrap())
+			return fmt.Errorf("%s: could not block domain: %s",
+				block.Domain,
+				errWithCode.Safe(),
+			)
+		}
+
+		return nil
 	}
-	defer file.Close()
+}
 
-	// Parse records out of the file.
-	records, err := csv.NewReader(file).ReadAll()
-	if err != nil {
-		err := fmt.Errorf("error reading blocks data file: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+func (p *Processor) importDomainBlocksCleanupF(
+	requester *gtsmodel.Account,
+	blocks []*gtsmodel.AccountDomainBlock,
+) func(context.Context) {
+	// Set of wanted blocked domains.
+	wanted := make(map[string]struct{}, len(blocks))
+	for _, block := range blocks {
+		domain, err := util.Punify(block.Domain)
+		if err != nil {
+			// Will fail to
+			// import anyway.
+			continue
+		}
+		wanted[domain] = struct{}{}
 	}
 
-	// Convert the records into a slice of barebones blocks.
-	//
-	// Only TargetAccount.Username and TargetAccount.Domain,
-	// will be set on each Block.
-	blocks, err := p.converter.CSVToBlocks(ctx, records)
-	if err != nil {
-		err := fmt.Errorf("error converting records to blocks: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+	return func(ctx context.Context) {
+		domains, err := p.state.DB.GetAccountBlockedDomains(ctx, requester.ID)
+		if err != nil {
+			log.Errorf(ctx, "db error getting domain blocks: %v", err)
+			return
+		}
+
+		for _, domain := range domains {
+			if _, ok := wanted[domain]; ok {
+				// Leave this
+				// one alone.
+				continue
+			}
+
+			if errWithCode := p.DomainBlockRemove(
+				ctx,
+				requester,
+				domain,
+			); errWithCode != nil {
+				log.Errorf(ctx, "could not unblock domain: %v", errWithCode.Unwrap())
+				continue
+			}
+		}
 	}
+}
 
-	// Do remaining processing of this import asynchronously.
-	f := importBlocksAsyncF(p, requester, blocks, overwrite)
-	p.state.Workers.Processing.Queue.Push(f)
+func importListsRow(entry *gtsmodel.ListEntry) importRow {
+	return func(ctx context.Context, j *importJob) error {
+		var (
+			title    = entry.List.Title
+			username = entry.Follow.TargetAccount.Username
+			domain   = entry.Follow.TargetAccount.Domain
+		)
 
-	return nil
+		// Get the target account, dereferencing it if necessary.
+		targetAcct, err := j.getAccount(ctx, j.requester.Username, username, domain)
+		if err != nil {
+			return err
+		}
+
+		// Requester has to follow target
+		// for it to be added to a list.
+		follow, err := j.p.state.DB.GetFollow(
+			gtscontext.SetBarebones(ctx),
+			j.requester.ID,
+			targetAcct.ID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting follow: %v", err)
+			return fmt.Errorf("%s: error getting follow", namestring(username, domain))
+		}
+
+		if follow == nil {
+			return fmt.Errorf("%s: account not followed, so cannot be added to list %s",
+				namestring(username, domain), title,
+			)
+		}
+
+		// Get or create list with title.
+		list, err := j.getList(ctx, title)
+		if err != nil {
+			log.Errorf(ctx, "could not get list: %v", err)
+			return fmt.Errorf("%s: could not create list %s", namestring(username, domain), title)
+		}
+
+		// Add follow to list, if it's not
+		// already in there (eg., merging).
+		switch err := j.p.state.DB.PutListEntries(ctx, []*gtsmodel.ListEntry{{
+			ID:       id.NewULID(),
+			ListID:   list.ID,
+			FollowID: follow.ID,
+		}}); {
+		case err == nil, errors.Is(err, db.ErrAlreadyExists):
+			return nil
+
+		default:
+			log.Errorf(ctx, "db error putting list entry: %v", err)
+			return fmt.Errorf("%s: could not add account to list %s", namestring(username, domain), title)
+		}
+	}
 }
 
-func importBlocksAsyncF(
-	p *Processor,
+func (p *Processor) importListsCleanupF(
 	requester *gtsmodel.Account,
-	blocks []*gtsmodel.Block,
-	overwrite bool,
+	entries []*gtsmodel.ListEntry,
 ) func(context.Context) {
+	// Set of wanted list
+	// members, by list title.
+	wanted := make(map[string]map[string]struct{})
+	for _, entry := range entries {
+		title := entry.List.Title
+		if wanted[title] == nil {
+			wanted[title] = make(map[string]struct{})
+		}
+
+		key := entry.Follow.TargetAccount.Username + "@" + entry.Follow.TargetAccount.Domain
+		wanted[title][key] = struct{}{}
+	}
+
 	return func(ctx context.Context) {
-		// Map used to store wanted
-		// block targets (if overwriting).
-		var wantedBlocks map[string]struct{}
+		lists, err := p.state.DB.GetListsByAccountID(ctx, requester.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting lists: %v", err)
+			return
+		}
 
-		if overwrite {
-			// If we're overwriting, we need to get current
-			// blocks owned by requester *before* making any
-			// changes, so that we can remove unwanted blocks
-			// after we've created new ones.
-			var (
-				prevBlocks []*gtsmodel.Block
-				err        error
-			)
+		for _, list := range lists {
+			wantedMembers, ok := wanted[list.Title]
+			if !ok {
+				// Not in import,
+				// remove whole list.
+				if err := p.state.DB.DeleteListByID(ctx, list.ID); err != nil {
+					log.Errorf(ctx, "db error deleting list: %v", err)
+				}
+				continue
+			}
 
-			prevBlocks, err = p.state.DB.GetAccountBlocks(ctx, requester.ID, nil)
-			if err != nil {
-				log.Errorf(ctx, "db error getting blocks: %v", err)
-				return
+			follows, err := p.state.DB.GetFollowsInList(ctx, list.ID, nil)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting list follows: %v", err)
+				continue
 			}
 
-			// Initialize new blocks map.
-			wantedBlocks = make(map[string]struct{}, len(blocks))
-
-			// Once we've created (or tried to create)
-			// the required blocks, go through previous
-			// blocks and remove unwanted ones.
-			defer func() {
-				for _, prev := range prevBlocks {
-					username := prev.TargetAccount.Username
-					domain := prev.TargetAccount.Domain
-
-					_, wanted := wantedBlocks[username+"@"+domain]
-					if wanted {
-						// Leave this
-						// one alone.
-						continue
-					}
-
-					if _, errWithCode := p.BlockRemove(
-						ctx,
-						requester,
-						prev.TargetAccountID,
-					); errWithCode != nil {
-						log.Errorf(ctx, "could not unblock account: %v", errWithCode.Unwrap())
-						continue
-					}
+			for _, follow := range follows {
+				key := follow.TargetAccount.Username + "@" + follow.TargetAccount.Domain
+				if _, ok := wantedMembers[key]; ok {
+					// Leave this
+					// one alone.
+					continue
 				}
-			}()
+
+				if err := p.state.DB.DeleteListEntry(ctx, list.ID, follow.ID); err != nil {
+					log.Errorf(ctx, "db error deleting list entry: %v", err)
+				}
+			}
 		}
+	}
+}
 
-		// Go through the blocks parsed from CSV
-		// file, and create / update each one.
-		for _, block := range blocks {
-			var (
-				// Username of the target.
-				username = block.TargetAccount.Username
+func importMutesRow(mute *gtsmodel.UserMute) importRow {
+	return func(ctx context.Context, j *importJob) error {
+		// Get the target account, dereferencing it if necessary.
+		targetAcct, err := j.getAccount(
+			ctx,
+			// Provide empty request user to use
+			// the instance account to deref, as
+			// we do for blocks.
+			"",
+			mute.TargetAccount.Username,
+			mute.TargetAccount.Domain,
+		)
+		if err != nil {
+			return err
+		}
 
-				// Domain of the target.
-				// Empty for our domain.
-				domain = block.TargetAccount.Domain
+		// Use the processor's MuteCreate function
+		// to create or update the mute. This takes
+		// account of existing mutes.
+		if _, errWithCode := j.p.MuteCreate(
+			ctx,
+			j.requester,
+			targetAcct.ID,
+			&apimodel.UserMuteCreateUpdateRequest{
+				Notifications: mute.Notifications,
+			},
+		); errWithCode != nil {
+			log.Errorf(ctx, "could not mute account: %v", errWithCode.Unwrap())
+			return fmt.Errorf("%s: could not mute account: %s",
+				namestring(targetAcct.Username, targetAcct.Domain),
+				errWithCode.Safe(),
 			)
+		}
 
-			if overwrite {
-				// We'll be overwriting, so store
-				// this new block in our handy map.
-				wantedBlocks[username+"@"+domain] = struct{}{}
-			}
+		return nil
+	}
+}
 
-			// Get the target account, dereferencing it if necessary.
-			targetAcct, _, err := p.federator.Dereferencer.GetAccountByUsernameDomain(
-				ctx,
-				// Provide empty request user to use the
-				// instance account to deref the account.
-				//
-				// It's pointless to make lots of calls
-				// to a remote from an account that's about
-				// to block that account.
-				"",
-				username,
-				domain,
-			)
-			if err != nil {
-				log.Errorf(ctx, "could not retrieve account: %v", err)
+func (p *Processor) importMutesCleanupF(
+	requester *gtsmodel.Account,
+	mutes []*gtsmodel.UserMute,
+) func(context.Context) {
+	// Set of wanted mute targets.
+	wanted := make(map[string]struct{}, len(mutes))
+	for _, mute := range mutes {
+		key := mute.TargetAccount.Username + "@" + mute.TargetAccount.Domain
+		wanted[key] = struct{}{}
+	}
+
+	return func(ctx context.Context) {
+		mutes, err := p.state.DB.GetAccountMutes(ctx, requester.ID, nil)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting mutes: %v", err)
+			return
+		}
+
+		for _, mute := range mutes {
+			key := mute.TargetAccount.Username + "@" + mute.TargetAccount.Domain
+			if _, ok := wanted[key]; ok {
+				// Leave this
+				// one alone.
 				continue
 			}
 
-			// Use the processor's BlockCreate function
-			// to create or update the block. This takes
-			// account of existing blocks, and also sends
-			// the block to the FromClientAPI processor.
-			if _, errWithCode := p.BlockCreate(
+			if _, errWithCode := p.MuteRemove(
 				ctx,
 				requester,
-				targetAcct.ID,
+				mute.TargetAccountID,
 			); errWithCode != nil {
-				log.Errorf(ctx, "could not block account: %v", errWithCode.Unwrap())
+				log.Errorf(ctx, "could not unmute account: %v", errWithCode.Unwrap())
 				continue
 			}
 		}
 	}
 }
+
+func importBookmarksRow(bookmark *gtsmodel.StatusBookmark) importRow {
+	return func(ctx context.Context, j *importJob) error {
+		uriStr := bookmark.Status.URI
+
+		uri, err := url.Parse(uriStr)
+		if err != nil {
+			return fmt.Errorf("%s: invalid status uri", uriStr)
+		}
+
+		// Get the status, dereferencing it if necessary.
+		status, _, err := j.p.federator.Dereferencer.GetStatusByURI(
+			ctx,
+			j.requester.Username,
+			uri,
+		)
+		if err != nil {
+			log.Errorf(ctx, "could not retrieve status: %v", err)
+			return fmt.Errorf("%s: could not retrieve status", uriStr)
+		}
+
+		// Ensure the status is visible to requester.
+		status, errWithCode := j.p.c.GetVisibleTargetStatus(ctx,
+			j.requester,
+			status.ID,
+			nil, // default freshness
+		)
+		if errWithCode != nil {
+			return fmt.Errorf("%s: could not bookmark status: %s", uriStr, errWithCode.Safe())
+		}
+
+		existing, err := j.p.state.DB.GetStatusBookmark(
+			gtscontext.SetBarebones(ctx),
+			j.requester.ID,
+			status.ID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting bookmark: %v", err)
+			return fmt.Errorf("%s: could not bookmark status", uriStr)
+		}
+
+		if existing != nil {
+			// Status is already bookmarked.
+			return nil
+		}
+
+		// Create and store a new bookmark.
+		if err := j.p.state.DB.PutStatusBookmark(ctx, &gtsmodel.StatusBookmark{
+			ID:              id.NewULID(),
+			AccountID:       j.requester.ID,
+			Account:         j.requester,
+			TargetAccountID: status.AccountID,
+			TargetAccount:   status.Account,
+			StatusID:        status.ID,
+			Status:          status,
+		}); err != nil {
+			log.Errorf(ctx, "db error putting bookmark: %v", err)
+			return fmt.Errorf("%s: could not bookmark status", uriStr)
+		}
+
+		if err := j.p.c.InvalidateTimelinedStatus(ctx, j.requester.ID, status.ID); err != nil {
+			log.Errorf(ctx, "error invalidating status from timelines: %v", err)
+		}
+
+		return nil
+	}
+}
+
+func (p *Processor) importBookmarksCleanupF(
+	requester *gtsmodel.Account,
+	bookmarks []*gtsmodel.StatusBookmark,
+) func(context.Context) {
+	// Set of wanted bookmarked status URIs.
+	wanted := make(map[string]struct{}, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		wanted[bookmark.Status.URI] = struct{}{}
+	}
+
+	return func(ctx context.Context) {
+		bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, requester.ID, 0, "", "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting bookmarks: %v", err)
+			return
+		}
+
+		for _, bookmark := range bookmarks {
+			// Exports contain status URIs, but
+			// be lenient and accept URLs too.
+			_, wantedURI := wanted[bookmark.Status.URI]
+			_, wantedURL := wanted[bookmark.Status.URL]
+			if wantedURI || wantedURL {
+				// Leave this
+				// one alone.
+				continue
+			}
+
+			if err := p.state.DB.DeleteStatusBookmarkByID(ctx, bookmark.ID); err != nil {
+				log.Errorf(ctx, "db error deleting bookmark: %v", err)
+				continue
+			}
+
+			if err := p.c.InvalidateTimelinedStatus(ctx, requester.ID, bookmark.StatusID); err != nil {
+				log.Errorf(ctx, "error invalidating status from timelines: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type ImportTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *ImportTestSuite) TestImportsFailInterrupted() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	// Pretend the instance was stopped
+	// partway through processing this.
+	imp := &gtsmodel.Import{
+		ID:             id.NewULID(),
+		AccountID:      requester.ID,
+		Type:           gtsmodel.ImportTypeFollowing,
+		Overwrite:      util.Ptr(false),
+		State:          gtsmodel.ImportStateProcessing,
+		TotalItems:     10,
+		ProcessedItems: 4,
+	}
+	if err := suite.state.DB.PutImport(ctx, imp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.accountProcessor.ImportsFailInterrupted(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiImport, errWithCode := suite.accountProcessor.ImportGet(ctx, requester, imp.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("failed", apiImport.State)
+	suite.Equal(4, apiImport.ProcessedItems)
+}
+
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
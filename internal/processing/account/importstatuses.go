@@ -0,0 +1,519 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// importStatuses opens the given archive zip, and queues
+// recreation of the public and unlisted statuses from its
+// outbox.json as local statuses of the job's requester.
+//
+// Statuses are created with their original timestamps,
+// but are neither federated nor sent to timelines, as
+// they're not new posts. Each status is created in a row
+// of its own, in order of publication, so that replies to
+// other statuses in the archive can be threaded properly.
+func (p *Processor) importStatuses(
+	ctx context.Context,
+	job *importJob,
+	data *multipart.FileHeader,
+) (*apimodel.Import, gtserror.WithCode) {
+	if *job.imp.Overwrite {
+		const text = "overwrite mode not supported for statuses"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	archive, path, errWithCode := openImportArchive(data)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// closeArchive closes and removes
+	// the temporary copy of the archive.
+	closeArchive := func(ctx context.Context) {
+		if err := archive.Close(); err != nil {
+			log.Errorf(ctx, "error closing archive: %v", err)
+		}
+
+		if err := os.Remove(path); err != nil {
+			log.Errorf(ctx, "error removing archive: %v", err)
+		}
+	}
+
+	statusables, err := readArchiveOutbox(ctx, archive)
+	if err != nil {
+		closeArchive(ctx)
+		err := fmt.Errorf("error reading archive outbox: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	job.archive = archive
+	job.statuses = make(map[string]*gtsmodel.Status, len(statusables))
+	job.cleanup = func(ctx context.Context) {
+		closeArchive(ctx)
+
+		// Statuses were created without going
+		// through the client API workers, so
+		// recount the requester's statuses.
+		unlock := p.state.ProcessingLocks.Lock(job.requester.URI)
+		defer unlock()
+
+		if err := p.state.DB.RegenerateAccountStats(ctx, job.requester); err != nil {
+			log.Errorf(ctx, "db error regenerating account stats: %v", err)
+		}
+	}
+
+	job.imp.TotalItems = len(statusables)
+	if err := p.state.DB.PutImport(ctx, job.imp); err != nil {
+		closeArchive(ctx)
+		err := gtserror.Newf("db error putting import: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Take a copy of the import for the
+	// response, before processing begins.
+	apiImport := p.converter.ImportToAPIImport(ctx, job.imp)
+
+	if len(statusables) == 0 {
+		// Nothing to process, but
+		// the archive must be closed.
+		p.state.Workers.Processing.Queue.Push(job.finish)
+		return apiImport, nil
+	}
+
+	// Do remaining processing of this import
+	// asynchronously, in one worker task, so
+	// that replies follow their parents.
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		for _, chunk := range importChunks(statusables) {
+			errs := make([]error, len(chunk))
+			for i, statusable := range chunk {
+				errs[i] = job.importStatus(ctx, statusable)
+			}
+			job.done(ctx, errs...)
+		}
+	})
+
+	return apiImport, nil
+}
+
+// openImportArchive copies the given archive zip
+// data file to a temporary file and opens it,
+// returning the opened archive and the file path.
+func openImportArchive(data *multipart.FileHeader) (*zip.ReadCloser, string, gtserror.WithCode) {
+	file, err := data.Open()
+	if err != nil {
+		err := fmt.Errorf("error opening data file: %w", err)
+		return nil, "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	// Rows are processed after the request
+	// is done with, when the uploaded file
+	// may be gone, so keep a copy until then.
+	tmp, err := os.CreateTemp(os.TempDir(), "gotosocial-*")
+	if err != nil {
+		err := gtserror.Newf("error creating temp file: %w", err)
+		return nil, "", gtserror.NewErrorInternalError(err)
+	}
+
+	_, err = io.Copy(tmp, file)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		os.Remove(tmp.Name())
+		err := gtserror.Newf("error writing temp file: %w", err)
+		return nil, "", gtserror.NewErrorInternalError(err)
+	}
+
+	archive, err := zip.OpenReader(tmp.Name())
+	if err != nil {
+		os.Remove(tmp.Name())
+		err := fmt.Errorf("error opening data file as zip: %w", err)
+		return nil, "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return archive, tmp.Name(), nil
+}
+
+// readArchiveOutbox reads the Create activities from
+// outbox.json in the given archive, returning their
+// public and unlisted statuses, oldest first.
+func readArchiveOutbox(ctx context.Context, archive *zip.ReadCloser) ([]ap.Statusable, error) {
+	f, err := archive.Open("outbox.json")
+	if err != nil {
+		return nil, err
+	}
+
+	// Handles closing of f.
+	outbox, err := ap.ResolveCollection(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	var statusables []ap.Statusable
+	for item := outbox.NextItem(); item != nil; item = outbox.NextItem() {
+		t := item.GetType()
+		if t == nil || t.GetTypeName() != ap.ActivityCreate {
+			// Boosts (Announces) can't be
+			// recreated, so only take Creates.
+			continue
+		}
+
+		create, ok := t.(vocab.ActivityStreamsCreate)
+		if !ok {
+			continue
+		}
+
+		for _, object := range ap.ExtractObjects(create) {
+			t := object.GetType()
+			if t == nil {
+				continue
+			}
+
+			statusable, ok := ap.ToStatusable(t)
+			if !ok {
+				continue
+			}
+
+			// Only public and unlisted statuses are
+			// recreated; the audience of others can't
+			// be known, as followers may have changed.
+			visibility, err := ap.ExtractVisibility(statusable, "")
+			if err != nil ||
+				(visibility != gtsmodel.VisibilityPublic &&
+					visibility != gtsmodel.VisibilityUnlocked) {
+				continue
+			}
+
+			statusables = append(statusables, statusable)
+		}
+	}
+
+	// Archives are usually oldest first
+	// already, but make sure, so that
+	// parents come before their replies.
+	slices.SortStableFunc(statusables, func(a, b ap.Statusable) int {
+		return ap.GetPublished(a).Compare(ap.GetPublished(b))
+	})
+
+	return statusables, nil
+}
+
+// importStatus recreates the given statusable from the
+// job's archive as a local status of the job's requester.
+func (j *importJob) importStatus(ctx context.Context, statusable ap.Statusable) error {
+	oldURI := ap.GetJSONLDId(statusable).String()
+
+	published := ap.GetPublished(statusable)
+	if published.IsZero() {
+		return fmt.Errorf("%s: no published time", oldURI)
+	}
+
+	visibility, err := ap.ExtractVisibility(statusable, "")
+	if err != nil {
+		return fmt.Errorf("%s: could not extract visibility", oldURI)
+	}
+
+	// ID by original publication time, so the
+	// status sorts where it was first posted,
+	// and by original URI, so that importing
+	// the same archive twice gives the same ID.
+	statusID, err := id.NewULIDFromTimeAndKey(published, j.requester.ID+" "+oldURI)
+	if err != nil {
+		log.Errorf(ctx, "error generating status id: %v", err)
+		return fmt.Errorf("%s: could not generate status id", oldURI)
+	}
+
+	// Check if this status was imported already.
+	existing, err := j.p.state.DB.GetStatusByID(
+		gtscontext.SetBarebones(ctx),
+		statusID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting status: %v", err)
+		return fmt.Errorf("%s: could not check for existing status", oldURI)
+	}
+
+	if existing != nil {
+		// Already imported, don't create it
+		// again, but keep it for any replies.
+		j.statuses[oldURI] = existing
+		return nil
+	}
+
+	// Generate necessary URIs for username, to build status URIs.
+	accountURIs := uris.GenerateURIsForAccount(j.requester.Username)
+
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 accountURIs.StatusesURI + "/" + statusID,
+		URL:                 accountURIs.StatusesURL + "/" + statusID,
+		CreatedAt:           published,
+		UpdatedAt:           published,
+		Local:               util.Ptr(true),
+		Account:             j.requester,
+		AccountID:           j.requester.ID,
+		AccountURI:          j.requester.URI,
+		ActivityStreamsType: ap.ObjectNote,
+		ContentWarning:      text.SanitizeToPlaintext(ap.ExtractSummary(statusable)),
+		Sensitive:           util.Ptr(ap.ExtractSensitive(statusable)),
+		Visibility:          visibility,
+		Federated:           util.Ptr(true),
+		PendingApproval:     util.Ptr(false),
+	}
+
+	// Content comes from an uploaded
+	// file, so sanitize it like any
+	// other user-provided HTML.
+	content, lang := typeutils.ContentToContentLanguage(ctx,
+		ap.ExtractContent(statusable),
+	)
+	status.Content = text.SanitizeToHTML(content)
+	status.Language = lang
+
+	if err := j.importStatusInReplyTo(ctx, statusable, status); err != nil {
+		log.Errorf(ctx, "error threading status: %v", err)
+		return fmt.Errorf("%s: could not thread status", oldURI)
+	}
+
+	if err := j.importStatusAttachments(ctx, statusable, status); err != nil {
+		return fmt.Errorf("%s: %w", oldURI, err)
+	}
+
+	// Only store the status: it's not new, so
+	// don't process it through the client API
+	// workers, which would deliver it to local
+	// timelines and federate it to followers.
+	if err := j.p.state.DB.PutStatus(ctx, status); err != nil {
+		log.Errorf(ctx, "db error putting status: %v", err)
+		return fmt.Errorf("%s: could not create status", oldURI)
+	}
+
+	// Mark the attachments as used by the
+	// status, so the cleaner doesn't prune
+	// them as unattached media.
+	for _, attachment := range status.Attachments {
+		attachment.StatusID = status.ID
+		if err := j.p.state.DB.UpdateAttachment(ctx, attachment, "status_id"); err != nil {
+			log.Errorf(ctx, "db error updating attachment: %v", err)
+			return fmt.Errorf("%s: could not attach media", oldURI)
+		}
+	}
+
+	// Store for any replies to come.
+	j.statuses[oldURI] = status
+	return nil
+}
+
+// importStatusInReplyTo sets the in-reply-to and thread
+// fields of status, to reply to the status's parent if
+// it was already imported, or is otherwise known to us.
+func (j *importJob) importStatusInReplyTo(
+	ctx context.Context,
+	statusable ap.Statusable,
+	status *gtsmodel.Status,
+) error {
+	var inReplyTo *gtsmodel.Status
+
+	if inReplyToURI := ap.ExtractInReplyToURI(statusable); inReplyToURI != nil {
+		uriStr := inReplyToURI.String()
+
+		// Prefer a parent from this archive,
+		// else check for one we already know.
+		inReplyTo = j.statuses[uriStr]
+		if inReplyTo == nil {
+			var err error
+			inReplyTo, err = j.p.state.DB.GetStatusByURI(ctx, uriStr)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return gtserror.Newf("db error getting status %s: %w", uriStr, err)
+			}
+		}
+
+		if inReplyTo != nil {
+			visible, err := j.p.visFilter.StatusVisible(ctx, j.requester, inReplyTo)
+			if err != nil {
+				return gtserror.Newf("error checking visibility of status %s: %w", uriStr, err)
+			}
+
+			if !visible {
+				// Don't thread onto statuses
+				// the requester can't see.
+				inReplyTo = nil
+			}
+		}
+	}
+
+	if inReplyTo != nil {
+		// Parent found, reply to it.
+		status.InReplyTo = inReplyTo
+		status.InReplyToID = inReplyTo.ID
+		status.InReplyToURI = inReplyTo.URI
+		status.InReplyToAccountID = inReplyTo.AccountID
+	}
+
+	if inReplyTo != nil && inReplyTo.ThreadID != "" {
+		// Just inherit threadID from parent.
+		status.ThreadID = inReplyTo.ThreadID
+		return nil
+	}
+
+	// Mark new thread (or threaded
+	// subsection) starting from here.
+	threadID := id.NewULID()
+	if err := j.p.state.DB.PutThread(ctx,
+		&gtsmodel.Thread{
+			ID: threadID,
+		},
+	); err != nil {
+		return gtserror.Newf("error inserting new thread in db: %w", err)
+	}
+	status.ThreadID = threadID
+
+	return nil
+}
+
+// importStatusAttachments stores the media files of
+// the statusable's attachments from the job's archive,
+// and sets them as the attachments of status.
+func (j *importJob) importStatusAttachments(
+	ctx context.Context,
+	statusable ap.Statusable,
+	status *gtsmodel.Status,
+) error {
+	attachmentProp := statusable.GetActivityStreamsAttachment()
+	if attachmentProp == nil {
+		return nil
+	}
+
+	for iter := attachmentProp.Begin(); iter != attachmentProp.End(); iter = iter.Next() {
+		if len(status.AttachmentIDs) >= config.GetStatusesMediaMaxFiles() {
+			break
+		}
+
+		t := iter.GetType()
+		if t == nil {
+			continue
+		}
+
+		attachmentable, ok := t.(ap.Attachmentable)
+		if !ok {
+			continue
+		}
+
+		name := archiveAttachmentName(t)
+		if name == "" {
+			return errors.New("attachment without url")
+		}
+
+		data := func(context.Context) (io.ReadCloser, error) {
+			return j.archive.Open(name)
+		}
+
+		description := ap.ExtractDescription(attachmentable)
+		blurhash := ap.ExtractBlurhash(attachmentable)
+		stored, errWithCode := j.p.c.StoreLocalMedia(ctx,
+			j.requester.ID,
+			data,
+			media.AdditionalMediaInfo{
+				CreatedAt:   &status.CreatedAt,
+				Description: &description,
+				Blurhash:    &blurhash,
+			},
+		)
+		if errWithCode != nil {
+			log.Errorf(ctx, "error storing attachment %s: %v", name, errWithCode)
+			return fmt.Errorf("could not store attachment %s", name)
+		}
+
+		status.AttachmentIDs = append(status.AttachmentIDs, stored.ID)
+		status.Attachments = append(status.Attachments, stored)
+	}
+
+	return nil
+}
+
+// archiveAttachmentName returns the name of the file of
+// the given attachment type in an archive, or empty if unset.
+//
+// Archived attachment URLs are the path of their file
+// relative to the root of the archive. Without a scheme
+// these aren't IRIs, and are only kept in the raw form of
+// the url property, so take them from the serialized map.
+func archiveAttachmentName(t vocab.Type) string {
+	m, err := t.Serialize()
+	if err != nil {
+		return ""
+	}
+
+	// Url may be a plain string,
+	// or a Link with an href, or
+	// an array of either of those.
+	var urlStr func(v any) string
+	urlStr = func(v any) string {
+		switch v := v.(type) {
+		case string:
+			return v
+		case map[string]any:
+			return urlStr(v["href"])
+		case []any:
+			for _, v := range v {
+				if s := urlStr(v); s != "" {
+					return s
+				}
+			}
+		}
+		return ""
+	}
+
+	u, err := url.Parse(urlStr(m["url"]))
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(u.Path, "/")
+}
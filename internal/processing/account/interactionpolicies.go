@@ -42,6 +42,7 @@ func (p *Processor) DefaultInteractionPoliciesGet(
 		requester.Settings.InteractionPolicyDirect,
 		gtsmodel.DefaultInteractionPolicyDirect(),
 	)
+	direct = withCanQuote(direct, gtsmodel.VisibilityDirect)
 
 	directAPI, err := p.converter.InteractionPolicyToAPIInteractionPolicy(ctx, direct, nil, nil)
 	if err != nil {
@@ -55,6 +56,7 @@ func (p *Processor) DefaultInteractionPoliciesGet(
 		requester.Settings.InteractionPolicyFollowersOnly,
 		gtsmodel.DefaultInteractionPolicyFollowersOnly(),
 	)
+	private = withCanQuote(private, gtsmodel.VisibilityFollowersOnly)
 
 	privateAPI, err := p.converter.InteractionPolicyToAPIInteractionPolicy(ctx, private, nil, nil)
 	if err != nil {
@@ -68,6 +70,7 @@ func (p *Processor) DefaultInteractionPoliciesGet(
 		requester.Settings.InteractionPolicyUnlocked,
 		gtsmodel.DefaultInteractionPolicyUnlocked(),
 	)
+	unlisted = withCanQuote(unlisted, gtsmodel.VisibilityUnlocked)
 
 	unlistedAPI, err := p.converter.InteractionPolicyToAPIInteractionPolicy(ctx, unlisted, nil, nil)
 	if err != nil {
@@ -81,6 +84,7 @@ func (p *Processor) DefaultInteractionPoliciesGet(
 		requester.Settings.InteractionPolicyPublic,
 		gtsmodel.DefaultInteractionPolicyPublic(),
 	)
+	public = withCanQuote(public, gtsmodel.VisibilityPublic)
 
 	publicAPI, err := p.converter.InteractionPolicyToAPIInteractionPolicy(ctx, public, nil, nil)
 	if err != nil {
@@ -182,6 +186,18 @@ func (p *Processor) DefaultInteractionPoliciesUpdate(
 	return p.DefaultInteractionPoliciesGet(ctx, requester)
 }
 
+// withCanQuote returns the given policy with
+// CanQuote set, falling back to the default quote
+// rules for the visibility if it wasn't set.
+func withCanQuote(
+	policy *gtsmodel.InteractionPolicy,
+	visibility gtsmodel.Visibility,
+) *gtsmodel.InteractionPolicy {
+	policy2 := *policy
+	policy2.CanQuote = policy.CanQuoteFor(visibility)
+	return &policy2
+}
+
 // populateAccountSettings just ensures that
 // Settings is populated on the given account.
 func (p *Processor) populateAccountSettings(
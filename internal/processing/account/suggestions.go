@@ -0,0 +1,291 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// Max no. of candidate accounts
+	// to consider from each source.
+	suggestionsCandidates = 80
+
+	// Sources of follow suggestions, as
+	// used in the v2 suggestions API.
+	suggestionSourceFeatured         = "featured"
+	suggestionSourceFriendsOfFriends = "friends_of_friends"
+	suggestionSourceMostInteractions = "most_interactions"
+)
+
+// suggestionSourceLegacy maps suggestion sources
+// to their deprecated single "source" equivalent.
+var suggestionSourceLegacy = map[string]string{
+	suggestionSourceFeatured:         "staff",
+	suggestionSourceFriendsOfFriends: "past_interactions",
+	suggestionSourceMostInteractions: "global",
+}
+
+// suggestionCandidate is one account that
+// may be suggested, and the sources that
+// suggested it, in order of precedence.
+type suggestionCandidate struct {
+	accountID string
+	sources   []string
+}
+
+// SuggestionsGet returns up to limit accounts suggested to requester
+// as worth following. Accounts picked by admins come first, then
+// accounts followed by requester's follows, ordered by how many of
+// requester's follows follow them, then recently active local accounts.
+//
+// Accounts that requester has dismissed, already follows or has requested
+// to follow, has blocked or muted or is blocked by, as well as suspended,
+// moved, and non-discoverable accounts, are never suggested.
+func (p *Processor) SuggestionsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+) ([]*apimodel.Suggestion, gtserror.WithCode) {
+	candidates, err := p.suggestionCandidates(ctx, requester)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	dismissedIDs, err := p.state.DB.GetSuggestionDismissalTargetIDs(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting suggestion dismissals: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	suggestions := make([]*apimodel.Suggestion, 0, limit)
+	for _, candidate := range candidates {
+		if len(suggestions) >= limit {
+			break
+		}
+
+		if slices.Contains(dismissedIDs, candidate.accountID) {
+			// Requester doesn't
+			// want to see this one.
+			continue
+		}
+
+		account, err := p.state.DB.GetAccountByID(ctx, candidate.accountID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting suggested account %s: %v", candidate.accountID, err)
+			}
+			continue
+		}
+
+		suggestable, err := p.suggestable(ctx, requester, account)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if !suggestable {
+			continue
+		}
+
+		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, account)
+		if err != nil {
+			log.Errorf(ctx, "error converting suggested account %s: %v", account.ID, err)
+			continue
+		}
+
+		suggestions = append(suggestions, &apimodel.Suggestion{
+			Source:  suggestionSourceLegacy[candidate.sources[0]],
+			Sources: candidate.sources,
+			Account: apiAccount,
+		})
+	}
+
+	return suggestions, nil
+}
+
+// suggestionCandidates gathers candidate accounts for
+// suggestion to requester from each source, in order of
+// precedence, merging the sources of duplicate accounts.
+func (p *Processor) suggestionCandidates(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*suggestionCandidate, error) {
+	staffPicks, err := p.state.DB.GetStaffPicks(gtscontext.SetBarebones(ctx))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting staff picks: %w", err)
+	}
+
+	staffPickIDs := make([]string, 0, len(staffPicks))
+	for _, staffPick := range staffPicks {
+		staffPickIDs = append(staffPickIDs, staffPick.AccountID)
+	}
+
+	friendsOfFriendsIDs, err := p.state.DB.GetFollowsOfFollowsAccountIDs(ctx, requester.ID, suggestionsCandidates)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting follows of follows: %w", err)
+	}
+
+	activeIDs, err := p.state.DB.GetRecentlyActiveLocalAccountIDs(ctx, suggestionsCandidates)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting recently active accounts: %w", err)
+	}
+
+	var (
+		candidates = make([]*suggestionCandidate, 0, len(staffPickIDs)+len(friendsOfFriendsIDs)+len(activeIDs))
+		byID       = make(map[string]*suggestionCandidate, cap(candidates))
+	)
+
+	for _, source := range []struct {
+		name       string
+		accountIDs []string
+	}{
+		{suggestionSourceFeatured, staffPickIDs},
+		{suggestionSourceFriendsOfFriends, friendsOfFriendsIDs},
+		{suggestionSourceMostInteractions, activeIDs},
+	} {
+		for _, accountID := range source.accountIDs {
+			if candidate, ok := byID[accountID]; ok {
+				// Already a candidate from an
+				// earlier source, just note this one.
+				candidate.sources = append(candidate.sources, source.name)
+				continue
+			}
+
+			candidate := &suggestionCandidate{
+				accountID: accountID,
+				sources:   []string{source.name},
+			}
+			candidates = append(candidates, candidate)
+			byID[accountID] = candidate
+		}
+	}
+
+	return candidates, nil
+}
+
+// suggestable returns true if account
+// may be suggested to requester.
+func (p *Processor) suggestable(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	account *gtsmodel.Account,
+) (bool, error) {
+	if account.ID == requester.ID ||
+		account.IsSuspended() ||
+		account.IsInstance() ||
+		account.IsMoving() ||
+		!*account.Discoverable {
+		return false, nil
+	}
+
+	following, err := p.state.DB.IsFollowing(ctx, requester.ID, account.ID)
+	if err != nil {
+		return false, gtserror.Newf("db error checking follow: %w", err)
+	}
+
+	if following {
+		return false, nil
+	}
+
+	requested, err := p.state.DB.IsFollowRequested(ctx, requester.ID, account.ID)
+	if err != nil {
+		return false, gtserror.Newf("db error checking follow request: %w", err)
+	}
+
+	if requested {
+		return false, nil
+	}
+
+	blocked, err := p.state.DB.IsEitherBlocked(ctx, requester.ID, account.ID)
+	if err != nil {
+		return false, gtserror.Newf("db error checking block: %w", err)
+	}
+
+	if blocked {
+		return false, nil
+	}
+
+	if account.IsRemote() {
+		// Don't suggest accounts from
+		// a domain requester has blocked.
+		blocked, err := p.state.DB.IsAccountDomainBlocked(ctx,
+			requester.ID,
+			account.Domain,
+		)
+		if err != nil {
+			return false, gtserror.Newf("db error checking account domain block: %w", err)
+		}
+
+		if blocked {
+			return false, nil
+		}
+	}
+
+	mute, err := p.state.DB.GetMute(gtscontext.SetBarebones(ctx), requester.ID, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error checking mute: %w", err)
+	}
+
+	if mute != nil && !mute.Expired(time.Now()) {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// SuggestionDismiss removes the account with the given
+// ID from requester's follow suggestions, so that it will
+// not be suggested to requester again.
+func (p *Processor) SuggestionDismiss(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetAccountID string,
+) gtserror.WithCode {
+	targetAccount, err := p.state.DB.GetAccountByID(gtscontext.SetBarebones(ctx), targetAccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", targetAccountID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if targetAccount == nil {
+		err := gtserror.Newf("account %s not found", targetAccountID)
+		return gtserror.NewErrorNotFound(err)
+	}
+
+	if err := p.state.DB.PutSuggestionDismissal(ctx, &gtsmodel.SuggestionDismissal{
+		ID:              id.NewULID(),
+		AccountID:       requester.ID,
+		TargetAccountID: targetAccount.ID,
+	}); err != nil {
+		err := gtserror.Newf("db error putting suggestion dismissal: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
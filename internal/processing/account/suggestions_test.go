@@ -0,0 +1,187 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type SuggestionsTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *SuggestionsTestSuite) suggestedIDs(suggestions []*apimodel.Suggestion) []string {
+	ids := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		ids = append(ids, suggestion.Account.ID)
+	}
+	return ids
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionsGet() {
+	var (
+		ctx          = context.Background()
+		requester    = suite.testAccounts["local_account_2"]
+		adminAccount = suite.testAccounts["admin_account"]
+	)
+
+	suggestions, errWithCode := suite.accountProcessor.SuggestionsGet(ctx, requester, 40)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Turtle follows zork, who follows admin, and admin
+	// has posted recently. Zork is already followed, and
+	// turtle themself and the instance account are skipped.
+	suite.Equal([]string{adminAccount.ID}, suite.suggestedIDs(suggestions))
+	suite.Equal("past_interactions", suggestions[0].Source)
+	suite.Equal([]string{"friends_of_friends", "most_interactions"}, suggestions[0].Sources)
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionsGetStaffPicks() {
+	var (
+		ctx             = context.Background()
+		requester       = suite.testAccounts["local_account_2"]
+		adminAccount    = suite.testAccounts["admin_account"]
+		pickedAccount   = suite.testAccounts["remote_account_3"]
+		blockedAccount  = suite.testAccounts["remote_account_1"]
+		hiddenAccount   = suite.testAccounts["remote_account_4"]
+		alreadyFollowed = suite.testAccounts["local_account_1"]
+	)
+
+	// Pick some accounts. Only remote_account_3 and admin
+	// should be suggested; turtle blocks remote_account_1,
+	// remote_account_4 is not discoverable, and turtle
+	// already follows zork.
+	//
+	// IDs are generated from increasing timestamps so
+	// that "newest first" ordering is deterministic.
+	now := time.Now()
+	for i, account := range []*gtsmodel.Account{
+		alreadyFollowed,
+		hiddenAccount,
+		blockedAccount,
+		adminAccount,
+		pickedAccount,
+	} {
+		pickID, err := id.NewULIDFromTime(now.Add(time.Duration(i) * time.Second))
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		if err := suite.db.PutStaffPick(ctx, &gtsmodel.StaffPick{
+			ID:                 pickID,
+			AccountID:          account.ID,
+			CreatedByAccountID: adminAccount.ID,
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	suggestions, errWithCode := suite.accountProcessor.SuggestionsGet(ctx, requester, 40)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Staff picks come first, newest first.
+	suite.Equal([]string{pickedAccount.ID, adminAccount.ID}, suite.suggestedIDs(suggestions))
+	suite.Equal("staff", suggestions[0].Source)
+	suite.Equal([]string{"featured"}, suggestions[0].Sources)
+	suite.Equal("staff", suggestions[1].Source)
+	suite.Equal([]string{"featured", "friends_of_friends", "most_interactions"}, suggestions[1].Sources)
+
+	// Limit should be respected.
+	suggestions, errWithCode = suite.accountProcessor.SuggestionsGet(ctx, requester, 1)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal([]string{pickedAccount.ID}, suite.suggestedIDs(suggestions))
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionsGetDomainBlocked() {
+	var (
+		ctx           = context.Background()
+		requester     = suite.testAccounts["local_account_2"]
+		adminAccount  = suite.testAccounts["admin_account"]
+		pickedAccount = suite.testAccounts["remote_account_3"]
+	)
+
+	// Pick remote_account_3, but have
+	// turtle block its whole domain.
+	if err := suite.db.PutStaffPick(ctx, &gtsmodel.StaffPick{
+		ID:                 id.NewULID(),
+		AccountID:          pickedAccount.ID,
+		CreatedByAccountID: adminAccount.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.PutAccountDomainBlock(ctx, &gtsmodel.AccountDomainBlock{
+		ID:        id.NewULID(),
+		AccountID: requester.ID,
+		Domain:    pickedAccount.Domain,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suggestions, errWithCode := suite.accountProcessor.SuggestionsGet(ctx, requester, 40)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Only admin should be left.
+	suite.Equal([]string{adminAccount.ID}, suite.suggestedIDs(suggestions))
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionDismiss() {
+	var (
+		ctx          = context.Background()
+		requester    = suite.testAccounts["local_account_2"]
+		adminAccount = suite.testAccounts["admin_account"]
+	)
+
+	// Dismiss admin twice; the
+	// second should be a no-op.
+	for range 2 {
+		if errWithCode := suite.accountProcessor.SuggestionDismiss(ctx, requester, adminAccount.ID); errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+	}
+
+	suggestions, errWithCode := suite.accountProcessor.SuggestionsGet(ctx, requester, 40)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(suggestions)
+
+	// Dismissing an account that
+	// doesn't exist should 404.
+	errWithCode = suite.accountProcessor.SuggestionDismiss(ctx, requester, "01HZZZZZZZZZZZZZZZZZZZZZZZ")
+	suite.EqualError(errWithCode, "SuggestionDismiss: account 01HZZZZZZZZZZZZZZZZZZZZZZZ not found")
+}
+
+func TestSuggestionsTestSuite(t *testing.T) {
+	suite.Run(t, new(SuggestionsTestSuite))
+}
@@ -35,6 +35,7 @@
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -49,6 +50,7 @@ type AdminStandardTestSuite struct {
 	oauthServer         oauth.Server
 	fromClientAPIChan   chan messages.FromClientAPI
 	transportController transport.Controller
+	httpClient          *testrig.MockHTTPClient
 	federator           *federation.Federator
 	emailSender         email.Sender
 	sentEmails          map[string]string
@@ -102,7 +104,8 @@ func (suite *AdminStandardTestSuite) SetupTest() {
 	suite.mediaManager = testrig.NewTestMediaManager(&suite.state)
 	suite.oauthServer = testrig.NewTestOauthServer(suite.db)
 
-	suite.transportController = testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(nil, "../../../testrig/media"))
+	suite.httpClient = testrig.NewMockHTTPClient(nil, "../../../testrig/media")
+	suite.transportController = testrig.NewTestTransportController(&suite.state, suite.httpClient)
 	suite.federator = testrig.NewTestFederator(&suite.state, suite.transportController, suite.mediaManager)
 	suite.sentEmails = make(map[string]string)
 	suite.emailSender = testrig.NewEmailSender("../../../web/template/", suite.sentEmails)
@@ -115,6 +118,7 @@ func (suite *AdminStandardTestSuite) SetupTest() {
 		suite.mediaManager,
 		&suite.state,
 		suite.emailSender,
+		webpush.NewNoopSender(nil),
 		visibility.NewFilter(&suite.state),
 		interaction.NewFilter(&suite.state),
 	)
@@ -0,0 +1,419 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+func (p *Processor) getAnnouncement(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.Announcement, gtserror.WithCode) {
+	announcement, err := p.state.DB.GetAnnouncementByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcement %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if announcement == nil {
+		err := gtserror.Newf("announcement %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return announcement, nil
+}
+
+// apiAnnouncement converts the given announcement to its
+// API model, including the original text for editing.
+func (p *Processor) apiAnnouncement(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, announcement, nil)
+	if err != nil {
+		err := gtserror.Newf("error converting announcement %s: %w", announcement.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAnnouncement.Text = announcement.Text
+	return apiAnnouncement, nil
+}
+
+// AnnouncementsGet returns all announcements
+// on this instance, including unpublished ones.
+func (p *Processor) AnnouncementsGet(ctx context.Context) ([]*apimodel.Announcement, gtserror.WithCode) {
+	announcements, err := p.state.DB.GetAnnouncements(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcements: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAnnouncements := make([]*apimodel.Announcement, 0, len(announcements))
+	for _, announcement := range announcements {
+		apiAnnouncement, errWithCode := p.apiAnnouncement(ctx, announcement)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiAnnouncements = append(apiAnnouncements, apiAnnouncement)
+	}
+
+	return apiAnnouncements, nil
+}
+
+// AnnouncementGet returns one announcement with the given ID.
+func (p *Processor) AnnouncementGet(ctx context.Context, id string) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiAnnouncement(ctx, announcement)
+}
+
+// AnnouncementCreate creates a new announcement with the given
+// parameters. If the announcement is published immediately, it
+// will be streamed to all users with an open user stream.
+func (p *Processor) AnnouncementCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.AnnouncementCreateRequest,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement := &gtsmodel.Announcement{
+		ID:                 id.NewULID(),
+		AllDay:             &form.AllDay,
+		CreatedByAccountID: adminAcct.ID,
+	}
+
+	if errWithCode := p.setAnnouncementText(ctx, announcement, form.Text); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	var err error
+	if announcement.StartsAt, err = parseAnnouncementTime(form.StartsAt); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if announcement.EndsAt, err = parseAnnouncementTime(form.EndsAt); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if errWithCode := validateAnnouncementTimes(announcement); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.Published {
+		announcement.PublishedAt = time.Now()
+	}
+
+	if err := p.state.DB.PutAnnouncement(ctx, announcement); err != nil {
+		err := gtserror.Newf("db error putting announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.streamAnnouncement(ctx, announcement, false)
+	p.scheduleAnnouncement(ctx, announcement)
+	return p.apiAnnouncement(ctx, announcement)
+}
+
+// AnnouncementUpdate updates the announcement with the given ID, setting
+// only those parameters given in the form. Users with an open user stream
+// will be sent the updated announcement, or told to remove it if it has
+// been unpublished or has already ended.
+func (p *Processor) AnnouncementUpdate(
+	ctx context.Context,
+	id string,
+	form *apimodel.AnnouncementUpdateRequest,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	var (
+		wasActive = announcement.Active(time.Now())
+		columns   []string
+		err       error
+	)
+
+	if form.Text != nil {
+		if errWithCode := p.setAnnouncementText(ctx, announcement, *form.Text); errWithCode != nil {
+			return nil, errWithCode
+		}
+		columns = append(columns, "text", "content", "emojis")
+	}
+
+	if form.StartsAt != nil {
+		if announcement.StartsAt, err = parseAnnouncementTime(*form.StartsAt); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		columns = append(columns, "starts_at")
+	}
+
+	if form.EndsAt != nil {
+		if announcement.EndsAt, err = parseAnnouncementTime(*form.EndsAt); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		columns = append(columns, "ends_at")
+	}
+
+	if errWithCode := validateAnnouncementTimes(announcement); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.AllDay != nil {
+		announcement.AllDay = form.AllDay
+		columns = append(columns, "all_day")
+	}
+
+	if form.Published != nil && *form.Published != announcement.Published() {
+		if *form.Published {
+			announcement.PublishedAt = time.Now()
+		} else {
+			announcement.PublishedAt = time.Time{}
+		}
+		columns = append(columns, "published_at")
+	}
+
+	if len(columns) == 0 {
+		const text = "empty form submitted"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if err := p.state.DB.UpdateAnnouncement(ctx, announcement, columns...); err != nil {
+		err := gtserror.Newf("db error updating announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.streamAnnouncement(ctx, announcement, wasActive)
+	p.scheduleAnnouncement(ctx, announcement)
+	return p.apiAnnouncement(ctx, announcement)
+}
+
+// AnnouncementDelete deletes the announcement with the given
+// ID, telling users with an open user stream to remove it.
+func (p *Processor) AnnouncementDelete(ctx context.Context, id string) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before deleting, as
+	// this also counts reactions.
+	apiAnnouncement, errWithCode := p.apiAnnouncement(ctx, announcement)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteAnnouncementByID(ctx, id); err != nil {
+		err := gtserror.Newf("db error deleting announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if announcement.Active(time.Now()) {
+		p.stream.AnnouncementDelete(ctx, announcement.ID)
+	}
+
+	p.cancelAnnouncement(announcement.ID)
+	return apiAnnouncement, nil
+}
+
+// setAnnouncementText validates the given markdown text,
+// and sets it and its HTML content on the announcement.
+func (p *Processor) setAnnouncementText(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+	text string,
+) gtserror.WithCode {
+	if err := validate.AnnouncementText(text); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Parse text as Markdown, keeping any
+	// mention links and emojis that are found.
+	result := p.formatter.FromMarkdown(
+		ctx,
+		p.parseMentionFunc,
+		announcement.CreatedByAccountID,
+		"",
+		text,
+	)
+
+	announcement.Text = text
+	announcement.Content = result.HTML
+	announcement.Emojis = result.Emojis
+	announcement.EmojiIDs = make([]string, len(result.Emojis))
+	for i, emoji := range result.Emojis {
+		announcement.EmojiIDs[i] = emoji.ID
+	}
+
+	return nil
+}
+
+// streamAnnouncement streams the given announcement to all users
+// if it's currently active, or streams a delete of it if it was
+// previously active but no longer is.
+func (p *Processor) streamAnnouncement(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+	wasActive bool,
+) {
+	if !announcement.Active(time.Now()) {
+		if wasActive {
+			p.stream.AnnouncementDelete(ctx, announcement.ID)
+		}
+		return
+	}
+
+	apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, announcement, nil)
+	if err != nil {
+		log.Errorf(ctx, "error converting announcement %s: %v", announcement.ID, err)
+		return
+	}
+
+	p.stream.Announcement(ctx, apiAnnouncement)
+}
+
+// ScheduleAnnouncements schedules streaming of all published
+// announcements which have yet to start or end. It should be
+// called once on startup, as scheduled tasks aren't persisted.
+func (p *Processor) ScheduleAnnouncements(ctx context.Context) error {
+	announcements, err := p.state.DB.GetAnnouncements(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting announcements: %w", err)
+	}
+
+	for _, announcement := range announcements {
+		p.scheduleAnnouncement(ctx, announcement)
+	}
+
+	return nil
+}
+
+// scheduleAnnouncement schedules the given announcement, if published,
+// to be streamed to all users at its start time, and to be removed from
+// users' clients at its end time, if either is in the future. Any times
+// previously scheduled for the announcement are cancelled.
+func (p *Processor) scheduleAnnouncement(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+) {
+	p.cancelAnnouncement(announcement.ID)
+
+	if !announcement.Published() {
+		// Nothing
+		// to stream.
+		return
+	}
+
+	now := time.Now()
+	for _, at := range []struct {
+		id        string
+		time      time.Time
+		wasActive bool
+	}{
+		{announcement.ID + ":starts_at", announcement.StartsAt, false},
+		{announcement.ID + ":ends_at", announcement.EndsAt, true},
+	} {
+		if !at.time.After(now) {
+			// Already
+			// passed.
+			continue
+		}
+
+		if !p.state.Workers.Scheduler.AddOnce(
+			at.id,
+			at.time,
+			p.onAnnouncementTime(announcement.ID, at.wasActive),
+		) {
+			log.Errorf(ctx, "failed adding announcement %s to scheduler", at.id)
+		}
+	}
+}
+
+// cancelAnnouncement cancels any scheduled
+// times for the announcement with given ID.
+func (p *Processor) cancelAnnouncement(announcementID string) {
+	p.state.Workers.Scheduler.Cancel(announcementID + ":starts_at")
+	p.state.Workers.Scheduler.Cancel(announcementID + ":ends_at")
+}
+
+// onAnnouncementTime returns a callback function to be used by
+// the scheduler when the given announcement starts or ends.
+func (p *Processor) onAnnouncementTime(
+	announcementID string,
+	wasActive bool,
+) func(context.Context, time.Time) {
+	return func(ctx context.Context, _ time.Time) {
+		// Get the latest version of announcement from database.
+		announcement, err := p.state.DB.GetAnnouncementByID(ctx, announcementID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting announcement %s: %v", announcementID, err)
+			}
+			return
+		}
+
+		p.streamAnnouncement(ctx, announcement, wasActive)
+	}
+}
+
+// parseAnnouncementTime parses the given start
+// or end time of an announcement, which may be
+// either an ISO 8601 datetime or just a date.
+// An empty string results in a zero time.
+func parseAnnouncementTime(in string) (time.Time, error) {
+	if in == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, in); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.Parse(util.ISO8601Date, in); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("could not parse %s as ISO 8601 datetime or date", in)
+}
+
+// validateAnnouncementTimes ensures that an announcement
+// doesn't end before it starts, if both times are set.
+func validateAnnouncementTimes(announcement *gtsmodel.Announcement) gtserror.WithCode {
+	if !announcement.StartsAt.IsZero() &&
+		!announcement.EndsAt.IsZero() &&
+		announcement.EndsAt.Before(announcement.StartsAt) {
+		const text = "ends_at must not be before starts_at"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return nil
+}
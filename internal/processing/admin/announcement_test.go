@@ -0,0 +1,244 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AnnouncementTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// openStream opens a user stream for local_account_1.
+func (suite *AnnouncementTestSuite) openStream() *stream.Stream {
+	str, errWithCode := suite.processor.Stream().Open(
+		context.Background(),
+		suite.testAccounts["local_account_1"],
+		stream.TimelineHome,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	return str
+}
+
+// recv receives one message from the given
+// stream, failing the test if none arrives.
+func (suite *AnnouncementTestSuite) recv(str *stream.Stream) stream.Message {
+	ctx, cncl := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cncl()
+
+	msg, ok := str.Recv(ctx)
+	if !ok {
+		suite.FailNow("expected a message but message was not received")
+	}
+	return msg
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementCreate() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		str       = suite.openStream()
+	)
+
+	apiAnnouncement, errWithCode := suite.adminProcessor.AnnouncementCreate(
+		ctx,
+		adminAcct,
+		&apimodel.AnnouncementCreateRequest{
+			Text:      "We are **moving** servers this weekend :rainbow:",
+			StartsAt:  "2025-01-04",
+			EndsAt:    "2099-01-05",
+			AllDay:    true,
+			Published: true,
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal("<p>We are <strong>moving</strong> servers this weekend :rainbow:</p>", apiAnnouncement.Content)
+	suite.Equal("We are **moving** servers this weekend :rainbow:", apiAnnouncement.Text)
+	suite.Equal("2025-01-04T00:00:00.000Z", apiAnnouncement.StartsAt)
+	suite.Equal("2099-01-05T00:00:00.000Z", apiAnnouncement.EndsAt)
+	suite.True(apiAnnouncement.AllDay)
+	suite.True(apiAnnouncement.Published)
+	suite.NotEmpty(apiAnnouncement.PublishedAt)
+	if suite.Len(apiAnnouncement.Emojis, 1) {
+		suite.Equal("rainbow", apiAnnouncement.Emojis[0].Shortcode)
+	}
+
+	// Published announcement should
+	// have been streamed to the user.
+	msg := suite.recv(str)
+	suite.Equal(stream.EventTypeAnnouncement, msg.Event)
+	suite.Contains(msg.Payload, apiAnnouncement.ID)
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementCreateScheduled() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		str       = suite.openStream()
+		now       = time.Now()
+	)
+
+	apiAnnouncement, errWithCode := suite.adminProcessor.AnnouncementCreate(
+		ctx,
+		adminAcct,
+		&apimodel.AnnouncementCreateRequest{
+			Text:      "Flash sale",
+			StartsAt:  now.Add(2 * time.Second).UTC().Format(time.RFC3339),
+			EndsAt:    now.Add(4 * time.Second).UTC().Format(time.RFC3339),
+			Published: true,
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Announcement hasn't started
+	// yet, so shouldn't be streamed.
+	recvCtx, cncl := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cncl()
+	if msg, ok := str.Recv(recvCtx); ok {
+		suite.FailNow("unexpected message", "%+v", msg)
+	}
+
+	// It should be streamed
+	// once it has started...
+	msg := suite.recv(str)
+	suite.Equal(stream.EventTypeAnnouncement, msg.Event)
+	suite.Contains(msg.Payload, apiAnnouncement.ID)
+
+	// ...and removed
+	// once it has ended.
+	msg = suite.recv(str)
+	suite.Equal(stream.EventTypeAnnouncementDelete, msg.Event)
+	suite.Equal(apiAnnouncement.ID, msg.Payload)
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementCreateBadTimes() {
+	_, errWithCode := suite.adminProcessor.AnnouncementCreate(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		&apimodel.AnnouncementCreateRequest{
+			Text:     "Time travel party",
+			StartsAt: "2025-01-05",
+			EndsAt:   "2025-01-04",
+		},
+	)
+	suite.EqualError(errWithCode, "ends_at must not be before starts_at")
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementUpdatePublish() {
+	var (
+		ctx   = context.Background()
+		draft = testrig.NewTestAnnouncements()["draft"]
+		str   = suite.openStream()
+	)
+
+	// The draft has already ended, so
+	// move the end date before publishing.
+	apiAnnouncement, errWithCode := suite.adminProcessor.AnnouncementUpdate(
+		ctx,
+		draft.ID,
+		&apimodel.AnnouncementUpdateRequest{
+			EndsAt:    util.Ptr(""),
+			Published: util.Ptr(true),
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(apiAnnouncement.Published)
+	suite.Empty(apiAnnouncement.EndsAt)
+	suite.Equal(draft.Text, apiAnnouncement.Text)
+
+	msg := suite.recv(str)
+	suite.Equal(stream.EventTypeAnnouncement, msg.Event)
+	suite.Contains(msg.Payload, draft.ID)
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementUpdateUnpublish() {
+	var (
+		ctx    = context.Background()
+		active = testrig.NewTestAnnouncements()["active"]
+		str    = suite.openStream()
+	)
+
+	apiAnnouncement, errWithCode := suite.adminProcessor.AnnouncementUpdate(
+		ctx,
+		active.ID,
+		&apimodel.AnnouncementUpdateRequest{
+			Published: util.Ptr(false),
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.False(apiAnnouncement.Published)
+	suite.Empty(apiAnnouncement.PublishedAt)
+
+	// Unpublished announcement should
+	// be removed from user's client.
+	msg := suite.recv(str)
+	suite.Equal(stream.EventTypeAnnouncementDelete, msg.Event)
+	suite.Equal(active.ID, msg.Payload)
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementDelete() {
+	var (
+		ctx    = context.Background()
+		active = testrig.NewTestAnnouncements()["active"]
+		str    = suite.openStream()
+	)
+
+	apiAnnouncement, errWithCode := suite.adminProcessor.AnnouncementDelete(ctx, active.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(active.ID, apiAnnouncement.ID)
+
+	msg := suite.recv(str)
+	suite.Equal(stream.EventTypeAnnouncementDelete, msg.Event)
+	suite.Equal(active.ID, msg.Payload)
+
+	// Announcement and its reactions should be gone.
+	_, errWithCode = suite.adminProcessor.AnnouncementGet(ctx, active.ID)
+	suite.NotNil(errWithCode)
+
+	reactions, err := suite.state.DB.GetAnnouncementReactions(ctx, active.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(reactions)
+}
+
+func TestAnnouncementTestSuite(t *testing.T) {
+	suite.Run(t, &AnnouncementTestSuite{})
+}
@@ -49,6 +49,23 @@ func (p *Processor) apiDomainPerm(
 	return apiDomainPerm, nil
 }
 
+// domainPermExcluded returns true if the given domain
+// is excluded from domain permission imports and
+// subscriptions, ie., if imports and subscriptions
+// should never create a block or allow targeting it.
+func (p *Processor) domainPermExcluded(
+	ctx context.Context,
+	domain string,
+) (bool, gtserror.WithCode) {
+	excluded, err := p.state.DB.IsDomainPermissionExcluded(ctx, domain)
+	if err != nil {
+		err := gtserror.NewfAt(3, "db error checking domain permission exclude for %s: %w", domain, err)
+		return false, gtserror.NewErrorInternalError(err)
+	}
+
+	return excluded, nil
+}
+
 // DomainPermissionCreate creates an instance-level permission
 // targeting the given domain, and then processes any side
 // effects of the permission creation.
@@ -144,6 +161,9 @@ func (p *Processor) DomainPermissionDelete(
 // function for each domain in the provided file. Will return
 // a slice of processed domain permissions.
 //
+// Domains on the domain permission excludes list are
+// never imported, and are reported as failures instead.
+//
 // In the case of total failure, a gtserror.WithCode will be
 // returned so that the caller can respond appropriately. In
 // the case of partial or total success, a MultiStatus model
@@ -196,19 +216,28 @@ func (p *Processor) DomainPermissionsImport(
 			publicComment  = domainPerm.PublicComment
 			privateComment = domainPerm.PrivateComment
 			subscriptionID = "" // No sub ID for imports.
+			excluded       bool
 			errWithCode    gtserror.WithCode
 		)
 
-		domainPerm, _, errWithCode = p.DomainPermissionCreate(
-			ctx,
-			permissionType,
-			account,
-			domain,
-			obfuscate,
-			publicComment,
-			privateComment,
-			subscriptionID,
-		)
+		excluded, errWithCode = p.domainPermExcluded(ctx, domain)
+		if errWithCode == nil && excluded {
+			err := fmt.Errorf("domain %s is excluded from domain permission imports", domain)
+			errWithCode = gtserror.NewErrorForbidden(err, err.Error())
+		}
+
+		if errWithCode == nil {
+			domainPerm, _, errWithCode = p.DomainPermissionCreate(
+				ctx,
+				permissionType,
+				account,
+				domain,
+				obfuscate,
+				publicComment,
+				privateComment,
+				subscriptionID,
+			)
+		}
 
 		var entry *apimodel.MultiStatusEntry
 
@@ -0,0 +1,304 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// getDomainPermDraft gets the domain permission draft
+// with the given ID, returning an appropriate error if
+// it doesn't exist or something goes wrong.
+func (p *Processor) getDomainPermDraft(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionDraft, gtserror.WithCode) {
+	permDraft, err := p.state.DB.GetDomainPermissionDraftByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("domain permission draft %s not found", id)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		err := gtserror.Newf("db error getting domain permission draft %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return permDraft, nil
+}
+
+// DomainPermissionDraftGet returns one
+// domain permission draft with the given id.
+func (p *Processor) DomainPermissionDraftGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainPermission, gtserror.WithCode) {
+	permDraft, errWithCode := p.getDomainPermDraft(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiDomainPerm(ctx, permDraft, false)
+}
+
+// DomainPermissionDraftsGet returns a page of
+// domain permission drafts, optionally filtered
+// by permission type, subscription ID, and domain.
+func (p *Processor) DomainPermissionDraftsGet(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	subscriptionID string,
+	domain string,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	permDrafts, err := p.state.DB.GetDomainPermissionDrafts(
+		ctx,
+		permType,
+		subscriptionID,
+		domain,
+		page,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting domain permission drafts: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(permDrafts)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := permDrafts[count-1].ID
+	hi := permDrafts[0].ID
+
+	// Convert each perm draft to API model.
+	items := make([]any, 0, count)
+	for _, permDraft := range permDrafts {
+		item, errWithCode := p.apiDomainPerm(ctx, permDraft, false)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		items = append(items, item)
+	}
+
+	// Assemble next/prev page queries.
+	query := make(url.Values, 3)
+	if permType != gtsmodel.DomainPermissionUnknown {
+		query.Set(apiutil.DomainPermissionPermTypeKey, permType.String())
+	}
+	if subscriptionID != "" {
+		query.Set(apiutil.DomainPermissionSubscriptionIDKey, subscriptionID)
+	}
+	if domain != "" {
+		query.Set(apiutil.DomainPermissionDomainKey, domain)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/domain_permission_drafts",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+		Query: query,
+	}), nil
+}
+
+// DomainPermissionDraftCreate creates a new domain permission
+// draft of the given type, targeting the given domain. The
+// draft will not be enacted until it has been accepted.
+func (p *Processor) DomainPermissionDraftCreate(
+	ctx context.Context,
+	acct *gtsmodel.Account,
+	domain string,
+	permType gtsmodel.DomainPermissionType,
+	obfuscate bool,
+	publicComment string,
+	privateComment string,
+) (*apimodel.DomainPermission, gtserror.WithCode) {
+	permDraft := &gtsmodel.DomainPermissionDraft{
+		ID:                 id.NewULID(),
+		PermissionType:     permType,
+		Domain:             domain,
+		CreatedByAccountID: acct.ID,
+		CreatedByAccount:   acct,
+		PrivateComment:     text.SanitizeToPlaintext(privateComment),
+		PublicComment:      text.SanitizeToPlaintext(publicComment),
+		Obfuscate:          &obfuscate,
+	}
+
+	if err := p.state.DB.PutDomainPermissionDraft(ctx, permDraft); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Unique constraint conflict on permission type + domain.
+			err := fmt.Errorf("a domain %s draft already exists for %s", permType.String(), domain)
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+
+		// Real database error.
+		err := gtserror.Newf("db error creating domain permission draft: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainPerm(ctx, permDraft, false)
+}
+
+// DomainPermissionDraftAccept converts the domain permission
+// draft with the given ID into a real domain permission, and
+// processes the side effects of the permission as usual.
+//
+// If a permission of the same type already exists for the
+// draft's domain, a conflict error will be returned, unless
+// overwrite is true, in which case the existing permission
+// is updated with the values from the draft.
+//
+// If review is required by the instance config, drafts created
+// by acct itself (ie., not by a subscription) can't be accepted.
+func (p *Processor) DomainPermissionDraftAccept(
+	ctx context.Context,
+	acct *gtsmodel.Account,
+	id string,
+	overwrite bool,
+) (*apimodel.DomainPermission, string, gtserror.WithCode) {
+	permDraft, errWithCode := p.getDomainPermDraft(ctx, id)
+	if errWithCode != nil {
+		return nil, "", errWithCode
+	}
+
+	if config.GetInstanceDomainPermissionDraftsRequireReview() &&
+		permDraft.SubscriptionID == "" &&
+		permDraft.CreatedByAccountID == acct.ID {
+		const text = "domain permission drafts must be accepted by someone other than their creator"
+		return nil, "", gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	existing, err := p.getDomainPerm(ctx, permDraft.PermissionType, permDraft.Domain)
+	if err != nil {
+		return nil, "", gtserror.NewErrorInternalError(err)
+	}
+
+	var (
+		apiPerm  *apimodel.DomainPermission
+		actionID string
+	)
+
+	if existing != nil {
+		if !overwrite {
+			err := fmt.Errorf(
+				"a domain %s already exists for %s; set overwrite to true to replace it",
+				permDraft.PermissionType.String(), permDraft.Domain,
+			)
+			return nil, "", gtserror.NewErrorConflict(err, err.Error())
+		}
+
+		// Update the existing perm with values from the draft.
+		if err := p.updateDomainPerm(ctx, existing, permDraft.SubscriptionID, permDraft); err != nil {
+			return nil, "", gtserror.NewErrorInternalError(err)
+		}
+
+		apiPerm, errWithCode = p.apiDomainPerm(ctx, existing, false)
+	} else {
+		// No perm exists yet, create it
+		// (and process side effects).
+		apiPerm, actionID, errWithCode = p.DomainPermissionCreate(
+			ctx,
+			permDraft.PermissionType,
+			acct,
+			permDraft.Domain,
+			*permDraft.Obfuscate,
+			permDraft.PublicComment,
+			permDraft.PrivateComment,
+			permDraft.SubscriptionID,
+		)
+	}
+
+	if errWithCode != nil {
+		return nil, actionID, errWithCode
+	}
+
+	// Draft has been enacted, remove it.
+	if err := p.state.DB.DeleteDomainPermissionDraft(ctx, permDraft.ID); err != nil {
+		err := gtserror.Newf("db error deleting domain permission draft: %w", err)
+		return nil, actionID, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPerm, actionID, nil
+}
+
+// DomainPermissionDraftReject removes the domain permission
+// draft with the given ID without enacting it. If excludeTarget
+// is true, the draft's domain will also be added to the domain
+// permission excludes list, so that it isn't drafted again.
+func (p *Processor) DomainPermissionDraftReject(
+	ctx context.Context,
+	acct *gtsmodel.Account,
+	id string,
+	excludeTarget bool,
+) (*apimodel.DomainPermission, gtserror.WithCode) {
+	permDraft, errWithCode := p.getDomainPermDraft(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before deleting.
+	apiPermDraft, errWithCode := p.apiDomainPerm(ctx, permDraft, false)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteDomainPermissionDraft(ctx, permDraft.ID); err != nil {
+		err := gtserror.Newf("db error deleting domain permission draft: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !excludeTarget {
+		return apiPermDraft, nil
+	}
+
+	// Add the domain to the excludes list,
+	// unless it's already excluded anyway.
+	excluded, errWithCode := p.domainPermExcluded(ctx, permDraft.Domain)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if !excluded {
+		if _, errWithCode := p.DomainPermissionExcludeCreate(
+			ctx,
+			acct,
+			permDraft.Domain,
+			permDraft.PrivateComment,
+		); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	return apiPermDraft, nil
+}
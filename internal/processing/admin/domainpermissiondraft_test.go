@@ -0,0 +1,212 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DomainPermissionDraftTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainPermissionDraftTestSuite) TestDraftAccept() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		draft     = testrig.NewTestDomainPermissionDrafts()["bad.example.org_block_draft"]
+	)
+
+	apiBlock, actionID, errWithCode := suite.adminProcessor.DomainPermissionDraftAccept(ctx, adminAcct, draft.ID, false)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.NotEmpty(actionID)
+	suite.Equal("bad.example.org", apiBlock.Domain.Domain)
+	suite.Equal("harassment", apiBlock.PublicComment)
+	suite.Equal("they're absolutely awful", apiBlock.PrivateComment)
+
+	// Wait for side effects.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	// Domain should now be blocked.
+	blocked, err := suite.db.IsDomainBlocked(ctx, "bad.example.org")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(blocked)
+
+	// Draft should be gone.
+	_, err = suite.db.GetDomainPermissionDraftByID(ctx, draft.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *DomainPermissionDraftTestSuite) TestDraftAcceptOwnDraft() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		draft     = testrig.NewTestDomainPermissionDrafts()["bad.example.org_block_draft"]
+	)
+
+	config.SetInstanceDomainPermissionDraftsRequireReview(true)
+	defer config.SetInstanceDomainPermissionDraftsRequireReview(false)
+
+	// Admin created the draft, so
+	// they shouldn't be able to accept it.
+	_, _, errWithCode := suite.adminProcessor.DomainPermissionDraftAccept(ctx, adminAcct, draft.ID, false)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusForbidden, errWithCode.Code())
+	}
+
+	// Draft should still be there.
+	if _, err := suite.db.GetDomainPermissionDraftByID(ctx, draft.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Domain shouldn't be blocked.
+	blocked, err := suite.db.IsDomainBlocked(ctx, "bad.example.org")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(blocked)
+}
+
+func (suite *DomainPermissionDraftTestSuite) TestDraftAcceptOverwrite() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		draft     = testrig.NewTestDomainPermissionDrafts()["good.example.org_allow_draft"]
+	)
+
+	// Create an existing allow for the draft's domain.
+	if err := suite.db.CreateDomainAllow(ctx, &gtsmodel.DomainAllow{
+		ID:                 id.NewULID(),
+		Domain:             "good.example.org",
+		CreatedByAccountID: adminAcct.ID,
+		PublicComment:      "old comment",
+		Obfuscate:          util.Ptr(false),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Accepting without overwrite should conflict.
+	_, _, errWithCode := suite.adminProcessor.DomainPermissionDraftAccept(ctx, adminAcct, draft.ID, false)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusConflict, errWithCode.Code())
+	}
+
+	// Draft should still be there.
+	if _, err := suite.db.GetDomainPermissionDraftByID(ctx, draft.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Accepting with overwrite should update the allow.
+	apiAllow, _, errWithCode := suite.adminProcessor.DomainPermissionDraftAccept(ctx, adminAcct, draft.ID, true)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("friends", apiAllow.PublicComment)
+
+	allow, err := suite.db.GetDomainAllow(ctx, "good.example.org")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("friends", allow.PublicComment)
+
+	// Draft should be gone.
+	_, err = suite.db.GetDomainPermissionDraftByID(ctx, draft.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *DomainPermissionDraftTestSuite) TestDraftRejectExcludeTarget() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		draft     = testrig.NewTestDomainPermissionDrafts()["bad.example.org_block_draft"]
+	)
+
+	apiDraft, errWithCode := suite.adminProcessor.DomainPermissionDraftReject(ctx, adminAcct, draft.ID, true)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("block", apiDraft.PermissionType)
+
+	// Draft should be gone.
+	_, err := suite.db.GetDomainPermissionDraftByID(ctx, draft.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Domain shouldn't be blocked.
+	blocked, err := suite.db.IsDomainBlocked(ctx, "bad.example.org")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(blocked)
+
+	// Domain and subdomains should now be excluded.
+	for _, domain := range []string{
+		"bad.example.org",
+		"sub.bad.example.org",
+	} {
+		excluded, err := suite.db.IsDomainPermissionExcluded(ctx, domain)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.True(excluded, domain)
+	}
+
+	// Rejecting again should 404.
+	_, errWithCode = suite.adminProcessor.DomainPermissionDraftReject(ctx, adminAcct, draft.ID, true)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusNotFound, errWithCode.Code())
+	}
+}
+
+func (suite *DomainPermissionDraftTestSuite) TestDraftCreateConflict() {
+	ctx := context.Background()
+
+	_, errWithCode := suite.adminProcessor.DomainPermissionDraftCreate(
+		ctx,
+		suite.testAccounts["admin_account"],
+		"bad.example.org",
+		gtsmodel.DomainPermissionBlock,
+		false,
+		"",
+		"",
+	)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusConflict, errWithCode.Code())
+	}
+}
+
+func TestDomainPermissionDraftTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainPermissionDraftTestSuite))
+}
@@ -0,0 +1,378 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// apiDomainPermSub is a cheeky shortcut for returning the
+// API version of the given domain permission subscription,
+// or an appropriate error if something goes wrong.
+func (p *Processor) apiDomainPermSub(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	count, err := p.state.DB.CountDomainPermissionSubscriptionPerms(ctx, permSub)
+	if err != nil {
+		err := gtserror.NewfAt(3, "error counting domain permission subscription perms: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiPermSub, err := p.converter.DomainPermSubToAPIDomainPermSub(ctx, permSub, count)
+	if err != nil {
+		err := gtserror.NewfAt(3, "error converting domain permission subscription to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPermSub, nil
+}
+
+// getDomainPermSub gets the domain permission subscription
+// with the given ID, returning an appropriate error if it
+// doesn't exist or something goes wrong.
+func (p *Processor) getDomainPermSub(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, err := p.state.DB.GetDomainPermissionSubscriptionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("domain permission subscription %s not found", id)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		err := gtserror.Newf("db error getting domain permission subscription %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return permSub, nil
+}
+
+// DomainPermissionSubscriptionGet returns one
+// domain permission subscription with the given id.
+func (p *Processor) DomainPermissionSubscriptionGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, errWithCode := p.getDomainPermSub(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiDomainPermSub(ctx, permSub)
+}
+
+// DomainPermissionSubscriptionsGet returns a page of
+// domain permission subscriptions, optionally filtered
+// by the given permission type.
+func (p *Processor) DomainPermissionSubscriptionsGet(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	permSubs, err := p.state.DB.GetDomainPermissionSubscriptions(
+		ctx,
+		permType,
+		page,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting domain permission subscriptions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(permSubs)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := permSubs[count-1].ID
+	hi := permSubs[0].ID
+
+	// Convert each perm sub to API model.
+	items := make([]any, 0, count)
+	for _, permSub := range permSubs {
+		item, errWithCode := p.apiDomainPermSub(ctx, permSub)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		items = append(items, item)
+	}
+
+	// Assemble next/prev page queries.
+	query := make(url.Values, 1)
+	if permType != gtsmodel.DomainPermissionUnknown {
+		query.Set(apiutil.DomainPermissionPermTypeKey, permType.String())
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/domain_permission_subscriptions",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+		Query: query,
+	}), nil
+}
+
+// DomainPermissionSubscriptionCreate creates a new domain
+// permission subscription with the given parameters. The
+// subscription will be processed next time subscriptions
+// are scheduled to run.
+func (p *Processor) DomainPermissionSubscriptionCreate(
+	ctx context.Context,
+	acct *gtsmodel.Account,
+	priority uint8,
+	title string,
+	uri string,
+	contentType gtsmodel.DomainPermSubContentType,
+	permType gtsmodel.DomainPermissionType,
+	asDraft bool,
+	adoptOrphans bool,
+	removeRetracted bool,
+	fetchUsername string,
+	fetchPassword string,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub := &gtsmodel.DomainPermissionSubscription{
+		ID:                 id.NewULID(),
+		Priority:           priority,
+		Title:              title,
+		PermissionType:     permType,
+		AsDraft:            &asDraft,
+		AdoptOrphans:       &adoptOrphans,
+		RemoveRetracted:    &removeRetracted,
+		CreatedByAccountID: acct.ID,
+		CreatedByAccount:   acct,
+		URI:                uri,
+		ContentType:        contentType,
+		FetchUsername:      fetchUsername,
+		FetchPassword:      fetchPassword,
+	}
+
+	if err := p.state.DB.PutDomainPermissionSubscription(ctx, permSub); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Unique constraint conflict.
+			const errText = "domain permission subscription with given title or uri already exists"
+			return nil, gtserror.NewErrorConflict(errors.New(errText), errText)
+		}
+
+		err := gtserror.Newf("db error putting domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainPermSub(ctx, permSub)
+}
+
+// DomainPermissionSubscriptionUpdate updates the domain
+// permission subscription with the given ID, setting only
+// those values which are not nil. Permission type of a
+// subscription cannot be changed after creation.
+func (p *Processor) DomainPermissionSubscriptionUpdate(
+	ctx context.Context,
+	id string,
+	priority *uint8,
+	title *string,
+	uri *string,
+	contentType *gtsmodel.DomainPermSubContentType,
+	asDraft *bool,
+	adoptOrphans *bool,
+	removeRetracted *bool,
+	fetchUsername *string,
+	fetchPassword *string,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, errWithCode := p.getDomainPermSub(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	columns := make([]string, 0, 9)
+
+	if priority != nil {
+		permSub.Priority = *priority
+		columns = append(columns, "priority")
+	}
+
+	if title != nil {
+		permSub.Title = *title
+		columns = append(columns, "title")
+	}
+
+	if uri != nil && *uri != permSub.URI {
+		permSub.URI = *uri
+		columns = append(columns, "uri")
+
+		// New list, so any cache
+		// headers we stored for the
+		// old one are now meaningless.
+		permSub.ETag = ""
+		permSub.LastModified = time.Time{}
+		permSub.SuccessfullyFetchedAt = time.Time{}
+		columns = append(columns, "etag", "last_modified", "successfully_fetched_at")
+	}
+
+	if contentType != nil {
+		permSub.ContentType = *contentType
+		columns = append(columns, "content_type")
+	}
+
+	if asDraft != nil {
+		permSub.AsDraft = asDraft
+		columns = append(columns, "as_draft")
+	}
+
+	if adoptOrphans != nil {
+		permSub.AdoptOrphans = adoptOrphans
+		columns = append(columns, "adopt_orphans")
+	}
+
+	if removeRetracted != nil {
+		permSub.RemoveRetracted = removeRetracted
+		columns = append(columns, "remove_retracted")
+	}
+
+	if fetchUsername != nil {
+		permSub.FetchUsername = *fetchUsername
+		columns = append(columns, "fetch_username")
+	}
+
+	if fetchPassword != nil {
+		permSub.FetchPassword = *fetchPassword
+		columns = append(columns, "fetch_password")
+	}
+
+	if len(columns) == 0 {
+		// Nothing to update.
+		return p.apiDomainPermSub(ctx, permSub)
+	}
+
+	if err := p.state.DB.UpdateDomainPermissionSubscription(ctx, permSub, columns...); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Unique constraint conflict.
+			const errText = "domain permission subscription with given title or uri already exists"
+			return nil, gtserror.NewErrorConflict(errors.New(errText), errText)
+		}
+
+		err := gtserror.Newf("db error updating domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainPermSub(ctx, permSub)
+}
+
+// DomainPermissionSubscriptionRemove removes the domain
+// permission subscription with the given ID. Drafts created
+// by the subscription are always removed. Domain permissions
+// owned by the subscription are removed too if removeChildren
+// is true, otherwise they're orphaned (ie., kept in place, but
+// with their subscription ID unset).
+func (p *Processor) DomainPermissionSubscriptionRemove(
+	ctx context.Context,
+	acct *gtsmodel.Account,
+	id string,
+	removeChildren bool,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, errWithCode := p.getDomainPermSub(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Prepare the subscription to return,
+	// *before* the deletion goes through.
+	apiPermSub, errWithCode := p.apiDomainPermSub(ctx, permSub)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Remove or orphan owned perms.
+	owned, err := p.ownedDomainPerms(ctx, permSub)
+	if err != nil {
+		err := gtserror.Newf("db error getting owned domain permissions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, perm := range owned {
+		if removeChildren {
+			_, _, errWithCode = p.DomainPermissionDelete(ctx, permSub.PermissionType, acct, perm.GetID())
+		} else {
+			errWithCode = p.orphanDomainPerm(ctx, perm)
+		}
+
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	// Remove any drafts created by this subscription.
+	if errWithCode := p.removeDomainPermSubDrafts(ctx, permSub, nil); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Finally remove the subscription itself.
+	if err := p.state.DB.DeleteDomainPermissionSubscription(ctx, permSub.ID); err != nil {
+		err := gtserror.Newf("db error deleting domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPermSub, nil
+}
+
+// DomainPermissionSubscriptionTest fetches and parses the list
+// of the domain permission subscription with the given ID, and
+// returns the parsed domain permissions, without creating them.
+// Useful for checking that a subscription is configured correctly.
+func (p *Processor) DomainPermissionSubscriptionTest(
+	ctx context.Context,
+	id string,
+) ([]*apimodel.DomainPermission, gtserror.WithCode) {
+	permSub, errWithCode := p.getDomainPermSub(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Fetch the list, skipping cache so
+	// that we definitely get a response.
+	perms, _, err := p.fetchDomainPermSub(ctx, permSub, true)
+	if err != nil {
+		err := fmt.Errorf("error fetching domain permission subscription list: %w", err)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	apiPerms := make([]*apimodel.DomainPermission, 0, len(perms))
+	for _, perm := range perms {
+		apiPerm, errWithCode := p.apiDomainPerm(ctx, perm, true)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiPerms = append(apiPerms, apiPerm)
+	}
+
+	return apiPerms, nil
+}
@@ -0,0 +1,338 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DomainPermissionSubscriptionTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) getPermSub() *gtsmodel.DomainPermissionSubscription {
+	permSub, err := suite.db.GetDomainPermissionSubscriptionByID(
+		context.Background(),
+		testrig.NewTestDomainPermissionSubscriptions()["admin_account_block_1"].ID,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return permSub
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) awaitActions() {
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessAsDrafts() {
+	ctx := context.Background()
+
+	// Process the test subscription,
+	// which is set to create drafts.
+	suite.adminProcessor.ProcessDomainPermissionSubscriptions(ctx)
+
+	permSub := suite.getPermSub()
+	suite.Empty(permSub.Error)
+	suite.NotZero(permSub.FetchedAt)
+	suite.Equal(permSub.FetchedAt, permSub.SuccessfullyFetchedAt)
+
+	// Drafts should have been created for the
+	// suspended domains, but not the silenced one.
+	drafts, err := suite.db.GetDomainPermissionDrafts(ctx, gtsmodel.DomainPermissionBlock, permSub.ID, "", nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	domains := make([]string, 0, len(drafts))
+	for _, draft := range drafts {
+		domains = append(domains, draft.Domain)
+	}
+	suite.ElementsMatch([]string{"bumfaces.net", "peepee.poopoo", "nothanks.com"}, domains)
+
+	// No actual blocks should have been created.
+	count, err := suite.db.CountDomainPermissionSubscriptionPerms(ctx, permSub)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(count)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessBlocks() {
+	ctx := context.Background()
+
+	// Enact blocks directly.
+	permSub := suite.getPermSub()
+	permSub.AsDraft = util.Ptr(false)
+	if err := suite.db.UpdateDomainPermissionSubscription(ctx, permSub, "as_draft"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Create a block owned by the subscription
+	// which isn't in the list; it should be
+	// retracted when the list is processed.
+	if err := suite.db.CreateDomainBlock(ctx, &gtsmodel.DomainBlock{
+		ID:                 id.NewULID(),
+		Domain:             "retracted.example.org",
+		CreatedByAccountID: permSub.CreatedByAccountID,
+		SubscriptionID:     permSub.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Create an orphan block for a domain that
+	// *is* in the list; since the sub doesn't
+	// adopt orphans, it should be left alone.
+	if err := suite.db.CreateDomainBlock(ctx, &gtsmodel.DomainBlock{
+		ID:                 id.NewULID(),
+		Domain:             "nothanks.com",
+		CreatedByAccountID: permSub.CreatedByAccountID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.adminProcessor.ProcessDomainPermissionSubscriptions(ctx)
+	suite.awaitActions()
+
+	permSub = suite.getPermSub()
+	suite.Empty(permSub.Error)
+
+	for domain, subID := range map[string]string{
+		"bumfaces.net":  permSub.ID,
+		"peepee.poopoo": permSub.ID,
+		"nothanks.com":  "",
+		"replyguys.com": "",
+	} {
+		block, err := suite.db.GetDomainBlock(ctx, domain)
+		if err != nil {
+			suite.FailNow(err.Error(), domain)
+		}
+		suite.Equal(subID, block.SubscriptionID, domain)
+	}
+
+	// Public comment should be taken from the list.
+	block, err := suite.db.GetDomainBlock(ctx, "bumfaces.net")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("big jerks", block.PublicComment)
+
+	// Silenced domain should not be blocked,
+	// and retracted domain should be removed.
+	for _, domain := range []string{
+		"silenced.example.org",
+		"retracted.example.org",
+	} {
+		blocked, err := suite.db.IsDomainBlocked(ctx, domain)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.False(blocked, domain)
+	}
+
+	// No drafts should have been created.
+	_, err = suite.db.GetDomainPermissionDrafts(ctx, gtsmodel.DomainPermissionBlock, permSub.ID, "", nil)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessExcluded() {
+	ctx := context.Background()
+
+	// Exclude one of the listed domains.
+	if err := suite.db.PutDomainPermissionExclude(ctx, &gtsmodel.DomainPermissionExclude{
+		ID:                 id.NewULID(),
+		Domain:             "peepee.poopoo",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.adminProcessor.ProcessDomainPermissionSubscriptions(ctx)
+
+	permSub := suite.getPermSub()
+	suite.Empty(permSub.Error)
+
+	// No draft should have been
+	// created for the excluded domain.
+	drafts, err := suite.db.GetDomainPermissionDrafts(ctx, gtsmodel.DomainPermissionBlock, permSub.ID, "", nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	domains := make([]string, 0, len(drafts))
+	for _, draft := range drafts {
+		domains = append(domains, draft.Domain)
+	}
+	suite.ElementsMatch([]string{"bumfaces.net", "nothanks.com"}, domains)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessAdoptOrphansNoRemove() {
+	ctx := context.Background()
+
+	// Switch to the plaintext list, adopt
+	// orphans, and orphan retracted perms.
+	permSub := suite.getPermSub()
+	permSub.AsDraft = util.Ptr(false)
+	permSub.AdoptOrphans = util.Ptr(true)
+	permSub.RemoveRetracted = util.Ptr(false)
+	permSub.URI = "https://lists.example.org/baddies.txt"
+	permSub.ContentType = gtsmodel.DomainPermSubContentTypePlain
+	if err := suite.db.UpdateDomainPermissionSubscription(ctx, permSub); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Create owned block not in the list,
+	// and orphan block that is in the list.
+	for domain, subID := range map[string]string{
+		"retracted.example.org": permSub.ID,
+		"nothanks.com":          "",
+	} {
+		if err := suite.db.CreateDomainBlock(ctx, &gtsmodel.DomainBlock{
+			ID:                 id.NewULID(),
+			Domain:             domain,
+			CreatedByAccountID: permSub.CreatedByAccountID,
+			SubscriptionID:     subID,
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	suite.adminProcessor.ProcessDomainPermissionSubscriptions(ctx)
+	suite.awaitActions()
+
+	permSub = suite.getPermSub()
+	suite.Empty(permSub.Error)
+
+	for domain, subID := range map[string]string{
+		"bumfaces.net":          permSub.ID,
+		"peepee.poopoo":         permSub.ID,
+		"nothanks.com":          permSub.ID, // adopted
+		"retracted.example.org": "",         // orphaned
+	} {
+		block, err := suite.db.GetDomainBlock(ctx, domain)
+		if err != nil {
+			suite.FailNow(err.Error(), domain)
+		}
+		suite.Equal(subID, block.SubscriptionID, domain)
+	}
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessPriority() {
+	ctx := context.Background()
+
+	// Enact blocks directly from the test subscription.
+	permSub := suite.getPermSub()
+	permSub.AsDraft = util.Ptr(false)
+	if err := suite.db.UpdateDomainPermissionSubscription(ctx, permSub, "as_draft"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Add a higher priority subscription
+	// serving a subset of the same domains.
+	higher := &gtsmodel.DomainPermissionSubscription{
+		ID:                 id.NewULID(),
+		Priority:           100,
+		PermissionType:     gtsmodel.DomainPermissionBlock,
+		AsDraft:            util.Ptr(false),
+		AdoptOrphans:       util.Ptr(false),
+		RemoveRetracted:    util.Ptr(true),
+		CreatedByAccountID: permSub.CreatedByAccountID,
+		URI:                "https://lists.example.org/baddies.json",
+		ContentType:        gtsmodel.DomainPermSubContentTypeJSON,
+	}
+	if err := suite.db.PutDomainPermissionSubscription(ctx, higher); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.adminProcessor.ProcessDomainPermissionSubscriptions(ctx)
+	suite.awaitActions()
+
+	// Domains listed by both should be owned
+	// by the higher priority subscription.
+	for _, domain := range []string{
+		"bumfaces.net",
+		"peepee.poopoo",
+		"nothanks.com",
+	} {
+		block, err := suite.db.GetDomainBlock(ctx, domain)
+		if err != nil {
+			suite.FailNow(err.Error(), domain)
+		}
+		suite.Equal(higher.ID, block.SubscriptionID, domain)
+	}
+
+	// Lower priority subscription owns nothing.
+	count, err := suite.db.CountDomainPermissionSubscriptionPerms(ctx, permSub)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(count)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestSubscriptionTest() {
+	ctx := context.Background()
+
+	perms, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionTest(
+		ctx,
+		suite.getPermSub().ID,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Len(perms, 3)
+	suite.Equal("bumfaces.net", perms[0].Domain.Domain)
+	suite.Equal("big jerks", perms[0].PublicComment)
+
+	// Nothing should have been created.
+	_, err := suite.db.GetDomainPermissionDrafts(ctx, gtsmodel.DomainPermissionBlock, suite.getPermSub().ID, "", nil)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessFetchError() {
+	ctx := context.Background()
+
+	permSub := suite.getPermSub()
+	permSub.URI = "https://lists.example.org/does-not-exist.csv"
+	if err := suite.db.UpdateDomainPermissionSubscription(ctx, permSub, "uri"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.adminProcessor.ProcessDomainPermissionSubscriptions(ctx)
+
+	permSub = suite.getPermSub()
+	suite.NotEmpty(permSub.Error)
+	suite.NotZero(permSub.FetchedAt)
+	suite.Zero(permSub.SuccessfullyFetchedAt)
+}
+
+func TestDomainPermissionSubscriptionTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainPermissionSubscriptionTestSuite))
+}
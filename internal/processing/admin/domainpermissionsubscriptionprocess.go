@@ -0,0 +1,840 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// ScheduleDomainPermissionSubscriptions schedules
+// processing of domain permission subscriptions
+// using configured parameters.
+//
+// Returns an error if `InstanceSubscriptionsProcessFrom`
+// is not a valid format (hh:mm).
+func (p *Processor) ScheduleDomainPermissionSubscriptions() error {
+	const hourMinute = "15:04"
+
+	var (
+		now            = time.Now()
+		processEvery   = config.GetInstanceSubscriptionsProcessEvery()
+		processFromStr = config.GetInstanceSubscriptionsProcessFrom()
+	)
+
+	// Parse processFromStr as hh:mm.
+	// Resulting time will be on 1 Jan year zero.
+	processFrom, err := time.Parse(hourMinute, processFromStr)
+	if err != nil {
+		return gtserror.Newf(
+			"error parsing '%s' in time format 'hh:mm': %w",
+			processFromStr, err,
+		)
+	}
+
+	// Move from year zero to today.
+	firstProcessAt := time.Date(
+		now.Year(),
+		now.Month(),
+		now.Day(),
+		processFrom.Hour(),
+		processFrom.Minute(),
+		0,
+		0,
+		now.Location(),
+	)
+
+	// Ensure first processing is in the future.
+	for firstProcessAt.Before(now) {
+		firstProcessAt = firstProcessAt.Add(processEvery)
+	}
+
+	fn := func(ctx context.Context, start time.Time) {
+		log.Info(ctx, "starting instance subscriptions processing")
+		p.ProcessDomainPermissionSubscriptions(ctx)
+		log.Infof(ctx, "finished instance subscriptions processing after %s", time.Since(start))
+	}
+
+	log.Infof(nil,
+		"scheduling instance subscriptions processing to run every %s, starting from %s; next processing will run at %s",
+		processEvery, processFromStr, firstProcessAt,
+	)
+
+	// Schedule processing to execute according to schedule.
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@subsprocessing",
+		firstProcessAt,
+		processEvery,
+		fn,
+	) {
+		panic("failed to schedule @subsprocessing")
+	}
+
+	return nil
+}
+
+// ProcessDomainPermissionSubscriptions fetches and processes
+// all domain permission subscriptions, allows first and then
+// blocks, in descending order of priority. Errors processing
+// one subscription are stored on that subscription, and do
+// not prevent other subscriptions from being processed.
+func (p *Processor) ProcessDomainPermissionSubscriptions(ctx context.Context) {
+	for _, permType := range []gtsmodel.DomainPermissionType{
+		gtsmodel.DomainPermissionAllow,
+		gtsmodel.DomainPermissionBlock,
+	} {
+		permSubs, err := p.state.DB.GetDomainPermissionSubscriptionsByPriority(ctx, permType)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting domain %s subscriptions: %v", permType.String(), err)
+			continue
+		}
+
+		// Map subscription IDs to their priorities,
+		// so we can tell which subscription wins
+		// when two subscriptions list the same domain.
+		priorities := make(map[string]uint8, len(permSubs))
+		for _, permSub := range permSubs {
+			priorities[permSub.ID] = permSub.Priority
+		}
+
+		for _, permSub := range permSubs {
+			p.processDomainPermSub(ctx, permSub, priorities)
+		}
+	}
+}
+
+// processDomainPermSub fetches the list of the given
+// subscription, and creates, updates, or retracts
+// domain permissions according to its contents.
+// Fetch / parse status is stored on the subscription.
+func (p *Processor) processDomainPermSub(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	priorities map[string]uint8,
+) {
+	l := log.
+		WithContext(ctx).
+		WithField("subscription", permSub.URI)
+
+	// Set fetched at to now. Even if we
+	// error below, we still attempted.
+	permSub.FetchedAt = time.Now()
+
+	perms, resp, err := p.fetchDomainPermSub(ctx, permSub, false)
+	if err == nil && resp.Unmodified {
+		// Nothing changed since last
+		// time, so nothing to process.
+		l.Debug("list unmodified since last fetch")
+	} else if err == nil {
+		// Enact the freshly fetched list.
+		err = p.enactDomainPermSub(ctx, permSub, perms, priorities)
+	}
+
+	if err != nil {
+		l.Warnf("error processing subscription: %v", err)
+		permSub.Error = err.Error()
+	} else {
+		permSub.SuccessfullyFetchedAt = permSub.FetchedAt
+		permSub.ETag = resp.ETag
+		permSub.LastModified = resp.LastModified
+		permSub.Error = ""
+	}
+
+	if err := p.state.DB.UpdateDomainPermissionSubscription(
+		ctx,
+		permSub,
+		"fetched_at",
+		"successfully_fetched_at",
+		"etag",
+		"last_modified",
+		"error",
+	); err != nil {
+		l.Errorf("db error updating subscription: %v", err)
+	}
+}
+
+// fetchDomainPermSub dereferences and parses the list of the
+// given subscription. If the list was not modified since the
+// last successful fetch, returned perms will be nil, and
+// resp.Unmodified will be true.
+func (p *Processor) fetchDomainPermSub(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	skipCache bool,
+) (
+	[]gtsmodel.DomainPermission,
+	*transport.DereferenceDomainPermissionsResp,
+	error,
+) {
+	// Fetch the list using the instance account transport.
+	tsport, err := p.transport.NewTransportForUsername(ctx, "")
+	if err != nil {
+		return nil, nil, gtserror.Newf("error getting instance transport: %w", err)
+	}
+
+	resp, err := tsport.DereferenceDomainPermissions(ctx, permSub, skipCache)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.Unmodified {
+		return nil, resp, nil
+	}
+
+	// Parse the body according to sub content type.
+	defer resp.Body.Close()
+
+	var perms []gtsmodel.DomainPermission
+	switch permSub.ContentType {
+	case gtsmodel.DomainPermSubContentTypeCSV:
+		perms, err = parseDomainPermsCSV(resp.Body, permSub.PermissionType)
+	case gtsmodel.DomainPermSubContentTypeJSON:
+		perms, err = parseDomainPermsJSON(resp.Body, permSub.PermissionType)
+	case gtsmodel.DomainPermSubContentTypePlain:
+		perms, err = parseDomainPermsPlain(resp.Body, permSub.PermissionType)
+	default:
+		err = gtserror.Newf("unrecognized content type %d", permSub.ContentType)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Guard against an upstream list accidentally
+	// being emptied: don't treat this as a valid
+	// list, or all owned perms would be retracted.
+	if len(perms) == 0 {
+		return nil, nil, errors.New("list contained no valid domain permission entries")
+	}
+
+	return perms, resp, nil
+}
+
+// enactDomainPermSub creates, updates or retracts domain
+// permissions (or drafts) so that they match the given
+// freshly-fetched list of perms for the subscription.
+func (p *Processor) enactDomainPermSub(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	perms []gtsmodel.DomainPermission,
+	priorities map[string]uint8,
+) error {
+	// Get the account to attribute new perms
+	// to, falling back to the instance account.
+	acct, err := p.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		permSub.CreatedByAccountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting subscription creator account: %w", err)
+	}
+
+	if acct == nil {
+		acct, err = p.state.DB.GetInstanceAccount(ctx, "")
+		if err != nil {
+			return gtserror.Newf("db error getting instance account: %w", err)
+		}
+	}
+
+	// Track domains present
+	// in the fetched list.
+	listed := make(map[string]struct{}, len(perms))
+
+	for _, perm := range perms {
+		domain := perm.GetDomain()
+		listed[domain] = struct{}{}
+
+		if err := p.enactDomainPerm(
+			ctx,
+			permSub,
+			priorities,
+			acct,
+			perm,
+		); err != nil {
+			// Log and carry on with the rest of the list.
+			log.Errorf(ctx, "error processing %s for subscription %s: %v", domain, permSub.ID, err)
+		}
+	}
+
+	// Retract any owned perms that
+	// are no longer present in the list.
+	owned, err := p.ownedDomainPerms(ctx, permSub)
+	if err != nil {
+		return gtserror.Newf("db error getting owned domain permissions: %w", err)
+	}
+
+	for _, perm := range owned {
+		if _, ok := listed[perm.GetDomain()]; ok {
+			// Still listed.
+			continue
+		}
+
+		var errWithCode gtserror.WithCode
+		if *permSub.RemoveRetracted {
+			_, _, errWithCode = p.DomainPermissionDelete(ctx, permSub.PermissionType, acct, perm.GetID())
+		} else {
+			errWithCode = p.orphanDomainPerm(ctx, perm)
+		}
+
+		if errWithCode != nil {
+			log.Errorf(ctx, "error retracting %s for subscription %s: %v", perm.GetDomain(), permSub.ID, errWithCode)
+		}
+	}
+
+	// Remove any drafts that
+	// were retracted from the list.
+	if errWithCode := p.removeDomainPermSubDrafts(ctx, permSub, listed); errWithCode != nil {
+		return errWithCode
+	}
+
+	return nil
+}
+
+// enactDomainPerm creates or updates one domain
+// permission (or draft) from a subscription list.
+func (p *Processor) enactDomainPerm(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	priorities map[string]uint8,
+	acct *gtsmodel.Account,
+	perm gtsmodel.DomainPermission,
+) error {
+	domain := perm.GetDomain()
+
+	// Never create or update perms
+	// (or drafts) for excluded domains.
+	excluded, errWithCode := p.domainPermExcluded(ctx, domain)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if excluded {
+		log.Debugf(ctx, "skipping excluded domain %s", domain)
+		return nil
+	}
+
+	existing, err := p.getDomainPerm(ctx, permSub.PermissionType, domain)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		ownerID := existing.GetSubscriptionID()
+		ownerPriority, ownerExists := priorities[ownerID]
+
+		switch {
+
+		// We already own this
+		// perm, just update it.
+		case ownerID == permSub.ID:
+
+		// Perm is orphaned, adopt it only
+		// if the subscription is set to do so.
+		case ownerID == "" || !ownerExists:
+			if !*permSub.AdoptOrphans {
+				return nil
+			}
+
+		// Perm is owned by a lower-priority
+		// subscription, so we take it over.
+		case ownerPriority < permSub.Priority:
+
+		// Perm is owned by a higher or equal
+		// priority subscription, leave it be.
+		default:
+			return nil
+		}
+
+		return p.updateDomainPerm(ctx, existing, permSub.ID, perm)
+	}
+
+	if !*permSub.AsDraft {
+		// No existing perm, create it
+		// (and process side effects).
+		_, _, errWithCode := p.DomainPermissionCreate(
+			ctx,
+			permSub.PermissionType,
+			acct,
+			domain,
+			*perm.GetObfuscate(),
+			perm.GetPublicComment(),
+			perm.GetPrivateComment(),
+			permSub.ID,
+		)
+		if errWithCode != nil {
+			return errWithCode
+		}
+		return nil
+	}
+
+	// We're in draft mode, so check if
+	// there's a draft for this already.
+	draft, err := p.state.DB.GetDomainPermissionDraftByDomain(ctx, permSub.PermissionType, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting domain permission draft: %w", err)
+	}
+
+	if draft != nil {
+		// Draft already
+		// waiting for review.
+		return nil
+	}
+
+	draft = &gtsmodel.DomainPermissionDraft{
+		ID:                 id.NewULID(),
+		PermissionType:     permSub.PermissionType,
+		Domain:             domain,
+		CreatedByAccountID: acct.ID,
+		CreatedByAccount:   acct,
+		PrivateComment:     perm.GetPrivateComment(),
+		PublicComment:      perm.GetPublicComment(),
+		Obfuscate:          perm.GetObfuscate(),
+		SubscriptionID:     permSub.ID,
+	}
+
+	if err := p.state.DB.PutDomainPermissionDraft(ctx, draft); err != nil {
+		return gtserror.Newf("db error putting domain permission draft: %w", err)
+	}
+
+	return nil
+}
+
+// getDomainPerm returns the existing domain permission of
+// the given type for the given domain, or nil if none exists.
+func (p *Processor) getDomainPerm(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	domain string,
+) (gtsmodel.DomainPermission, error) {
+	var (
+		perm gtsmodel.DomainPermission
+		err  error
+	)
+
+	// Careful to only ever assign non-nil
+	// pointers to perm, so that the
+	// returned interface is really nil.
+	switch permType {
+	case gtsmodel.DomainPermissionBlock:
+		var block *gtsmodel.DomainBlock
+		block, err = p.state.DB.GetDomainBlock(ctx, domain)
+		if block != nil {
+			perm = block
+		}
+	case gtsmodel.DomainPermissionAllow:
+		var allow *gtsmodel.DomainAllow
+		allow, err = p.state.DB.GetDomainAllow(ctx, domain)
+		if allow != nil {
+			perm = allow
+		}
+	default:
+		err = gtserror.Newf("unrecognized permission type %d", permType)
+	}
+
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting domain %s %s: %w", permType.String(), domain, err)
+	}
+
+	return perm, nil
+}
+
+// updateDomainPerm sets the subscription ID of the given
+// existing domain permission, and updates its comments
+// and obfuscate value from the given listed permission.
+func (p *Processor) updateDomainPerm(
+	ctx context.Context,
+	existing gtsmodel.DomainPermission,
+	subscriptionID string,
+	listed gtsmodel.DomainPermission,
+) error {
+	var (
+		publicComment  = text.SanitizeToPlaintext(listed.GetPublicComment())
+		privateComment = text.SanitizeToPlaintext(listed.GetPrivateComment())
+		obfuscate      = listed.GetObfuscate()
+	)
+
+	// Only update private comment if the
+	// list gave one, otherwise keep ours.
+	if privateComment == "" {
+		privateComment = existing.GetPrivateComment()
+	}
+
+	if existing.GetSubscriptionID() == subscriptionID &&
+		existing.GetPublicComment() == publicComment &&
+		existing.GetPrivateComment() == privateComment &&
+		*existing.GetObfuscate() == *obfuscate {
+		// Nothing changed.
+		return nil
+	}
+
+	columns := []string{
+		"subscription_id",
+		"public_comment",
+		"private_comment",
+		"obfuscate",
+	}
+
+	var err error
+	switch perm := existing.(type) {
+	case *gtsmodel.DomainBlock:
+		perm.SubscriptionID = subscriptionID
+		perm.PublicComment = publicComment
+		perm.PrivateComment = privateComment
+		perm.Obfuscate = obfuscate
+		err = p.state.DB.UpdateDomainBlock(ctx, perm, columns...)
+	case *gtsmodel.DomainAllow:
+		perm.SubscriptionID = subscriptionID
+		perm.PublicComment = publicComment
+		perm.PrivateComment = privateComment
+		perm.Obfuscate = obfuscate
+		err = p.state.DB.UpdateDomainAllow(ctx, perm, columns...)
+	default:
+		err = gtserror.Newf("unrecognized domain permission %T", existing)
+	}
+
+	if err != nil {
+		return gtserror.Newf("db error updating domain permission: %w", err)
+	}
+
+	return nil
+}
+
+// orphanDomainPerm unsets the subscription
+// ID of the given domain permission.
+func (p *Processor) orphanDomainPerm(
+	ctx context.Context,
+	perm gtsmodel.DomainPermission,
+) gtserror.WithCode {
+	var err error
+
+	switch perm := perm.(type) {
+	case *gtsmodel.DomainBlock:
+		perm.SubscriptionID = ""
+		err = p.state.DB.UpdateDomainBlock(ctx, perm, "subscription_id")
+	case *gtsmodel.DomainAllow:
+		perm.SubscriptionID = ""
+		err = p.state.DB.UpdateDomainAllow(ctx, perm, "subscription_id")
+	default:
+		err = gtserror.Newf("unrecognized domain permission %T", perm)
+	}
+
+	if err != nil {
+		err := gtserror.Newf("db error orphaning domain permission: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// ownedDomainPerms returns all domain permissions
+// currently owned by the given subscription.
+func (p *Processor) ownedDomainPerms(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+) ([]gtsmodel.DomainPermission, error) {
+	var owned []gtsmodel.DomainPermission
+
+	switch permSub.PermissionType {
+	case gtsmodel.DomainPermissionBlock:
+		blocks, err := p.state.DB.GetDomainBlocks(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, block := range blocks {
+			if block.SubscriptionID == permSub.ID {
+				owned = append(owned, block)
+			}
+		}
+
+	case gtsmodel.DomainPermissionAllow:
+		allows, err := p.state.DB.GetDomainAllows(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, allow := range allows {
+			if allow.SubscriptionID == permSub.ID {
+				owned = append(owned, allow)
+			}
+		}
+
+	default:
+		return nil, gtserror.Newf("unrecognized permission type %d", permSub.PermissionType)
+	}
+
+	return owned, nil
+}
+
+// removeDomainPermSubDrafts removes drafts created by the
+// given subscription, except for those with domains in keep.
+func (p *Processor) removeDomainPermSubDrafts(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	keep map[string]struct{},
+) gtserror.WithCode {
+	drafts, err := p.state.DB.GetDomainPermissionDrafts(
+		ctx,
+		permSub.PermissionType,
+		permSub.ID,
+		"",  // any domain
+		nil, // all drafts
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting domain permission drafts: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	for _, draft := range drafts {
+		if _, ok := keep[draft.Domain]; ok {
+			continue
+		}
+
+		if err := p.state.DB.DeleteDomainPermissionDraft(ctx, draft.ID); err != nil {
+			err := gtserror.Newf("db error deleting domain permission draft: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// newDomainPerm returns a new domain permission of
+// the given type, with the given domain and values.
+//
+// The returned model is only a carrier for parsed
+// list entries, and should not be stored directly.
+func newDomainPerm(
+	permType gtsmodel.DomainPermissionType,
+	domain string,
+	publicComment string,
+	privateComment string,
+	obfuscate bool,
+) gtsmodel.DomainPermission {
+	if permType == gtsmodel.DomainPermissionAllow {
+		return &gtsmodel.DomainAllow{
+			Domain:         domain,
+			PublicComment:  publicComment,
+			PrivateComment: privateComment,
+			Obfuscate:      &obfuscate,
+		}
+	}
+
+	return &gtsmodel.DomainBlock{
+		Domain:         domain,
+		PublicComment:  publicComment,
+		PrivateComment: privateComment,
+		Obfuscate:      &obfuscate,
+	}
+}
+
+// normalizeListedDomain trims and punifies the given
+// domain from a subscription list, returning "" if the
+// domain is invalid, or refers to this instance.
+func normalizeListedDomain(raw string) string {
+	domain := strings.TrimSpace(raw)
+	if domain == "" {
+		return ""
+	}
+
+	domain, err := util.Punify(domain)
+	if err != nil ||
+		strings.ContainsAny(domain, "/:@ \t") ||
+		!strings.Contains(domain, ".") {
+		log.Debugf(nil, "skipping invalid domain %q", raw)
+		return ""
+	}
+
+	// Never block or allow ourselves.
+	if domain == config.GetHost() ||
+		domain == config.GetAccountDomain() {
+		return ""
+	}
+
+	return domain
+}
+
+// parseDomainPermsCSV parses a CSV list of domain permissions
+// in the format exported by Mastodon, with header row:
+//
+//	#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate
+//
+// For block lists, only entries with "suspend" severity
+// (or no severity column at all) are included.
+func parseDomainPermsCSV(
+	body io.Reader,
+	permType gtsmodel.DomainPermissionType,
+) ([]gtsmodel.DomainPermission, error) {
+	csvReader := csv.NewReader(body)
+	csvReader.FieldsPerRecord = -1 // Allow variable.
+	csvReader.TrimLeadingSpace = true
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, gtserror.Newf("error reading csv: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("csv list was empty")
+	}
+
+	// Map header column names
+	// (with "#" prefix removed)
+	// to their column index.
+	columns := make(map[string]int, len(records[0]))
+	for i, column := range records[0] {
+		column = strings.TrimPrefix(strings.TrimSpace(column), "#")
+		columns[column] = i
+	}
+
+	domainIdx, ok := columns["domain"]
+	if !ok {
+		return nil, errors.New("csv header did not contain domain column")
+	}
+
+	// Get value of named column in
+	// record, or "" if not present.
+	get := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	perms := make([]gtsmodel.DomainPermission, 0, len(records)-1)
+	for _, record := range records[1:] {
+		if domainIdx >= len(record) {
+			continue
+		}
+
+		domain := normalizeListedDomain(record[domainIdx])
+		if domain == "" {
+			continue
+		}
+
+		// Silences and no-ops don't
+		// map to anything we support.
+		if permType == gtsmodel.DomainPermissionBlock {
+			if severity := get(record, "severity"); severity != "" && severity != "suspend" {
+				continue
+			}
+		}
+
+		obfuscate, _ := strconv.ParseBool(get(record, "obfuscate"))
+		perms = append(perms, newDomainPerm(
+			permType,
+			domain,
+			get(record, "public_comment"),
+			"",
+			obfuscate,
+		))
+	}
+
+	return perms, nil
+}
+
+// parseDomainPermsJSON parses a JSON array of domain
+// permissions, in the format exported by GoToSocial.
+func parseDomainPermsJSON(
+	body io.Reader,
+	permType gtsmodel.DomainPermissionType,
+) ([]gtsmodel.DomainPermission, error) {
+	var apiPerms []*apimodel.DomainPermission
+	if err := json.NewDecoder(body).Decode(&apiPerms); err != nil {
+		return nil, gtserror.Newf("error decoding json: %w", err)
+	}
+
+	perms := make([]gtsmodel.DomainPermission, 0, len(apiPerms))
+	for _, apiPerm := range apiPerms {
+		if apiPerm == nil {
+			continue
+		}
+
+		domain := normalizeListedDomain(apiPerm.Domain.Domain)
+		if domain == "" {
+			continue
+		}
+
+		perms = append(perms, newDomainPerm(
+			permType,
+			domain,
+			apiPerm.PublicComment,
+			apiPerm.PrivateComment,
+			apiPerm.Obfuscate,
+		))
+	}
+
+	return perms, nil
+}
+
+// parseDomainPermsPlain parses a plaintext list of
+// domains, one per line. Empty lines, and lines
+// beginning with "#" (comments) are ignored.
+func parseDomainPermsPlain(
+	body io.Reader,
+	permType gtsmodel.DomainPermissionType,
+) ([]gtsmodel.DomainPermission, error) {
+	var perms []gtsmodel.DomainPermission
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		domain := normalizeListedDomain(line)
+		if domain == "" {
+			continue
+		}
+
+		perms = append(perms, newDomainPerm(
+			permType,
+			domain,
+			"",
+			"",
+			false,
+		))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, gtserror.Newf("error reading plaintext list: %w", err)
+	}
+
+	return perms, nil
+}
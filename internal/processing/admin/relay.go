@@ -0,0 +1,281 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+func (p *Processor) getRelay(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.Relay, gtserror.WithCode) {
+	relay, err := p.state.DB.GetRelayByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting relay %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if relay == nil {
+		err := gtserror.Newf("relay %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return relay, nil
+}
+
+// RelaysGet returns all relays known to this instance.
+func (p *Processor) RelaysGet(ctx context.Context) ([]*apimodel.AdminRelay, gtserror.WithCode) {
+	relays, err := p.state.DB.GetRelays(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting relays: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiRelays := make([]*apimodel.AdminRelay, 0, len(relays))
+	for _, relay := range relays {
+		apiRelays = append(apiRelays, p.converter.RelayToAdminAPIRelay(relay))
+	}
+
+	return apiRelays, nil
+}
+
+// RelayGet returns one relay with the given ID.
+func (p *Processor) RelayGet(ctx context.Context, id string) (*apimodel.AdminRelay, gtserror.WithCode) {
+	relay, errWithCode := p.getRelay(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.RelayToAdminAPIRelay(relay), nil
+}
+
+// RelayCreate adds a new relay with the given inbox
+// URL, and asynchronously sends a Follow from the
+// instance actor to the relay. The relay will remain
+// pending until the relay Accepts the Follow.
+func (p *Processor) RelayCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	inboxURL string,
+) (*apimodel.AdminRelay, gtserror.WithCode) {
+	inboxURI, errWithCode := p.validateRelayInbox(ctx, inboxURL)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Ensure we don't already have this relay.
+	existing, err := p.state.DB.GetRelayByInboxURI(ctx, inboxURI.String())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking for existing relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		err := fmt.Errorf("relay with inbox %s already exists", inboxURI)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	// The Follow is sent from the instance
+	// actor, whose username is our host.
+	relayID := id.NewULID()
+	relay := &gtsmodel.Relay{
+		ID:                 relayID,
+		InboxURI:           inboxURI.String(),
+		FollowURI:          uris.GenerateURIForFollow(config.GetHost(), relayID),
+		State:              gtsmodel.RelayStatePending,
+		CreatedByAccountID: adminAcct.ID,
+	}
+
+	if err := p.state.DB.PutRelay(ctx, relay); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err := fmt.Errorf("relay with inbox %s already exists", inboxURI)
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+
+		err := gtserror.Newf("db error putting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Send the Follow asynchronously, recording
+	// any errors on the relay so admins can see.
+	r := *relay
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		p.followRelay(ctx, &r)
+	})
+
+	return p.converter.RelayToAdminAPIRelay(relay), nil
+}
+
+// RelayDelete removes the relay with the given ID,
+// and asynchronously sends an Undo of the instance
+// actor's Follow to the relay.
+func (p *Processor) RelayDelete(ctx context.Context, id string) (*apimodel.AdminRelay, gtserror.WithCode) {
+	relay, errWithCode := p.getRelay(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteRelayByID(ctx, relay.ID); err != nil {
+		err := gtserror.Newf("db error deleting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Undo the Follow asynchronously. The relay is
+	// already gone from the db, so we can only log.
+	r := *relay
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		undo, err := p.converter.RelayToASUndo(ctx, &r)
+		if err != nil {
+			log.Errorf(ctx, "error converting relay to undo: %v", err)
+			return
+		}
+
+		if err := p.sendToRelay(ctx, &r, undo); err != nil {
+			log.Warnf(ctx, "error sending undo to relay %s: %v", r.InboxURI, err)
+		}
+	})
+
+	return p.converter.RelayToAdminAPIRelay(relay), nil
+}
+
+// validateRelayInbox parses the given inbox URL, and checks
+// it's an http(s) URL on a remote host that isn't blocked.
+func (p *Processor) validateRelayInbox(
+	ctx context.Context,
+	inboxURL string,
+) (*url.URL, gtserror.WithCode) {
+	if inboxURL == "" {
+		const text = "inbox_url must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	inboxURI, err := url.Parse(inboxURL)
+	if err != nil ||
+		(inboxURI.Scheme != "https" && inboxURI.Scheme != "http") ||
+		inboxURI.Host == "" {
+		const text = "inbox_url must be a valid http(s) URL"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if inboxURI.Host == config.GetHost() ||
+		inboxURI.Host == config.GetAccountDomain() {
+		const text = "inbox_url cannot point to this instance"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	blocked, err := p.state.DB.IsDomainBlocked(ctx, inboxURI.Hostname())
+	if err != nil {
+		err := gtserror.Newf("db error checking domain block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		const text = "inbox_url domain is blocked"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return inboxURI, nil
+}
+
+// followRelay sends a Follow from the instance actor
+// to the given relay, storing any error on the relay.
+func (p *Processor) followRelay(ctx context.Context, relay *gtsmodel.Relay) {
+	follow, err := p.converter.RelayToASFollow(ctx, relay)
+	if err != nil {
+		log.Errorf(ctx, "error converting relay to follow: %v", err)
+		return
+	}
+
+	if err := p.sendToRelay(ctx, relay, follow); err != nil {
+		log.Warnf(ctx, "error sending follow to relay %s: %v", relay.InboxURI, err)
+		relay.Error = err.Error()
+	} else {
+		relay.Error = ""
+	}
+
+	if err := p.state.DB.UpdateRelay(ctx, relay, "error"); err != nil {
+		log.Errorf(ctx, "db error updating relay: %v", err)
+	}
+}
+
+// sendToRelay signs and POSTs the given activity
+// to the inbox of the relay using the instance
+// transport, returning any error immediately
+// rather than queueing the activity for delivery.
+func (p *Processor) sendToRelay(
+	ctx context.Context,
+	relay *gtsmodel.Relay,
+	activity vocab.Type,
+) error {
+	m, err := ap.Serialize(activity)
+	if err != nil {
+		return gtserror.Newf("error serializing activity: %w", err)
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return gtserror.Newf("error marshaling json: %w", err)
+	}
+
+	tsport, err := p.transport.NewTransportForUsername(ctx, "")
+	if err != nil {
+		return gtserror.Newf("error getting instance transport: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx,
+		http.MethodPost,
+		relay.InboxURI,
+		bytes.NewReader(b),
+	)
+	if err != nil {
+		return gtserror.Newf("error preparing request: %w", err)
+	}
+	req.Header.Add("Content-Type", string(apiutil.AppActivityLDJSON))
+	req.Header.Add("Accept-Charset", "utf-8")
+
+	rsp, err := tsport.POST(req, b)
+	if err != nil {
+		return err
+	}
+
+	if code := rsp.StatusCode; code < 200 || code >= 300 {
+		return gtserror.NewFromResponse(rsp)
+	}
+
+	_ = rsp.Body.Close()
+	return nil
+}
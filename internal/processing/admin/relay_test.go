@@ -0,0 +1,145 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RelayTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// sentTo returns the first message sent
+// by the mock http client to the given URL.
+func (suite *RelayTestSuite) sentTo(url string) map[string]any {
+	var sent []byte
+	if !testrig.WaitFor(func() bool {
+		sI, ok := suite.httpClient.SentMessages.Load(url)
+		if !ok {
+			return false
+		}
+		sent = sI.([][]byte)[0]
+		return true
+	}) {
+		suite.FailNow("timed out waiting for message to " + url)
+	}
+
+	m := make(map[string]any)
+	if err := json.Unmarshal(sent, &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return m
+}
+
+func (suite *RelayTestSuite) TestRelayCreate() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		inboxURL  = "https://relay.example.net/inbox"
+	)
+
+	apiRelay, errWithCode := suite.adminProcessor.RelayCreate(ctx, adminAcct, inboxURL)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(inboxURL, apiRelay.InboxURL)
+	suite.Equal("pending", apiRelay.State)
+	suite.Empty(apiRelay.ActorURL)
+
+	// A Follow of Public should have
+	// been sent from the instance actor.
+	follow := suite.sentTo(inboxURL)
+	suite.Equal("Follow", follow["type"])
+	suite.Equal("http://localhost:8080/users/localhost:8080", follow["actor"])
+	suite.Equal("https://www.w3.org/ns/activitystreams#Public", follow["object"])
+
+	relay, err := suite.state.DB.GetRelayByID(ctx, apiRelay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(relay.FollowURI, follow["id"])
+}
+
+func (suite *RelayTestSuite) TestRelayCreateConflict() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		relay     = testrig.NewTestRelays()["relay.example.org"]
+	)
+
+	_, errWithCode := suite.adminProcessor.RelayCreate(ctx, adminAcct, relay.InboxURI)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+}
+
+func (suite *RelayTestSuite) TestRelayCreateInvalid() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	for _, inboxURL := range []string{
+		"",
+		"not a url",
+		"ftp://relay.example.net/inbox",
+		"http://localhost:8080/inbox",
+	} {
+		_, errWithCode := suite.adminProcessor.RelayCreate(ctx, adminAcct, inboxURL)
+		suite.Equal(http.StatusBadRequest, errWithCode.Code(), inboxURL)
+	}
+}
+
+func (suite *RelayTestSuite) TestRelayDelete() {
+	var (
+		ctx   = context.Background()
+		relay = testrig.NewTestRelays()["relay.example.org"]
+	)
+
+	apiRelay, errWithCode := suite.adminProcessor.RelayDelete(ctx, relay.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(relay.ID, apiRelay.ID)
+
+	// Relay should be gone.
+	_, err := suite.state.DB.GetRelayByID(ctx, relay.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// An Undo of the Follow should
+	// have been sent to the relay.
+	undo := suite.sentTo(relay.InboxURI)
+	suite.Equal("Undo", undo["type"])
+
+	follow, ok := undo["object"].(map[string]any)
+	if !ok {
+		suite.FailNow("undo object was not a follow")
+	}
+	suite.Equal("Follow", follow["type"])
+	suite.Equal(relay.FollowURI, follow["id"])
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, &RelayTestSuite{})
+}
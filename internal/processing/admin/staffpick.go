@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// StaffPicksGet returns all staff picks, newest first.
+func (p *Processor) StaffPicksGet(ctx context.Context) ([]*apimodel.AdminStaffPick, gtserror.WithCode) {
+	staffPicks, err := p.state.DB.GetStaffPicks(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting staff picks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiStaffPicks := make([]*apimodel.AdminStaffPick, 0, len(staffPicks))
+	for _, staffPick := range staffPicks {
+		apiStaffPick, err := p.converter.StaffPickToAdminAPIStaffPick(ctx, staffPick)
+		if err != nil {
+			err := gtserror.Newf("error converting staff pick %s: %w", staffPick.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiStaffPicks = append(apiStaffPicks, apiStaffPick)
+	}
+
+	return apiStaffPicks, nil
+}
+
+// StaffPickCreate picks the account with the given ID,
+// so that it will be suggested to users as worth following.
+func (p *Processor) StaffPickCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	accountID string,
+) (*apimodel.AdminStaffPick, gtserror.WithCode) {
+	if accountID == "" {
+		const text = "account_id must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if account == nil {
+		err := gtserror.Newf("account %s not found", accountID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if account.IsSuspended() || account.IsInstance() {
+		const text = "suspended and instance accounts cannot be picked"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Ensure we haven't already picked this account.
+	existing, err := p.state.DB.GetStaffPickByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking for existing staff pick: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		err := fmt.Errorf("account %s is already a staff pick", account.ID)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	staffPick := &gtsmodel.StaffPick{
+		ID:                 id.NewULID(),
+		AccountID:          account.ID,
+		Account:            account,
+		CreatedByAccountID: adminAcct.ID,
+	}
+
+	if err := p.state.DB.PutStaffPick(ctx, staffPick); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err := fmt.Errorf("account %s is already a staff pick", account.ID)
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+
+		err := gtserror.Newf("db error putting staff pick: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiStaffPick, err := p.converter.StaffPickToAdminAPIStaffPick(ctx, staffPick)
+	if err != nil {
+		err := gtserror.Newf("error converting staff pick: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiStaffPick, nil
+}
+
+// StaffPickDelete removes the staff pick with the given ID,
+// so that the account will no longer be suggested because
+// admins picked it. It may still be suggested for other reasons.
+func (p *Processor) StaffPickDelete(ctx context.Context, id string) (*apimodel.AdminStaffPick, gtserror.WithCode) {
+	staffPick, err := p.state.DB.GetStaffPickByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting staff pick %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if staffPick == nil {
+		err := gtserror.Newf("staff pick %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	apiStaffPick, err := p.converter.StaffPickToAdminAPIStaffPick(ctx, staffPick)
+	if err != nil {
+		err := gtserror.Newf("error converting staff pick: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteStaffPickByID(ctx, staffPick.ID); err != nil {
+		err := gtserror.Newf("db error deleting staff pick: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiStaffPick, nil
+}
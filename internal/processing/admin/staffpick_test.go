@@ -0,0 +1,96 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StaffPickTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *StaffPickTestSuite) TestStaffPickCreateDelete() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		target    = suite.testAccounts["local_account_1"]
+	)
+
+	apiStaffPick, errWithCode := suite.adminProcessor.StaffPickCreate(ctx, adminAcct, target.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(target.ID, apiStaffPick.Account.ID)
+
+	// Picking the same account
+	// again should conflict.
+	_, errWithCode = suite.adminProcessor.StaffPickCreate(ctx, adminAcct, target.ID)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	apiStaffPicks, errWithCode := suite.adminProcessor.StaffPicksGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(apiStaffPicks, 1)
+	suite.Equal(apiStaffPick.ID, apiStaffPicks[0].ID)
+
+	if _, errWithCode := suite.adminProcessor.StaffPickDelete(ctx, apiStaffPick.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	apiStaffPicks, errWithCode = suite.adminProcessor.StaffPicksGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiStaffPicks)
+
+	// Deleting it again should 404.
+	_, errWithCode = suite.adminProcessor.StaffPickDelete(ctx, apiStaffPick.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *StaffPickTestSuite) TestStaffPickCreateInvalid() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	for _, test := range []struct {
+		accountID string
+		code      int
+	}{
+		{"", http.StatusBadRequest},
+		{"01HZZZZZZZZZZZZZZZZZZZZZZZ", http.StatusNotFound},
+		{suite.testAccounts["instance_account"].ID, http.StatusUnprocessableEntity},
+	} {
+		_, errWithCode := suite.adminProcessor.StaffPickCreate(ctx, adminAcct, test.accountID)
+		if errWithCode == nil {
+			suite.FailNow("expected error picking " + test.accountID)
+		}
+		suite.Equal(test.code, errWithCode.Code())
+	}
+}
+
+func TestStaffPickTestSuite(t *testing.T) {
+	suite.Run(t, new(StaffPickTestSuite))
+}
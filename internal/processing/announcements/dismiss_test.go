@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/announcements"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DismissTestSuite struct {
+	AnnouncementsTestSuite
+}
+
+func (suite *DismissTestSuite) TestDismiss() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx    = context.Background()
+		acct   = suite.testAccounts["local_account_2"]
+		active = suite.testAnnouncements["active"]
+		draft  = suite.testAnnouncements["draft"]
+		p      = announcements.New(testStructs.State, testStructs.TypeConverter)
+	)
+
+	if errWithCode := p.Dismiss(ctx, acct, active.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Dismissing again should be fine.
+	if errWithCode := p.Dismiss(ctx, acct, active.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Dismissed announcement should now be left out...
+	apiAnnouncements, errWithCode := p.Get(ctx, acct, false)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiAnnouncements)
+
+	// ...unless we ask for dismissed ones too.
+	apiAnnouncements, errWithCode = p.Get(ctx, acct, true)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if !suite.Len(apiAnnouncements, 1) {
+		suite.FailNow("")
+	}
+	suite.True(apiAnnouncements[0].Read)
+
+	// Unpublished announcements can't be dismissed.
+	errWithCode = p.Dismiss(ctx, acct, draft.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestDismissTestSuite(t *testing.T) {
+	suite.Run(t, &DismissTestSuite{})
+}
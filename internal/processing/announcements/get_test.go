@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/announcements"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type GetTestSuite struct {
+	AnnouncementsTestSuite
+}
+
+func (suite *GetTestSuite) TestGet() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx    = context.Background()
+		acct   = suite.testAccounts["local_account_1"]
+		active = suite.testAnnouncements["active"]
+		p      = announcements.New(testStructs.State, testStructs.TypeConverter)
+	)
+
+	// Only the published announcement
+	// should be returned, not the draft.
+	apiAnnouncements, errWithCode := p.Get(ctx, acct, false)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if !suite.Len(apiAnnouncements, 1) {
+		suite.FailNow("")
+	}
+
+	apiAnnouncement := apiAnnouncements[0]
+	suite.Equal(active.ID, apiAnnouncement.ID)
+	suite.Equal(active.Content, apiAnnouncement.Content)
+	suite.True(apiAnnouncement.Published)
+	suite.False(apiAnnouncement.Read)
+	suite.Empty(apiAnnouncement.Text)
+
+	// local_account_1 already reacted with a thumbs up.
+	if !suite.Len(apiAnnouncement.Reactions, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal("👍", apiAnnouncement.Reactions[0].Name)
+	suite.Equal(1, apiAnnouncement.Reactions[0].Count)
+	suite.True(apiAnnouncement.Reactions[0].Me)
+
+	// Without a requester (eg., for the web
+	// view), the reaction shouldn't be "ours".
+	apiAnnouncements, errWithCode = p.Get(ctx, nil, true)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(apiAnnouncements, 1)
+	suite.False(apiAnnouncements[0].Reactions[0].Me)
+}
+
+func TestGetTestSuite(t *testing.T) {
+	suite.Run(t, &GetTestSuite{})
+}
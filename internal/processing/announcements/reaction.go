@@ -0,0 +1,132 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+)
+
+// maxUnicodeReactionRunes is the maximum number of runes
+// a unicode emoji reaction may consist of. Emoji using
+// zero width joiners and modifiers can be fairly long.
+const maxUnicodeReactionRunes = 16
+
+// ReactionAdd adds a reaction with the given name by the requester to
+// the announcement with the given ID. Name should be either a unicode
+// emoji, or the shortcode of an enabled custom emoji on this instance.
+func (p *Processor) ReactionAdd(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+	name string,
+) gtserror.WithCode {
+	announcement, errWithCode := p.getActive(ctx, announcementID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	reaction := &gtsmodel.AnnouncementReaction{
+		ID:             id.NewULID(),
+		AnnouncementID: announcement.ID,
+		AccountID:      requester.ID,
+		Name:           name,
+	}
+
+	if regexes.EmojiValidator.MatchString(name) {
+		// Name looks like a custom emoji shortcode,
+		// ensure we have a usable local emoji for it.
+		emoji, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, name, "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting emoji %s: %w", name, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if emoji == nil || *emoji.Disabled {
+			err := fmt.Errorf("custom emoji %s not found", name)
+			return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+
+		reaction.EmojiID = emoji.ID
+	} else if !isUnicodeEmoji(name) {
+		err := fmt.Errorf("reaction %s is neither a unicode emoji nor a custom emoji shortcode", name)
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if err := p.state.DB.PutAnnouncementReaction(ctx, reaction); err != nil &&
+		!errors.Is(err, db.ErrAlreadyExists) {
+		err := gtserror.Newf("db error putting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// ReactionRemove removes the reaction with the given name by
+// the requester from the announcement with the given ID.
+func (p *Processor) ReactionRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+	name string,
+) gtserror.WithCode {
+	announcement, errWithCode := p.getActive(ctx, announcementID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteAnnouncementReaction(
+		ctx,
+		announcement.ID,
+		requester.ID,
+		name,
+	); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error deleting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// isUnicodeEmoji performs a loose check of whether
+// the given string could plausibly be a unicode emoji,
+// ie., it's short, contains no whitespace and contains
+// at least one non-ASCII character.
+func isUnicodeEmoji(name string) bool {
+	if name == "" || utf8.RuneCountInString(name) > maxUnicodeReactionRunes {
+		return false
+	}
+
+	if strings.ContainsFunc(name, unicode.IsSpace) {
+		return false
+	}
+
+	return strings.ContainsFunc(name, func(r rune) bool {
+		return r > unicode.MaxASCII
+	})
+}
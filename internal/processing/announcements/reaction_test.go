@@ -0,0 +1,93 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/announcements"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ReactionTestSuite struct {
+	AnnouncementsTestSuite
+}
+
+func (suite *ReactionTestSuite) TestReactionAddRemove() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx    = context.Background()
+		acct   = suite.testAccounts["local_account_2"]
+		active = suite.testAnnouncements["active"]
+		p      = announcements.New(testStructs.State, testStructs.TypeConverter)
+	)
+
+	// React with a unicode emoji someone
+	// else already used, and a custom emoji.
+	for _, name := range []string{"👍", "rainbow"} {
+		if errWithCode := p.ReactionAdd(ctx, acct, active.ID, name); errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+	}
+
+	// Plain text and unknown custom emojis aren't allowed.
+	for _, name := range []string{"hello world", "not_an_emoji"} {
+		errWithCode := p.ReactionAdd(ctx, acct, active.ID, name)
+		suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	}
+
+	apiAnnouncements, errWithCode := p.Get(ctx, acct, false)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	reactions := apiAnnouncements[0].Reactions
+	if !suite.Len(reactions, 2) {
+		suite.FailNow("")
+	}
+	suite.Equal("👍", reactions[0].Name)
+	suite.Equal(2, reactions[0].Count)
+	suite.True(reactions[0].Me)
+	suite.Equal("rainbow", reactions[1].Name)
+	suite.Equal(1, reactions[1].Count)
+	suite.True(reactions[1].Me)
+	suite.NotEmpty(reactions[1].URL)
+
+	// Remove our thumbs up.
+	if errWithCode := p.ReactionRemove(ctx, acct, active.ID, "👍"); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	apiAnnouncements, errWithCode = p.Get(ctx, acct, false)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	reactions = apiAnnouncements[0].Reactions
+	suite.Equal(1, reactions[0].Count)
+	suite.False(reactions[0].Me)
+}
+
+func TestReactionTestSuite(t *testing.T) {
+	suite.Run(t, &ReactionTestSuite{})
+}
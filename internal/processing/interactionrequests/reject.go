@@ -112,6 +112,32 @@ func (p *Processor) Reject(
 			Target:         req.InteractingAccount,
 		})
 
+	case gtsmodel.InteractionQuote:
+		// Mark the quote itself as rejected. Unlike a
+		// rejected reply or boost, the quoting status is
+		// kept, it just won't show the quoted status.
+		if req.Quote != nil {
+			req.Quote.QuoteState = gtsmodel.QuoteStateRejected
+			if err := p.state.DB.UpdateStatus(
+				ctx,
+				req.Quote,
+				"quote_state",
+			); err != nil {
+				err := gtserror.Newf("db error updating status quote: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+		}
+
+		// Send the rejected request off through the
+		// client API processor to handle side effects.
+		p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+			APObjectType:   ap.ObjectQuote,
+			APActivityType: ap.ActivityReject,
+			GTSModel:       req,
+			Origin:         req.TargetAccount,
+			Target:         req.InteractingAccount,
+		})
+
 	default:
 		err := gtserror.Newf("unknown interaction type for interaction request %s", reqID)
 		return nil, gtserror.NewErrorInternalError(err)
@@ -45,7 +45,7 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 	}
 
 	// Parse focus details from API form input.
-	focusX, focusY, err := parseFocus(form.Focus)
+	focusX, focusY, err := ParseFocus(form.Focus)
 	if err != nil {
 		text := fmt.Sprintf("could not parse focus value %s: %s", form.Focus, err)
 		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
@@ -52,7 +52,7 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, media
 	}
 
 	if form.Focus != nil {
-		focusx, focusy, err := parseFocus(*form.Focus)
+		focusx, focusy, err := ParseFocus(*form.Focus)
 		if err != nil {
 			return nil, gtserror.NewErrorBadRequest(err)
 		}
@@ -23,7 +23,10 @@
 	"strings"
 )
 
-func parseFocus(focus string) (focusx, focusy float32, err error) {
+// ParseFocus parses the given "x,y" focus string into
+// its component floats, each of which must be within
+// the range -1 to 1. Empty input returns zero values.
+func ParseFocus(focus string) (focusx, focusy float32, err error) {
 	if focus == "" {
 		return
 	}
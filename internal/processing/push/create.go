@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+)
+
+// CreateOrReplace creates a Web Push subscription for the
+// given access token, replacing any existing subscription.
+func (p *Processor) CreateOrReplace(
+	ctx context.Context,
+	accountID string,
+	accessToken string,
+	request *apimodel.WebPushSubscriptionCreateRequest,
+) (*apimodel.WebPushSubscription, gtserror.WithCode) {
+	tokenID, errWithCode := p.getTokenID(ctx, accessToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	endpoint, err := url.Parse(request.Subscription.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		const text = "subscription endpoint must be a valid https URL"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	keys := request.Subscription.Keys
+	if err := webpush.ValidateKeys(keys.P256dh, keys.Auth); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, "invalid subscription keys: "+err.Error())
+	}
+
+	subscription := &gtsmodel.WebPushSubscription{
+		ID:        id.NewULID(),
+		AccountID: accountID,
+		TokenID:   tokenID,
+		Endpoint:  endpoint.String(),
+		Auth:      keys.Auth,
+		P256dh:    keys.P256dh,
+		Policy:    gtsmodel.WebPushNotificationPolicyAll,
+	}
+
+	if _, errWithCode := applyData(subscription, request.Data); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// A token may only have one subscription,
+	// so clear out the old one (if any) first.
+	if err := p.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, tokenID); err != nil {
+		err := gtserror.Newf("db error deleting web push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.PutWebPushSubscription(ctx, subscription); err != nil {
+		err := gtserror.Newf("db error putting web push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiSubscription(ctx, subscription)
+}
@@ -0,0 +1,109 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// getTokenID returns the ID of the token
+// with the given access token value.
+func (p *Processor) getTokenID(ctx context.Context, accessToken string) (string, gtserror.WithCode) {
+	token, err := p.state.DB.GetTokenByAccess(ctx, accessToken)
+	if err != nil {
+		err := gtserror.Newf("db error getting token: %w", err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	return token.ID, nil
+}
+
+// getSubscription returns the subscription created with
+// the token with given access token value, or 404 if none.
+func (p *Processor) getSubscription(ctx context.Context, accessToken string) (*gtsmodel.WebPushSubscription, gtserror.WithCode) {
+	tokenID, errWithCode := p.getTokenID(ctx, accessToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	subscription, err := p.state.DB.GetWebPushSubscriptionByTokenID(ctx, tokenID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting web push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if subscription == nil {
+		const text = "no web push subscription exists for this access token"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return subscription, nil
+}
+
+// applyData sets the alerts and policy from given request data on
+// the subscription, returning updated column names, or an error
+// if the data was invalid. Nil data or fields are left unchanged.
+func applyData(subscription *gtsmodel.WebPushSubscription, data *apimodel.WebPushSubscriptionRequestData) ([]string, gtserror.WithCode) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var columns []string
+
+	if alerts := data.Alerts; alerts != nil {
+		flags := &subscription.NotificationFlags
+		flags.Set(gtsmodel.NotificationFollow, alerts.Follow)
+		flags.Set(gtsmodel.NotificationFollowRequest, alerts.FollowRequest)
+		flags.Set(gtsmodel.NotificationFave, alerts.Favourite)
+		flags.Set(gtsmodel.NotificationMention, alerts.Mention)
+		flags.Set(gtsmodel.NotificationReblog, alerts.Reblog)
+		flags.Set(gtsmodel.NotificationPoll, alerts.Poll)
+		flags.Set(gtsmodel.NotificationStatus, alerts.Status)
+		flags.Set(gtsmodel.NotificationSignup, alerts.AdminSignup)
+		flags.Set(gtsmodel.NotificationPendingFave, alerts.PendingFavourite)
+		flags.Set(gtsmodel.NotificationPendingReply, alerts.PendingReply)
+		flags.Set(gtsmodel.NotificationPendingReblog, alerts.PendingReblog)
+		flags.Set(gtsmodel.NotificationQuote, alerts.Quote)
+		flags.Set(gtsmodel.NotificationPendingQuote, alerts.PendingQuote)
+		columns = append(columns, "notification_flags")
+	}
+
+	if data.Policy != nil {
+		policy := gtsmodel.WebPushNotificationPolicy(*data.Policy)
+		switch policy {
+		case gtsmodel.WebPushNotificationPolicyAll,
+			gtsmodel.WebPushNotificationPolicyFollowed,
+			gtsmodel.WebPushNotificationPolicyFollower,
+			gtsmodel.WebPushNotificationPolicyNone:
+			subscription.Policy = policy
+			columns = append(columns, "policy")
+		default:
+			text := fmt.Sprintf("policy %q not recognized; must be one of all, followed, follower, none", *data.Policy)
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+	}
+
+	return columns, nil
+}
@@ -113,6 +113,15 @@ func (p *Processor) Create(
 		return nil, errWithCode
 	}
 
+	// Check + attach quoted status.
+	if errWithCode := p.processQuote(ctx,
+		requester,
+		status,
+		form.QuoteID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	if errWithCode := p.processThreadID(ctx, status); errWithCode != nil {
 		return nil, errWithCode
 	}
@@ -271,6 +280,79 @@ func (p *Processor) processInReplyTo(ctx context.Context, requester *gtsmodel.Ac
 	return nil
 }
 
+func (p *Processor) processQuote(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status, quoteID string) gtserror.WithCode {
+	if quoteID == "" {
+		// Not a quote.
+		// Nothing to do.
+		return nil
+	}
+
+	// Fetch target quoted status (checking visibility).
+	quote, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		quoteID,
+		nil,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// If this is a boost, unwrap it to get source status.
+	quote, errWithCode = p.c.UnwrapIfBoost(ctx,
+		requester,
+		quote,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Ensure valid quote target for requester.
+	policyResult, err := p.intFilter.StatusQuoteable(ctx,
+		requester,
+		quote,
+	)
+	if err != nil {
+		err := gtserror.Newf("error seeing if status %s is quoteable: %w", status.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if policyResult.Forbidden() {
+		const errText = "you do not have permission to quote this status"
+		err := gtserror.New(errText)
+		return gtserror.NewErrorForbidden(err, errText)
+	}
+
+	// Derive state of the quote. Unlike with
+	// replies, the quoting status itself is
+	// never pending approval, only the quote.
+	switch {
+	case policyResult.WithApproval():
+		// We're allowed to do
+		// this pending approval.
+		status.QuoteState = gtsmodel.QuoteStatePending
+
+	case policyResult.MatchedOnCollection() && !*quote.Local:
+		// We're permitted to do this, but since
+		// we matched due to presence in a remote
+		// followers or following collection, we
+		// need to wait for an Accept from remote.
+		status.QuoteState = gtsmodel.QuoteStatePending
+
+	default:
+		// We're permitted to do this, either
+		// outright or based on a collection
+		// we can verify locally ourselves.
+		status.QuoteState = gtsmodel.QuoteStateAccepted
+	}
+
+	// Set status fields from quote.
+	status.QuoteID = quote.ID
+	status.Quote = quote
+	status.QuoteURI = quote.URI
+
+	return nil
+}
+
 func (p *Processor) processThreadID(ctx context.Context, status *gtsmodel.Status) gtserror.WithCode {
 	// Status takes the thread ID of
 	// whatever it replies to, if set.
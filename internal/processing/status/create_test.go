@@ -19,6 +19,7 @@
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -238,6 +239,71 @@ func (suite *StatusCreateTestSuite) TestProcessReplyToUnthreadedRemoteStatus() {
 	suite.NotEmpty(dbStatus.ThreadID)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessQuote() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quoted := suite.testStatuses["admin_account_status_1"]
+
+	statusCreateForm := &apimodel.StatusCreateRequest{
+		Status:      "look at this",
+		QuoteID:     quoted.ID,
+		Visibility:  apimodel.VisibilityPublic,
+		LocalOnly:   util.Ptr(false),
+		Language:    "en",
+		ContentType: apimodel.StatusContentTypePlain,
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	// Quote of a public status with
+	// default policy should be accepted.
+	if suite.NotNil(apiStatus.Quote) {
+		suite.Equal("accepted", apiStatus.Quote.State)
+		if suite.NotNil(apiStatus.Quote.QuotedStatus) {
+			suite.Equal(quoted.ID, apiStatus.Quote.QuotedStatus.ID)
+		}
+	}
+
+	dbStatus, dbErr := suite.state.DB.GetStatusByID(ctx, apiStatus.ID)
+	if dbErr != nil {
+		suite.FailNow(dbErr.Error())
+	}
+	suite.Equal(quoted.ID, dbStatus.QuoteID)
+	suite.Equal(quoted.URI, dbStatus.QuoteURI)
+	suite.Equal(gtsmodel.QuoteStateAccepted, dbStatus.QuoteState)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessQuoteForbidden() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Followers-only status; by
+	// default only the author can
+	// quote followers-only statuses.
+	quoted := suite.testStatuses["local_account_2_status_7"]
+
+	statusCreateForm := &apimodel.StatusCreateRequest{
+		Status:      "look at this",
+		QuoteID:     quoted.ID,
+		Visibility:  apimodel.VisibilityPublic,
+		LocalOnly:   util.Ptr(false),
+		Language:    "en",
+		ContentType: apimodel.StatusContentTypePlain,
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.Nil(apiStatus)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusForbidden, errWithCode.Code())
+	}
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
@@ -0,0 +1,468 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Edit processes the given form to edit an existing status owned by requester,
+// storing the previous revision of the status as a StatusEdit, and returning the
+// api model representation of the updated status if it's OK.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) Edit(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	statusID string,
+	form *apimodel.StatusEditRequest,
+) (
+	*apimodel.Status,
+	gtserror.WithCode,
+) {
+	// Ensure account populated; we'll need settings.
+	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
+		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
+	}
+
+	// Fetch the status to be edited from the database.
+	status, err := p.state.DB.GetStatusByID(ctx, statusID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting status %s: %w", statusID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Only the author may edit a status,
+	// and boosts themselves can't be edited.
+	if status == nil ||
+		status.AccountID != requester.ID ||
+		status.BoostOfID != "" {
+		const text = "status not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	// Get current time.
+	now := time.Now()
+
+	// Snapshot the current state of the status
+	// as a historical revision, before editing.
+	edit := typeutils.StatusToStatusEdit(status)
+
+	// Check + gather the media attachments for new revision.
+	attachments, errWithCode := p.processEditMedia(ctx, form, requester.ID, status)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Prepare a poll for the new revision, if any.
+	var poll *gtsmodel.Poll
+	if form.Poll != nil {
+		secs := time.Duration(form.Poll.ExpiresIn)
+		poll = &gtsmodel.Poll{
+			ID:         id.NewULID(),
+			Multiple:   &form.Poll.Multiple,
+			HideCounts: &form.Poll.HideTotals,
+			Options:    form.Poll.Options,
+			StatusID:   status.ID,
+			Status:     status,
+			ExpiresAt:  now.Add(secs * time.Second),
+		}
+	}
+
+	// Format the new status content into a scratch status model.
+	//
+	// Note this is passed WITHOUT a status ID so that newly
+	// parsed mentions are not yet stored in the database,
+	// we need to compare them to the existing ones first.
+	revision := &gtsmodel.Status{
+		AccountID:     requester.ID,
+		Account:       requester,
+		AttachmentIDs: util.Gather(nil, attachments, func(a *gtsmodel.MediaAttachment) string { return a.ID }),
+		Poll:          poll,
+		Sensitive:     &form.Sensitive,
+	}
+
+	if err := p.processContent(ctx, p.parseMention, &apimodel.StatusCreateRequest{
+		Status:      form.Status,
+		SpoilerText: form.SpoilerText,
+		ContentType: form.ContentType,
+	}, revision); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Update the mentions on the status to match new revision.
+	mentions, errWithCode := p.processEditMentions(ctx, status, revision.Mentions)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Update the poll on the status to match new revision.
+	if errWithCode := p.processEditPoll(ctx, status, edit, poll); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Keep the previous attachments,
+	// to unlink any that were removed.
+	prevAttachments := status.Attachments
+
+	// Set the new status fields.
+	status.Content = revision.Content
+	status.ContentWarning = revision.ContentWarning
+	status.Text = form.Status
+	status.Sensitive = revision.Sensitive
+	status.Attachments = attachments
+	status.AttachmentIDs = revision.AttachmentIDs
+	status.Mentions = mentions
+	status.MentionIDs = util.Gather(nil, mentions, func(m *gtsmodel.Mention) string { return m.ID })
+	status.Tags = revision.Tags
+	status.TagIDs = revision.TagIDs
+	status.Emojis = revision.Emojis
+	status.EmojiIDs = revision.EmojiIDs
+	status.EditedAt = now
+
+	if form.Language != "" {
+		// Only update language if set,
+		// else leave the previous one.
+		status.Language = form.Language
+	}
+
+	// Append the previous revision to the status' edits.
+	status.EditIDs = append(status.EditIDs, edit.ID)
+	status.Edits = append(status.Edits, edit)
+
+	// Update the status in the database.
+	if err := p.state.DB.UpdateStatus(ctx, status,
+		"edited_at",
+		"content",
+		"content_warning",
+		"text",
+		"language",
+		"sensitive",
+		"attachments",
+		"mentions",
+		"tags",
+		"emojis",
+		"edits",
+		"poll_id",
+	); err != nil {
+		err := gtserror.Newf("error updating status in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Now the status is updated, insert the
+	// previous revision into the database.
+	if err := p.state.DB.PutStatusEdit(ctx, edit); err != nil {
+		log.Errorf(ctx, "error inserting status edit in db: %v", err)
+
+		// Don't leave the status pointing
+		// at a revision that doesn't exist.
+		status.EditIDs = status.EditIDs[:len(status.EditIDs)-1]
+		status.Edits = status.Edits[:len(status.Edits)-1]
+		if err := p.state.DB.UpdateStatus(ctx, status, "edits"); err != nil {
+			log.Errorf(ctx, "error updating status in db: %v", err)
+		}
+	}
+
+	// Link media newly attached to the status,
+	// and unlink media removed from the status.
+	if errWithCode := p.processEditMediaLinks(ctx, status, prevAttachments); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// send it back to the client API worker for async side-effects.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       status,
+		Origin:         requester,
+	})
+
+	if poll != nil && status.PollID == poll.ID {
+		// Now that the status is updated, and side effects
+		// queued, attempt to schedule an expiry handler
+		// for the newly created status poll.
+		if err := p.polls.ScheduleExpiry(ctx, poll); err != nil {
+			log.Errorf(ctx, "error scheduling poll expiry: %v", err)
+		}
+	}
+
+	return p.c.GetAPIStatus(ctx, requester, status)
+}
+
+// processEditMedia checks the media IDs and attributes in the given
+// edit form, updating the attributes of any attachments as necessary,
+// and returning the list of media attachments for the new revision.
+func (p *Processor) processEditMedia(
+	ctx context.Context,
+	form *apimodel.StatusEditRequest,
+	thisAccountID string,
+	status *gtsmodel.Status,
+) (
+	[]*gtsmodel.MediaAttachment,
+	gtserror.WithCode,
+) {
+	// Get minimum allowed char descriptions.
+	minChars := config.GetMediaDescriptionMinChars()
+
+	attachments := make([]*gtsmodel.MediaAttachment, 0, len(form.MediaIDs))
+
+	for _, mediaID := range form.MediaIDs {
+		attachment, err := p.state.DB.GetAttachmentByID(ctx, mediaID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("error fetching media from db: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if attachment == nil {
+			text := fmt.Sprintf("media %s not found", mediaID)
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		if attachment.AccountID != thisAccountID {
+			text := fmt.Sprintf("media %s does not belong to account", mediaID)
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		// Media may have been attached to this status
+		// before (in current or previous revisions),
+		// but must not be attached to any other status.
+		if (attachment.StatusID != "" && attachment.StatusID != status.ID) ||
+			attachment.ScheduledStatusID != "" {
+			text := fmt.Sprintf("media %s already attached to status", mediaID)
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	// Columns to update
+	// on each attachment.
+	columns := make(map[string][]string)
+
+	for _, attrs := range form.MediaAttributes {
+		i := slices.IndexFunc(attachments, func(a *gtsmodel.MediaAttachment) bool {
+			return a.ID == attrs.ID
+		})
+
+		if i == -1 {
+			text := fmt.Sprintf("media %s not attached to status", attrs.ID)
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		// Take a copy of attachment, so we don't
+		// modify the cached one before the update.
+		attachment := new(gtsmodel.MediaAttachment)
+		*attachment = *attachments[i]
+		attachments[i] = attachment
+
+		if attrs.Description != "" {
+			attachment.Description = text.SanitizeToPlaintext(attrs.Description)
+			columns[attachment.ID] = append(columns[attachment.ID], "description")
+		}
+
+		if attrs.Focus != "" {
+			focusx, focusy, err := media.ParseFocus(attrs.Focus)
+			if err != nil {
+				text := fmt.Sprintf("could not parse focus value %s: %s", attrs.Focus, err)
+				return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+			}
+			attachment.FileMeta.Focus.X = focusx
+			attachment.FileMeta.Focus.Y = focusy
+			columns[attachment.ID] = append(columns[attachment.ID], "focus_x", "focus_y")
+		}
+	}
+
+	for _, attachment := range attachments {
+		if length := len([]rune(attachment.Description)); length < minChars {
+			text := fmt.Sprintf("media %s description too short, at least %d required", attachment.ID, minChars)
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+	}
+
+	// Everything is valid, now update changed attachment attributes.
+	for _, attachment := range attachments {
+		cols, ok := columns[attachment.ID]
+		if !ok {
+			continue
+		}
+
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, cols...); err != nil {
+			err := gtserror.Newf("error updating media %s in db: %w", attachment.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return attachments, nil
+}
+
+// processEditMediaLinks sets the status ID on media attachments
+// of the edited status, and clears it on the given previous media
+// attachments that are no longer attached, so that these can be
+// attached to other statuses or pruned as unused.
+func (p *Processor) processEditMediaLinks(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	prevAttachments []*gtsmodel.MediaAttachment,
+) gtserror.WithCode {
+	for _, attachment := range status.Attachments {
+		if attachment.StatusID == status.ID {
+			continue
+		}
+
+		attachment.StatusID = status.ID
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "status_id"); err != nil {
+			err := gtserror.Newf("error updating media %s in db: %w", attachment.ID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	for _, attachment := range prevAttachments {
+		if slices.Contains(status.AttachmentIDs, attachment.ID) {
+			continue
+		}
+
+		attachment.StatusID = ""
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "status_id"); err != nil {
+			err := gtserror.Newf("error updating media %s in db: %w", attachment.ID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// processEditMentions compares the mentions parsed from the new
+// revision of status content to the existing status mentions,
+// inserting any new mentions in the database and deleting those
+// that are no longer present. Returns the final list of mentions.
+func (p *Processor) processEditMentions(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	parsed []*gtsmodel.Mention,
+) (
+	[]*gtsmodel.Mention,
+	gtserror.WithCode,
+) {
+	mentions := make([]*gtsmodel.Mention, 0, len(parsed))
+
+	for _, mention := range parsed {
+		// Check for existing mention of this target account,
+		// reusing it so we don't duplicate any notifications.
+		i := slices.IndexFunc(status.Mentions, func(m *gtsmodel.Mention) bool {
+			return m.TargetAccountID == mention.TargetAccountID
+		})
+
+		if i != -1 {
+			mentions = append(mentions, status.Mentions[i])
+			continue
+		}
+
+		// This is a new mention,
+		// insert it in the database.
+		mention.StatusID = status.ID
+		if err := p.state.DB.PutMention(ctx, mention); err != nil {
+			err := gtserror.Newf("error inserting mention in db: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		mentions = append(mentions, mention)
+	}
+
+	for _, mention := range status.Mentions {
+		if slices.Contains(mentions, mention) {
+			continue
+		}
+
+		// This mention was removed from
+		// the status, delete it from db.
+		if err := p.state.DB.DeleteMentionByID(ctx, mention.ID); err != nil {
+			err := gtserror.Newf("error deleting mention from db: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return mentions, nil
+}
+
+// processEditPoll updates the poll attached to status according to
+// the (formatted) poll given from the new revision. If the poll options
+// are unchanged the existing poll and its votes are kept, else the old
+// poll is replaced and its vote counts are recorded on the status edit.
+func (p *Processor) processEditPoll(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	edit *gtsmodel.StatusEdit,
+	poll *gtsmodel.Poll,
+) gtserror.WithCode {
+	if status.Poll != nil {
+		if poll != nil &&
+			*poll.Multiple == *status.Poll.Multiple &&
+			slices.Equal(poll.Options, status.Poll.Options) {
+			// Poll effectively unchanged,
+			// keep the existing poll + votes.
+			return nil
+		}
+
+		// Record the vote counts at this revision
+		// as the poll (and its votes) will be removed.
+		edit.PollVotes = status.Poll.Votes
+
+		// Delete the old poll from the database.
+		if err := p.state.DB.DeletePollByID(ctx, status.PollID); err != nil {
+			err := gtserror.Newf("error deleting poll from db: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		// Cancel any scheduled expiry task for poll.
+		_ = p.state.Workers.Scheduler.Cancel(status.PollID)
+
+		status.PollID = ""
+		status.Poll = nil
+	}
+
+	if poll != nil {
+		// Try to insert the new status poll in the database.
+		if err := p.state.DB.PutPoll(ctx, poll); err != nil {
+			err := gtserror.Newf("error inserting poll in db: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		status.PollID = poll.ID
+		status.Poll = poll
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type StatusEditTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusContent() {
+	ctx := context.Background()
+
+	editingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	apiStatus, errWithCode := suite.status.Edit(ctx, editingAccount, targetStatus.ID, &apimodel.StatusEditRequest{
+		Status:      "hello everyone! (edited)",
+		SpoilerText: "edit",
+		Language:    "en",
+		ContentType: apimodel.StatusContentTypePlain,
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("<p>hello everyone! (edited)</p>", apiStatus.Content)
+	suite.Equal("edit", apiStatus.SpoilerText)
+	suite.NotNil(apiStatus.EditedAt)
+
+	// Status in the db should be updated,
+	// with the previous revision stored.
+	dbStatus, err := suite.db.GetStatusByID(ctx, targetStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("hello everyone! (edited)", dbStatus.Text)
+	suite.False(dbStatus.EditedAt.IsZero())
+	suite.Len(dbStatus.Edits, 1)
+
+	edit := dbStatus.Edits[0]
+	suite.Equal(targetStatus.ID, edit.StatusID)
+	suite.Equal(targetStatus.Content, edit.Content)
+	suite.Equal(targetStatus.Text, edit.Text)
+	suite.Equal(targetStatus.ContentWarning, edit.ContentWarning)
+	suite.True(targetStatus.CreatedAt.Equal(edit.CreatedAt))
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusNotOwned() {
+	ctx := context.Background()
+
+	editingAccount := suite.testAccounts["local_account_2"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	apiStatus, errWithCode := suite.status.Edit(ctx, editingAccount, targetStatus.ID, &apimodel.StatusEditRequest{
+		Status: "this isn't my status!",
+	})
+	suite.Nil(apiStatus)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusRemoveMedia() {
+	ctx := context.Background()
+
+	editingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_4"]
+	keptMediaID := targetStatus.AttachmentIDs[0]
+	removedMediaID := targetStatus.AttachmentIDs[1]
+
+	apiStatus, errWithCode := suite.status.Edit(ctx, editingAccount, targetStatus.ID, &apimodel.StatusEditRequest{
+		Status:   "here's a little gif of trent",
+		MediaIDs: []string{keptMediaID},
+		MediaAttributes: []apimodel.AttachmentAttributesRequest{
+			{ID: keptMediaID, Description: "trent reznor, looking sad"},
+		},
+	})
+	suite.NoError(errWithCode)
+	suite.Len(apiStatus.MediaAttachments, 1)
+	suite.Equal(keptMediaID, apiStatus.MediaAttachments[0].ID)
+	suite.Equal("trent reznor, looking sad", *apiStatus.MediaAttachments[0].Description)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, targetStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{keptMediaID}, dbStatus.AttachmentIDs)
+
+	// Previous revision should still reference
+	// both media, with their old descriptions.
+	edit := dbStatus.Edits[0]
+	suite.Equal([]string{keptMediaID, removedMediaID}, edit.AttachmentIDs)
+	suite.Equal(suite.testAttachments["local_account_1_status_4_attachment_1"].Description, edit.AttachmentDescriptions[0])
+
+	// Removed media should no longer belong to the status.
+	removed, err := suite.db.GetAttachmentByID(ctx, removedMediaID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(removed.StatusID)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusAddMedia() {
+	ctx := context.Background()
+
+	editingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+	addedMediaID := suite.testAttachments["local_account_1_unattached_1"].ID
+
+	apiStatus, errWithCode := suite.status.Edit(ctx, editingAccount, targetStatus.ID, &apimodel.StatusEditRequest{
+		Status:   "hello everyone! now with media",
+		MediaIDs: []string{addedMediaID},
+	})
+	suite.NoError(errWithCode)
+	suite.Len(apiStatus.MediaAttachments, 1)
+
+	// Added media should now belong to the status...
+	added, err := suite.db.GetAttachmentByID(ctx, addedMediaID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(targetStatus.ID, added.StatusID)
+
+	// ...so it can't be attached to another one.
+	_, errWithCode = suite.status.Edit(ctx, editingAccount, suite.testStatuses["local_account_1_status_2"].ID, &apimodel.StatusEditRequest{
+		Status:   "stealing media",
+		MediaIDs: []string{addedMediaID},
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusChangePoll() {
+	ctx := context.Background()
+
+	editingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_6"]
+
+	oldPoll, err := suite.db.GetPollByID(ctx, targetStatus.PollID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiStatus, errWithCode := suite.status.Edit(ctx, editingAccount, targetStatus.ID, &apimodel.StatusEditRequest{
+		Status: "what do you think of sloths, really?",
+		Poll: &apimodel.PollRequest{
+			Options:   []string{"they're great", "they're ok"},
+			ExpiresIn: 3600,
+		},
+	})
+	suite.NoError(errWithCode)
+	suite.NotNil(apiStatus.Poll)
+	suite.NotEqual(oldPoll.ID, apiStatus.Poll.ID)
+	suite.Len(apiStatus.Poll.Options, 2)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, targetStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Previous revision should contain old poll.
+	edit := dbStatus.Edits[0]
+	suite.Equal(oldPoll.Options, edit.PollOptions)
+	suite.Equal(oldPoll.Votes, edit.PollVotes)
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEditTestSuite))
+}
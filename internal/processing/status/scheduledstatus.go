@@ -0,0 +1,620 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// scheduledStatusMinDelay is the minimum amount of
+	// time in the future a status may be scheduled for.
+	scheduledStatusMinDelay = 5 * time.Minute
+
+	// scheduledStatusMaxTotal is the maximum number of pending
+	// scheduled statuses one account may have in total.
+	scheduledStatusMaxTotal = 300
+
+	// scheduledStatusMaxDaily is the maximum number of pending
+	// scheduled statuses one account may have on any one (UTC) day.
+	scheduledStatusMaxDaily = 25
+)
+
+// ScheduledStatusCreate processes the given form to schedule a new
+// status for publication at form.ScheduledAt, returning the api model
+// representation of the scheduled status if it's OK.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) ScheduledStatusCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	application *gtsmodel.Application,
+	form *apimodel.StatusCreateRequest,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledAt, errWithCode := p.validateScheduledAt(ctx, requester, form.ScheduledAt, "")
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Ensure account populated; we'll need settings.
+	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
+		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
+	}
+
+	// Run the form through the same checks as a regular
+	// status would get, using a scratch model to do so.
+	status := &gtsmodel.Status{
+		ID:        id.NewULID(),
+		Account:   requester,
+		AccountID: requester.ID,
+	}
+
+	if errWithCode := p.processInReplyTo(ctx,
+		requester,
+		status,
+		form.InReplyToID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := p.processQuote(ctx,
+		requester,
+		status,
+		form.QuoteID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := p.processMediaIDs(ctx, form, requester.ID, status); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Resolve visibility now, since this
+	// sets it on the form for policy parsing.
+	if err := p.processVisibility(ctx, form, requester.Settings.Privacy, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := processLanguage(form, requester.Settings.Language, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if form.ContentType == "" {
+		// If content type wasn't specified, use the author's preferred content-type.
+		form.ContentType = apimodel.StatusContentType(requester.Settings.StatusContentType)
+	}
+
+	if form.ContentType == "" {
+		// Author has no preference either.
+		form.ContentType = apimodel.StatusContentTypeDefault
+	}
+
+	scheduledStatus := &gtsmodel.ScheduledStatus{
+		ID:               status.ID,
+		AccountID:        requester.ID,
+		Account:          requester,
+		ScheduledAt:      scheduledAt,
+		Text:             form.Status,
+		SpoilerText:      form.SpoilerText,
+		Sensitive:        &form.Sensitive,
+		Visibility:       status.Visibility,
+		LocalOnly:        util.Ptr(util.PtrOrValue(form.LocalOnly, false)),
+		Language:         status.Language,
+		ContentType:      string(form.ContentType),
+		InReplyToID:      status.InReplyToID,
+		QuoteID:          status.QuoteID,
+		MediaIDs:         status.AttachmentIDs,
+		MediaAttachments: status.Attachments,
+		ApplicationID:    application.ID,
+		Application:      application,
+	}
+
+	if form.Poll != nil {
+		scheduledStatus.Poll = &gtsmodel.ScheduledStatusPoll{
+			Options:    form.Poll.Options,
+			ExpiresIn:  form.Poll.ExpiresIn,
+			Multiple:   form.Poll.Multiple,
+			HideTotals: form.Poll.HideTotals,
+		}
+	}
+
+	// Only store a policy if one was explicitly given,
+	// otherwise the account default is used on publication.
+	if form.InteractionPolicy != nil {
+		policy, err := typeutils.APIInteractionPolicyToInteractionPolicy(
+			form.InteractionPolicy,
+			form.Visibility,
+		)
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		scheduledStatus.InteractionPolicy = policy
+	}
+
+	if err := p.state.DB.PutScheduledStatus(ctx, scheduledStatus); err != nil {
+		err := gtserror.Newf("db error inserting scheduled status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Reserve the attachments for this scheduled status,
+	// so they can't be attached elsewhere or cleaned up.
+	for _, attachment := range scheduledStatus.MediaAttachments {
+		attachment.ScheduledStatusID = scheduledStatus.ID
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "scheduled_status_id"); err != nil {
+			err := gtserror.Newf("db error updating attachment %s: %w", attachment.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if err := p.ScheduleStatus(ctx, scheduledStatus); err != nil {
+		log.Errorf(ctx, "error scheduling status: %v", err)
+	}
+
+	return p.apiScheduledStatus(ctx, scheduledStatus)
+}
+
+// ScheduledStatusesGetPage returns a page of
+// pending scheduled statuses owned by requester.
+func (p *Processor) ScheduledStatusesGetPage(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	scheduledStatuses, err := p.state.DB.GetScheduledStatusesForAcct(ctx, requester.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting scheduled statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(scheduledStatuses)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = scheduledStatuses[count-1].ID
+		hi = scheduledStatuses[0].ID
+
+		// Best-guess items length.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, scheduledStatus := range scheduledStatuses {
+		apiScheduledStatus, err := p.converter.ScheduledStatusToAPIScheduledStatus(ctx, scheduledStatus)
+		if err != nil {
+			log.Errorf(ctx, "error converting scheduled status to api scheduled status: %v", err)
+			continue
+		}
+
+		// Append scheduled status to return items.
+		items = append(items, apiScheduledStatus)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/scheduled_statuses",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// ScheduledStatusGet returns one pending scheduled
+// status with the given ID, owned by requester.
+func (p *Processor) ScheduledStatusGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledStatus, errWithCode := p.getOwnScheduledStatus(ctx, requester, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiScheduledStatus(ctx, scheduledStatus)
+}
+
+// ScheduledStatusUpdate changes the publication time of one pending
+// scheduled status with the given ID, owned by requester.
+func (p *Processor) ScheduledStatusUpdate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+	form *apimodel.ScheduledStatusUpdateRequest,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledStatus, errWithCode := p.getOwnScheduledStatus(ctx, requester, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduledAt, errWithCode := p.validateScheduledAt(ctx, requester, form.ScheduledAt, scheduledStatus.ID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduledStatus.ScheduledAt = scheduledAt
+	if err := p.state.DB.UpdateScheduledStatus(ctx, scheduledStatus, "scheduled_at"); err != nil {
+		err := gtserror.Newf("db error updating scheduled status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Replace the existing scheduler
+	// task with one at the new time.
+	p.state.Workers.Scheduler.Cancel(scheduledStatus.ID)
+	if err := p.ScheduleStatus(ctx, scheduledStatus); err != nil {
+		log.Errorf(ctx, "error scheduling status: %v", err)
+	}
+
+	return p.apiScheduledStatus(ctx, scheduledStatus)
+}
+
+// ScheduledStatusDelete cancels and deletes one pending
+// scheduled status with the given ID, owned by requester.
+func (p *Processor) ScheduledStatusDelete(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) gtserror.WithCode {
+	scheduledStatus, errWithCode := p.getOwnScheduledStatus(ctx, requester, id)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	p.state.Workers.Scheduler.Cancel(scheduledStatus.ID)
+
+	if err := p.deleteScheduledStatus(ctx, scheduledStatus); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// ScheduledStatusesScheduleAll adds all pending scheduled statuses
+// in the database to the scheduler. To be called on startup.
+func (p *Processor) ScheduledStatusesScheduleAll(ctx context.Context) error {
+	// Fetch all pending scheduled statuses from the database (barebones models are enough).
+	scheduledStatuses, err := p.state.DB.GetAllScheduledStatuses(gtscontext.SetBarebones(ctx))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting scheduled statuses from db: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, scheduledStatus := range scheduledStatuses {
+		// Schedule each of the statuses and catch any errors.
+		if err := p.ScheduleStatus(ctx, scheduledStatus); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// ScheduleStatus adds the given scheduled status to the scheduler,
+// to be published at its ScheduledAt time. Statuses whose time has
+// already passed (eg., while the instance was down) publish immediately.
+func (p *Processor) ScheduleStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) error {
+	// Add the given scheduled status to the scheduler.
+	ok := p.state.Workers.Scheduler.AddOnce(
+		scheduledStatus.ID,
+		scheduledStatus.ScheduledAt,
+		p.onPublish(scheduledStatus.ID),
+	)
+
+	if !ok {
+		// Failed to add the status to the scheduler, either it was
+		// starting / stopping or there already exists a task for it.
+		return gtserror.Newf("failed adding scheduled status %s to scheduler", scheduledStatus.ID)
+	}
+
+	atStr := scheduledStatus.ScheduledAt.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled status %s for publication at '%s'", scheduledStatus.ID, atStr)
+	return nil
+}
+
+// onPublish returns a callback function to be used by
+// the scheduler when the given scheduled status is due.
+func (p *Processor) onPublish(scheduledStatusID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, _ time.Time) {
+		// Get the latest version of scheduled status from database.
+		scheduledStatus, err := p.state.DB.GetScheduledStatusByID(ctx, scheduledStatusID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "error getting scheduled status %s from db: %v", scheduledStatusID, err)
+			}
+
+			// Either errored or the scheduled
+			// status was deleted in the meantime.
+			return
+		}
+
+		if scheduledStatus.Account == nil {
+			// Cannot continue without account.
+			log.Errorf(ctx, "scheduled status %s account not found", scheduledStatusID)
+			return
+		}
+
+		if scheduledStatus.Account.IsSuspended() {
+			// Account was suspended in the meantime,
+			// drop the scheduled status quietly.
+			if err := p.deleteScheduledStatus(ctx, scheduledStatus); err != nil {
+				log.Errorf(ctx, "error deleting scheduled status %s: %v", scheduledStatusID, err)
+			}
+			return
+		}
+
+		application := scheduledStatus.Application
+		if application == nil {
+			// Application may have since been
+			// deleted; don't let that block us.
+			application = new(gtsmodel.Application)
+		}
+
+		form, err := p.scheduledStatusToForm(ctx, scheduledStatus)
+		if err != nil {
+			log.Errorf(ctx, "error converting scheduled status %s: %v", scheduledStatusID, err)
+			return
+		}
+
+		// Release the scheduled status' media
+		// so it can be attached to the real status.
+		if err := p.setScheduledStatusMedia(ctx, scheduledStatus, ""); err != nil {
+			log.Errorf(ctx, "error releasing scheduled status %s media: %v", scheduledStatusID, err)
+			return
+		}
+
+		if _, errWithCode := p.Create(ctx,
+			scheduledStatus.Account,
+			application,
+			form,
+		); errWithCode != nil {
+			log.Errorf(ctx, "error publishing scheduled status %s: %v", scheduledStatusID, errWithCode)
+
+			// Publishing failed, so keep the scheduled
+			// status (and its media) around rather than
+			// losing the post. It will be retried on the
+			// next startup, or can be edited or deleted.
+			if err := p.setScheduledStatusMedia(ctx, scheduledStatus, scheduledStatus.ID); err != nil {
+				log.Errorf(ctx, "error reserving scheduled status %s media: %v", scheduledStatusID, err)
+			}
+			return
+		}
+
+		// Status was published, the
+		// scheduled status can go now.
+		if err := p.deleteScheduledStatus(ctx, scheduledStatus); err != nil {
+			log.Errorf(ctx, "error deleting scheduled status %s: %v", scheduledStatusID, err)
+		}
+	}
+}
+
+// scheduledStatusToForm rebuilds a status create request
+// from the parameters stored on the given scheduled status.
+func (p *Processor) scheduledStatusToForm(
+	ctx context.Context,
+	scheduledStatus *gtsmodel.ScheduledStatus,
+) (*apimodel.StatusCreateRequest, error) {
+	form := &apimodel.StatusCreateRequest{
+		Status:      scheduledStatus.Text,
+		MediaIDs:    scheduledStatus.MediaIDs,
+		InReplyToID: scheduledStatus.InReplyToID,
+		QuoteID:     scheduledStatus.QuoteID,
+		Sensitive:   util.PtrOrValue(scheduledStatus.Sensitive, false),
+		SpoilerText: scheduledStatus.SpoilerText,
+		Visibility:  p.converter.VisToAPIVis(ctx, scheduledStatus.Visibility),
+		LocalOnly:   util.Ptr(util.PtrOrValue(scheduledStatus.LocalOnly, false)),
+		Language:    scheduledStatus.Language,
+		ContentType: apimodel.StatusContentType(scheduledStatus.ContentType),
+	}
+
+	if scheduledStatus.Visibility == gtsmodel.VisibilityMutualsOnly {
+		// Not distinguished from followers-only
+		// by VisToAPIVis, so set this explicitly.
+		form.Visibility = apimodel.VisibilityMutualsOnly
+	}
+
+	if poll := scheduledStatus.Poll; poll != nil {
+		form.Poll = &apimodel.PollRequest{
+			Options:    poll.Options,
+			ExpiresIn:  poll.ExpiresIn,
+			Multiple:   poll.Multiple,
+			HideTotals: poll.HideTotals,
+		}
+	}
+
+	if policy := scheduledStatus.InteractionPolicy; policy != nil {
+		apiPolicy, err := p.converter.InteractionPolicyToAPIInteractionPolicy(ctx, policy, nil, nil)
+		if err != nil {
+			return nil, gtserror.Newf("error converting interaction policy: %w", err)
+		}
+		form.InteractionPolicy = apiPolicy
+	}
+
+	return form, nil
+}
+
+// validateScheduledAt parses the given scheduled_at value, checking it's far
+// enough in the future, and that scheduling a status at that time wouldn't
+// take requester over any limits. The scheduled status with excludeID (if set)
+// is not counted towards the daily limit, to allow for updating its time.
+func (p *Processor) validateScheduledAt(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledAtStr string,
+	excludeID string,
+) (time.Time, gtserror.WithCode) {
+	scheduledAt, err := time.Parse(time.RFC3339, scheduledAtStr)
+	if err != nil {
+		const text = "scheduled_at must be a valid ISO 8601 datetime"
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(err, text)
+	}
+
+	if time.Until(scheduledAt) < scheduledStatusMinDelay {
+		const text = "scheduled_at must be at least 5 minutes in the future"
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if excludeID == "" {
+		total, err := p.state.DB.CountScheduledStatusesForAcct(ctx, requester.ID, time.Time{})
+		if err != nil {
+			err := gtserror.Newf("db error counting scheduled statuses: %w", err)
+			return time.Time{}, gtserror.NewErrorInternalError(err)
+		}
+
+		if total >= scheduledStatusMaxTotal {
+			text := fmt.Sprintf("total limit of %d scheduled statuses reached", scheduledStatusMaxTotal)
+			return time.Time{}, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	daily, err := p.state.DB.CountScheduledStatusesForAcct(ctx, requester.ID, scheduledAt)
+	if err != nil {
+		err := gtserror.Newf("db error counting scheduled statuses: %w", err)
+		return time.Time{}, gtserror.NewErrorInternalError(err)
+	}
+
+	if excludeID != "" {
+		// Don't count the status being updated
+		// if it's already scheduled on this day.
+		existing, err := p.state.DB.GetScheduledStatusByID(gtscontext.SetBarebones(ctx), excludeID)
+		if err != nil {
+			err := gtserror.Newf("db error getting scheduled status: %w", err)
+			return time.Time{}, gtserror.NewErrorInternalError(err)
+		}
+
+		if sameUTCDay(existing.ScheduledAt, scheduledAt) {
+			daily--
+		}
+	}
+
+	if daily >= scheduledStatusMaxDaily {
+		text := fmt.Sprintf("daily limit of %d scheduled statuses reached", scheduledStatusMaxDaily)
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return scheduledAt, nil
+}
+
+// getOwnScheduledStatus gets the scheduled status with
+// the given ID, ensuring it is owned by requester.
+func (p *Processor) getOwnScheduledStatus(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*gtsmodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledStatus, err := p.state.DB.GetScheduledStatusByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting scheduled status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if scheduledStatus == nil || scheduledStatus.AccountID != requester.ID {
+		err := gtserror.Newf("scheduled status %s not found for account %s", id, requester.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return scheduledStatus, nil
+}
+
+// deleteScheduledStatus releases any media reserved by the
+// given scheduled status, then deletes it from the database.
+func (p *Processor) deleteScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) error {
+	if err := p.setScheduledStatusMedia(ctx, scheduledStatus, ""); err != nil {
+		return err
+	}
+
+	if err := p.state.DB.DeleteScheduledStatusByID(ctx, scheduledStatus.ID); err != nil {
+		return gtserror.Newf("db error deleting scheduled status: %w", err)
+	}
+
+	return nil
+}
+
+// setScheduledStatusMedia sets the scheduled status ID of the media
+// attachments of the given scheduled status to scheduledStatusID.
+// Set to "" to release them, or to the scheduled status' ID to
+// reserve them again. Media attached elsewhere in the meantime
+// is left alone.
+func (p *Processor) setScheduledStatusMedia(
+	ctx context.Context,
+	scheduledStatus *gtsmodel.ScheduledStatus,
+	scheduledStatusID string,
+) error {
+	if !scheduledStatus.AttachmentsPopulated() {
+		var err error
+		scheduledStatus.MediaAttachments, err = p.state.DB.GetAttachmentsByIDs(ctx, scheduledStatus.MediaIDs)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting attachments: %w", err)
+		}
+	}
+
+	for _, attachment := range scheduledStatus.MediaAttachments {
+		if attachment.StatusID != "" ||
+			(attachment.ScheduledStatusID != "" &&
+				attachment.ScheduledStatusID != scheduledStatus.ID) {
+			continue
+		}
+
+		if attachment.ScheduledStatusID == scheduledStatusID {
+			// Already set.
+			continue
+		}
+
+		attachment.ScheduledStatusID = scheduledStatusID
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "scheduled_status_id"); err != nil {
+			return gtserror.Newf("db error updating attachment %s: %w", attachment.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Processor) apiScheduledStatus(
+	ctx context.Context,
+	scheduledStatus *gtsmodel.ScheduledStatus,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	apiScheduledStatus, err := p.converter.ScheduledStatusToAPIScheduledStatus(ctx, scheduledStatus)
+	if err != nil {
+		err := gtserror.Newf("error converting scheduled status to api scheduled status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiScheduledStatus, nil
+}
+
+// sameUTCDay returns whether a and b fall on the same UTC calendar day.
+func sameUTCDay(a, b time.Time) bool {
+	ay, am, ad := a.UTC().Date()
+	by, bm, bd := b.UTC().Date()
+	return ay == by && am == bm && ad == bd
+}
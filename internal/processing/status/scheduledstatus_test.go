@@ -0,0 +1,238 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ScheduledStatusTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusCreateReservesMedia() {
+	ctx := context.Background()
+
+	var (
+		requester   = suite.testAccounts["local_account_1"]
+		application = suite.testApplications["application_1"]
+		attachment  = suite.testAttachments["local_account_1_unattached_1"]
+		scheduledAt = time.Now().Add(time.Hour).UTC()
+	)
+
+	apiScheduledStatus, errWithCode := suite.status.ScheduledStatusCreate(ctx, requester, application, &apimodel.StatusCreateRequest{
+		Status:      "see you in an hour",
+		MediaIDs:    []string{attachment.ID},
+		ScheduledAt: scheduledAt.Format(time.RFC3339),
+	})
+	suite.NoError(errWithCode)
+	suite.Equal([]string{attachment.ID}, apiScheduledStatus.Params.MediaIDs)
+	suite.Len(apiScheduledStatus.MediaAttachments, 1)
+
+	// Visibility and language should
+	// be resolved from account defaults.
+	suite.Equal(apimodel.VisibilityPublic, apiScheduledStatus.Params.Visibility)
+	suite.Equal("en", apiScheduledStatus.Params.Language)
+
+	// Attachment should now be reserved.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(apiScheduledStatus.ID, dbAttachment.ScheduledStatusID)
+
+	// So it can't be used for another status.
+	_, errWithCode = suite.status.Create(ctx, requester, application, &apimodel.StatusCreateRequest{
+		Status:   "sneaky",
+		MediaIDs: []string{attachment.ID},
+	})
+	suite.EqualError(errWithCode, "media "+attachment.ID+" already attached to status")
+
+	// Deleting the scheduled status should release the attachment.
+	errWithCode = suite.status.ScheduledStatusDelete(ctx, requester, apiScheduledStatus.ID)
+	suite.NoError(errWithCode)
+
+	dbAttachment, err = suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Empty(dbAttachment.ScheduledStatusID)
+
+	_, err = suite.db.GetScheduledStatusByID(ctx, apiScheduledStatus.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusCreateTooSoon() {
+	ctx := context.Background()
+
+	var (
+		requester   = suite.testAccounts["local_account_1"]
+		application = suite.testApplications["application_1"]
+		scheduledAt = time.Now().Add(time.Minute).UTC()
+	)
+
+	_, errWithCode := suite.status.ScheduledStatusCreate(ctx, requester, application, &apimodel.StatusCreateRequest{
+		Status:      "see you in a minute",
+		ScheduledAt: scheduledAt.Format(time.RFC3339),
+	})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.EqualError(errWithCode, "scheduled_at must be at least 5 minutes in the future")
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusUpdateOtherAccount() {
+	ctx := context.Background()
+
+	var (
+		requester       = suite.testAccounts["local_account_2"]
+		scheduledStatus = testrig.NewTestScheduledStatuses()["local_account_1_scheduled_status_1"]
+		scheduledAt     = time.Now().Add(time.Hour).UTC()
+	)
+
+	_, errWithCode := suite.status.ScheduledStatusUpdate(ctx, requester, scheduledStatus.ID, &apimodel.ScheduledStatusUpdateRequest{
+		ScheduledAt: scheduledAt.Format(time.RFC3339),
+	})
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusPublish() {
+	ctx := context.Background()
+
+	scheduledStatus, err := suite.db.GetScheduledStatusByID(ctx,
+		testrig.NewTestScheduledStatuses()["local_account_1_scheduled_status_1"].ID,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Pretend the publication time has
+	// come, and hand status to scheduler.
+	scheduledStatus.ScheduledAt = time.Now()
+	scheduledStatus.Sensitive = util.Ptr(true)
+	if err := suite.db.UpdateScheduledStatus(ctx, scheduledStatus, "scheduled_at", "sensitive"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Ensure the scheduler is running, it may have been
+	// stopped by a lagging teardown from the previous test.
+	_ = suite.state.Workers.Scheduler.Start()
+
+	if err := suite.status.ScheduleStatus(ctx, scheduledStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The status should be published, and
+	// side effects queued for the client worker.
+	ctx, cncl := context.WithTimeout(ctx, 10*time.Second)
+	defer cncl()
+
+	msg, ok := suite.state.Workers.Client.Queue.PopCtx(ctx)
+	if !ok {
+		suite.FailNow("timed out waiting for scheduled status to publish")
+	}
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+
+	status, ok := msg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		suite.FailNow("", "expected *gtsmodel.Status, got %T", msg.GTSModel)
+	}
+	suite.Equal(scheduledStatus.AccountID, status.AccountID)
+	suite.Equal(scheduledStatus.ApplicationID, status.CreatedWithApplicationID)
+	suite.Equal("<p>this status will be posted in the far future!</p>", status.Content)
+	suite.Equal(gtsmodel.VisibilityPublic, status.Visibility)
+	suite.True(*status.Sensitive)
+
+	// The scheduled status itself should be
+	// gone, once the status has been created.
+	if !testrig.WaitFor(func() bool {
+		_, err := suite.db.GetScheduledStatusByID(ctx, scheduledStatus.ID)
+		return errors.Is(err, db.ErrNoEntries)
+	}) {
+		suite.FailNow("timed out waiting for scheduled status to be deleted")
+	}
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusPublishFailed() {
+	ctx := context.Background()
+
+	scheduledStatus, err := suite.db.GetScheduledStatusByID(ctx,
+		testrig.NewTestScheduledStatuses()["local_account_1_scheduled_status_1"].ID,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Reserve some media for the scheduled
+	// status, and have it reply to a status
+	// that's been deleted in the meantime.
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+	attachment.ScheduledStatusID = scheduledStatus.ID
+	if err := suite.db.UpdateAttachment(ctx, attachment, "scheduled_status_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	scheduledStatus.ScheduledAt = time.Now()
+	scheduledStatus.InReplyToID = "01JMZ2DG5YFR7PZ8Y4F2XZS1QX"
+	scheduledStatus.MediaIDs = []string{attachment.ID}
+	scheduledStatus.MediaAttachments = nil
+	if err := suite.db.UpdateScheduledStatus(ctx, scheduledStatus,
+		"scheduled_at",
+		"in_reply_to_id",
+		"attachments",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_ = suite.state.Workers.Scheduler.Start()
+
+	if err := suite.status.ScheduleStatus(ctx, scheduledStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Nothing should be published.
+	popCtx, cncl := context.WithTimeout(ctx, 5*time.Second)
+	defer cncl()
+
+	if _, ok := suite.state.Workers.Client.Queue.PopCtx(popCtx); ok {
+		suite.FailNow("expected scheduled status not to publish")
+	}
+
+	// The scheduled status should still
+	// be there, with its media reserved.
+	_, err = suite.db.GetScheduledStatusByID(ctx, scheduledStatus.ID)
+	suite.NoError(err)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(scheduledStatus.ID, dbAttachment.ScheduledStatusID)
+	suite.Empty(dbAttachment.StatusID)
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusTestSuite))
+}
@@ -24,39 +24,49 @@
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
-// Authorize returns an oauth2 token info in response to an access token query from the streaming API
-func (p *Processor) Authorize(ctx context.Context, accessToken string) (*gtsmodel.Account, gtserror.WithCode) {
+// Authorize returns the account and granted scope of the given access token,
+// in response to an access token query from the streaming API. The token must
+// be granted a scope permitting it to read either statuses or notifications.
+func (p *Processor) Authorize(ctx context.Context, accessToken string) (*gtsmodel.Account, string, gtserror.WithCode) {
 	ti, err := p.oauthServer.LoadAccessToken(ctx, accessToken)
 	if err != nil {
 		err := fmt.Errorf("could not load access token: %s", err)
-		return nil, gtserror.NewErrorUnauthorized(err)
+		return nil, "", gtserror.NewErrorUnauthorized(err)
+	}
+
+	scope := ti.GetScope()
+	if !oauth.ScopeReadStatuses.Permits(scope) &&
+		!oauth.ScopeReadNotifications.Permits(scope) {
+		err := fmt.Errorf("token scope %q does not permit streaming", scope)
+		return nil, "", gtserror.NewErrorForbidden(err, err.Error())
 	}
 
 	uid := ti.GetUserID()
 	if uid == "" {
 		err := fmt.Errorf("no userid in token")
-		return nil, gtserror.NewErrorUnauthorized(err)
+		return nil, "", gtserror.NewErrorUnauthorized(err)
 	}
 
 	user, err := p.state.DB.GetUserByID(ctx, uid)
 	if err != nil {
 		if err == db.ErrNoEntries {
 			err := fmt.Errorf("no user found for validated uid %s", uid)
-			return nil, gtserror.NewErrorUnauthorized(err)
+			return nil, "", gtserror.NewErrorUnauthorized(err)
 		}
-		return nil, gtserror.NewErrorInternalError(err)
+		return nil, "", gtserror.NewErrorInternalError(err)
 	}
 
 	acct, err := p.state.DB.GetAccountByID(ctx, user.AccountID)
 	if err != nil {
 		if err == db.ErrNoEntries {
 			err := fmt.Errorf("no account found for validated uid %s", uid)
-			return nil, gtserror.NewErrorUnauthorized(err)
+			return nil, "", gtserror.NewErrorUnauthorized(err)
 		}
-		return nil, gtserror.NewErrorInternalError(err)
+		return nil, "", gtserror.NewErrorInternalError(err)
 	}
 
-	return acct, nil
+	return acct, scope, nil
 }
@@ -19,10 +19,13 @@
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 )
 
 type AuthorizeTestSuite struct {
@@ -30,17 +33,55 @@ type AuthorizeTestSuite struct {
 }
 
 func (suite *AuthorizeTestSuite) TestAuthorize() {
-	account1, err := suite.streamProcessor.Authorize(context.Background(), suite.testTokens["local_account_1"].Access)
+	account1, scope1, err := suite.streamProcessor.Authorize(context.Background(), suite.testTokens["local_account_1"].Access)
 	suite.NoError(err)
 	suite.Equal(suite.testAccounts["local_account_1"].ID, account1.ID)
+	suite.Equal(suite.testTokens["local_account_1"].Scope, scope1)
 
-	account2, err := suite.streamProcessor.Authorize(context.Background(), suite.testTokens["local_account_2"].Access)
+	account2, scope2, err := suite.streamProcessor.Authorize(context.Background(), suite.testTokens["local_account_2"].Access)
 	suite.NoError(err)
 	suite.Equal(suite.testAccounts["local_account_2"].ID, account2.ID)
+	suite.Equal(suite.testTokens["local_account_2"].Scope, scope2)
 
-	noAccount, err := suite.streamProcessor.Authorize(context.Background(), "aaaaaaaaaaaaaaaaaaaaa!!")
+	noAccount, noScope, err := suite.streamProcessor.Authorize(context.Background(), "aaaaaaaaaaaaaaaaaaaaa!!")
 	suite.EqualError(err, "could not load access token: "+db.ErrNoEntries.Error())
 	suite.Nil(noAccount)
+	suite.Empty(noScope)
+}
+
+func (suite *AuthorizeTestSuite) TestAuthorizeScope() {
+	ctx := context.Background()
+
+	for _, test := range []struct {
+		scope  string
+		expect string
+	}{
+		{"read:notifications", ""},
+		{"read:statuses write", ""},
+		{"write push", `token scope "write push" does not permit streaming`},
+	} {
+		token := new(gtsmodel.Token)
+		*token = *suite.testTokens["local_account_1"]
+		token.ID = id.NewULID()
+		token.Access = id.NewULID()
+		token.Scope = test.scope
+
+		if err := suite.db.PutToken(ctx, token); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		account, scope, errWithCode := suite.streamProcessor.Authorize(ctx, token.Access)
+		if test.expect != "" {
+			suite.EqualError(errWithCode, test.expect)
+			suite.Equal(http.StatusForbidden, errWithCode.Code())
+			suite.Nil(account)
+			continue
+		}
+
+		suite.NoError(errWithCode)
+		suite.Equal(suite.testAccounts["local_account_1"].ID, account.ID)
+		suite.Equal(test.scope, scope)
+	}
 }
 
 func TestAuthorizeTestSuite(t *testing.T) {
@@ -36,3 +36,9 @@ func New(state *state.State, oauthServer oauth.Server) Processor {
 		streams:     stream.Streams{},
 	}
 }
+
+// SubscribedAccountIDs returns the IDs of all accounts that
+// have at least one open stream of any of the given types.
+func (p *Processor) SubscribedAccountIDs(streamTypes ...string) []string {
+	return p.streams.AccountIDs(streamTypes...)
+}
@@ -0,0 +1,710 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// notifGroupSpan is the span of time
+	// within which groupable notifications
+	// of the same type are grouped together.
+	notifGroupSpan = 12 * time.Hour
+
+	// notifGroupSampleSize is the max number of
+	// sample accounts returned for a group.
+	notifGroupSampleSize = 8
+
+	// notifGroupBatchSize and notifGroupMaxBatches control
+	// how many notifications are looked at to fill a page
+	// of groups. Big groups may mean a page has fewer groups
+	// than the limit, but the next page will carry on.
+	notifGroupBatchSize  = 80
+	notifGroupMaxBatches = 5
+
+	// ungroupedPrefix prefixes the group key
+	// of notifications that aren't grouped.
+	ungroupedPrefix = "ungrouped-"
+)
+
+// groupableNotifTypes are the types of
+// notification that may be grouped.
+var groupableNotifTypes = []gtsmodel.NotificationType{
+	gtsmodel.NotificationFave,
+	gtsmodel.NotificationReblog,
+	gtsmodel.NotificationFollow,
+}
+
+// parseGroupedTypes returns the groupable notification
+// types out of the given types, or all of them if none.
+func parseGroupedTypes(types []string) []gtsmodel.NotificationType {
+	grouped := make([]gtsmodel.NotificationType, 0, len(groupableNotifTypes))
+	for _, t := range groupableNotifTypes {
+		if slices.Contains(types, string(t)) {
+			grouped = append(grouped, t)
+		}
+	}
+
+	if len(grouped) == 0 {
+		return groupableNotifTypes
+	}
+
+	return grouped
+}
+
+// notifGroupKey returns the key of the group that the given
+// notification belongs to, grouping only the given types.
+//
+// Favourites and reblogs are grouped by status, and follows
+// all together, within spans of notifGroupSpan. For example,
+// "favourite-01F8MHAMCHF6Y650WCRSCP4WMY-38426" or "follow-38426".
+func notifGroupKey(n *gtsmodel.Notification, groupedTypes []gtsmodel.NotificationType) string {
+	if !slices.Contains(groupedTypes, n.NotificationType) {
+		return ungroupedPrefix + n.ID
+	}
+
+	slot := n.CreatedAt.Unix() / int64(notifGroupSpan/time.Second)
+	slotStr := strconv.FormatInt(slot, 10)
+
+	switch n.NotificationType {
+	case gtsmodel.NotificationFave:
+		return string(n.NotificationType) + "-" + n.StatusID + "-" + slotStr
+	case gtsmodel.NotificationReblog:
+		// Reblog notifications are about the
+		// boost, so group by the boosted status.
+		statusID := n.StatusID
+		if n.Status != nil && n.Status.BoostOfID != "" {
+			statusID = n.Status.BoostOfID
+		}
+		return string(n.NotificationType) + "-" + statusID + "-" + slotStr
+	case gtsmodel.NotificationFollow:
+		return string(n.NotificationType) + "-" + slotStr
+	default:
+		return ungroupedPrefix + n.ID
+	}
+}
+
+// notifGroup models the parts of a parsed notification group key.
+type notifGroup struct {
+	notifID   string // Only set for ungrouped notifications.
+	notifType gtsmodel.NotificationType
+	statusID  string
+	from      time.Time
+	to        time.Time
+}
+
+// parseNotifGroupKey parses the given group
+// key, as generated by notifGroupKey.
+func parseNotifGroupKey(key string) (*notifGroup, error) {
+	if notifID, ok := strings.CutPrefix(key, ungroupedPrefix); ok {
+		if notifID == "" {
+			return nil, gtserror.Newf("invalid group key %s", key)
+		}
+		return &notifGroup{notifID: notifID}, nil
+	}
+
+	parts := strings.Split(key, "-")
+	group := &notifGroup{notifType: gtsmodel.NotificationType(parts[0])}
+
+	switch {
+	case len(parts) == 3 &&
+		(group.notifType == gtsmodel.NotificationFave ||
+			group.notifType == gtsmodel.NotificationReblog):
+		group.statusID = parts[1]
+	case len(parts) == 2 &&
+		group.notifType == gtsmodel.NotificationFollow:
+	default:
+		return nil, gtserror.Newf("invalid group key %s", key)
+	}
+
+	slot, err := strconv.ParseInt(parts[len(parts)-1], 10, 64)
+	if err != nil {
+		return nil, gtserror.Newf("invalid group key %s: %w", key, err)
+	}
+
+	group.from = time.Unix(slot*int64(notifGroupSpan/time.Second), 0)
+	group.to = group.from.Add(notifGroupSpan)
+
+	return group, nil
+}
+
+// groupedNotifsBuilder builds grouped notifications
+// results, deduplicating accounts and statuses.
+type groupedNotifsBuilder struct {
+	p         *Processor
+	requester *gtsmodel.Account
+	filters   []*gtsmodel.Filter
+	mutes     *usermute.CompiledUserMuteList
+
+	results    *apimodel.GroupedNotificationsResults
+	accountIDs map[string]struct{}
+	statusIDs  map[string]struct{}
+}
+
+func (p *Processor) newGroupedNotifsBuilder(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) (*groupedNotifsBuilder, gtserror.WithCode) {
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, requester.ID)
+	if err != nil {
+		err = gtserror.Newf("couldn't retrieve filters for account %s: %w", requester.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mutes, err := p.state.DB.GetAccountMutes(gtscontext.SetBarebones(ctx), requester.ID, nil)
+	if err != nil {
+		err = gtserror.Newf("couldn't retrieve mutes for account %s: %w", requester.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &groupedNotifsBuilder{
+		p:         p,
+		requester: requester,
+		filters:   filters,
+		mutes:     usermute.NewCompiledUserMuteList(mutes),
+		results: &apimodel.GroupedNotificationsResults{
+			Accounts:           make([]*apimodel.Account, 0),
+			Statuses:           make([]*apimodel.Status, 0),
+			NotificationGroups: make([]*apimodel.NotificationGroup, 0),
+		},
+		accountIDs: make(map[string]struct{}),
+		statusIDs:  make(map[string]struct{}),
+	}, nil
+}
+
+// addGroup adds a group with the given key, made up of
+// the given (visible) notifications sorted newest first.
+// Returns nil if the group's status is hidden by filters.
+func (b *groupedNotifsBuilder) addGroup(
+	ctx context.Context,
+	key string,
+	notifs []*gtsmodel.Notification,
+) (*apimodel.NotificationGroup, error) {
+	// Convert the most recent notification, which
+	// also checks the status against filters + mutes.
+	apiNotif, err := b.p.converter.NotificationToAPINotification(ctx, notifs[0], b.filters, b.mutes)
+	if err != nil {
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	group := &apimodel.NotificationGroup{
+		GroupKey:                 key,
+		NotificationsCount:       len(notifs),
+		Type:                     apiNotif.Type,
+		MostRecentNotificationID: apiNotif.ID,
+		SampleAccountIDs:         []string{apiNotif.Account.ID},
+	}
+
+	b.addAccount(apiNotif.Account)
+	if apiNotif.Status != nil {
+		group.StatusID = apiNotif.Status.ID
+		if _, ok := b.statusIDs[apiNotif.Status.ID]; !ok {
+			b.statusIDs[apiNotif.Status.ID] = struct{}{}
+			b.results.Statuses = append(b.results.Statuses, apiNotif.Status)
+		}
+	}
+
+	// Add more sample accounts
+	// from older notifications.
+	for _, n := range notifs[1:] {
+		if len(group.SampleAccountIDs) == notifGroupSampleSize {
+			break
+		}
+
+		if slices.Contains(group.SampleAccountIDs, n.OriginAccountID) {
+			continue
+		}
+
+		apiAccount, err := b.p.converter.AccountToAPIAccountPublic(ctx, n.OriginAccount)
+		if err != nil {
+			log.Debugf(ctx, "skipping sample account %s: %v", n.OriginAccountID, err)
+			continue
+		}
+
+		group.SampleAccountIDs = append(group.SampleAccountIDs, apiAccount.ID)
+		b.addAccount(apiAccount)
+	}
+
+	b.results.NotificationGroups = append(b.results.NotificationGroups, group)
+	return group, nil
+}
+
+func (b *groupedNotifsBuilder) addAccount(account *apimodel.Account) {
+	if _, ok := b.accountIDs[account.ID]; ok {
+		return
+	}
+	b.accountIDs[account.ID] = struct{}{}
+	b.results.Accounts = append(b.results.Accounts, account)
+}
+
+// NotificationGroupsGet returns a page of notification groups for
+// the authed account, and the Link header to use for paging.
+//
+// The page limit is the maximum number of groups; a page covers a
+// range of notifications in which there are at most that many groups.
+func (p *Processor) NotificationGroupsGet(
+	ctx context.Context,
+	authed *oauth.Auth,
+	page *paging.Page,
+	types []string,
+	excludeTypes []string,
+	groupedTypes []string,
+) (*apimodel.GroupedNotificationsResults, string, gtserror.WithCode) {
+	var (
+		grouped = parseGroupedTypes(groupedTypes)
+		limit   = page.GetLimit()
+		minID   = page.GetMin()
+		maxID   = page.GetMax()
+		asc     = page.GetOrder() == paging.OrderAscending
+
+		// Notifications in the page range,
+		// in the order they were paged in.
+		scanned []*gtsmodel.Notification
+
+		// Visible notifications in the page
+		// range, and the keys of their groups.
+		visible   []*gtsmodel.Notification
+		groupKeys = make(map[string]struct{}, limit)
+	)
+
+scan:
+	for i := 0; i < notifGroupMaxBatches; i++ {
+		var sinceID, batchMinID string
+		if asc {
+			batchMinID = minID
+		} else {
+			sinceID = minID
+		}
+
+		notifs, err := p.state.DB.GetAccountNotifications(
+			ctx,
+			authed.Account.ID,
+			maxID,
+			sinceID,
+			batchMinID,
+			notifGroupBatchSize,
+			types,
+			excludeTypes,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting notifications: %w", err)
+			return nil, "", gtserror.NewErrorInternalError(err)
+		}
+
+		if asc {
+			// Notifications are always returned
+			// newest first, we want paging order.
+			slices.Reverse(notifs)
+		}
+
+		for _, n := range notifs {
+			// Invisible notifications are still part of
+			// the page range, but don't make up groups.
+			ok, err := p.notifVisible(ctx, n, authed.Account)
+			if err != nil {
+				log.Debugf(ctx, "skipping notification %s because of an error checking notification visibility: %v", n.ID, err)
+				ok = false
+			}
+
+			if ok {
+				key := notifGroupKey(n, grouped)
+				if _, have := groupKeys[key]; !have {
+					if len(groupKeys) == limit {
+						// Page is full, don't include
+						// any more notifications in it.
+						break scan
+					}
+					groupKeys[key] = struct{}{}
+				}
+				visible = append(visible, n)
+			}
+
+			scanned = append(scanned, n)
+		}
+
+		if len(notifs) < notifGroupBatchSize {
+			// Reached the end.
+			break
+		}
+
+		// Carry on from the last notification.
+		if asc {
+			minID = notifs[len(notifs)-1].ID
+		} else {
+			maxID = notifs[len(notifs)-1].ID
+		}
+	}
+
+	if len(scanned) == 0 {
+		results := &apimodel.GroupedNotificationsResults{
+			Accounts:           make([]*apimodel.Account, 0),
+			Statuses:           make([]*apimodel.Status, 0),
+			NotificationGroups: make([]*apimodel.NotificationGroup, 0),
+		}
+		return results, "", nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo, hi := scanned[len(scanned)-1].ID, scanned[0].ID
+	if asc {
+		lo, hi = hi, lo
+		slices.Reverse(visible)
+	}
+
+	// Gather the visible notifications into their groups,
+	// keeping the groups ordered by newest notification.
+	var (
+		keys   = make([]string, 0, len(groupKeys))
+		groups = make(map[string][]*gtsmodel.Notification, len(groupKeys))
+	)
+	for _, n := range visible {
+		key := notifGroupKey(n, grouped)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], n)
+	}
+
+	builder, errWithCode := p.newGroupedNotifsBuilder(ctx, authed.Account)
+	if errWithCode != nil {
+		return nil, "", errWithCode
+	}
+
+	for _, key := range keys {
+		notifs := groups[key]
+
+		group, err := builder.addGroup(ctx, key, notifs)
+		if err != nil {
+			log.Debugf(ctx, "skipping notification group %s because it couldn't be converted to its api representation: %v", key, err)
+			continue
+		}
+
+		if group == nil {
+			// Hidden.
+			continue
+		}
+
+		group.PageMaxID = notifs[0].ID
+		group.PageMinID = notifs[len(notifs)-1].ID
+		group.LatestPageNotificationAt = util.FormatISO8601(notifs[0].CreatedAt)
+	}
+
+	// Build extra query params to return in Link header.
+	extraParams := make(url.Values, 3)
+	for _, t := range types {
+		extraParams.Add("types[]", t)
+	}
+	for _, t := range excludeTypes {
+		extraParams.Add("exclude_types[]", t)
+	}
+	for _, t := range groupedTypes {
+		extraParams.Add("grouped_types[]", t)
+	}
+
+	resp := paging.PackageResponse(paging.ResponseParams{
+		Path:  "/api/v2/notifications",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+		Query: extraParams,
+	})
+
+	return builder.results, resp.LinkHeader, nil
+}
+
+// getNotifGroup returns the visible notifications making
+// up the group with the given key for the given account,
+// sorted newest first, or an error if there are none.
+func (p *Processor) getNotifGroup(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	key string,
+) ([]*gtsmodel.Notification, gtserror.WithCode) {
+	group, err := parseNotifGroupKey(key)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	var notifs []*gtsmodel.Notification
+	if group.notifID != "" {
+		notif, err := p.state.DB.GetNotificationByID(ctx, group.notifID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting notification %s: %w", group.notifID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if notif != nil && notif.TargetAccountID == account.ID {
+			notifs = []*gtsmodel.Notification{notif}
+		}
+	} else {
+		notifs, err = p.state.DB.GetAccountNotificationsGroup(
+			ctx,
+			account.ID,
+			group.notifType,
+			group.statusID,
+			group.from,
+			group.to,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting notifications in group %s: %w", key, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Only keep visible notifications.
+	notifs = slices.DeleteFunc(notifs, func(n *gtsmodel.Notification) bool {
+		visible, err := p.notifVisible(ctx, n, account)
+		if err != nil {
+			log.Debugf(ctx, "skipping notification %s because of an error checking notification visibility: %v", n.ID, err)
+			return true
+		}
+		return !visible
+	})
+
+	if len(notifs) == 0 {
+		err := gtserror.Newf("notification group %s not found", key)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return notifs, nil
+}
+
+// NotificationGroupGet returns the notification group with the given key.
+func (p *Processor) NotificationGroupGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	key string,
+) (*apimodel.GroupedNotificationsResults, gtserror.WithCode) {
+	notifs, errWithCode := p.getNotifGroup(ctx, account, key)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	builder, errWithCode := p.newGroupedNotifsBuilder(ctx, account)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	group, err := builder.addGroup(ctx, key, notifs)
+	if err != nil {
+		err = gtserror.Newf("error converting notification group %s: %w", key, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if group == nil {
+		err := gtserror.Newf("notification group %s status is hidden", key)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return builder.results, nil
+}
+
+// NotificationGroupAccountsGet returns the accounts that performed the
+// actions that generated the notifications in the given group, newest first.
+func (p *Processor) NotificationGroupAccountsGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	key string,
+) ([]*apimodel.Account, gtserror.WithCode) {
+	notifs, errWithCode := p.getNotifGroup(ctx, account, key)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	accounts := make([]*apimodel.Account, 0, len(notifs))
+	accountIDs := make(map[string]struct{}, len(notifs))
+
+	for _, n := range notifs {
+		if _, ok := accountIDs[n.OriginAccountID]; ok {
+			continue
+		}
+		accountIDs[n.OriginAccountID] = struct{}{}
+
+		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, n.OriginAccount)
+		if err != nil {
+			log.Debugf(ctx, "skipping account %s: %v", n.OriginAccountID, err)
+			continue
+		}
+
+		accounts = append(accounts, apiAccount)
+	}
+
+	return accounts, nil
+}
+
+// NotificationGroupDismiss deletes all of
+// the notifications in the given group.
+func (p *Processor) NotificationGroupDismiss(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	key string,
+) gtserror.WithCode {
+	notifs, errWithCode := p.getNotifGroup(ctx, account, key)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	for _, n := range notifs {
+		if err := p.state.DB.DeleteNotificationByID(ctx, n.ID); err != nil {
+			err = gtserror.Newf("db error deleting notification %s: %w", n.ID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// getUnreadNotifs returns up to limit notifications visible
+// to the given account and newer than its notifications
+// marker, sorted newest first.
+func (p *Processor) getUnreadNotifs(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	limit int,
+	types []string,
+	excludeTypes []string,
+) ([]*gtsmodel.Notification, gtserror.WithCode) {
+	var lastReadID string
+
+	marker, err := p.state.DB.GetMarker(ctx, account.ID, gtsmodel.MarkerNameNotifications)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting notifications marker: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if marker != nil {
+		lastReadID = marker.LastReadID
+	}
+
+	var (
+		maxID   string
+		visible = make([]*gtsmodel.Notification, 0, limit)
+	)
+
+	// Page down through unread notifications in batches,
+	// as with groups, so that invisible notifications
+	// don't stop us counting up to the limit.
+	for i := 0; i < notifGroupMaxBatches; i++ {
+		notifs, err := p.state.DB.GetAccountNotifications(
+			ctx,
+			account.ID,
+			maxID,
+			lastReadID,
+			"",
+			notifGroupBatchSize,
+			types,
+			excludeTypes,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting notifications: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if len(notifs) == 0 {
+			// No more unread.
+			break
+		}
+
+		for _, n := range notifs {
+			ok, err := p.notifVisible(ctx, n, account)
+			if err != nil {
+				log.Debugf(ctx, "skipping notification %s because of an error checking notification visibility: %v", n.ID, err)
+				continue
+			}
+
+			if !ok {
+				continue
+			}
+
+			visible = append(visible, n)
+			if len(visible) == limit {
+				// Reached the cap.
+				return visible, nil
+			}
+		}
+
+		maxID = notifs[len(notifs)-1].ID
+	}
+
+	return visible, nil
+}
+
+// NotificationsUnreadCount returns the number of notifications
+// for the given account newer than its notifications marker,
+// counting up to the given limit.
+func (p *Processor) NotificationsUnreadCount(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	limit int,
+	types []string,
+	excludeTypes []string,
+) (*apimodel.NotificationsUnreadCount, gtserror.WithCode) {
+	notifs, errWithCode := p.getUnreadNotifs(ctx, account, limit, types, excludeTypes)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return &apimodel.NotificationsUnreadCount{Count: len(notifs)}, nil
+}
+
+// NotificationGroupsUnreadCount returns the number of notification
+// groups with notifications for the given account newer than its
+// notifications marker, counting up to the given limit notifications.
+func (p *Processor) NotificationGroupsUnreadCount(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	limit int,
+	types []string,
+	excludeTypes []string,
+	groupedTypes []string,
+) (*apimodel.NotificationsUnreadCount, gtserror.WithCode) {
+	notifs, errWithCode := p.getUnreadNotifs(ctx, account, limit, types, excludeTypes)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	grouped := parseGroupedTypes(groupedTypes)
+	groupKeys := make(map[string]struct{}, len(notifs))
+	for _, n := range notifs {
+		groupKeys[notifGroupKey(n, grouped)] = struct{}{}
+	}
+
+	return &apimodel.NotificationsUnreadCount{Count: len(groupKeys)}, nil
+}
@@ -0,0 +1,339 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// NotificationPolicyGet returns the notification policy
+// of the given account, or the default policy if unset.
+func (p *Processor) NotificationPolicyGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (*apimodel.NotificationPolicy, gtserror.WithCode) {
+	policy, err := p.state.DB.GetNotificationPolicy(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting notification policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if policy == nil {
+		policy = gtsmodel.DefaultNotificationPolicy(account.ID)
+	}
+
+	return p.apiNotificationPolicy(ctx, policy)
+}
+
+// NotificationPolicyUpdate updates the notification
+// policy of the given account with the given form.
+func (p *Processor) NotificationPolicyUpdate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	form *apimodel.NotificationPolicyUpdateRequest,
+) (*apimodel.NotificationPolicy, gtserror.WithCode) {
+	policy, err := p.state.DB.GetNotificationPolicy(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting notification policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	exists := policy != nil
+	if !exists {
+		policy = gtsmodel.DefaultNotificationPolicy(account.ID)
+		policy.ID = id.NewULID()
+	}
+
+	var columns []string
+	for _, field := range []struct {
+		name  string
+		value *string
+		dst   *gtsmodel.NotificationPolicyValue
+	}{
+		{"for_not_following", form.ForNotFollowing, &policy.ForNotFollowing},
+		{"for_not_followers", form.ForNotFollowers, &policy.ForNotFollowers},
+		{"for_new_accounts", form.ForNewAccounts, &policy.ForNewAccounts},
+		{"for_private_mentions", form.ForPrivateMentions, &policy.ForPrivateMentions},
+		{"for_limited_accounts", form.ForLimitedAccounts, &policy.ForLimitedAccounts},
+	} {
+		if field.value == nil {
+			// Not changed.
+			continue
+		}
+
+		value, ok := gtsmodel.ParseNotificationPolicyValue(*field.value)
+		if !ok {
+			text := field.name + " must be one of accept, filter or drop"
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		*field.dst = value
+		columns = append(columns, field.name)
+	}
+
+	switch {
+	case !exists:
+		if err := p.state.DB.PutNotificationPolicy(ctx, policy); err != nil {
+			err := gtserror.Newf("db error putting notification policy: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+	case len(columns) > 0:
+		if err := p.state.DB.UpdateNotificationPolicy(ctx, policy, columns...); err != nil {
+			err := gtserror.Newf("db error updating notification policy: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.apiNotificationPolicy(ctx, policy)
+}
+
+func (p *Processor) apiNotificationPolicy(
+	ctx context.Context,
+	policy *gtsmodel.NotificationPolicy,
+) (*apimodel.NotificationPolicy, gtserror.WithCode) {
+	requests, notifs, err := p.state.DB.CountAccountNotificationRequests(ctx, policy.AccountID)
+	if err != nil {
+		err := gtserror.Newf("db error counting notification requests: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.NotificationPolicyToAPINotificationPolicy(policy, requests, notifs), nil
+}
+
+// NotificationRequestsGet returns a page of pending
+// notification requests for the given account.
+func (p *Processor) NotificationRequestsGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	reqs, err := p.state.DB.GetAccountNotificationRequests(ctx, account.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting notification requests: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(reqs)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	filters, mutes, errWithCode := p.notifFiltersMutes(ctx, account)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = reqs[count-1].ID
+		hi = reqs[0].ID
+
+		// Best-guess items length.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, req := range reqs {
+		apiReq, err := p.apiNotificationRequest(ctx, account, req, filters, mutes)
+		if err != nil {
+			log.Debugf(ctx, "skipping notification request %s because it couldn't be converted to its api representation: %v", req.ID, err)
+			continue
+		}
+
+		items = append(items, apiReq)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/notifications/requests",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// NotificationRequestGet returns the pending notification
+// request with the given ID for the given account.
+func (p *Processor) NotificationRequestGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	reqID string,
+) (*apimodel.NotificationRequest, gtserror.WithCode) {
+	req, errWithCode := p.getNotificationRequest(ctx, account, reqID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filters, mutes, errWithCode := p.notifFiltersMutes(ctx, account)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiReq, err := p.apiNotificationRequest(ctx, account, req, filters, mutes)
+	if err != nil {
+		err := gtserror.Newf("error converting notification request %s: %w", reqID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiReq, nil
+}
+
+// NotificationRequestsAccept accepts the pending notification requests with
+// the given IDs for the given account. Their filtered notifications are moved
+// into the account's notifications, and later notifications from the accounts
+// of the requests won't be filtered anymore.
+func (p *Processor) NotificationRequestsAccept(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	reqIDs []string,
+) gtserror.WithCode {
+	for _, reqID := range reqIDs {
+		req, errWithCode := p.getNotificationRequest(gtscontext.SetBarebones(ctx), account, reqID)
+		if errWithCode != nil {
+			return errWithCode
+		}
+
+		req.AcceptedAt = time.Now()
+		if err := p.state.DB.UpdateNotificationRequest(ctx, req, "accepted_at"); err != nil {
+			err := gtserror.Newf("db error updating notification request %s: %w", reqID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if err := p.state.DB.UnfilterNotifications(ctx, account.ID, req.FromAccountID); err != nil {
+			err := gtserror.Newf("db error unfiltering notifications for request %s: %w", reqID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// NotificationRequestsDismiss dismisses the pending notification requests
+// with the given IDs for the given account, deleting their filtered
+// notifications. Later filtered notifications from the accounts of
+// the requests will make new notification requests.
+func (p *Processor) NotificationRequestsDismiss(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	reqIDs []string,
+) gtserror.WithCode {
+	for _, reqID := range reqIDs {
+		req, errWithCode := p.getNotificationRequest(gtscontext.SetBarebones(ctx), account, reqID)
+		if errWithCode != nil {
+			return errWithCode
+		}
+
+		if err := p.state.DB.DeleteFilteredNotifications(ctx, account.ID, req.FromAccountID); err != nil {
+			err := gtserror.Newf("db error deleting notifications for request %s: %w", reqID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if err := p.state.DB.DeleteNotificationRequestByID(ctx, reqID); err != nil {
+			err := gtserror.Newf("db error deleting notification request %s: %w", reqID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// getNotificationRequest returns the pending notification request
+// with the given ID for the given account, or a not found error.
+func (p *Processor) getNotificationRequest(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	reqID string,
+) (*gtsmodel.NotificationRequest, gtserror.WithCode) {
+	req, err := p.state.DB.GetNotificationRequestByID(ctx, reqID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting notification request %s: %w", reqID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if req == nil || req.AccountID != account.ID || req.IsAccepted() {
+		const text = "notification request not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return req, nil
+}
+
+// notifFiltersMutes returns the filters and
+// compiled mutes of the given account, for
+// converting notification statuses.
+func (p *Processor) notifFiltersMutes(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) ([]*gtsmodel.Filter, *usermute.CompiledUserMuteList, gtserror.WithCode) {
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil {
+		err = gtserror.Newf("couldn't retrieve filters for account %s: %w", account.ID, err)
+		return nil, nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mutes, err := p.state.DB.GetAccountMutes(gtscontext.SetBarebones(ctx), account.ID, nil)
+	if err != nil {
+		err = gtserror.Newf("couldn't retrieve mutes for account %s: %w", account.ID, err)
+		return nil, nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return filters, usermute.NewCompiledUserMuteList(mutes), nil
+}
+
+// apiNotificationRequest converts the given notification
+// request for the given account, leaving out its last
+// status if it's not visible to the account.
+func (p *Processor) apiNotificationRequest(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	req *gtsmodel.NotificationRequest,
+	filters []*gtsmodel.Filter,
+	mutes *usermute.CompiledUserMuteList,
+) (*apimodel.NotificationRequest, error) {
+	if err := p.state.DB.PopulateNotificationRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if req.LastStatus != nil {
+		visible, err := p.visFilter.StatusVisible(ctx, account, req.LastStatus)
+		if err != nil {
+			return nil, err
+		}
+
+		if !visible {
+			// Don't show this
+			// status to account.
+			req.LastStatusID = ""
+			req.LastStatus = nil
+		}
+	}
+
+	return p.converter.NotificationRequestToAPINotificationRequest(ctx, req, filters, mutes)
+}
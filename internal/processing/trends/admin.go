@@ -0,0 +1,186 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// AdminTrendsGet returns all items of the given type that
+// are currently trending on this instance, regardless of
+// whether they've been approved, for review by an admin.
+func (p *Processor) AdminTrendsGet(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	trendType gtsmodel.TrendType,
+) ([]*apimodel.AdminTrend, gtserror.WithCode) {
+	trends := p.getTrends(trendType, true)
+
+	apiTrends := make([]*apimodel.AdminTrend, 0, len(trends))
+	for _, t := range trends {
+		apiTrend, err := p.apiAdminTrend(ctx, adminAcct, t)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if apiTrend == nil {
+			// Target deleted,
+			// or has no card.
+			continue
+		}
+
+		apiTrends = append(apiTrends, apiTrend)
+	}
+
+	return apiTrends, nil
+}
+
+// AdminTrendApprove approves the trending item with the
+// given review ID, allowing it to be shown in trends.
+func (p *Processor) AdminTrendApprove(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	reviewID string,
+) (*apimodel.AdminTrend, gtserror.WithCode) {
+	return p.review(ctx, adminAcct, reviewID, gtsmodel.TrendReviewStateApproved)
+}
+
+// AdminTrendReject rejects the trending item with the
+// given review ID, preventing it from being shown in trends.
+func (p *Processor) AdminTrendReject(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	reviewID string,
+) (*apimodel.AdminTrend, gtserror.WithCode) {
+	return p.review(ctx, adminAcct, reviewID, gtsmodel.TrendReviewStateRejected)
+}
+
+func (p *Processor) review(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	reviewID string,
+	state gtsmodel.TrendReviewState,
+) (*apimodel.AdminTrend, gtserror.WithCode) {
+	review, err := p.state.DB.GetTrendReviewByID(ctx, reviewID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting trend review %s: %w", reviewID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if review == nil {
+		err := gtserror.Newf("trend review %s not found", reviewID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	review.State = state
+	review.ReviewedAt = time.Now()
+	review.ReviewedByAccountID = adminAcct.ID
+
+	if err := p.state.DB.UpdateTrendReview(
+		ctx,
+		review,
+		"state",
+		"reviewed_at",
+		"reviewed_by_account_id",
+	); err != nil {
+		err := gtserror.Newf("db error updating trend review %s: %w", reviewID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Update any cached trend so the
+	// change is reflected immediately.
+	t := p.setReview(review)
+	if t == nil {
+		// No longer
+		// trending.
+		t = &trend{
+			target: review.Target,
+			review: review,
+		}
+	}
+
+	apiTrend, err := p.apiAdminTrend(ctx, adminAcct, t)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if apiTrend == nil {
+		err := gtserror.Newf("%s %s no longer exists", review.TrendType, review.Target)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return apiTrend, nil
+}
+
+// apiAdminTrend converts the given trending item to
+// its admin API model. If the trending tag or status
+// no longer exists, or the link has no preview card,
+// it returns nil.
+func (p *Processor) apiAdminTrend(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	t *trend,
+) (*apimodel.AdminTrend, error) {
+	apiTrend := &apimodel.AdminTrend{
+		ID:       t.review.ID,
+		Type:     t.review.TrendType.String(),
+		State:    t.review.State.String(),
+		Accounts: t.accounts,
+		Uses:     t.uses,
+	}
+
+	switch t.review.TrendType {
+	case gtsmodel.TrendTypeTag:
+		apiTag, err := p.apiTag(ctx, adminAcct, t)
+		if err != nil || apiTag == nil {
+			return nil, err
+		}
+		apiTrend.Tag = apiTag
+
+	case gtsmodel.TrendTypeStatus:
+		status, err := p.state.DB.GetStatusByID(ctx, t.target)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				return nil, nil
+			}
+			return nil, gtserror.Newf("db error getting status %s: %w", t.target, err)
+		}
+
+		apiTrend.Status, err = p.converter.StatusToAPIStatus(ctx, status, adminAcct, statusfilter.FilterContextNone, nil, nil)
+		if err != nil {
+			return nil, gtserror.Newf("error converting status %s: %w", t.target, err)
+		}
+
+	case gtsmodel.TrendTypeLink:
+		apiLink, err := p.apiLink(ctx, t)
+		if err != nil || apiLink == nil {
+			return nil, err
+		}
+		apiTrend.Link = apiLink
+	}
+
+	return apiTrend, nil
+}
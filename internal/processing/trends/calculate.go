@@ -0,0 +1,538 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// trendsDays is the number of days of
+	// activity considered when calculating
+	// trends, and shown in their history.
+	trendsDays = 7
+
+	// trendsHalfLife is how long it takes
+	// for an account's use of an item to
+	// count half as much toward its score.
+	trendsHalfLife = 24 * time.Hour
+
+	// trendsMinAccounts is the number of distinct
+	// accounts that must have used an item within
+	// trendsDays for it to be considered trending.
+	trendsMinAccounts = 2
+
+	// trendsMaxItems is the maximum number of
+	// items of each type that will be trending.
+	trendsMaxItems = 40
+
+	// trendsRefresh is how often
+	// trends are recalculated.
+	trendsRefresh = 15 * time.Minute
+
+	day = 24 * time.Hour
+)
+
+// trend is one tag, status, or link that's
+// trending on this instance, with its stats.
+type trend struct {
+	target   string                // ID of the tag or status, or URL of the link.
+	score    float64               // Distinct accounts, weighted by how recently they used the item.
+	accounts int                   // Distinct accounts that used the item within trendsDays.
+	uses     int                   // Total uses of the item within trendsDays.
+	history  []apimodel.History    // Daily usage of the item, most recent day first.
+	review   *gtsmodel.TrendReview // Admin review of the item.
+}
+
+// trendsCache holds the most
+// recently calculated trends.
+type trendsCache struct {
+	sync.Mutex
+	trends map[gtsmodel.TrendType][]*trend
+}
+
+// ScheduleCalculate schedules trends to be
+// calculated in the background, straight
+// away and then every trendsRefresh.
+func (p *Processor) ScheduleCalculate() error {
+	fn := func(ctx context.Context, start time.Time) {
+		if err := p.Calculate(ctx); err != nil {
+			log.Errorf(ctx, "error calculating trends: %v", err)
+			return
+		}
+		log.Debugf(ctx, "calculated trends after %s", time.Since(start))
+	}
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@trends",
+		time.Now(),
+		trendsRefresh,
+		fn,
+	) {
+		return gtserror.New("failed to schedule @trends")
+	}
+
+	return nil
+}
+
+// Calculate calculates trending items of each
+// type, and replaces the cached trends with them.
+func (p *Processor) Calculate(ctx context.Context) error {
+	trends, err := p.calculate(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	p.cache.Lock()
+	defer p.cache.Unlock()
+
+	// Reviews may have been made while calculating,
+	// so keep any cached review newer than the one
+	// that was loaded during calculation.
+	reviews := make(map[string]*gtsmodel.TrendReview)
+	for _, cached := range p.cache.trends {
+		for _, t := range cached {
+			reviews[t.review.ID] = t.review
+		}
+	}
+
+	for _, calculated := range trends {
+		for _, t := range calculated {
+			review, ok := reviews[t.review.ID]
+			if ok && review.ReviewedAt.After(t.review.ReviewedAt) {
+				t.review = review
+			}
+		}
+	}
+
+	p.cache.trends = trends
+	return nil
+}
+
+// getTrends returns cached trending items of the given type, highest
+// scoring first. Items that haven't been approved by an admin are only
+// returned if withUnapproved. Trends are calculated in the background,
+// so until the first calculation has finished, nothing is returned.
+func (p *Processor) getTrends(
+	trendType gtsmodel.TrendType,
+	withUnapproved bool,
+) []*trend {
+	p.cache.Lock()
+	defer p.cache.Unlock()
+
+	cached := p.cache.trends[trendType]
+	trends := make([]*trend, 0, len(cached))
+	for _, t := range cached {
+		if !withUnapproved && !t.review.Approved() {
+			continue
+		}
+
+		// Copy trend and its review, as
+		// review may change while in use.
+		t2 := *t
+		review := *t.review
+		t2.review = &review
+		trends = append(trends, &t2)
+	}
+
+	return trends
+}
+
+// setReview replaces the review of the matching
+// cached trend, returning a copy of the trend if
+// it's currently trending, or nil otherwise.
+func (p *Processor) setReview(review *gtsmodel.TrendReview) *trend {
+	p.cache.Lock()
+	defer p.cache.Unlock()
+
+	for _, t := range p.cache.trends[review.TrendType] {
+		if t.review.ID != review.ID {
+			continue
+		}
+
+		t.review = review
+		t2 := *t
+		return &t2
+	}
+
+	return nil
+}
+
+// calculate calculates trending items of each type from
+// activity within the last trendsDays, returning them
+// keyed by type, and ensuring each has an admin review.
+func (p *Processor) calculate(
+	ctx context.Context,
+	now time.Time,
+) (map[gtsmodel.TrendType][]*trend, error) {
+	sinceID, err := id.NewULIDFromTime(now.Add(-trendsDays * day))
+	if err != nil {
+		return nil, gtserror.Newf("error generating sinceID: %w", err)
+	}
+
+	// Cache domain block checks, as
+	// lots of activity from one domain
+	// is likely during calculation.
+	blocked := make(map[string]bool)
+	isBlocked := func(domain string) (bool, error) {
+		if domain == "" {
+			// Local.
+			return false, nil
+		}
+
+		if b, ok := blocked[domain]; ok {
+			return b, nil
+		}
+
+		b, err := p.state.DB.IsDomainBlocked(ctx, domain)
+		if err != nil {
+			return false, gtserror.Newf("db error checking domain block for %s: %w", domain, err)
+		}
+
+		blocked[domain] = b
+		return b, nil
+	}
+
+	tagActivity, err := p.state.DB.GetTagTrendActivity(ctx, sinceID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting tag activity: %w", err)
+	}
+
+	statusActivity, err := p.state.DB.GetStatusTrendActivity(ctx, sinceID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting status activity: %w", err)
+	}
+
+	linkActivity, err := p.linkActivity(ctx, sinceID)
+	if err != nil {
+		return nil, err
+	}
+
+	trendable := map[gtsmodel.TrendType]func(string) (bool, error){
+		gtsmodel.TrendTypeTag: func(tagID string) (bool, error) {
+			tag, err := p.state.DB.GetTag(ctx, tagID)
+			if err != nil {
+				return false, gtserror.Newf("db error getting tag %s: %w", tagID, err)
+			}
+
+			return *tag.Useable && *tag.Listable, nil
+		},
+
+		gtsmodel.TrendTypeStatus: func(statusID string) (bool, error) {
+			if statusID <= sinceID {
+				// Only recent statuses trend,
+				// not old ones being boosted.
+				return false, nil
+			}
+
+			status, err := p.state.DB.GetStatusByID(ctx, statusID)
+			if err != nil {
+				return false, gtserror.Newf("db error getting status %s: %w", statusID, err)
+			}
+
+			if status.Visibility != gtsmodel.VisibilityPublic ||
+				*status.Sensitive ||
+				status.Account.IsSuspended() ||
+				!status.Account.SilencedAt.IsZero() {
+				return false, nil
+			}
+
+			domainBlocked, err := isBlocked(status.Account.Domain)
+			return !domainBlocked, err
+		},
+
+		gtsmodel.TrendTypeLink: func(link string) (bool, error) {
+			u, err := url.Parse(link)
+			if err != nil {
+				return false, nil
+			}
+
+			domainBlocked, err := isBlocked(u.Hostname())
+			return !domainBlocked, err
+		},
+	}
+
+	trends := make(map[gtsmodel.TrendType][]*trend, 3)
+	for trendType, activity := range map[gtsmodel.TrendType][]*gtsmodel.TrendActivity{
+		gtsmodel.TrendTypeTag:    tagActivity,
+		gtsmodel.TrendTypeStatus: statusActivity,
+		gtsmodel.TrendTypeLink:   linkActivity,
+	} {
+		candidates, err := rank(now, activity, isBlocked)
+		if err != nil {
+			return nil, err
+		}
+
+		trends[trendType], err = p.selectTrends(ctx, trendType, candidates, trendable[trendType])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return trends, nil
+}
+
+// rank tallies the given activity by target, returning
+// those with at least trendsMinAccounts distinct accounts,
+// highest scoring first. Activity by accounts on blocked
+// domains is ignored.
+func rank(
+	now time.Time,
+	activity []*gtsmodel.TrendActivity,
+	isBlocked func(string) (bool, error),
+) ([]*trend, error) {
+	type tally struct {
+		latest map[string]time.Time       // Latest use by each account.
+		days   [trendsDays]map[string]int // Uses by each account on each day.
+		uses   int
+	}
+
+	var (
+		today   = now.UTC().Truncate(day)
+		tallies = make(map[string]*tally)
+	)
+
+	for _, a := range activity {
+		blocked, err := isBlocked(a.AccountDomain)
+		if err != nil {
+			return nil, err
+		}
+
+		if blocked {
+			continue
+		}
+
+		t, ok := tallies[a.Target]
+		if !ok {
+			t = &tally{latest: make(map[string]time.Time)}
+			tallies[a.Target] = t
+		}
+
+		t.uses++
+		if a.CreatedAt.After(t.latest[a.AccountID]) {
+			t.latest[a.AccountID] = a.CreatedAt
+		}
+
+		// Days ago relative to today, clamped
+		// to guard against dodgy timestamps.
+		d := int(today.Sub(a.CreatedAt.UTC().Truncate(day)) / day)
+		d = max(0, min(d, trendsDays-1))
+		if t.days[d] == nil {
+			t.days[d] = make(map[string]int)
+		}
+		t.days[d][a.AccountID]++
+	}
+
+	candidates := make([]*trend, 0, len(tallies))
+	for target, t := range tallies {
+		if len(t.latest) < trendsMinAccounts {
+			continue
+		}
+
+		var score float64
+		for _, latest := range t.latest {
+			age := max(0, now.Sub(latest))
+			score += math.Exp2(-float64(age) / float64(trendsHalfLife))
+		}
+
+		history := make([]apimodel.History, trendsDays)
+		for d, accounts := range t.days {
+			var uses int
+			for _, n := range accounts {
+				uses += n
+			}
+
+			history[d] = apimodel.History{
+				Day:      strconv.FormatInt(today.Add(-time.Duration(d)*day).Unix(), 10),
+				Uses:     strconv.Itoa(uses),
+				Accounts: strconv.Itoa(len(accounts)),
+			}
+		}
+
+		candidates = append(candidates, &trend{
+			target:   target,
+			score:    score,
+			accounts: len(t.latest),
+			uses:     t.uses,
+			history:  history,
+		})
+	}
+
+	slices.SortFunc(candidates, func(a, b *trend) int {
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+
+		// Tie-break deterministically.
+		return cmp.Compare(a.target, b.target)
+	})
+
+	return candidates, nil
+}
+
+// selectTrends selects up to trendsMaxItems of the given candidates that
+// are currently trendable, ensuring each has an admin review. Items that
+// were rejected by an admin are kept, but don't count toward the limit.
+func (p *Processor) selectTrends(
+	ctx context.Context,
+	trendType gtsmodel.TrendType,
+	candidates []*trend,
+	trendable func(string) (bool, error),
+) ([]*trend, error) {
+	var (
+		trends []*trend
+		count  int
+	)
+
+	for _, t := range candidates {
+		if count == trendsMaxItems {
+			break
+		}
+
+		ok, err := trendable(t.target)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+
+		if !ok {
+			// Missing or not
+			// trendable, skip.
+			continue
+		}
+
+		t.review, err = p.getOrCreateReview(ctx, trendType, t.target)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.review.State != gtsmodel.TrendReviewStateRejected {
+			count++
+		}
+
+		trends = append(trends, t)
+	}
+
+	return trends, nil
+}
+
+// getOrCreateReview gets the review of the given trending item,
+// creating a pending review first if it hasn't trended before.
+func (p *Processor) getOrCreateReview(
+	ctx context.Context,
+	trendType gtsmodel.TrendType,
+	target string,
+) (*gtsmodel.TrendReview, error) {
+	review, err := p.state.DB.GetTrendReview(ctx, trendType, target)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting %s trend review: %w", trendType, err)
+	}
+
+	if review != nil {
+		return review, nil
+	}
+
+	review = &gtsmodel.TrendReview{
+		ID:        id.NewULID(),
+		TrendType: trendType,
+		Target:    target,
+		State:     gtsmodel.TrendReviewStatePending,
+	}
+
+	if err := p.state.DB.PutTrendReview(ctx, review); err != nil {
+		return nil, gtserror.Newf("db error putting %s trend review: %w", trendType, err)
+	}
+
+	return review, nil
+}
+
+// linkActivity returns one activity per link posted in a
+// public status with ID greater than sinceID, with the link
+// URL as target. Each link is counted once per status.
+func (p *Processor) linkActivity(
+	ctx context.Context,
+	sinceID string,
+) ([]*gtsmodel.TrendActivity, error) {
+	statusActivity, err := p.state.DB.GetLinkTrendActivity(ctx, sinceID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting link activity: %w", err)
+	}
+
+	if len(statusActivity) == 0 {
+		return nil, nil
+	}
+
+	statusIDs := make([]string, len(statusActivity))
+	for i, a := range statusActivity {
+		statusIDs[i] = a.Target
+	}
+
+	statuses, err := p.state.DB.GetStatusesByIDs(gtscontext.SetBarebones(ctx), statusIDs)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting link statuses: %w", err)
+	}
+
+	content := make(map[string]string, len(statuses))
+	for _, status := range statuses {
+		content[status.ID] = status.Content
+	}
+
+	var activity []*gtsmodel.TrendActivity
+	for _, a := range statusActivity {
+		for _, link := range extractLinks(content[a.Target]) {
+			activity = append(activity, &gtsmodel.TrendActivity{
+				Target:        link,
+				AccountID:     a.AccountID,
+				AccountDomain: a.AccountDomain,
+				CreatedAt:     a.CreatedAt,
+			})
+		}
+	}
+
+	return activity, nil
+}
+
+// page returns up to limit
+// items, starting from offset.
+func page(trends []*trend, limit int, offset int) []*trend {
+	if offset >= len(trends) {
+		return nil
+	}
+
+	trends = trends[offset:]
+	if len(trends) > limit {
+		trends = trends[:limit]
+	}
+
+	return trends
+}
@@ -0,0 +1,149 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// LinksGet returns links that are
+// currently trending on this instance.
+func (p *Processor) LinksGet(
+	ctx context.Context,
+	limit int,
+	offset int,
+) ([]*apimodel.TrendsLink, gtserror.WithCode) {
+	trends := p.getTrends(gtsmodel.TrendTypeLink, false)
+
+	trends = page(trends, limit, offset)
+	apiLinks := make([]*apimodel.TrendsLink, 0, len(trends))
+	for _, t := range trends {
+		apiLink, err := p.apiLink(ctx, t)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if apiLink == nil {
+			// No preview
+			// card (yet).
+			continue
+		}
+
+		apiLinks = append(apiLinks, apiLink)
+	}
+
+	return apiLinks, nil
+}
+
+// apiLink converts the given trending link to its
+// API model, using the stored preview card of the
+// link. If there's no card for the link, it returns nil.
+func (p *Processor) apiLink(
+	ctx context.Context,
+	t *trend,
+) (*apimodel.TrendsLink, error) {
+	card, err := p.state.DB.GetCardByURL(ctx, t.target)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, nil
+		}
+		return nil, gtserror.Newf("db error getting card %s: %w", t.target, err)
+	}
+
+	return &apimodel.TrendsLink{
+		Card:    p.converter.CardToAPICard(ctx, card),
+		History: t.history,
+	}, nil
+}
+
+// extractLinks returns http(s) links found in the given
+// status HTML content, without duplicates or fragments.
+// Mention and hashtag links are not included.
+func extractLinks(content string) []string {
+	var (
+		links []string
+		z     = html.NewTokenizer(strings.NewReader(content))
+	)
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			// End of content.
+			return links
+
+		case html.StartTagToken:
+			token := z.Token()
+			if token.DataAtom != atom.A {
+				continue
+			}
+
+			link := linkFromAnchor(token)
+			if link != "" && !slices.Contains(links, link) {
+				links = append(links, link)
+			}
+		}
+	}
+}
+
+// linkFromAnchor returns the normalized href of
+// the given anchor token, or an empty string if
+// it's not an http(s) link or is a mention/hashtag.
+func linkFromAnchor(token html.Token) string {
+	var href string
+	for _, attr := range token.Attr {
+		switch attr.Key {
+		case "href":
+			href = attr.Val
+
+		case "class":
+			for _, class := range strings.Fields(attr.Val) {
+				if class == "mention" || class == "hashtag" {
+					return ""
+				}
+			}
+
+		case "rel":
+			if slices.Contains(strings.Fields(attr.Val), "tag") {
+				return ""
+			}
+		}
+	}
+
+	u, err := url.Parse(href)
+	if err != nil ||
+		(u.Scheme != "http" && u.Scheme != "https") ||
+		u.Host == "" {
+		return ""
+	}
+
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/trends"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type LinksTestSuite struct {
+	TrendsTestSuite
+}
+
+func (suite *LinksTestSuite) TestTrendingLinks() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx       = context.Background()
+		state     = testStructs.State
+		adminAcct = suite.testAccounts["admin_account"]
+		p         = trends.New(state, testStructs.TypeConverter, visibility.NewFilter(state))
+	)
+
+	// Two accounts post the same link, one with a fragment
+	// and one without. Hashtag and mention links shouldn't
+	// count, even though they're also posted by both.
+	for _, content := range []string{
+		`<p>read this <a href="https://news.example.com/article#comments" rel="nofollow noreferrer noopener" target="_blank">https://news.example.com/article</a> <a href="http://localhost:8080/tags/welcome" class="mention hashtag" rel="tag nofollow noreferrer noopener" target="_blank">#<span>welcome</span></a></p>`,
+		`<p><span class="h-card"><a href="http://localhost:8080/@the_mighty_zork" class="u-url mention">@<span>the_mighty_zork</span></a></span> did you see <a href="https://news.example.com/article">this</a>?</p>`,
+	} {
+		for _, acct := range []*gtsmodel.Account{
+			suite.testAccounts["local_account_1"],
+			suite.testAccounts["local_account_2"],
+		} {
+			suite.putStatus(state, acct, content, false)
+		}
+	}
+
+	if err := p.Calculate(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Without a preview card,
+	// the link isn't shown.
+	apiTrends, errWithCode := p.AdminTrendsGet(ctx, adminAcct, gtsmodel.TrendTypeLink)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiTrends)
+
+	if err := state.DB.PutCard(ctx, &gtsmodel.Card{
+		ID:           id.NewULID(),
+		URL:          "https://news.example.com/article",
+		Type:         gtsmodel.CardTypeLink,
+		Title:        "Big news!",
+		Description:  "Something happened.",
+		ProviderName: "Example News",
+		ProviderURL:  "https://news.example.com",
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiTrends, errWithCode = p.AdminTrendsGet(ctx, adminAcct, gtsmodel.TrendTypeLink)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if !suite.Len(apiTrends, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal("link", apiTrends[0].Type)
+	suite.Equal(2, apiTrends[0].Accounts)
+	suite.Equal(4, apiTrends[0].Uses)
+	suite.Equal("https://news.example.com/article", apiTrends[0].Link.URL)
+
+	if _, errWithCode := p.AdminTrendApprove(ctx, adminAcct, apiTrends[0].ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	apiLinks, errWithCode := p.LinksGet(ctx, 10, 0)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if !suite.Len(apiLinks, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal("https://news.example.com/article", apiLinks[0].URL)
+	suite.Equal("Big news!", apiLinks[0].Title)
+	suite.Equal("Something happened.", apiLinks[0].Description)
+	suite.Equal("link", apiLinks[0].Type)
+	suite.Equal("Example News", apiLinks[0].ProviderName)
+	suite.Equal("https://news.example.com", apiLinks[0].ProviderURL)
+	suite.Equal("4", apiLinks[0].History[0].Uses)
+	suite.Equal("2", apiLinks[0].History[0].Accounts)
+}
+
+func TestLinksTestSuite(t *testing.T) {
+	suite.Run(t, &LinksTestSuite{})
+}
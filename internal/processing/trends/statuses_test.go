@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/trends"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusesTestSuite struct {
+	TrendsTestSuite
+}
+
+func (suite *StatusesTestSuite) TestTrendingStatuses() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx       = context.Background()
+		state     = testStructs.State
+		adminAcct = suite.testAccounts["admin_account"]
+		p         = trends.New(state, testStructs.TypeConverter, visibility.NewFilter(state))
+	)
+
+	// Two accounts fave a status, so it trends.
+	status := suite.putStatus(state, suite.testAccounts["local_account_1"], "<p>good post</p>", false)
+	suite.putFave(state, suite.testAccounts["local_account_2"], status)
+	suite.putFave(state, adminAcct, status)
+
+	// Sensitive statuses never trend.
+	sensitive := suite.putStatus(state, suite.testAccounts["local_account_1"], "<p>spicy post</p>", true)
+	suite.putFave(state, suite.testAccounts["local_account_2"], sensitive)
+	suite.putFave(state, adminAcct, sensitive)
+
+	if err := p.Calculate(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiTrends, errWithCode := p.AdminTrendsGet(ctx, adminAcct, gtsmodel.TrendTypeStatus)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if !suite.Len(apiTrends, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal("status", apiTrends[0].Type)
+	suite.Equal("pending", apiTrends[0].State)
+	suite.Equal(2, apiTrends[0].Accounts)
+	suite.Equal(status.ID, apiTrends[0].Status.ID)
+
+	// Not yet approved.
+	apiStatuses, errWithCode := p.StatusesGet(ctx, nil, 20, 0)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiStatuses)
+
+	if _, errWithCode := p.AdminTrendApprove(ctx, adminAcct, apiTrends[0].ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Approved, so should be
+	// visible even when logged out.
+	apiStatuses, errWithCode = p.StatusesGet(ctx, nil, 20, 0)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if !suite.Len(apiStatuses, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal(status.ID, apiStatuses[0].ID)
+	suite.Equal("<p>good post</p>", apiStatuses[0].Content)
+}
+
+func TestStatusesTestSuite(t *testing.T) {
+	suite.Run(t, &StatusesTestSuite{})
+}
@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// TagsGet returns hashtags that are currently trending on
+// this instance. Requester may be nil, but if not, tags
+// will include whether they're followed by the requester.
+func (p *Processor) TagsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+	offset int,
+) ([]*apimodel.Tag, gtserror.WithCode) {
+	trends := p.getTrends(gtsmodel.TrendTypeTag, false)
+
+	trends = page(trends, limit, offset)
+	apiTags := make([]*apimodel.Tag, 0, len(trends))
+	for _, t := range trends {
+		apiTag, err := p.apiTag(ctx, requester, t)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if apiTag == nil {
+			// Tag
+			// deleted.
+			continue
+		}
+
+		apiTags = append(apiTags, apiTag)
+	}
+
+	return apiTags, nil
+}
+
+// apiTag converts the given trending tag to its API
+// model, including its history. If the tag no longer
+// exists, it returns nil.
+func (p *Processor) apiTag(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	t *trend,
+) (*apimodel.Tag, error) {
+	tag, err := p.state.DB.GetTag(ctx, t.target)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, nil
+		}
+		return nil, gtserror.Newf("db error getting tag %s: %w", t.target, err)
+	}
+
+	var following *bool
+	if requester != nil {
+		f, err := p.state.DB.IsAccountFollowingTag(ctx, requester.ID, tag.ID)
+		if err != nil {
+			return nil, gtserror.Newf("db error checking if following tag %s: %w", tag.ID, err)
+		}
+		following = &f
+	}
+
+	apiTag, err := p.converter.TagToAPITag(ctx, tag, true, following)
+	if err != nil {
+		return nil, gtserror.Newf("error converting tag %s: %w", tag.ID, err)
+	}
+
+	history := t.history
+	apiTag.History = &history
+	return &apiTag, nil
+}
@@ -0,0 +1,171 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/trends"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TagsTestSuite struct {
+	TrendsTestSuite
+}
+
+func (suite *TagsTestSuite) TestTrendingTags() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx       = context.Background()
+		state     = testStructs.State
+		adminAcct = suite.testAccounts["admin_account"]
+		p         = trends.New(state, testStructs.TypeConverter, visibility.NewFilter(state))
+	)
+
+	// Two accounts use #welcome,
+	// once twice, so it trends.
+	welcome := suite.testTags["welcome"]
+	suite.putStatus(state, suite.testAccounts["local_account_1"], "hello", false, welcome)
+	suite.putStatus(state, suite.testAccounts["local_account_1"], "hello again", false, welcome)
+	suite.putStatus(state, suite.testAccounts["local_account_2"], "hi", false, welcome)
+
+	// Three accounts use #hashtag, but one is on a
+	// blocked domain, and another is silenced, so
+	// it shouldn't trend.
+	hashtag := suite.testTags["Hashtag"]
+	remoteAcct := suite.testAccounts["remote_account_1"]
+	silencedAcct := suite.testAccounts["remote_account_2"]
+	suite.putStatus(state, suite.testAccounts["local_account_1"], "hmm", false, hashtag)
+	suite.putStatus(state, remoteAcct, "hmm", false, hashtag)
+	suite.putStatus(state, silencedAcct, "hmm", false, hashtag)
+
+	if err := state.DB.CreateDomainBlock(ctx, &gtsmodel.DomainBlock{
+		ID:                 id.NewULID(),
+		Domain:             remoteAcct.Domain,
+		CreatedByAccountID: adminAcct.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	silencedAcct.SilencedAt = time.Now()
+	if err := state.DB.UpdateAccount(ctx, silencedAcct, "silenced_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Trends are only calculated in the background,
+	// so until then, nothing is trending even for admins.
+	apiTrends, errWithCode := p.AdminTrendsGet(ctx, adminAcct, gtsmodel.TrendTypeTag)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiTrends)
+
+	if err := p.Calculate(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Nothing has been approved yet, so
+	// nothing should be trending for users.
+	apiTags, errWithCode := p.TagsGet(ctx, nil, 10, 0)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiTags)
+
+	// Admin should see #welcome awaiting review.
+	apiTrends, errWithCode = p.AdminTrendsGet(ctx, adminAcct, gtsmodel.TrendTypeTag)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if !suite.Len(apiTrends, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal("tag", apiTrends[0].Type)
+	suite.Equal("pending", apiTrends[0].State)
+	suite.Equal(2, apiTrends[0].Accounts)
+	suite.Equal(3, apiTrends[0].Uses)
+	suite.Equal("welcome", apiTrends[0].Tag.Name)
+
+	// Approve #welcome.
+	apiTrend, errWithCode := p.AdminTrendApprove(ctx, adminAcct, apiTrends[0].ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("approved", apiTrend.State)
+
+	// Now it should trend, with history.
+	apiTags, errWithCode = p.TagsGet(ctx, suite.testAccounts["local_account_2"], 10, 0)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if !suite.Len(apiTags, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal("welcome", apiTags[0].Name)
+	suite.False(*apiTags[0].Following)
+	history := *apiTags[0].History
+	if !suite.Len(history, 7) {
+		suite.FailNow("")
+	}
+	suite.Equal("3", history[0].Uses)
+	suite.Equal("2", history[0].Accounts)
+	suite.Equal("0", history[6].Uses)
+
+	// Paging past the end should give nothing.
+	apiTags, errWithCode = p.TagsGet(ctx, nil, 10, 1)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiTags)
+
+	// Reject #welcome, it should stop trending.
+	apiTrend, errWithCode = p.AdminTrendReject(ctx, adminAcct, apiTrends[0].ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("rejected", apiTrend.State)
+
+	apiTags, errWithCode = p.TagsGet(ctx, nil, 10, 0)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiTags)
+
+	// And stay rejected once recalculated.
+	if err := p.Calculate(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiTags, errWithCode = p.TagsGet(ctx, nil, 10, 0)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiTags)
+}
+
+func TestTagsTestSuite(t *testing.T) {
+	suite.Run(t, &TagsTestSuite{})
+}
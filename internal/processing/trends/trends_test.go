@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+const (
+	rMediaPath    = "../../../testrig/media"
+	rTemplatePath = "../../../web/template"
+)
+
+type TrendsTestSuite struct {
+	suite.Suite
+
+	testAccounts map[string]*gtsmodel.Account
+	testTags     map[string]*gtsmodel.Tag
+}
+
+func (suite *TrendsTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testTags = testrig.NewTestTags()
+}
+
+// putStatus puts a new public status by the given account in
+// the database, as if it had just been created. Test statuses
+// are all too old to trend, so trends tests use these instead.
+func (suite *TrendsTestSuite) putStatus(
+	state *state.State,
+	account *gtsmodel.Account,
+	content string,
+	sensitive bool,
+	tags ...*gtsmodel.Tag,
+) *gtsmodel.Status {
+	statusID := id.NewULID()
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		CreatedAt:           time.Now(),
+		UpdatedAt:           time.Now(),
+		URI:                 account.URI + "/statuses/" + statusID,
+		URL:                 account.URL + "/statuses/" + statusID,
+		Content:             content,
+		Local:               util.Ptr(account.IsLocal()),
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		Visibility:          gtsmodel.VisibilityPublic,
+		Sensitive:           &sensitive,
+		ActivityStreamsType: ap.ObjectNote,
+		Federated:           util.Ptr(true),
+	}
+
+	for _, tag := range tags {
+		status.TagIDs = append(status.TagIDs, tag.ID)
+	}
+
+	if err := state.DB.PutStatus(context.Background(), status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return status
+}
+
+// putFave puts a new fave of the given
+// status by the given account in the database.
+func (suite *TrendsTestSuite) putFave(
+	state *state.State,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+) {
+	faveID := id.NewULID()
+	if err := state.DB.PutStatusFave(context.Background(), &gtsmodel.StatusFave{
+		ID:              faveID,
+		AccountID:       account.ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+		URI:             account.URI + "/faves/" + faveID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
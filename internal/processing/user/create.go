@@ -27,8 +27,10 @@
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/oauth2/v4"
 )
 
@@ -49,6 +51,25 @@ func (p *Processor) Create(
 		regBacklog  = 20
 	)
 
+	// If signing up with an invite, ensure
+	// it's still usable. Lock on the code so
+	// concurrent sign-ups can't exceed max uses.
+	var invite *gtsmodel.Invite
+	if form.InviteCode != "" {
+		unlock := p.state.ProcessingLocks.Lock("invite:" + form.InviteCode)
+		defer unlock()
+
+		var errWithCode gtserror.WithCode
+		invite, errWithCode = p.InviteGetByCode(ctx, form.InviteCode)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	// Sign-ups with an auto-approving
+	// invite skip the approval backlog.
+	preApproved := invite != nil && util.PtrOrZero(invite.AutoApprove)
+
 	// Ensure no more than usersPerDay
 	// have registered in the last 24h.
 	newUsersCount, err := p.state.DB.CountApprovedSignupsSince(ctx, time.Now().Add(-24*time.Hour))
@@ -63,15 +84,17 @@ func (p *Processor) Create(
 	}
 
 	// Ensure the new users backlog isn't full.
-	backlogLen, err := p.state.DB.CountUnhandledSignups(ctx)
-	if err != nil {
-		err := fmt.Errorf("db error counting registration backlog length: %w", err)
-		return nil, gtserror.NewErrorInternalError(err)
-	}
+	if !preApproved {
+		backlogLen, err := p.state.DB.CountUnhandledSignups(ctx)
+		if err != nil {
+			err := fmt.Errorf("db error counting registration backlog length: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
 
-	if backlogLen >= regBacklog {
-		err := fmt.Errorf("this instance's sign-up backlog is currently full; you must wait until pending sign-ups are handled by the admin(s)")
-		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		if backlogLen >= regBacklog {
+			err := fmt.Errorf("this instance's sign-up backlog is currently full; you must wait until pending sign-ups are handled by the admin(s)")
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
 	}
 
 	emailAvailable, err := p.state.DB.IsEmailAvailable(ctx, form.Email)
@@ -109,20 +132,37 @@ func (p *Processor) Create(
 		}
 	}
 
-	user, err := p.state.DB.NewSignup(ctx, gtsmodel.NewSignup{
-		Username: form.Username,
-		Email:    form.Email,
-		Password: form.Password,
-		Reason:   text.SanitizeToPlaintext(reason),
-		SignUpIP: form.IP,
-		Locale:   form.Locale,
-		AppID:    app.ID,
-	})
+	newSignup := gtsmodel.NewSignup{
+		Username:    form.Username,
+		Email:       form.Email,
+		Password:    form.Password,
+		Reason:      text.SanitizeToPlaintext(reason),
+		PreApproved: preApproved,
+		SignUpIP:    form.IP,
+		Locale:      form.Locale,
+		AppID:       app.ID,
+	}
+
+	if invite != nil {
+		newSignup.InviteID = invite.ID
+	}
+
+	user, err := p.state.DB.NewSignup(ctx, newSignup)
 	if err != nil {
 		err := fmt.Errorf("db error creating new signup: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if invite != nil {
+		// Count this use of the invite. The user
+		// is already created at this point so
+		// just log if something goes wrong.
+		invite.Uses++
+		if err := p.state.DB.UpdateInvite(ctx, invite, "uses"); err != nil {
+			log.Errorf(ctx, "db error updating invite uses: %v", err)
+		}
+	}
+
 	// There are side effects for creating a new user+account
 	// (confirmation emails etc), perform these async.
 	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
@@ -0,0 +1,224 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// inviteCodeLen is the number of random
+// bytes used to generate an invite code.
+const inviteCodeLen = 6
+
+// InvitesGet returns a page of invites
+// created by the given account.
+func (p *Processor) InvitesGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	invites, err := p.state.DB.GetInvitesByAccountID(ctx, account.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invites: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(invites)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = invites[count-1].ID
+		hi = invites[0].ID
+
+		// Best-guess items length.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, invite := range invites {
+		items = append(items, p.converter.InviteToAPIInvite(invite))
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/invites",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// InviteCreate creates a new invite for the given
+// account, if the instance invite role allows it.
+func (p *Processor) InviteCreate(
+	ctx context.Context,
+	user *gtsmodel.User,
+	account *gtsmodel.Account,
+	form *apimodel.InviteCreateRequest,
+) (*apimodel.Invite, gtserror.WithCode) {
+	var (
+		admin     = util.PtrOrZero(user.Admin)
+		moderator = admin || util.PtrOrZero(user.Moderator)
+	)
+
+	// Check if this user is
+	// allowed to invite people.
+	var canInvite bool
+	switch config.GetAccountsInviteRole() {
+	case config.AccountsInviteRoleUser:
+		canInvite = true
+	case config.AccountsInviteRoleModerator:
+		canInvite = moderator
+	}
+
+	if !canInvite {
+		const text = "you are not permitted to create invites on this instance"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	if form.AutoApprove && !moderator {
+		const text = "only moderators and admins can create auto-approving invites"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	if form.MaxUses < 0 {
+		const text = "max_uses must not be negative"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if form.ExpiresIn < 0 {
+		const text = "expires_in must not be negative"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	code, err := newInviteCode()
+	if err != nil {
+		err := gtserror.Newf("error generating invite code: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	invite := &gtsmodel.Invite{
+		ID:          id.NewULID(),
+		Code:        code,
+		AccountID:   account.ID,
+		Account:     account,
+		MaxUses:     form.MaxUses,
+		AutoApprove: &form.AutoApprove,
+	}
+
+	if form.ExpiresIn > 0 {
+		expiresIn := time.Duration(form.ExpiresIn) * time.Second
+		invite.ExpiresAt = time.Now().Add(expiresIn)
+	}
+
+	if err := p.state.DB.PutInvite(ctx, invite); err != nil {
+		err := gtserror.Newf("db error putting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.InviteToAPIInvite(invite), nil
+}
+
+// InviteExpire expires the invite with the given ID
+// owned by the given account, so that it can no longer
+// be used to sign up. The expired invite is returned.
+func (p *Processor) InviteExpire(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	id string,
+) (*apimodel.Invite, gtserror.WithCode) {
+	invite, err := p.state.DB.GetInviteByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil || invite.AccountID != account.ID {
+		const text = "invite not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	if !invite.Expired() {
+		invite.ExpiresAt = time.Now()
+		if err := p.state.DB.UpdateInvite(ctx, invite, "expires_at"); err != nil {
+			err := gtserror.Newf("db error updating invite: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.converter.InviteToAPIInvite(invite), nil
+}
+
+// InviteGetByCode returns the invite with the given
+// code, if it can still be used to sign up. If not,
+// an unprocessable entity error is returned.
+func (p *Processor) InviteGetByCode(
+	ctx context.Context,
+	code string,
+) (*gtsmodel.Invite, gtserror.WithCode) {
+	const text = "invite is invalid or has expired"
+
+	invite, err := p.state.DB.GetInviteByCode(ctx, code)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil || !invite.Usable() {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Invites from suspended accounts
+	// shouldn't let anyone in anymore.
+	invite.Account, err = p.state.DB.GetAccountByID(ctx, invite.AccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite.Account == nil || invite.Account.IsSuspended() {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return invite, nil
+}
+
+// newInviteCode returns a new random
+// hex-encoded code for an invite.
+func newInviteCode() (string, error) {
+	b := make([]byte, inviteCodeLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
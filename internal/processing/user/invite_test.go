@@ -0,0 +1,195 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type InviteTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *InviteTestSuite) SetupTest() {
+	suite.UserStandardTestSuite.SetupTest()
+	testrig.StartNoopWorkers(&suite.state)
+}
+
+func (suite *InviteTestSuite) TearDownTest() {
+	testrig.StopWorkers(&suite.state)
+	suite.UserStandardTestSuite.TearDownTest()
+}
+
+func (suite *InviteTestSuite) TestInvitesGet() {
+	var (
+		ctx     = context.Background()
+		account = testrig.NewTestAccounts()["admin_account"]
+	)
+
+	resp, errWithCode := suite.user.InvitesGet(ctx, account, nil)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Items, 2)
+
+	// Newest first.
+	invite, ok := resp.Items[0].(*apimodel.Invite)
+	if !ok {
+		suite.FailNow("", "unexpected type %T", resp.Items[0])
+	}
+	suite.Equal("oldnewsbees", invite.Code)
+	suite.Equal("http://localhost:8080/signup?invite=oldnewsbees", invite.URL)
+	suite.True(invite.Expired)
+	suite.Nil(invite.MaxUses)
+
+	invite = resp.Items[1].(*apimodel.Invite)
+	suite.Equal("buzzbuzzbees", invite.Code)
+	suite.False(invite.Expired)
+	suite.Equal(5, *invite.MaxUses)
+	suite.Equal(1, invite.Uses)
+	suite.True(invite.AutoApprove)
+}
+
+func (suite *InviteTestSuite) TestInviteCreate() {
+	var (
+		ctx     = context.Background()
+		user    = suite.testUsers["admin_account"]
+		account = testrig.NewTestAccounts()["admin_account"]
+	)
+
+	invite, errWithCode := suite.user.InviteCreate(ctx, user, account, &apimodel.InviteCreateRequest{
+		MaxUses:     1,
+		ExpiresIn:   3600,
+		AutoApprove: true,
+	})
+	suite.NoError(errWithCode)
+	suite.Len(invite.Code, 12)
+	suite.NotNil(invite.ExpiresAt)
+	suite.Equal(1, *invite.MaxUses)
+	suite.True(invite.AutoApprove)
+	suite.False(invite.Expired)
+
+	dbInvite, err := suite.db.GetInviteByCode(ctx, invite.Code)
+	suite.NoError(err)
+	suite.Equal(account.ID, dbInvite.AccountID)
+}
+
+func (suite *InviteTestSuite) TestInviteCreateNotPermitted() {
+	var (
+		ctx     = context.Background()
+		user    = suite.testUsers["local_account_1"]
+		account = testrig.NewTestAccounts()["local_account_1"]
+	)
+
+	// Only mods and admins can invite by default.
+	_, errWithCode := suite.user.InviteCreate(ctx, user, account, &apimodel.InviteCreateRequest{})
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// Let users invite people too.
+	config.SetAccountsInviteRole(config.AccountsInviteRoleUser)
+
+	invite, errWithCode := suite.user.InviteCreate(ctx, user, account, &apimodel.InviteCreateRequest{})
+	suite.NoError(errWithCode)
+	suite.Nil(invite.ExpiresAt)
+	suite.Nil(invite.MaxUses)
+
+	// Still only mods and admins can auto approve.
+	_, errWithCode = suite.user.InviteCreate(ctx, user, account, &apimodel.InviteCreateRequest{
+		AutoApprove: true,
+	})
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// Nobody can invite.
+	config.SetAccountsInviteRole(config.AccountsInviteRoleNone)
+
+	_, errWithCode = suite.user.InviteCreate(ctx, suite.testUsers["admin_account"], testrig.NewTestAccounts()["admin_account"], &apimodel.InviteCreateRequest{})
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func (suite *InviteTestSuite) TestInviteExpire() {
+	var (
+		ctx    = context.Background()
+		invite = testrig.NewTestInvites()["admin_account_invite_1"]
+	)
+
+	// Can't expire someone else's invite.
+	_, errWithCode := suite.user.InviteExpire(ctx, testrig.NewTestAccounts()["local_account_1"], invite.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	apiInvite, errWithCode := suite.user.InviteExpire(ctx, testrig.NewTestAccounts()["admin_account"], invite.ID)
+	suite.NoError(errWithCode)
+	suite.True(apiInvite.Expired)
+
+	_, errWithCode = suite.user.InviteGetByCode(ctx, invite.Code)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *InviteTestSuite) TestCreateWithInvite() {
+	var (
+		ctx    = context.Background()
+		invite = testrig.NewTestInvites()["admin_account_invite_1"]
+	)
+
+	user, errWithCode := suite.user.Create(ctx, nil, &apimodel.AccountCreateRequest{
+		Username:   "invited",
+		Email:      "invited@example.org",
+		Password:   "a very strong password indeed",
+		Agreement:  true,
+		Locale:     "en",
+		InviteCode: invite.Code,
+		IP:         net.ParseIP("192.0.2.1"),
+	})
+	suite.NoError(errWithCode)
+	suite.Equal(invite.ID, user.InviteID)
+
+	// Invite auto approves.
+	suite.True(*user.Approved)
+
+	dbInvite, err := suite.db.GetInviteByID(ctx, invite.ID)
+	suite.NoError(err)
+	suite.Equal(2, dbInvite.Uses)
+}
+
+func (suite *InviteTestSuite) TestCreateWithExpiredInvite() {
+	var (
+		ctx    = context.Background()
+		invite = testrig.NewTestInvites()["admin_account_invite_2_expired"]
+	)
+
+	_, errWithCode := suite.user.Create(ctx, nil, &apimodel.AccountCreateRequest{
+		Username:   "invited",
+		Email:      "invited@example.org",
+		Password:   "a very strong password indeed",
+		Agreement:  true,
+		Locale:     "en",
+		InviteCode: invite.Code,
+		IP:         net.ParseIP("192.0.2.1"),
+	})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("Unprocessable Entity: invite is invalid or has expired", errWithCode.Safe())
+}
+
+func TestInviteTestSuite(t *testing.T) {
+	suite.Run(t, new(InviteTestSuite))
+}
@@ -0,0 +1,183 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// TokensGetPage returns a page of
+// access tokens owned by the given user.
+func (p *Processor) TokensGetPage(
+	ctx context.Context,
+	user *gtsmodel.User,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	tokens, err := p.state.DB.GetAccessTokensByUserID(ctx, user.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tokens: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(tokens)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = tokens[count-1].ID
+		hi = tokens[0].ID
+
+		// Best-guess items length.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, token := range tokens {
+		tokenInfo, err := p.converter.TokenToAPITokenInfo(ctx, token)
+		if err != nil {
+			log.Errorf(ctx, "error converting token to api token info: %v", err)
+			continue
+		}
+
+		// Append token info to return items.
+		items = append(items, tokenInfo)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/tokens",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// TokenGet returns the access token
+// with the given ID, owned by the given user.
+func (p *Processor) TokenGet(
+	ctx context.Context,
+	user *gtsmodel.User,
+	id string,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	token, errWithCode := p.getOwnToken(ctx, user, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiTokenInfo(ctx, token)
+}
+
+// TokenInvalidate deletes the access token with the
+// given ID owned by the given user, so that it can no
+// longer be used. The deleted token's info is returned.
+func (p *Processor) TokenInvalidate(
+	ctx context.Context,
+	user *gtsmodel.User,
+	id string,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	token, errWithCode := p.getOwnToken(ctx, user, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	tokenInfo, errWithCode := p.apiTokenInfo(ctx, token)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteTokenByID(ctx, token.ID); err != nil {
+		err := gtserror.Newf("db error deleting token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return tokenInfo, nil
+}
+
+// TokensInvalidateAll deletes all access tokens owned by the
+// given user, except for the token with the given access value
+// (ie., the token being used to make the request), if set.
+func (p *Processor) TokensInvalidateAll(
+	ctx context.Context,
+	user *gtsmodel.User,
+	keepAccess string,
+) gtserror.WithCode {
+	// No paging; get all tokens.
+	tokens, err := p.state.DB.GetAccessTokensByUserID(ctx, user.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tokens: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	for _, token := range tokens {
+		if keepAccess != "" && token.Access == keepAccess {
+			// Leave this one.
+			continue
+		}
+
+		if err := p.state.DB.DeleteTokenByID(ctx, token.ID); err != nil {
+			err := gtserror.Newf("db error deleting token %s: %w", token.ID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// getOwnToken gets the access token with the given
+// ID, returning 404 if it's not owned by user.
+func (p *Processor) getOwnToken(
+	ctx context.Context,
+	user *gtsmodel.User,
+	id string,
+) (*gtsmodel.Token, gtserror.WithCode) {
+	token, err := p.state.DB.GetTokenByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if token == nil || token.UserID != user.ID || token.Access == "" {
+		const text = "token not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return token, nil
+}
+
+// apiTokenInfo wraps converting token to API model.
+func (p *Processor) apiTokenInfo(
+	ctx context.Context,
+	token *gtsmodel.Token,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	tokenInfo, err := p.converter.TokenToAPITokenInfo(ctx, token)
+	if err != nil {
+		err := gtserror.Newf("error converting token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return tokenInfo, nil
+}
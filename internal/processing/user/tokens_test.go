@@ -0,0 +1,142 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TokensTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *TokensTestSuite) TestTokensGet() {
+	var (
+		ctx  = context.Background()
+		user = suite.testUsers["local_account_1"]
+	)
+
+	resp, errWithCode := suite.user.TokensGetPage(ctx, user, nil)
+	suite.NoError(errWithCode)
+
+	// Only the access token should be included,
+	// not the app token or the authorization code.
+	suite.Len(resp.Items, 1)
+	tokenInfo, ok := resp.Items[0].(*apimodel.TokenInfo)
+	if !ok {
+		suite.FailNow("", "unexpected type %T", resp.Items[0])
+	}
+
+	suite.Equal("01F8MGTQW4DKTDF8SW5CT9HYGA", tokenInfo.ID)
+	suite.Equal("2022-06-10T15:22:08.000Z", tokenInfo.CreatedAt)
+	suite.Empty(tokenInfo.LastUsed)
+	suite.Equal("read write follow push", tokenInfo.Scope)
+	suite.Equal("really cool gts application", tokenInfo.Application.Name)
+	suite.Equal("https://reallycool.app", tokenInfo.Application.Website)
+}
+
+func (suite *TokensTestSuite) TestTokenGetNotOwn() {
+	var (
+		ctx   = context.Background()
+		user  = suite.testUsers["local_account_1"]
+		token = testrig.NewTestTokens()["local_account_2"]
+	)
+
+	_, errWithCode := suite.user.TokenGet(ctx, user, token.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *TokensTestSuite) TestTokenInvalidate() {
+	var (
+		ctx   = context.Background()
+		user  = suite.testUsers["local_account_1"]
+		token = testrig.NewTestTokens()["local_account_1"]
+	)
+
+	tokenInfo, errWithCode := suite.user.TokenInvalidate(ctx, user, token.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(token.ID, tokenInfo.ID)
+
+	// Token should be gone.
+	_, err := suite.db.GetTokenByID(ctx, token.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *TokensTestSuite) TestTokensInvalidateAll() {
+	var (
+		ctx   = context.Background()
+		user  = suite.testUsers["local_account_1"]
+		token = testrig.NewTestTokens()["local_account_1"]
+	)
+
+	// Add another couple of tokens for this user.
+	for _, t := range []*gtsmodel.Token{
+		{
+			ID:             "01JG3Y1V6W6Y3N0QG3WCKH8KZP",
+			ClientID:       token.ClientID,
+			UserID:         user.ID,
+			RedirectURI:    token.RedirectURI,
+			Scope:          "read",
+			Access:         "SOMEOTHERACCESSTOKEN1",
+			AccessCreateAt: time.Now(),
+			LastUsed:       time.Now(),
+		},
+		{
+			ID:             "01JG3Y2A4KX0CDS4Y7JZ1GJ6VR",
+			ClientID:       token.ClientID,
+			UserID:         user.ID,
+			RedirectURI:    token.RedirectURI,
+			Scope:          "write",
+			Access:         "SOMEOTHERACCESSTOKEN2",
+			AccessCreateAt: time.Now(),
+		},
+	} {
+		if err := suite.db.PutToken(ctx, t); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	resp, errWithCode := suite.user.TokensGetPage(ctx, user, nil)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Items, 3)
+
+	// Invalidate all but the current token.
+	errWithCode = suite.user.TokensInvalidateAll(ctx, user, token.Access)
+	suite.NoError(errWithCode)
+
+	resp, errWithCode = suite.user.TokensGetPage(ctx, user, nil)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Items, 1)
+
+	// The remaining one should be the current one.
+	_, err := suite.db.GetTokenByID(ctx, token.ID)
+	suite.NoError(err)
+}
+
+func TestTokensTestSuite(t *testing.T) {
+	suite.Run(t, new(TokensTestSuite))
+}
@@ -0,0 +1,331 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // #nosec G505 -- SHA1 is what TOTP authenticator apps expect.
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	totpPeriod        = 30 // Seconds per TOTP time step.
+	totpDigits        = 6  // Digits per TOTP code.
+	totpSkew          = 1  // Time steps either side of now to accept.
+	totpSecretLen     = 20 // Bytes of randomness in TOTP secrets.
+	recoveryCodeCount = 8  // Number of one-time recovery codes.
+	recoveryCodeLen   = 6  // Bytes of randomness per recovery code.
+)
+
+// b32 is the encoding used for TOTP secrets,
+// as expected by common authenticator apps.
+var b32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TwoFactorSecretCreate generates and stores a new TOTP secret
+// for the given user, after checking that the given password is
+// correct, returning the secret and a provisioning URI. 2FA is not
+// enabled until TwoFactorEnable is called with a valid code
+// generated using the secret.
+func (p *Processor) TwoFactorSecretCreate(
+	ctx context.Context,
+	user *gtsmodel.User,
+	password string,
+) (*apimodel.TwoFactorSecret, gtserror.WithCode) {
+	if user.TwoFactorEnabled() {
+		const text = "two-factor authentication is already enabled"
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	if errWithCode := twoFactorPasswordCheck(user, password); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	secretB := make([]byte, totpSecretLen)
+	if _, err := rand.Read(secretB); err != nil {
+		err := gtserror.Newf("error generating secret: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	user.TwoFactorSecret = b32.EncodeToString(secretB)
+	if err := p.state.DB.UpdateUser(ctx, user, "two_factor_secret"); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	account, err := p.state.DB.GetAccountByID(ctx, user.AccountID)
+	if err != nil {
+		err := gtserror.Newf("db error getting account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.TwoFactorSecret{
+		Secret: user.TwoFactorSecret,
+		URI:    totpURI(user.TwoFactorSecret, account.Username),
+	}, nil
+}
+
+// TwoFactorEnable confirms the user's previously created TOTP
+// secret using the given code, after checking that the given
+// password is correct, then enables 2FA for the user. One-time
+// recovery codes are returned, which won't be shown again.
+func (p *Processor) TwoFactorEnable(
+	ctx context.Context,
+	user *gtsmodel.User,
+	password string,
+	code string,
+) (*apimodel.TwoFactorRecoveryCodes, gtserror.WithCode) {
+	if user.TwoFactorEnabled() {
+		const text = "two-factor authentication is already enabled"
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	if user.TwoFactorSecret == "" {
+		const text = "no two-factor secret has been created yet"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if errWithCode := twoFactorPasswordCheck(user, password); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	step, ok := totpValidate(user.TwoFactorSecret, code, time.Now())
+	if !ok {
+		const text = "two-factor code was incorrect"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		codeB := make([]byte, recoveryCodeLen)
+		if _, err := rand.Read(codeB); err != nil {
+			err := gtserror.Newf("error generating recovery code: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		codes[i] = hex.EncodeToString(codeB)
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(codes[i]), bcrypt.DefaultCost)
+		if err != nil {
+			err := gtserror.Newf("error hashing recovery code: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		hashes[i] = string(hash)
+	}
+
+	user.TwoFactorBackups = hashes
+	user.TwoFactorEnabledAt = time.Now()
+	user.TwoFactorLastStep = step
+	if err := p.state.DB.UpdateUser(ctx,
+		user,
+		"two_factor_backups",
+		"two_factor_enabled_at",
+		"two_factor_last_step",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.TwoFactorRecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// TwoFactorDisable disables 2FA for the given user,
+// after checking that the given password is correct.
+func (p *Processor) TwoFactorDisable(
+	ctx context.Context,
+	user *gtsmodel.User,
+	password string,
+) gtserror.WithCode {
+	if !user.TwoFactorEnabled() {
+		const text = "two-factor authentication is not enabled"
+		return gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	if errWithCode := twoFactorPasswordCheck(user, password); errWithCode != nil {
+		return errWithCode
+	}
+
+	user.TwoFactorSecret = ""
+	user.TwoFactorBackups = nil
+	user.TwoFactorEnabledAt = time.Time{}
+	if err := p.state.DB.UpdateUser(ctx,
+		user,
+		"two_factor_secret",
+		"two_factor_backups",
+		"two_factor_enabled_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// TwoFactorCheck checks the given code for a user with 2FA
+// enabled during sign-in. The code may be either a current
+// TOTP code which hasn't been used already, or one of the
+// user's unused recovery codes, in which case the recovery
+// code is used up.
+func (p *Processor) TwoFactorCheck(
+	ctx context.Context,
+	user *gtsmodel.User,
+	code string,
+) gtserror.WithCode {
+	if !user.TwoFactorEnabled() {
+		// Nothing to check.
+		return nil
+	}
+
+	// Authenticator apps sometimes
+	// display codes with spaces.
+	code = strings.ReplaceAll(code, " ", "")
+
+	if step, ok := totpValidate(user.TwoFactorSecret, code, time.Now()); ok {
+		if step <= user.TwoFactorLastStep {
+			// Don't allow a code to be replayed,
+			// nor an older code to be used after
+			// a newer one, within the skew window.
+			const text = "two-factor code was already used"
+			return gtserror.NewErrorUnauthorized(errors.New(text), text)
+		}
+
+		user.TwoFactorLastStep = step
+		if err := p.state.DB.UpdateUser(ctx, user, "two_factor_last_step"); err != nil {
+			err := gtserror.Newf("db error updating user: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		return nil
+	}
+
+	for i, hash := range user.TwoFactorBackups {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(code)) != nil {
+			continue
+		}
+
+		// Recovery codes are single
+		// use; remove this one now.
+		user.TwoFactorBackups = append(
+			user.TwoFactorBackups[:i:i],
+			user.TwoFactorBackups[i+1:]...,
+		)
+		if err := p.state.DB.UpdateUser(ctx, user, "two_factor_backups"); err != nil {
+			err := gtserror.Newf("db error updating user: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		return nil
+	}
+
+	const text = "two-factor code was incorrect"
+	return gtserror.NewErrorUnauthorized(errors.New(text), text)
+}
+
+// twoFactorPasswordCheck checks that the given
+// password is the user's current password, as
+// required before making any changes to 2FA.
+func twoFactorPasswordCheck(user *gtsmodel.User, password string) gtserror.WithCode {
+	if err := bcrypt.CompareHashAndPassword(
+		[]byte(user.EncryptedPassword),
+		[]byte(password),
+	); err != nil {
+		err := gtserror.Newf("%w", err)
+		return gtserror.NewErrorUnauthorized(err, "password was incorrect")
+	}
+
+	return nil
+}
+
+// totpURI returns an otpauth:// provisioning URI for
+// the given secret and username, using our host as issuer.
+func totpURI(secret string, username string) string {
+	issuer := config.GetHost()
+	query := url.Values{
+		"secret":    {secret},
+		"issuer":    {issuer},
+		"algorithm": {"SHA1"},
+		"digits":    {fmt.Sprint(totpDigits)},
+		"period":    {fmt.Sprint(totpPeriod)},
+	}
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + username,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// totpValidate returns the matching time step and
+// true if code is a valid TOTP code for the given
+// base32 secret at now, allowing for a little clock
+// skew either way.
+func totpValidate(secret string, code string, now time.Time) (int64, bool) {
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	key, err := b32.DecodeString(secret)
+	if err != nil {
+		return 0, false
+	}
+
+	step := now.Unix() / totpPeriod
+	for i := int64(-totpSkew); i <= totpSkew; i++ {
+		want := totpCode(key, uint64(step+i)) // #nosec G115 -- step is positive
+		if subtle.ConstantTimeCompare([]byte(want), []byte(code)) == 1 {
+			return step + i, true
+		}
+	}
+
+	return 0, false
+}
+
+// totpCode generates the HOTP code (RFC 4226)
+// for the given key at the given counter, as
+// used for each time step by TOTP (RFC 6238).
+func totpCode(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation.
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
@@ -0,0 +1,210 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type TwoFactorTestSuite struct {
+	UserStandardTestSuite
+}
+
+// totp independently generates the
+// current RFC 6238 code for secret.
+func totp(secret string) string {
+	return totpAt(secret, time.Now())
+}
+
+// totpAt independently generates the
+// RFC 6238 code for secret at t.
+func totpAt(secret string, t time.Time) string {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		panic(err)
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(t.Unix()/30))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+func (suite *TwoFactorTestSuite) enable(user *gtsmodel.User) []string {
+	secret, errWithCode := suite.user.TwoFactorSecretCreate(context.Background(), user, "password")
+	suite.NoError(errWithCode)
+
+	codes, errWithCode := suite.user.TwoFactorEnable(context.Background(), user, "password", totp(secret.Secret))
+	suite.NoError(errWithCode)
+
+	return codes.RecoveryCodes
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorSecretCreate() {
+	user := suite.testUsers["local_account_1"]
+
+	// Wrong password.
+	_, errWithCode := suite.user.TwoFactorSecretCreate(context.Background(), user, "ooooopsydoooopsy")
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+	suite.Empty(user.TwoFactorSecret)
+
+	secret, errWithCode := suite.user.TwoFactorSecretCreate(context.Background(), user, "password")
+	suite.NoError(errWithCode)
+	suite.Len(secret.Secret, 32)
+
+	uri, err := url.Parse(secret.URI)
+	suite.NoError(err)
+	suite.Equal("otpauth", uri.Scheme)
+	suite.Equal("totp", uri.Host)
+	suite.Equal("/localhost:8080:the_mighty_zork", uri.Path)
+	suite.Equal(secret.Secret, uri.Query().Get("secret"))
+	suite.Equal("localhost:8080", uri.Query().Get("issuer"))
+
+	// Secret should be stored,
+	// but 2FA not yet enabled.
+	dbUser, err := suite.db.GetUserByID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.Equal(secret.Secret, dbUser.TwoFactorSecret)
+	suite.False(dbUser.TwoFactorEnabled())
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorEnableWrongCode() {
+	user := suite.testUsers["local_account_1"]
+
+	_, errWithCode := suite.user.TwoFactorSecretCreate(context.Background(), user, "password")
+	suite.NoError(errWithCode)
+
+	_, errWithCode = suite.user.TwoFactorEnable(context.Background(), user, "password", "000000")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+	suite.False(user.TwoFactorEnabled())
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorEnableWrongPassword() {
+	user := suite.testUsers["local_account_1"]
+
+	secret, errWithCode := suite.user.TwoFactorSecretCreate(context.Background(), user, "password")
+	suite.NoError(errWithCode)
+
+	_, errWithCode = suite.user.TwoFactorEnable(context.Background(), user, "ooooopsydoooopsy", totp(secret.Secret))
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+	suite.False(user.TwoFactorEnabled())
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorEnableNoSecret() {
+	user := suite.testUsers["local_account_1"]
+
+	_, errWithCode := suite.user.TwoFactorEnable(context.Background(), user, "password", "123456")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorEnableAndCheck() {
+	user := suite.testUsers["local_account_1"]
+
+	recoveryCodes := suite.enable(user)
+	suite.Len(recoveryCodes, 8)
+
+	dbUser, err := suite.db.GetUserByID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.True(dbUser.TwoFactorEnabled())
+	suite.Len(dbUser.TwoFactorBackups, 8)
+
+	// Can't create a new secret while enabled.
+	_, errWithCode := suite.user.TwoFactorSecretCreate(context.Background(), dbUser, "password")
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// TOTP code used to enable 2FA was used
+	// up, so it can't be used again to sign in.
+	step := dbUser.TwoFactorLastStep
+	suite.NotZero(step)
+	used := totpAt(dbUser.TwoFactorSecret, time.Unix(step*30, 0))
+	errWithCode = suite.user.TwoFactorCheck(context.Background(), dbUser, used)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	// Next TOTP code should work, as
+	// it's still within the skew window...
+	next := totpAt(dbUser.TwoFactorSecret, time.Unix((step+1)*30, 0))
+	suite.NoError(suite.user.TwoFactorCheck(context.Background(), dbUser, next))
+
+	// ...but only once.
+	errWithCode = suite.user.TwoFactorCheck(context.Background(), dbUser, next)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	dbUser, err = suite.db.GetUserByID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.Equal(step+1, dbUser.TwoFactorLastStep)
+
+	// Wrong code should never work.
+	errWithCode = suite.user.TwoFactorCheck(context.Background(), dbUser, "nope")
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	// Recovery code should work once...
+	suite.NoError(suite.user.TwoFactorCheck(context.Background(), dbUser, recoveryCodes[3]))
+
+	dbUser, err = suite.db.GetUserByID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.Len(dbUser.TwoFactorBackups, 7)
+
+	// ...but not twice.
+	errWithCode = suite.user.TwoFactorCheck(context.Background(), dbUser, recoveryCodes[3])
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	// Other recovery codes still fine.
+	suite.NoError(suite.user.TwoFactorCheck(context.Background(), dbUser, recoveryCodes[0]))
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorDisable() {
+	user := suite.testUsers["local_account_1"]
+	suite.enable(user)
+
+	// Wrong password.
+	errWithCode := suite.user.TwoFactorDisable(context.Background(), user, "ooooopsydoooopsy")
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+	suite.True(user.TwoFactorEnabled())
+
+	// Right password.
+	errWithCode = suite.user.TwoFactorDisable(context.Background(), user, "password")
+	suite.NoError(errWithCode)
+
+	dbUser, err := suite.db.GetUserByID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.False(dbUser.TwoFactorEnabled())
+	suite.Empty(dbUser.TwoFactorSecret)
+	suite.Empty(dbUser.TwoFactorBackups)
+}
+
+func TestTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorTestSuite))
+}
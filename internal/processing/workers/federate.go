@@ -19,15 +19,18 @@
 
 import (
 	"context"
+	"errors"
 	"net/url"
 
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -192,9 +195,94 @@ func (f *federate) CreateStatus(ctx context.Context, status *gtsmodel.Status) er
 	if _, err := f.FederatingActor().Send(ctx, outboxIRI, create); err != nil {
 		return gtserror.Newf("error sending Create activity via outbox %s: %w", outboxIRI, err)
 	}
+
+	// Public statuses are additionally
+	// delivered to any accepted relays.
+	if status.Visibility == gtsmodel.VisibilityPublic {
+		f.deliverToRelays(ctx, status.Account, create)
+	}
+
 	return nil
 }
 
+// deliverToRelays delivers the given activity on behalf of
+// sendingAcct to the inbox of every relay that has accepted
+// our instance actor's Follow. Deliveries are queued on the
+// delivery workers like any other, and their outcome is
+// recorded on the relay once done, for admins to see.
+func (f *federate) deliverToRelays(
+	ctx context.Context,
+	sendingAcct *gtsmodel.Account,
+	t vocab.Type,
+) {
+	relays, err := f.state.DB.GetAcceptedRelays(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting relays: %v", err)
+		return
+	}
+
+	if len(relays) == 0 {
+		// Nothing to do.
+		return
+	}
+
+	tsport, err := f.TransportController().NewTransportForUsername(
+		ctx,
+		sendingAcct.Username,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error getting transport for %s: %v", sendingAcct.Username, err)
+		return
+	}
+
+	m, err := ap.Serialize(t)
+	if err != nil {
+		log.Errorf(ctx, "error serializing activity %T: %v", t, err)
+		return
+	}
+
+	for _, relay := range relays {
+		inboxIRI, err := parseURI(relay.InboxURI)
+		if err != nil {
+			log.Errorf(ctx, "invalid relay inbox: %v", err)
+			continue
+		}
+
+		if err := tsport.DeliverFunc(ctx, m, inboxIRI,
+			func(ctx context.Context, err error) {
+				f.updateRelayError(ctx, relay, err)
+			},
+		); err != nil {
+			log.Errorf(ctx, "error queueing delivery to relay %s: %v", relay.InboxURI, err)
+			f.updateRelayError(ctx, relay, err)
+		}
+	}
+}
+
+// updateRelayError stores the given delivery error
+// (or lack thereof) on relay, if it has changed.
+func (f *federate) updateRelayError(
+	ctx context.Context,
+	relay *gtsmodel.Relay,
+	err error,
+) {
+	var errStr string
+	if err != nil {
+		log.Warnf(ctx, "error delivering to relay %s: %v", relay.InboxURI, err)
+		errStr = err.Error()
+	}
+
+	if relay.Error == errStr {
+		// No change.
+		return
+	}
+
+	relay.Error = errStr
+	if err := f.state.DB.UpdateRelay(ctx, relay, "error"); err != nil {
+		log.Errorf(ctx, "db error updating relay: %v", err)
+	}
+}
+
 func (f *federate) CreatePollVote(ctx context.Context, poll *gtsmodel.Poll, vote *gtsmodel.PollVote) error {
 	// Extract status from poll.
 	status := poll.Status
@@ -266,6 +354,12 @@ func (f *federate) DeleteStatus(ctx context.Context, status *gtsmodel.Status) er
 		)
 	}
 
+	// Relays were sent public statuses,
+	// so they should be told to drop them.
+	if status.Visibility == gtsmodel.VisibilityPublic {
+		f.deliverToRelays(ctx, status.Account, delete)
+	}
+
 	return nil
 }
 
@@ -305,6 +399,12 @@ func (f *federate) UpdateStatus(ctx context.Context, status *gtsmodel.Status) er
 		return gtserror.Newf("error sending Update activity via outbox %s: %w", outboxIRI, err)
 	}
 
+	// Relays were sent public statuses,
+	// so they should be kept up to date.
+	if status.Visibility == gtsmodel.VisibilityPublic {
+		f.deliverToRelays(ctx, status.Account, update)
+	}
+
 	return nil
 }
 
@@ -152,6 +152,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		// ACCEPT BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.AcceptAnnounce(ctx, cMsg)
+
+		// ACCEPT QUOTE
+		case ap.ObjectQuote:
+			return p.clientAPI.AcceptQuote(ctx, cMsg)
 		}
 
 	// REJECT SOMETHING
@@ -177,6 +181,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		// REJECT BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.RejectAnnounce(ctx, cMsg)
+
+		// REJECT QUOTE
+		case ap.ObjectQuote:
+			return p.clientAPI.RejectQuote(ctx, cMsg)
 		}
 
 	// UNDO SOMETHING
@@ -348,6 +356,25 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientA
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
 
+	// Generate a preview card for
+	// any link in the status content.
+	p.federate.FetchStatusCardAsync(ctx, status)
+
+	switch {
+	case status.QuotePendingApproval():
+		// Quote requires approval, create interaction
+		// request for and notify the quoted status
+		// author, if the quoted status is ours.
+		if err := p.utils.requestQuote(ctx, status); err != nil {
+			log.Errorf(ctx, "error pending quote: %v", err)
+		}
+
+	case status.QuoteState == gtsmodel.QuoteStateAccepted:
+		if err := p.surface.notifyQuote(ctx, status); err != nil {
+			log.Errorf(ctx, "error notifying quote: %v", err)
+		}
+	}
+
 	if err := p.federate.CreateStatus(ctx, status); err != nil {
 		log.Errorf(ctx, "error federating status: %v", err)
 	}
@@ -706,6 +733,12 @@ func (p *clientAPI) UpdateStatus(ctx context.Context, cMsg *messages.FromClientA
 		log.Errorf(ctx, "error federating status update: %v", err)
 	}
 
+	// Notify any newly mentioned accounts
+	// (this is a no-op for existing mentions).
+	if err := p.surface.notifyMentions(ctx, status); err != nil {
+		log.Errorf(ctx, "error notifying status mentions: %v", err)
+	}
+
 	if status.Poll != nil && status.Poll.Closing {
 
 		// If the latest status has a newly closed poll, at least compared
@@ -723,6 +756,10 @@ func (p *clientAPI) UpdateStatus(ctx context.Context, cMsg *messages.FromClientA
 	// Status representation has changed, invalidate from timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, status.ID)
 
+	// Links may have changed, update
+	// the status' preview card to match.
+	p.federate.FetchStatusCardAsync(ctx, status)
+
 	return nil
 }
 
@@ -806,20 +843,39 @@ func (p *clientAPI) AcceptFollow(ctx context.Context, cMsg *messages.FromClientA
 }
 
 func (p *clientAPI) RejectFollowRequest(ctx context.Context, cMsg *messages.FromClientAPI) error {
-	followReq, ok := cMsg.GTSModel.(*gtsmodel.FollowRequest)
-	if !ok {
-		return gtserror.Newf("%T not parseable as *gtsmodel.FollowRequest", cMsg.GTSModel)
-	}
+	var follow *gtsmodel.Follow
 
-	// Update stats for the target account.
-	if err := p.utils.decrementFollowRequestsCount(ctx, cMsg.Target); err != nil {
-		log.Errorf(ctx, "error updating account stats: %v", err)
+	switch model := cMsg.GTSModel.(type) {
+
+	// Pending follow request rejected.
+	case *gtsmodel.FollowRequest:
+		// Update stats for the target account.
+		if err := p.utils.decrementFollowRequestsCount(ctx, cMsg.Target); err != nil {
+			log.Errorf(ctx, "error updating account stats: %v", err)
+		}
+
+		follow = p.converter.FollowRequestToFollow(ctx, model)
+
+	// Existing (accepted) follow
+	// rejected, eg., domain block.
+	case *gtsmodel.Follow:
+		// Update stats for the origin account.
+		if err := p.utils.decrementFollowingCount(ctx, cMsg.Origin); err != nil {
+			log.Errorf(ctx, "error updating account stats: %v", err)
+		}
+
+		// Update stats for the target account.
+		if err := p.utils.decrementFollowersCount(ctx, cMsg.Target); err != nil {
+			log.Errorf(ctx, "error updating account stats: %v", err)
+		}
+
+		follow = model
+
+	default:
+		return gtserror.Newf("%T not parseable as *gtsmodel.FollowRequest or *gtsmodel.Follow", cMsg.GTSModel)
 	}
 
-	if err := p.federate.RejectFollow(
-		ctx,
-		p.converter.FollowRequestToFollow(ctx, followReq),
-	); err != nil {
+	if err := p.federate.RejectFollow(ctx, follow); err != nil {
 		log.Errorf(ctx, "error federating follow reject: %v", err)
 	}
 
@@ -1260,6 +1316,54 @@ func (p *clientAPI) RejectLike(ctx context.Context, cMsg *messages.FromClientAPI
 	return nil
 }
 
+func (p *clientAPI) AcceptQuote(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	req, ok := cMsg.GTSModel.(*gtsmodel.InteractionRequest)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.InteractionRequest", cMsg.GTSModel)
+	}
+
+	quote := req.Quote
+
+	// Notify the quote (distinct from the notif for the pending quote).
+	if err := p.surface.notifyQuote(ctx, quote); err != nil {
+		log.Errorf(ctx, "error notifying quote: %v", err)
+	}
+
+	// Send out the Accept.
+	if err := p.federate.AcceptInteraction(ctx, req); err != nil {
+		log.Errorf(ctx, "error federating approval of quote: %v", err)
+	}
+
+	// Quote state changed on the quoting status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, quote.ID)
+
+	return nil
+}
+
+func (p *clientAPI) RejectQuote(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	req, ok := cMsg.GTSModel.(*gtsmodel.InteractionRequest)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.InteractionRequest", cMsg.GTSModel)
+	}
+
+	// At this point the InteractionRequest should already
+	// be in the database, we just need to do side effects.
+
+	// Send out the Reject.
+	if err := p.federate.RejectInteraction(ctx, req); err != nil {
+		log.Errorf(ctx, "error federating rejection of quote: %v", err)
+	}
+
+	// Quote state changed on the quoting status;
+	// uncache the prepared version from all timelines.
+	if req.Quote != nil {
+		p.surface.invalidateStatusFromTimelines(ctx, req.Quote.ID)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) RejectReply(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	req, ok := cMsg.GTSModel.(*gtsmodel.InteractionRequest)
 	if !ok {
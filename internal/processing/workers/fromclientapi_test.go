@@ -34,6 +34,7 @@
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
@@ -1125,6 +1126,82 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusWithFollowedHashtag(
 	)
 }
 
+// A public status with a hashtag should be streamed to local
+// users subscribed to the hashtag stream (and hashtag:local
+// stream) for that tag, even if they don't follow the author.
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusWithHashtagStream() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		testTag          = suite.testTags["welcome"]
+		otherTag         = suite.testTags["Hashtag"]
+
+		// postingAccount posts a new public status not mentioning anyone but using testTag.
+		status = suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			gtsmodel.VisibilityPublic,
+			nil,
+			nil,
+			nil,
+			false,
+			[]string{testTag.ID},
+		)
+	)
+
+	// Open hashtag streams for receivingAccount.
+	openStream := func(streamType string) *stream.Stream {
+		str, err := testStructs.Processor.Stream().Open(ctx, receivingAccount, streamType)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		return str
+	}
+	tagStream := openStream(stream.TimelineHashtag + ":" + testTag.Name)
+	tagLocalStream := openStream(stream.TimelineHashtagLocal + ":" + testTag.Name)
+	otherTagStream := openStream(stream.TimelineHashtag + ":" + otherTag.Name)
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Check status in both matching hashtag streams.
+	suite.checkStreamed(
+		tagStream,
+		true,
+		"",
+		stream.EventTypeUpdate,
+	)
+	suite.checkStreamed(
+		tagLocalStream,
+		true,
+		"",
+		stream.EventTypeUpdate,
+	)
+
+	// Check status not in other hashtag stream.
+	suite.checkStreamed(
+		otherTagStream,
+		false,
+		"",
+		"",
+	)
+}
+
 // A public status with a hashtag followed by a local user who does not otherwise follow the author
 // should not end up in the tag-following user's home timeline
 // if the user has the author blocked.
@@ -1972,6 +2049,83 @@ func (suite *FromClientAPITestSuite) TestProcessStatusDelete() {
 	}
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusDeliversToRelay() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx            = context.Background()
+		postingAccount = suite.testAccounts["admin_account"]
+		relay          = testrig.NewTestRelays()["relay.example.org"]
+
+		// Admin account posts a new top-level status.
+		status = suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			gtsmodel.VisibilityPublic,
+			nil,
+			nil,
+			nil,
+			false,
+			nil,
+		)
+	)
+
+	// Mark the relay as accepted
+	// so that it gets deliveries.
+	relay.State = gtsmodel.RelayStateAccepted
+	if err := testStructs.State.DB.UpdateRelay(ctx, relay, "state"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The Create should have been queued for
+	// delivery to the relay, like any other.
+	var dlv *delivery.Delivery
+	if !testrig.WaitFor(func() bool {
+		d, ok := testStructs.State.Workers.Delivery.Queue.Pop()
+		if ok && d.Request.URL.String() == relay.InboxURI {
+			dlv = d
+		}
+		return dlv != nil
+	}) {
+		suite.FailNow("timed out waiting for relay delivery")
+	}
+	suite.NotNil(dlv.Done)
+
+	// A failed delivery should be
+	// recorded on the relay...
+	dlv.Done(ctx, errors.New("relay is down"))
+
+	dbRelay, err := testStructs.State.DB.GetRelayByID(ctx, relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("relay is down", dbRelay.Error)
+
+	// ...and cleared once delivery succeeds again.
+	dlv.Done(ctx, nil)
+
+	dbRelay, err = testStructs.State.DB.GetRelayByID(ctx, relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbRelay.Error)
+}
+
 func TestFromClientAPITestSuite(t *testing.T) {
 	suite.Run(t, &FromClientAPITestSuite{})
 }
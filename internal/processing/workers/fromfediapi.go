@@ -146,6 +146,10 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromF
 		case ap.ActivityAnnounce:
 			return p.fediAPI.AcceptAnnounce(ctx, fMsg)
 
+		// ACCEPT (pending) QUOTE
+		case ap.ObjectQuote:
+			return p.fediAPI.AcceptQuote(ctx, fMsg)
+
 		// ACCEPT (remote) REPLY or ANNOUNCE
 		case ap.ObjectUnknown:
 			return p.fediAPI.AcceptRemoteStatus(ctx, fMsg)
@@ -166,6 +170,10 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromF
 		// REJECT BOOST
 		case ap.ActivityAnnounce:
 			return p.fediAPI.RejectAnnounce(ctx, fMsg)
+
+		// REJECT QUOTE
+		case ap.ObjectQuote:
+			return p.fediAPI.RejectQuote(ctx, fMsg)
 		}
 
 	// DELETE SOMETHING
@@ -330,6 +338,21 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
 
+	switch {
+	case status.QuotePendingApproval():
+		// Quote requires approval, create interaction
+		// request for and notify the quoted status
+		// author, if the quoted status is ours.
+		if err := p.utils.requestQuote(ctx, status); err != nil {
+			log.Errorf(ctx, "error pending quote: %v", err)
+		}
+
+	case status.QuoteState == gtsmodel.QuoteStateAccepted:
+		if err := p.surface.notifyQuote(ctx, status); err != nil {
+			log.Errorf(ctx, "error notifying quote: %v", err)
+		}
+	}
+
 	if status.InReplyToID != "" {
 		// Interaction counts changed on the replied status; uncache the
 		// prepared version from all timelines. The status dereferencer
@@ -828,6 +851,44 @@ func (p *fediAPI) AcceptReply(ctx context.Context, fMsg *messages.FromFediAPI) e
 	return nil
 }
 
+func (p *fediAPI) AcceptQuote(ctx context.Context, fMsg *messages.FromFediAPI) error {
+	status, ok := fMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Status", fMsg.GTSModel)
+	}
+
+	// Send out the quote again
+	// with the approval attached.
+	if err := p.federate.UpdateStatus(ctx, status); err != nil {
+		log.Errorf(ctx, "error federating quote: %v", err)
+	}
+
+	// Quote state changed on the quoting status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, status.ID)
+
+	return nil
+}
+
+func (p *fediAPI) RejectQuote(ctx context.Context, fMsg *messages.FromFediAPI) error {
+	status, ok := fMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Status", fMsg.GTSModel)
+	}
+
+	// Send out the status again
+	// with the quote removed.
+	if err := p.federate.UpdateStatus(ctx, status); err != nil {
+		log.Errorf(ctx, "error federating rejected quote: %v", err)
+	}
+
+	// Quote state changed on the quoting status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, status.ID)
+
+	return nil
+}
+
 func (p *fediAPI) AcceptRemoteStatus(ctx context.Context, fMsg *messages.FromFediAPI) error {
 	// See if we can accept a remote
 	// status we don't have stored yet.
@@ -24,6 +24,7 @@
 	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // Surface wraps functions for 'surfacing' the result
@@ -32,11 +33,13 @@
 //   - removing a status from timelines
 //   - sending a notification to a user
 //   - sending an email
+//   - sending a web push notification
 type Surface struct {
 	State         *state.State
 	Converter     *typeutils.Converter
 	Stream        *stream.Processor
 	VisFilter     *visibility.Filter
 	EmailSender   email.Sender
+	WebPushSender webpush.Sender
 	Conversations *conversations.Processor
 }
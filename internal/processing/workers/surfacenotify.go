@@ -20,7 +20,9 @@
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/status"
@@ -29,6 +31,7 @@
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -445,6 +448,133 @@ func (s *Surface) notifyableAnnounce(
 	return true, nil
 }
 
+// notifyQuote notifies the quoted status
+// account that their status has been quoted.
+func (s *Surface) notifyQuote(
+	ctx context.Context,
+	status *gtsmodel.Status,
+) error {
+	notifyable, err := s.notifyableQuote(ctx, status)
+	if err != nil {
+		return err
+	}
+
+	if !notifyable {
+		// Nothing to do.
+		return nil
+	}
+
+	// notify quoted status
+	// author of quote by account.
+	if err := s.Notify(ctx,
+		gtsmodel.NotificationQuote,
+		status.Quote.Account,
+		status.Account,
+		status.ID,
+	); err != nil {
+		return gtserror.Newf("error notifying quote target %s: %w", status.Quote.AccountID, err)
+	}
+
+	return nil
+}
+
+// notifyPendingQuote notifies the quoted status
+// account that their status has been quoted,
+// and that the quote requires approval.
+func (s *Surface) notifyPendingQuote(
+	ctx context.Context,
+	status *gtsmodel.Status,
+) error {
+	notifyable, err := s.notifyableQuote(ctx, status)
+	if err != nil {
+		return err
+	}
+
+	if !notifyable {
+		// Nothing to do.
+		return nil
+	}
+
+	// notify quoted status
+	// author of quote by account.
+	if err := s.Notify(ctx,
+		gtsmodel.NotificationPendingQuote,
+		status.Quote.Account,
+		status.Account,
+		status.ID,
+	); err != nil {
+		return gtserror.Newf("error notifying quote target %s: %w", status.Quote.AccountID, err)
+	}
+
+	return nil
+}
+
+// notifyableQuote checks that the given
+// quote should be notified, taking account
+// of localness of receiving account, and mutes.
+func (s *Surface) notifyableQuote(
+	ctx context.Context,
+	status *gtsmodel.Status,
+) (bool, error) {
+	if status.QuoteID == "" {
+		// Not a quote of a status
+		// we know, nothing to do.
+		return false, nil
+	}
+
+	// Beforehand, ensure the passed status is fully populated.
+	if err := s.State.DB.PopulateStatus(ctx, status); err != nil {
+		return false, gtserror.Newf("error populating status %s: %w", status.ID, err)
+	}
+
+	if status.Quote == nil {
+		// Quoted status
+		// is gone now.
+		return false, nil
+	}
+
+	if status.Quote.AccountID == status.AccountID {
+		// Self-quote, nothing to do.
+		return false, nil
+	}
+
+	// Ensure quoted status
+	// author is populated.
+	if status.Quote.Account == nil {
+		var err error
+		status.Quote.Account, err = s.State.DB.GetAccountByID(ctx, status.Quote.AccountID)
+		if err != nil {
+			return false, gtserror.Newf("error getting quoted status author: %w", err)
+		}
+	}
+
+	if status.Quote.Account.IsRemote() {
+		// no need to notify
+		// remote accounts.
+		return false, nil
+	}
+
+	// Ensure quotee hasn't
+	// muted the thread.
+	muted, err := s.State.DB.IsThreadMutedByAccount(
+		ctx,
+		status.Quote.ThreadID,
+		status.Quote.AccountID,
+	)
+
+	if err != nil {
+		return false, gtserror.Newf("error checking status thread mute %s: %w", status.QuoteID, err)
+	}
+
+	if muted {
+		// Quotee doesn't want
+		// notifs for this thread.
+		return false, nil
+	}
+
+	return true, nil
+}
+
 func (s *Surface) notifyPollClose(ctx context.Context, status *gtsmodel.Status) error {
 	// Beforehand, ensure the passed status is fully populated.
 	if err := s.State.DB.PopulateStatus(ctx, status); err != nil {
@@ -574,6 +704,40 @@ func (s *Surface) Notify(
 		return nil
 	}
 
+	if originAccount.IsRemote() {
+		// Don't notify target of anything
+		// from a domain they've blocked.
+		blocked, err := s.State.DB.IsAccountDomainBlocked(ctx,
+			targetAccount.ID,
+			originAccount.Domain,
+		)
+		if err != nil {
+			return gtserror.Newf("error checking account domain block: %w", err)
+		}
+
+		if blocked {
+			// nothing to do.
+			return nil
+		}
+	}
+
+	// Check what the target's notification
+	// policy says to do with this notif.
+	policyValue, err := s.notifyPolicyValue(ctx,
+		notificationType,
+		targetAccount,
+		originAccount,
+		statusID,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking notification policy: %w", err)
+	}
+
+	if policyValue == gtsmodel.NotificationPolicyDrop {
+		// nothing to do.
+		return nil
+	}
+
 	// We're doing state-y stuff so get a
 	// lock on this combo of notif params.
 	lockURI := getNotifyLockURI(
@@ -616,6 +780,7 @@ func (s *Surface) Notify(
 		OriginAccountID:  originAccount.ID,
 		OriginAccount:    originAccount,
 		StatusID:         statusID,
+		Filtered:         util.Ptr(policyValue == gtsmodel.NotificationPolicyFilter),
 	}
 
 	if err := s.State.DB.PutNotification(ctx, notif); err != nil {
@@ -626,6 +791,15 @@ func (s *Surface) Notify(
 	// with the state-y stuff.
 	unlock()
 
+	if *notif.Filtered {
+		// Filtered notifs aren't streamed or
+		// pushed, just added to a notif request.
+		if err := s.addToNotificationRequest(ctx, notif); err != nil {
+			return gtserror.Newf("error updating notification request: %w", err)
+		}
+		return nil
+	}
+
 	// Stream notification to the user.
 	filters, err := s.State.DB.GetFiltersForAccountID(ctx, targetAccount.ID)
 	if err != nil {
@@ -647,5 +821,196 @@ func (s *Surface) Notify(
 	}
 	s.Stream.Notify(ctx, targetAccount, apiNotif)
 
+	// Push notification to any Web Push subscriptions.
+	// This is done on a separate worker, as slow or dead
+	// push services would otherwise hold up this worker.
+	s.State.Workers.WebPush.Queue.Push(func(ctx context.Context) {
+		if err := s.WebPushSender.Send(ctx, notif, apiNotif); err != nil {
+			log.Errorf(ctx, "error sending web push notifications: %v", err)
+		}
+	})
+
+	return nil
+}
+
+// filterableNotifTypes are the types of notification
+// that can be filtered by notification policies. Other
+// types are either about the account's own content, or
+// are pending interactions that need approving anyway.
+var filterableNotifTypes = []gtsmodel.NotificationType{
+	gtsmodel.NotificationFollow,
+	gtsmodel.NotificationFollowRequest,
+	gtsmodel.NotificationMention,
+	gtsmodel.NotificationReblog,
+	gtsmodel.NotificationFave,
+	gtsmodel.NotificationQuote,
+}
+
+// notifyPolicyValue returns what to do with a notification of the
+// given type from originAccount, according to the notification
+// policy of targetAccount. If more than one part of the policy
+// applies, the strictest value of them is returned.
+//
+// Notifications from accounts that targetAccount follows, or whose
+// notification request targetAccount has accepted, are always accepted.
+func (s *Surface) notifyPolicyValue(
+	ctx context.Context,
+	notificationType gtsmodel.NotificationType,
+	targetAccount *gtsmodel.Account,
+	originAccount *gtsmodel.Account,
+	statusID string,
+) (gtsmodel.NotificationPolicyValue, error) {
+	if !slices.Contains(filterableNotifTypes, notificationType) ||
+		targetAccount.ID == originAccount.ID {
+		return gtsmodel.NotificationPolicyAccept, nil
+	}
+
+	following, err := s.State.DB.IsFollowing(ctx,
+		targetAccount.ID,
+		originAccount.ID,
+	)
+	if err != nil {
+		return "", gtserror.Newf("error checking follow: %w", err)
+	}
+
+	if following {
+		// Target chose to
+		// hear from origin.
+		return gtsmodel.NotificationPolicyAccept, nil
+	}
+
+	req, err := s.State.DB.GetNotificationRequest(
+		gtscontext.SetBarebones(ctx),
+		targetAccount.ID,
+		originAccount.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return "", gtserror.Newf("error getting notification request: %w", err)
+	}
+
+	if req != nil && req.IsAccepted() {
+		// Target already accepted
+		// notifs from origin.
+		return gtsmodel.NotificationPolicyAccept, nil
+	}
+
+	policy, err := s.State.DB.GetNotificationPolicy(ctx, targetAccount.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return "", gtserror.Newf("error getting notification policy: %w", err)
+	}
+
+	if policy == nil {
+		policy = gtsmodel.DefaultNotificationPolicy(targetAccount.ID)
+	}
+
+	// Start from accept, and
+	// apply stricter values.
+	value := gtsmodel.NotificationPolicyAccept
+	apply := func(v gtsmodel.NotificationPolicyValue) {
+		if v == gtsmodel.NotificationPolicyDrop ||
+			(v == gtsmodel.NotificationPolicyFilter && value == gtsmodel.NotificationPolicyAccept) {
+			value = v
+		}
+	}
+
+	// Target isn't following origin.
+	apply(policy.ForNotFollowing)
+
+	if policy.ForNotFollowers != gtsmodel.NotificationPolicyAccept {
+		followedBy, err := s.State.DB.IsFollowing(ctx,
+			originAccount.ID,
+			targetAccount.ID,
+		)
+		if err != nil {
+			return "", gtserror.Newf("error checking follow: %w", err)
+		}
+
+		if !followedBy {
+			apply(policy.ForNotFollowers)
+		}
+	}
+
+	if time.Since(originAccount.CreatedAt) < gtsmodel.NotificationPolicyNewAccountAge {
+		apply(policy.ForNewAccounts)
+	}
+
+	if originAccount.IsSilenced() {
+		apply(policy.ForLimitedAccounts)
+	}
+
+	if notificationType == gtsmodel.NotificationMention &&
+		policy.ForPrivateMentions != gtsmodel.NotificationPolicyAccept {
+		status, err := s.State.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			statusID,
+		)
+		if err != nil {
+			return "", gtserror.Newf("error getting mention status: %w", err)
+		}
+
+		// A private mention is solicited if it's
+		// a reply to one of the target's statuses.
+		if status.Visibility == gtsmodel.VisibilityDirect &&
+			status.InReplyToAccountID != targetAccount.ID {
+			apply(policy.ForPrivateMentions)
+		}
+	}
+
+	return value, nil
+}
+
+// addToNotificationRequest adds the given filtered notification
+// to the notification request from its origin account, creating
+// the notification request if it doesn't exist yet.
+func (s *Surface) addToNotificationRequest(
+	ctx context.Context,
+	notif *gtsmodel.Notification,
+) error {
+	// Lock on this notif request, as more notifs from the
+	// same origin with different params may come in at once.
+	unlock := s.State.ProcessingLocks.Lock(
+		"notificationrequest:?target=" + notif.TargetAccount.URI +
+			"&origin=" + notif.OriginAccount.URI,
+	)
+	defer unlock()
+
+	req, err := s.State.DB.GetNotificationRequest(
+		gtscontext.SetBarebones(ctx),
+		notif.TargetAccountID,
+		notif.OriginAccountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting notification request: %w", err)
+	}
+
+	if req == nil {
+		// No request yet, create a new one.
+		req = &gtsmodel.NotificationRequest{
+			ID:                 id.NewULID(),
+			AccountID:          notif.TargetAccountID,
+			FromAccountID:      notif.OriginAccountID,
+			LastStatusID:       notif.StatusID,
+			NotificationsCount: 1,
+		}
+
+		if err := s.State.DB.PutNotificationRequest(ctx, req); err != nil {
+			return gtserror.Newf("error putting notification request: %w", err)
+		}
+
+		return nil
+	}
+
+	// Add notif to existing request.
+	columns := []string{"notifications_count"}
+	req.NotificationsCount++
+	if notif.StatusID != "" {
+		req.LastStatusID = notif.StatusID
+		columns = append(columns, "last_status_id")
+	}
+
+	if err := s.State.DB.UpdateNotificationRequest(ctx, req, columns...); err != nil {
+		return gtserror.Newf("error updating notification request: %w", err)
+	}
+
 	return nil
 }
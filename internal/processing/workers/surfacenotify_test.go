@@ -24,9 +24,11 @@
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/workers"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -45,6 +47,7 @@ func (suite *SurfaceNotifyTestSuite) TestSpamNotifs() {
 		Stream:        testStructs.Processor.Stream(),
 		VisFilter:     visibility.NewFilter(testStructs.State),
 		EmailSender:   testStructs.EmailSender,
+		WebPushSender: testStructs.WebPushSender,
 		Conversations: testStructs.Processor.Conversations(),
 	}
 
@@ -112,6 +115,90 @@ func (suite *SurfaceNotifyTestSuite) TestSpamNotifs() {
 	}
 }
 
+func (suite *SurfaceNotifyTestSuite) TestNotifyPolicy() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	surface := &workers.Surface{
+		State:         testStructs.State,
+		Converter:     testStructs.TypeConverter,
+		Stream:        testStructs.Processor.Stream(),
+		VisFilter:     visibility.NewFilter(testStructs.State),
+		EmailSender:   testStructs.EmailSender,
+		WebPushSender: testStructs.WebPushSender,
+		Conversations: testStructs.Processor.Conversations(),
+	}
+
+	var (
+		ctx           = context.Background()
+		targetAccount = suite.testAccounts["local_account_1"]
+		followed      = suite.testAccounts["local_account_2"]
+		notFollowed   = suite.testAccounts["remote_account_1"]
+		dropped       = suite.testAccounts["remote_account_2"]
+		policy        = gtsmodel.DefaultNotificationPolicy(targetAccount.ID)
+	)
+
+	// Filter notifs from accounts target doesn't follow.
+	policy.ID = id.NewULID()
+	policy.ForNotFollowing = gtsmodel.NotificationPolicyFilter
+	if err := testStructs.State.DB.PutNotificationPolicy(ctx, policy); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Notifs from followed accounts are accepted, notifs
+	// from the other account are filtered each time.
+	for _, origin := range []*gtsmodel.Account{followed, notFollowed} {
+		for _, notifType := range []gtsmodel.NotificationType{
+			gtsmodel.NotificationFollow,
+			gtsmodel.NotificationFollowRequest,
+		} {
+			if err := surface.Notify(ctx, notifType, targetAccount, origin, ""); err != nil {
+				suite.FailNow(err.Error())
+			}
+		}
+	}
+
+	// Only the accepted notifs are shown.
+	notifs, err := testStructs.State.DB.GetAccountNotifications(
+		gtscontext.SetBarebones(ctx),
+		targetAccount.ID,
+		"", "", "", 0, nil, nil,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, notif := range notifs {
+		suite.NotEqual(notFollowed.ID, notif.OriginAccountID)
+	}
+
+	// Filtered notifs made one request.
+	req, err := testStructs.State.DB.GetNotificationRequest(ctx, targetAccount.ID, notFollowed.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(2, req.NotificationsCount)
+
+	_, err = testStructs.State.DB.GetNotificationRequest(ctx, targetAccount.ID, followed.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Dropped notifs aren't stored at all.
+	policy.ForNotFollowing = gtsmodel.NotificationPolicyDrop
+	if err := testStructs.State.DB.UpdateNotificationPolicy(ctx, policy, "for_not_following"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := surface.Notify(ctx, gtsmodel.NotificationFollow, targetAccount, dropped, ""); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = testStructs.State.DB.GetNotification(ctx, gtsmodel.NotificationFollow, targetAccount.ID, dropped.ID, "")
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, err = testStructs.State.DB.GetNotificationRequest(ctx, targetAccount.ID, dropped.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func TestSurfaceNotifyTestSuite(t *testing.T) {
 	suite.Run(t, new(SurfaceNotifyTestSuite))
 }
@@ -71,6 +71,9 @@ func (s *Surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.
 		return gtserror.Newf("error timelining status %s for tag followers: %w", status.ID, err)
 	}
 
+	// Stream the status to anyone watching hashtags used by this status.
+	s.streamStatusToTagStreams(ctx, status, false)
+
 	// Notify each local account that's mentioned by this status.
 	if err := s.notifyMentions(ctx, status); err != nil {
 		return gtserror.Newf("error notifying status mentions for status %s: %w", status.ID, err)
@@ -620,6 +623,9 @@ func (s *Surface) timelineStatusUpdate(ctx context.Context, status *gtsmodel.Sta
 		return gtserror.Newf("error timelining status %s for tag followers: %w", status.ID, err)
 	}
 
+	// Push updated status to anyone watching hashtags used by this status.
+	s.streamStatusToTagStreams(ctx, status, true)
+
 	return nil
 }
 
@@ -859,3 +865,93 @@ func (s *Surface) timelineStatusUpdateForTagFollowers(
 	}
 	return errs.Combine()
 }
+
+// streamStatusToTagStreams streams the given status into the open
+// hashtag streams (and for local statuses, local hashtag streams)
+// of each account watching a useable + listable tag of the status,
+// provided the status is tag timelineable for that account. If
+// update is true, the status is streamed as an edit, rather than
+// as a new status.
+func (s *Surface) streamStatusToTagStreams(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	update bool,
+) {
+	if status.Visibility != gtsmodel.VisibilityPublic ||
+		status.BoostOfID != "" || len(status.Tags) == 0 {
+		// Only original public statuses
+		// with tags get streamed to tags.
+		return
+	}
+
+	// Build list of stream types
+	// this status could go into.
+	streamTypes := make([]string, 0, 2*len(status.Tags))
+	for _, tag := range status.Tags {
+		if !*tag.Useable || !*tag.Listable {
+			continue
+		}
+
+		streamTypes = append(streamTypes, stream.TimelineHashtag+":"+tag.Name)
+		if status.IsLocal() {
+			streamTypes = append(streamTypes, stream.TimelineHashtagLocal+":"+tag.Name)
+		}
+	}
+
+	if len(streamTypes) == 0 {
+		// No eligible tags.
+		return
+	}
+
+	// Get accounts that have streams open for any of these tags.
+	accountIDs := s.Stream.SubscribedAccountIDs(streamTypes...)
+	for _, accountID := range accountIDs {
+		account, err := s.State.DB.GetAccountByID(ctx, accountID)
+		if err != nil {
+			log.Errorf(ctx, "db error getting account %s: %v", accountID, err)
+			continue
+		}
+
+		// Check status is tag timelineable for this account.
+		timelineable, err := s.VisFilter.StatusTagTimelineable(ctx, account, status)
+		if err != nil {
+			log.Errorf(ctx, "error checking status %s tag timelineability: %v", status.ID, err)
+			continue
+		}
+
+		if !timelineable {
+			continue
+		}
+
+		filters, mutes, err := s.getFiltersAndMutes(ctx, account.ID)
+		if err != nil {
+			log.Errorf(ctx, "error getting filters and mutes for account %s: %v", account.ID, err)
+			continue
+		}
+
+		// Convert status to frontend model for this account.
+		apiStatus, err := s.Converter.StatusToAPIStatus(ctx,
+			status,
+			account,
+			statusfilter.FilterContextPublic,
+			filters,
+			mutes,
+		)
+		if err != nil {
+			if !errors.Is(err, statusfilter.ErrHideStatus) {
+				log.Errorf(ctx, "error converting status %s to frontend representation: %v", status.ID, err)
+			}
+			continue
+		}
+
+		// Stream into each tag stream type. Streams
+		// not subscribed to a type are skipped over.
+		for _, streamType := range streamTypes {
+			if update {
+				s.Stream.StatusUpdate(ctx, account, apiStatus, streamType)
+			} else {
+				s.Stream.Update(ctx, account, apiStatus, streamType)
+			}
+		}
+	}
+}
@@ -20,6 +20,7 @@
 import (
 	"context"
 	"errors"
+	"slices"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -97,6 +98,39 @@ func (u *utils) wipeStatus(
 		}
 	}
 
+	// Gather any media only attached to previous
+	// revisions of this status; these are no longer
+	// visible to the author, so always delete them.
+	var editMediaIDs []string
+	edits, err := u.state.DB.GetStatusEditsByIDs(
+		gtscontext.SetBarebones(ctx),
+		status.EditIDs,
+	)
+	if err != nil {
+		errs.Appendf("error fetching status edits: %w", err)
+	}
+
+	for _, edit := range edits {
+		for _, id := range edit.AttachmentIDs {
+			if slices.Contains(status.AttachmentIDs, id) ||
+				slices.Contains(editMediaIDs, id) {
+				continue
+			}
+			editMediaIDs = append(editMediaIDs, id)
+		}
+	}
+
+	for _, id := range editMediaIDs {
+		if err := u.media.Delete(ctx, id); err != nil {
+			errs.Appendf("error deleting edit media: %w", err)
+		}
+	}
+
+	// Delete all previous revisions of this status.
+	if err := u.state.DB.DeleteStatusEdits(ctx, status.EditIDs); err != nil {
+		errs.Appendf("error deleting status edits: %w", err)
+	}
+
 	// Delete all mentions generated by this status.
 	// todo:u.state.DB.DeleteMentionsForStatus
 	for _, id := range status.MentionIDs {
@@ -601,6 +635,66 @@ func (u *utils) requestReply(
 	return nil
 }
 
+// requestQuote stores an interaction request
+// for the quote by the given quoting status,
+// and notifies the quoted account. If the
+// status already has an interaction request
+// (ie., it's also a reply pending approval)
+// then the quote is rejected, as only one
+// request may exist per interaction URI.
+func (u *utils) requestQuote(
+	ctx context.Context,
+	status *gtsmodel.Status,
+) error {
+	// Only create interaction request if
+	// status quotes a local status.
+	if status.Quote == nil ||
+		!status.Quote.IsLocal() {
+		return nil
+	}
+
+	// Lock on the interaction URI.
+	unlock := u.state.ProcessingLocks.Lock(status.URI)
+	defer unlock()
+
+	// Ensure no req with this URI exists already.
+	req, err := u.state.DB.GetInteractionRequestByInteractionURI(ctx, status.URI)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error checking for existing interaction request: %w", err)
+	}
+
+	if req != nil {
+		if req.InteractionType != gtsmodel.InteractionQuote {
+			// Request exists for something
+			// else, we can't request the quote.
+			status.QuoteState = gtsmodel.QuoteStateRejected
+			if err := u.state.DB.UpdateStatus(ctx, status, "quote_state"); err != nil {
+				return gtserror.Newf("db error updating status: %w", err)
+			}
+		}
+
+		// Nothing else to do.
+		return nil
+	}
+
+	// Create + store interaction request.
+	req, err = typeutils.StatusQuoteToInteractionRequest(ctx, status)
+	if err != nil {
+		return gtserror.Newf("error creating interaction request: %w", err)
+	}
+
+	if err := u.state.DB.PutInteractionRequest(ctx, req); err != nil {
+		return gtserror.Newf("db error storing interaction request: %w", err)
+	}
+
+	// Notify *local* account of pending quote.
+	if err := u.surface.notifyPendingQuote(ctx, status); err != nil {
+		return gtserror.Newf("error notifying pending quote: %w", err)
+	}
+
+	return nil
+}
+
 // requestAnnounce stores an interaction request
 // for the given announce, and notifies the interactee.
 func (u *utils) requestAnnounce(
@@ -50,6 +50,14 @@
 	// EventTypeConversation -- a user
 	// should be shown an updated conversation.
 	EventTypeConversation = "conversation"
+
+	// EventTypeAnnouncement -- a user should be
+	// shown a new or updated admin announcement.
+	EventTypeAnnouncement = "announcement"
+
+	// EventTypeAnnouncementDelete -- an admin
+	// announcement should no longer be shown.
+	EventTypeAnnouncementDelete = "announcement.delete"
 )
 
 const (
@@ -79,6 +87,16 @@
 	// TimelineList:
 	// Updates to a specific list.
 	TimelineList = "list"
+
+	// TimelineHashtag:
+	// All public posts using a specific hashtag.
+	// Analogous to the tag timeline.
+	TimelineHashtag = "hashtag"
+
+	// TimelineHashtagLocal:
+	// All public posts originating from this
+	// server using a specific hashtag.
+	TimelineHashtagLocal = "hashtag:local"
 )
 
 // AllStatusTimelines contains all Timelines
@@ -90,6 +108,8 @@
 	TimelineHome,
 	TimelineDirect,
 	TimelineList,
+	TimelineHashtag,
+	TimelineHashtagLocal,
 }
 
 type Streams struct {
@@ -196,6 +216,34 @@ func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool
 	return ok
 }
 
+// AccountIDs returns the IDs of all accounts that have
+// at least one open stream subscribed to any of the given
+// stream types, useful for fanning out messages that need
+// to be checked / prepared individually for each account.
+func (s *Streams) AccountIDs(streamTypes ...string) []string {
+	var accountIDs []string
+
+	// Acquire lock.
+	s.mutex.Lock()
+
+	// Iterate ALL stored streams.
+	for accountID, strs := range s.streams {
+		for _, str := range strs {
+
+			// Check whether stream supports any of our types.
+			if stype := str.getStreamType(streamTypes...); stype != "" {
+				accountIDs = append(accountIDs, accountID)
+				break
+			}
+		}
+	}
+
+	// Done with lock.
+	s.mutex.Unlock()
+
+	return accountIDs
+}
+
 // PostAll will post the given message to all streams with matching types.
 func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 	var deferred []func() bool
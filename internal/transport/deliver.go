@@ -88,6 +88,10 @@ func (t *transport) BatchDeliver(ctx context.Context, obj map[string]interface{}
 }
 
 func (t *transport) Deliver(ctx context.Context, obj map[string]interface{}, to *url.URL) error {
+	return t.DeliverFunc(ctx, obj, to, nil)
+}
+
+func (t *transport) DeliverFunc(ctx context.Context, obj map[string]interface{}, to *url.URL, done func(context.Context, error)) error {
 	// if 'to' host is our own, skip as we don't need to deliver to ourselves...
 	if to.Host == config.GetHost() || to.Host == config.GetAccountDomain() {
 		return nil
@@ -111,6 +115,9 @@ func (t *transport) Deliver(ctx context.Context, obj map[string]interface{}, to
 		return err
 	}
 
+	// Set outcome callback.
+	req.Done = done
+
 	// Push prepared request to the delivery queue.
 	t.controller.state.Workers.Delivery.Queue.Push(req)
 
@@ -19,6 +19,7 @@
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -53,6 +54,14 @@ type Delivery struct {
 	// constitutes this ActivtyPub delivery.
 	Request *httpclient.Request
 
+	// Done is an optional function called with
+	// the outcome of this delivery once the worker
+	// is finished with it, i.e. a nil error once
+	// delivered, else the error it was dropped on.
+	// This is not serialized, so won't be called
+	// for deliveries persisted over a restart.
+	Done func(ctx context.Context, err error)
+
 	// internal fields.
 	next time.Time
 }
@@ -149,6 +158,13 @@ func (dlv *Delivery) Deserialize(data []byte) error {
 	return nil
 }
 
+// done calls the Done function, if set.
+func (dlv *Delivery) done(err error) {
+	if dlv.Done != nil {
+		dlv.Done(dlv.Request.Context(), err)
+	}
+}
+
 // backoff returns a valid (>= 0) backoff duration.
 func (dlv *Delivery) backoff() time.Duration {
 	if dlv.next.IsZero() {
@@ -26,6 +26,7 @@
 	"codeberg.org/gruf/go-runners"
 	"codeberg.org/gruf/go-structr"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/queue"
@@ -208,8 +209,14 @@ func (w *Worker) process(ctx context.Context) bool {
 
 		switch {
 		case err == nil:
+			if code := rsp.StatusCode; code < 200 || code >= 300 {
+				// Delivered, but refused by the remote.
+				err = gtserror.NewFromResponse(rsp)
+			}
+
 			// Ensure body closed.
 			_ = rsp.Body.Close()
+			dlv.done(err)
 			continue loop
 
 		case errors.Is(err, context.Canceled) &&
@@ -230,6 +237,7 @@ func (w *Worker) process(ctx context.Context) bool {
 			// Drop deliveries when no
 			// retry requested, or they
 			// reached max (either).
+			dlv.done(err)
 			continue loop
 		}
 
@@ -19,6 +19,7 @@
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"math/rand"
@@ -42,6 +43,66 @@ func TestDeliveryWorkerPool(t *testing.T) {
 	}
 }
 
+func TestDeliveryWorkerDone(t *testing.T) {
+	wp := new(delivery.WorkerPool)
+	wp.Init(httpclient.New(httpclient.Config{
+		AllowRanges: config.MustParseIPPrefixes([]string{
+			"127.0.0.0/8",
+		}),
+	}))
+	wp.Start(1)
+	defer wp.Stop()
+
+	// Prepare an HTTP test handler that refuses
+	// deliveries to one path and accepts the rest.
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/refused" {
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+		rw.WriteHeader(http.StatusAccepted)
+	})
+
+	// Start new HTTP test server listener.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	// Start the HTTP server.
+	srv := new(http.Server)
+	srv.Addr = "http://" + l.Addr().String()
+	srv.Handler = handler
+	go srv.Serve(l)
+	defer srv.Close()
+
+	for path, expectErr := range map[string]bool{
+		"/accepted": false,
+		"/refused":  true,
+	} {
+		req, err := http.NewRequest(http.MethodPost, srv.Addr+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		errs := make(chan error, 1)
+
+		// Enqueue delivery with
+		// outcome callback set.
+		wp.Queue.Push(&delivery.Delivery{
+			Request: httpclient.WrapRequest(req),
+			Done: func(_ context.Context, err error) {
+				errs <- err
+			},
+		})
+
+		if err := <-errs; (err != nil) != expectErr {
+			t.Errorf("unexpected delivery outcome for %s: %v", path, err)
+		}
+	}
+}
+
 func testDeliveryWorkerPool(t *testing.T, sz int, input []*testrequest) {
 	wp := new(delivery.WorkerPool)
 	wp.Init(httpclient.New(httpclient.Config{
@@ -0,0 +1,126 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+
+	"codeberg.org/gruf/go-bytesize"
+	"codeberg.org/gruf/go-iotools"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// maxOEmbedSize is the maximum size
+// of oEmbed response we'll accept.
+const maxOEmbedSize = 256 * bytesize.KiB
+
+// OEmbed models the fields of an oEmbed
+// response that are used to generate link
+// preview cards. See: https://oembed.com/
+//
+// Numeric fields are decoded as json.Number,
+// as some providers return them as strings.
+type OEmbed struct {
+	Type            string      `json:"type"`
+	Title           string      `json:"title"`
+	AuthorName      string      `json:"author_name"`
+	AuthorURL       string      `json:"author_url"`
+	ProviderName    string      `json:"provider_name"`
+	ProviderURL     string      `json:"provider_url"`
+	URL             string      `json:"url"`
+	HTML            string      `json:"html"`
+	Width           json.Number `json:"width"`
+	Height          json.Number `json:"height"`
+	ThumbnailURL    string      `json:"thumbnail_url"`
+	ThumbnailWidth  json.Number `json:"thumbnail_width"`
+	ThumbnailHeight json.Number `json:"thumbnail_height"`
+}
+
+func (t *transport) DereferenceWebPage(ctx context.Context, iri *url.URL, maxsz int64) (*http.Response, error) {
+	// Prepare HTTP request to this page's IRI
+	req, err := http.NewRequestWithContext(ctx, "GET", iri.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.8")
+
+	// Perform the HTTP request
+	rsp, err := t.GET(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for an expected status code
+	if rsp.StatusCode != http.StatusOK {
+		return nil, gtserror.NewFromResponse(rsp)
+	}
+
+	// Check we were actually given a web page.
+	ct, _, _ := mime.ParseMediaType(rsp.Header.Get("Content-Type"))
+	if ct != "text/html" && ct != "application/xhtml+xml" {
+		_ = rsp.Body.Close() // close early.
+		return nil, gtserror.Newf("unexpected content type %s", ct)
+	}
+
+	// Check page within size limit.
+	if rsp.ContentLength > maxsz {
+		_ = rsp.Body.Close()       // close early.
+		sz := bytesize.Size(maxsz) //nolint:gosec
+		return nil, gtserror.Newf("page body exceeds max size %s", sz)
+	}
+
+	// Update response body with maximum supported page size.
+	rsp.Body, _, _ = iotools.UpdateReadCloserLimit(rsp.Body, maxsz)
+
+	return rsp, nil
+}
+
+func (t *transport) DereferenceOEmbed(ctx context.Context, iri *url.URL) (*OEmbed, error) {
+	// Prepare HTTP request to this oEmbed IRI
+	req, err := http.NewRequestWithContext(ctx, "GET", iri.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept", "application/json")
+
+	// Perform the HTTP request
+	rsp, err := t.GET(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	// Check for an expected status code
+	if rsp.StatusCode != http.StatusOK {
+		return nil, gtserror.NewFromResponse(rsp)
+	}
+
+	// Limit the body so we don't decode anything huge.
+	rsp.Body, _, _ = iotools.UpdateReadCloserLimit(rsp.Body, int64(maxOEmbedSize))
+
+	oembed := new(OEmbed)
+	if err := json.NewDecoder(rsp.Body).Decode(oembed); err != nil {
+		return nil, gtserror.Newf("error decoding oembed: %w", err)
+	}
+
+	return oembed, nil
+}
@@ -58,6 +58,10 @@ type Transport interface {
 	// Deliver sends an ActivityStreams object.
 	Deliver(ctx context.Context, obj map[string]interface{}, to *url.URL) error
 
+	// DeliverFunc is like Deliver, but calls the given function with
+	// the outcome of the delivery, once the delivery worker is done with it.
+	DeliverFunc(ctx context.Context, obj map[string]interface{}, to *url.URL, done func(context.Context, error)) error
+
 	// BatchDeliver sends an ActivityStreams object to multiple recipients.
 	BatchDeliver(ctx context.Context, obj map[string]interface{}, recipients []*url.URL) error
 
@@ -80,6 +84,23 @@ type Transport interface {
 
 	// Finger performs a webfinger request with the given username and domain, and returns the bytes from the response body.
 	Finger(ctx context.Context, targetUsername string, targetDomain string) ([]byte, error)
+
+	// DereferenceDomainPermissions dereferences the permissions list present at the given
+	// subscription's URI, using its ETag and SuccessfullyFetchedAt to make the request
+	// conditional, unless skipCache is true.
+	DereferenceDomainPermissions(
+		ctx context.Context,
+		permSub *gtsmodel.DomainPermissionSubscription,
+		skipCache bool,
+	) (*DereferenceDomainPermissionsResp, error)
+
+	// DereferenceWebPage fetches the html web page at the given IRI, eg., to generate a link
+	// preview card, returning the response with body limited to given max. The request URL
+	// of the returned response is that of the final page, after following any redirects.
+	DereferenceWebPage(ctx context.Context, iri *url.URL, maxsz int64) (*http.Response, error)
+
+	// DereferenceOEmbed fetches and decodes the oEmbed json response at the given IRI.
+	DereferenceOEmbed(ctx context.Context, iri *url.URL) (*OEmbed, error)
 }
 
 // transport implements
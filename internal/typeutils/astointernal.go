@@ -353,6 +353,14 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		log.Warnf(ctx, "unusable published property on %s", uri)
 	}
 
+	// status.EditedAt
+	//
+	// Extract updated time for the status,
+	// zero-time indicates it was never edited.
+	if upd := ap.GetUpdated(statusable); !upd.IsZero() {
+		status.EditedAt = upd
+	}
+
 	// status.AccountURI
 	// status.AccountID
 	// status.Account
@@ -405,6 +413,29 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		}
 	}
 
+	// status.QuoteURI
+	// status.QuoteID
+	// status.Quote
+	//
+	// Status that this status quotes, if applicable.
+	// As with inReplyTo, if we don't have the quoted
+	// status in the database we just set the URI.
+	if quoteURI := ap.ExtractQuoteURI(statusable); quoteURI != nil {
+		status.QuoteURI = quoteURI.String()
+
+		// Check if we already have the quoted status.
+		quote, err := c.state.DB.GetStatusByURI(ctx, status.QuoteURI)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("error getting quote %s from db: %w", status.QuoteURI, err)
+			return nil, err
+		}
+
+		if quote != nil {
+			status.QuoteID = quote.ID
+			status.Quote = quote
+		}
+	}
+
 	// Calculate intended visibility of the status.
 	status.Visibility, err = ap.ExtractVisibility(
 		statusable,
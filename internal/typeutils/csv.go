@@ -20,8 +20,10 @@
 import (
 	"cmp"
 	"context"
+	"net/url"
 	"slices"
 	"strconv"
+	"strings"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -66,13 +68,22 @@ func (c *Converter) AccountToExportStats(
 		)
 	}
 
+	domainBlockingCount, err := c.state.DB.CountAccountDomainBlocks(ctx, a.ID)
+	if err != nil {
+		return nil, gtserror.Newf(
+			"error counting domain blocks for account %s: %w",
+			a.ID, err,
+		)
+	}
+
 	return &apimodel.AccountExportStats{
-		FollowersCount: *a.Stats.FollowersCount,
-		FollowingCount: *a.Stats.FollowingCount,
-		StatusesCount:  *a.Stats.StatusesCount,
-		ListsCount:     listsCount,
-		BlocksCount:    blockingCount,
-		MutesCount:     mutingCount,
+		FollowersCount:    *a.Stats.FollowersCount,
+		FollowingCount:    *a.Stats.FollowingCount,
+		StatusesCount:     *a.Stats.StatusesCount,
+		ListsCount:        listsCount,
+		BlocksCount:       blockingCount,
+		MutesCount:        mutingCount,
+		DomainBlocksCount: domainBlockingCount,
 	}, nil
 }
 
@@ -325,6 +336,42 @@ func(a *gtsmodel.Block, b *gtsmodel.Block) int {
 	return records, nil
 }
 
+// DomainBlocksToCSV converts a slice of account
+// domain blocks into a slice of CSV-compatible
+// domain blocks records.
+func (c *Converter) DomainBlocksToCSV(
+	ctx context.Context,
+	blocks []*gtsmodel.AccountDomainBlock,
+) ([][]string, error) {
+	// NOTE: Mastodon-compatible domain blocks
+	// CSV doesn't use column headers.
+	records := make([][]string, 0, len(blocks))
+
+	// Pre-sort the
+	// blocks by domain.
+	slices.SortFunc(
+		blocks,
+		func(a *gtsmodel.AccountDomainBlock, b *gtsmodel.AccountDomainBlock) int {
+			return cmp.Compare(a.Domain, b.Domain)
+		},
+	)
+
+	// For each item, add a record.
+	for _, block := range blocks {
+		domain, err := util.DePunify(block.Domain)
+		if err != nil {
+			return nil, gtserror.Newf(
+				"error depunifying domain %s: %w",
+				block.Domain, err,
+			)
+		}
+
+		records = append(records, []string{domain})
+	}
+
+	return records, nil
+}
+
 // MutesToCSV converts a slice of mutes into
 // a slice of CSV-compatible mute records.
 //
@@ -553,3 +600,243 @@ func (c *Converter) CSVToBlocks(
 
 	return blocks, nil
 }
+
+// CSVToDomainBlocks converts a slice of CSV records
+// to a slice of barebones *gtsmodel.AccountDomainBlock's,
+// ready for further processing.
+//
+// Only Domain will be set on each AccountDomainBlock.
+func (c *Converter) CSVToDomainBlocks(
+	ctx context.Context,
+	records [][]string,
+) ([]*gtsmodel.AccountDomainBlock, error) {
+	blocks := make([]*gtsmodel.AccountDomainBlock, 0, len(records))
+
+	for _, record := range records {
+		if len(record) != 1 {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		domain := strings.TrimSpace(record[0])
+		if domain == "" {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// Looks good, whack it in the slice.
+		blocks = append(blocks, &gtsmodel.AccountDomainBlock{
+			Domain: domain,
+		})
+	}
+
+	return blocks, nil
+}
+
+// CSVToListEntries converts a slice of CSV records
+// to a slice of barebones *gtsmodel.ListEntry's,
+// ready for further processing.
+//
+// Only List.Title, Follow.TargetAccount.Username,
+// and Follow.TargetAccount.Domain will be set on
+// each ListEntry.
+func (c *Converter) CSVToListEntries(
+	ctx context.Context,
+	records [][]string,
+) ([]*gtsmodel.ListEntry, error) {
+	// We need to know our own domain for this.
+	// Try account domain, fall back to host.
+	var (
+		thisHost          = config.GetHost()
+		thisAccountDomain = config.GetAccountDomain()
+		entries           = make([]*gtsmodel.ListEntry, 0, len(records))
+	)
+
+	for _, record := range records {
+		if len(record) != 2 {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// "List name"
+		title := strings.TrimSpace(record[0])
+		if title == "" {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// "Account address"
+		namestring := record[1]
+		if namestring == "" {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// Prepend with "@"
+		// if not included.
+		if namestring[0] != '@' {
+			namestring = "@" + namestring
+		}
+
+		username, domain, err := util.ExtractNamestringParts(namestring)
+		if err != nil {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		if domain == thisHost || domain == thisAccountDomain {
+			// Clear the domain,
+			// since it's ours.
+			domain = ""
+		}
+
+		// Looks good, whack it in the slice.
+		entries = append(entries, &gtsmodel.ListEntry{
+			List: &gtsmodel.List{
+				Title: title,
+			},
+			Follow: &gtsmodel.Follow{
+				TargetAccount: &gtsmodel.Account{
+					Username: username,
+					Domain:   domain,
+				},
+			},
+		})
+	}
+
+	return entries, nil
+}
+
+// CSVToMutes converts a slice of CSV records
+// to a slice of barebones *gtsmodel.UserMute's,
+// ready for further processing.
+//
+// Only TargetAccount.Username, TargetAccount.Domain,
+// and Notifications will be set on each UserMute.
+func (c *Converter) CSVToMutes(
+	ctx context.Context,
+	records [][]string,
+) ([]*gtsmodel.UserMute, error) {
+	// We need to know our own domain for this.
+	// Try account domain, fall back to host.
+	var (
+		thisHost          = config.GetHost()
+		thisAccountDomain = config.GetAccountDomain()
+		mutes             = make([]*gtsmodel.UserMute, 0, len(records))
+	)
+
+	for _, record := range records {
+		recordLen := len(record)
+
+		// Older versions of this Masto CSV
+		// schema may not include "Hide notifications",
+		// so be lenient here in what we accept.
+		if recordLen == 0 ||
+			recordLen > 2 {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// "Account address"
+		namestring := record[0]
+		if namestring == "" {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		if namestring == "Account address" {
+			// CSV header row,
+			// skip this one.
+			continue
+		}
+
+		// Prepend with "@"
+		// if not included.
+		if namestring[0] != '@' {
+			namestring = "@" + namestring
+		}
+
+		username, domain, err := util.ExtractNamestringParts(namestring)
+		if err != nil {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		if domain == thisHost || domain == thisAccountDomain {
+			// Clear the domain,
+			// since it's ours.
+			domain = ""
+		}
+
+		// "Hide notifications"
+		notifications := util.Ptr(true)
+		if recordLen > 1 {
+			b, err := strconv.ParseBool(record[1])
+			if err != nil {
+				// Badly formatted,
+				// skip this one.
+				continue
+			}
+			notifications = &b
+		}
+
+		// Looks good, whack it in the slice.
+		mutes = append(mutes, &gtsmodel.UserMute{
+			TargetAccount: &gtsmodel.Account{
+				Username: username,
+				Domain:   domain,
+			},
+			Notifications: notifications,
+		})
+	}
+
+	return mutes, nil
+}
+
+// CSVToBookmarks converts a slice of CSV records
+// to a slice of barebones *gtsmodel.StatusBookmark's,
+// ready for further processing.
+//
+// Only Status.URI will be set on each StatusBookmark.
+func (c *Converter) CSVToBookmarks(
+	ctx context.Context,
+	records [][]string,
+) ([]*gtsmodel.StatusBookmark, error) {
+	bookmarks := make([]*gtsmodel.StatusBookmark, 0, len(records))
+
+	for _, record := range records {
+		if len(record) != 1 {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		uri := strings.TrimSpace(record[0])
+		u, err := url.Parse(uri)
+		if err != nil ||
+			(u.Scheme != "https" && u.Scheme != "http") ||
+			u.Host == "" {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// Looks good, whack it in the slice.
+		bookmarks = append(bookmarks, &gtsmodel.StatusBookmark{
+			Status: &gtsmodel.Status{
+				URI: uri,
+			},
+		})
+	}
+
+	return bookmarks, nil
+}
@@ -166,10 +166,22 @@ func APIInteractionPolicyToInteractionPolicy(
 		return nil, err
 	}
 
+	canQuoteAlways, err := convertURIs(p.CanQuote.Always)
+	if err != nil {
+		err := fmt.Errorf("error converting %s.can_quote.always: %w", v, err)
+		return nil, err
+	}
+
+	canQuoteWithApproval, err := convertURIs(p.CanQuote.WithApproval)
+	if err != nil {
+		err := fmt.Errorf("error converting %s.can_quote.with_approval: %w", v, err)
+		return nil, err
+	}
+
 	// Normalize URIs.
 	//
 	// 1. Ensure canLikeAlways, canReplyAlways,
-	//    and canAnnounceAlways include self
+	//    canAnnounceAlways, and canQuoteAlways include self
 	//    (either explicitly or within public).
 
 	// ensureIncludesSelf adds the "author" PolicyValue
@@ -201,6 +213,13 @@ func(uri gtsmodel.PolicyValue) bool {
 	canReplyAlways = ensureIncludesSelf(canReplyAlways)
 	canAnnounceAlways = ensureIncludesSelf(canAnnounceAlways)
 
+	// Clients that don't know about quotes won't
+	// send can_quote at all; in that case leave it
+	// unset, so that the default rules are used.
+	if len(canQuoteAlways) != 0 || len(canQuoteWithApproval) != 0 {
+		canQuoteAlways = ensureIncludesSelf(canQuoteAlways)
+	}
+
 	// 2. Ensure canReplyAlways includes mentioned
 	//    accounts (either explicitly or within public).
 	if !slices.ContainsFunc(
@@ -229,5 +248,9 @@ func(uri gtsmodel.PolicyValue) bool {
 			Always:       canAnnounceAlways,
 			WithApproval: canAnnounceWithApproval,
 		},
+		CanQuote: gtsmodel.PolicyRules{
+			Always:       canQuoteAlways,
+			WithApproval: canQuoteWithApproval,
+		},
 	}, nil
 }
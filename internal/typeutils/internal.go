@@ -157,6 +157,32 @@ func StatusToInteractionRequest(
 	}, nil
 }
 
+// StatusQuoteToInteractionRequest returns an interaction
+// request for the quote of the given quoting status.
+func StatusQuoteToInteractionRequest(
+	ctx context.Context,
+	status *gtsmodel.Status,
+) (*gtsmodel.InteractionRequest, error) {
+	reqID, err := id.NewULIDFromTime(status.CreatedAt)
+	if err != nil {
+		return nil, gtserror.Newf("error generating ID: %w", err)
+	}
+
+	return &gtsmodel.InteractionRequest{
+		ID:                   reqID,
+		CreatedAt:            status.CreatedAt,
+		StatusID:             status.QuoteID,
+		Status:               status.Quote,
+		TargetAccountID:      status.Quote.AccountID,
+		TargetAccount:        status.Quote.Account,
+		InteractingAccountID: status.AccountID,
+		InteractingAccount:   status.Account,
+		InteractionURI:       status.URI,
+		InteractionType:      gtsmodel.InteractionQuote,
+		Quote:                status,
+	}, nil
+}
+
 func StatusFaveToInteractionRequest(
 	ctx context.Context,
 	fave *gtsmodel.StatusFave,
@@ -268,3 +294,41 @@ func (c *Converter) StatusToSinBinStatus(
 		ActivityStreamsType: status.ActivityStreamsType,
 	}, nil
 }
+
+// StatusToStatusEdit returns a new StatusEdit model
+// containing a snapshot of the given status in its
+// current state, for storing as a previous revision.
+func StatusToStatusEdit(status *gtsmodel.Status) *gtsmodel.StatusEdit {
+	// The current revision was created
+	// either at the time of last edit, or
+	// the status' creation if never edited.
+	createdAt := status.EditedAt
+	if createdAt.IsZero() {
+		createdAt = status.CreatedAt
+	}
+
+	edit := &gtsmodel.StatusEdit{
+		ID:             id.NewULID(),
+		CreatedAt:      createdAt,
+		StatusID:       status.ID,
+		Content:        status.Content,
+		ContentWarning: status.ContentWarning,
+		Text:           status.Text,
+		Language:       status.Language,
+		Sensitive:      status.Sensitive,
+	}
+
+	// Media descriptions may be changed in future edits,
+	// so store a snapshot of them alongside attachment IDs.
+	for _, attachment := range status.Attachments {
+		edit.AttachmentIDs = append(edit.AttachmentIDs, attachment.ID)
+		edit.AttachmentDescriptions = append(edit.AttachmentDescriptions, attachment.Description)
+	}
+
+	if status.Poll != nil {
+		// Store poll options at this revision.
+		edit.PollOptions = status.Poll.Options
+	}
+
+	return edit
+}
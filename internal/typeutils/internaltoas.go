@@ -489,6 +489,13 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 	publishedProp.Set(s.CreatedAt)
 	status.SetActivityStreamsPublished(publishedProp)
 
+	// updated
+	if !s.EditedAt.IsZero() {
+		updatedProp := streams.NewActivityStreamsUpdatedProperty()
+		updatedProp.Set(s.EditedAt)
+		status.SetActivityStreamsUpdated(updatedProp)
+	}
+
 	// url
 	if s.URL != "" {
 		sURL, err := url.Parse(s.URL)
@@ -693,6 +700,15 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 	policyProp.AppendGoToSocialInteractionPolicy(policy)
 	status.SetGoToSocialInteractionPolicy(policyProp)
 
+	// Set quote, if this status quotes another.
+	if s.QuoteURI != "" && s.QuoteState != gtsmodel.QuoteStateRejected {
+		quoteURI, err := url.Parse(s.QuoteURI)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing quoteURI: %w", err)
+		}
+		ap.SetQuoteURI(status, quoteURI)
+	}
+
 	// Parse + set approvedBy.
 	if s.ApprovedByURI != "" {
 		approvedBy, err := url.Parse(s.ApprovedByURI)
@@ -965,6 +981,63 @@ func (c *Converter) FollowToAS(ctx context.Context, f *gtsmodel.Follow) (vocab.A
 	return follow, nil
 }
 
+// RelayToASFollow converts the given relay into an
+// activity streams Follow from the instance actor, suitable
+// for delivery to the relay's inbox. Following the Mastodon
+// convention for relays, the object of the Follow is the
+// ActivityStreams Public collection.
+func (c *Converter) RelayToASFollow(ctx context.Context, r *gtsmodel.Relay) (vocab.ActivityStreamsFollow, error) {
+	instanceAcct, err := c.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("error getting instance account: %w", err)
+	}
+
+	actorIRI, err := url.Parse(instanceAcct.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing instance account uri: %w", err)
+	}
+
+	followIRI, err := url.Parse(r.FollowURI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing follow uri: %w", err)
+	}
+
+	publicIRI, err := url.Parse(pub.PublicActivityPubIRI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing url %s: %w", pub.PublicActivityPubIRI, err)
+	}
+
+	follow := streams.NewActivityStreamsFollow()
+	ap.SetJSONLDId(follow, followIRI)
+	ap.AppendActorIRIs(follow, actorIRI)
+	ap.AppendObjectIRIs(follow, publicIRI)
+
+	return follow, nil
+}
+
+// RelayToASUndo converts the given relay into an activity
+// streams Undo of the instance actor's Follow of the relay.
+func (c *Converter) RelayToASUndo(ctx context.Context, r *gtsmodel.Relay) (vocab.ActivityStreamsUndo, error) {
+	follow, err := c.RelayToASFollow(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	undo := streams.NewActivityStreamsUndo()
+
+	// Actor of the Undo is
+	// the actor of the Follow.
+	undo.SetActivityStreamsActor(follow.GetActivityStreamsActor())
+
+	// Send the whole Follow again as object,
+	// since most relays won't dereference it.
+	undoObject := streams.NewActivityStreamsObjectProperty()
+	undoObject.AppendActivityStreamsFollow(follow)
+	undo.SetActivityStreamsObject(undoObject)
+
+	return undo, nil
+}
+
 // MentionToAS converts a gts model mention into an activity streams Mention, suitable for federation
 func (c *Converter) MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab.ActivityStreamsMention, error) {
 	if m.TargetAccount == nil {
@@ -1957,6 +2030,43 @@ func (c *Converter) InteractionPolicyToASInteractionPolicy(
 	canAnnounceProp.AppendGoToSocialCanAnnounce(canAnnounce)
 	policy.SetGoToSocialCanAnnounce(canAnnounceProp)
 
+	/*
+		CAN QUOTE
+	*/
+
+	// Get canQuote rules, falling back
+	// to defaults for older policies.
+	canQuote := interactionPolicy.CanQuoteFor(status.Visibility)
+
+	// Build canQuote.always
+	canQuoteAlwaysProp := streams.NewGoToSocialAlwaysProperty()
+	if err := populateValuesForProp(
+		canQuoteAlwaysProp,
+		status,
+		canQuote.Always,
+	); err != nil {
+		return nil, gtserror.Newf("error setting canQuote.always: %w", err)
+	}
+
+	// Build canQuote.approvalRequired
+	canQuoteApprovalRequiredProp := streams.NewGoToSocialApprovalRequiredProperty()
+	if err := populateValuesForProp(
+		canQuoteApprovalRequiredProp,
+		status,
+		canQuote.WithApproval,
+	); err != nil {
+		return nil, gtserror.Newf("error setting canQuote.approvalRequired: %w", err)
+	}
+
+	// Set canQuote on the policy.
+	if err := ap.SetCanQuote(
+		policy,
+		canQuoteAlwaysProp,
+		canQuoteApprovalRequiredProp,
+	); err != nil {
+		return nil, gtserror.Newf("error setting canQuote: %w", err)
+	}
+
 	return policy, nil
 }
 
@@ -2021,6 +2131,10 @@ func (c *Converter) InteractionReqToASAccept(
 	case gtsmodel.InteractionAnnounce:
 		// Accept of announce gets cc'd.
 		cc = true
+
+	case gtsmodel.InteractionQuote:
+		// Accept of quote gets cc'd.
+		cc = true
 	}
 
 	if cc {
@@ -2101,6 +2215,10 @@ func (c *Converter) InteractionReqToASReject(
 	case gtsmodel.InteractionAnnounce:
 		// Reject of announce gets cc'd.
 		cc = true
+
+	case gtsmodel.InteractionQuote:
+		// Reject of quote gets cc'd.
+		cc = true
 	}
 
 	if cc {
@@ -2119,3 +2237,63 @@ func (c *Converter) InteractionReqToASReject(
 
 	return reject, nil
 }
+
+// StatusesToASArchiveOutbox converts a slice of statuses into an
+// OrderedCollection of Create activities with the given ID, for
+// inclusion in an account archive. Unlike outbox pages served
+// over federation, Create objects are embedded in full, and all
+// items are included in the one collection.
+func (c *Converter) StatusesToASArchiveOutbox(ctx context.Context, id string, statuses []*gtsmodel.Status) (vocab.ActivityStreamsOrderedCollection, error) {
+	idIRI, err := url.Parse(id)
+	if err != nil {
+		return nil, gtserror.Newf("invalid collection id %s: %w", id, err)
+	}
+
+	total := len(statuses)
+	collection := ap.NewASOrderedCollection(ap.CollectionParams{
+		ID:    idIRI,
+		Total: &total,
+	})
+
+	itemsProp := streams.NewActivityStreamsOrderedItemsProperty()
+	for _, s := range statuses {
+		statusable, err := c.StatusToAS(ctx, s)
+		if err != nil {
+			return nil, gtserror.Newf("error converting status %s: %w", s.ID, err)
+		}
+
+		create := WrapStatusableInCreate(statusable, false)
+		itemsProp.AppendActivityStreamsCreate(create)
+	}
+	collection.SetActivityStreamsOrderedItems(itemsProp)
+
+	return collection, nil
+}
+
+// URIsToASArchiveCollection converts a slice of URIs, eg., of
+// liked or bookmarked statuses, into an OrderedCollection with
+// the given ID, for inclusion in an account archive.
+func (c *Converter) URIsToASArchiveCollection(id string, uris []string) (vocab.ActivityStreamsOrderedCollection, error) {
+	idIRI, err := url.Parse(id)
+	if err != nil {
+		return nil, gtserror.Newf("invalid collection id %s: %w", id, err)
+	}
+
+	total := len(uris)
+	collection := ap.NewASOrderedCollection(ap.CollectionParams{
+		ID:    idIRI,
+		Total: &total,
+	})
+
+	itemsProp := streams.NewActivityStreamsOrderedItemsProperty()
+	for _, uri := range uris {
+		iri, err := url.Parse(uri)
+		if err != nil {
+			return nil, gtserror.Newf("invalid uri %s: %w", uri, err)
+		}
+		itemsProp.AppendIRI(iri)
+	}
+	collection.SetActivityStreamsOrderedItems(itemsProp)
+
+	return collection, nil
+}
@@ -476,6 +476,12 @@ func (suite *InternalToASTestSuite) TestStatusToAS() {
       ],
       "approvalRequired": []
     },
+    "canQuote": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
@@ -558,6 +564,12 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASWithIDs() {
       ],
       "approvalRequired": []
     },
+    "canQuote": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
@@ -658,6 +670,12 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASFromDB() {
       ],
       "approvalRequired": []
     },
+    "canQuote": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
@@ -751,6 +769,12 @@ func (suite *InternalToASTestSuite) TestStatusToASWithMentions() {
       ],
       "approvalRequired": []
     },
+    "canQuote": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
@@ -98,6 +98,10 @@ func (c *Converter) UserToAPIUser(ctx context.Context, u *gtsmodel.User) *apimod
 		user.ResetPasswordSentAt = util.FormatISO8601(u.ResetPasswordSentAt)
 	}
 
+	if !u.TwoFactorEnabledAt.IsZero() {
+		user.TwoFactorEnabledAt = util.FormatISO8601(u.TwoFactorEnabledAt)
+	}
+
 	return user
 }
 
@@ -540,6 +544,7 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		disabled               bool
 		role                   = *c.APIAccountDisplayRoleToAPIAccountRoleSensitive(nil)
 		createdByApplicationID string
+		invitedByAccountID     string
 	)
 
 	if err := c.state.DB.PopulateAccount(ctx, a); err != nil {
@@ -586,6 +591,19 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		approved = *user.Approved
 		disabled = *user.Disabled
 		createdByApplicationID = user.CreatedByApplicationID
+
+		if user.InviteID != "" {
+			// User signed up with an invite,
+			// include who created the invite.
+			invite, err := c.state.DB.GetInviteByID(ctx, user.InviteID)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return nil, fmt.Errorf("AccountToAdminAPIAccount: error getting invite %s from database: %w", user.InviteID, err)
+			}
+
+			if invite != nil {
+				invitedByAccountID = invite.AccountID
+			}
+		}
 	}
 
 	apiAccount, err := c.AccountToAPIAccountPublic(ctx, a)
@@ -611,7 +629,7 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		Suspended:              !a.SuspendedAt.IsZero(),
 		Account:                apiAccount,
 		CreatedByApplicationID: createdByApplicationID,
-		InvitedByAccountID:     "", // not implemented (yet)
+		InvitedByAccountID:     invitedByAccountID,
 	}, nil
 }
 
@@ -636,6 +654,64 @@ func (c *Converter) AppToAPIAppPublic(ctx context.Context, a *gtsmodel.Applicati
 	}, nil
 }
 
+// TokenToAPITokenInfo converts a gtsmodel token into an API model
+// token info, including the public details of the application used
+// to create the token. The token values themselves are not included.
+func (c *Converter) TokenToAPITokenInfo(ctx context.Context, t *gtsmodel.Token) (*apimodel.TokenInfo, error) {
+	app, err := c.state.DB.GetApplicationByClientID(ctx, t.ClientID)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting application for token %s: %w", t.ID, err)
+	}
+
+	apiApp, err := c.AppToAPIAppPublic(ctx, app)
+	if err != nil {
+		return nil, gtserror.Newf("error converting application: %w", err)
+	}
+
+	createdAt := t.AccessCreateAt
+	if createdAt.IsZero() {
+		createdAt = t.CreatedAt
+	}
+
+	tokenInfo := &apimodel.TokenInfo{
+		ID:          t.ID,
+		CreatedAt:   util.FormatISO8601(createdAt),
+		Scope:       t.Scope,
+		Application: apiApp,
+	}
+
+	if !t.LastUsed.IsZero() {
+		tokenInfo.LastUsed = util.FormatISO8601(t.LastUsed)
+	}
+
+	return tokenInfo, nil
+}
+
+// InviteToAPIInvite converts the given invite to its API representation.
+func (c *Converter) InviteToAPIInvite(i *gtsmodel.Invite) *apimodel.Invite {
+	apiInvite := &apimodel.Invite{
+		ID:          i.ID,
+		Code:        i.Code,
+		URL:         config.GetProtocol() + "://" + config.GetHost() + "/signup?invite=" + i.Code,
+		CreatedAt:   util.FormatISO8601(i.CreatedAt),
+		Uses:        i.Uses,
+		AutoApprove: util.PtrOrZero(i.AutoApprove),
+		Expired:     !i.Usable(),
+	}
+
+	if !i.ExpiresAt.IsZero() {
+		expiresAt := util.FormatISO8601(i.ExpiresAt)
+		apiInvite.ExpiresAt = &expiresAt
+	}
+
+	if i.MaxUses > 0 {
+		maxUses := i.MaxUses
+		apiInvite.MaxUses = &maxUses
+	}
+
+	return apiInvite
+}
+
 // AttachmentToAPIAttachment converts a gts model media attacahment into its api representation for serialization on the API.
 func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, media *gtsmodel.MediaAttachment) (apimodel.Attachment, error) {
 	var api apimodel.Attachment
@@ -692,6 +768,43 @@ func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, media *gtsmod
 	return api, nil
 }
 
+// CardToAPICard converts a gts model preview card into its api (frontend) representation for serialization on the API.
+func (c *Converter) CardToAPICard(ctx context.Context, card *gtsmodel.Card) apimodel.Card {
+	api := apimodel.Card{
+		URL:          card.URL,
+		Title:        card.Title,
+		Description:  card.Description,
+		Language:     card.Language,
+		Type:         card.Type.String(),
+		AuthorName:   card.AuthorName,
+		AuthorURL:    card.AuthorURL,
+		ProviderName: card.ProviderName,
+		ProviderURL:  card.ProviderURL,
+		HTML:         card.HTML,
+		Width:        card.Width,
+		Height:       card.Height,
+		EmbedURL:     card.EmbedURL,
+	}
+
+	// Only add image details if
+	// we have it stored locally,
+	// preferring the thumbnail.
+	if image := card.Image; image != nil {
+		switch {
+		case image.Thumbnail.Path != "":
+			api.Image = image.Thumbnail.URL
+		case image.File.Path != "":
+			api.Image = image.URL
+		}
+
+		if api.Image != "" {
+			api.Blurhash = image.Blurhash
+		}
+	}
+
+	return api
+}
+
 // MentionToAPIMention converts a gts model mention into its api (frontend) representation for serialization on the API.
 func (c *Converter) MentionToAPIMention(ctx context.Context, m *gtsmodel.Mention) (apimodel.Mention, error) {
 	if m.TargetAccount == nil {
@@ -794,12 +907,12 @@ func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, stubHistor
 	return apimodel.Tag{
 		Name: strings.ToLower(t.Name),
 		URL:  uris.URIForTag(t.Name),
-		History: func() *[]any {
+		History: func() *[]apimodel.History {
 			if !stubHistory {
 				return nil
 			}
 
-			h := make([]any, 0)
+			h := make([]apimodel.History, 0)
 			return &h
 		}(),
 		Following: following,
@@ -881,6 +994,36 @@ func (c *Converter) statusToAPIStatus(
 		apiStatus.Reblog.Account = boostAcct
 	}
 
+	// Embed quoted status (if set), or the
+	// quoted status of the boosted status.
+	quoting, apiQuoting := status, apiStatus
+	if apiStatus.Reblog != nil {
+		quoting, apiQuoting = status.BoostOf, apiStatus.Reblog.Status
+	}
+
+	quoted, err := c.quotedStatusToFrontend(ctx,
+		quoting,
+		apiQuoting,
+		requestingAccount,
+		filterContext,
+		filters,
+		mutes,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if quoted != nil {
+		// Convert author of quoted status to API model.
+		quoteAcct, err := c.AccountToAPIAccountPublic(ctx, quoting.Quote.Account)
+		if err != nil {
+			return nil, gtserror.Newf("error converting quote acct: %w", err)
+		}
+		quoted.Account = quoteAcct
+		apiQuoting.Quote.QuotedStatus = quoted
+		apiQuoting.Quote.QuotedStatusID = ""
+	}
+
 	if placeholdAttachments {
 		// Normalize status for API by pruning attachments
 		// that were not able to be locally stored, and replacing
@@ -1097,6 +1240,17 @@ func filterAppliesInContext(filter *gtsmodel.Filter, filterContext statusfilter.
 func (c *Converter) StatusToWebStatus(
 	ctx context.Context,
 	s *gtsmodel.Status,
+) (*apimodel.WebStatus, error) {
+	return c.statusToWebStatus(ctx, s, true)
+}
+
+// statusToWebStatus is the package-internal implementation
+// of StatusToWebStatus that lets the caller choose whether
+// to embed the quoted status, so quotes don't nest deeply.
+func (c *Converter) statusToWebStatus(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	embedQuote bool,
 ) (*apimodel.WebStatus, error) {
 	apiStatus, err := c.statusToFrontend(ctx, s,
 		nil,                            // No authed requester.
@@ -1182,6 +1336,17 @@ func (c *Converter) StatusToWebStatus(
 		webStatus.PollOptions = PollOptions
 	}
 
+	if len(s.EditIDs) > 0 {
+		// Status has been edited, get all revisions
+		// and drop the last one, which is current.
+		edits, err := c.StatusToAPIEdits(ctx, nil, s)
+		if err != nil {
+			log.Errorf(ctx, "error converting status edits: %v", err)
+		} else {
+			webStatus.Edits = edits[:len(edits)-1]
+		}
+	}
+
 	// Mark local.
 	webStatus.Local = *s.Local
 
@@ -1214,24 +1379,226 @@ func (c *Converter) StatusToWebStatus(
 		}
 	}
 
+	if embedQuote && s.Quote != nil &&
+		s.QuoteState == gtsmodel.QuoteStateAccepted {
+		// Embed quoted status if it's visible to
+		// unauthenticated viewers via the web.
+		visible, err := c.visFilter.StatusVisible(ctx, nil, s.Quote)
+		if err != nil {
+			return nil, gtserror.Newf("error checking quote visibility: %w", err)
+		}
+
+		if visible {
+			webStatus.QuotedStatus, err = c.statusToWebStatus(ctx, s.Quote, false)
+			if err != nil {
+				log.Errorf(ctx, "error converting quoted status: %v", err)
+			}
+		}
+	}
+
 	return webStatus, nil
 }
 
+// quoteStateToAPIQuoteState returns the API
+// quote state for the given quoting status.
+func quoteStateToAPIQuoteState(s *gtsmodel.Status) string {
+	switch s.QuoteState {
+	case gtsmodel.QuoteStateAccepted:
+		if s.Quote == nil {
+			// Quote was accepted, but
+			// quoted status is gone.
+			return "deleted"
+		}
+		return "accepted"
+	case gtsmodel.QuoteStateRejected:
+		return "rejected"
+	default:
+		// Either pending, or we don't
+		// (yet) know about the quote.
+		return "pending"
+	}
+}
+
+// quotedStatusToFrontend converts the status quoted by the
+// given quoting status to its frontend representation, if
+// the quote was accepted and the quoted status is visible
+// to the requester. If not visible, the state of apiStatus'
+// quote is updated. Quotes within the quoted status are
+// left shallow, to prevent possible recursion issues.
+//
+// This function doesn't handle converting the
+// account to api/web model -- the caller must do that.
+func (c *Converter) quotedStatusToFrontend(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	apiStatus *apimodel.Status,
+	requestingAccount *gtsmodel.Account,
+	filterContext statusfilter.FilterContext,
+	filters []*gtsmodel.Filter,
+	mutes *usermute.CompiledUserMuteList,
+) (*apimodel.Status, error) {
+	if apiStatus.Quote == nil ||
+		apiStatus.Quote.State != "accepted" {
+		// Nothing to embed.
+		return nil, nil
+	}
+
+	visible, err := c.visFilter.StatusVisible(ctx, requestingAccount, s.Quote)
+	if err != nil {
+		return nil, gtserror.Newf("error checking quote visibility: %w", err)
+	}
+
+	if !visible {
+		apiStatus.Quote.State = "unauthorized"
+		return nil, nil
+	}
+
+	quoted, err := c.baseStatusToFrontend(ctx,
+		s.Quote,
+		requestingAccount,
+		filterContext,
+		filters,
+		mutes,
+	)
+	if errors.Is(err, statusfilter.ErrHideStatus) {
+		// If we'd hide the quoted status, don't
+		// embed it, but still show the quote.
+		return nil, nil
+	} else if err != nil {
+		return nil, gtserror.Newf("error converting quoted status: %w", err)
+	}
+
+	return quoted, nil
+}
+
 // StatusToAPIStatusSource returns the *apimodel.StatusSource of the given status.
 // Callers should check beforehand whether a requester has permission to view the
 // source of the status, and ensure they're passing only a local status into this function.
 func (c *Converter) StatusToAPIStatusSource(ctx context.Context, s *gtsmodel.Status) (*apimodel.StatusSource, error) {
-	// TODO: remove this when edit support is added.
-	text := "**STATUS EDITS ARE NOT CURRENTLY SUPPORTED IN GOTOSOCIAL (coming in 2024)**\n" +
-		"You can review the original text of your status below, but you will not be able to submit this edit.\n\n---\n\n" + s.Text
-
 	return &apimodel.StatusSource{
 		ID:          s.ID,
-		Text:        text,
+		Text:        s.Text,
 		SpoilerText: s.ContentWarning,
 	}, nil
 }
 
+// StatusToAPIEdits converts a status and its previous revisions into a slice of
+// *apimodel.StatusEdit, ordered oldest first, with the current version last.
+// Callers should check beforehand whether a requester has permission to view the
+// status. Requesting account can be nil, and is only used to show poll votes.
+func (c *Converter) StatusToAPIEdits(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	s *gtsmodel.Status,
+) ([]*apimodel.StatusEdit, error) {
+	// Try to populate status struct pointer fields,
+	// (including edits), continuing where possible.
+	if err := c.state.DB.PopulateStatus(ctx, s); err != nil {
+		if s.Account == nil {
+			return nil, gtserror.Newf("error(s) populating status, required account not set: %w", err)
+		}
+		log.Errorf(ctx, "error(s) populating status, will continue: %v", err)
+	}
+
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, s.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting status author: %w", err)
+	}
+
+	// Emojis aren't stored per revision,
+	// so just use those of the status.
+	apiEmojis, err := c.convertEmojisToAPIEmojis(ctx, s.Emojis, s.EmojiIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting status emojis: %v", err)
+	}
+
+	apiEdits := make([]*apimodel.StatusEdit, 0, len(s.Edits)+1)
+
+	for _, edit := range s.Edits {
+		apiEdit := &apimodel.StatusEdit{
+			Content:          edit.Content,
+			SpoilerText:      edit.ContentWarning,
+			Sensitive:        util.PtrOrZero(edit.Sensitive),
+			CreatedAt:        util.FormatISO8601(edit.CreatedAt),
+			Account:          apiAccount,
+			MediaAttachments: make([]*apimodel.Attachment, 0, len(edit.Attachments)),
+			Emojis:           apiEmojis,
+		}
+
+		for _, attachment := range edit.Attachments {
+			apiAttachment, err := c.AttachmentToAPIAttachment(ctx, attachment)
+			if err != nil {
+				log.Errorf(ctx, "error converting edit attachment %s: %v", attachment.ID, err)
+				continue
+			}
+
+			// Set description as it
+			// was at this revision.
+			i := slices.Index(edit.AttachmentIDs, attachment.ID)
+			if i >= 0 && i < len(edit.AttachmentDescriptions) {
+				apiAttachment.Description = util.PtrIf(edit.AttachmentDescriptions[i])
+			}
+
+			apiEdit.MediaAttachments = append(apiEdit.MediaAttachments, &apiAttachment)
+		}
+
+		if len(edit.PollOptions) > 0 {
+			// Only the options of a previous poll
+			// are stored, and the votes if reset.
+			apiPoll := &apimodel.Poll{
+				Options: make([]apimodel.PollOption, len(edit.PollOptions)),
+				Emojis:  apiEmojis,
+			}
+
+			for i, title := range edit.PollOptions {
+				apiPoll.Options[i].Title = title
+				if i < len(edit.PollVotes) {
+					apiPoll.Options[i].VotesCount = util.Ptr(edit.PollVotes[i])
+					apiPoll.VotesCount += edit.PollVotes[i]
+				}
+			}
+
+			apiEdit.Poll = apiPoll
+		}
+
+		apiEdits = append(apiEdits, apiEdit)
+	}
+
+	// The current revision was created
+	// either at the time of last edit, or
+	// the status' creation if never edited.
+	createdAt := s.EditedAt
+	if createdAt.IsZero() {
+		createdAt = s.CreatedAt
+	}
+
+	apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, s.Attachments, s.AttachmentIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting status attachments: %v", err)
+	}
+
+	var apiPoll *apimodel.Poll
+	if s.Poll != nil {
+		apiPoll, err = c.PollToAPIPoll(ctx, requester, s.Poll)
+		if err != nil {
+			return nil, gtserror.Newf("error converting poll: %w", err)
+		}
+	}
+
+	apiEdits = append(apiEdits, &apimodel.StatusEdit{
+		Content:          s.Content,
+		SpoilerText:      s.ContentWarning,
+		Sensitive:        util.PtrOrZero(s.Sensitive),
+		CreatedAt:        util.FormatISO8601(createdAt),
+		Account:          apiAccount,
+		Poll:             apiPoll,
+		MediaAttachments: apiAttachments,
+		Emojis:           apiEmojis,
+	})
+
+	return apiEdits, nil
+}
+
 // statusToFrontend is a package internal function for
 // parsing a status into its initial frontend representation.
 //
@@ -1374,6 +1741,7 @@ func (c *Converter) baseStatusToFrontend(
 	apiStatus := &apimodel.Status{
 		ID:                 s.ID,
 		CreatedAt:          util.FormatISO8601(s.CreatedAt),
+		EditedAt:           nil, // Set below.
 		InReplyToID:        nil, // Set below.
 		InReplyToAccountID: nil, // Set below.
 		Sensitive:          *s.Sensitive,
@@ -1394,12 +1762,16 @@ func (c *Converter) baseStatusToFrontend(
 		Mentions:           apiMentions,
 		Tags:               apiTags,
 		Emojis:             apiEmojis,
-		Card:               nil, // TODO: implement cards
+		Card:               nil, // Set below.
 		Text:               s.Text,
 		InteractionPolicy:  *apiInteractionPolicy,
 	}
 
 	// Nullable fields.
+	if !s.EditedAt.IsZero() {
+		apiStatus.EditedAt = util.Ptr(util.FormatISO8601(s.EditedAt))
+	}
+
 	if s.InReplyToID != "" {
 		apiStatus.InReplyToID = util.Ptr(s.InReplyToID)
 	}
@@ -1412,6 +1784,20 @@ func (c *Converter) baseStatusToFrontend(
 		apiStatus.Language = util.Ptr(s.Language)
 	}
 
+	if s.IsQuote() {
+		// Only a shallow quote is set here, the quoted
+		// status is embedded by callers where relevant.
+		apiStatus.Quote = &apimodel.StatusQuote{
+			State:          quoteStateToAPIQuoteState(s),
+			QuotedStatusID: s.QuoteID,
+		}
+	}
+
+	if s.Card != nil {
+		apiCard := c.CardToAPICard(ctx, s.Card)
+		apiStatus.Card = &apiCard
+	}
+
 	if app := s.CreatedWithApplication; app != nil {
 		apiStatus.Application, err = c.AppToAPIAppPublic(ctx, app)
 		if err != nil {
@@ -1521,6 +1907,101 @@ func (c *Converter) InstanceRuleToAdminAPIRule(r *gtsmodel.Rule) *apimodel.Admin
 	}
 }
 
+// RelayToAdminAPIRelay converts a relay into its api equivalent for serving at /api/v1/admin/relays/:id
+func (c *Converter) RelayToAdminAPIRelay(r *gtsmodel.Relay) *apimodel.AdminRelay {
+	return &apimodel.AdminRelay{
+		ID:        r.ID,
+		InboxURL:  r.InboxURI,
+		ActorURL:  r.ActorURI,
+		State:     r.State.String(),
+		Error:     r.Error,
+		CreatedBy: r.CreatedByAccountID,
+		CreatedAt: util.FormatISO8601(r.CreatedAt),
+		UpdatedAt: util.FormatISO8601(r.UpdatedAt),
+	}
+}
+
+// AnnouncementToAPIAnnouncement converts an announcement into its api equivalent for serving at /api/v1/announcements.
+//
+// If requester is set, the read status of the announcement and the requester's
+// own reactions will be filled in. Requester can be nil, eg., for web views.
+func (c *Converter) AnnouncementToAPIAnnouncement(
+	ctx context.Context,
+	a *gtsmodel.Announcement,
+	requester *gtsmodel.Account,
+) (*apimodel.Announcement, error) {
+	apiAnnouncement := &apimodel.Announcement{
+		ID:        a.ID,
+		Content:   a.Content,
+		AllDay:    *a.AllDay,
+		UpdatedAt: util.FormatISO8601(a.UpdatedAt),
+		Published: a.Published(),
+		Mentions:  []apimodel.Mention{},
+		Statuses:  []apimodel.Status{},
+		Tags:      []apimodel.Tag{},
+	}
+
+	if !a.StartsAt.IsZero() {
+		apiAnnouncement.StartsAt = util.FormatISO8601(a.StartsAt)
+	}
+
+	if !a.EndsAt.IsZero() {
+		apiAnnouncement.EndsAt = util.FormatISO8601(a.EndsAt)
+	}
+
+	if a.Published() {
+		apiAnnouncement.PublishedAt = util.FormatISO8601(a.PublishedAt)
+	}
+
+	var err error
+	apiAnnouncement.Emojis, err = c.convertEmojisToAPIEmojis(ctx, a.Emojis, a.EmojiIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting announcement emojis: %v", err)
+	}
+
+	if requester != nil {
+		apiAnnouncement.Read, err = c.state.DB.IsAnnouncementDismissed(ctx, a.ID, requester.ID)
+		if err != nil {
+			return nil, gtserror.Newf("error checking announcement dismissal: %w", err)
+		}
+	}
+
+	reactions, err := c.state.DB.GetAnnouncementReactions(ctx, a.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error getting announcement reactions: %w", err)
+	}
+
+	// Aggregate reactions by name,
+	// keeping them in order of when
+	// each was first reacted with.
+	apiAnnouncement.Reactions = []apimodel.AnnouncementReaction{}
+	idx := make(map[string]int, len(reactions))
+	for _, reaction := range reactions {
+		i, ok := idx[reaction.Name]
+		if !ok {
+			apiReaction := apimodel.AnnouncementReaction{
+				Name: reaction.Name,
+			}
+
+			if reaction.Emoji != nil {
+				apiReaction.URL = reaction.Emoji.ImageURL
+				apiReaction.StaticURL = reaction.Emoji.ImageStaticURL
+			}
+
+			i = len(apiAnnouncement.Reactions)
+			idx[reaction.Name] = i
+			apiAnnouncement.Reactions = append(apiAnnouncement.Reactions, apiReaction)
+		}
+
+		apiAnnouncement.Reactions[i].Count++
+		if requester != nil && reaction.AccountID == requester.ID {
+			apiAnnouncement.Reactions[i].Me = true
+		}
+	}
+
+	return apiAnnouncement, nil
+}
+
 // InstanceToAPIV1Instance converts a gts instance into its api equivalent for serving at /api/v1/instance
 func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Instance) (*apimodel.InstanceV1, error) {
 	domain := i.Domain
@@ -1541,8 +2022,8 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 		Version:              config.GetSoftwareVersion(),
 		Languages:            config.GetInstanceLanguages().TagStrs(),
 		Registrations:        config.GetAccountsRegistrationOpen(),
-		ApprovalRequired:     true,                               // approval always required
-		InvitesEnabled:       false,                              // todo: not supported yet
+		ApprovalRequired:     true, // approval always required
+		InvitesEnabled:       config.GetAccountsInviteRole() == config.AccountsInviteRoleUser,
 		MaxTootChars:         uint(config.GetStatusesMaxChars()), // #nosec G115 -- Already validated.
 		Rules:                c.InstanceRulesToAPIRules(i.Rules),
 		Terms:                i.Terms,
@@ -1728,6 +2209,12 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.Emojis.EmojiSizeLimit = int(config.GetMediaEmojiLocalMaxSize()) // #nosec G115 -- Already validated.
 	instance.Configuration.OIDCEnabled = config.GetOIDCEnabled()
 
+	vapidKeyPair, err := c.state.DB.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("InstanceToAPIV2Instance: db error getting VAPID key pair: %w", err)
+	}
+	instance.Configuration.VAPID.PublicKey = vapidKeyPair.PublicKey
+
 	// registrations
 	instance.Registrations.Enabled = config.GetAccountsRegistrationOpen()
 	instance.Registrations.ApprovalRequired = true // always required
@@ -1844,6 +2331,68 @@ func (c *Converter) NotificationToAPINotification(
 	}, nil
 }
 
+// NotificationPolicyToAPINotificationPolicy converts a notification policy into its
+// API representation, with the given numbers of pending requests and notifications.
+func (c *Converter) NotificationPolicyToAPINotificationPolicy(
+	policy *gtsmodel.NotificationPolicy,
+	pendingRequests int,
+	pendingNotifications int,
+) *apimodel.NotificationPolicy {
+	return &apimodel.NotificationPolicy{
+		ForNotFollowing:    string(policy.ForNotFollowing),
+		ForNotFollowers:    string(policy.ForNotFollowers),
+		ForNewAccounts:     string(policy.ForNewAccounts),
+		ForPrivateMentions: string(policy.ForPrivateMentions),
+		ForLimitedAccounts: string(policy.ForLimitedAccounts),
+		Summary: apimodel.NotificationPolicySummary{
+			PendingRequestsCount:      pendingRequests,
+			PendingNotificationsCount: pendingNotifications,
+		},
+	}
+}
+
+// NotificationRequestToAPINotificationRequest converts a notification request into its
+// API representation. The last status will be filtered using the notification filter
+// context, and may be nil if the status was hidden or has been deleted.
+func (c *Converter) NotificationRequestToAPINotificationRequest(
+	ctx context.Context,
+	req *gtsmodel.NotificationRequest,
+	filters []*gtsmodel.Filter,
+	mutes *usermute.CompiledUserMuteList,
+) (*apimodel.NotificationRequest, error) {
+	if err := c.state.DB.PopulateNotificationRequest(ctx, req); err != nil {
+		return nil, gtserror.Newf("error populating notification request: %w", err)
+	}
+
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, req.FromAccount)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account to api: %w", err)
+	}
+
+	apiReq := &apimodel.NotificationRequest{
+		ID:                 req.ID,
+		CreatedAt:          util.FormatISO8601(req.CreatedAt),
+		UpdatedAt:          util.FormatISO8601(req.UpdatedAt),
+		Account:            apiAccount,
+		NotificationsCount: strconv.Itoa(req.NotificationsCount),
+	}
+
+	if req.LastStatus != nil {
+		apiReq.LastStatus, err = c.StatusToAPIStatus(ctx,
+			req.LastStatus,
+			req.Account,
+			statusfilter.FilterContextNotifications,
+			filters,
+			mutes,
+		)
+		if err != nil && !errors.Is(err, statusfilter.ErrHideStatus) {
+			return nil, gtserror.Newf("error converting status to api: %w", err)
+		}
+	}
+
+	return apiReq, nil
+}
+
 // ConversationToAPIConversation converts a conversation into its API representation.
 // The conversation status will be filtered using the notification filter context,
 // and may be nil if the status was hidden.
@@ -1970,9 +2519,59 @@ func (c *Converter) DomainPermToAPIDomainPerm(
 	domainPerm.CreatedBy = d.GetCreatedByAccountID()
 	domainPerm.CreatedAt = util.FormatISO8601(d.GetCreatedAt())
 
+	// Drafts may be of either type,
+	// so indicate which one this is.
+	if draft, ok := d.(*gtsmodel.DomainPermissionDraft); ok {
+		domainPerm.PermissionType = draft.PermissionType.String()
+	}
+
 	return domainPerm, nil
 }
 
+// DomainPermSubToAPIDomainPermSub converts the given
+// gtsmodel domain permission subscription into an api
+// model domain permission subscription. Count should be
+// the number of domain permissions currently owned by
+// the subscription.
+func (c *Converter) DomainPermSubToAPIDomainPermSub(
+	ctx context.Context,
+	d *gtsmodel.DomainPermissionSubscription,
+	count int,
+) (*apimodel.DomainPermissionSubscription, error) {
+	var (
+		fetchedAt             string
+		successfullyFetchedAt string
+	)
+
+	if !d.FetchedAt.IsZero() {
+		fetchedAt = util.FormatISO8601(d.FetchedAt)
+	}
+
+	if !d.SuccessfullyFetchedAt.IsZero() {
+		successfullyFetchedAt = util.FormatISO8601(d.SuccessfullyFetchedAt)
+	}
+
+	return &apimodel.DomainPermissionSubscription{
+		ID:                    d.ID,
+		Priority:              d.Priority,
+		Title:                 d.Title,
+		PermissionType:        d.PermissionType.String(),
+		AsDraft:               *d.AsDraft,
+		AdoptOrphans:          *d.AdoptOrphans,
+		RemoveRetracted:       *d.RemoveRetracted,
+		CreatedBy:             d.CreatedByAccountID,
+		CreatedAt:             util.FormatISO8601(d.CreatedAt),
+		URI:                   d.URI,
+		ContentType:           d.ContentType.String(),
+		FetchUsername:         d.FetchUsername,
+		FetchPassword:         d.FetchPassword,
+		FetchedAt:             fetchedAt,
+		SuccessfullyFetchedAt: successfullyFetchedAt,
+		Error:                 d.Error,
+		Count:                 uint64(count), //nolint:gosec
+	}, nil
+}
+
 // ReportToAPIReport converts a gts model report into an api model report, for serving at /api/v1/reports
 func (c *Converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (*apimodel.Report, error) {
 	report := &apimodel.Report{
@@ -2546,6 +3145,20 @@ func (c *Converter) InteractionPolicyToAPIInteractionPolicy(
 			Always:       policyValsToAPIPolicyVals(policy.CanAnnounce.Always),
 			WithApproval: policyValsToAPIPolicyVals(policy.CanAnnounce.WithApproval),
 		},
+		CanQuote: apimodel.PolicyRules{
+			Always:       policyValsToAPIPolicyVals(policy.CanQuote.Always),
+			WithApproval: policyValsToAPIPolicyVals(policy.CanQuote.WithApproval),
+		},
+	}
+
+	if status != nil {
+		// Fall back to default quote rules
+		// for policies that predate quotes.
+		canQuote := policy.CanQuoteFor(status.Visibility)
+		apiPolicy.CanQuote = apimodel.PolicyRules{
+			Always:       policyValsToAPIPolicyVals(canQuote.Always),
+			WithApproval: policyValsToAPIPolicyVals(canQuote.WithApproval),
+		}
 	}
 
 	if status == nil || requester == nil {
@@ -2617,6 +3230,26 @@ func (c *Converter) InteractionPolicyToAPIInteractionPolicy(
 		)
 	}
 
+	quoteable, err := c.intFilter.StatusQuoteable(ctx, requester, status)
+	if err != nil {
+		err := gtserror.Newf("error checking status quoteable by requester: %w", err)
+		return nil, err
+	}
+
+	if quoteable.Permission == gtsmodel.PolicyPermissionPermitted {
+		// We can do this!
+		apiPolicy.CanQuote.Always = append(
+			apiPolicy.CanQuote.Always,
+			apimodel.PolicyValueMe,
+		)
+	} else if quoteable.Permission == gtsmodel.PolicyPermissionWithApproval {
+		// We can do this with approval.
+		apiPolicy.CanQuote.WithApproval = append(
+			apiPolicy.CanQuote.WithApproval,
+			apimodel.PolicyValueMe,
+		)
+	}
+
 	return apiPolicy, nil
 }
 
@@ -2751,6 +3384,24 @@ func (c *Converter) InteractionReqToAPIInteractionReq(
 		}
 	}
 
+	var quote *apimodel.Status
+	if req.InteractionType == gtsmodel.InteractionQuote && req.Quote != nil {
+		quote, err = c.statusToAPIStatus(
+			ctx,
+			req.Quote,
+			requestingAcct,
+			statusfilter.FilterContextNone,
+			nil,  // No filters.
+			nil,  // No mutes.
+			true, // Placehold unknown attachments.
+			false,
+		)
+		if err != nil {
+			err := gtserror.Newf("error converting quote: %w", err)
+			return nil, err
+		}
+	}
+
 	var acceptedAt string
 	if req.IsAccepted() {
 		acceptedAt = util.FormatISO8601(req.AcceptedAt)
@@ -2768,8 +3419,179 @@ func (c *Converter) InteractionReqToAPIInteractionReq(
 		Account:    interactingAcct,
 		Status:     interactedStatus,
 		Reply:      reply,
+		Quote:      quote,
 		AcceptedAt: acceptedAt,
 		RejectedAt: rejectedAt,
 		URI:        req.URI,
 	}, nil
 }
+
+// WebPushSubscriptionToAPIWebPushSubscription converts a
+// gtsmodel Web Push subscription to its API representation.
+func (c *Converter) WebPushSubscriptionToAPIWebPushSubscription(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+) (*apimodel.WebPushSubscription, error) {
+	keyPair, err := c.state.DB.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		return nil, gtserror.Newf("error getting VAPID key pair: %w", err)
+	}
+
+	flags := subscription.NotificationFlags
+	return &apimodel.WebPushSubscription{
+		ID:        subscription.ID,
+		Endpoint:  subscription.Endpoint,
+		ServerKey: keyPair.PublicKey,
+		Alerts: apimodel.WebPushSubscriptionAlerts{
+			Follow:           flags.Get(gtsmodel.NotificationFollow),
+			FollowRequest:    flags.Get(gtsmodel.NotificationFollowRequest),
+			Favourite:        flags.Get(gtsmodel.NotificationFave),
+			Mention:          flags.Get(gtsmodel.NotificationMention),
+			Reblog:           flags.Get(gtsmodel.NotificationReblog),
+			Poll:             flags.Get(gtsmodel.NotificationPoll),
+			Status:           flags.Get(gtsmodel.NotificationStatus),
+			AdminSignup:      flags.Get(gtsmodel.NotificationSignup),
+			PendingFavourite: flags.Get(gtsmodel.NotificationPendingFave),
+			PendingReply:     flags.Get(gtsmodel.NotificationPendingReply),
+			PendingReblog:    flags.Get(gtsmodel.NotificationPendingReblog),
+			Quote:            flags.Get(gtsmodel.NotificationQuote),
+			PendingQuote:     flags.Get(gtsmodel.NotificationPendingQuote),
+		},
+		Policy: string(subscription.Policy),
+	}, nil
+}
+
+// ScheduledStatusToAPIScheduledStatus converts a gtsmodel
+// scheduled status to its API representation. Media
+// attachments will be populated if not already present.
+func (c *Converter) ScheduledStatusToAPIScheduledStatus(
+	ctx context.Context,
+	scheduledStatus *gtsmodel.ScheduledStatus,
+) (*apimodel.ScheduledStatus, error) {
+	if !scheduledStatus.AttachmentsPopulated() {
+		var err error
+		scheduledStatus.MediaAttachments, err = c.state.DB.GetAttachmentsByIDs(ctx, scheduledStatus.MediaIDs)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("error getting attachments: %w", err)
+		}
+	}
+
+	apiAttachments := make([]*apimodel.Attachment, 0, len(scheduledStatus.MediaAttachments))
+	for _, attachment := range scheduledStatus.MediaAttachments {
+		apiAttachment, err := c.AttachmentToAPIAttachment(ctx, attachment)
+		if err != nil {
+			return nil, gtserror.Newf("error converting attachment %s: %w", attachment.ID, err)
+		}
+		apiAttachments = append(apiAttachments, &apiAttachment)
+	}
+
+	mediaIDs := scheduledStatus.MediaIDs
+	if mediaIDs == nil {
+		mediaIDs = []string{}
+	}
+
+	params := &apimodel.StatusParams{
+		Text:          scheduledStatus.Text,
+		MediaIDs:      mediaIDs,
+		Sensitive:     util.PtrOrValue(scheduledStatus.Sensitive, false),
+		SpoilerText:   scheduledStatus.SpoilerText,
+		Visibility:    c.VisToAPIVis(ctx, scheduledStatus.Visibility),
+		LocalOnly:     util.PtrOrValue(scheduledStatus.LocalOnly, false),
+		InReplyToID:   scheduledStatus.InReplyToID,
+		QuoteID:       scheduledStatus.QuoteID,
+		Language:      scheduledStatus.Language,
+		ContentType:   apimodel.StatusContentType(scheduledStatus.ContentType),
+		ApplicationID: scheduledStatus.ApplicationID,
+	}
+
+	if poll := scheduledStatus.Poll; poll != nil {
+		params.Poll = &apimodel.StatusParamsPoll{
+			Options:    poll.Options,
+			ExpiresIn:  poll.ExpiresIn,
+			Multiple:   poll.Multiple,
+			HideTotals: poll.HideTotals,
+		}
+	}
+
+	if policy := scheduledStatus.InteractionPolicy; policy != nil {
+		apiPolicy, err := c.InteractionPolicyToAPIInteractionPolicy(ctx, policy, nil, nil)
+		if err != nil {
+			return nil, gtserror.Newf("error converting interaction policy: %w", err)
+		}
+		params.InteractionPolicy = apiPolicy
+	}
+
+	return &apimodel.ScheduledStatus{
+		ID:               scheduledStatus.ID,
+		ScheduledAt:      util.FormatISO8601(scheduledStatus.ScheduledAt),
+		Params:           params,
+		MediaAttachments: apiAttachments,
+	}, nil
+}
+
+// StaffPickToAdminAPIStaffPick converts a staff pick into its api equivalent for serving at /api/v1/admin/staff_picks.
+func (c *Converter) StaffPickToAdminAPIStaffPick(ctx context.Context, s *gtsmodel.StaffPick) (*apimodel.AdminStaffPick, error) {
+	if s.Account == nil {
+		return nil, gtserror.Newf("staff pick %s account was nil", s.ID)
+	}
+
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, s.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting staff pick account: %w", err)
+	}
+
+	return &apimodel.AdminStaffPick{
+		ID:        s.ID,
+		CreatedAt: util.FormatISO8601(s.CreatedAt),
+		Account:   apiAccount,
+	}, nil
+}
+
+// ImportToAPIImport converts an import into its api equivalent for serving at /api/v1/import.
+func (c *Converter) ImportToAPIImport(ctx context.Context, i *gtsmodel.Import) *apimodel.Import {
+	mode := "merge"
+	if util.PtrOrValue(i.Overwrite, false) {
+		mode = "overwrite"
+	}
+
+	errs := i.Errors
+	if errs == nil {
+		errs = []string{}
+	}
+
+	return &apimodel.Import{
+		ID:             i.ID,
+		Type:           i.Type.String(),
+		Mode:           mode,
+		State:          i.State.String(),
+		CreatedAt:      util.FormatISO8601(i.CreatedAt),
+		UpdatedAt:      util.FormatISO8601(i.UpdatedAt),
+		TotalItems:     i.TotalItems,
+		ProcessedItems: i.ProcessedItems,
+		FailedItems:    i.FailedItems,
+		Errors:         errs,
+	}
+}
+
+// AccountArchiveToAPIAccountArchive converts a gts model account archive into its api representation.
+func (c *Converter) AccountArchiveToAPIAccountArchive(ctx context.Context, a *gtsmodel.AccountArchive) *apimodel.AccountArchive {
+	var expiresAt *string
+	if !a.ExpiresAt.IsZero() {
+		expiresAt = util.Ptr(util.FormatISO8601(a.ExpiresAt))
+	}
+
+	var url *string
+	if a.Ready() {
+		url = util.Ptr(config.GetProtocol() + "://" + config.GetHost() + "/api/v1/exports/archive/" + a.ID + "/download")
+	}
+
+	return &apimodel.AccountArchive{
+		ID:        a.ID,
+		State:     a.State.String(),
+		CreatedAt: util.FormatISO8601(a.CreatedAt),
+		UpdatedAt: util.FormatISO8601(a.UpdatedAt),
+		ExpiresAt: expiresAt,
+		Size:      a.Size,
+		URL:       url,
+	}
+}
@@ -463,6 +463,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
 	suite.Equal(`{
   "id": "01F8MH75CBF9JFX4ZAD54N0W0R",
   "created_at": "2021-10-20T11:36:45.000Z",
+  "edited_at": null,
   "in_reply_to_id": null,
   "in_reply_to_account_id": null,
   "sensitive": false,
@@ -587,6 +588,13 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -641,6 +649,7 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
 	suite.Equal(`{
   "id": "01F8MH75CBF9JFX4ZAD54N0W0R",
   "created_at": "2021-10-20T11:36:45.000Z",
+  "edited_at": null,
   "in_reply_to_id": null,
   "in_reply_to_account_id": null,
   "sensitive": false,
@@ -791,6 +800,13 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -807,6 +823,7 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredBoostToFrontend() {
 	suite.Equal(`{
   "id": "01G36SF3V6Y6V5BF9P4R7PQG7G",
   "created_at": "2021-10-20T10:41:37.000Z",
+  "edited_at": null,
   "in_reply_to_id": null,
   "in_reply_to_account_id": null,
   "sensitive": false,
@@ -827,6 +844,7 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredBoostToFrontend() {
   "reblog": {
     "id": "01F8MH75CBF9JFX4ZAD54N0W0R",
     "created_at": "2021-10-20T11:36:45.000Z",
+    "edited_at": null,
     "in_reply_to_id": null,
     "in_reply_to_account_id": null,
     "sensitive": false,
@@ -973,6 +991,13 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredBoostToFrontend() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
       }
     }
   },
@@ -1064,6 +1089,13 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredBoostToFrontend() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -1218,6 +1250,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments
 	suite.Equal(`{
   "id": "01HE7XJ1CG84TBKH5V9XKBVGF5",
   "created_at": "2023-11-02T10:44:25.000Z",
+  "edited_at": null,
   "in_reply_to_id": "01F8MH75CBF9JFX4ZAD54N0W0R",
   "in_reply_to_account_id": "01F8MH17FWEB39HZJ76B6VXSKF",
   "sensitive": true,
@@ -1323,6 +1356,13 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -1350,6 +1390,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
 	suite.Equal(`{
   "id": "01HE7XJ1CG84TBKH5V9XKBVGF5",
   "created_at": "2023-11-02T10:44:25.000Z",
+  "edited_at": null,
   "in_reply_to_id": "01F8MH75CBF9JFX4ZAD54N0W0R",
   "in_reply_to_account_id": "01F8MH17FWEB39HZJ76B6VXSKF",
   "sensitive": true,
@@ -1398,6 +1439,12 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
         "public"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public"
+      ],
+      "with_approval": []
     }
   },
   "account": {
@@ -1489,6 +1536,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
   ],
   "LanguageTag": "en",
   "PollOptions": null,
+  "Edits": null,
+  "QuotedStatus": null,
   "Local": false,
   "Indent": 0,
   "ThreadLastMain": false,
@@ -1511,6 +1560,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
 	suite.Equal(`{
   "id": "01F8MH75CBF9JFX4ZAD54N0W0R",
   "created_at": "2021-10-20T11:36:45.000Z",
+  "edited_at": null,
   "in_reply_to_id": null,
   "in_reply_to_account_id": null,
   "sensitive": false,
@@ -1635,6 +1685,13 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -1654,6 +1711,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendPartialInteraction
 	suite.Equal(`{
   "id": "01F8MHBBN8120SYH7D5S050MGK",
   "created_at": "2021-10-20T10:40:37.000Z",
+  "edited_at": null,
   "in_reply_to_id": null,
   "in_reply_to_account_id": null,
   "sensitive": false,
@@ -1728,6 +1786,12 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendPartialInteraction
         "author"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "author"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -1764,6 +1828,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToAPIStatusPendingApproval()
 	suite.Equal(`{
   "id": "01J5QVB9VC76NPPRQ207GG4DRZ",
   "created_at": "2024-02-20T10:41:37.000Z",
+  "edited_at": null,
   "in_reply_to_id": "01F8MHC8VWDRBQR0N1BATDDEM5",
   "in_reply_to_account_id": "01F8MH5NBDF2MV7CTC4Q5128HF",
   "sensitive": false,
@@ -1852,6 +1917,13 @@ func (suite *InternalToFrontendTestSuite) TestStatusToAPIStatusPendingApproval()
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }
@@ -2129,6 +2201,9 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
     },
     "emojis": {
       "emoji_size_limit": 51200
+    },
+    "vapid": {
+      "public_key": "BOp8s8FC_In8M9fAPN9WpAUswyzJXb4AGjDXLSVx03fve4MexXiw9CQ64Jzs6vb4LzdIeXfjb-6ziWCDH3MvSV0"
     }
   },
   "registrations": {
@@ -2707,6 +2782,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
     {
       "id": "01FVW7JHQFSFK166WWKR8CBA6M",
       "created_at": "2021-09-20T10:40:37.000Z",
+      "edited_at": null,
       "in_reply_to_id": null,
       "in_reply_to_account_id": null,
       "sensitive": false,
@@ -2805,6 +2881,13 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
             "me"
           ],
           "with_approval": []
+        },
+        "can_quote": {
+          "always": [
+            "public",
+            "me"
+          ],
+          "with_approval": []
         }
       }
     }
@@ -3214,6 +3297,7 @@ func (suite *InternalToFrontendTestSuite) TestIntReqToAPI() {
   "status": {
     "id": "01F8MHC8VWDRBQR0N1BATDDEM5",
     "created_at": "2021-10-20T10:40:37.000Z",
+    "edited_at": null,
     "in_reply_to_id": null,
     "in_reply_to_account_id": null,
     "sensitive": true,
@@ -3301,12 +3385,20 @@ func (suite *InternalToFrontendTestSuite) TestIntReqToAPI() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
       }
     }
   },
   "reply": {
     "id": "01J5QVB9VC76NPPRQ207GG4DRZ",
     "created_at": "2024-02-20T10:41:37.000Z",
+    "edited_at": null,
     "in_reply_to_id": "01F8MHC8VWDRBQR0N1BATDDEM5",
     "in_reply_to_account_id": "01F8MH5NBDF2MV7CTC4Q5128HF",
     "sensitive": false,
@@ -3395,6 +3487,13 @@ func (suite *InternalToFrontendTestSuite) TestIntReqToAPI() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
       }
     }
   }
@@ -3474,6 +3573,7 @@ func (suite *InternalToFrontendTestSuite) TestConversationToAPISelfConvo() {
   "last_status": {
     "id": "01F8MHAMCHF6Y650WCRSCP4WMY",
     "created_at": "2021-10-20T10:40:37.000Z",
+    "edited_at": null,
     "in_reply_to_id": null,
     "in_reply_to_account_id": null,
     "sensitive": true,
@@ -3551,6 +3651,13 @@ func (suite *InternalToFrontendTestSuite) TestConversationToAPISelfConvo() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
       }
     }
   }
@@ -3640,6 +3747,7 @@ func (suite *InternalToFrontendTestSuite) TestConversationToAPI() {
   "last_status": {
     "id": "01F8MHAMCHF6Y650WCRSCP4WMY",
     "created_at": "2021-10-20T10:40:37.000Z",
+    "edited_at": null,
     "in_reply_to_id": null,
     "in_reply_to_account_id": null,
     "sensitive": true,
@@ -3717,6 +3825,13 @@ func (suite *InternalToFrontendTestSuite) TestConversationToAPI() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
       }
     }
   }
@@ -108,6 +108,12 @@ func (suite *WrapTestSuite) TestWrapNoteInCreate() {
         ],
         "approvalRequired": []
       },
+      "canQuote": {
+        "always": [
+          "https://www.w3.org/ns/activitystreams#Public"
+        ],
+        "approvalRequired": []
+      },
       "canReply": {
         "always": [
           "https://www.w3.org/ns/activitystreams#Public"
@@ -39,6 +39,7 @@
 	maximumShortDescriptionLength = 500
 	maximumDescriptionLength      = 5000
 	maximumSiteTermsLength        = 5000
+	maximumAnnouncementLength     = 5000
 	maximumUsernameLength         = 64
 	maximumEmojiCategoryLength    = 64
 	maximumProfileFieldLength     = 255
@@ -243,6 +244,20 @@ func SiteTerms(t string) error {
 	return nil
 }
 
+// AnnouncementText checks that the text of an
+// announcement is set and not too long.
+func AnnouncementText(t string) error {
+	if t == "" {
+		return errors.New("announcement text must be provided")
+	}
+
+	if length := len([]rune(t)); length > maximumAnnouncementLength {
+		return fmt.Errorf("announcement text should be no more than %d chars but given text was %d", maximumAnnouncementLength, length)
+	}
+
+	return nil
+}
+
 // ULID returns an error if the passed string is not a valid ULID.
 // The name param is used to form error messages.
 func ULID(i string, name string) error {
@@ -394,7 +409,9 @@ func CreateAccount(form *apimodel.AccountCreateRequest) error {
 		return errors.New("form was nil")
 	}
 
-	if !config.GetAccountsRegistrationOpen() {
+	// An invite lets people sign up even
+	// if registration is otherwise closed.
+	if !config.GetAccountsRegistrationOpen() && form.InviteCode == "" {
 		return errors.New("registration is not open for this server")
 	}
 
@@ -420,5 +437,8 @@ func CreateAccount(form *apimodel.AccountCreateRequest) error {
 	}
 	form.Locale = locale
 
-	return SignUpReason(form.Reason, config.GetAccountsReasonRequired())
+	// No reason required when signing up with an invite,
+	// since the inviter has already vouched for this person.
+	reasonRequired := config.GetAccountsReasonRequired() && form.InviteCode == ""
+	return SignUpReason(form.Reason, reasonRequired)
 }
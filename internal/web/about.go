@@ -51,10 +51,11 @@ func (m *Module) aboutGETHandler(c *gin.Context) {
 	}
 
 	page := apiutil.WebPage{
-		Template:    "about.tmpl",
-		Instance:    instance,
-		OGMeta:      apiutil.OGBase(instance),
-		Stylesheets: []string{cssAbout},
+		Template:      "about.tmpl",
+		Instance:      instance,
+		OGMeta:        apiutil.OGBase(instance),
+		Stylesheets:   []string{cssAbout},
+		Announcements: m.announcements(c.Request.Context()),
 		Extra: map[string]any{
 			"showStrap":        true,
 			"blocklistExposed": config.GetInstanceExposeSuspendedWeb(),
@@ -0,0 +1,39 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// announcements returns currently active admin
+// announcements for showing in the page banner.
+// Errors are logged rather than returned, since
+// announcements shouldn't prevent page rendering.
+func (m *Module) announcements(ctx context.Context) []*apimodel.Announcement {
+	announcements, errWithCode := m.processor.Announcements().Get(ctx, nil, true)
+	if errWithCode != nil {
+		log.Errorf(ctx, "error getting announcements: %v", errWithCode)
+		return nil
+	}
+
+	return announcements
+}
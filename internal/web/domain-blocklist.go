@@ -64,12 +64,13 @@ func (m *Module) domainBlockListGETHandler(c *gin.Context) {
 	}
 
 	page := apiutil.WebPage{
-		Template:    "domain-blocklist.tmpl",
-		Instance:    instance,
-		OGMeta:      apiutil.OGBase(instance),
-		Stylesheets: []string{cssFA},
-		Javascript:  []string{jsFrontend},
-		Extra:       map[string]any{"blocklist": domainBlocks},
+		Template:      "domain-blocklist.tmpl",
+		Instance:      instance,
+		OGMeta:        apiutil.OGBase(instance),
+		Stylesheets:   []string{cssFA},
+		Javascript:    []string{jsFrontend},
+		Announcements: m.announcements(c.Request.Context()),
+		Extra:         map[string]any{"blocklist": domainBlocks},
 	}
 
 	apiutil.TemplateWebPage(c, page)
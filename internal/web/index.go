@@ -56,11 +56,12 @@ func (m *Module) indexHandler(c *gin.Context) {
 	}
 
 	page := apiutil.WebPage{
-		Template:    "index.tmpl",
-		Instance:    instance,
-		OGMeta:      apiutil.OGBase(instance),
-		Stylesheets: []string{cssAbout, cssIndex},
-		Extra:       map[string]any{"showStrap": true},
+		Template:      "index.tmpl",
+		Instance:      instance,
+		OGMeta:        apiutil.OGBase(instance),
+		Stylesheets:   []string{cssAbout, cssIndex},
+		Announcements: m.announcements(c.Request.Context()),
+		Extra:         map[string]any{"showStrap": true},
 	}
 
 	apiutil.TemplateWebPage(c, page)
@@ -160,11 +160,12 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 	)
 
 	page := apiutil.WebPage{
-		Template:    "profile.tmpl",
-		Instance:    instance,
-		OGMeta:      apiutil.OGBase(instance).WithAccount(targetAccount),
-		Stylesheets: stylesheets,
-		Javascript:  []string{jsFrontend},
+		Template:      "profile.tmpl",
+		Instance:      instance,
+		OGMeta:        apiutil.OGBase(instance).WithAccount(targetAccount),
+		Stylesheets:   stylesheets,
+		Javascript:    []string{jsFrontend},
+		Announcements: m.announcements(c.Request.Context()),
 		Extra: map[string]any{
 			"account":          targetAccount,
 			"rssFeed":          rssFeed,
@@ -53,13 +53,26 @@ func (m *Module) signupGETHandler(c *gin.Context) {
 		return
 	}
 
+	// If an invite code was given, make sure
+	// it's valid before showing the form for it.
+	inviteCode := c.Query("invite")
+	if inviteCode != "" {
+		if _, errWithCode := m.processor.User().InviteGetByCode(ctx, inviteCode); errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+			return
+		}
+	}
+
+	// With a valid invite, people can sign up even if
+	// registration is closed, and don't need a reason.
 	page := apiutil.WebPage{
 		Template: "sign-up.tmpl",
 		Instance: instance,
 		OGMeta:   apiutil.OGBase(instance),
 		Extra: map[string]any{
-			"reasonRequired":   config.GetAccountsReasonRequired(),
-			"registrationOpen": config.GetAccountsRegistrationOpen(),
+			"reasonRequired":   config.GetAccountsReasonRequired() && inviteCode == "",
+			"registrationOpen": config.GetAccountsRegistrationOpen() || inviteCode != "",
+			"inviteCode":       inviteCode,
 		},
 	}
 
@@ -56,11 +56,12 @@ func (m *Module) tagGETHandler(c *gin.Context) {
 	}
 
 	page := apiutil.WebPage{
-		Template:    "tag.tmpl",
-		Instance:    instance,
-		OGMeta:      apiutil.OGBase(instance),
-		Stylesheets: []string{cssFA, cssThread, cssTag},
-		Extra:       map[string]any{"tagName": tagName},
+		Template:      "tag.tmpl",
+		Instance:      instance,
+		OGMeta:        apiutil.OGBase(instance),
+		Stylesheets:   []string{cssFA, cssThread, cssTag},
+		Announcements: m.announcements(c.Request.Context()),
+		Extra:         map[string]any{"tagName": tagName},
 	}
 
 	apiutil.TemplateWebPage(c, page)
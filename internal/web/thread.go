@@ -142,11 +142,12 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 	)
 
 	page := apiutil.WebPage{
-		Template:    "thread.tmpl",
-		Instance:    instance,
-		OGMeta:      apiutil.OGBase(instance).WithStatus(context.Status),
-		Stylesheets: stylesheets,
-		Javascript:  []string{jsFrontend},
+		Template:      "thread.tmpl",
+		Instance:      instance,
+		OGMeta:        apiutil.OGBase(instance).WithStatus(context.Status),
+		Stylesheets:   stylesheets,
+		Javascript:    []string{jsFrontend},
+		Announcements: m.announcements(c.Request.Context()),
 		Extra: map[string]any{
 			"context": context,
 		},
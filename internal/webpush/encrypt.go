@@ -0,0 +1,170 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// recordSize is the aes128gcm record size
+// we advertise. Payloads are always sent
+// as a single record, so they must fit.
+const recordSize = 4096
+
+// maxPlaintextSize is the largest plaintext that
+// fits in a single record, accounting for the
+// 16 byte auth tag and the 1 byte delimiter.
+const maxPlaintextSize = recordSize - 16 - 1
+
+// encrypt encrypts the given plaintext for the user agent with the
+// given base64url encoded P-256 public key and auth secret, using
+// the "aes128gcm" content coding as specified by RFC 8291 + RFC 8188.
+func encrypt(plaintext []byte, p256dh string, auth string) ([]byte, error) {
+	if len(plaintext) > maxPlaintextSize {
+		return nil, errors.New("payload too large")
+	}
+
+	uaPublicBytes, err := decodeBase64URL(p256dh)
+	if err != nil {
+		return nil, err
+	}
+
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	authSecret, err := decodeBase64URL(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate a new ephemeral key pair
+	// to perform the key agreement with.
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	asPublicBytes := asPrivate.PublicKey().Bytes()
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate a random salt.
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	// Combine the shared secret with the
+	// auth secret and both public keys to
+	// derive the input keying material.
+	keyInfo := make([]byte, 0, 14+65+65)
+	keyInfo = append(keyInfo, "WebPush: info\x00"...)
+	keyInfo = append(keyInfo, uaPublicBytes...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm := hkdf(authSecret, ecdhSecret, keyInfo, 32)
+
+	// Derive the content encryption key and nonce.
+	cek := hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write the content coding header: salt,
+	// record size, and key ID (our public key).
+	body := make([]byte, 0, 16+4+1+len(asPublicBytes)+len(plaintext)+1+gcm.Overhead())
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, recordSize)
+	body = append(body, byte(len(asPublicBytes)))
+	body = append(body, asPublicBytes...)
+
+	// Append the single encrypted record,
+	// padded with the last record delimiter.
+	record := make([]byte, 0, len(plaintext)+1)
+	record = append(record, plaintext...)
+	record = append(record, 0x02)
+	body = gcm.Seal(body, nonce, record, nil)
+
+	return body, nil
+}
+
+// hkdf performs HKDF (RFC 5869) using SHA-256 to
+// derive length bytes of keying material. As length
+// never exceeds the hash size, a single expand
+// iteration suffices.
+func hkdf(salt, secret, info []byte, length int) []byte {
+	extract := hmac.New(sha256.New, salt)
+	extract.Write(secret)
+	prk := extract.Sum(nil)
+
+	expand := hmac.New(sha256.New, prk)
+	expand.Write(info)
+	expand.Write([]byte{0x01})
+	return expand.Sum(nil)[:length]
+}
+
+// decodeBase64URL decodes base64url encoded
+// data, tolerating trailing padding, which
+// some user agents include and others don't.
+func decodeBase64URL(in string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(in, "="))
+}
+
+// ValidateKeys checks that the given base64url encoded user
+// agent public key and auth secret are usable for encryption.
+func ValidateKeys(p256dh string, auth string) error {
+	uaPublicBytes, err := decodeBase64URL(p256dh)
+	if err != nil {
+		return errors.New("p256dh is not valid base64url")
+	}
+
+	if _, err := ecdh.P256().NewPublicKey(uaPublicBytes); err != nil {
+		return errors.New("p256dh is not a valid P-256 public key")
+	}
+
+	authSecret, err := decodeBase64URL(auth)
+	if err != nil {
+		return errors.New("auth is not valid base64url")
+	}
+
+	if len(authSecret) != 16 {
+		return errors.New("auth must be 16 bytes")
+	}
+
+	return nil
+}
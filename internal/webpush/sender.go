@@ -0,0 +1,409 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// ttl is how long push services should
+// hold on to an undelivered notification.
+const ttl = 48 * time.Hour
+
+// maxBodyRunes is the maximum length of
+// a notification body, to keep payloads
+// comfortably within one record.
+const maxBodyRunes = 500
+
+// Sender can send Web Push notifications.
+type Sender interface {
+	// Send sends the given notification to each of the
+	// target account's Web Push subscriptions that want
+	// it, pruning any subscriptions that have expired.
+	Send(
+		ctx context.Context,
+		notification *gtsmodel.Notification,
+		apiNotification *apimodel.Notification,
+	) error
+}
+
+// NewRealSender returns a Sender that encrypts notifications and
+// delivers them to push services using the given HTTP client.
+func NewRealSender(httpClient *httpclient.Client, state *state.State) Sender {
+	return &realSender{
+		httpClient: httpClient,
+		state:      state,
+	}
+}
+
+type realSender struct {
+	httpClient *httpclient.Client
+	state      *state.State
+}
+
+func (r *realSender) Send(
+	ctx context.Context,
+	notification *gtsmodel.Notification,
+	apiNotification *apimodel.Notification,
+) error {
+	// Get all of the target account's subscriptions.
+	subscriptions, err := r.state.DB.GetWebPushSubscriptionsByAccountID(ctx, notification.TargetAccountID)
+	if err != nil {
+		return gtserror.Newf("error getting web push subscriptions for account %s: %w", notification.TargetAccountID, err)
+	}
+
+	// Filter out subscriptions that don't want this notification.
+	subscriptions, err = r.filterSubscriptions(ctx, notification, subscriptions)
+	if err != nil {
+		return err
+	}
+
+	if len(subscriptions) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	keyPair, err := r.state.DB.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		return gtserror.Newf("error getting VAPID key pair: %w", err)
+	}
+
+	// Get the user's preferred locale, if any.
+	var locale string
+	if user, err := r.state.DB.GetUserByAccountID(gtscontext.SetBarebones(ctx), notification.TargetAccountID); err != nil {
+		log.Warnf(ctx, "error getting user for account %s: %v", notification.TargetAccountID, err)
+	} else {
+		locale = user.Locale
+	}
+
+	var errs gtserror.MultiError
+	for _, subscription := range subscriptions {
+		if err := r.sendToSubscription(ctx,
+			keyPair,
+			subscription,
+			locale,
+			apiNotification,
+		); err != nil {
+			errs.Appendf("error sending notification %s to web push subscription %s: %w",
+				notification.ID, subscription.ID, err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// filterSubscriptions returns only those subscriptions whose
+// alerts and policy permit pushing the given notification.
+func (r *realSender) filterSubscriptions(
+	ctx context.Context,
+	notification *gtsmodel.Notification,
+	subscriptions []*gtsmodel.WebPushSubscription,
+) ([]*gtsmodel.WebPushSubscription, error) {
+	var (
+		// Relationships between target and origin,
+		// lazily loaded as needed by the policies.
+		followed *bool
+		follower *bool
+	)
+
+	filtered := make([]*gtsmodel.WebPushSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if !subscription.NotificationFlags.Get(notification.NotificationType) {
+			// Alert disabled.
+			continue
+		}
+
+		switch subscription.Policy {
+		case gtsmodel.WebPushNotificationPolicyAll:
+			// Always permitted.
+
+		case gtsmodel.WebPushNotificationPolicyFollowed:
+			if followed == nil {
+				is, err := r.state.DB.IsFollowing(ctx,
+					notification.TargetAccountID,
+					notification.OriginAccountID,
+				)
+				if err != nil {
+					return nil, gtserror.Newf("error checking follow: %w", err)
+				}
+				followed = &is
+			}
+			if !*followed {
+				continue
+			}
+
+		case gtsmodel.WebPushNotificationPolicyFollower:
+			if follower == nil {
+				is, err := r.state.DB.IsFollowing(ctx,
+					notification.OriginAccountID,
+					notification.TargetAccountID,
+				)
+				if err != nil {
+					return nil, gtserror.Newf("error checking follow: %w", err)
+				}
+				follower = &is
+			}
+			if !*follower {
+				continue
+			}
+
+		default:
+			// Policy "none" or unknown.
+			continue
+		}
+
+		filtered = append(filtered, subscription)
+	}
+
+	return filtered, nil
+}
+
+// sendToSubscription encrypts the notification for, and
+// delivers it to, the given subscription. Subscriptions
+// reported as gone by the push service are deleted.
+func (r *realSender) sendToSubscription(
+	ctx context.Context,
+	keyPair *gtsmodel.VAPIDKeyPair,
+	subscription *gtsmodel.WebPushSubscription,
+	locale string,
+	apiNotification *apimodel.Notification,
+) error {
+	// The payload includes the subscription's access token,
+	// allowing the client to fetch the full notification.
+	token, err := r.state.DB.GetTokenByID(ctx, subscription.TokenID)
+	if errors.Is(err, db.ErrNoEntries) {
+		// Token has since been revoked,
+		// so the subscription is orphaned.
+		return r.state.DB.DeleteWebPushSubscriptionByID(ctx, subscription.ID)
+	} else if err != nil {
+		return gtserror.Newf("error getting token %s: %w", subscription.TokenID, err)
+	}
+
+	payload, err := json.Marshal(newWebPushNotification(
+		token.Access,
+		locale,
+		apiNotification,
+	))
+	if err != nil {
+		return gtserror.Newf("error marshaling payload: %w", err)
+	}
+
+	body, err := encrypt(payload, subscription.P256dh, subscription.Auth)
+	if err != nil {
+		return gtserror.Newf("error encrypting payload: %w", err)
+	}
+
+	endpoint, err := url.Parse(subscription.Endpoint)
+	if err != nil {
+		return gtserror.Newf("error parsing endpoint: %w", err)
+	}
+
+	authorization, err := vapidAuthorization(
+		keyPair,
+		endpoint,
+		config.GetProtocol()+"://"+config.GetHost(),
+		time.Now(),
+	)
+	if err != nil {
+		return gtserror.Newf("error signing VAPID token: %w", err)
+	}
+
+	// Use *bytes.Reader for request body,
+	// as NewRequest() automatically will
+	// set .GetBody and content-length.
+	req, err := http.NewRequestWithContext(ctx,
+		http.MethodPost,
+		endpoint.String(),
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return gtserror.Newf("error preparing request: %w", err)
+	}
+
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(ttl/time.Second)))
+	req.Header.Set("Urgency", "normal")
+	req.Header.Set("User-Agent", config.GetApplicationName()+" ("+config.GetHost()+")")
+
+	rsp, err := r.do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	switch code := rsp.StatusCode; {
+	case code >= 200 && code < 300:
+		// Delivered!
+		return nil
+
+	case code == http.StatusNotFound || code == http.StatusGone:
+		// The subscription has expired or been
+		// unsubscribed by the user agent, so
+		// there's no point keeping it around.
+		log.Infof(ctx, "pruning expired web push subscription %s", subscription.ID)
+		if err := r.state.DB.DeleteWebPushSubscriptionByID(ctx, subscription.ID); err != nil {
+			return gtserror.Newf("error deleting expired subscription: %w", err)
+		}
+		return nil
+
+	default:
+		msg, _ := io.ReadAll(io.LimitReader(rsp.Body, 256))
+		return gtserror.Newf("push service responded %s: %s", rsp.Status, msg)
+	}
+}
+
+// do performs the given request with the HTTP client, retrying
+// with backoff on temporary failures. Unlike httpclient.Client{}.Do()
+// it rewinds the request body before each attempt.
+func (r *realSender) do(req *http.Request) (*http.Response, error) {
+	if err := httpclient.ValidateRequest(req); err != nil {
+		return nil, err
+	}
+
+	wrapped := httpclient.WrapRequest(req)
+	for {
+		// Fetch a fresh copy of request body.
+		body, err := wrapped.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		wrapped.Body = body
+
+		rsp, retry, err := r.httpClient.DoOnce(wrapped)
+		if err == nil {
+			return rsp, nil
+		}
+
+		if !retry {
+			return nil, err
+		}
+
+		// Start new backoff sleep timer.
+		backoff := time.NewTimer(wrapped.BackOff())
+
+		select {
+		case <-req.Context().Done():
+			backoff.Stop()
+			return nil, req.Context().Err()
+
+		case <-backoff.C:
+		}
+	}
+}
+
+// newWebPushNotification builds the
+// payload for a pushed notification.
+func newWebPushNotification(
+	accessToken string,
+	locale string,
+	apiNotification *apimodel.Notification,
+) *apimodel.WebPushNotification {
+	var (
+		name string
+		icon string
+		body string
+	)
+
+	if account := apiNotification.Account; account != nil {
+		name = account.DisplayName
+		if name == "" {
+			name = account.Username
+		}
+		icon = account.AvatarStatic
+		body = text.SanitizeToPlaintext(account.Note)
+	}
+
+	if status := apiNotification.Status; status != nil {
+		if status.SpoilerText != "" {
+			body = status.SpoilerText
+		} else {
+			body = text.SanitizeToPlaintext(status.Content)
+		}
+	}
+
+	if runes := []rune(body); len(runes) > maxBodyRunes {
+		body = string(runes[:maxBodyRunes-1]) + "…"
+	}
+
+	return &apimodel.WebPushNotification{
+		AccessToken:      accessToken,
+		PreferredLocale:  locale,
+		NotificationID:   apiNotification.ID,
+		NotificationType: apiNotification.Type,
+		Icon:             icon,
+		Title:            notificationTitle(gtsmodel.NotificationType(apiNotification.Type), name),
+		Body:             body,
+	}
+}
+
+// notificationTitle returns a human readable
+// title for a notification of the given type
+// triggered by the account with given name.
+func notificationTitle(notificationType gtsmodel.NotificationType, name string) string {
+	switch notificationType {
+	case gtsmodel.NotificationFollow:
+		return name + " followed you"
+	case gtsmodel.NotificationFollowRequest:
+		return name + " requested to follow you"
+	case gtsmodel.NotificationMention:
+		return name + " mentioned you"
+	case gtsmodel.NotificationReblog:
+		return name + " boosted your post"
+	case gtsmodel.NotificationFave:
+		return name + " favourited your post"
+	case gtsmodel.NotificationPoll:
+		return "A poll has ended"
+	case gtsmodel.NotificationStatus:
+		return name + " just posted"
+	case gtsmodel.NotificationSignup:
+		return name + " requested to sign up"
+	case gtsmodel.NotificationPendingFave:
+		return name + " favourited your post, pending your approval"
+	case gtsmodel.NotificationPendingReply:
+		return name + " replied to your post, pending your approval"
+	case gtsmodel.NotificationPendingReblog:
+		return name + " boosted your post, pending your approval"
+	case gtsmodel.NotificationQuote:
+		return name + " quoted your post"
+	case gtsmodel.NotificationPendingQuote:
+		return name + " quoted your post, pending your approval"
+	default:
+		return "New notification from " + name
+	}
+}
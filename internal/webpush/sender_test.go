@@ -0,0 +1,195 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SenderTestSuite struct {
+	suite.Suite
+	db    db.DB
+	state state.State
+
+	testAccounts      map[string]*gtsmodel.Account
+	testSubscriptions map[string]*gtsmodel.WebPushSubscription
+
+	// Push service stand-in.
+	server     *httptest.Server
+	statusCode int
+	requests   []*http.Request
+	bodies     [][]byte
+	mu         sync.Mutex
+
+	sender webpush.Sender
+}
+
+func (suite *SenderTestSuite) SetupSuite() {
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *SenderTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.db, nil)
+
+	suite.testSubscriptions = testrig.NewTestWebPushSubscriptions()
+	suite.statusCode = http.StatusCreated
+	suite.requests = nil
+	suite.bodies = nil
+	suite.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		suite.mu.Lock()
+		defer suite.mu.Unlock()
+		suite.requests = append(suite.requests, r)
+		suite.bodies = append(suite.bodies, body)
+		rw.WriteHeader(suite.statusCode)
+	}))
+
+	// Point the test subscription at the stand-in push service.
+	subscription := suite.testSubscriptions["local_account_1_token_1"]
+	subscription.Endpoint = suite.server.URL + "/send/" + subscription.ID
+	if err := suite.db.UpdateWebPushSubscription(context.Background(), subscription, "endpoint"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.sender = webpush.NewRealSender(
+		httpclient.New(httpclient.Config{
+			AllowRanges: config.MustParseIPPrefixes([]string{
+				"127.0.0.0/8",
+			}),
+		}),
+		&suite.state,
+	)
+}
+
+func (suite *SenderTestSuite) TearDownTest() {
+	suite.server.Close()
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *SenderTestSuite) notification(notificationType gtsmodel.NotificationType) (*gtsmodel.Notification, *apimodel.Notification) {
+	target := suite.testAccounts["local_account_1"]
+	origin := suite.testAccounts["remote_account_1"]
+
+	notification := &gtsmodel.Notification{
+		ID:               "01JD0A3Q0MSHK4MPNEWSJJS3VA",
+		NotificationType: notificationType,
+		TargetAccountID:  target.ID,
+		OriginAccountID:  origin.ID,
+	}
+
+	apiNotification := &apimodel.Notification{
+		ID:   notification.ID,
+		Type: string(notificationType),
+		Account: &apimodel.Account{
+			ID:       origin.ID,
+			Username: origin.Username,
+		},
+	}
+
+	return notification, apiNotification
+}
+
+func (suite *SenderTestSuite) TestSend() {
+	notification, apiNotification := suite.notification(gtsmodel.NotificationMention)
+
+	err := suite.sender.Send(context.Background(), notification, apiNotification)
+	suite.NoError(err)
+
+	suite.Len(suite.requests, 1)
+	req := suite.requests[0]
+	suite.Equal(http.MethodPost, req.Method)
+	suite.Equal("/send/"+suite.testSubscriptions["local_account_1_token_1"].ID, req.URL.Path)
+	suite.Equal("aes128gcm", req.Header.Get("Content-Encoding"))
+	suite.Equal("application/octet-stream", req.Header.Get("Content-Type"))
+	suite.Equal("172800", req.Header.Get("TTL"))
+	suite.True(strings.HasPrefix(req.Header.Get("Authorization"), "vapid t="))
+	suite.True(strings.HasSuffix(req.Header.Get("Authorization"), ", k="+testrig.NewTestVAPIDKeyPair().PublicKey))
+
+	// Salt, record size, key ID length, key
+	// ID, plus at least the delimiter and tag.
+	suite.Greater(len(suite.bodies[0]), 16+4+1+65+1+16)
+}
+
+func (suite *SenderTestSuite) TestSendFilteredByAlerts() {
+	// Test subscription doesn't want reblogs.
+	notification, apiNotification := suite.notification(gtsmodel.NotificationReblog)
+
+	err := suite.sender.Send(context.Background(), notification, apiNotification)
+	suite.NoError(err)
+	suite.Empty(suite.requests)
+}
+
+func (suite *SenderTestSuite) TestSendFilteredByPolicy() {
+	ctx := context.Background()
+	subscription := suite.testSubscriptions["local_account_1_token_1"]
+
+	// local_account_1 doesn't follow remote_account_1.
+	subscription.Policy = gtsmodel.WebPushNotificationPolicyFollowed
+	if err := suite.db.UpdateWebPushSubscription(ctx, subscription, "policy"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	notification, apiNotification := suite.notification(gtsmodel.NotificationMention)
+
+	err := suite.sender.Send(ctx, notification, apiNotification)
+	suite.NoError(err)
+	suite.Empty(suite.requests)
+}
+
+func (suite *SenderTestSuite) TestSendPrunesExpired() {
+	ctx := context.Background()
+	suite.statusCode = http.StatusGone
+
+	notification, apiNotification := suite.notification(gtsmodel.NotificationMention)
+
+	err := suite.sender.Send(ctx, notification, apiNotification)
+	suite.NoError(err)
+	suite.Len(suite.requests, 1)
+
+	// Subscription should now be gone.
+	_, err = suite.db.GetWebPushSubscriptionByTokenID(ctx, suite.testSubscriptions["local_account_1_token_1"].TokenID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+}
+
+func TestSenderTestSuite(t *testing.T) {
+	suite.Run(t, new(SenderTestSuite))
+}
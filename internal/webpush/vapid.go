@@ -0,0 +1,108 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// vapidTokenTTL is how long signed
+// VAPID tokens remain valid for. RFC
+// 8292 limits this to at most 24h.
+const vapidTokenTTL = 12 * time.Hour
+
+// vapidAuthorization returns an Authorization header value for
+// a request to the given push service endpoint, containing a
+// VAPID (RFC 8292) JWT signed with the given instance key pair.
+func vapidAuthorization(
+	keyPair *gtsmodel.VAPIDKeyPair,
+	endpoint *url.URL,
+	subject string,
+	now time.Time,
+) (string, error) {
+	privateKey, err := vapidPrivateKey(keyPair)
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		"aud": endpoint.Scheme + "://" + endpoint.Host,
+		"exp": now.Add(vapidTokenTTL).Unix(),
+		"sub": subject,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Build the unsigned JWT from the
+	// (constant) header and claims.
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`)) +
+		"." + base64.RawURLEncoding.EncodeToString(claims)
+
+	// Sign with ES256, encoding the signature
+	// as the fixed size concatenation r || s.
+	digest := sha256.Sum256([]byte(unsigned))
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest[:])
+	if err != nil {
+		return "", err
+	}
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+
+	return "vapid t=" + unsigned + "." + base64.RawURLEncoding.EncodeToString(sig) +
+		", k=" + keyPair.PublicKey, nil
+}
+
+// vapidPrivateKey decodes the given key pair
+// into an ECDSA private key suitable for signing.
+func vapidPrivateKey(keyPair *gtsmodel.VAPIDKeyPair) (*ecdsa.PrivateKey, error) {
+	d, err := decodeBase64URL(keyPair.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := decodeBase64URL(keyPair.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(d) != 32 || len(pub) != 65 || pub[0] != 0x04 {
+		return nil, errors.New("invalid VAPID key pair")
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(pub[1:33]),
+			Y:     new(big.Int).SetBytes(pub[33:]),
+		},
+		D: new(big.Int).SetBytes(d),
+	}, nil
+}
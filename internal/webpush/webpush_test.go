@@ -0,0 +1,227 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// decrypt reverses encrypt, as a user agent would.
+func decrypt(t *testing.T, body []byte, uaPrivate *ecdh.PrivateKey, authSecret []byte) []byte {
+	t.Helper()
+
+	if len(body) < 21 {
+		t.Fatalf("body too short: %d bytes", len(body))
+	}
+
+	salt := body[:16]
+	if rs := binary.BigEndian.Uint32(body[16:20]); rs != recordSize {
+		t.Fatalf("expected record size %d, got %d", recordSize, rs)
+	}
+	idLen := int(body[20])
+	asPublicBytes := body[21 : 21+idLen]
+	ciphertext := body[21+idLen:]
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecdhSecret, err := uaPrivate.ECDH(asPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyInfo := []byte("WebPush: info\x00")
+	keyInfo = append(keyInfo, uaPrivate.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm := hkdf(authSecret, ecdhSecret, keyInfo, 32)
+
+	cek := hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if record[len(record)-1] != 0x02 {
+		t.Fatalf("expected last record delimiter, got %x", record[len(record)-1])
+	}
+
+	return record[:len(record)-1]
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authSecret := make([]byte, 16)
+	if _, err := rand.Read(authSecret); err != nil {
+		t.Fatal(err)
+	}
+
+	p256dh := base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes())
+	auth := base64.URLEncoding.EncodeToString(authSecret) // padded, should be tolerated
+
+	if err := ValidateKeys(p256dh, auth); err != nil {
+		t.Fatal(err)
+	}
+
+	plaintext := []byte(`{"title":"hello","body":"world"}`)
+	body, err := encrypt(plaintext, p256dh, auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decrypted := decrypt(t, body, uaPrivate, authSecret); string(decrypted) != string(plaintext) {
+		t.Fatalf("expected %s, got %s", plaintext, decrypted)
+	}
+
+	// Plaintext that doesn't fit in a single record should be rejected.
+	if _, err := encrypt(make([]byte, maxPlaintextSize+1), p256dh, auth); err == nil {
+		t.Fatal("expected error encrypting oversized payload")
+	}
+}
+
+func TestValidateKeys(t *testing.T) {
+	for _, tt := range []struct {
+		p256dh string
+		auth   string
+		valid  bool
+	}{
+		{
+			p256dh: "BLRPuwMR_RAaaJzorvqDPm7np72Kh_A_vF14owxaZGvu8PsuhtxI7MCwnpz_TyM325oq-oxfWPXez0J_TTq-r_c",
+			auth:   "Wbhwt14_yAmVAbJ6O-rGOg",
+			valid:  true,
+		},
+		{
+			p256dh: "not a key",
+			auth:   "Wbhwt14_yAmVAbJ6O-rGOg",
+		},
+		{
+			p256dh: "BLRPuwMR_RAaaJzorvqDPm7np72Kh_A_vF14owxaZGvu8PsuhtxI7MCwnpz_TyM325oq-oxfWPXez0J_TTq-r_c",
+			auth:   "dG9vIHNob3J0",
+		},
+	} {
+		err := ValidateKeys(tt.p256dh, tt.auth)
+		if tt.valid && err != nil {
+			t.Errorf("expected keys %q/%q to be valid, got %v", tt.p256dh, tt.auth, err)
+		} else if !tt.valid && err == nil {
+			t.Errorf("expected keys %q/%q to be invalid", tt.p256dh, tt.auth)
+		}
+	}
+}
+
+func TestVAPIDAuthorization(t *testing.T) {
+	keyPair := &gtsmodel.VAPIDKeyPair{
+		PublicKey:  "BOp8s8FC_In8M9fAPN9WpAUswyzJXb4AGjDXLSVx03fve4MexXiw9CQ64Jzs6vb4LzdIeXfjb-6ziWCDH3MvSV0",
+		PrivateKey: "dZe96U_8ltgo1CwAcJ3-Egy394ZnMHodVjI8TUf_DyY",
+	}
+
+	endpoint, err := url.Parse("https://push.example.org/send/some_subscription")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	header, err := vapidAuthorization(keyPair, endpoint, "https://localhost:8080", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, key, ok := strings.Cut(strings.TrimPrefix(header, "vapid t="), ", k=")
+	if !ok || key != keyPair.PublicKey {
+		t.Fatalf("unexpected authorization header %q", header)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected 3 JWT parts, got %d", len(parts))
+	}
+
+	// Check claims.
+	claimsBytes, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var claims struct {
+		Aud string `json:"aud"`
+		Exp int64  `json:"exp"`
+		Sub string `json:"sub"`
+	}
+	if err := json.Unmarshal(claimsBytes, &claims); err != nil {
+		t.Fatal(err)
+	}
+
+	if claims.Aud != "https://push.example.org" {
+		t.Errorf("unexpected aud %q", claims.Aud)
+	}
+	if claims.Exp != now.Add(vapidTokenTTL).Unix() {
+		t.Errorf("unexpected exp %d", claims.Exp)
+	}
+	if claims.Sub != "https://localhost:8080" {
+		t.Errorf("unexpected sub %q", claims.Sub)
+	}
+
+	// Check signature against the public key.
+	privateKey, err := vapidPrivateKey(keyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	if !ecdsa.Verify(&privateKey.PublicKey, digest[:], r, s) {
+		t.Fatal("VAPID signature did not verify")
+	}
+}
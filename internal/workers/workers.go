@@ -54,6 +54,11 @@ type Workers struct {
 	// eg., import tasks, admin tasks.
 	Processing FnWorkerPool
 
+	// WebPush provides a worker pool for
+	// delivering Web Push notifications,
+	// as push services may be slow to reply.
+	WebPush FnWorkerPool
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -90,6 +95,10 @@ func (w *Workers) Start() {
 	n = maxprocs
 	w.Processing.Start(n)
 	log.Infof(nil, "started %d processing workers", n)
+
+	n = 4 * maxprocs
+	w.WebPush.Start(n)
+	log.Infof(nil, "started %d web push workers", n)
 }
 
 // Stop will stop all of the contained
@@ -113,6 +122,9 @@ func (w *Workers) Stop() {
 
 	w.Processing.Stop()
 	log.Info(nil, "stopped processing workers")
+
+	w.WebPush.Stop()
+	log.Info(nil, "stopped web push workers")
 }
 
 // nocopy when embedded will signal linter to
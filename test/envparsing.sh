@@ -6,7 +6,9 @@ EXPECT=$(cat << "EOF"
 {
     "account-domain": "peepee",
     "accounts-allow-custom-css": true,
+    "accounts-archive-expiry": 604800000000000,
     "accounts-custom-css-length": 5000,
+    "accounts-invite-role": "user",
     "accounts-reason-required": false,
     "accounts-registration-open": true,
     "advanced-cookies-samesite": "strict",
@@ -16,13 +18,17 @@ EXPECT=$(cat << "EOF"
         "192.0.2.0/24",
         "127.0.0.1/32"
     ],
+    "advanced-rate-limit-inbox": 1234,
     "advanced-rate-limit-requests": 6969,
+    "advanced-rate-limit-sign-in": 42,
+    "advanced-rate-limit-store": "database",
     "advanced-sender-multiplier": -1,
     "advanced-throttling-multiplier": -1,
     "advanced-throttling-retry-after": 10000000000,
     "application-name": "gts",
     "bind-address": "127.0.0.1",
     "cache": {
+        "account-domain-blocks-mem-ratio": 0.5,
         "account-mem-ratio": 5,
         "account-note-mem-ratio": 1,
         "account-settings-mem-ratio": 0.1,
@@ -31,6 +37,7 @@ EXPECT=$(cat << "EOF"
         "block-ids-mem-ratio": 3,
         "block-mem-ratio": 2,
         "boost-of-ids-mem-ratio": 3,
+        "card-mem-ratio": 0.5,
         "client-mem-ratio": 0.1,
         "conversation-last-status-ids-mem-ratio": 2,
         "conversation-mem-ratio": 1,
@@ -60,9 +67,11 @@ EXPECT=$(cat << "EOF"
         "poll-vote-ids-mem-ratio": 2,
         "poll-vote-mem-ratio": 2,
         "report-mem-ratio": 1,
+        "scheduled-status-mem-ratio": 0.5,
         "sin-bin-status-mem-ratio": 0.5,
         "status-bookmark-ids-mem-ratio": 2,
         "status-bookmark-mem-ratio": 0.5,
+        "status-edit-mem-ratio": 2,
         "status-fave-ids-mem-ratio": 3,
         "status-fave-mem-ratio": 2,
         "status-mem-ratio": 5,
@@ -74,11 +83,14 @@ EXPECT=$(cat << "EOF"
         "user-mute-ids-mem-ratio": 3,
         "user-mute-mem-ratio": 2,
         "visibility-mem-ratio": 2,
+        "web-push-subscription-ids-mem-ratio": 1,
+        "web-push-subscription-mem-ratio": 1,
         "webfinger-mem-ratio": 0.1
     },
     "config-path": "internal/config/testdata/test.yaml",
     "db-address": ":memory:",
     "db-database": "gotosocial_prod",
+    "db-full-text-search": true,
     "db-max-open-conns-multiplier": 3,
     "db-password": "hunter2",
     "db-port": 6969,
@@ -101,6 +113,7 @@ EXPECT=$(cat << "EOF"
         "tls-insecure-skip-verify": false
     },
     "instance-deliver-to-shared-inboxes": false,
+    "instance-domain-permission-drafts-require-review": true,
     "instance-expose-peers": true,
     "instance-expose-public-timeline": true,
     "instance-expose-suspended": true,
@@ -112,6 +125,8 @@ EXPECT=$(cat << "EOF"
         "nl",
         "en-GB"
     ],
+    "instance-subscriptions-process-every": 86400000000000,
+    "instance-subscriptions-process-from": "23:00",
     "landing-page-user": "admin",
     "letsencrypt-cert-dir": "/gotosocial/storage/certs",
     "letsencrypt-email-address": "",
@@ -220,6 +235,7 @@ GTS_DB_PORT=6969 \
 GTS_DB_USER='sex-haver' \
 GTS_DB_PASSWORD='hunter2' \
 GTS_DB_DATABASE='gotosocial_prod' \
+GTS_DB_FULL_TEXT_SEARCH=true \
 GTS_DB_MAX_OPEN_CONNS_MULTIPLIER=3 \
 GTS_DB_SQLITE_JOURNAL_MODE='DELETE' \
 GTS_DB_SQLITE_SYNCHRONOUS='FULL' \
@@ -242,6 +258,7 @@ GTS_ACCOUNTS_ALLOW_CUSTOM_CSS=true \
 GTS_ACCOUNTS_CUSTOM_CSS_LENGTH=5000 \
 GTS_ACCOUNTS_REGISTRATION_OPEN=true \
 GTS_ACCOUNTS_REASON_REQUIRED=false \
+GTS_ACCOUNTS_INVITE_ROLE=user \
 GTS_MEDIA_DESCRIPTION_MIN_CHARS=69 \
 GTS_MEDIA_DESCRIPTION_MAX_CHARS=5000 \
 GTS_MEDIA_LOCAL_MAX_SIZE=420 \
@@ -294,6 +311,9 @@ GTS_TRACING_INSECURE_TRANSPORT=true \
 GTS_ADVANCED_COOKIES_SAMESITE='strict' \
 GTS_ADVANCED_RATE_LIMIT_EXCEPTIONS="192.0.2.0/24,127.0.0.1/32" \
 GTS_ADVANCED_RATE_LIMIT_REQUESTS=6969 \
+GTS_ADVANCED_RATE_LIMIT_STORE=database \
+GTS_ADVANCED_RATE_LIMIT_SIGN_IN=42 \
+GTS_ADVANCED_RATE_LIMIT_INBOX=1234 \
 GTS_ADVANCED_SENDER_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_RETRY_AFTER='10s' \
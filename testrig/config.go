@@ -102,8 +102,10 @@ func testDefaults() config.Configuration {
 
 		AccountsRegistrationOpen: true,
 		AccountsReasonRequired:   true,
+		AccountsInviteRole:       config.AccountsInviteRoleDefault,
 		AccountsAllowCustomCSS:   true,
 		AccountsCustomCSSLength:  10000,
+		AccountsArchiveExpiry:    7 * 24 * time.Hour,
 
 		MediaDescriptionMinChars: 0,
 		MediaDescriptionMaxChars: 500,
@@ -163,6 +165,9 @@ func testDefaults() config.Configuration {
 
 		AdvancedCookiesSamesite:      "lax",
 		AdvancedRateLimitRequests:    0, // disabled
+		AdvancedRateLimitStore:       config.RateLimitStoreDefault,
+		AdvancedRateLimitSignIn:      0, // disabled
+		AdvancedRateLimitInbox:       0, // disabled
 		AdvancedThrottlingMultiplier: 0, // disabled
 		AdvancedSenderMultiplier:     0, // 1 sender only, regardless of CPU
 
@@ -34,7 +34,11 @@
 	&gtsmodel.AccountToEmoji{},
 	&gtsmodel.Application{},
 	&gtsmodel.Block{},
+	&gtsmodel.Card{},
 	&gtsmodel.DomainBlock{},
+	&gtsmodel.DomainPermissionDraft{},
+	&gtsmodel.DomainPermissionExclude{},
+	&gtsmodel.DomainPermissionSubscription{},
 	&gtsmodel.EmailDomainBlock{},
 	&gtsmodel.Filter{},
 	&gtsmodel.FilterKeyword{},
@@ -42,18 +46,21 @@
 	&gtsmodel.Follow{},
 	&gtsmodel.FollowRequest{},
 	&gtsmodel.InteractionRequest{},
+	&gtsmodel.Invite{},
 	&gtsmodel.List{},
 	&gtsmodel.ListEntry{},
 	&gtsmodel.Marker{},
 	&gtsmodel.MediaAttachment{},
 	&gtsmodel.Mention{},
 	&gtsmodel.Poll{},
+	&gtsmodel.RateLimit{},
 	&gtsmodel.PollVote{},
 	&gtsmodel.Status{},
 	&gtsmodel.StatusToEmoji{},
 	&gtsmodel.StatusToTag{},
 	&gtsmodel.StatusFave{},
 	&gtsmodel.StatusBookmark{},
+	&gtsmodel.StatusEdit{},
 	&gtsmodel.Tag{},
 	&gtsmodel.Thread{},
 	&gtsmodel.ThreadMute{},
@@ -63,13 +70,28 @@
 	&gtsmodel.Emoji{},
 	&gtsmodel.Instance{},
 	&gtsmodel.Notification{},
+	&gtsmodel.NotificationPolicy{},
+	&gtsmodel.NotificationRequest{},
 	&gtsmodel.RouterSession{},
 	&gtsmodel.Token{},
 	&gtsmodel.Client{},
 	&gtsmodel.EmojiCategory{},
 	&gtsmodel.Tombstone{},
+	&gtsmodel.Relay{},
+	&gtsmodel.Announcement{},
+	&gtsmodel.AnnouncementDismissal{},
+	&gtsmodel.AnnouncementReaction{},
+	&gtsmodel.TrendReview{},
+	&gtsmodel.StaffPick{},
+	&gtsmodel.SuggestionDismissal{},
+	&gtsmodel.AccountDomainBlock{},
+	&gtsmodel.Import{},
+	&gtsmodel.AccountArchive{},
 	&gtsmodel.Report{},
 	&gtsmodel.Rule{},
+	&gtsmodel.ScheduledStatus{},
+	&gtsmodel.VAPIDKeyPair{},
+	&gtsmodel.WebPushSubscription{},
 	&gtsmodel.WorkerTask{},
 }
 
@@ -353,6 +375,64 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	for _, v := range NewTestWebPushSubscriptions() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestScheduledStatuses() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestInvites() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestDomainPermissionSubscriptions() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestDomainPermissionDrafts() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestDomainPermissionExcludes() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestRelays() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestAnnouncements() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestAnnouncementReactions() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	if err := db.Put(ctx, NewTestVAPIDKeyPair()); err != nil {
+		log.Panic(nil, err)
+	}
+
 	if err := db.CreateInstanceAccount(ctx); err != nil {
 		log.Panic(nil, err)
 	}
@@ -3490,6 +3490,203 @@ func NewTestInteractionRequests() map[string]*gtsmodel.InteractionRequest {
 	}
 }
 
+func NewTestWebPushSubscriptions() map[string]*gtsmodel.WebPushSubscription {
+	return map[string]*gtsmodel.WebPushSubscription{
+		"local_account_1_token_1": {
+			ID:        "01JCZ84PHSHE18T4DPDFH16RD7",
+			CreatedAt: TimeMustParse("2024-11-20T12:41:37+02:00"),
+			UpdatedAt: TimeMustParse("2024-11-20T12:41:37+02:00"),
+			AccountID: "01F8MH1H7YV1Z7D2C8K2730QBF",
+			TokenID:   "01F8MGTQW4DKTDF8SW5CT9HYGA",
+			Endpoint:  "https://push.example.org/send/01JCZ84PHSHE18T4DPDFH16RD7",
+			Auth:      "Wbhwt14_yAmVAbJ6O-rGOg",
+			P256dh:    "BLRPuwMR_RAaaJzorvqDPm7np72Kh_A_vF14owxaZGvu8PsuhtxI7MCwnpz_TyM325oq-oxfWPXez0J_TTq-r_c",
+			NotificationFlags: func() gtsmodel.WebPushSubscriptionNotificationFlags {
+				var flags gtsmodel.WebPushSubscriptionNotificationFlags
+				flags.Set(gtsmodel.NotificationMention, true)
+				flags.Set(gtsmodel.NotificationFave, true)
+				return flags
+			}(),
+			Policy: gtsmodel.WebPushNotificationPolicyAll,
+		},
+	}
+}
+
+func NewTestScheduledStatuses() map[string]*gtsmodel.ScheduledStatus {
+	return map[string]*gtsmodel.ScheduledStatus{
+		"local_account_1_scheduled_status_1": {
+			ID:            "01JDNYS1XPZ7Y3Z0KYHD5VAN2T",
+			CreatedAt:     TimeMustParse("2024-11-27T12:41:37+02:00"),
+			UpdatedAt:     TimeMustParse("2024-11-27T12:41:37+02:00"),
+			AccountID:     "01F8MH1H7YV1Z7D2C8K2730QBF",
+			ScheduledAt:   TimeMustParse("2080-10-04T15:32:02+00:00"),
+			Text:          "this status will be posted in the far future!",
+			Sensitive:     util.Ptr(false),
+			Visibility:    gtsmodel.VisibilityPublic,
+			LocalOnly:     util.Ptr(false),
+			Language:      "en",
+			ContentType:   "text/plain",
+			MediaIDs:      []string{},
+			ApplicationID: "01F8MGY43H3N2C8EWPR2FPYEXG",
+		},
+	}
+}
+
+func NewTestInvites() map[string]*gtsmodel.Invite {
+	return map[string]*gtsmodel.Invite{
+		"admin_account_invite_1": {
+			ID:          "01JM8Q5DX0W4BZ7RS3N2EHFV6C",
+			CreatedAt:   TimeMustParse("2025-02-17T10:15:44+01:00"),
+			UpdatedAt:   TimeMustParse("2025-02-17T10:15:44+01:00"),
+			Code:        "buzzbuzzbees",
+			AccountID:   "01F8MH17FWEB39HZJ76B6VXSKF",
+			MaxUses:     5,
+			Uses:        1,
+			ExpiresAt:   TimeMustParse("2080-02-17T10:15:44+01:00"),
+			AutoApprove: util.Ptr(true),
+		},
+		"admin_account_invite_2_expired": {
+			ID:          "01JM8Q7TB3PZ0G6XN1K5YAV8QD",
+			CreatedAt:   TimeMustParse("2025-02-17T10:17:03+01:00"),
+			UpdatedAt:   TimeMustParse("2025-02-17T10:17:03+01:00"),
+			Code:        "oldnewsbees",
+			AccountID:   "01F8MH17FWEB39HZJ76B6VXSKF",
+			ExpiresAt:   TimeMustParse("2025-02-18T10:17:03+01:00"),
+			AutoApprove: util.Ptr(false),
+		},
+	}
+}
+
+func NewTestDomainPermissionSubscriptions() map[string]*gtsmodel.DomainPermissionSubscription {
+	return map[string]*gtsmodel.DomainPermissionSubscription{
+		"admin_account_block_1": {
+			ID:                 "01JGE681TQSBPAV59GZXPKE62H",
+			CreatedAt:          TimeMustParse("2024-12-03T12:46:08+01:00"),
+			UpdatedAt:          TimeMustParse("2024-12-03T12:46:08+01:00"),
+			Priority:           0,
+			Title:              "whatever!",
+			PermissionType:     gtsmodel.DomainPermissionBlock,
+			AsDraft:            util.Ptr(true),
+			AdoptOrphans:       util.Ptr(false),
+			RemoveRetracted:    util.Ptr(true),
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			URI:                "https://lists.example.org/baddies.csv",
+			ContentType:        gtsmodel.DomainPermSubContentTypeCSV,
+		},
+	}
+}
+
+func NewTestDomainPermissionDrafts() map[string]*gtsmodel.DomainPermissionDraft {
+	return map[string]*gtsmodel.DomainPermissionDraft{
+		"bad.example.org_block_draft": {
+			ID:                 "01JEMV8N6RF4VPGBVZ5Q8WW58G",
+			CreatedAt:          TimeMustParse("2024-12-09T11:38:06+01:00"),
+			UpdatedAt:          TimeMustParse("2024-12-09T11:38:06+01:00"),
+			PermissionType:     gtsmodel.DomainPermissionBlock,
+			Domain:             "bad.example.org",
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			PrivateComment:     "they're absolutely awful",
+			PublicComment:      "harassment",
+			Obfuscate:          util.Ptr(false),
+		},
+		"good.example.org_allow_draft": {
+			ID:                 "01JEMV9QDWS4HA4S3ZWGPC5NDW",
+			CreatedAt:          TimeMustParse("2024-12-09T11:38:41+01:00"),
+			UpdatedAt:          TimeMustParse("2024-12-09T11:38:41+01:00"),
+			PermissionType:     gtsmodel.DomainPermissionAllow,
+			Domain:             "good.example.org",
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			PrivateComment:     "they're lovely",
+			PublicComment:      "friends",
+			Obfuscate:          util.Ptr(false),
+		},
+	}
+}
+
+func NewTestDomainPermissionExcludes() map[string]*gtsmodel.DomainPermissionExclude {
+	return map[string]*gtsmodel.DomainPermissionExclude{
+		"elephant.example.org_exclude": {
+			ID:                 "01JEMVB8C3F3JNHN6B6RN5ZYNY",
+			CreatedAt:          TimeMustParse("2024-12-09T11:39:30+01:00"),
+			UpdatedAt:          TimeMustParse("2024-12-09T11:39:30+01:00"),
+			Domain:             "elephant.example.org",
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			PrivateComment:     "never block these guys",
+		},
+	}
+}
+
+// NewTestRelays returns a map of relays keyed by
+// their host, for use in testing. The relay has
+// not yet accepted the instance actor's Follow.
+func NewTestRelays() map[string]*gtsmodel.Relay {
+	return map[string]*gtsmodel.Relay{
+		"relay.example.org": {
+			ID:                 "01JFEG1ZH3KS9SFXV6F5N7Q2M4",
+			CreatedAt:          TimeMustParse("2024-12-19T10:15:42+01:00"),
+			UpdatedAt:          TimeMustParse("2024-12-19T10:15:42+01:00"),
+			InboxURI:           "https://relay.example.org/inbox",
+			FollowURI:          "http://localhost:8080/users/localhost:8080/follow/01JFEG1ZH3KS9SFXV6F5N7Q2M4",
+			State:              gtsmodel.RelayStatePending,
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+		},
+	}
+}
+
+// NewTestAnnouncements returns a map of admin
+// announcements keyed by a short description,
+// for use in testing. "active" is published
+// and shown to users, "draft" is unpublished.
+func NewTestAnnouncements() map[string]*gtsmodel.Announcement {
+	return map[string]*gtsmodel.Announcement{
+		"active": {
+			ID:                 "01JG9Z1B2QK0XH5T1TY6N3C8PA",
+			CreatedAt:          TimeMustParse("2024-12-30T10:22:33+01:00"),
+			UpdatedAt:          TimeMustParse("2024-12-30T10:22:33+01:00"),
+			Text:               "Scheduled maintenance this weekend, expect some downtime!",
+			Content:            "<p>Scheduled maintenance this weekend, expect some downtime!</p>",
+			AllDay:             util.Ptr(false),
+			PublishedAt:        TimeMustParse("2024-12-30T10:22:33+01:00"),
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+		},
+		"draft": {
+			ID:                 "01JG9Z4W8VJ6ZPD1F8GSRK0E2M",
+			CreatedAt:          TimeMustParse("2024-12-30T10:24:29+01:00"),
+			UpdatedAt:          TimeMustParse("2024-12-30T10:24:29+01:00"),
+			Text:               "Happy new year everyone!",
+			Content:            "<p>Happy new year everyone!</p>",
+			AllDay:             util.Ptr(true),
+			StartsAt:           TimeMustParse("2025-01-01T00:00:00+00:00"),
+			EndsAt:             TimeMustParse("2025-01-02T00:00:00+00:00"),
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+		},
+	}
+}
+
+// NewTestAnnouncementReactions returns a map of
+// reactions to announcements, for use in testing.
+func NewTestAnnouncementReactions() map[string]*gtsmodel.AnnouncementReaction {
+	return map[string]*gtsmodel.AnnouncementReaction{
+		"local_account_1_active_thumbsup": {
+			ID:             "01JG9Z8D7YF2GQ3N5R4V6W8X0B",
+			CreatedAt:      TimeMustParse("2024-12-30T10:26:10+01:00"),
+			AnnouncementID: "01JG9Z1B2QK0XH5T1TY6N3C8PA",
+			AccountID:      "01F8MH1H7YV1Z7D2C8K2730QBF",
+			Name:           "👍",
+		},
+	}
+}
+
+// NewTestVAPIDKeyPair returns a fixed VAPID
+// key pair, so that it doesn't vary between tests.
+func NewTestVAPIDKeyPair() *gtsmodel.VAPIDKeyPair {
+	return &gtsmodel.VAPIDKeyPair{
+		ID:         1,
+		PublicKey:  "BOp8s8FC_In8M9fAPN9WpAUswyzJXb4AGjDXLSVx03fve4MexXiw9CQ64Jzs6vb4LzdIeXfjb-6ziWCDH3MvSV0",
+		PrivateKey: "dZe96U_8ltgo1CwAcJ3-Egy394ZnMHodVjI8TUf_DyY",
+	}
+}
+
 // GetSignatureForActivity prepares a mock HTTP request as if it were going to deliver activity to destination signed for privkey and pubKeyID, signs the request and returns the header values.
 func GetSignatureForActivity(activity pub.Activity, pubKeyID string, privkey *rsa.PrivateKey, destination *url.URL) (signatureHeader string, digestHeader string, dateHeader string) {
 	// convert the activity into json bytes
@@ -41,6 +41,9 @@
 const (
 	applicationJSON         = "application/json"
 	applicationActivityJSON = "application/activity+json"
+	textCSV                 = "text/csv"
+	textHTML                = "text/html; charset=utf-8"
+	textPlain               = "text/plain"
 )
 
 // NewTestTransportController returns a test transport controller with the given http client.
@@ -129,6 +132,10 @@ func NewMockHTTPClient(do func(req *http.Request) (*http.Response, error), relat
 			responseCode, responseBytes, responseContentType, responseContentLength = WebfingerResponse(req)
 		} else if strings.Contains(reqURLString, ".well-known/host-meta") {
 			responseCode, responseBytes, responseContentType, responseContentLength = HostMetaResponse(req)
+		} else if strings.HasPrefix(reqURLString, "https://lists.example.org/") {
+			responseCode, responseBytes, responseContentType, responseContentLength = DomainPermissionSubscriptionResponse(req)
+		} else if strings.HasPrefix(reqURLString, "https://links.example.org/") {
+			responseCode, responseBytes, responseContentType, responseContentLength = LinkPreviewResponse(req)
 		} else if note, ok := mockHTTPClient.TestRemoteStatuses[reqURLString]; ok {
 			// the request is for a note that we have stored
 			noteI, err := streams.Serialize(note)
@@ -297,6 +304,124 @@ func HostMetaResponse(req *http.Request) (responseCode int, responseBytes []byte
 	return
 }
 
+func DomainPermissionSubscriptionResponse(req *http.Request) (responseCode int, responseBytes []byte, responseContentType string, responseContentLength int) {
+	const (
+		csvResp = `#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate
+bumfaces.net,suspend,false,false,big jerks,false
+peepee.poopoo,suspend,false,false,harassment,false
+nothanks.com,suspend,false,false,,false
+silenced.example.org,silence,false,false,just a bit annoying,false`
+
+		jsonResp = `[
+  {
+    "domain": "bumfaces.net",
+    "public_comment": "big jerks"
+  },
+  {
+    "domain": "peepee.poopoo",
+    "public_comment": "harassment"
+  },
+  {
+    "domain": "nothanks.com"
+  }
+]`
+
+		plainResp = `bumfaces.net
+peepee.poopoo
+nothanks.com`
+	)
+
+	switch req.URL.String() {
+	case "https://lists.example.org/baddies.csv":
+		responseBytes = []byte(csvResp)
+		responseContentType = textCSV
+		responseCode = http.StatusOK
+	case "https://lists.example.org/baddies.json":
+		responseBytes = []byte(jsonResp)
+		responseContentType = applicationJSON
+		responseCode = http.StatusOK
+	case "https://lists.example.org/baddies.txt":
+		responseBytes = []byte(plainResp)
+		responseContentType = textPlain
+		responseCode = http.StatusOK
+	default:
+		responseBytes = []byte(`{"error":"not found"}`)
+		responseContentType = applicationJSON
+		responseCode = http.StatusNotFound
+	}
+
+	responseContentLength = len(responseBytes)
+	return
+}
+
+func LinkPreviewResponse(req *http.Request) (responseCode int, responseBytes []byte, responseContentType string, responseContentLength int) {
+	const (
+		articleResp = `<!DOCTYPE html>
+<html lang="en-GB">
+<head>
+  <title>Ignored in favour of og:title</title>
+  <meta property="og:title" content="Is Water Wet?">
+  <meta property="og:description" content="We're not sure. In this article, we ask an expert...">
+  <meta property="og:site_name" content="Links Example">
+  <meta property="og:image" content="https://s3-us-west-2.amazonaws.com/plushcity/media_attachments/files/106/867/380/219/163/828/original/88e8758c5f011439.jpg">
+  <meta name="author" content="Some Writer">
+</head>
+<body><p>Water is wet, probably.</p></body>
+</html>`
+
+		videoResp = `<!DOCTYPE html>
+<html>
+<head>
+  <title>A Video About Water</title>
+  <link rel="alternate" type="application/json+oembed" href="/oembed.json?url=https%3A%2F%2Flinks.example.org%2Fvideo">
+</head>
+<body></body>
+</html>`
+
+		oembedResp = `{
+  "type": "video",
+  "version": "1.0",
+  "title": "Water: The Video",
+  "author_name": "Video Person",
+  "author_url": "https://links.example.org/@videoperson",
+  "provider_name": "Links Example Video",
+  "provider_url": "https://links.example.org/",
+  "width": "640",
+  "height": 360,
+  "html": "<iframe src=\"https://links.example.org/embed/video\" width=\"640\" height=\"360\" onload=\"alert(1)\"></iframe><script>alert(1)</script>"
+}`
+
+		untitledResp = `<!DOCTYPE html>
+<html><head></head><body><p>Nothing to see here.</p></body></html>`
+	)
+
+	switch req.URL.String() {
+	case "https://links.example.org/article":
+		responseBytes = []byte(articleResp)
+		responseContentType = textHTML
+		responseCode = http.StatusOK
+	case "https://links.example.org/video":
+		responseBytes = []byte(videoResp)
+		responseContentType = textHTML
+		responseCode = http.StatusOK
+	case "https://links.example.org/oembed.json?url=https%3A%2F%2Flinks.example.org%2Fvideo":
+		responseBytes = []byte(oembedResp)
+		responseContentType = applicationJSON
+		responseCode = http.StatusOK
+	case "https://links.example.org/untitled":
+		responseBytes = []byte(untitledResp)
+		responseContentType = textHTML
+		responseCode = http.StatusOK
+	default:
+		responseBytes = []byte(`<!DOCTYPE html><html><head><title>Not Found</title></head></html>`)
+		responseContentType = textHTML
+		responseCode = http.StatusNotFound
+	}
+
+	responseContentLength = len(responseBytes)
+	return
+}
+
 func WebfingerResponse(req *http.Request) (responseCode int, responseBytes []byte, responseContentType string, responseContentLength int) {
 	var wfr *apimodel.WellKnownResponse
 
@@ -0,0 +1,98 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import { gtsApi } from "../../gts-api";
+import {
+	Announcement,
+	AnnouncementCreateParams,
+	AnnouncementUpdateParams,
+} from "../../../types/announcement";
+
+const extended = gtsApi.injectEndpoints({
+	endpoints: (build) => ({
+		getAnnouncements: build.query<Announcement[], void>({
+			query: () => ({
+				url: `/api/v1/admin/announcements`
+			}),
+			providesTags: (res) =>
+				res
+					? [
+						...res.map(({ id }) => ({ type: "Announcements" as const, id })),
+						{ type: "Announcements", id: "LIST" },
+					]
+					: [{ type: "Announcements", id: "LIST" }],
+		}),
+
+		postAnnouncement: build.mutation<Announcement, AnnouncementCreateParams>({
+			query: (formData) => ({
+				method: "POST",
+				url: `/api/v1/admin/announcements`,
+				asForm: true,
+				body: formData,
+				discardEmpty: true
+			}),
+			invalidatesTags: [{ type: "Announcements", id: "LIST" }],
+		}),
+
+		updateAnnouncement: build.mutation<Announcement, AnnouncementUpdateParams>({
+			query: ({ id, ...formData }) => ({
+				method: "PATCH",
+				url: `/api/v1/admin/announcements/${id}`,
+				asForm: true,
+				body: formData,
+			}),
+			invalidatesTags: (_res, _error, { id }) => [{ type: "Announcements", id }],
+		}),
+
+		deleteAnnouncement: build.mutation<Announcement, string>({
+			query: (id) => ({
+				method: "DELETE",
+				url: `/api/v1/admin/announcements/${id}`
+			}),
+			invalidatesTags: (_res, _error, id) => [{ type: "Announcements", id }],
+		}),
+	}),
+});
+
+/**
+ * Get admin view of all announcements, including unpublished ones.
+ */
+const useGetAnnouncementsQuery = extended.useGetAnnouncementsQuery;
+
+/**
+ * Create a new announcement.
+ */
+const usePostAnnouncementMutation = extended.usePostAnnouncementMutation;
+
+/**
+ * Update one announcement, eg., to publish or unpublish it.
+ */
+const useUpdateAnnouncementMutation = extended.useUpdateAnnouncementMutation;
+
+/**
+ * Delete one announcement.
+ */
+const useDeleteAnnouncementMutation = extended.useDeleteAnnouncementMutation;
+
+export {
+	useGetAnnouncementsQuery,
+	usePostAnnouncementMutation,
+	useUpdateAnnouncementMutation,
+	useDeleteAnnouncementMutation,
+};
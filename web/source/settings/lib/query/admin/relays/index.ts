@@ -0,0 +1,78 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import { gtsApi } from "../../gts-api";
+import { Relay } from "../../../types/relay";
+
+const extended = gtsApi.injectEndpoints({
+	endpoints: (build) => ({
+		getRelays: build.query<Relay[], void>({
+			query: () => ({
+				url: `/api/v1/admin/relays`
+			}),
+			providesTags: (res) =>
+				res
+					? [
+						...res.map(({ id }) => ({ type: "Relays" as const, id })),
+						{ type: "Relays", id: "LIST" },
+					]
+					: [{ type: "Relays", id: "LIST" }],
+		}),
+
+		postRelay: build.mutation<Relay, { inbox_url: string }>({
+			query: (formData) => ({
+				method: "POST",
+				url: `/api/v1/admin/relays`,
+				asForm: true,
+				body: formData,
+				discardEmpty: true
+			}),
+			invalidatesTags: [{ type: "Relays", id: "LIST" }],
+		}),
+
+		deleteRelay: build.mutation<Relay, string>({
+			query: (id) => ({
+				method: "DELETE",
+				url: `/api/v1/admin/relays/${id}`
+			}),
+			invalidatesTags: (_res, _error, id) => [{ type: "Relays", id }],
+		}),
+	}),
+});
+
+/**
+ * Get admin view of all relays.
+ */
+const useGetRelaysQuery = extended.useGetRelaysQuery;
+
+/**
+ * Add a new relay, and follow it.
+ */
+const usePostRelayMutation = extended.usePostRelayMutation;
+
+/**
+ * Remove one relay, and unfollow it.
+ */
+const useDeleteRelayMutation = extended.useDeleteRelayMutation;
+
+export {
+	useGetRelaysQuery,
+	usePostRelayMutation,
+	useDeleteRelayMutation,
+};
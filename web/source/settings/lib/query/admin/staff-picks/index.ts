@@ -0,0 +1,73 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import { gtsApi } from "../../gts-api";
+import { AdminStaffPick } from "../../../types/suggestion";
+
+const extended = gtsApi.injectEndpoints({
+	endpoints: (build) => ({
+		getStaffPicks: build.query<AdminStaffPick[], void>({
+			query: () => ({
+				url: `/api/v1/admin/staff_picks`
+			}),
+			providesTags: ["StaffPicks"],
+		}),
+
+		createStaffPick: build.mutation<AdminStaffPick, string>({
+			query: (accountID) => ({
+				method: "POST",
+				url: `/api/v1/admin/staff_picks`,
+				asForm: true,
+				body: { account_id: accountID },
+				discardEmpty: true,
+			}),
+			invalidatesTags: ["StaffPicks"],
+		}),
+
+		deleteStaffPick: build.mutation<AdminStaffPick, string>({
+			query: (id) => ({
+				method: "DELETE",
+				url: `/api/v1/admin/staff_picks/${id}`
+			}),
+			invalidatesTags: ["StaffPicks"],
+		}),
+	}),
+});
+
+/**
+ * Get all accounts picked by admins
+ * to be suggested to users.
+ */
+const useGetStaffPicksQuery = extended.useGetStaffPicksQuery;
+
+/**
+ * Pick one account by its ID.
+ */
+const useCreateStaffPickMutation = extended.useCreateStaffPickMutation;
+
+/**
+ * Remove one staff pick by its ID.
+ */
+const useDeleteStaffPickMutation = extended.useDeleteStaffPickMutation;
+
+export {
+	useGetStaffPicksQuery,
+	useCreateStaffPickMutation,
+	useDeleteStaffPickMutation,
+};
@@ -0,0 +1,76 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import { gtsApi } from "../../gts-api";
+import { AdminTrend, TrendType } from "../../../types/trend";
+
+const trendPaths: Record<TrendType, string> = {
+	tag: "tags",
+	status: "statuses",
+	link: "links",
+};
+
+const extended = gtsApi.injectEndpoints({
+	endpoints: (build) => ({
+		getAdminTrends: build.query<AdminTrend[], TrendType>({
+			query: (type) => ({
+				url: `/api/v1/admin/trends/${trendPaths[type]}`
+			}),
+			providesTags: (_res, _error, type) => [{ type: "Trends", id: type }],
+		}),
+
+		approveTrend: build.mutation<AdminTrend, string>({
+			query: (id) => ({
+				method: "POST",
+				url: `/api/v1/admin/trends/reviews/${id}/approve`
+			}),
+			invalidatesTags: (res) => res ? [{ type: "Trends", id: res.type }] : [],
+		}),
+
+		rejectTrend: build.mutation<AdminTrend, string>({
+			query: (id) => ({
+				method: "POST",
+				url: `/api/v1/admin/trends/reviews/${id}/reject`
+			}),
+			invalidatesTags: (res) => res ? [{ type: "Trends", id: res.type }] : [],
+		}),
+	}),
+});
+
+/**
+ * Get admin view of trending items of the given type,
+ * including those awaiting review or rejected.
+ */
+const useGetAdminTrendsQuery = extended.useGetAdminTrendsQuery;
+
+/**
+ * Approve one trending item by its review ID.
+ */
+const useApproveTrendMutation = extended.useApproveTrendMutation;
+
+/**
+ * Reject one trending item by its review ID.
+ */
+const useRejectTrendMutation = extended.useRejectTrendMutation;
+
+export {
+	useGetAdminTrendsQuery,
+	useApproveTrendMutation,
+	useRejectTrendMutation,
+};
@@ -164,11 +164,17 @@ export const gtsApi = createApi({
 		"Emoji",
 		"Report",
 		"Account",
+		"User",
 		"InstanceRules",
 		"HTTPHeaderAllows",
 		"HTTPHeaderBlocks",
+		"Relays",
+		"Announcements",
+		"Trends",
+		"StaffPicks",
 		"DefaultInteractionPolicies",
 		"InteractionRequest",
+		"TokenInfo",
 	],
 	endpoints: (build) => ({
 		instanceV1: build.query<InstanceV1, void>({
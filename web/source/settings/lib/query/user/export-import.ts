@@ -126,6 +126,25 @@ const extended = gtsApi.injectEndpoints({
 			}
 		}),
 
+		exportDomainBlocks: build.mutation<string | null, void>({
+			async queryFn(_arg, _api, _extraOpts, fetchWithBQ) {
+				const csvRes = await fetchWithBQ({
+					url: `/api/v1/exports/domain_blocks.csv`,
+					acceptContentType: "text/csv",
+				});
+				if (csvRes.error) {
+					return { error: csvRes.error as FetchBaseQueryError };
+				}
+
+				if (csvRes.meta?.response?.status !== 200) {
+					return { error: csvRes.data };
+				}
+
+				fileDownload(csvRes.data, "domain_blocks.csv", "text/csv");
+				return { data: null };
+			}
+		}),
+
 		importData: build.mutation({
 			query: (formData) => ({
 				method: "POST",
@@ -145,5 +164,6 @@ export const {
 	useExportListsMutation,
 	useExportBlocksMutation,
 	useExportMutesMutation,
+	useExportDomainBlocksMutation,
 	useImportDataMutation,
 } = extended;
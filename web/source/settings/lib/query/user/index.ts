@@ -24,7 +24,7 @@ import type {
 	UpdateAliasesFormData
 } from "../../types/migration";
 import type { Theme } from "../../types/theme";
-import { User } from "../../types/user";
+import type { TwoFactorRecoveryCodes, TwoFactorSecret, User } from "../../types/user";
 import { DefaultInteractionPolicies, UpdateDefaultInteractionPolicies } from "../../types/interaction";
 
 const extended = gtsApi.injectEndpoints({
@@ -41,7 +41,8 @@ const extended = gtsApi.injectEndpoints({
 		}),
 		
 		user: build.query<User, void>({
-			query: () => ({url: `/api/v1/user`})
+			query: () => ({url: `/api/v1/user`}),
+			providesTags: ["User"]
 		}),
 		
 		passwordChange: build.mutation({
@@ -61,6 +62,32 @@ const extended = gtsApi.injectEndpoints({
 			...replaceCacheOnMutation("user")
 		}),
 		
+		twoFactorSecret: build.mutation<TwoFactorSecret, { password: string }>({
+			query: (data) => ({
+				method: "POST",
+				url: `/api/v1/user/2fa/secret`,
+				body: data
+			})
+		}),
+
+		twoFactorEnable: build.mutation<TwoFactorRecoveryCodes, { password: string, code: string }>({
+			query: (data) => ({
+				method: "POST",
+				url: `/api/v1/user/2fa/enable`,
+				body: data
+			}),
+			invalidatesTags: ["User"]
+		}),
+
+		twoFactorDisable: build.mutation<any, { password: string }>({
+			query: (data) => ({
+				method: "POST",
+				url: `/api/v1/user/2fa/disable`,
+				body: data
+			}),
+			invalidatesTags: ["User"]
+		}),
+		
 		aliasAccount: build.mutation<any, UpdateAliasesFormData>({
 			async queryFn(formData, _api, _extraOpts, fetchWithBQ) {
 				// Pull entries out from the hooked form.
@@ -125,6 +152,9 @@ export const {
 	useUserQuery,
 	usePasswordChangeMutation,
 	useEmailChangeMutation,
+	useTwoFactorSecretMutation,
+	useTwoFactorEnableMutation,
+	useTwoFactorDisableMutation,
 	useAliasAccountMutation,
 	useMoveAccountMutation,
 	useAccountThemesQuery,
@@ -123,4 +123,5 @@ export interface AccountExportStats {
 	lists_count: number;
 	blocks_count: number;
 	mutes_count: number;
+	domain_blocks_count: number;
 }
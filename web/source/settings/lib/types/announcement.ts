@@ -0,0 +1,136 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import { CustomEmoji } from "./custom-emoji";
+
+export interface Announcement {
+	/**
+	 * ID of this announcement.
+	 */
+	id: string;
+
+	/**
+	 * HTML content of the announcement.
+	 */
+	content: string;
+
+	/**
+	 * Original markdown text of the
+	 * announcement. Only shown to admins.
+	 */
+	text?: string;
+
+	/**
+	 * ISO8601 timestamp when the event
+	 * described by the announcement starts.
+	 */
+	starts_at?: string;
+
+	/**
+	 * ISO8601 timestamp when the event
+	 * described by the announcement ends.
+	 */
+	ends_at?: string;
+
+	/**
+	 * Treat starts_at and ends_at as
+	 * dates rather than times.
+	 */
+	all_day: boolean;
+
+	/**
+	 * ISO8601 timestamp when this
+	 * announcement was published.
+	 */
+	published_at?: string;
+
+	/**
+	 * ISO8601 timestamp when this
+	 * announcement was last updated.
+	 */
+	updated_at: string;
+
+	/**
+	 * Whether this announcement
+	 * is shown to users.
+	 */
+	published: boolean;
+
+	/**
+	 * Whether the requester has
+	 * dismissed this announcement.
+	 */
+	read: boolean;
+
+	/**
+	 * Custom emojis used in this announcement.
+	 */
+	emoji: CustomEmoji[];
+
+	/**
+	 * Emoji reactions to this announcement.
+	 */
+	reactions: AnnouncementReaction[];
+}
+
+export interface AnnouncementReaction {
+	/**
+	 * Unicode emoji, or custom emoji shortcode.
+	 */
+	name: string;
+
+	/**
+	 * Number of accounts that
+	 * reacted with this emoji.
+	 */
+	count: number;
+
+	/**
+	 * Whether the requester
+	 * reacted with this emoji.
+	 */
+	me: boolean;
+
+	/**
+	 * Image URL of the custom emoji, if any.
+	 */
+	url?: string;
+
+	/**
+	 * Static image URL of the custom emoji, if any.
+	 */
+	static_url?: string;
+}
+
+export interface AnnouncementCreateParams {
+	text: string;
+	starts_at?: string;
+	ends_at?: string;
+	all_day?: boolean;
+	published?: boolean;
+}
+
+export interface AnnouncementUpdateParams {
+	id: string;
+	text?: string;
+	starts_at?: string;
+	ends_at?: string;
+	all_day?: boolean;
+	published?: boolean;
+}
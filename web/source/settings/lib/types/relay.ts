@@ -0,0 +1,67 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+export interface Relay {
+	/**
+	 * ID of this relay.
+	 */
+	id: string;
+
+	/**
+	 * Inbox URL of the relay.
+	 */
+	inbox_url: string;
+
+	/**
+	 * ActivityPub actor URL of the relay.
+	 * Only set once the relay has accepted
+	 * the instance's follow.
+	 */
+	actor_url?: string;
+
+	/**
+	 * State of the instance's follow
+	 * of the relay.
+	 */
+	state: "pending" | "accepted" | "rejected";
+
+	/**
+	 * Most recent error encountered when
+	 * following or delivering to the relay.
+	 */
+	error?: string;
+
+	/**
+	 * ID of the account that
+	 * added this relay.
+	 */
+	created_by: string;
+
+	/**
+	 * ISO8601 timestamp when
+	 * this relay was added.
+	 */
+	created_at: string;
+
+	/**
+	 * ISO8601 timestamp when
+	 * this relay was last updated.
+	 */
+	updated_at: string;
+}
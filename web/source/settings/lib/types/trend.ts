@@ -0,0 +1,73 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import { Status } from "./status";
+
+export type TrendType = "tag" | "status" | "link";
+
+export interface AdminTrend {
+	/**
+	 * ID of the trend review.
+	 */
+	id: string;
+
+	/**
+	 * Type of the trending item.
+	 */
+	type: TrendType;
+
+	/**
+	 * State of the admin review of this item.
+	 * Only approved items are shown in trends.
+	 */
+	state: "pending" | "approved" | "rejected";
+
+	/**
+	 * Number of distinct accounts
+	 * that used this item recently.
+	 */
+	accounts: number;
+
+	/**
+	 * Number of times this
+	 * item was used recently.
+	 */
+	uses: number;
+
+	/**
+	 * The trending tag, if type is tag.
+	 */
+	tag?: {
+		name: string;
+		url: string;
+	};
+
+	/**
+	 * The trending status, if type is status.
+	 */
+	status?: Status;
+
+	/**
+	 * The trending link, if type is link.
+	 */
+	link?: {
+		url: string;
+		provider_name: string;
+	};
+}
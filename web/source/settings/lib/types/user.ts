@@ -31,4 +31,14 @@ export interface User {
 	disabled: boolean;
 	approved: boolean;
 	reset_password_sent_at?: string;
+	two_factor_enabled_at?: string;
+}
+
+export interface TwoFactorSecret {
+	secret: string;
+	uri: string;
+}
+
+export interface TwoFactorRecoveryCodes {
+	recovery_codes: string[];
 }
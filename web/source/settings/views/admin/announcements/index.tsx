@@ -0,0 +1,202 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React from "react";
+import { NoArg } from "../../../lib/types/query";
+import { PageableList } from "../../../components/pageable-list";
+import { Announcement } from "../../../lib/types/announcement";
+import {
+	useDeleteAnnouncementMutation,
+	useGetAnnouncementsQuery,
+	usePostAnnouncementMutation,
+	useUpdateAnnouncementMutation,
+} from "../../../lib/query/admin/announcements";
+import { useBoolInput, useTextInput } from "../../../lib/form";
+import useFormSubmit from "../../../lib/form/submit";
+import { Checkbox, TextArea, TextInput } from "../../../components/form/inputs";
+import MutationButton from "../../../components/form/mutation-button";
+
+/**
+ * - /settings/admin/announcements
+ */
+export default function Announcements() {
+	const {
+		data: announcements,
+		isLoading,
+		isFetching,
+		isSuccess,
+		isError,
+		error,
+	} = useGetAnnouncementsQuery(NoArg);
+
+	const emptyMessage = (
+		<div className="info">
+			<i className="fa fa-fw fa-info-circle" aria-hidden="true"></i>
+			<b>
+				No announcements have been created yet.
+				You can create one using the form below.
+			</b>
+		</div>
+	);
+
+	return (
+		<div className="admin-announcements">
+			<div className="form-section-docs">
+				<h1>Announcements</h1>
+				<p>
+					On this page, you can view, create, publish, and delete announcements.
+					<br/>
+					Published announcements are shown to all users of your instance in
+					their client, and in a banner at the top of your instance's public
+					web pages, until their end date has passed. Users can dismiss
+					announcements in their client, and react to them with emojis.
+				</p>
+			</div>
+			<PageableList
+				isLoading={isLoading}
+				isFetching={isFetching}
+				isSuccess={isSuccess}
+				isError={isError}
+				error={error}
+				items={announcements}
+				itemToEntry={(announcement: Announcement) => (
+					<AnnouncementEntry key={announcement.id} announcement={announcement} />
+				)}
+				emptyMessage={emptyMessage}
+			/>
+			<AnnouncementCreateForm />
+		</div>
+	);
+}
+
+function AnnouncementEntry({ announcement }: { announcement: Announcement }) {
+	const [ updateTrigger, updateResult ] = useUpdateAnnouncementMutation();
+	const [ deleteTrigger, deleteResult ] = useDeleteAnnouncementMutation();
+
+	const formatDate = (date: string) => announcement.all_day
+		? new Date(date).toLocaleDateString()
+		: new Date(date).toLocaleString();
+
+	return (
+		<dl className="entry">
+			<dt
+				className="announcement-content"
+				dangerouslySetInnerHTML={{ __html: announcement.content }}
+			/>
+			<dd className="announcement-info">
+				<span className={announcement.published ? "published" : "draft"}>
+					{announcement.published ? "Published" : "Draft"}
+				</span>
+				{ announcement.starts_at && <span>Starts: {formatDate(announcement.starts_at)}</span> }
+				{ announcement.ends_at && <span>Ends: {formatDate(announcement.ends_at)}</span> }
+				{ announcement.reactions.length > 0 &&
+					<span className="reactions">
+						{announcement.reactions.map((reaction) => (
+							<span key={reaction.name}>
+								{ reaction.url
+									? <img src={reaction.url} alt={reaction.name} title={reaction.name} className="emoji" />
+									: reaction.name
+								} {reaction.count}
+							</span>
+						))}
+					</span>
+				}
+			</dd>
+			<dd className="announcement-actions">
+				<MutationButton
+					type="button"
+					onClick={() => updateTrigger({ id: announcement.id, published: !announcement.published })}
+					label={announcement.published ? "Unpublish" : "Publish"}
+					result={updateResult}
+					showError={false}
+					disabled={false}
+				/>
+				<MutationButton
+					type="button"
+					onClick={() => deleteTrigger(announcement.id)}
+					label="Delete"
+					result={deleteResult}
+					className="button danger"
+					showError={false}
+					disabled={false}
+				/>
+			</dd>
+		</dl>
+	);
+}
+
+function AnnouncementCreateForm() {
+	const form = {
+		text: useTextInput("text"),
+		starts_at: useTextInput("starts_at"),
+		ends_at: useTextInput("ends_at"),
+		all_day: useBoolInput("all_day", { defaultValue: true }),
+		published: useBoolInput("published"),
+	};
+
+	const [formSubmit, result] = useFormSubmit(
+		form,
+		usePostAnnouncementMutation(),
+		{
+			changedOnly: false,
+			onFinish: ({ _data }) => {
+				form.text.reset();
+				form.starts_at.reset();
+				form.ends_at.reset();
+				form.published.reset();
+			},
+		});
+
+	return (
+		<form onSubmit={formSubmit}>
+			<h2>Create new announcement</h2>
+			<TextArea
+				field={form.text}
+				label="Text (markdown)"
+				placeholder="We'll be down for maintenance this Saturday, sorry for any inconvenience!"
+				rows={5}
+			/>
+			<div className="announcement-dates">
+				<TextInput
+					field={form.starts_at}
+					label="Start date (optional)"
+					type="date"
+				/>
+				<TextInput
+					field={form.ends_at}
+					label="End date (optional), after which the announcement will no longer be shown"
+					type="date"
+				/>
+			</div>
+			<Checkbox
+				field={form.all_day}
+				label="All day event (start and end dates have no time)"
+			/>
+			<Checkbox
+				field={form.published}
+				label="Publish immediately"
+			/>
+			<MutationButton
+				label="Create"
+				result={result}
+				disabled={!form.text.value}
+			/>
+		</form>
+	);
+}
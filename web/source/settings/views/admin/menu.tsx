@@ -41,6 +41,9 @@ import { useHasPermission, useInstanceDebug } from "../../lib/navigation/util";
  * - /settings/admin/http-header-permissions/blocks/:blockId\
  * - /settings/admin/http-header-permissions/allows
  * - /settings/admin/http-header-permissions/allows/:allowId
+ * - /settings/admin/relays
+ * - /settings/admin/announcements
+ * - /settings/admin/trends
  */
 export default function AdminMenu() {	
 	const permissions = ["admin"];
@@ -60,6 +63,21 @@ export default function AdminMenu() {
 			<AdminEmojisMenu />
 			<AdminActionsMenu />
 			<AdminHTTPHeaderPermissionsMenu />
+			<MenuItem
+				name="Relays"
+				itemUrl="relays"
+				icon="fa-retweet"
+			/>
+			<MenuItem
+				name="Announcements"
+				itemUrl="announcements"
+				icon="fa-bullhorn"
+			/>
+			<MenuItem
+				name="Trends"
+				itemUrl="trends"
+				icon="fa-line-chart"
+			/>
 			<AdminDebugMenu />
 		</MenuItem>
 	);
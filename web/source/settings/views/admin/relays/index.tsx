@@ -0,0 +1,140 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React from "react";
+import { NoArg } from "../../../lib/types/query";
+import { PageableList } from "../../../components/pageable-list";
+import { Relay } from "../../../lib/types/relay";
+import { useDeleteRelayMutation, useGetRelaysQuery, usePostRelayMutation } from "../../../lib/query/admin/relays";
+import { useTextInput } from "../../../lib/form";
+import useFormSubmit from "../../../lib/form/submit";
+import { TextInput } from "../../../components/form/inputs";
+import MutationButton from "../../../components/form/mutation-button";
+
+/**
+ * - /settings/admin/relays
+ */
+export default function Relays() {
+	const {
+		data: relays,
+		isLoading,
+		isFetching,
+		isSuccess,
+		isError,
+		error,
+	} = useGetRelaysQuery(NoArg);
+
+	const emptyMessage = (
+		<div className="info">
+			<i className="fa fa-fw fa-info-circle" aria-hidden="true"></i>
+			<b>
+				No relays have been added yet.
+				You can add one using the form below.
+			</b>
+		</div>
+	);
+
+	return (
+		<div className="admin-relays">
+			<div className="form-section-docs">
+				<h1>Relays</h1>
+				<p>
+					On this page, you can view, add, and remove relays.
+					<br/>
+					When you add a relay, your instance will send it a follow request.
+					Once the relay accepts, public posts from your instance will be
+					delivered to the relay, and public posts shared by the relay will
+					appear in your instance's federated timeline.
+				</p>
+			</div>
+			<PageableList
+				isLoading={isLoading}
+				isFetching={isFetching}
+				isSuccess={isSuccess}
+				isError={isError}
+				error={error}
+				items={relays}
+				itemToEntry={(relay: Relay) => <RelayEntry key={relay.id} relay={relay} />}
+				emptyMessage={emptyMessage}
+			/>
+			<RelayCreateForm />
+		</div>
+	);
+}
+
+function RelayEntry({ relay }: { relay: Relay }) {
+	const [ removeTrigger, removeResult ] = useDeleteRelayMutation();
+
+	return (
+		<dl className="entry">
+			<dt className="monospace">{relay.inbox_url}</dt>
+			<dd className={`relay-state ${relay.state}`}>
+				{relay.state}
+				{ relay.error && <div className="error">{relay.error}</div> }
+			</dd>
+			<dd>
+				<MutationButton
+					type="button"
+					onClick={() => removeTrigger(relay.id)}
+					label="Remove"
+					result={removeResult}
+					className="button danger"
+					showError={false}
+					disabled={false}
+				/>
+			</dd>
+		</dl>
+	);
+}
+
+function RelayCreateForm() {
+	const form = {
+		inbox_url: useTextInput("inbox_url"),
+	};
+
+	const [formSubmit, result] = useFormSubmit(
+		form,
+		usePostRelayMutation(),
+		{
+			changedOnly: false,
+			onFinish: ({ _data }) => {
+				form.inbox_url.reset();
+			},
+		});
+
+	return (
+		<form onSubmit={formSubmit}>
+			<h2>Add new relay</h2>
+			<TextInput
+				field={form.inbox_url}
+				label="Relay inbox URL"
+				placeholder="https://relay.example.org/inbox"
+				type="url"
+				autoCapitalize="none"
+				spellCheck="false"
+				{...{className: "monospace"}}
+			/>
+			<MutationButton
+				label="Add"
+				result={result}
+				disabled={!form.inbox_url.value}
+			/>
+		</form>
+	);
+}
@@ -31,6 +31,9 @@ import EmojiDetail from "./emoji/local/detail";
 import RemoteEmoji from "./emoji/remote";
 import HeaderPermsOverview from "./http-header-permissions/overview";
 import HeaderPermDetail from "./http-header-permissions/detail";
+import Relays from "./relays";
+import Announcements from "./announcements";
+import Trends from "./trends";
 import Email from "./actions/email";
 import ApURL from "./debug/apurl";
 import Caches from "./debug/caches";
@@ -55,6 +58,9 @@ import Caches from "./debug/caches";
  * - /settings/admin/http-header-permissions/allows/:allowId
  * - /settings/admin/http-header-permissions/blocks
  * - /settings/admin/http-header-permissions/blocks/:blockId
+ * - /settings/admin/relays
+ * - /settings/admin/announcements
+ * - /settings/admin/trends
  * - /settings/admin/debug
  */
 export default function AdminRouter() {
@@ -69,6 +75,21 @@ export default function AdminRouter() {
 				<AdminEmojisRouter />
 				<AdminActionsRouter />
 				<AdminHTTPHeaderPermissionsRouter />
+				<Route path="/relays">
+					<ErrorBoundary>
+						<Relays />
+					</ErrorBoundary>
+				</Route>
+				<Route path="/announcements">
+					<ErrorBoundary>
+						<Announcements />
+					</ErrorBoundary>
+				</Route>
+				<Route path="/trends">
+					<ErrorBoundary>
+						<Trends />
+					</ErrorBoundary>
+				</Route>
 				<AdminDebugRouter />
 			</Router>
 		</BaseUrlContext.Provider>
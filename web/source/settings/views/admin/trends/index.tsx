@@ -0,0 +1,172 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React from "react";
+import { PageableList } from "../../../components/pageable-list";
+import { AdminTrend, TrendType } from "../../../lib/types/trend";
+import {
+	useApproveTrendMutation,
+	useGetAdminTrendsQuery,
+	useRejectTrendMutation,
+} from "../../../lib/query/admin/trends";
+import MutationButton from "../../../components/form/mutation-button";
+
+/**
+ * - /settings/admin/trends
+ */
+export default function Trends() {
+	return (
+		<div className="admin-trends">
+			<div className="form-section-docs">
+				<h1>Trends</h1>
+				<p>
+					On this page, you can review hashtags, posts, and links that
+					are currently trending on your instance, based on activity from
+					the past week. Trends are recalculated every 15 minutes.
+					<br/>
+					Nothing is shown to users in trends until you've approved it.
+					Rejected items are never shown to users, even if they trend again later.
+				</p>
+				<a
+					href="https://docs.gotosocial.org/en/latest/admin/trends/"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+					Learn more about trends (opens in a new tab)
+				</a>
+			</div>
+			<TrendsSection type="tag" title="Hashtags" />
+			<TrendsSection type="status" title="Posts" />
+			<TrendsSection type="link" title="Links" />
+		</div>
+	);
+}
+
+function TrendsSection({ type, title }: { type: TrendType, title: string }) {
+	const {
+		data: trends,
+		isLoading,
+		isFetching,
+		isSuccess,
+		isError,
+		error,
+	} = useGetAdminTrendsQuery(type);
+
+	const emptyMessage = (
+		<div className="info">
+			<i className="fa fa-fw fa-info-circle" aria-hidden="true"></i>
+			<b>Nothing is trending right now.</b>
+		</div>
+	);
+
+	return (
+		<>
+			<h2>{title}</h2>
+			<PageableList
+				isLoading={isLoading}
+				isFetching={isFetching}
+				isSuccess={isSuccess}
+				isError={isError}
+				error={error}
+				items={trends}
+				itemToEntry={(trend: AdminTrend) => (
+					<TrendEntry key={trend.id} trend={trend} />
+				)}
+				emptyMessage={emptyMessage}
+			/>
+		</>
+	);
+}
+
+function TrendEntry({ trend }: { trend: AdminTrend }) {
+	const [ approveTrigger, approveResult ] = useApproveTrendMutation();
+	const [ rejectTrigger, rejectResult ] = useRejectTrendMutation();
+
+	return (
+		<dl className="entry">
+			<dt className="trend-item">
+				<TrendItem trend={trend} />
+			</dt>
+			<dd className="trend-info">
+				<span className={trend.state}>
+					{trend.state.charAt(0).toUpperCase() + trend.state.slice(1)}
+				</span>
+				<span>{trend.accounts} accounts, {trend.uses} uses</span>
+			</dd>
+			<dd className="trend-actions">
+				{ trend.state !== "approved" &&
+					<MutationButton
+						type="button"
+						onClick={() => approveTrigger(trend.id)}
+						label="Approve"
+						result={approveResult}
+						showError={false}
+						disabled={false}
+					/>
+				}
+				{ trend.state !== "rejected" &&
+					<MutationButton
+						type="button"
+						onClick={() => rejectTrigger(trend.id)}
+						label="Reject"
+						result={rejectResult}
+						className="button danger"
+						showError={false}
+						disabled={false}
+					/>
+				}
+			</dd>
+		</dl>
+	);
+}
+
+function TrendItem({ trend }: { trend: AdminTrend }) {
+	if (trend.tag) {
+		return (
+			<a href={trend.tag.url} target="_blank" rel="noreferrer">
+				#{trend.tag.name}
+			</a>
+		);
+	}
+
+	if (trend.status) {
+		return (
+			<>
+				<a href={trend.status.url} target="_blank" rel="noreferrer">
+					@{trend.status.account.acct}
+				</a>
+				{ trend.status.spoiler_text
+					? <p>{trend.status.spoiler_text}</p>
+					: <div dangerouslySetInnerHTML={{ __html: trend.status.content }} />
+				}
+			</>
+		);
+	}
+
+	if (trend.link) {
+		return (
+			<a href={trend.link.url} target="_blank" rel="noreferrer nofollow">
+				{trend.link.url}
+			</a>
+		);
+	}
+
+	return null;
+}
@@ -20,6 +20,11 @@
 import React from "react";
 
 import { useActionAccountMutation, useHandleSignupMutation } from "../../../../lib/query/admin";
+import {
+	useGetStaffPicksQuery,
+	useCreateStaffPickMutation,
+	useDeleteStaffPickMutation,
+} from "../../../../lib/query/admin/staff-picks";
 import MutationButton from "../../../../components/form/mutation-button";
 import useFormSubmit from "../../../../lib/form/submit";
 import {
@@ -58,10 +63,56 @@ export function AccountActions({ account, backLocation }: AccountActionsProps) {
 		default:
 			// Normal local or remote account, show
 			// full range of moderation options.
-			return <ModerateAccount account={account} />;
+			return (
+				<>
+					<StaffPick account={account} />
+					<ModerateAccount account={account} />
+				</>
+			);
 	}
 }
 
+function StaffPick({ account }: { account: AdminAccount }) {
+	const { data: staffPicks, isLoading } = useGetStaffPicksQuery();
+	const [ createTrigger, createResult ] = useCreateStaffPickMutation();
+	const [ deleteTrigger, deleteResult ] = useDeleteStaffPickMutation();
+
+	if (isLoading) {
+		return null;
+	}
+
+	const staffPick = staffPicks?.find((pick) => pick.account.id === account.id);
+
+	return (
+		<div aria-labelledby="account-staff-pick">
+			<h3 id="account-staff-pick">Staff Pick</h3>
+			<div>
+				Staff picks are suggested to users as accounts worth
+				following, ahead of any other follow suggestions.
+				<br/>
+				Accounts which are not discoverable will not be
+				suggested, even if they are picked.
+			</div>
+			{ staffPick
+				? <MutationButton
+					type="button"
+					onClick={() => deleteTrigger(staffPick.id)}
+					label="Remove staff pick"
+					result={deleteResult}
+					disabled={false}
+				/>
+				: <MutationButton
+					type="button"
+					onClick={() => createTrigger(account.id)}
+					label="Pick account"
+					result={createResult}
+					disabled={false}
+				/>
+			}
+		</div>
+	);
+}
+
 function ModerateAccount({ account }: { account: AdminAccount }) {
 	const form = {
 		id: useValue("id", account.id),
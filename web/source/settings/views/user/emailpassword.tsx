@@ -22,7 +22,14 @@ import { useTextInput } from "../../lib/form";
 import useFormSubmit from "../../lib/form/submit";
 import { TextInput } from "../../components/form/inputs";
 import MutationButton from "../../components/form/mutation-button";
-import { useEmailChangeMutation, usePasswordChangeMutation, useUserQuery } from "../../lib/query/user";
+import {
+	useEmailChangeMutation,
+	usePasswordChangeMutation,
+	useTwoFactorDisableMutation,
+	useTwoFactorEnableMutation,
+	useTwoFactorSecretMutation,
+	useUserQuery,
+} from "../../lib/query/user";
 import Loading from "../../components/loading";
 import { User } from "../../lib/types/user";
 import { useInstanceV1Query } from "../../lib/query/gts-api";
@@ -33,6 +40,7 @@ export default function EmailPassword() {
 			<h1>Email & Password Settings</h1>
 			<EmailChange />
 			<PasswordChange />
+			<TwoFactor />
 		</>
 	);
 }
@@ -261,4 +269,174 @@ function EmailChangeForm({user, oidcEnabled}: { user: User, oidcEnabled?: boolea
 			/>
 		</form>
 	);
-}
\ No newline at end of file
+}
+function TwoFactor() {
+	// Load instance data.
+	const {
+		data: instance,
+		isFetching: isFetchingInstance,
+		isLoading: isLoadingInstance
+	} = useInstanceV1Query();
+	
+	// Load user data.
+	const {
+		data: user,
+		isFetching: isFetchingUser,
+		isLoading: isLoadingUser
+	} = useUserQuery();
+
+	// Keep the enable mutation up here, so that
+	// recovery codes remain visible once the user
+	// has been refetched with 2FA enabled.
+	const enableMutation = useTwoFactorEnableMutation();
+
+	if (
+		(isFetchingInstance || isLoadingInstance) ||
+		(isFetchingUser || isLoadingUser)
+	) {
+		return <Loading />;
+	}
+
+	if (user === undefined) {
+		throw "could not fetch user";
+	}
+
+	if (instance === undefined) {
+		throw "could not fetch instance";
+	}
+
+	const oidcEnabled = instance.configuration.oidc_enabled;
+	const recoveryCodes = enableMutation[1].data?.recovery_codes;
+
+	return (
+		<div className="two-factor">
+			<div className="form-section-docs">
+				<h3>Two-Factor Authentication</h3>
+				{ oidcEnabled && <p>
+					This instance is running with OIDC as its authorization + identity provider.
+					<br/>
+					This means <strong>you cannot set up two-factor authentication using this settings panel</strong>.
+					<br/>
+					To set up two-factor authentication, you should instead contact your OIDC provider.
+				</p> }
+				<a
+					href="https://docs.gotosocial.org/en/latest/user_guide/settings/#two-factor-authentication"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+					Learn more about this (opens in a new tab)
+				</a>
+			</div>
+
+			{ recoveryCodes && <RecoveryCodes codes={recoveryCodes} /> }
+
+			{ !oidcEnabled && (
+				user.two_factor_enabled_at
+					? <TwoFactorDisableForm enabledAt={user.two_factor_enabled_at} />
+					: <TwoFactorEnableForm enableMutation={enableMutation} />
+			)}
+		</div>
+	);
+}
+
+function TwoFactorEnableForm({ enableMutation }: { enableMutation: ReturnType<typeof useTwoFactorEnableMutation> }) {
+	const [ createSecret, secretResult ] = useTwoFactorSecretMutation();
+	const form = {
+		password: useTextInput("password"),
+		code: useTextInput("code"),
+	};
+	const [submitForm, result] = useFormSubmit(form, enableMutation);
+
+	if (!secretResult.data) {
+		return (
+			<>
+				<p>Two-factor authentication is not enabled for your account.</p>
+				<TextInput
+					type="password"
+					name="password"
+					field={form.password}
+					label="Current password"
+					autoComplete="current-password"
+				/>
+				<MutationButton
+					type="button"
+					label="Set up two-factor authentication"
+					result={secretResult}
+					onClick={() => createSecret({ password: form.password.value ?? "" })}
+					disabled={!form.password.value}
+				/>
+			</>
+		);
+	}
+
+	const { secret, uri } = secretResult.data;
+	return (
+		<form onSubmit={submitForm}>
+			<p>
+				Add the following secret to your authenticator app, either by entering
+				it manually, or by opening the <a href={uri}>setup link</a> on the device
+				where your authenticator app is installed.
+			</p>
+			<pre className="two-factor-secret">{secret}</pre>
+			<details>
+				<summary>Show setup link as text</summary>
+				<pre className="two-factor-secret">{uri}</pre>
+			</details>
+			<TextInput
+				name="code"
+				field={form.code}
+				label="Code shown in your authenticator app"
+				autoComplete="one-time-code"
+				inputMode="numeric"
+			/>
+			<MutationButton
+				label="Enable two-factor authentication"
+				result={result}
+				disabled={!form.code.value}
+			/>
+		</form>
+	);
+}
+
+function TwoFactorDisableForm({ enabledAt }: { enabledAt: string }) {
+	const form = { password: useTextInput("password") };
+	const [submitForm, result] = useFormSubmit(form, useTwoFactorDisableMutation());
+
+	return (
+		<form onSubmit={submitForm}>
+			<p>
+				Two-factor authentication has been enabled for your
+				account since {new Date(enabledAt).toLocaleString()}.
+			</p>
+			<TextInput
+				type="password"
+				name="password"
+				field={form.password}
+				label="Current password"
+				autoComplete="current-password"
+			/>
+			<MutationButton
+				label="Disable two-factor authentication"
+				result={result}
+				disabled={!form.password.value}
+			/>
+		</form>
+	);
+}
+
+function RecoveryCodes({ codes }: { codes: string[] }) {
+	return (
+		<div className="info">
+			<i className="fa fa-fw fa-info-circle" aria-hidden="true"></i>
+			<b>
+				Two-factor authentication is now enabled. Please store the following
+				recovery codes somewhere safe; each can be used once to sign in if you
+				lose access to your authenticator app. They will not be shown again.
+				<ul className="two-factor-recovery-codes">
+					{ codes.map(code => <li key={code}><code>{code}</code></li>) }
+				</ul>
+			</b>
+		</div>
+	);
+}
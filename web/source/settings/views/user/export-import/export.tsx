@@ -24,6 +24,7 @@ import {
 	useExportListsMutation,
 	useExportBlocksMutation,
 	useExportMutesMutation,
+	useExportDomainBlocksMutation,
 } from "../../../lib/query/user/export-import";
 import MutationButton from "../../../components/form/mutation-button";
 import useFormSubmit from "../../../lib/form/submit";
@@ -81,6 +82,16 @@ export default function Export({ exportStats }: { exportStats: AccountExportStat
 		// we want to always trigger.
 		{ changedOnly: false },
 	);
+
+	const [exportDomainBlocks, exportDomainBlocksResult] = useFormSubmit(
+		// Use a dummy value.
+		{ type: useValue("exportDomainBlocks", "exportDomainBlocks") },
+		// Mutation we're wrapping.
+		useExportDomainBlocksMutation(),
+		// Form never changes but
+		// we want to always trigger.
+		{ changedOnly: false },
+	);
 	
 	return (
 		<form className="export-data">
@@ -167,6 +178,20 @@ export default function Export({ exportStats }: { exportStats: AccountExportStat
 						disabled={exportStats.mutes_count === 0}
 					/>
 				</div>
+				<div className="stats-and-button">
+					<span className="text-cutoff">
+						Blocking {exportStats.domain_blocks_count} domain{ exportStats.domain_blocks_count !== 1 && "s" }
+					</span>
+					<MutationButton
+						className="text-cutoff"
+						label="Download domain_blocks.csv"
+						type="button"
+						onClick={() => exportDomainBlocks()}
+						result={exportDomainBlocksResult}
+						showError={true}
+						disabled={exportStats.domain_blocks_count === 0}
+					/>
+				</div>
 			</div>
 		</form>
 	);
@@ -55,9 +55,9 @@ export default function Import() {
 			</div>
 			
 			<FileInput
-				label="CSV data file"
+				label="CSV data file (or archive zip for statuses)"
 				field={form.data}
-				accept="text/csv"
+				accept="text/csv,application/zip"
 			/>
 
 			<Select
@@ -68,6 +68,11 @@ export default function Import() {
 						<option value="">- Select import type -</option>
 						<option value="following">Following list</option>
 						<option value="blocks">Blocked accounts list</option>
+						<option value="domain_blocks">Blocked domains list</option>
+						<option value="mutes">Muted accounts list</option>
+						<option value="lists">Lists</option>
+						<option value="bookmarks">Bookmarks</option>
+						<option value="statuses">Statuses from archive</option>
 					</>
 				}>
 			</Select>
@@ -57,6 +57,9 @@ export default function InteractionRequestsSearchForm() {
 		boosts: useBoolInput("reblogs", {
 			defaultValue: defaultTrue(urlQueryParams.get("reblogs"))
 		}),
+		quotes: useBoolInput("quotes", {
+			defaultValue: defaultTrue(urlQueryParams.get("quotes"))
+		}),
 	};
 
 	// On mount, trigger search.
@@ -126,6 +129,10 @@ export default function InteractionRequestsSearchForm() {
 					label="Include boosts"
 					field={form.boosts}
 				/>
+				<Checkbox
+					label="Include quotes"
+					field={form.quotes}
+				/>
 				<MutationButton
 					disabled={false}
 					label={"Search"}
@@ -25,6 +25,7 @@ import React from "react";
  * - /settings/user/posts
  * - /settings/user/emailpassword
  * - /settings/user/migration
+ * - /settings/user/tokens
  */
 export default function UserMenu() {	
 	return (
@@ -53,6 +54,11 @@ export default function UserMenu() {
 				itemUrl="emailpassword"
 				icon="fa-user-secret"
 			/>
+			<MenuItem
+				name="Access Tokens"
+				itemUrl="tokens"
+				icon="fa-key"
+			/>
 			<MenuItem
 				name="Migration"
 				itemUrl="migration"
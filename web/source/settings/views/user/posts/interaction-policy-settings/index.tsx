@@ -82,6 +82,7 @@ function InteractionPoliciesForm({ defaultPolicies }: InteractionPoliciesFormPro
 			can_favourite: assemblePolicyEntry("public", "favourite", formPublic),
 			can_reply: assemblePolicyEntry("public", "reply", formPublic),
 			can_reblog: assemblePolicyEntry("public", "reblog", formPublic),
+			can_quote: assemblePolicyEntry("public", "quote", formPublic),
 		};
 	}, [formPublic]);
 	
@@ -92,6 +93,7 @@ function InteractionPoliciesForm({ defaultPolicies }: InteractionPoliciesFormPro
 			can_favourite: assemblePolicyEntry("unlisted", "favourite", formUnlisted),
 			can_reply: assemblePolicyEntry("unlisted", "reply", formUnlisted),
 			can_reblog: assemblePolicyEntry("unlisted", "reblog", formUnlisted),
+			can_quote: assemblePolicyEntry("unlisted", "quote", formUnlisted),
 		};
 	}, [formUnlisted]);
 	
@@ -102,6 +104,7 @@ function InteractionPoliciesForm({ defaultPolicies }: InteractionPoliciesFormPro
 			can_favourite: assemblePolicyEntry("private", "favourite", formPrivate),
 			can_reply: assemblePolicyEntry("private", "reply", formPrivate),
 			can_reblog: assemblePolicyEntry("private", "reblog", formPrivate),
+			can_quote: assemblePolicyEntry("private", "quote", formPrivate),
 		};
 	}, [formPrivate]);
 
@@ -137,7 +140,7 @@ function InteractionPoliciesForm({ defaultPolicies }: InteractionPoliciesFormPro
 					permission to see the post</em>, taking account of blocks.
 					<br/>
 					Bear in mind that no matter what you set below, you will always
-					be able to like, reply-to, and boost your own posts.
+					be able to like, reply-to, boost, and quote your own posts.
 				</p>
 				<a
 					href="https://docs.gotosocial.org/en/latest/user_guide/settings#default-interaction-policies"
@@ -271,6 +274,12 @@ function PolicyPanel({ policyForm, forVis, isActive }: PolicyPanelProps) {
 					forAction="reblog"
 				/>
 			}
+			{ forVis !== "private" &&
+				<PolicyComponent
+					form={policyForm.quote}
+					forAction="quote"
+				/>
+			}
 		</div>
 	);
 }
@@ -369,6 +378,13 @@ function useLegend(action: Action) {
 						<span>Boost</span>
 					</>
 				);
+			case "quote":
+				return (
+					<>
+						<i className="fa fa-fw fa-quote-right" aria-hidden="true"></i>
+						<span>Quote</span>
+					</>
+				);
 		}
 	}, [action]);
 }
@@ -388,6 +404,10 @@ interface PolicyForm {
 		basic: PolicyFormSub,
 		somethingElse: PolicyFormSomethingElse,
 	}
+	quote: {
+		basic: PolicyFormSub,
+		somethingElse: PolicyFormSomethingElse,
+	}
 }
 
 // Return a PolicyForm for the given visibility,
@@ -439,6 +459,20 @@ function useFormForVis(
 				currentPolicy.can_reblog.with_approval,
 			),
 		},
+		quote: {
+			basic: useBasicFor(
+				forVis,
+				"quote",
+				currentPolicy.can_quote.always,
+				currentPolicy.can_quote.with_approval,
+			),
+			somethingElse: useSomethingElseFor(
+				forVis,
+				"quote",
+				currentPolicy.can_quote.always,
+				currentPolicy.can_quote.with_approval,
+			),
+		},
 	};
 }
 
@@ -0,0 +1,153 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React, { ReactNode, useEffect, useMemo } from "react";
+import { useSearch } from "wouter";
+import { PageableList } from "../../../components/pageable-list";
+import MutationButton from "../../../components/form/mutation-button";
+import {
+	useInvalidateAllTokensMutation,
+	useInvalidateTokenMutation,
+	useLazySearchTokenInfoQuery,
+} from "../../../lib/query/user/tokens";
+import { TokenInfo } from "../../../lib/types/tokeninfo";
+
+/**
+ * - /settings/user/tokens
+ */
+export default function Tokens() {
+	const search = useSearch();
+	const urlQueryParams = useMemo(() => new URLSearchParams(search), [search]);
+	const [ searchTokens, searchRes ] = useLazySearchTokenInfoQuery();
+	const [ invalidateAll, invalidateAllResult ] = useInvalidateAllTokensMutation();
+
+	// On mount, or when paging, trigger search.
+	useEffect(() => {
+		searchTokens(Object.fromEntries(urlQueryParams), true);
+	}, [urlQueryParams, searchTokens]);
+
+	// Function to map an item to a list entry.
+	function itemToEntry(token: TokenInfo): ReactNode {
+		return <TokenListEntry key={token.id} token={token} />;
+	}
+
+	return (
+		<div className="tokens-view">
+			<div className="form-section-docs">
+				<h1>Access Tokens</h1>
+				<p>
+					On this page you can see the access tokens that applications
+					have been granted to act on behalf of your account, including
+					this settings panel. If you don't recognize a token, or you've
+					lost a device that was signed in to your account, you can
+					invalidate the token so that it can no longer be used.
+				</p>
+				<a
+					href="https://docs.gotosocial.org/en/latest/user_guide/settings/#access-tokens"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+					Learn more about this (opens in a new tab)
+				</a>
+			</div>
+			<MutationButton
+				type="button"
+				label="Invalidate all tokens except this one"
+				result={invalidateAllResult}
+				onClick={() => invalidateAll()}
+				className="button danger"
+				disabled={false}
+			/>
+			<PageableList
+				isLoading={searchRes.isLoading}
+				isFetching={searchRes.isFetching}
+				isSuccess={searchRes.isSuccess}
+				items={searchRes.data?.tokens}
+				itemToEntry={itemToEntry}
+				isError={searchRes.isError}
+				error={searchRes.error}
+				emptyMessage={<b>No tokens found.</b>}
+				prevNextLinks={searchRes.data?.links}
+			/>
+		</div>
+	);
+}
+
+function TokenListEntry({ token }: { token: TokenInfo }) {
+	const [ invalidate, invalidateResult ] = useInvalidateTokenMutation();
+
+	const created = useMemo(() => {
+		return new Date(token.created_at).toLocaleString();
+	}, [token.created_at]);
+
+	const lastUsed = useMemo(() => {
+		if (!token.last_used) {
+			return "never";
+		}
+		return new Date(token.last_used).toLocaleString();
+	}, [token.last_used]);
+
+	return (
+		<span className="token-info entry">
+			<dl className="info-list">
+				<div className="info-list-entry">
+					<dt>App:</dt>
+					<dd className="text-cutoff">
+						{ token.application.website
+							? <a
+								href={token.application.website}
+								target="_blank"
+								rel="nofollow noreferrer noopener"
+							>{token.application.name}</a>
+							: token.application.name
+						}
+					</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Scope:</dt>
+					<dd className="text-cutoff monospace">{token.scope}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Created:</dt>
+					<dd><time dateTime={token.created_at}>{created}</time></dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Last used:</dt>
+					<dd>
+						{ token.last_used
+							? <time dateTime={token.last_used}>{lastUsed}</time>
+							: lastUsed
+						}
+					</dd>
+				</div>
+			</dl>
+			<div className="action-buttons">
+				<MutationButton
+					type="button"
+					label="Invalidate"
+					result={invalidateResult}
+					onClick={() => invalidate(token.id)}
+					className="button danger"
+					disabled={false}
+				/>
+			</div>
+		</span>
+	);
+}